@@ -1,8 +1,13 @@
 package api
 
 import (
+	"net/http"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // The canonical errors from the EigenDA gRPC API endpoints.
@@ -14,6 +19,9 @@ import (
 //   in user-facing errors defined here. Consider map and convert system-internal errors
 //   before return to users from APIs.
 
+// ErrorDomain is the domain of the ErrorInfo details attached to the errors
+const ErrorDomain = "eigenda"
+
 func NewGRPCError(code codes.Code, msg string) error {
 	return status.Errorf(code, msg)
 }
@@ -23,6 +31,13 @@ func NewInvalidArgError(msg string) error {
 	return NewGRPCError(codes.InvalidArgument, msg)
 }
 
+// HTTP Mapping: 400 Bad Request
+//
+// The reason is attached as an ErrorInfo detail, telling the client why the argument is invalid.
+func NewInvalidArgErrorWithReason(msg string, reason string) error {
+	return withReason(status.New(codes.InvalidArgument, msg), reason)
+}
+
 // HTTP Mapping: 404 Not Found
 func NewNotFoundError(msg string) error {
 	return NewGRPCError(codes.NotFound, msg)
@@ -33,7 +48,89 @@ func NewResourceExhaustedError(msg string) error {
 	return NewGRPCError(codes.ResourceExhausted, msg)
 }
 
+// HTTP Mapping: 429 Too Many Requests
+//
+// The retryAfter delay is attached as a RetryInfo detail, telling the client when to retry.
+func NewResourceExhaustedErrorWithRetry(msg string, retryAfter time.Duration) error {
+	return withRetryInfo(status.New(codes.ResourceExhausted, msg), retryAfter)
+}
+
+// HTTP Mapping: 400 Bad Request
+//
+// The reason is attached as an ErrorInfo detail, telling the client which precondition failed.
+func NewFailedPreconditionError(msg string, reason string) error {
+	return withReason(status.New(codes.FailedPrecondition, msg), reason)
+}
+
+func withReason(st *status.Status, reason string) error {
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// ErrorReason returns the reason of the ErrorInfo detail of the gRPC error, or an empty string if it has none.
+func ErrorReason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
 // HTTP Mapping: 500 Internal Server Error
 func NewInternalError(msg string) error {
 	return NewGRPCError(codes.Internal, msg)
 }
+
+// HTTP Mapping: 503 Service Unavailable
+//
+// The retryAfter delay is attached as a RetryInfo detail, telling the client when to retry.
+func NewUnavailableError(msg string, retryAfter time.Duration) error {
+	return withRetryInfo(status.New(codes.Unavailable, msg), retryAfter)
+}
+
+func withRetryInfo(st *status.Status, retryAfter time.Duration) error {
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// HTTPStatusFromCode returns the HTTP status matching the gRPC status code, following the HTTP mappings of the
+// canonical errors above.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 // Client Closed Request
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
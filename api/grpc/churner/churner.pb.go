@@ -376,14 +376,18 @@ var file_churner_churner_proto_rawDesc = []byte{
 	0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
 	0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62,
 	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x32, 0x40, 0x0a, 0x07, 0x43, 0x68, 0x75, 0x72, 0x6e, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x05,
+	0x79, 0x32, 0x7e, 0x0a, 0x07, 0x43, 0x68, 0x75, 0x72, 0x6e, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x05,
 	0x43, 0x68, 0x75, 0x72, 0x6e, 0x12, 0x15, 0x2e, 0x63, 0x68, 0x75, 0x72, 0x6e, 0x65, 0x72, 0x2e,
 	0x43, 0x68, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x63,
 	0x68, 0x75, 0x72, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65,
-	0x6e, 0x64, 0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x68, 0x75,
-	0x72, 0x6e, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0c, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x43, 0x68,
+	0x75, 0x72, 0x6e, 0x12, 0x15, 0x2e, 0x63, 0x68, 0x75, 0x72, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x68,
+	0x75, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x63, 0x68, 0x75,
+	0x72, 0x6e, 0x65, 0x72, 0x2e, 0x43, 0x68, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64,
+	0x61, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x68, 0x75, 0x72, 0x6e,
+	0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -409,9 +413,11 @@ var file_churner_churner_proto_depIdxs = []int32{
 	2, // 0: churner.ChurnReply.signature_with_salt_and_expiry:type_name -> churner.SignatureWithSaltAndExpiry
 	3, // 1: churner.ChurnReply.operators_to_churn:type_name -> churner.OperatorToChurn
 	0, // 2: churner.Churner.Churn:input_type -> churner.ChurnRequest
-	1, // 3: churner.Churner.Churn:output_type -> churner.ChurnReply
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
+	0, // 3: churner.Churner.RefreshChurn:input_type -> churner.ChurnRequest
+	1, // 4: churner.Churner.Churn:output_type -> churner.ChurnReply
+	1, // 5: churner.Churner.RefreshChurn:output_type -> churner.ChurnReply
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
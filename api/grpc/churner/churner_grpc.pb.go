@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Churner_Churn_FullMethodName = "/churner.Churner/Churn"
+	Churner_Churn_FullMethodName        = "/churner.Churner/Churn"
+	Churner_RefreshChurn_FullMethodName = "/churner.Churner/RefreshChurn"
 )
 
 // ChurnerClient is the client API for Churner service.
@@ -27,6 +28,14 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ChurnerClient interface {
 	Churn(ctx context.Context, in *ChurnRequest, opts ...grpc.CallOption) (*ChurnReply, error)
+	// RefreshChurn re-validates the unexpired churn approval previously given to the
+	// operator against the stakes at the current block, and if it still holds, returns
+	// it signed again with a new salt and expiry.
+	// If the stakes changed since the approval such that the registration would revert,
+	// the approval is revoked and a FAILED_PRECONDITION error is returned, with an
+	// ErrorInfo detail whose reason is STAKE_CHANGED. The operator may then make a new
+	// Churn request.
+	RefreshChurn(ctx context.Context, in *ChurnRequest, opts ...grpc.CallOption) (*ChurnReply, error)
 }
 
 type churnerClient struct {
@@ -46,11 +55,28 @@ func (c *churnerClient) Churn(ctx context.Context, in *ChurnRequest, opts ...grp
 	return out, nil
 }
 
+func (c *churnerClient) RefreshChurn(ctx context.Context, in *ChurnRequest, opts ...grpc.CallOption) (*ChurnReply, error) {
+	out := new(ChurnReply)
+	err := c.cc.Invoke(ctx, Churner_RefreshChurn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChurnerServer is the server API for Churner service.
 // All implementations must embed UnimplementedChurnerServer
 // for forward compatibility
 type ChurnerServer interface {
 	Churn(context.Context, *ChurnRequest) (*ChurnReply, error)
+	// RefreshChurn re-validates the unexpired churn approval previously given to the
+	// operator against the stakes at the current block, and if it still holds, returns
+	// it signed again with a new salt and expiry.
+	// If the stakes changed since the approval such that the registration would revert,
+	// the approval is revoked and a FAILED_PRECONDITION error is returned, with an
+	// ErrorInfo detail whose reason is STAKE_CHANGED. The operator may then make a new
+	// Churn request.
+	RefreshChurn(context.Context, *ChurnRequest) (*ChurnReply, error)
 	mustEmbedUnimplementedChurnerServer()
 }
 
@@ -61,6 +87,9 @@ type UnimplementedChurnerServer struct {
 func (UnimplementedChurnerServer) Churn(context.Context, *ChurnRequest) (*ChurnReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Churn not implemented")
 }
+func (UnimplementedChurnerServer) RefreshChurn(context.Context, *ChurnRequest) (*ChurnReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshChurn not implemented")
+}
 func (UnimplementedChurnerServer) mustEmbedUnimplementedChurnerServer() {}
 
 // UnsafeChurnerServer may be embedded to opt out of forward compatibility for this service.
@@ -92,6 +121,24 @@ func _Churner_Churn_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Churner_RefreshChurn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChurnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChurnerServer).RefreshChurn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Churner_RefreshChurn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChurnerServer).RefreshChurn(ctx, req.(*ChurnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Churner_ServiceDesc is the grpc.ServiceDesc for Churner service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -103,6 +150,10 @@ var Churner_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Churn",
 			Handler:    _Churner_Churn_Handler,
 		},
+		{
+			MethodName: "RefreshChurn",
+			Handler:    _Churner_RefreshChurn_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "churner/churner.proto",
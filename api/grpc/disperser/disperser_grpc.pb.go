@@ -21,8 +21,13 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	Disperser_DisperseBlob_FullMethodName              = "/disperser.Disperser/DisperseBlob"
 	Disperser_DisperseBlobAuthenticated_FullMethodName = "/disperser.Disperser/DisperseBlobAuthenticated"
+	Disperser_DisperseBlobStream_FullMethodName        = "/disperser.Disperser/DisperseBlobStream"
+	Disperser_DisperseBlobs_FullMethodName             = "/disperser.Disperser/DisperseBlobs"
 	Disperser_GetBlobStatus_FullMethodName             = "/disperser.Disperser/GetBlobStatus"
+	Disperser_GetBlobStatuses_FullMethodName           = "/disperser.Disperser/GetBlobStatuses"
+	Disperser_SubscribeBlobStatus_FullMethodName       = "/disperser.Disperser/SubscribeBlobStatus"
 	Disperser_RetrieveBlob_FullMethodName              = "/disperser.Disperser/RetrieveBlob"
+	Disperser_RetrieveBlobWithProof_FullMethodName     = "/disperser.Disperser/RetrieveBlobWithProof"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -43,8 +48,27 @@ type DisperserClient interface {
 	//     AuthenticationData message.
 	//  4. The Disperser verifies the signature and returns a DisperseBlobReply message.
 	DisperseBlobAuthenticated(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobAuthenticatedClient, error)
+	// DisperseBlobStream is similar to DisperseBlob, except that the data of the blob is streamed in chunks, so that
+	// large blobs don't have to fit in a single message. The protocol is as follows:
+	//  1. The client sends the data of the blob in order, in DisperseBlobStreamRequest messages with data_chunk set.
+	//  2. The client sends a last DisperseBlobStreamRequest message with the header set to the DisperseBlobRequest
+	//     of the blob, whose data is left empty, and closes the stream.
+	//  3. The Disperser assembles the blob and returns a DisperseBlobReply message as DisperseBlob would.
+	DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error)
+	// DisperseBlobs disperses several blobs in a single call. Each blob is validated as by DisperseBlob, and either all
+	// the blobs are accepted or none of them is. The replies carry the request IDs of the blobs in the order of the
+	// blobs of the request. The Disperser puts the blobs in the same batch when they fit in a single batch.
+	DisperseBlobs(ctx context.Context, in *DisperseBlobsRequest, opts ...grpc.CallOption) (*DisperseBlobsReply, error)
 	// This API is meant to be polled for the blob status.
 	GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error)
+	// GetBlobStatuses returns the statuses of several blobs in a single call, either the blobs with the given request
+	// IDs or the blobs dispersed by an account in a time range, a page at a time. The statuses are those GetBlobStatus
+	// returns, with the BlobInfo of the confirmed blobs.
+	GetBlobStatuses(ctx context.Context, in *BlobStatusesRequest, opts ...grpc.CallOption) (*BlobStatusesReply, error)
+	// SubscribeBlobStatus is an alternative to polling GetBlobStatus. The Disperser streams the status of the blob
+	// each time it changes, and a keepalive message while it doesn't, until the blob reaches a terminal status.
+	// A client whose stream is interrupted resumes it by subscribing again with the last status it received.
+	SubscribeBlobStatus(ctx context.Context, in *SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error)
 	// This retrieves the requested blob from the Disperser's backend.
 	// This is a more efficient way to retrieve blobs than directly retrieving
 	// from the DA Nodes (see detail about this approach in
@@ -52,6 +76,11 @@ type DisperserClient interface {
 	// The blob should have been initially dispersed via this Disperser service
 	// for this API to work.
 	RetrieveBlob(ctx context.Context, in *RetrieveBlobRequest, opts ...grpc.CallOption) (*RetrieveBlobReply, error)
+	// RetrieveBlobWithProof retrieves the requested blob from the Disperser's backend as RetrieveBlob does, along with
+	// the BlobInfo of the blob and a KZG proof that the data is the blob committed to by the commitment of its
+	// BlobHeader. The Disperser checks the data against the commitment before returning it. This lets light clients
+	// fetch a blob from the Disperser while it still holds it, and verify it without talking to the DA Nodes.
+	RetrieveBlobWithProof(ctx context.Context, in *RetrieveBlobRequest, opts ...grpc.CallOption) (*RetrieveBlobWithProofReply, error)
 }
 
 type disperserClient struct {
@@ -102,6 +131,49 @@ func (x *disperserDisperseBlobAuthenticatedClient) Recv() (*AuthenticatedReply,
 	return m, nil
 }
 
+func (c *disperserClient) DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[1], Disperser_DisperseBlobStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserDisperseBlobStreamClient{stream}
+	return x, nil
+}
+
+type Disperser_DisperseBlobStreamClient interface {
+	Send(*DisperseBlobStreamRequest) error
+	CloseAndRecv() (*DisperseBlobReply, error)
+	grpc.ClientStream
+}
+
+type disperserDisperseBlobStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserDisperseBlobStreamClient) Send(m *DisperseBlobStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamClient) CloseAndRecv() (*DisperseBlobReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(DisperseBlobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disperserClient) DisperseBlobs(ctx context.Context, in *DisperseBlobsRequest, opts ...grpc.CallOption) (*DisperseBlobsReply, error) {
+	out := new(DisperseBlobsReply)
+	err := c.cc.Invoke(ctx, Disperser_DisperseBlobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *disperserClient) GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error) {
 	out := new(BlobStatusReply)
 	err := c.cc.Invoke(ctx, Disperser_GetBlobStatus_FullMethodName, in, out, opts...)
@@ -111,6 +183,47 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, in *BlobStatusReque
 	return out, nil
 }
 
+func (c *disperserClient) GetBlobStatuses(ctx context.Context, in *BlobStatusesRequest, opts ...grpc.CallOption) (*BlobStatusesReply, error) {
+	out := new(BlobStatusesReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobStatuses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) SubscribeBlobStatus(ctx context.Context, in *SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[2], Disperser_SubscribeBlobStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserSubscribeBlobStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Disperser_SubscribeBlobStatusClient interface {
+	Recv() (*BlobStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type disperserSubscribeBlobStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserSubscribeBlobStatusClient) Recv() (*BlobStatusUpdate, error) {
+	m := new(BlobStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *disperserClient) RetrieveBlob(ctx context.Context, in *RetrieveBlobRequest, opts ...grpc.CallOption) (*RetrieveBlobReply, error) {
 	out := new(RetrieveBlobReply)
 	err := c.cc.Invoke(ctx, Disperser_RetrieveBlob_FullMethodName, in, out, opts...)
@@ -120,6 +233,15 @@ func (c *disperserClient) RetrieveBlob(ctx context.Context, in *RetrieveBlobRequ
 	return out, nil
 }
 
+func (c *disperserClient) RetrieveBlobWithProof(ctx context.Context, in *RetrieveBlobRequest, opts ...grpc.CallOption) (*RetrieveBlobWithProofReply, error) {
+	out := new(RetrieveBlobWithProofReply)
+	err := c.cc.Invoke(ctx, Disperser_RetrieveBlobWithProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisperserServer is the server API for Disperser service.
 // All implementations must embed UnimplementedDisperserServer
 // for forward compatibility
@@ -138,8 +260,27 @@ type DisperserServer interface {
 	//     AuthenticationData message.
 	//  4. The Disperser verifies the signature and returns a DisperseBlobReply message.
 	DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error
+	// DisperseBlobStream is similar to DisperseBlob, except that the data of the blob is streamed in chunks, so that
+	// large blobs don't have to fit in a single message. The protocol is as follows:
+	//  1. The client sends the data of the blob in order, in DisperseBlobStreamRequest messages with data_chunk set.
+	//  2. The client sends a last DisperseBlobStreamRequest message with the header set to the DisperseBlobRequest
+	//     of the blob, whose data is left empty, and closes the stream.
+	//  3. The Disperser assembles the blob and returns a DisperseBlobReply message as DisperseBlob would.
+	DisperseBlobStream(Disperser_DisperseBlobStreamServer) error
+	// DisperseBlobs disperses several blobs in a single call. Each blob is validated as by DisperseBlob, and either all
+	// the blobs are accepted or none of them is. The replies carry the request IDs of the blobs in the order of the
+	// blobs of the request. The Disperser puts the blobs in the same batch when they fit in a single batch.
+	DisperseBlobs(context.Context, *DisperseBlobsRequest) (*DisperseBlobsReply, error)
 	// This API is meant to be polled for the blob status.
 	GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error)
+	// GetBlobStatuses returns the statuses of several blobs in a single call, either the blobs with the given request
+	// IDs or the blobs dispersed by an account in a time range, a page at a time. The statuses are those GetBlobStatus
+	// returns, with the BlobInfo of the confirmed blobs.
+	GetBlobStatuses(context.Context, *BlobStatusesRequest) (*BlobStatusesReply, error)
+	// SubscribeBlobStatus is an alternative to polling GetBlobStatus. The Disperser streams the status of the blob
+	// each time it changes, and a keepalive message while it doesn't, until the blob reaches a terminal status.
+	// A client whose stream is interrupted resumes it by subscribing again with the last status it received.
+	SubscribeBlobStatus(*SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error
 	// This retrieves the requested blob from the Disperser's backend.
 	// This is a more efficient way to retrieve blobs than directly retrieving
 	// from the DA Nodes (see detail about this approach in
@@ -147,6 +288,11 @@ type DisperserServer interface {
 	// The blob should have been initially dispersed via this Disperser service
 	// for this API to work.
 	RetrieveBlob(context.Context, *RetrieveBlobRequest) (*RetrieveBlobReply, error)
+	// RetrieveBlobWithProof retrieves the requested blob from the Disperser's backend as RetrieveBlob does, along with
+	// the BlobInfo of the blob and a KZG proof that the data is the blob committed to by the commitment of its
+	// BlobHeader. The Disperser checks the data against the commitment before returning it. This lets light clients
+	// fetch a blob from the Disperser while it still holds it, and verify it without talking to the DA Nodes.
+	RetrieveBlobWithProof(context.Context, *RetrieveBlobRequest) (*RetrieveBlobWithProofReply, error)
 	mustEmbedUnimplementedDisperserServer()
 }
 
@@ -160,12 +306,27 @@ func (UnimplementedDisperserServer) DisperseBlob(context.Context, *DisperseBlobR
 func (UnimplementedDisperserServer) DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error {
 	return status.Errorf(codes.Unimplemented, "method DisperseBlobAuthenticated not implemented")
 }
+func (UnimplementedDisperserServer) DisperseBlobStream(Disperser_DisperseBlobStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DisperseBlobStream not implemented")
+}
+func (UnimplementedDisperserServer) DisperseBlobs(context.Context, *DisperseBlobsRequest) (*DisperseBlobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisperseBlobs not implemented")
+}
 func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatus not implemented")
 }
+func (UnimplementedDisperserServer) GetBlobStatuses(context.Context, *BlobStatusesRequest) (*BlobStatusesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatuses not implemented")
+}
+func (UnimplementedDisperserServer) SubscribeBlobStatus(*SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlobStatus not implemented")
+}
 func (UnimplementedDisperserServer) RetrieveBlob(context.Context, *RetrieveBlobRequest) (*RetrieveBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlob not implemented")
 }
+func (UnimplementedDisperserServer) RetrieveBlobWithProof(context.Context, *RetrieveBlobRequest) (*RetrieveBlobWithProofReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlobWithProof not implemented")
+}
 func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
 
 // UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
@@ -223,6 +384,50 @@ func (x *disperserDisperseBlobAuthenticatedServer) Recv() (*AuthenticatedRequest
 	return m, nil
 }
 
+func _Disperser_DisperseBlobStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisperserServer).DisperseBlobStream(&disperserDisperseBlobStreamServer{stream})
+}
+
+type Disperser_DisperseBlobStreamServer interface {
+	SendAndClose(*DisperseBlobReply) error
+	Recv() (*DisperseBlobStreamRequest, error)
+	grpc.ServerStream
+}
+
+type disperserDisperseBlobStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserDisperseBlobStreamServer) SendAndClose(m *DisperseBlobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamServer) Recv() (*DisperseBlobStreamRequest, error) {
+	m := new(DisperseBlobStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Disperser_DisperseBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisperseBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).DisperseBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_DisperseBlobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).DisperseBlobs(ctx, req.(*DisperseBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlobStatusRequest)
 	if err := dec(in); err != nil {
@@ -241,6 +446,45 @@ func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_GetBlobStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, req.(*BlobStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_SubscribeBlobStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlobStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisperserServer).SubscribeBlobStatus(m, &disperserSubscribeBlobStatusServer{stream})
+}
+
+type Disperser_SubscribeBlobStatusServer interface {
+	Send(*BlobStatusUpdate) error
+	grpc.ServerStream
+}
+
+type disperserSubscribeBlobStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserSubscribeBlobStatusServer) Send(m *BlobStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Disperser_RetrieveBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RetrieveBlobRequest)
 	if err := dec(in); err != nil {
@@ -259,6 +503,24 @@ func _Disperser_RetrieveBlob_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_RetrieveBlobWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetrieveBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).RetrieveBlobWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_RetrieveBlobWithProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).RetrieveBlobWithProof(ctx, req.(*RetrieveBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -270,14 +532,26 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DisperseBlob",
 			Handler:    _Disperser_DisperseBlob_Handler,
 		},
+		{
+			MethodName: "DisperseBlobs",
+			Handler:    _Disperser_DisperseBlobs_Handler,
+		},
 		{
 			MethodName: "GetBlobStatus",
 			Handler:    _Disperser_GetBlobStatus_Handler,
 		},
+		{
+			MethodName: "GetBlobStatuses",
+			Handler:    _Disperser_GetBlobStatuses_Handler,
+		},
 		{
 			MethodName: "RetrieveBlob",
 			Handler:    _Disperser_RetrieveBlob_Handler,
 		},
+		{
+			MethodName: "RetrieveBlobWithProof",
+			Handler:    _Disperser_RetrieveBlobWithProof_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -286,6 +560,16 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DisperseBlobStream",
+			Handler:       _Disperser_DisperseBlobStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeBlobStatus",
+			Handler:       _Disperser_SubscribeBlobStatus_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "disperser/disperser.proto",
 }
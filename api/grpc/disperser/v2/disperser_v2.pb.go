@@ -0,0 +1,472 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.23.4
+// source: disperser/v2/disperser_v2.proto
+
+package v2
+
+import (
+	common "github.com/Layr-Labs/eigenda/api/grpc/common"
+	disperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuthMode identifies the ways the disperser authenticates the accounts of the requests.
+type AuthMode int32
+
+const (
+	// The requests are not authenticated, and attributed to the IP address of the client.
+	AuthMode_AUTH_MODE_NONE AuthMode = 0
+	// The account signs a challenge of the disperser, see Disperser.DisperseBlobAuthenticated().
+	AuthMode_AUTH_MODE_CHALLENGE AuthMode = 1
+	// The account signs the payload hash of the request, see the signature of DisperseBlobRequest.
+	AuthMode_AUTH_MODE_SIGNED_REQUEST AuthMode = 2
+)
+
+// Enum value maps for AuthMode.
+var (
+	AuthMode_name = map[int32]string{
+		0: "AUTH_MODE_NONE",
+		1: "AUTH_MODE_CHALLENGE",
+		2: "AUTH_MODE_SIGNED_REQUEST",
+	}
+	AuthMode_value = map[string]int32{
+		"AUTH_MODE_NONE":           0,
+		"AUTH_MODE_CHALLENGE":      1,
+		"AUTH_MODE_SIGNED_REQUEST": 2,
+	}
+)
+
+func (x AuthMode) Enum() *AuthMode {
+	p := new(AuthMode)
+	*p = x
+	return p
+}
+
+func (x AuthMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_disperser_v2_disperser_v2_proto_enumTypes[0].Descriptor()
+}
+
+func (AuthMode) Type() protoreflect.EnumType {
+	return &file_disperser_v2_disperser_v2_proto_enumTypes[0]
+}
+
+func (x AuthMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthMode.Descriptor instead.
+func (AuthMode) EnumDescriptor() ([]byte, []int) {
+	return file_disperser_v2_disperser_v2_proto_rawDescGZIP(), []int{0}
+}
+
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The highest protocol version supported by the client.
+	ProtocolVersion uint32 `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+}
+
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_v2_disperser_v2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_v2_disperser_v2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_disperser_v2_disperser_v2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetCapabilitiesRequest) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+type GetCapabilitiesReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The negotiated protocol version: the highest version supported by both the client and the disperser.
+	ProtocolVersion uint32 `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// The protocol versions supported by the disperser.
+	SupportedProtocolVersions []uint32 `protobuf:"varint,2,rep,packed,name=supported_protocol_versions,json=supportedProtocolVersions,proto3" json:"supported_protocol_versions,omitempty"`
+	// The size in bytes of the largest blob the disperser accepts from the client, given the limits of the required
+	// quorums and of the tenant of the API key of the request. The custom quorums may have lower limits.
+	MaxBlobSize uint32 `protobuf:"varint,3,opt,name=max_blob_size,json=maxBlobSize,proto3" json:"max_blob_size,omitempty"`
+	// The number of blobs a DisperseBlobs request can carry at most.
+	MaxBlobsPerRequest uint32 `protobuf:"varint,4,opt,name=max_blobs_per_request,json=maxBlobsPerRequest,proto3" json:"max_blobs_per_request,omitempty"`
+	// The quorums the blobs can be dispersed to.
+	QuorumIds []uint32 `protobuf:"varint,5,rep,packed,name=quorum_ids,json=quorumIds,proto3" json:"quorum_ids,omitempty"`
+	// The quorums every blob is dispersed to, in addition to its custom quorums.
+	RequiredQuorumIds []uint32 `protobuf:"varint,6,rep,packed,name=required_quorum_ids,json=requiredQuorumIds,proto3" json:"required_quorum_ids,omitempty"`
+	// The ways the disperser authenticates the accounts of the requests.
+	AuthModes []AuthMode `protobuf:"varint,7,rep,packed,name=auth_modes,json=authModes,proto3,enum=disperser.v2.AuthMode" json:"auth_modes,omitempty"`
+	// Whether the blobs can be streamed to the disperser with DisperseBlobStream, and their status streamed back
+	// with SubscribeBlobStatus.
+	Streaming bool `protobuf:"varint,8,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	// The build of the disperser and the capabilities it's deployed with.
+	BuildInfo *common.BuildInfo `protobuf:"bytes,9,opt,name=build_info,json=buildInfo,proto3" json:"build_info,omitempty"`
+}
+
+func (x *GetCapabilitiesReply) Reset() {
+	*x = GetCapabilitiesReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_disperser_v2_disperser_v2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCapabilitiesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCapabilitiesReply) ProtoMessage() {}
+
+func (x *GetCapabilitiesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_disperser_v2_disperser_v2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCapabilitiesReply.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesReply) Descriptor() ([]byte, []int) {
+	return file_disperser_v2_disperser_v2_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetCapabilitiesReply) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *GetCapabilitiesReply) GetSupportedProtocolVersions() []uint32 {
+	if x != nil {
+		return x.SupportedProtocolVersions
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesReply) GetMaxBlobSize() uint32 {
+	if x != nil {
+		return x.MaxBlobSize
+	}
+	return 0
+}
+
+func (x *GetCapabilitiesReply) GetMaxBlobsPerRequest() uint32 {
+	if x != nil {
+		return x.MaxBlobsPerRequest
+	}
+	return 0
+}
+
+func (x *GetCapabilitiesReply) GetQuorumIds() []uint32 {
+	if x != nil {
+		return x.QuorumIds
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesReply) GetRequiredQuorumIds() []uint32 {
+	if x != nil {
+		return x.RequiredQuorumIds
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesReply) GetAuthModes() []AuthMode {
+	if x != nil {
+		return x.AuthModes
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesReply) GetStreaming() bool {
+	if x != nil {
+		return x.Streaming
+	}
+	return false
+}
+
+func (x *GetCapabilitiesReply) GetBuildInfo() *common.BuildInfo {
+	if x != nil {
+		return x.BuildInfo
+	}
+	return nil
+}
+
+var File_disperser_v2_disperser_v2_proto protoreflect.FileDescriptor
+
+var file_disperser_v2_disperser_v2_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2f, 0x76, 0x32, 0x2f, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x5f, 0x76, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x1a,
+	0x13, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2f,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x43, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0xae, 0x03, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a,
+	0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x1b, 0x73, 0x75, 0x70, 0x70,
+	0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x19, 0x73,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f,
+	0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x31, 0x0a, 0x15,
+	0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78,
+	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x50, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x73, 0x12, 0x2e,
+	0x0a, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75,
+	0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x11, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64, 0x73, 0x12, 0x35,
+	0x0a, 0x0a, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76,
+	0x32, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68,
+	0x4d, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69,
+	0x6e, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x69, 0x6e, 0x67, 0x12, 0x30, 0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x69, 0x6e, 0x66,
+	0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x09, 0x62, 0x75, 0x69, 0x6c,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x2a, 0x55, 0x0a, 0x08, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4e,
+	0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x10, 0x01, 0x12, 0x1c,
+	0x0a, 0x18, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x49, 0x47, 0x4e,
+	0x45, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x02, 0x32, 0xff, 0x06, 0x0a,
+	0x09, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x12, 0x5d, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x24, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e,
+	0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x44, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c,
+	0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x19, 0x44, 0x69, 0x73,
+	0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1f, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x12,
+	0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x24, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65,
+	0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f,
+	0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x51, 0x0a, 0x0d, 0x44, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x1f, 0x2e, 0x64, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73,
+	0x65, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a,
+	0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c,
+	0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x1e, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5d, 0x0a,
+	0x13, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x64, 0x69,
+	0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4e, 0x0a, 0x0c,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x12, 0x1e, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76,
+	0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64,
+	0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76,
+	0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x15,
+	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x57, 0x69, 0x74, 0x68,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1e, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x57, 0x69,
+	0x74, 0x68, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x42, 0x34,
+	0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4c, 0x61, 0x79,
+	0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x65, 0x69, 0x67, 0x65, 0x6e, 0x64, 0x61, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x64, 0x69, 0x73, 0x70, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_disperser_v2_disperser_v2_proto_rawDescOnce sync.Once
+	file_disperser_v2_disperser_v2_proto_rawDescData = file_disperser_v2_disperser_v2_proto_rawDesc
+)
+
+func file_disperser_v2_disperser_v2_proto_rawDescGZIP() []byte {
+	file_disperser_v2_disperser_v2_proto_rawDescOnce.Do(func() {
+		file_disperser_v2_disperser_v2_proto_rawDescData = protoimpl.X.CompressGZIP(file_disperser_v2_disperser_v2_proto_rawDescData)
+	})
+	return file_disperser_v2_disperser_v2_proto_rawDescData
+}
+
+var file_disperser_v2_disperser_v2_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_disperser_v2_disperser_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_disperser_v2_disperser_v2_proto_goTypes = []interface{}{
+	(AuthMode)(0),                                // 0: disperser.v2.AuthMode
+	(*GetCapabilitiesRequest)(nil),               // 1: disperser.v2.GetCapabilitiesRequest
+	(*GetCapabilitiesReply)(nil),                 // 2: disperser.v2.GetCapabilitiesReply
+	(*common.BuildInfo)(nil),                     // 3: common.BuildInfo
+	(*disperser.DisperseBlobRequest)(nil),        // 4: disperser.DisperseBlobRequest
+	(*disperser.AuthenticatedRequest)(nil),       // 5: disperser.AuthenticatedRequest
+	(*disperser.DisperseBlobStreamRequest)(nil),  // 6: disperser.DisperseBlobStreamRequest
+	(*disperser.DisperseBlobsRequest)(nil),       // 7: disperser.DisperseBlobsRequest
+	(*disperser.BlobStatusRequest)(nil),          // 8: disperser.BlobStatusRequest
+	(*disperser.BlobStatusesRequest)(nil),        // 9: disperser.BlobStatusesRequest
+	(*disperser.SubscribeBlobStatusRequest)(nil), // 10: disperser.SubscribeBlobStatusRequest
+	(*disperser.RetrieveBlobRequest)(nil),        // 11: disperser.RetrieveBlobRequest
+	(*disperser.DisperseBlobReply)(nil),          // 12: disperser.DisperseBlobReply
+	(*disperser.AuthenticatedReply)(nil),         // 13: disperser.AuthenticatedReply
+	(*disperser.DisperseBlobsReply)(nil),         // 14: disperser.DisperseBlobsReply
+	(*disperser.BlobStatusReply)(nil),            // 15: disperser.BlobStatusReply
+	(*disperser.BlobStatusesReply)(nil),          // 16: disperser.BlobStatusesReply
+	(*disperser.BlobStatusUpdate)(nil),           // 17: disperser.BlobStatusUpdate
+	(*disperser.RetrieveBlobReply)(nil),          // 18: disperser.RetrieveBlobReply
+	(*disperser.RetrieveBlobWithProofReply)(nil), // 19: disperser.RetrieveBlobWithProofReply
+}
+var file_disperser_v2_disperser_v2_proto_depIdxs = []int32{
+	0,  // 0: disperser.v2.GetCapabilitiesReply.auth_modes:type_name -> disperser.v2.AuthMode
+	3,  // 1: disperser.v2.GetCapabilitiesReply.build_info:type_name -> common.BuildInfo
+	1,  // 2: disperser.v2.Disperser.GetCapabilities:input_type -> disperser.v2.GetCapabilitiesRequest
+	4,  // 3: disperser.v2.Disperser.DisperseBlob:input_type -> disperser.DisperseBlobRequest
+	5,  // 4: disperser.v2.Disperser.DisperseBlobAuthenticated:input_type -> disperser.AuthenticatedRequest
+	6,  // 5: disperser.v2.Disperser.DisperseBlobStream:input_type -> disperser.DisperseBlobStreamRequest
+	7,  // 6: disperser.v2.Disperser.DisperseBlobs:input_type -> disperser.DisperseBlobsRequest
+	8,  // 7: disperser.v2.Disperser.GetBlobStatus:input_type -> disperser.BlobStatusRequest
+	9,  // 8: disperser.v2.Disperser.GetBlobStatuses:input_type -> disperser.BlobStatusesRequest
+	10, // 9: disperser.v2.Disperser.SubscribeBlobStatus:input_type -> disperser.SubscribeBlobStatusRequest
+	11, // 10: disperser.v2.Disperser.RetrieveBlob:input_type -> disperser.RetrieveBlobRequest
+	11, // 11: disperser.v2.Disperser.RetrieveBlobWithProof:input_type -> disperser.RetrieveBlobRequest
+	2,  // 12: disperser.v2.Disperser.GetCapabilities:output_type -> disperser.v2.GetCapabilitiesReply
+	12, // 13: disperser.v2.Disperser.DisperseBlob:output_type -> disperser.DisperseBlobReply
+	13, // 14: disperser.v2.Disperser.DisperseBlobAuthenticated:output_type -> disperser.AuthenticatedReply
+	12, // 15: disperser.v2.Disperser.DisperseBlobStream:output_type -> disperser.DisperseBlobReply
+	14, // 16: disperser.v2.Disperser.DisperseBlobs:output_type -> disperser.DisperseBlobsReply
+	15, // 17: disperser.v2.Disperser.GetBlobStatus:output_type -> disperser.BlobStatusReply
+	16, // 18: disperser.v2.Disperser.GetBlobStatuses:output_type -> disperser.BlobStatusesReply
+	17, // 19: disperser.v2.Disperser.SubscribeBlobStatus:output_type -> disperser.BlobStatusUpdate
+	18, // 20: disperser.v2.Disperser.RetrieveBlob:output_type -> disperser.RetrieveBlobReply
+	19, // 21: disperser.v2.Disperser.RetrieveBlobWithProof:output_type -> disperser.RetrieveBlobWithProofReply
+	12, // [12:22] is the sub-list for method output_type
+	2,  // [2:12] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_disperser_v2_disperser_v2_proto_init() }
+func file_disperser_v2_disperser_v2_proto_init() {
+	if File_disperser_v2_disperser_v2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_disperser_v2_disperser_v2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCapabilitiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_disperser_v2_disperser_v2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCapabilitiesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_disperser_v2_disperser_v2_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_disperser_v2_disperser_v2_proto_goTypes,
+		DependencyIndexes: file_disperser_v2_disperser_v2_proto_depIdxs,
+		EnumInfos:         file_disperser_v2_disperser_v2_proto_enumTypes,
+		MessageInfos:      file_disperser_v2_disperser_v2_proto_msgTypes,
+	}.Build()
+	File_disperser_v2_disperser_v2_proto = out.File
+	file_disperser_v2_disperser_v2_proto_rawDesc = nil
+	file_disperser_v2_disperser_v2_proto_goTypes = nil
+	file_disperser_v2_disperser_v2_proto_depIdxs = nil
+}
@@ -0,0 +1,539 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.4
+// source: disperser/v2/disperser_v2.proto
+
+package v2
+
+import (
+	context "context"
+	disperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Disperser_GetCapabilities_FullMethodName           = "/disperser.v2.Disperser/GetCapabilities"
+	Disperser_DisperseBlob_FullMethodName              = "/disperser.v2.Disperser/DisperseBlob"
+	Disperser_DisperseBlobAuthenticated_FullMethodName = "/disperser.v2.Disperser/DisperseBlobAuthenticated"
+	Disperser_DisperseBlobStream_FullMethodName        = "/disperser.v2.Disperser/DisperseBlobStream"
+	Disperser_DisperseBlobs_FullMethodName             = "/disperser.v2.Disperser/DisperseBlobs"
+	Disperser_GetBlobStatus_FullMethodName             = "/disperser.v2.Disperser/GetBlobStatus"
+	Disperser_GetBlobStatuses_FullMethodName           = "/disperser.v2.Disperser/GetBlobStatuses"
+	Disperser_SubscribeBlobStatus_FullMethodName       = "/disperser.v2.Disperser/SubscribeBlobStatus"
+	Disperser_RetrieveBlob_FullMethodName              = "/disperser.v2.Disperser/RetrieveBlob"
+	Disperser_RetrieveBlobWithProof_FullMethodName     = "/disperser.v2.Disperser/RetrieveBlobWithProof"
+)
+
+// DisperserClient is the client API for Disperser service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DisperserClient interface {
+	// GetCapabilities negotiates the protocol version with the client and returns the capabilities of the disperser.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesReply, error)
+	DisperseBlob(ctx context.Context, in *disperser.DisperseBlobRequest, opts ...grpc.CallOption) (*disperser.DisperseBlobReply, error)
+	DisperseBlobAuthenticated(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobAuthenticatedClient, error)
+	DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error)
+	DisperseBlobs(ctx context.Context, in *disperser.DisperseBlobsRequest, opts ...grpc.CallOption) (*disperser.DisperseBlobsReply, error)
+	GetBlobStatus(ctx context.Context, in *disperser.BlobStatusRequest, opts ...grpc.CallOption) (*disperser.BlobStatusReply, error)
+	GetBlobStatuses(ctx context.Context, in *disperser.BlobStatusesRequest, opts ...grpc.CallOption) (*disperser.BlobStatusesReply, error)
+	SubscribeBlobStatus(ctx context.Context, in *disperser.SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error)
+	RetrieveBlob(ctx context.Context, in *disperser.RetrieveBlobRequest, opts ...grpc.CallOption) (*disperser.RetrieveBlobReply, error)
+	RetrieveBlobWithProof(ctx context.Context, in *disperser.RetrieveBlobRequest, opts ...grpc.CallOption) (*disperser.RetrieveBlobWithProofReply, error)
+}
+
+type disperserClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDisperserClient(cc grpc.ClientConnInterface) DisperserClient {
+	return &disperserClient{cc}
+}
+
+func (c *disperserClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesReply, error) {
+	out := new(GetCapabilitiesReply)
+	err := c.cc.Invoke(ctx, Disperser_GetCapabilities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) DisperseBlob(ctx context.Context, in *disperser.DisperseBlobRequest, opts ...grpc.CallOption) (*disperser.DisperseBlobReply, error) {
+	out := new(disperser.DisperseBlobReply)
+	err := c.cc.Invoke(ctx, Disperser_DisperseBlob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobAuthenticatedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[0], Disperser_DisperseBlobAuthenticated_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserDisperseBlobAuthenticatedClient{stream}
+	return x, nil
+}
+
+type Disperser_DisperseBlobAuthenticatedClient interface {
+	Send(*disperser.AuthenticatedRequest) error
+	Recv() (*disperser.AuthenticatedReply, error)
+	grpc.ClientStream
+}
+
+type disperserDisperseBlobAuthenticatedClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserDisperseBlobAuthenticatedClient) Send(m *disperser.AuthenticatedRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobAuthenticatedClient) Recv() (*disperser.AuthenticatedReply, error) {
+	m := new(disperser.AuthenticatedReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disperserClient) DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[1], Disperser_DisperseBlobStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserDisperseBlobStreamClient{stream}
+	return x, nil
+}
+
+type Disperser_DisperseBlobStreamClient interface {
+	Send(*disperser.DisperseBlobStreamRequest) error
+	CloseAndRecv() (*disperser.DisperseBlobReply, error)
+	grpc.ClientStream
+}
+
+type disperserDisperseBlobStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserDisperseBlobStreamClient) Send(m *disperser.DisperseBlobStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamClient) CloseAndRecv() (*disperser.DisperseBlobReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(disperser.DisperseBlobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disperserClient) DisperseBlobs(ctx context.Context, in *disperser.DisperseBlobsRequest, opts ...grpc.CallOption) (*disperser.DisperseBlobsReply, error) {
+	out := new(disperser.DisperseBlobsReply)
+	err := c.cc.Invoke(ctx, Disperser_DisperseBlobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetBlobStatus(ctx context.Context, in *disperser.BlobStatusRequest, opts ...grpc.CallOption) (*disperser.BlobStatusReply, error) {
+	out := new(disperser.BlobStatusReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) GetBlobStatuses(ctx context.Context, in *disperser.BlobStatusesRequest, opts ...grpc.CallOption) (*disperser.BlobStatusesReply, error) {
+	out := new(disperser.BlobStatusesReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobStatuses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) SubscribeBlobStatus(ctx context.Context, in *disperser.SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[2], Disperser_SubscribeBlobStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserSubscribeBlobStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Disperser_SubscribeBlobStatusClient interface {
+	Recv() (*disperser.BlobStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type disperserSubscribeBlobStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserSubscribeBlobStatusClient) Recv() (*disperser.BlobStatusUpdate, error) {
+	m := new(disperser.BlobStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disperserClient) RetrieveBlob(ctx context.Context, in *disperser.RetrieveBlobRequest, opts ...grpc.CallOption) (*disperser.RetrieveBlobReply, error) {
+	out := new(disperser.RetrieveBlobReply)
+	err := c.cc.Invoke(ctx, Disperser_RetrieveBlob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) RetrieveBlobWithProof(ctx context.Context, in *disperser.RetrieveBlobRequest, opts ...grpc.CallOption) (*disperser.RetrieveBlobWithProofReply, error) {
+	out := new(disperser.RetrieveBlobWithProofReply)
+	err := c.cc.Invoke(ctx, Disperser_RetrieveBlobWithProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DisperserServer is the server API for Disperser service.
+// All implementations must embed UnimplementedDisperserServer
+// for forward compatibility
+type DisperserServer interface {
+	// GetCapabilities negotiates the protocol version with the client and returns the capabilities of the disperser.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesReply, error)
+	DisperseBlob(context.Context, *disperser.DisperseBlobRequest) (*disperser.DisperseBlobReply, error)
+	DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error
+	DisperseBlobStream(Disperser_DisperseBlobStreamServer) error
+	DisperseBlobs(context.Context, *disperser.DisperseBlobsRequest) (*disperser.DisperseBlobsReply, error)
+	GetBlobStatus(context.Context, *disperser.BlobStatusRequest) (*disperser.BlobStatusReply, error)
+	GetBlobStatuses(context.Context, *disperser.BlobStatusesRequest) (*disperser.BlobStatusesReply, error)
+	SubscribeBlobStatus(*disperser.SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error
+	RetrieveBlob(context.Context, *disperser.RetrieveBlobRequest) (*disperser.RetrieveBlobReply, error)
+	RetrieveBlobWithProof(context.Context, *disperser.RetrieveBlobRequest) (*disperser.RetrieveBlobWithProofReply, error)
+	mustEmbedUnimplementedDisperserServer()
+}
+
+// UnimplementedDisperserServer must be embedded to have forward compatible implementations.
+type UnimplementedDisperserServer struct {
+}
+
+func (UnimplementedDisperserServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedDisperserServer) DisperseBlob(context.Context, *disperser.DisperseBlobRequest) (*disperser.DisperseBlobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisperseBlob not implemented")
+}
+func (UnimplementedDisperserServer) DisperseBlobAuthenticated(Disperser_DisperseBlobAuthenticatedServer) error {
+	return status.Errorf(codes.Unimplemented, "method DisperseBlobAuthenticated not implemented")
+}
+func (UnimplementedDisperserServer) DisperseBlobStream(Disperser_DisperseBlobStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DisperseBlobStream not implemented")
+}
+func (UnimplementedDisperserServer) DisperseBlobs(context.Context, *disperser.DisperseBlobsRequest) (*disperser.DisperseBlobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisperseBlobs not implemented")
+}
+func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *disperser.BlobStatusRequest) (*disperser.BlobStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatus not implemented")
+}
+func (UnimplementedDisperserServer) GetBlobStatuses(context.Context, *disperser.BlobStatusesRequest) (*disperser.BlobStatusesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatuses not implemented")
+}
+func (UnimplementedDisperserServer) SubscribeBlobStatus(*disperser.SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlobStatus not implemented")
+}
+func (UnimplementedDisperserServer) RetrieveBlob(context.Context, *disperser.RetrieveBlobRequest) (*disperser.RetrieveBlobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlob not implemented")
+}
+func (UnimplementedDisperserServer) RetrieveBlobWithProof(context.Context, *disperser.RetrieveBlobRequest) (*disperser.RetrieveBlobWithProofReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlobWithProof not implemented")
+}
+func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
+
+// UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DisperserServer will
+// result in compilation errors.
+type UnsafeDisperserServer interface {
+	mustEmbedUnimplementedDisperserServer()
+}
+
+func RegisterDisperserServer(s grpc.ServiceRegistrar, srv DisperserServer) {
+	s.RegisterService(&Disperser_ServiceDesc, srv)
+}
+
+func _Disperser_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_DisperseBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(disperser.DisperseBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).DisperseBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_DisperseBlob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).DisperseBlob(ctx, req.(*disperser.DisperseBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_DisperseBlobAuthenticated_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisperserServer).DisperseBlobAuthenticated(&disperserDisperseBlobAuthenticatedServer{stream})
+}
+
+type Disperser_DisperseBlobAuthenticatedServer interface {
+	Send(*disperser.AuthenticatedReply) error
+	Recv() (*disperser.AuthenticatedRequest, error)
+	grpc.ServerStream
+}
+
+type disperserDisperseBlobAuthenticatedServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserDisperseBlobAuthenticatedServer) Send(m *disperser.AuthenticatedReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobAuthenticatedServer) Recv() (*disperser.AuthenticatedRequest, error) {
+	m := new(disperser.AuthenticatedRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Disperser_DisperseBlobStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisperserServer).DisperseBlobStream(&disperserDisperseBlobStreamServer{stream})
+}
+
+type Disperser_DisperseBlobStreamServer interface {
+	SendAndClose(*disperser.DisperseBlobReply) error
+	Recv() (*disperser.DisperseBlobStreamRequest, error)
+	grpc.ServerStream
+}
+
+type disperserDisperseBlobStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserDisperseBlobStreamServer) SendAndClose(m *disperser.DisperseBlobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamServer) Recv() (*disperser.DisperseBlobStreamRequest, error) {
+	m := new(disperser.DisperseBlobStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Disperser_DisperseBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(disperser.DisperseBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).DisperseBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_DisperseBlobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).DisperseBlobs(ctx, req.(*disperser.DisperseBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(disperser.BlobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobStatus(ctx, req.(*disperser.BlobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_GetBlobStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(disperser.BlobStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, req.(*disperser.BlobStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_SubscribeBlobStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(disperser.SubscribeBlobStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisperserServer).SubscribeBlobStatus(m, &disperserSubscribeBlobStatusServer{stream})
+}
+
+type Disperser_SubscribeBlobStatusServer interface {
+	Send(*disperser.BlobStatusUpdate) error
+	grpc.ServerStream
+}
+
+type disperserSubscribeBlobStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserSubscribeBlobStatusServer) Send(m *disperser.BlobStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Disperser_RetrieveBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(disperser.RetrieveBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).RetrieveBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_RetrieveBlob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).RetrieveBlob(ctx, req.(*disperser.RetrieveBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_RetrieveBlobWithProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(disperser.RetrieveBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).RetrieveBlobWithProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_RetrieveBlobWithProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).RetrieveBlobWithProof(ctx, req.(*disperser.RetrieveBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Disperser_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "disperser.v2.Disperser",
+	HandlerType: (*DisperserServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _Disperser_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "DisperseBlob",
+			Handler:    _Disperser_DisperseBlob_Handler,
+		},
+		{
+			MethodName: "DisperseBlobs",
+			Handler:    _Disperser_DisperseBlobs_Handler,
+		},
+		{
+			MethodName: "GetBlobStatus",
+			Handler:    _Disperser_GetBlobStatus_Handler,
+		},
+		{
+			MethodName: "GetBlobStatuses",
+			Handler:    _Disperser_GetBlobStatuses_Handler,
+		},
+		{
+			MethodName: "RetrieveBlob",
+			Handler:    _Disperser_RetrieveBlob_Handler,
+		},
+		{
+			MethodName: "RetrieveBlobWithProof",
+			Handler:    _Disperser_RetrieveBlobWithProof_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DisperseBlobAuthenticated",
+			Handler:       _Disperser_DisperseBlobAuthenticated_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DisperseBlobStream",
+			Handler:       _Disperser_DisperseBlobStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeBlobStatus",
+			Handler:       _Disperser_SubscribeBlobStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "disperser/v2/disperser_v2.proto",
+}
@@ -19,7 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Dispersal_StoreChunks_FullMethodName = "/node.Dispersal/StoreChunks"
+	Dispersal_StoreChunks_FullMethodName       = "/node.Dispersal/StoreChunks"
+	Dispersal_StoreChunksStream_FullMethodName = "/node.Dispersal/StoreChunksStream"
+	Dispersal_NodeInfo_FullMethodName          = "/node.Dispersal/NodeInfo"
 )
 
 // DispersalClient is the client API for Dispersal service.
@@ -33,6 +35,13 @@ type DispersalClient interface {
 	// for the protocol-defined length of custody. It will return a signature at the
 	// end to attest to the data in this request it has processed.
 	StoreChunks(ctx context.Context, in *StoreChunksRequest, opts ...grpc.CallOption) (*StoreChunksReply, error)
+	// StoreChunksStream is StoreChunks with the blobs of the batch streamed in minibatches,
+	// which the Node validates as they arrive. The first request carries the batch header,
+	// and each request carries the next blobs of the batch in order. The Node returns the
+	// signature once the client closes the stream and the whole batch is validated.
+	StoreChunksStream(ctx context.Context, opts ...grpc.CallOption) (Dispersal_StoreChunksStreamClient, error)
+	// NodeInfo returns the build of the Node and the capabilities it's deployed with.
+	NodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfoReply, error)
 }
 
 type dispersalClient struct {
@@ -52,6 +61,49 @@ func (c *dispersalClient) StoreChunks(ctx context.Context, in *StoreChunksReques
 	return out, nil
 }
 
+func (c *dispersalClient) StoreChunksStream(ctx context.Context, opts ...grpc.CallOption) (Dispersal_StoreChunksStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dispersal_ServiceDesc.Streams[0], Dispersal_StoreChunksStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dispersalStoreChunksStreamClient{stream}
+	return x, nil
+}
+
+type Dispersal_StoreChunksStreamClient interface {
+	Send(*StoreChunksRequest) error
+	CloseAndRecv() (*StoreChunksReply, error)
+	grpc.ClientStream
+}
+
+type dispersalStoreChunksStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *dispersalStoreChunksStreamClient) Send(m *StoreChunksRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dispersalStoreChunksStreamClient) CloseAndRecv() (*StoreChunksReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StoreChunksReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dispersalClient) NodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfoReply, error) {
+	out := new(NodeInfoReply)
+	err := c.cc.Invoke(ctx, Dispersal_NodeInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DispersalServer is the server API for Dispersal service.
 // All implementations must embed UnimplementedDispersalServer
 // for forward compatibility
@@ -63,6 +115,13 @@ type DispersalServer interface {
 	// for the protocol-defined length of custody. It will return a signature at the
 	// end to attest to the data in this request it has processed.
 	StoreChunks(context.Context, *StoreChunksRequest) (*StoreChunksReply, error)
+	// StoreChunksStream is StoreChunks with the blobs of the batch streamed in minibatches,
+	// which the Node validates as they arrive. The first request carries the batch header,
+	// and each request carries the next blobs of the batch in order. The Node returns the
+	// signature once the client closes the stream and the whole batch is validated.
+	StoreChunksStream(Dispersal_StoreChunksStreamServer) error
+	// NodeInfo returns the build of the Node and the capabilities it's deployed with.
+	NodeInfo(context.Context, *NodeInfoRequest) (*NodeInfoReply, error)
 	mustEmbedUnimplementedDispersalServer()
 }
 
@@ -73,6 +132,12 @@ type UnimplementedDispersalServer struct {
 func (UnimplementedDispersalServer) StoreChunks(context.Context, *StoreChunksRequest) (*StoreChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreChunks not implemented")
 }
+func (UnimplementedDispersalServer) StoreChunksStream(Dispersal_StoreChunksStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method StoreChunksStream not implemented")
+}
+func (UnimplementedDispersalServer) NodeInfo(context.Context, *NodeInfoRequest) (*NodeInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeInfo not implemented")
+}
 func (UnimplementedDispersalServer) mustEmbedUnimplementedDispersalServer() {}
 
 // UnsafeDispersalServer may be embedded to opt out of forward compatibility for this service.
@@ -104,6 +169,50 @@ func _Dispersal_StoreChunks_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dispersal_StoreChunksStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DispersalServer).StoreChunksStream(&dispersalStoreChunksStreamServer{stream})
+}
+
+type Dispersal_StoreChunksStreamServer interface {
+	SendAndClose(*StoreChunksReply) error
+	Recv() (*StoreChunksRequest, error)
+	grpc.ServerStream
+}
+
+type dispersalStoreChunksStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *dispersalStoreChunksStreamServer) SendAndClose(m *StoreChunksReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *dispersalStoreChunksStreamServer) Recv() (*StoreChunksRequest, error) {
+	m := new(StoreChunksRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Dispersal_NodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispersalServer).NodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dispersal_NodeInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispersalServer).NodeInfo(ctx, req.(*NodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Dispersal_ServiceDesc is the grpc.ServiceDesc for Dispersal service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,14 +224,25 @@ var Dispersal_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StoreChunks",
 			Handler:    _Dispersal_StoreChunks_Handler,
 		},
+		{
+			MethodName: "NodeInfo",
+			Handler:    _Dispersal_NodeInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StoreChunksStream",
+			Handler:       _Dispersal_StoreChunksStream_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "node/node.proto",
 }
 
 const (
 	Retrieval_RetrieveChunks_FullMethodName = "/node.Retrieval/RetrieveChunks"
 	Retrieval_GetBlobHeader_FullMethodName  = "/node.Retrieval/GetBlobHeader"
+	Retrieval_NodeInfo_FullMethodName       = "/node.Retrieval/NodeInfo"
 )
 
 // RetrievalClient is the client API for Retrieval service.
@@ -133,6 +253,8 @@ type RetrievalClient interface {
 	RetrieveChunks(ctx context.Context, in *RetrieveChunksRequest, opts ...grpc.CallOption) (*RetrieveChunksReply, error)
 	// Similar to RetrieveChunks, this just returns the header of the blob.
 	GetBlobHeader(ctx context.Context, in *GetBlobHeaderRequest, opts ...grpc.CallOption) (*GetBlobHeaderReply, error)
+	// NodeInfo returns the build of the Node and the capabilities it's deployed with.
+	NodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfoReply, error)
 }
 
 type retrievalClient struct {
@@ -161,6 +283,15 @@ func (c *retrievalClient) GetBlobHeader(ctx context.Context, in *GetBlobHeaderRe
 	return out, nil
 }
 
+func (c *retrievalClient) NodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfoReply, error) {
+	out := new(NodeInfoReply)
+	err := c.cc.Invoke(ctx, Retrieval_NodeInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RetrievalServer is the server API for Retrieval service.
 // All implementations must embed UnimplementedRetrievalServer
 // for forward compatibility
@@ -169,6 +300,8 @@ type RetrievalServer interface {
 	RetrieveChunks(context.Context, *RetrieveChunksRequest) (*RetrieveChunksReply, error)
 	// Similar to RetrieveChunks, this just returns the header of the blob.
 	GetBlobHeader(context.Context, *GetBlobHeaderRequest) (*GetBlobHeaderReply, error)
+	// NodeInfo returns the build of the Node and the capabilities it's deployed with.
+	NodeInfo(context.Context, *NodeInfoRequest) (*NodeInfoReply, error)
 	mustEmbedUnimplementedRetrievalServer()
 }
 
@@ -182,6 +315,9 @@ func (UnimplementedRetrievalServer) RetrieveChunks(context.Context, *RetrieveChu
 func (UnimplementedRetrievalServer) GetBlobHeader(context.Context, *GetBlobHeaderRequest) (*GetBlobHeaderReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobHeader not implemented")
 }
+func (UnimplementedRetrievalServer) NodeInfo(context.Context, *NodeInfoRequest) (*NodeInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeInfo not implemented")
+}
 func (UnimplementedRetrievalServer) mustEmbedUnimplementedRetrievalServer() {}
 
 // UnsafeRetrievalServer may be embedded to opt out of forward compatibility for this service.
@@ -231,6 +367,24 @@ func _Retrieval_GetBlobHeader_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Retrieval_NodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrievalServer).NodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retrieval_NodeInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrievalServer).NodeInfo(ctx, req.(*NodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Retrieval_ServiceDesc is the grpc.ServiceDesc for Retrieval service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -246,6 +400,10 @@ var Retrieval_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlobHeader",
 			Handler:    _Retrieval_GetBlobHeader_Handler,
 		},
+		{
+			MethodName: "NodeInfo",
+			Handler:    _Retrieval_NodeInfo_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "node/node.proto",
@@ -0,0 +1,102 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+)
+
+// DataAPIClient fetches pages of the paginated listings of the data API. Cursors are the opaque strings returned
+// in the next_cursor of the pages, and an empty cursor fetches the first page.
+type DataAPIClient interface {
+	// FetchBlobs fetches the blobs confirmed after the cursor, in confirmation order.
+	FetchBlobs(ctx context.Context, cursor string, limit int) (*dataapi.BlobsResponse, error)
+	// FetchBatches fetches the batches confirmed after the cursor, in ascending order of batch ID.
+	FetchBatches(ctx context.Context, cursor string, limit int) (*dataapi.BatchesResponse, error)
+	// FetchOperatorsNonsigningPercentage fetches the nonsigning metrics of the operators during the interval ending
+	// at end, after the cursor, in ascending order of operator ID and quorum ID.
+	FetchOperatorsNonsigningPercentage(ctx context.Context, end time.Time, interval time.Duration, liveOnly bool, cursor string, limit int) (*dataapi.OperatorsNonsigningPercentage, error)
+}
+
+type dataAPIClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+var _ DataAPIClient = &dataAPIClient{}
+
+// NewDataAPIClient creates a client of the data API served at baseURL, e.g. "https://dataapi.example.com/api/v1".
+func NewDataAPIClient(baseURL string, timeout time.Duration) DataAPIClient {
+	return &dataAPIClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *dataAPIClient) FetchBlobs(ctx context.Context, cursor string, limit int) (*dataapi.BlobsResponse, error) {
+	var response dataapi.BlobsResponse
+	err := c.get(ctx, "/feed/blobs", pageQuery(cursor, limit), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *dataAPIClient) FetchBatches(ctx context.Context, cursor string, limit int) (*dataapi.BatchesResponse, error) {
+	var response dataapi.BatchesResponse
+	err := c.get(ctx, "/feed/batches", pageQuery(cursor, limit), &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *dataAPIClient) FetchOperatorsNonsigningPercentage(ctx context.Context, end time.Time, interval time.Duration, liveOnly bool, cursor string, limit int) (*dataapi.OperatorsNonsigningPercentage, error) {
+	query := pageQuery(cursor, limit)
+	query.Set("end", end.UTC().Format("2006-01-02T15:04:05Z"))
+	query.Set("interval", strconv.FormatInt(int64(interval/time.Second), 10))
+	query.Set("live_only", strconv.FormatBool(liveOnly))
+
+	var response dataapi.OperatorsNonsigningPercentage
+	err := c.get(ctx, "/metrics/operator-nonsigning-percentage", query, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func pageQuery(cursor string, limit int) url.Values {
+	query := url.Values{}
+	// the cursor is always set, as it makes the data API paginate the listings
+	query.Set("cursor", cursor)
+	query.Set("limit", strconv.Itoa(limit))
+	return query
+}
+
+func (c *dataAPIClient) get(ctx context.Context, path string, query url.Values, response any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResponse dataapi.ErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResponse); err == nil && errResponse.Error != "" {
+			return fmt.Errorf("data API request %s failed with status %d: %s", path, resp.StatusCode, errResponse.Error)
+		}
+		return fmt.Errorf("data API request %s failed with status %d", path, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
@@ -0,0 +1,133 @@
+package clients
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+)
+
+// PageIterator iterates over the items of a paginated listing of the data API, fetching a page of items at a time.
+//
+// The cursor of each item can be persisted to resume the iteration after that item later, e.g. so that an indexer
+// only reads the items that were added to a listing since its last run:
+//
+//	it := clients.IterateBlobs(client, lastCursor, 100)
+//	for it.Next(ctx) {
+//		process(it.Item())
+//		lastCursor = it.Cursor()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PageIterator[T any] struct {
+	fetch    func(ctx context.Context, cursor string) ([]T, error)
+	cursorOf func(item T) string
+	pageSize int
+
+	page   []T
+	item   T
+	cursor string
+	done   bool
+	err    error
+}
+
+func newPageIterator[T any](cursor string, pageSize int, fetch func(ctx context.Context, cursor string) ([]T, error), cursorOf func(item T) string) *PageIterator[T] {
+	return &PageIterator[T]{
+		fetch:    fetch,
+		cursorOf: cursorOf,
+		pageSize: pageSize,
+		cursor:   cursor,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page if needed. It returns false once there are
+// no more items, or if a page could not be fetched, in which case Err returns the error.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.page) == 0 {
+		if it.done {
+			return false
+		}
+		page, err := it.fetch(ctx, it.cursor)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		// a short page is the last page
+		it.done = len(page) < it.pageSize
+		if len(page) == 0 {
+			return false
+		}
+		it.page = page
+	}
+
+	it.item = it.page[0]
+	it.page = it.page[1:]
+	it.cursor = it.cursorOf(it.item)
+	return true
+}
+
+// Item returns the current item.
+func (it *PageIterator[T]) Item() T {
+	return it.item
+}
+
+// Cursor returns the cursor of the current item, or the cursor the iteration started from if Next wasn't called yet.
+// Iterating from this cursor resumes the iteration after the current item.
+func (it *PageIterator[T]) Cursor() string {
+	return it.cursor
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// IterateBlobs iterates over the blobs confirmed after the cursor, in confirmation order.
+func IterateBlobs(client DataAPIClient, cursor string, pageSize int) *PageIterator[*dataapi.BlobMetadataResponse] {
+	return newPageIterator(cursor, pageSize,
+		func(ctx context.Context, cursor string) ([]*dataapi.BlobMetadataResponse, error) {
+			response, err := client.FetchBlobs(ctx, cursor, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			return response.Data, nil
+		},
+		func(blob *dataapi.BlobMetadataResponse) string {
+			return dataapi.NewBlobCursor(blob.BatchId, blob.BlobIndex).Encode()
+		})
+}
+
+// IterateBatches iterates over the batches confirmed after the cursor, in ascending order of batch ID.
+func IterateBatches(client DataAPIClient, cursor string, pageSize int) *PageIterator[*dataapi.BatchResponse] {
+	return newPageIterator(cursor, pageSize,
+		func(ctx context.Context, cursor string) ([]*dataapi.BatchResponse, error) {
+			response, err := client.FetchBatches(ctx, cursor, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			return response.Data, nil
+		},
+		func(batch *dataapi.BatchResponse) string {
+			return dataapi.NewBatchCursor(batch.BatchId).Encode()
+		})
+}
+
+// IterateOperatorsNonsigningPercentage iterates over the nonsigning metrics of the operators during the interval
+// ending at end, after the cursor, in ascending order of operator ID and quorum ID.
+func IterateOperatorsNonsigningPercentage(client DataAPIClient, end time.Time, interval time.Duration, liveOnly bool, cursor string, pageSize int) *PageIterator[*dataapi.OperatorNonsigningPercentageMetrics] {
+	return newPageIterator(cursor, pageSize,
+		func(ctx context.Context, cursor string) ([]*dataapi.OperatorNonsigningPercentageMetrics, error) {
+			response, err := client.FetchOperatorsNonsigningPercentage(ctx, end, interval, liveOnly, cursor, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			return response.Data, nil
+		},
+		func(metrics *dataapi.OperatorNonsigningPercentageMetrics) string {
+			return dataapi.NewOperatorNonsigningCursor(metrics.OperatorId, metrics.QuorumId).Encode()
+		})
+}
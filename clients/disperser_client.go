@@ -2,9 +2,12 @@ package clients
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
@@ -13,8 +16,10 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type Config struct {
@@ -36,12 +41,25 @@ func NewConfig(hostname, port string, timeout time.Duration, useSecureGrpcFlag b
 type DisperserClient interface {
 	DisperseBlob(ctx context.Context, data []byte, customQuorums []uint8) (*disperser.BlobStatus, []byte, error)
 	DisperseBlobAuthenticated(ctx context.Context, data []byte, customQuorums []uint8) (*disperser.BlobStatus, []byte, error)
+	DisperseBlobStream(ctx context.Context, data []byte, customQuorums []uint8) (*disperser.BlobStatus, []byte, error)
 	GetBlobStatus(ctx context.Context, key []byte) (*disperser_rpc.BlobStatusReply, error)
+	SubscribeBlobStatus(ctx context.Context, key []byte, onUpdate func(*disperser_rpc.BlobStatusReply) error) error
 }
 
+// streamChunkSize is the size of the data chunks sent by DisperseBlobStream
+const streamChunkSize = 256 * 1024
+
+// maxSubscribeRetries is the number of times in a row SubscribeBlobStatus resumes an interrupted stream
+// without receiving any message before giving up
+const maxSubscribeRetries = 3
+
+// signedRequestExpiry is how long the requests signed by a signing client are valid
+const signedRequestExpiry = 5 * time.Minute
+
 type disperserClient struct {
-	config *Config
-	signer core.BlobRequestSigner
+	config        *Config
+	signer        core.BlobRequestSigner
+	requestSigner core.RequestSigner
 }
 
 var _ DisperserClient = &disperserClient{}
@@ -53,6 +71,35 @@ func NewDisperserClient(config *Config, signer core.BlobRequestSigner) Disperser
 	}
 }
 
+// NewSigningDisperserClient returns a client that signs its DisperseBlob and DisperseBlobStream requests with the
+// account of the signer, so that the disperser attributes them to the account, which must be registered with it.
+func NewSigningDisperserClient(config *Config, signer core.RequestSigner) DisperserClient {
+	return &disperserClient{
+		config:        config,
+		requestSigner: signer,
+	}
+}
+
+// signRequest signs the request with the account of the requestSigner of the client, if any
+func (c *disperserClient) signRequest(request *disperser_rpc.DisperseBlobRequest, payloadHash []byte) error {
+	if c.requestSigner == nil {
+		return nil
+	}
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return fmt.Errorf("failed to generate a nonce: %w", err)
+	}
+	request.AccountId = c.requestSigner.GetAccountID()
+	request.Nonce = binary.BigEndian.Uint64(nonce[:])
+	request.Expiry = uint64(time.Now().Add(signedRequestExpiry).Unix())
+	signature, err := c.requestSigner.SignRequest(payloadHash, request.Nonce, request.Expiry)
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+	request.Signature = signature
+	return nil
+}
+
 func (c *disperserClient) getDialOptions() []grpc.DialOption {
 	if c.config.UseSecureGrpcFlag {
 		config := &tls.Config{}
@@ -90,6 +137,10 @@ func (c *disperserClient) DisperseBlob(ctx context.Context, data []byte, quorums
 	request := &disperser_rpc.DisperseBlobRequest{
 		Data:                data,
 		CustomQuorumNumbers: quorumNumbers,
+		PayloadHash:         disperser.ComputePayloadHash(data),
+	}
+	if err := c.signRequest(request, request.PayloadHash); err != nil {
+		return nil, nil, err
 	}
 
 	reply, err := disperserClient.DisperseBlob(ctxTimeout, request)
@@ -140,6 +191,7 @@ func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, data []
 		Data:                data,
 		CustomQuorumNumbers: quorumNumbers,
 		AccountId:           c.signer.GetAccountID(),
+		PayloadHash:         disperser.ComputePayloadHash(data),
 	}
 
 	// Send the initial request
@@ -199,6 +251,73 @@ func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, data []
 	return blobStatus, disperseReply.DisperseReply.GetRequestId(), nil
 }
 
+// DisperseBlobStream disperses the blob like DisperseBlob, but sends its data in chunks of streamChunkSize bytes, so
+// that blobs larger than the gRPC message size limit can be dispersed.
+func (c *disperserClient) DisperseBlobStream(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+
+	dialOptions := c.getDialOptions()
+	conn, err := grpc.Dial(addr, dialOptions...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	disperserClient := disperser_rpc.NewDisperserClient(conn)
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	quorumNumbers := make([]uint32, len(quorums))
+	for i, q := range quorums {
+		quorumNumbers[i] = uint32(q)
+	}
+
+	// check every 32 bytes of data are within the valid range for a bn254 field element
+	_, err = rs.ToFrArray(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617 %w", err)
+	}
+
+	stream, err := disperserClient.DisperseBlobStream(ctxTimeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while calling DisperseBlobStream: %w", err)
+	}
+	for offset := 0; offset < len(data); offset += streamChunkSize {
+		chunk := data[offset:min(offset+streamChunkSize, len(data))]
+		err = stream.Send(&disperser_rpc.DisperseBlobStreamRequest{Payload: &disperser_rpc.DisperseBlobStreamRequest_DataChunk{
+			DataChunk: chunk,
+		}})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to send data chunk: %w", err)
+		}
+	}
+	header := &disperser_rpc.DisperseBlobRequest{
+		CustomQuorumNumbers: quorumNumbers,
+		PayloadHash:         disperser.ComputePayloadHash(data),
+	}
+	if err := c.signRequest(header, header.PayloadHash); err != nil {
+		return nil, nil, err
+	}
+	err = stream.Send(&disperser_rpc.DisperseBlobStreamRequest{Payload: &disperser_rpc.DisperseBlobStreamRequest_Header{
+		Header: header,
+	}})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	reply, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blobStatus, err := disperser.FromBlobStatusProto(reply.GetResult())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blobStatus, reply.GetRequestId(), nil
+}
+
 func (c *disperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
 	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
 	conn, err := grpc.Dial(
@@ -224,3 +343,54 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (
 
 	return reply, nil
 }
+
+// SubscribeBlobStatus calls onUpdate with each new status of the blob, until the blob reaches a terminal status, ctx
+// is done or onUpdate returns an error. A stream interrupted by the network is resumed from the last status received.
+func (c *disperserClient) SubscribeBlobStatus(ctx context.Context, requestID []byte, onUpdate func(*disperser_rpc.BlobStatusReply) error) error {
+	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+
+	dialOptions := c.getDialOptions()
+	conn, err := grpc.Dial(addr, dialOptions...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	disperserClient := disperser_rpc.NewDisperserClient(conn)
+
+	lastStatus := disperser_rpc.BlobStatus_UNKNOWN
+	retries := 0
+	for {
+		stream, err := disperserClient.SubscribeBlobStatus(ctx, &disperser_rpc.SubscribeBlobStatusRequest{
+			RequestId:  requestID,
+			LastStatus: lastStatus,
+		})
+		if err != nil {
+			return fmt.Errorf("error while calling SubscribeBlobStatus: %w", err)
+		}
+
+		for {
+			update, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				if status.Code(err) != codes.Unavailable || retries >= maxSubscribeRetries {
+					return err
+				}
+				retries++
+				break
+			}
+			retries = 0
+
+			// Keepalive messages don't carry a status
+			if update.GetReply() == nil {
+				continue
+			}
+			lastStatus = update.GetReply().GetStatus()
+			if err := onUpdate(update.GetReply()); err != nil {
+				return err
+			}
+		}
+	}
+}
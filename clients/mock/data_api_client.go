@@ -0,0 +1,47 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDataAPIClient struct {
+	mock.Mock
+}
+
+var _ clients.DataAPIClient = (*MockDataAPIClient)(nil)
+
+func NewMockDataAPIClient() *MockDataAPIClient {
+	return &MockDataAPIClient{}
+}
+
+func (c *MockDataAPIClient) FetchBlobs(ctx context.Context, cursor string, limit int) (*dataapi.BlobsResponse, error) {
+	args := c.Called(cursor, limit)
+	var response *dataapi.BlobsResponse
+	if args.Get(0) != nil {
+		response = args.Get(0).(*dataapi.BlobsResponse)
+	}
+	return response, args.Error(1)
+}
+
+func (c *MockDataAPIClient) FetchBatches(ctx context.Context, cursor string, limit int) (*dataapi.BatchesResponse, error) {
+	args := c.Called(cursor, limit)
+	var response *dataapi.BatchesResponse
+	if args.Get(0) != nil {
+		response = args.Get(0).(*dataapi.BatchesResponse)
+	}
+	return response, args.Error(1)
+}
+
+func (c *MockDataAPIClient) FetchOperatorsNonsigningPercentage(ctx context.Context, end time.Time, interval time.Duration, liveOnly bool, cursor string, limit int) (*dataapi.OperatorsNonsigningPercentage, error) {
+	args := c.Called(end, interval, liveOnly, cursor, limit)
+	var response *dataapi.OperatorsNonsigningPercentage
+	if args.Get(0) != nil {
+		response = args.Get(0).(*dataapi.OperatorsNonsigningPercentage)
+	}
+	return response, args.Error(1)
+}
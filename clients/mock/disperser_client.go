@@ -53,6 +53,23 @@ func (c *MockDisperserClient) DisperseBlob(ctx context.Context, data []byte, quo
 	return status, key, err
 }
 
+func (c *MockDisperserClient) DisperseBlobStream(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	args := c.Called(data, quorums)
+	var status *disperser.BlobStatus
+	if args.Get(0) != nil {
+		status = (args.Get(0)).(*disperser.BlobStatus)
+	}
+	var key []byte
+	if args.Get(1) != nil {
+		key = (args.Get(1)).([]byte)
+	}
+	var err error
+	if args.Get(2) != nil {
+		err = (args.Get(2)).(error)
+	}
+	return status, key, err
+}
+
 func (c *MockDisperserClient) GetBlobStatus(ctx context.Context, key []byte) (*disperser_rpc.BlobStatusReply, error) {
 	args := c.Called(key)
 	var reply *disperser_rpc.BlobStatusReply
@@ -65,3 +82,19 @@ func (c *MockDisperserClient) GetBlobStatus(ctx context.Context, key []byte) (*d
 	}
 	return reply, err
 }
+
+func (c *MockDisperserClient) SubscribeBlobStatus(ctx context.Context, key []byte, onUpdate func(*disperser_rpc.BlobStatusReply) error) error {
+	args := c.Called(key)
+	if replies, ok := args.Get(0).([]*disperser_rpc.BlobStatusReply); ok {
+		for _, reply := range replies {
+			if err := onUpdate(reply); err != nil {
+				return err
+			}
+		}
+	}
+	var err error
+	if args.Get(1) != nil {
+		err = (args.Get(1)).(error)
+	}
+	return err
+}
@@ -0,0 +1,453 @@
+package mock
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	bn254utils "github.com/Layr-Labs/eigenda/core/bn254"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+)
+
+type DisperserServerConfig struct {
+	// Latency delays every reply
+	Latency time.Duration
+	// ConfirmationDelay is the time after its dispersal at which a blob is confirmed
+	ConfirmationDelay time.Duration
+	// FinalizationDelay is the time after its confirmation at which a blob is finalized
+	FinalizationDelay time.Duration
+	// Quorums are the quorums of the blobs dispersed without custom quorums. Defaults to quorums 0 and 1.
+	Quorums []uint8
+}
+
+// dispersedBlob is a blob dispersed to the DisperserServer, with its certificate
+type dispersedBlob struct {
+	data         []byte
+	dispersedAt  time.Time
+	status       disperser_rpc.BlobStatus
+	info         *disperser_rpc.BlobInfo
+	statusForced bool
+}
+
+// DisperserServer is an in-process disperser serving the Disperser gRPC API, so the integrations with EigenDA can be
+// tested without a devnet. Each blob is confirmed in a batch of its own after ConfirmationDelay, and its certificate is
+// derived deterministically from the blob and the order of the dispersals. The commitments aren't KZG commitments to
+// the blobs, but the inclusion proofs are valid against the batch roots.
+type DisperserServer struct {
+	disperser_rpc.UnimplementedDisperserServer
+	*faults
+
+	config   DisperserServerConfig
+	server   *grpc.Server
+	listener net.Listener
+
+	mu            sync.Mutex
+	blobs         map[string]*dispersedBlob
+	batches       map[[32]byte]*dispersedBlob
+	numDispersals uint32
+}
+
+var _ disperser_rpc.DisperserServer = (*DisperserServer)(nil)
+
+func NewDisperserServer(config DisperserServerConfig) *DisperserServer {
+	if len(config.Quorums) == 0 {
+		config.Quorums = []uint8{0, 1}
+	}
+	return &DisperserServer{
+		faults:  newFaults(config.Latency),
+		config:  config,
+		blobs:   make(map[string]*dispersedBlob),
+		batches: make(map[[32]byte]*dispersedBlob),
+	}
+}
+
+// Start serves the Disperser API on a local port, until Stop is called
+func (s *DisperserServer) Start() error {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	s.listener = listener
+	s.server = grpc.NewServer(grpc.MaxRecvMsgSize(1024 * 1024 * 300))
+	disperser_rpc.RegisterDisperserServer(s.server, s)
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	return nil
+}
+
+// Stop stops serving the Disperser API
+func (s *DisperserServer) Stop() {
+	if s.server != nil {
+		s.server.Stop()
+	}
+}
+
+// Port returns the port the Disperser API is served on
+func (s *DisperserServer) Port() string {
+	return fmt.Sprint(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+// SetBlobStatus overrides the status of the blob with the request ID, e.g. to make it FAILED. The status of the blob
+// doesn't change afterwards.
+func (s *DisperserServer) SetBlobStatus(requestID []byte, status disperser_rpc.BlobStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blob, ok := s.blobs[string(requestID)]
+	if !ok {
+		return errors.New("blob not found")
+	}
+	blob.status = status
+	blob.statusForced = true
+	return nil
+}
+
+func (s *DisperserServer) DisperseBlob(ctx context.Context, req *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	if err := s.before(ctx, "DisperseBlob"); err != nil {
+		return nil, err
+	}
+	return s.disperse(req)
+}
+
+func (s *DisperserServer) DisperseBlobAuthenticated(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer) error {
+	if err := s.before(stream.Context(), "DisperseBlobAuthenticated"); err != nil {
+		return err
+	}
+
+	in, err := stream.Recv()
+	if err != nil {
+		return api.NewInvalidArgError(fmt.Sprintf("error receiving the request: %v", err))
+	}
+	request, ok := in.GetPayload().(*disperser_rpc.AuthenticatedRequest_DisperseRequest)
+	if !ok {
+		return api.NewInvalidArgError("missing DisperseBlobRequest")
+	}
+
+	// The challenge is answered, but the signature isn't checked
+	s.mu.Lock()
+	challenge := s.numDispersals
+	s.mu.Unlock()
+	err = stream.Send(&disperser_rpc.AuthenticatedReply{Payload: &disperser_rpc.AuthenticatedReply_BlobAuthHeader{
+		BlobAuthHeader: &disperser_rpc.BlobAuthHeader{
+			ChallengeParameter: challenge,
+		},
+	}})
+	if err != nil {
+		return err
+	}
+	in, err = stream.Recv()
+	if err != nil {
+		return api.NewInvalidArgError(fmt.Sprintf("error receiving the challenge reply: %v", err))
+	}
+	if _, ok := in.GetPayload().(*disperser_rpc.AuthenticatedRequest_AuthenticationData); !ok {
+		return api.NewInvalidArgError("expected AuthenticationData")
+	}
+
+	reply, err := s.disperse(request.DisperseRequest)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&disperser_rpc.AuthenticatedReply{Payload: &disperser_rpc.AuthenticatedReply_DisperseReply{
+		DisperseReply: reply,
+	}})
+}
+
+func (s *DisperserServer) DisperseBlobStream(stream disperser_rpc.Disperser_DisperseBlobStreamServer) error {
+	if err := s.before(stream.Context(), "DisperseBlobStream"); err != nil {
+		return err
+	}
+
+	var data []byte
+	var header *disperser_rpc.DisperseBlobRequest
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return api.NewInvalidArgError(fmt.Sprintf("error receiving the request: %v", err))
+		}
+		if header != nil {
+			return api.NewInvalidArgError("received a message after the DisperseBlobRequest")
+		}
+		switch payload := in.GetPayload().(type) {
+		case *disperser_rpc.DisperseBlobStreamRequest_DataChunk:
+			data = append(data, payload.DataChunk...)
+		case *disperser_rpc.DisperseBlobStreamRequest_Header:
+			header = payload.Header
+		default:
+			return api.NewInvalidArgError("missing payload")
+		}
+	}
+	if header == nil {
+		return api.NewInvalidArgError("missing DisperseBlobRequest")
+	}
+
+	reply, err := s.disperse(&disperser_rpc.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: header.GetCustomQuorumNumbers(),
+		AccountId:           header.GetAccountId(),
+		PayloadHash:         header.GetPayloadHash(),
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(reply)
+}
+
+func (s *DisperserServer) GetBlobStatus(ctx context.Context, req *disperser_rpc.BlobStatusRequest) (*disperser_rpc.BlobStatusReply, error) {
+	if err := s.before(ctx, "GetBlobStatus"); err != nil {
+		return nil, err
+	}
+	return s.blobStatus(req.GetRequestId())
+}
+
+// statusPollInterval is how often SubscribeBlobStatus checks the status of its blob
+const statusPollInterval = 10 * time.Millisecond
+
+func (s *DisperserServer) SubscribeBlobStatus(req *disperser_rpc.SubscribeBlobStatusRequest, stream disperser_rpc.Disperser_SubscribeBlobStatusServer) error {
+	if err := s.before(stream.Context(), "SubscribeBlobStatus"); err != nil {
+		return err
+	}
+
+	lastStatus := req.GetLastStatus()
+	for {
+		reply, err := s.blobStatus(req.GetRequestId())
+		if err != nil {
+			return err
+		}
+		if reply.GetStatus() != lastStatus {
+			if err := stream.Send(&disperser_rpc.BlobStatusUpdate{Reply: reply}); err != nil {
+				return err
+			}
+			lastStatus = reply.GetStatus()
+		}
+		switch lastStatus {
+		case disperser_rpc.BlobStatus_FINALIZED, disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+			return nil
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-time.After(statusPollInterval):
+		}
+	}
+}
+
+// blobStatus returns the current status of the blob with the request ID
+func (s *DisperserServer) blobStatus(requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blob, ok := s.blobs[string(requestID)]
+	if !ok {
+		return nil, api.NewNotFoundError("no metadata found for the requestID")
+	}
+
+	status := blob.status
+	if !blob.statusForced {
+		elapsed := time.Since(blob.dispersedAt)
+		switch {
+		case elapsed < s.config.ConfirmationDelay:
+			status = disperser_rpc.BlobStatus_PROCESSING
+		case elapsed < s.config.ConfirmationDelay+s.config.FinalizationDelay:
+			status = disperser_rpc.BlobStatus_CONFIRMED
+		default:
+			status = disperser_rpc.BlobStatus_FINALIZED
+		}
+	}
+	reply := &disperser_rpc.BlobStatusReply{Status: status}
+	if status == disperser_rpc.BlobStatus_CONFIRMED || status == disperser_rpc.BlobStatus_FINALIZED {
+		reply.Info = blob.info
+	}
+	return reply, nil
+}
+
+func (s *DisperserServer) RetrieveBlob(ctx context.Context, req *disperser_rpc.RetrieveBlobRequest) (*disperser_rpc.RetrieveBlobReply, error) {
+	if err := s.before(ctx, "RetrieveBlob"); err != nil {
+		return nil, err
+	}
+
+	var batchHeaderHash [32]byte
+	copy(batchHeaderHash[:], req.GetBatchHeaderHash())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blob, ok := s.batches[batchHeaderHash]
+	if !ok || req.GetBlobIndex() != 0 {
+		return nil, api.NewNotFoundError("no blob found for the batch header hash and blob index")
+	}
+	return &disperser_rpc.RetrieveBlobReply{Data: blob.data}, nil
+}
+
+// disperse stores the blob and makes its certificate
+func (s *DisperserServer) disperse(req *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	data := req.GetData()
+	if len(data) == 0 {
+		return nil, api.NewInvalidArgError("blob size must be greater than 0")
+	}
+	if _, err := rs.ToFrArray(data); err != nil {
+		return nil, api.NewInvalidArgError(fmt.Sprintf("encountered an error to convert a 32-bytes into a valid field element: %v", err))
+	}
+	// Signed requests are verified, but any account is accepted and nonces aren't tracked
+	if len(req.GetSignature()) > 0 {
+		_, err := auth.VerifyRequestSignature(req.GetAccountId(), disperser.ComputePayloadHash(data), req.GetNonce(), req.GetExpiry(), req.GetSignature())
+		if err != nil {
+			return nil, api.NewInvalidArgError(fmt.Sprintf("failed to authenticate the signed request: %v", err))
+		}
+	}
+	quorums := slices.Clone(s.config.Quorums)
+	if len(req.GetCustomQuorumNumbers()) > 0 {
+		quorums = make([]uint8, len(req.GetCustomQuorumNumbers()))
+		for i, quorum := range req.GetCustomQuorumNumbers() {
+			if quorum > core.MaxQuorumID {
+				return nil, api.NewInvalidArgError(fmt.Sprintf("custom_quorum_numbers must be in range [0, %d], but found %d", core.MaxQuorumID, quorum))
+			}
+			if slices.Contains(quorums[:i], uint8(quorum)) {
+				return nil, api.NewInvalidArgError(fmt.Sprintf("custom_quorum_numbers must not contain duplicates, but found %d twice", quorum))
+			}
+			quorums[i] = uint8(quorum)
+		}
+	}
+	slices.Sort(quorums)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.numDispersals++
+	batchID := s.numDispersals
+
+	info, batchHeaderHash, err := makeBlobInfo(data, quorums, batchID)
+	if err != nil {
+		return nil, api.NewInternalError(err.Error())
+	}
+	blobHash := crypto.Keccak256(data)
+	metadataHash := crypto.Keccak256(binary.BigEndian.AppendUint32(blobHash, batchID))
+	requestID := disperser.BlobKey{
+		BlobHash:     hex.EncodeToString(blobHash),
+		MetadataHash: hex.EncodeToString(metadataHash),
+	}.String()
+
+	blob := &dispersedBlob{
+		data:        data,
+		dispersedAt: time.Now(),
+		info:        info,
+	}
+	s.blobs[requestID] = blob
+	s.batches[batchHeaderHash] = blob
+
+	return &disperser_rpc.DisperseBlobReply{
+		Result:    disperser_rpc.BlobStatus_PROCESSING,
+		RequestId: []byte(requestID),
+	}, nil
+}
+
+// makeBlobInfo returns the certificate of the blob confirmed alone in the batch with the ID, and the hash of the batch
+// header. The commitment is derived from the hash of the blob.
+func makeBlobInfo(data []byte, quorums []uint8, batchID uint32) (*disperser_rpc.BlobInfo, [32]byte, error) {
+	var scalar fr.Element
+	scalar.SetBytes(crypto.Keccak256(data))
+	commitment := (*encoding.G1Commitment)(bn254utils.MulByGeneratorG1(&scalar))
+
+	blobHeader := &core.BlobHeader{
+		BlobCommitments: encoding.BlobCommitments{
+			Commitment: commitment,
+			Length:     encoding.GetBlobLength(uint(len(data))),
+		},
+		QuorumInfos: make([]*core.BlobQuorumInfo, len(quorums)),
+	}
+	blobQuorumParams := make([]*disperser_rpc.BlobQuorumParam, len(quorums))
+	quorumSignedPercentages := make([]byte, len(quorums))
+	for i, quorum := range quorums {
+		blobHeader.QuorumInfos[i] = &core.BlobQuorumInfo{
+			SecurityParam: core.SecurityParam{
+				QuorumID:              quorum,
+				AdversaryThreshold:    33,
+				ConfirmationThreshold: 55,
+			},
+			ChunkLength: 1,
+		}
+		blobQuorumParams[i] = &disperser_rpc.BlobQuorumParam{
+			QuorumNumber:                    uint32(quorum),
+			AdversaryThresholdPercentage:    33,
+			ConfirmationThresholdPercentage: 55,
+			ChunkLength:                     1,
+		}
+		quorumSignedPercentages[i] = 100
+	}
+
+	referenceBlockNumber := batchID
+	batchHeader := &core.BatchHeader{
+		ReferenceBlockNumber: uint(referenceBlockNumber),
+	}
+	tree, err := batchHeader.SetBatchRoot([]*core.BlobHeader{blobHeader})
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	proof, err := tree.GenerateProofByIndex(0)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	inclusionProof := make([]byte, 0)
+	for _, hash := range proof.Hashes {
+		inclusionProof = append(inclusionProof, hash...)
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	signatoryRecordHash := core.ComputeSignatoryRecordHash(referenceBlockNumber, nil)
+
+	return &disperser_rpc.BlobInfo{
+		BlobHeader: &disperser_rpc.BlobHeader{
+			Commitment: &commonpb.G1Commitment{
+				X: commitment.X.Marshal(),
+				Y: commitment.Y.Marshal(),
+			},
+			DataLength:       uint32(blobHeader.Length),
+			BlobQuorumParams: blobQuorumParams,
+			PayloadHash:      disperser.ComputePayloadHash(data),
+		},
+		BlobVerificationProof: &disperser_rpc.BlobVerificationProof{
+			BatchId:   batchID,
+			BlobIndex: 0,
+			BatchMetadata: &disperser_rpc.BatchMetadata{
+				BatchHeader: &disperser_rpc.BatchHeader{
+					BatchRoot:               batchHeader.BatchRoot[:],
+					QuorumNumbers:           quorums,
+					QuorumSignedPercentages: quorumSignedPercentages,
+					ReferenceBlockNumber:    referenceBlockNumber,
+				},
+				SignatoryRecordHash:     signatoryRecordHash[:],
+				Fee:                     []byte{0},
+				ConfirmationBlockNumber: referenceBlockNumber + 1,
+				BatchHeaderHash:         batchHeaderHash[:],
+			},
+			InclusionProof: inclusionProof,
+			QuorumIndexes:  quorumIndexes(quorums),
+		},
+	}, batchHeaderHash, nil
+}
+
+func quorumIndexes(quorums []uint8) []byte {
+	indexes := make([]byte, len(quorums))
+	for i := range quorums {
+		indexes[i] = byte(i)
+	}
+	return indexes
+}
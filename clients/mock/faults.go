@@ -0,0 +1,81 @@
+package mock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// injectedError is an error returned by the next count calls of a method, or by every call if count is negative
+type injectedError struct {
+	err   error
+	count int
+}
+
+// faults delays the calls of the in-process servers and makes them fail on demand
+type faults struct {
+	mu      sync.Mutex
+	latency time.Duration
+	errs    map[string]*injectedError
+}
+
+func newFaults(latency time.Duration) *faults {
+	return &faults{
+		latency: latency,
+		errs:    make(map[string]*injectedError),
+	}
+}
+
+// SetLatency sets the delay before every reply
+func (f *faults) SetLatency(latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency = latency
+}
+
+// InjectError makes the next count calls of the method, e.g. "DisperseBlob", return err. If count is negative, every
+// call returns err until ClearErrors is called.
+func (f *faults) InjectError(method string, err error, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if count == 0 {
+		delete(f.errs, method)
+		return
+	}
+	f.errs[method] = &injectedError{err: err, count: count}
+}
+
+// ClearErrors removes the errors injected in every method
+func (f *faults) ClearErrors() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errs = make(map[string]*injectedError)
+}
+
+// before waits for the latency and returns the error injected in the method, if any
+func (f *faults) before(ctx context.Context, method string) error {
+	f.mu.Lock()
+	latency := f.latency
+	var err error
+	if injected, ok := f.errs[method]; ok {
+		err = injected.err
+		if injected.count > 0 {
+			injected.count--
+			if injected.count == 0 {
+				delete(f.errs, method)
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return err
+}
@@ -0,0 +1,239 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	node_utils "github.com/Layr-Labs/eigenda/node/grpc"
+	"google.golang.org/grpc"
+)
+
+// defaultNodeKey is the BLS private key of the NodeServers without a key pair, so their signatures are deterministic
+const defaultNodeKey = "0000000000000000000000000000000000000000000000000000000000000001"
+
+type NodeServerConfig struct {
+	// Latency delays every reply
+	Latency time.Duration
+	// KeyPair signs the batches stored by the node. Defaults to a fixed key pair.
+	KeyPair *core.KeyPair
+}
+
+// storedBatch is a batch stored by the NodeServer
+type storedBatch struct {
+	blobHeaders []*pb.BlobHeader
+	blobs       []*core.BlobMessage
+	tree        *core.MerkleTree
+}
+
+// NodeServer is an in-process operator node serving the Dispersal and Retrieval gRPC APIs, so the integrations with
+// EigenDA can be tested without a devnet. It stores the chunks it's sent and signs the batches, without validating the
+// chunks against the commitments.
+type NodeServer struct {
+	pb.UnimplementedDispersalServer
+	pb.UnimplementedRetrievalServer
+	*faults
+
+	keyPair           *core.KeyPair
+	server            *grpc.Server
+	dispersalListener net.Listener
+	retrievalListener net.Listener
+	mu                sync.Mutex
+	batches           map[[32]byte]*storedBatch
+}
+
+var _ pb.DispersalServer = (*NodeServer)(nil)
+var _ pb.RetrievalServer = (*NodeServer)(nil)
+
+func NewNodeServer(config NodeServerConfig) (*NodeServer, error) {
+	keyPair := config.KeyPair
+	if keyPair == nil {
+		var err error
+		keyPair, err = core.MakeKeyPairFromString(defaultNodeKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &NodeServer{
+		faults:  newFaults(config.Latency),
+		keyPair: keyPair,
+		batches: make(map[[32]byte]*storedBatch),
+	}, nil
+}
+
+// Start serves the Dispersal and Retrieval APIs on local ports, until Stop is called
+func (s *NodeServer) Start() error {
+	dispersalListener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	retrievalListener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		_ = dispersalListener.Close()
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	s.dispersalListener = dispersalListener
+	s.retrievalListener = retrievalListener
+
+	s.server = grpc.NewServer(grpc.MaxRecvMsgSize(1024 * 1024 * 300))
+	pb.RegisterDispersalServer(s.server, s)
+	pb.RegisterRetrievalServer(s.server, s)
+	go func() {
+		_ = s.server.Serve(dispersalListener)
+	}()
+	go func() {
+		_ = s.server.Serve(retrievalListener)
+	}()
+	return nil
+}
+
+// Stop stops serving the Dispersal and Retrieval APIs
+func (s *NodeServer) Stop() {
+	if s.server != nil {
+		s.server.Stop()
+	}
+}
+
+// Socket returns the socket of the node, in the format of the operator sockets registered on chain
+func (s *NodeServer) Socket() core.OperatorSocket {
+	return core.MakeOperatorSocket(
+		"localhost",
+		fmt.Sprint(s.dispersalListener.Addr().(*net.TCPAddr).Port),
+		fmt.Sprint(s.retrievalListener.Addr().(*net.TCPAddr).Port),
+	)
+}
+
+// KeyPair returns the key pair signing the batches stored by the node
+func (s *NodeServer) KeyPair() *core.KeyPair {
+	return s.keyPair
+}
+
+func (s *NodeServer) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
+	if err := s.before(ctx, "StoreChunks"); err != nil {
+		return nil, err
+	}
+	if in.GetBatchHeader() == nil {
+		return nil, api.NewInvalidArgError("missing batch_header in request")
+	}
+	if len(in.GetBlobs()) == 0 {
+		return nil, api.NewInvalidArgError("missing blobs in request")
+	}
+
+	batchHeader, err := node_utils.GetBatchHeader(in)
+	if err != nil {
+		return nil, api.NewInvalidArgError(err.Error())
+	}
+	blobs, err := node_utils.GetBlobMessages(in)
+	if err != nil {
+		return nil, api.NewInvalidArgError(err.Error())
+	}
+	blobHeaders := make([]*core.BlobHeader, len(blobs))
+	protoBlobHeaders := make([]*pb.BlobHeader, len(blobs))
+	for i, blob := range blobs {
+		blobHeaders[i] = blob.BlobHeader
+		protoBlobHeaders[i] = in.GetBlobs()[i].GetHeader()
+	}
+	tree, err := core.BuildBlobHeadersTree(blobHeaders)
+	if err != nil {
+		return nil, api.NewInvalidArgError(err.Error())
+	}
+	if string(tree.Root()) != string(batchHeader.BatchRoot[:]) {
+		return nil, api.NewInvalidArgError("the batch root doesn't match the blob headers")
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, api.NewInternalError(err.Error())
+	}
+
+	s.mu.Lock()
+	s.batches[batchHeaderHash] = &storedBatch{
+		blobHeaders: protoBlobHeaders,
+		blobs:       blobs,
+		tree:        tree,
+	}
+	s.mu.Unlock()
+
+	sig := s.keyPair.SignMessage(batchHeaderHash)
+	sigData := sig.Serialize()
+	return &pb.StoreChunksReply{Signature: sigData[:]}, nil
+}
+
+func (s *NodeServer) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksRequest) (*pb.RetrieveChunksReply, error) {
+	if err := s.before(ctx, "RetrieveChunks"); err != nil {
+		return nil, err
+	}
+
+	blob, err := s.getBlob(in.GetBatchHeaderHash(), in.GetBlobIndex())
+	if err != nil {
+		return nil, err
+	}
+	bundle, ok := blob.Bundles[core.QuorumID(in.GetQuorumId())]
+	if !ok {
+		return nil, api.NewNotFoundError("no chunks stored for the quorum")
+	}
+	chunks := make([][]byte, len(bundle))
+	for i, chunk := range bundle {
+		chunks[i], err = chunk.Serialize()
+		if err != nil {
+			return nil, api.NewInternalError(err.Error())
+		}
+	}
+	return &pb.RetrieveChunksReply{Chunks: chunks}, nil
+}
+
+func (s *NodeServer) GetBlobHeader(ctx context.Context, in *pb.GetBlobHeaderRequest) (*pb.GetBlobHeaderReply, error) {
+	if err := s.before(ctx, "GetBlobHeader"); err != nil {
+		return nil, err
+	}
+
+	batch, err := s.getBatch(in.GetBatchHeaderHash(), in.GetBlobIndex())
+	if err != nil {
+		return nil, err
+	}
+	proof, err := batch.tree.GenerateProofByIndex(uint64(in.GetBlobIndex()))
+	if err != nil {
+		return nil, api.NewInternalError(err.Error())
+	}
+	return &pb.GetBlobHeaderReply{
+		BlobHeader: batch.blobHeaders[in.GetBlobIndex()],
+		Proof: &pb.MerkleProof{
+			Hashes: proof.Hashes,
+			Index:  uint32(proof.Index),
+		},
+	}, nil
+}
+
+// NodeInfo returns the build of the mock node, on both the Dispersal and Retrieval APIs
+func (s *NodeServer) NodeInfo(ctx context.Context, in *pb.NodeInfoRequest) (*pb.NodeInfoReply, error) {
+	if err := s.before(ctx, "NodeInfo"); err != nil {
+		return nil, err
+	}
+	return &pb.NodeInfoReply{BuildInfo: &commonpb.BuildInfo{Service: "mock-node"}}, nil
+}
+
+func (s *NodeServer) getBatch(batchHeaderHash []byte, blobIndex uint32) (*storedBatch, error) {
+	if len(batchHeaderHash) != 32 {
+		return nil, api.NewInvalidArgError("batch_header_hash must be 32 bytes")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	batch, ok := s.batches[[32]byte(batchHeaderHash)]
+	if !ok || int(blobIndex) >= len(batch.blobs) {
+		return nil, api.NewNotFoundError("no blob stored for the batch header hash and blob index")
+	}
+	return batch, nil
+}
+
+func (s *NodeServer) getBlob(batchHeaderHash []byte, blobIndex uint32) (*core.BlobMessage, error) {
+	batch, err := s.getBatch(batchHeaderHash, blobIndex)
+	if err != nil {
+		return nil, err
+	}
+	return batch.blobs[blobIndex], nil
+}
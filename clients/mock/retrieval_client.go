@@ -35,3 +35,23 @@ func (c *MockRetrievalClient) RetrieveBlob(
 	result := args.Get(0)
 	return result.([]byte), args.Error(1)
 }
+
+func (c *MockRetrievalClient) RetrievePayload(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, error) {
+	args := c.Called()
+
+	result := args.Get(0)
+	return result.([]byte), args.Error(1)
+}
+
+func (c *MockRetrievalClient) GetBlobs(ctx context.Context, requests []clients.BlobRequest) []clients.BlobResult {
+	args := c.Called(requests)
+
+	result := args.Get(0)
+	return result.([]clients.BlobResult)
+}
@@ -2,6 +2,8 @@ package clients
 
 import (
 	"context"
+	"crypto/tls"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
@@ -10,7 +12,10 @@ import (
 	node_utils "github.com/Layr-Labs/eigenda/node/grpc"
 	"github.com/wealdtech/go-merkletree"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 type RetrievedChunks struct {
@@ -25,29 +30,81 @@ type NodeClient interface {
 }
 
 type client struct {
-	timeout time.Duration
+	timeout        time.Duration
+	verifyIdentity bool
+	conns          *connectionPool
 }
 
+// connectionPool shares one connection per operator socket between all the requests of a client. A gRPC connection
+// multiplexes concurrent requests, so the requests to the same operator don't need connections of their own.
+type connectionPool struct {
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+func newConnectionPool() *connectionPool {
+	return &connectionPool{
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+func (p *connectionPool) get(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn, ok := p.conns[target]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[target] = conn
+	return conn, nil
+}
+
+// NewNodeClient returns a client which keeps a connection to each operator it has sent a request to, and reuses it
+// for the following requests to the same operator.
 func NewNodeClient(timeout time.Duration) NodeClient {
 	return client{
 		timeout: timeout,
+		conns:   newConnectionPool(),
+	}
+}
+
+// NewIdentityVerifyingNodeClient returns a client connecting to the nodes over TLS, which rejects the chunks of the
+// nodes whose TLS certificate isn't signed by the BLS key the operator registered on chain, so that a host spoofing
+// the socket of an operator can't serve junk chunks. The certificates themselves aren't checked against certificate
+// authorities, as the signatures of the operators take their place.
+func NewIdentityVerifyingNodeClient(timeout time.Duration) NodeClient {
+	return client{
+		timeout:        timeout,
+		verifyIdentity: true,
+		conns:          newConnectionPool(),
 	}
 }
 
+func (c client) getDialOptions() []grpc.DialOption {
+	if c.verifyIdentity {
+		config := &tls.Config{InsecureSkipVerify: true}
+		return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+}
+
 func (c client) GetBlobHeader(
 	ctx context.Context,
 	socket string,
 	batchHeaderHash [32]byte,
 	blobIndex uint32,
 ) (*core.BlobHeader, *merkletree.Proof, error) {
-	conn, err := grpc.Dial(
+	conn, err := c.conns.get(
 		core.OperatorSocket(socket).GetRetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		c.getDialOptions()...,
 	)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer conn.Close()
 
 	n := node.NewRetrievalClient(conn)
 	nodeCtx, cancel := context.WithTimeout(ctx, c.timeout)
@@ -85,9 +142,9 @@ func (c client) GetChunks(
 	quorumID core.QuorumID,
 	chunksChan chan RetrievedChunks,
 ) {
-	conn, err := grpc.Dial(
+	conn, err := c.conns.get(
 		core.OperatorSocket(opInfo.Socket).GetRetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		c.getDialOptions()...,
 	)
 	if err != nil {
 		chunksChan <- RetrievedChunks{
@@ -108,7 +165,12 @@ func (c client) GetChunks(
 		QuorumId:        uint32(quorumID),
 	}
 
-	reply, err := n.RetrieveChunks(nodeCtx, request)
+	var p peer.Peer
+	var header metadata.MD
+	reply, err := n.RetrieveChunks(nodeCtx, request, grpc.Peer(&p), grpc.Header(&header))
+	if err == nil && c.verifyIdentity {
+		err = node_utils.VerifyOperatorIdentity(opID, opInfo.PubkeyG2, &p, header)
+	}
 	if err != nil {
 		chunksChan <- RetrievedChunks{
 			OperatorID: opID,
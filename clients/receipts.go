@@ -0,0 +1,216 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var ErrReceiptNotFound = errors.New("receipt not found")
+
+const receiptKeyPrefix = "receipt/"
+
+// Receipt is the local record of a dispersal request made through the client, along with the last status received for
+// the blob, which gives an audit trail independent of the disperser.
+type Receipt struct {
+	// ID is the request ID of the blob, or a local ID for the requests rejected by the disperser
+	ID string `json:"id"`
+	// Method is the RPC the blob was dispersed with
+	Method      string        `json:"method"`
+	DispersedAt time.Time     `json:"dispersed_at"`
+	DataSize    int           `json:"data_size"`
+	Quorums     []uint32      `json:"quorums"`
+	PayloadHash hexutil.Bytes `json:"payload_hash"`
+	// Error is why the request failed, in which case the blob wasn't dispersed
+	Error string `json:"error,omitempty"`
+	// Status is the last status of the blob received from the disperser
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// BlobInfo is the cert of the blob, in the protobuf JSON encoding, once it's confirmed
+	BlobInfo json.RawMessage `json:"blob_info,omitempty"`
+}
+
+// ReceiptStore persists the receipts of the dispersal requests in a LevelDB database
+type ReceiptStore struct {
+	db *leveldb.DB
+}
+
+// NewReceiptStore opens the receipt store at path, creating it if it doesn't exist. A store can only be opened by one
+// process at a time.
+func NewReceiptStore(path string) (*ReceiptStore, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the receipt store at %s: %w", path, err)
+	}
+	return &ReceiptStore{db: db}, nil
+}
+
+func (s *ReceiptStore) Close() error {
+	return s.db.Close()
+}
+
+// Put stores the receipt, replacing the receipt with the same ID if any
+func (s *ReceiptStore) Put(receipt *Receipt) error {
+	data, err := json.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	return s.db.Put([]byte(receiptKeyPrefix+receipt.ID), data, nil)
+}
+
+// Get returns the receipt with the given ID, or ErrReceiptNotFound
+func (s *ReceiptStore) Get(id string) (*Receipt, error) {
+	data, err := s.db.Get([]byte(receiptKeyPrefix+id), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, ErrReceiptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var receipt Receipt
+	if err := json.Unmarshal(data, &receipt); err != nil {
+		return nil, fmt.Errorf("failed to decode receipt %s: %w", id, err)
+	}
+	return &receipt, nil
+}
+
+// List returns all the receipts, the most recent dispersal first
+func (s *ReceiptStore) List() ([]*Receipt, error) {
+	iter := s.db.NewIterator(util.BytesPrefix([]byte(receiptKeyPrefix)), nil)
+	defer iter.Release()
+
+	receipts := make([]*Receipt, 0)
+	for iter.Next() {
+		var receipt Receipt
+		if err := json.Unmarshal(iter.Value(), &receipt); err != nil {
+			return nil, fmt.Errorf("failed to decode receipt %s: %w", iter.Key(), err)
+		}
+		receipts = append(receipts, &receipt)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(receipts, func(i, j int) bool {
+		return receipts[i].DispersedAt.After(receipts[j].DispersedAt)
+	})
+	return receipts, nil
+}
+
+// UpdateStatus records the status received for the blob in its receipt, along with its cert once it's confirmed. The
+// blobs without a receipt are ignored.
+func (s *ReceiptStore) UpdateStatus(requestID []byte, reply *disperser_rpc.BlobStatusReply) error {
+	receipt, err := s.Get(string(requestID))
+	if errors.Is(err, ErrReceiptNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	receipt.Status = reply.GetStatus().String()
+	receipt.UpdatedAt = time.Now()
+	if reply.GetInfo().GetBlobVerificationProof() != nil {
+		receipt.BlobInfo, err = protojson.Marshal(reply.GetInfo())
+		if err != nil {
+			return err
+		}
+	}
+	return s.Put(receipt)
+}
+
+type recordingDisperserClient struct {
+	client DisperserClient
+	store  *ReceiptStore
+	logger logging.Logger
+}
+
+var _ DisperserClient = (*recordingDisperserClient)(nil)
+
+// NewRecordingDisperserClient returns a DisperserClient which records the receipts of the dispersal requests made
+// through client, and the statuses it receives for them, in the store. Failing to record a receipt is logged, but
+// doesn't fail the request.
+func NewRecordingDisperserClient(client DisperserClient, store *ReceiptStore, logger logging.Logger) DisperserClient {
+	return &recordingDisperserClient{
+		client: client,
+		store:  store,
+		logger: logger.With("component", "RecordingDisperserClient"),
+	}
+}
+
+func (c *recordingDisperserClient) DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	status, requestID, err := c.client.DisperseBlob(ctx, data, quorums)
+	c.recordDispersal("DisperseBlob", data, quorums, status, requestID, err)
+	return status, requestID, err
+}
+
+func (c *recordingDisperserClient) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	status, requestID, err := c.client.DisperseBlobAuthenticated(ctx, data, quorums)
+	c.recordDispersal("DisperseBlobAuthenticated", data, quorums, status, requestID, err)
+	return status, requestID, err
+}
+
+func (c *recordingDisperserClient) DisperseBlobStream(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	status, requestID, err := c.client.DisperseBlobStream(ctx, data, quorums)
+	c.recordDispersal("DisperseBlobStream", data, quorums, status, requestID, err)
+	return status, requestID, err
+}
+
+func (c *recordingDisperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	reply, err := c.client.GetBlobStatus(ctx, requestID)
+	if err == nil {
+		c.recordStatus(requestID, reply)
+	}
+	return reply, err
+}
+
+func (c *recordingDisperserClient) SubscribeBlobStatus(ctx context.Context, requestID []byte, onUpdate func(*disperser_rpc.BlobStatusReply) error) error {
+	return c.client.SubscribeBlobStatus(ctx, requestID, func(reply *disperser_rpc.BlobStatusReply) error {
+		c.recordStatus(requestID, reply)
+		return onUpdate(reply)
+	})
+}
+
+func (c *recordingDisperserClient) recordDispersal(method string, data []byte, quorums []uint8, status *disperser.BlobStatus, requestID []byte, dispersalErr error) {
+	now := time.Now()
+	quorumNumbers := make([]uint32, len(quorums))
+	for i, q := range quorums {
+		quorumNumbers[i] = uint32(q)
+	}
+	receipt := &Receipt{
+		ID:          string(requestID),
+		Method:      method,
+		DispersedAt: now,
+		DataSize:    len(data),
+		Quorums:     quorumNumbers,
+		PayloadHash: disperser.ComputePayloadHash(data),
+		UpdatedAt:   now,
+	}
+	if dispersalErr != nil {
+		receipt.Error = dispersalErr.Error()
+	}
+	if status != nil {
+		receipt.Status = disperser.ToBlobStatusProto(*status).String()
+	}
+	if len(requestID) == 0 {
+		receipt.ID = fmt.Sprintf("rejected-%d", now.UnixNano())
+	}
+	if err := c.store.Put(receipt); err != nil {
+		c.logger.Warn("failed to record the dispersal receipt", "requestID", receipt.ID, "err", err)
+	}
+}
+
+func (c *recordingDisperserClient) recordStatus(requestID []byte, reply *disperser_rpc.BlobStatusReply) {
+	if err := c.store.UpdateStatus(requestID, reply); err != nil {
+		c.logger.Warn("failed to record the blob status", "requestID", string(requestID), "err", err)
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 
 	"github.com/gammazero/workerpool"
@@ -14,6 +17,35 @@ import (
 	"github.com/wealdtech/go-merkletree/keccak256"
 )
 
+var (
+	// ErrInvalidBlobLength is returned when the length claimed by a blob header isn't proven by its length proof
+	ErrInvalidBlobLength = errors.New("invalid blob length proof")
+	// ErrBlobLengthMismatch is returned when the length claimed by a blob header doesn't match the data
+	// reconstructed from the chunks of the blob
+	ErrBlobLengthMismatch = errors.New("blob length mismatch")
+	// ErrChunksNotAssigned is returned when an operator replies with other chunks than the ones assigned to it at the
+	// reference block of the blob
+	ErrChunksNotAssigned = errors.New("chunks not assigned to operator")
+)
+
+// maxRequestsPerOperator is the number of chunk requests GetBlobs sends to an operator at the same time
+const maxRequestsPerOperator = 4
+
+// BlobRequest identifies a blob to retrieve by the fields of its certificate
+type BlobRequest struct {
+	BatchHeaderHash      [32]byte
+	BlobIndex            uint32
+	ReferenceBlockNumber uint
+	BatchRoot            [32]byte
+	QuorumID             core.QuorumID
+}
+
+// BlobResult is the outcome of the retrieval of one of the blobs requested from GetBlobs
+type BlobResult struct {
+	Data []byte
+	Err  error
+}
+
 type RetrievalClient interface {
 	RetrieveBlob(
 		ctx context.Context,
@@ -22,6 +54,20 @@ type RetrievalClient interface {
 		referenceBlockNumber uint,
 		batchRoot [32]byte,
 		quorumID core.QuorumID) ([]byte, error)
+	// RetrievePayload retrieves a blob encoded with codec.EncodePayload, and returns its payload. It checks the
+	// blob length of the blob header is the length of the encoding of the payload, so the blob header identifies the
+	// length of the payload in bytes.
+	RetrievePayload(
+		ctx context.Context,
+		batchHeaderHash [32]byte,
+		blobIndex uint32,
+		referenceBlockNumber uint,
+		batchRoot [32]byte,
+		quorumID core.QuorumID) ([]byte, error)
+	// GetBlobs retrieves many blobs at once, and returns the result of each request in the order of the requests.
+	// The chunk requests of all the blobs are scheduled together and grouped by operator, so that each operator
+	// serves a bounded number of them at a time and the requests of the blobs share the connections to the operators.
+	GetBlobs(ctx context.Context, requests []BlobRequest) []BlobResult
 }
 
 type retrievalClient struct {
@@ -61,10 +107,212 @@ func (r *retrievalClient) RetrieveBlob(
 	referenceBlockNumber uint,
 	batchRoot [32]byte,
 	quorumID core.QuorumID) ([]byte, error) {
-	indexedOperatorState, err := r.indexedChainState.GetIndexedOperatorState(ctx, referenceBlockNumber, []core.QuorumID{quorumID})
+	data, _, err := r.retrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	return data, err
+}
+
+func (r *retrievalClient) RetrievePayload(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, error) {
+	data, blobHeader, err := r.retrieveBlob(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := codec.DecodePayload(data)
 	if err != nil {
 		return nil, err
 	}
+	// Trailing zero symbols don't change the commitment of a blob, so the blob header must claim exactly the length
+	// of the encoded payload
+	if blobHeader.Length != codec.EncodedPayloadLength(len(payload)) {
+		return nil, fmt.Errorf("%w: blob header claims %d symbols, the payload of %d bytes is encoded in %d symbols", ErrBlobLengthMismatch, blobHeader.Length, len(payload), codec.EncodedPayloadLength(len(payload)))
+	}
+	return payload, nil
+}
+
+func (r *retrievalClient) GetBlobs(ctx context.Context, requests []BlobRequest) []BlobResult {
+	// Blobs requested more than once are only retrieved once
+	var blobs []BlobRequest
+	blobIndices := make([]int, len(requests))
+	seen := make(map[BlobRequest]int, len(requests))
+	for i, request := range requests {
+		index, ok := seen[request]
+		if !ok {
+			index = len(blobs)
+			seen[request] = index
+			blobs = append(blobs, request)
+		}
+		blobIndices[i] = index
+	}
+
+	// Get the operator state of each reference block once, for all the quorums requested at that block
+	quorumsByBlock := make(map[uint][]core.QuorumID)
+	for _, blob := range blobs {
+		quorums := quorumsByBlock[blob.ReferenceBlockNumber]
+		if !slices.Contains(quorums, blob.QuorumID) {
+			quorumsByBlock[blob.ReferenceBlockNumber] = append(quorums, blob.QuorumID)
+		}
+	}
+	states := make(map[uint]*core.IndexedOperatorState, len(quorumsByBlock))
+	stateErrs := make(map[uint]error)
+	for blockNumber, quorums := range quorumsByBlock {
+		state, err := r.indexedChainState.GetIndexedOperatorState(ctx, blockNumber, quorums)
+		if err != nil {
+			stateErrs[blockNumber] = err
+			continue
+		}
+		states[blockNumber] = state
+	}
+
+	// Get and verify the blob headers
+	retrievals := make([]*blobRetrieval, len(blobs))
+	errs := make([]error, len(blobs))
+	pool := workerpool.New(r.numConnections)
+	for i, blob := range blobs {
+		if err, ok := stateErrs[blob.ReferenceBlockNumber]; ok {
+			errs[i] = err
+			continue
+		}
+		i, blob := i, blob
+		pool.Submit(func() {
+			retrievals[i], errs[i] = r.prepareRetrieval(ctx, states[blob.ReferenceBlockNumber], blob)
+		})
+	}
+	pool.StopWait()
+
+	// Group the chunk requests of all the blobs by operator
+	blobsByOperator := make(map[core.OperatorID][]int)
+	for i, retrieval := range retrievals {
+		if retrieval == nil {
+			continue
+		}
+		for opID := range retrieval.operators {
+			blobsByOperator[opID] = append(blobsByOperator[opID], i)
+		}
+	}
+
+	// Each operator serves its requests in turn, with up to maxRequestsPerOperator of them in flight, while the total
+	// number of requests in flight is bounded by the number of connections of the client
+	replies := make([][]RetrievedChunks, len(blobs))
+	var repliesMu sync.Mutex
+	inFlight := make(chan struct{}, r.numConnections)
+	var wg sync.WaitGroup
+	for opID, blobIndices := range blobsByOperator {
+		queue := make(chan int, len(blobIndices))
+		for _, i := range blobIndices {
+			queue <- i
+		}
+		close(queue)
+
+		for w := 0; w < min(maxRequestsPerOperator, len(blobIndices)); w++ {
+			wg.Add(1)
+			go func(opID core.OperatorID) {
+				defer wg.Done()
+				for i := range queue {
+					blob := blobs[i]
+					chunksChan := make(chan RetrievedChunks, 1)
+					inFlight <- struct{}{}
+					r.nodeClient.GetChunks(ctx, opID, retrievals[i].operators[opID], blob.BatchHeaderHash, blob.BlobIndex, blob.QuorumID, chunksChan)
+					<-inFlight
+					reply := <-chunksChan
+
+					repliesMu.Lock()
+					replies[i] = append(replies[i], reply)
+					repliesMu.Unlock()
+				}
+			}(opID)
+		}
+	}
+	wg.Wait()
+
+	// Reconstruct the blobs from the chunks
+	data := make([][]byte, len(blobs))
+	pool = workerpool.New(r.numConnections)
+	for i, retrieval := range retrievals {
+		if retrieval == nil {
+			continue
+		}
+		i, retrieval := i, retrieval
+		pool.Submit(func() {
+			data[i], errs[i] = r.decodeBlob(retrieval, replies[i])
+		})
+	}
+	pool.StopWait()
+
+	results := make([]BlobResult, len(requests))
+	for i, index := range blobIndices {
+		results[i] = BlobResult{Data: data[index], Err: errs[index]}
+	}
+	return results
+}
+
+// retrieveBlob retrieves and verifies the blob, and returns it along with its blob header
+func (r *retrievalClient) retrieveBlob(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID) ([]byte, *core.BlobHeader, error) {
+	indexedOperatorState, err := r.indexedChainState.GetIndexedOperatorState(ctx, referenceBlockNumber, []core.QuorumID{quorumID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	retrieval, err := r.prepareRetrieval(ctx, indexedOperatorState, BlobRequest{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            blobIndex,
+		ReferenceBlockNumber: referenceBlockNumber,
+		BatchRoot:            batchRoot,
+		QuorumID:             quorumID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Fetch chunks from all operators
+	chunksChan := make(chan RetrievedChunks, len(retrieval.operators))
+	pool := workerpool.New(r.numConnections)
+	for opID, opInfo := range retrieval.operators {
+		opID := opID
+		opInfo := opInfo
+		pool.Submit(func() {
+			r.nodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+		})
+	}
+
+	// TODO(ian-shim): if we gathered enough chunks, cancel remaining RPC calls
+	replies := make([]RetrievedChunks, len(retrieval.operators))
+	for i := range replies {
+		replies[i] = <-chunksChan
+	}
+
+	data, err := r.decodeBlob(retrieval, replies)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, retrieval.blobHeader, nil
+}
+
+// blobRetrieval is a blob whose blob header has been verified, along with what is needed to fetch and verify its
+// chunks
+type blobRetrieval struct {
+	blobHeader     *core.BlobHeader
+	operators      map[core.OperatorID]*core.IndexedOperatorInfo
+	assignments    map[core.OperatorID]core.Assignment
+	encodingParams encoding.EncodingParams
+}
+
+// prepareRetrieval gets the blob header of the requested blob from any operator of the quorum and verifies it, and
+// computes the assignments of the chunks of the blob
+func (r *retrievalClient) prepareRetrieval(ctx context.Context, indexedOperatorState *core.IndexedOperatorState, request BlobRequest) (*blobRetrieval, error) {
+	batchHeaderHash, blobIndex, batchRoot, quorumID := request.BatchHeaderHash, request.BlobIndex, request.BatchRoot, request.QuorumID
+
 	operators, ok := indexedOperatorState.Operators[quorumID]
 	if !ok {
 		return nil, fmt.Errorf("no quorum with ID: %d", quorumID)
@@ -74,6 +322,7 @@ func (r *retrievalClient) RetrieveBlob(
 	var blobHeader *core.BlobHeader
 	var proof *merkletree.Proof
 	var proofVerified bool
+	var err error
 	for opID := range operators {
 		opInfo := indexedOperatorState.IndexedOperators[opID]
 		blobHeader, proof, err = r.nodeClient.GetBlobHeader(ctx, opInfo.Socket, batchHeaderHash, blobIndex)
@@ -117,9 +366,12 @@ func (r *retrievalClient) RetrieveBlob(
 	}
 
 	// Validate the blob length
+	if blobHeader.Length == 0 {
+		return nil, fmt.Errorf("%w: blob header claims an empty blob", ErrBlobLengthMismatch)
+	}
 	err = r.verifier.VerifyBlobLength(blobHeader.BlobCommitments)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBlobLength, err)
 	}
 
 	// Validate the commitments are equivalent
@@ -129,39 +381,52 @@ func (r *retrievalClient) RetrieveBlob(
 		return nil, err
 	}
 
+	// Validate the chunks of the quorum can hold the claimed length
+	ok, err = r.assignmentCoordinator.ValidateChunkLength(indexedOperatorState.OperatorState, blobHeader.Length, quorumHeader)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("%w: chunk length %d is invalid for blob length %d: %v", ErrBlobLengthMismatch, quorumHeader.ChunkLength, blobHeader.Length, err)
+	}
+
 	assignments, info, err := r.assignmentCoordinator.GetAssignments(indexedOperatorState.OperatorState, blobHeader.Length, quorumHeader)
 	if err != nil {
 		return nil, errors.New("failed to get assignments")
 	}
 
-	// Fetch chunks from all operators
-	chunksChan := make(chan RetrievedChunks, len(operators))
-	pool := workerpool.New(r.numConnections)
+	quorumOperators := make(map[core.OperatorID]*core.IndexedOperatorInfo, len(operators))
 	for opID := range operators {
-		opID := opID
-		opInfo := indexedOperatorState.IndexedOperators[opID]
-		pool.Submit(func() {
-			r.nodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
-		})
+		quorumOperators[opID] = indexedOperatorState.IndexedOperators[opID]
 	}
 
-	encodingParams := encoding.ParamsFromMins(quorumHeader.ChunkLength, info.TotalChunks)
+	return &blobRetrieval{
+		blobHeader:     blobHeader,
+		operators:      quorumOperators,
+		assignments:    assignments,
+		encodingParams: encoding.ParamsFromMins(quorumHeader.ChunkLength, info.TotalChunks),
+	}, nil
+}
+
+// decodeBlob verifies the chunks the operators replied with, and reconstructs the blob from the valid ones
+func (r *retrievalClient) decodeBlob(retrieval *blobRetrieval, replies []RetrievedChunks) ([]byte, error) {
+	blobHeader := retrieval.blobHeader
 
 	var chunks []*encoding.Frame
 	var indices []encoding.ChunkNumber
-	// TODO(ian-shim): if we gathered enough chunks, cancel remaining RPC calls
-	for i := 0; i < len(operators); i++ {
-		reply := <-chunksChan
+	for _, reply := range replies {
 		if reply.Err != nil {
 			r.logger.Error("failed to get chunks from operator", "operator", reply.OperatorID, "err", reply.Err)
 			continue
 		}
-		assignment, ok := assignments[reply.OperatorID]
+		assignment, ok := retrieval.assignments[reply.OperatorID]
 		if !ok {
 			return nil, fmt.Errorf("no assignment to operator %v", reply.OperatorID)
 		}
 
-		err = r.verifier.VerifyFrames(reply.Chunks, assignment.GetIndices(), blobHeader.BlobCommitments, encodingParams)
+		err := verifyAssignedChunks(reply.Chunks, assignment, retrieval.encodingParams)
+		if err != nil {
+			r.logger.Error("rejected chunks from operator", "operator", reply.OperatorID, "err", err)
+			continue
+		}
+		err = r.verifier.VerifyFrames(reply.Chunks, assignment.GetIndices(), blobHeader.BlobCommitments, retrieval.encodingParams)
 		if err != nil {
 			r.logger.Error("failed to verify chunks from operator", "operator", reply.OperatorID, "err", err)
 			continue
@@ -173,5 +438,32 @@ func (r *retrievalClient) RetrieveBlob(
 		indices = append(indices, assignment.GetIndices()...)
 	}
 
-	return r.verifier.Decode(chunks, indices, encodingParams, uint64(blobHeader.Length)*encoding.BYTES_PER_SYMBOL)
+	blobSize := uint64(blobHeader.Length) * encoding.BYTES_PER_SYMBOL
+	data, err := r.verifier.Decode(chunks, indices, retrieval.encodingParams, blobSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// The data is padded to a whole number of symbols, so the reconstructed data must be exactly the claimed length
+	if uint64(len(data)) != blobSize {
+		return nil, fmt.Errorf("%w: reconstructed %d bytes, blob header claims %d symbols", ErrBlobLengthMismatch, len(data), blobHeader.Length)
+	}
+	return data, nil
+}
+
+// verifyAssignedChunks checks an operator replied with exactly the chunks of its assignment, which is computed locally
+// from the operator state at the reference block of the blob. The chunks of a reply are identified by their position,
+// so each of them is then verified against the proof of its assigned index, and an operator serving the chunks
+// assigned to other operators is rejected rather than credited with them. The chunks must also be well-formed for the
+// encoding params, which is checked before any of them is verified.
+func verifyAssignedChunks(chunks []*encoding.Frame, assignment core.Assignment, params encoding.EncodingParams) error {
+	if len(chunks) != int(assignment.NumChunks) {
+		return fmt.Errorf("%w: got %d chunks, operator is assigned %d chunks starting at index %d", ErrChunksNotAssigned, len(chunks), assignment.NumChunks, assignment.StartIndex)
+	}
+	for i, chunk := range chunks {
+		if err := chunk.Validate(params); err != nil {
+			return fmt.Errorf("invalid chunk %d: %w", i, err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,109 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding/split"
+)
+
+// SplitBlobClient disperses blobs too large for the SRS loaded by the disperser as several parts that each fit
+// within it, and retrieves them back from the manifest of their parts.
+type SplitBlobClient interface {
+	// DisperseBlob splits the data into parts, disperses them, and waits for all of them to be confirmed. The
+	// returned manifest locates the parts and can be stored, or dispersed as a blob itself, to retrieve the data.
+	DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (*split.Manifest, error)
+	// RetrieveBlob retrieves the parts of the manifest and reassembles the data
+	RetrieveBlob(ctx context.Context, manifest *split.Manifest, quorumID core.QuorumID) ([]byte, error)
+}
+
+type splitBlobClient struct {
+	disperserClient DisperserClient
+	retrievalClient RetrievalClient
+	maxPartSize     uint64
+	pollInterval    time.Duration
+}
+
+var _ SplitBlobClient = (*splitBlobClient)(nil)
+
+// NewSplitBlobClient returns a SplitBlobClient splitting blobs into parts whose polynomials fit within
+// srsNumberToLoad SRS points, and polling the status of the dispersed parts every pollInterval.
+func NewSplitBlobClient(disperserClient DisperserClient, retrievalClient RetrievalClient, srsNumberToLoad uint64, pollInterval time.Duration) SplitBlobClient {
+	return &splitBlobClient{
+		disperserClient: disperserClient,
+		retrievalClient: retrievalClient,
+		maxPartSize:     split.MaxPartSize(srsNumberToLoad),
+		pollInterval:    pollInterval,
+	}
+}
+
+func (c *splitBlobClient) DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (*split.Manifest, error) {
+	parts, manifest, err := split.Split(data, c.maxPartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	requestIDs := make([][]byte, len(parts))
+	for i, part := range parts {
+		_, requestIDs[i], err = c.disperserClient.DisperseBlob(ctx, part, quorums)
+		if err != nil {
+			return nil, fmt.Errorf("failed to disperse part %d: %w", i, err)
+		}
+	}
+
+	for i, requestID := range requestIDs {
+		info, err := c.waitForConfirmation(ctx, requestID)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", i, err)
+		}
+
+		proof := info.GetBlobVerificationProof()
+		batchHeader := proof.GetBatchMetadata().GetBatchHeader()
+		part := &manifest.Parts[i]
+		copy(part.BatchHeaderHash[:], proof.GetBatchMetadata().GetBatchHeaderHash())
+		part.BlobIndex = proof.GetBlobIndex()
+		part.ReferenceBlockNumber = batchHeader.GetReferenceBlockNumber()
+		copy(part.BatchRoot[:], batchHeader.GetBatchRoot())
+	}
+	return manifest, nil
+}
+
+func (c *splitBlobClient) waitForConfirmation(ctx context.Context, requestID []byte) (*disperser_rpc.BlobInfo, error) {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		reply, err := c.disperserClient.GetBlobStatus(ctx, requestID)
+		if err != nil {
+			return nil, err
+		}
+		switch reply.GetStatus() {
+		case disperser_rpc.BlobStatus_CONFIRMED, disperser_rpc.BlobStatus_FINALIZED:
+			return reply.GetInfo(), nil
+		case disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+			return nil, fmt.Errorf("dispersal failed with status %s", reply.GetStatus())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.New("timed out waiting for the dispersal to be confirmed")
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *splitBlobClient) RetrieveBlob(ctx context.Context, manifest *split.Manifest, quorumID core.QuorumID) ([]byte, error) {
+	parts := make([][]byte, len(manifest.Parts))
+	for i, part := range manifest.Parts {
+		data, err := c.retrievalClient.RetrieveBlob(ctx, part.BatchHeaderHash, part.BlobIndex, uint(part.ReferenceBlockNumber), part.BatchRoot, quorumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve part %d: %w", i, err)
+		}
+		parts[i] = data
+	}
+	return split.Join(manifest, parts)
+}
@@ -0,0 +1,125 @@
+package retriever_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFakeDataAPI serves the batches with IDs from 0 to numBatches-1 like the data API
+func newFakeDataAPI(t *testing.T, numBatches uint32, numRequests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numRequests.Add(1)
+		if r.URL.Path != "/api/v1/feed/batches" {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(dataapi.ErrorResponse{Error: "not found"})
+			return
+		}
+		_, paginated := r.URL.Query()["cursor"]
+		assert.True(t, paginated)
+
+		start := uint32(0)
+		if encoded := r.URL.Query().Get("cursor"); encoded != "" {
+			cursor, err := dataapi.DecodeCursor(encoded)
+			assert.NoError(t, err)
+			start = cursor.BatchId + 1
+		}
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		assert.NoError(t, err)
+
+		response := dataapi.BatchesResponse{Data: []*dataapi.BatchResponse{}}
+		for id := start; id < numBatches && len(response.Data) < limit; id++ {
+			response.Data = append(response.Data, &dataapi.BatchResponse{BatchId: id})
+		}
+		response.Meta.Size = len(response.Data)
+		if len(response.Data) > 0 {
+			response.Meta.NextCursor = dataapi.NewBatchCursor(response.Data[len(response.Data)-1].BatchId).Encode()
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestDataAPIClientIterateBatches(t *testing.T) {
+	ctx := context.Background()
+	var numRequests atomic.Int32
+	server := newFakeDataAPI(t, 6, &numRequests)
+	defer server.Close()
+	client := clients.NewDataAPIClient(server.URL+"/api/v1/", time.Second)
+
+	// stop the iteration after the third batch
+	var ids []uint32
+	it := clients.IterateBatches(client, "", 2)
+	for it.Next(ctx) {
+		ids = append(ids, it.Item().BatchId)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []uint32{0, 1, 2}, ids)
+
+	// the iteration is resumed after the last processed batch
+	it = clients.IterateBatches(client, it.Cursor(), 2)
+	for it.Next(ctx) {
+		ids = append(ids, it.Item().BatchId)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []uint32{0, 1, 2, 3, 4, 5}, ids)
+	// the last page is short, so the iteration ends without fetching an empty page
+	assert.Equal(t, int32(4), numRequests.Load())
+	assert.Equal(t, dataapi.NewBatchCursor(5).Encode(), it.Cursor())
+
+	// the cursor is kept when there's nothing new
+	cursor := it.Cursor()
+	it = clients.IterateBatches(client, cursor, 2)
+	assert.False(t, it.Next(ctx))
+	assert.NoError(t, it.Err())
+	assert.Equal(t, cursor, it.Cursor())
+
+	// the errors of the data API are returned
+	_, err := client.FetchBlobs(ctx, "", 2)
+	assert.ErrorContains(t, err, "failed with status 404: not found")
+}
+
+func TestDataAPIClientIterateBlobs(t *testing.T) {
+	ctx := context.Background()
+	client := clientsmock.NewMockDataAPIClient()
+
+	first := dataapi.BlobsResponse{Data: []*dataapi.BlobMetadataResponse{
+		{BatchId: 1, BlobIndex: 0},
+		{BatchId: 1, BlobIndex: 1},
+	}}
+	second := dataapi.BlobsResponse{Data: []*dataapi.BlobMetadataResponse{
+		{BatchId: 3, BlobIndex: 0},
+		{BatchId: 3, BlobIndex: 1},
+	}}
+	client.On("FetchBlobs", "", 2).Return(&first, nil).Once()
+	client.On("FetchBlobs", dataapi.NewBlobCursor(1, 1).Encode(), 2).Return(&second, nil).Once()
+	client.On("FetchBlobs", dataapi.NewBlobCursor(3, 1).Encode(), 2).Return(nil, assert.AnError).Once()
+
+	it := clients.IterateBlobs(client, "", 2)
+	var cursors []string
+	for it.Next(ctx) {
+		cursors = append(cursors, it.Cursor())
+	}
+	assert.ErrorIs(t, it.Err(), assert.AnError)
+	assert.Equal(t, []string{
+		dataapi.NewBlobCursor(1, 0).Encode(),
+		dataapi.NewBlobCursor(1, 1).Encode(),
+		dataapi.NewBlobCursor(3, 0).Encode(),
+		dataapi.NewBlobCursor(3, 1).Encode(),
+	}, cursors)
+	assert.False(t, it.Next(ctx))
+	client.AssertExpectations(t)
+	client.AssertNumberOfCalls(t, "FetchBlobs", 3)
+}
@@ -0,0 +1,284 @@
+package retriever_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/disperser"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func startDisperserServer(t *testing.T, config clientsmock.DisperserServerConfig) *clientsmock.DisperserServer {
+	server := clientsmock.NewDisperserServer(config)
+	require.NoError(t, server.Start())
+	t.Cleanup(server.Stop)
+	return server
+}
+
+func TestMockDisperserServer(t *testing.T) {
+	server := startDisperserServer(t, clientsmock.DisperserServerConfig{
+		ConfirmationDelay: 200 * time.Millisecond,
+		FinalizationDelay: 200 * time.Millisecond,
+	})
+	client := clients.NewDisperserClient(clients.NewConfig("localhost", server.Port(), 5*time.Second, false), nil)
+	ctx := context.Background()
+	data := codec.ConvertByPaddingEmptyByte([]byte("hello EigenDA"))
+
+	blobStatus, requestID, err := client.DisperseBlob(ctx, data, []uint8{1, 0})
+	require.NoError(t, err)
+	assert.Equal(t, disperser.Processing, *blobStatus)
+	reply, err := client.GetBlobStatus(ctx, requestID)
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_PROCESSING, reply.GetStatus())
+	assert.Nil(t, reply.GetInfo())
+
+	require.Eventually(t, func() bool {
+		reply, err = client.GetBlobStatus(ctx, requestID)
+		require.NoError(t, err)
+		return reply.GetStatus() == disperser_rpc.BlobStatus_CONFIRMED
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// The certificate is consistent: the batch header hashes to the batch header hash
+	info := reply.GetInfo()
+	assert.Equal(t, uint32(encoding.GetBlobLength(uint(len(data)))), info.GetBlobHeader().GetDataLength())
+	assert.Equal(t, disperser.ComputePayloadHash(data), info.GetBlobHeader().GetPayloadHash())
+	assert.Equal(t, []byte{0, 1}, info.GetBlobVerificationProof().GetBatchMetadata().GetBatchHeader().GetQuorumNumbers())
+	batchHeader := info.GetBlobVerificationProof().GetBatchMetadata().GetBatchHeader()
+	batchHeaderHash, err := core.BatchHeader{
+		BatchRoot:            [32]byte(batchHeader.GetBatchRoot()),
+		ReferenceBlockNumber: uint(batchHeader.GetReferenceBlockNumber()),
+	}.GetBatchHeaderHash()
+	require.NoError(t, err)
+	assert.Equal(t, batchHeaderHash[:], info.GetBlobVerificationProof().GetBatchMetadata().GetBatchHeaderHash())
+
+	// The certificates are deterministic
+	other := startDisperserServer(t, clientsmock.DisperserServerConfig{})
+	otherClient := clients.NewDisperserClient(clients.NewConfig("localhost", other.Port(), 5*time.Second, false), nil)
+	_, otherRequestID, err := otherClient.DisperseBlob(ctx, data, []uint8{0, 1})
+	require.NoError(t, err)
+	assert.Equal(t, requestID, otherRequestID)
+	otherReply, err := otherClient.GetBlobStatus(ctx, otherRequestID)
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_FINALIZED, otherReply.GetStatus())
+	assert.Equal(t, info.String(), otherReply.GetInfo().String())
+
+	require.Eventually(t, func() bool {
+		reply, err = client.GetBlobStatus(ctx, requestID)
+		require.NoError(t, err)
+		return reply.GetStatus() == disperser_rpc.BlobStatus_FINALIZED
+	}, 5*time.Second, 20*time.Millisecond)
+
+	// The blob can be retrieved from the disperser
+	conn, err := grpc.Dial("localhost:"+server.Port(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	retrieved, err := disperser_rpc.NewDisperserClient(conn).RetrieveBlob(ctx, &disperser_rpc.RetrieveBlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data, retrieved.GetData())
+
+	require.NoError(t, server.SetBlobStatus(requestID, disperser_rpc.BlobStatus_FAILED))
+	reply, err = client.GetBlobStatus(ctx, requestID)
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_FAILED, reply.GetStatus())
+}
+
+func TestMockDisperserServerAuthenticated(t *testing.T) {
+	server := startDisperserServer(t, clientsmock.DisperserServerConfig{})
+	signer := auth.NewSigner("0x73ae7e3a40b59caacb1cda8fa04f4e7fa5bb2b37101f9f3506290c201f57cf7")
+	client := clients.NewDisperserClient(clients.NewConfig("localhost", server.Port(), 5*time.Second, false), signer)
+	ctx := context.Background()
+
+	blobStatus, requestID, err := client.DisperseBlobAuthenticated(ctx, codec.ConvertByPaddingEmptyByte([]byte("hello EigenDA")), nil)
+	require.NoError(t, err)
+	assert.Equal(t, disperser.Processing, *blobStatus)
+	reply, err := client.GetBlobStatus(ctx, requestID)
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_FINALIZED, reply.GetStatus())
+	assert.Equal(t, []byte{0, 1}, reply.GetInfo().GetBlobVerificationProof().GetBatchMetadata().GetBatchHeader().GetQuorumNumbers())
+}
+
+func TestMockDisperserServerFaults(t *testing.T) {
+	server := startDisperserServer(t, clientsmock.DisperserServerConfig{})
+	client := clients.NewDisperserClient(clients.NewConfig("localhost", server.Port(), 100*time.Millisecond, false), nil)
+	ctx := context.Background()
+	data := codec.ConvertByPaddingEmptyByte([]byte("hello EigenDA"))
+
+	server.InjectError("DisperseBlob", api.NewResourceExhaustedError("rate limited"), 1)
+	_, _, err := client.DisperseBlob(ctx, data, nil)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, _, err = client.DisperseBlob(ctx, data, nil)
+	assert.NoError(t, err)
+
+	server.SetLatency(time.Second)
+	_, _, err = client.DisperseBlob(ctx, data, nil)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	server.SetLatency(0)
+
+	_, _, err = client.DisperseBlob(ctx, data, []uint8{0, 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestMockNodeServer(t *testing.T) {
+	p, _, err := makeTestComponents()
+	require.NoError(t, err)
+	server, err := clientsmock.NewNodeServer(clientsmock.NodeServerConfig{})
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer server.Stop()
+	ctx := context.Background()
+
+	data := codec.ConvertByPaddingEmptyByte([]byte("hello EigenDA"))
+	commitments, chunks, err := p.EncodeAndProve(data, encoding.ParamsFromMins(1, 4))
+	require.NoError(t, err)
+	blobMessage := &core.BlobMessage{
+		BlobHeader: &core.BlobHeader{
+			BlobCommitments: commitments,
+			QuorumInfos: []*core.BlobQuorumInfo{{
+				SecurityParam: core.SecurityParam{
+					QuorumID:              0,
+					AdversaryThreshold:    33,
+					ConfirmationThreshold: 55,
+				},
+				ChunkLength: 1,
+			}},
+		},
+		Bundles: core.Bundles{0: chunks},
+	}
+	batchHeader := &core.BatchHeader{ReferenceBlockNumber: 10}
+	_, err = batchHeader.SetBatchRoot([]*core.BlobHeader{blobMessage.BlobHeader})
+	require.NoError(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	require.NoError(t, err)
+
+	// The node signs the batches it stores
+	request, _, err := dispatcher.GetStoreChunksRequest([]*core.BlobMessage{blobMessage}, batchHeader, encoding.GobChunkEncodingFormat)
+	require.NoError(t, err)
+	conn, err := grpc.Dial(server.Socket().GetDispersalSocket(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	reply, err := node.NewDispersalClient(conn).StoreChunks(ctx, request)
+	require.NoError(t, err)
+	point, err := new(core.G1Point).Deserialize(reply.GetSignature())
+	require.NoError(t, err)
+	sig := &core.Signature{G1Point: point}
+	assert.True(t, sig.Verify(server.KeyPair().GetPubKeyG2(), batchHeaderHash))
+
+	// The blob headers and chunks can be retrieved
+	nodeClient := clients.NewNodeClient(5 * time.Second)
+	blobHeader, proof, err := nodeClient.GetBlobHeader(ctx, server.Socket().String(), batchHeaderHash, 0)
+	require.NoError(t, err)
+	blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+	require.NoError(t, err)
+	ok, err := merkletree.VerifyProofUsing(blobHeaderHash[:], false, proof, [][]byte{batchHeader.BatchRoot[:]}, keccak256.New())
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	operatorInfo := &core.IndexedOperatorInfo{Socket: server.Socket().String()}
+	chunksChan := make(chan clients.RetrievedChunks, 1)
+	nodeClient.GetChunks(ctx, core.OperatorID{}, operatorInfo, batchHeaderHash, 0, 0, chunksChan)
+	retrieved := <-chunksChan
+	require.NoError(t, retrieved.Err)
+	assert.Equal(t, chunks, retrieved.Chunks)
+
+	server.InjectError("RetrieveChunks", api.NewInternalError("node is down"), -1)
+	nodeClient.GetChunks(ctx, core.OperatorID{}, operatorInfo, batchHeaderHash, 0, 0, chunksChan)
+	retrieved = <-chunksChan
+	assert.Equal(t, codes.Internal, status.Code(retrieved.Err))
+	server.ClearErrors()
+	nodeClient.GetChunks(ctx, core.OperatorID{}, operatorInfo, batchHeaderHash, 0, 0, chunksChan)
+	retrieved = <-chunksChan
+	assert.NoError(t, retrieved.Err)
+}
+
+func TestMockDisperserServerStream(t *testing.T) {
+	server := startDisperserServer(t, clientsmock.DisperserServerConfig{})
+	client := clients.NewDisperserClient(clients.NewConfig("localhost", server.Port(), 5*time.Second, false), nil)
+	ctx := context.Background()
+
+	// The blob spans several data chunks
+	data := codec.ConvertByPaddingEmptyByte(make([]byte, 600*1024))
+	blobStatus, requestID, err := client.DisperseBlobStream(ctx, data, []uint8{0})
+	require.NoError(t, err)
+	assert.Equal(t, disperser.Processing, *blobStatus)
+
+	// The request ID is the one of the blob dispersed in a single message
+	other := startDisperserServer(t, clientsmock.DisperserServerConfig{})
+	otherClient := clients.NewDisperserClient(clients.NewConfig("localhost", other.Port(), 5*time.Second, false), nil)
+	_, otherRequestID, err := otherClient.DisperseBlob(ctx, data, []uint8{0})
+	require.NoError(t, err)
+	assert.Equal(t, otherRequestID, requestID)
+
+	reply, err := client.GetBlobStatus(ctx, requestID)
+	require.NoError(t, err)
+	assert.Equal(t, disperser.ComputePayloadHash(data), reply.GetInfo().GetBlobHeader().GetPayloadHash())
+}
+
+func TestMockDisperserServerSubscribe(t *testing.T) {
+	server := startDisperserServer(t, clientsmock.DisperserServerConfig{
+		ConfirmationDelay: 100 * time.Millisecond,
+		FinalizationDelay: 100 * time.Millisecond,
+	})
+	client := clients.NewDisperserClient(clients.NewConfig("localhost", server.Port(), 5*time.Second, false), nil)
+	ctx := context.Background()
+
+	_, requestID, err := client.DisperseBlob(ctx, codec.ConvertByPaddingEmptyByte([]byte("hello EigenDA")), nil)
+	require.NoError(t, err)
+
+	// The subscription is retried while the disperser is unavailable
+	server.InjectError("SubscribeBlobStatus", api.NewUnavailableError("restarting", time.Second), 1)
+	statuses := make([]disperser_rpc.BlobStatus, 0)
+	err = client.SubscribeBlobStatus(ctx, requestID, func(reply *disperser_rpc.BlobStatusReply) error {
+		statuses = append(statuses, reply.GetStatus())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []disperser_rpc.BlobStatus{
+		disperser_rpc.BlobStatus_PROCESSING,
+		disperser_rpc.BlobStatus_CONFIRMED,
+		disperser_rpc.BlobStatus_FINALIZED,
+	}, statuses)
+
+	err = client.SubscribeBlobStatus(ctx, []byte("unknown"), func(*disperser_rpc.BlobStatusReply) error { return nil })
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMockDisperserServerSigned(t *testing.T) {
+	server := startDisperserServer(t, clientsmock.DisperserServerConfig{})
+	config := clients.NewConfig("localhost", server.Port(), 5*time.Second, false)
+	ctx := context.Background()
+	data := codec.ConvertByPaddingEmptyByte([]byte("hello EigenDA"))
+
+	signer := auth.NewSigner("0x73ae7e3a40b59caacb1cda8fa04f4e7fa5bb2b37101f9f3506290c201f57cf7")
+	client := clients.NewSigningDisperserClient(config, signer.(core.RequestSigner))
+	_, _, err := client.DisperseBlob(ctx, data, nil)
+	assert.NoError(t, err)
+	_, _, err = client.DisperseBlobStream(ctx, data, nil)
+	assert.NoError(t, err)
+
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	client = clients.NewSigningDisperserClient(config, auth.NewBLSSigner(keyPair))
+	_, _, err = client.DisperseBlob(ctx, data, nil)
+	assert.NoError(t, err)
+}
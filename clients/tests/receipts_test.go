@@ -0,0 +1,66 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRecordingDisperserClient(t *testing.T) {
+	ctx := context.Background()
+	store, err := clients.NewReceiptStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	disperserClient := clientsmock.NewMockDisperserClient()
+	status := disperser.Processing
+	disperserClient.On("DisperseBlob", []byte("first"), []uint8{0}).Return(&status, []byte("blob0"), nil).Once()
+	disperserClient.On("DisperseBlobStream", []byte("second"), []uint8{1}).Return(nil, nil, errors.New("rate limited")).Once()
+	disperserClient.On("GetBlobStatus", []byte("blob0")).Return(confirmedReply(1, 3), nil).Once()
+	client := clients.NewRecordingDisperserClient(disperserClient, store, logging.NewNoopLogger())
+
+	_, requestID, err := client.DisperseBlob(ctx, []byte("first"), []uint8{0})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("blob0"), requestID)
+	_, _, err = client.DisperseBlobStream(ctx, []byte("second"), []uint8{1})
+	assert.ErrorContains(t, err, "rate limited")
+
+	receipt, err := store.Get("blob0")
+	require.NoError(t, err)
+	assert.Equal(t, "DisperseBlob", receipt.Method)
+	assert.Equal(t, 5, receipt.DataSize)
+	assert.Equal(t, []uint32{0}, receipt.Quorums)
+	assert.Equal(t, disperser.ComputePayloadHash([]byte("first")), []byte(receipt.PayloadHash))
+	assert.Equal(t, disperser_rpc.BlobStatus_PROCESSING.String(), receipt.Status)
+	assert.Empty(t, receipt.BlobInfo)
+
+	// The cert of the blob is recorded once it's confirmed
+	_, err = client.GetBlobStatus(ctx, []byte("blob0"))
+	require.NoError(t, err)
+	receipt, err = store.Get("blob0")
+	require.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_CONFIRMED.String(), receipt.Status)
+	var info disperser_rpc.BlobInfo
+	require.NoError(t, protojson.Unmarshal(receipt.BlobInfo, &info))
+	assert.Equal(t, uint32(3), info.GetBlobVerificationProof().GetBlobIndex())
+
+	// The rejected requests are recorded too, the most recent first
+	receipts, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+	assert.Equal(t, "DisperseBlobStream", receipts[0].Method)
+	assert.Equal(t, "rate limited", receipts[0].Error)
+	assert.Equal(t, "blob0", receipts[1].ID)
+
+	_, err = store.Get("unknown")
+	assert.ErrorIs(t, err, clients.ErrReceiptNotFound)
+}
@@ -69,6 +69,11 @@ var (
 )
 
 func setup(t *testing.T) {
+	setupWithData(t, codec.ConvertByPaddingEmptyByte(gettysburgAddressBytes))
+}
+
+// setupWithData sets up the operators to serve the chunks of a blob of the data
+func setupWithData(t *testing.T, data []byte) {
 
 	var err error
 	chainState, err = coremock.MakeChainDataMock(map[uint8]int{
@@ -129,7 +134,7 @@ func setup(t *testing.T) {
 		RequestHeader: core.BlobRequestHeader{
 			SecurityParams: securityParams,
 		},
-		Data: codec.ConvertByPaddingEmptyByte(gettysburgAddressBytes),
+		Data: data,
 	}
 	operatorState, err = indexedChainState.GetOperatorState(context.Background(), (0), []core.QuorumID{quorumID})
 	if err != nil {
@@ -281,3 +286,136 @@ func TestValidBlobHeader(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, restored[:len(gettysburgAddressBytes)])
 
 }
+
+func TestBlobHeaderLengthMismatch(t *testing.T) {
+
+	setup(t)
+
+	// Claim a longer blob than the length proof proves
+	tampered := *blobHeader
+	tampered.Length = blobHeader.Length * 2
+	blobHeaderHash, err := tampered.GetBlobHeaderHash()
+	assert.NoError(t, err)
+	tree, err := merkletree.NewTree(merkletree.WithData([][]byte{blobHeaderHash[:]}), merkletree.WithHashType(keccak256.New()))
+	assert.NoError(t, err)
+	copy(batchRoot[:], tree.Root())
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&tampered, [][]byte{}, uint64(0), nil).Once()
+
+	operatorPubKeys := mustMakeOpertatorPubKeysPair(t)
+	operatorSocket := musMakeOperatorSocket(t)
+
+	indexer.On("GetObject", mock.Anything, 0).Return(operatorPubKeys, nil).Once()
+	indexer.On("GetObject", mock.Anything, 1).Return(operatorSocket, nil).Once()
+
+	_, err = retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrInvalidBlobLength)
+
+}
+
+func TestRetrievePayload(t *testing.T) {
+
+	data, err := codec.EncodePayload(gettysburgAddressBytes)
+	assert.NoError(t, err)
+	setupWithData(t, data)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	indexer.On("GetObject", mock.Anything, 0).Return(mustMakeOpertatorPubKeysPair(t), nil).Once()
+	indexer.On("GetObject", mock.Anything, 1).Return(musMakeOperatorSocket(t), nil).Once()
+
+	payload, err := retrievalClient.RetrievePayload(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, payload)
+}
+
+func TestRetrievePayloadTrailingZeroSymbols(t *testing.T) {
+
+	// The trailing zero symbol doesn't change the commitment, but the blob header claims one more symbol than the
+	// encoding of the payload
+	data, err := codec.EncodePayload(gettysburgAddressBytes)
+	assert.NoError(t, err)
+	setupWithData(t, append(data, make([]byte, encoding.BYTES_PER_SYMBOL)...))
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	indexer.On("GetObject", mock.Anything, 0).Return(mustMakeOpertatorPubKeysPair(t), nil).Once()
+	indexer.On("GetObject", mock.Anything, 1).Return(musMakeOperatorSocket(t), nil).Once()
+
+	_, err = retrievalClient.RetrievePayload(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.ErrorIs(t, err, clients.ErrBlobLengthMismatch)
+}
+
+func TestGetBlobs(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	indexer.On("GetObject", mock.Anything, 0).Return(mustMakeOpertatorPubKeysPair(t), nil).Once()
+	indexer.On("GetObject", mock.Anything, 1).Return(musMakeOperatorSocket(t), nil).Once()
+
+	request := clients.BlobRequest{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            0,
+		ReferenceBlockNumber: 0,
+		BatchRoot:            batchRoot,
+		QuorumID:             0,
+	}
+	invalidRequest := request
+	invalidRequest.BatchRoot = [32]byte{1}
+
+	results := retrievalClient.GetBlobs(context.Background(), []clients.BlobRequest{request, invalidRequest, request})
+	assert.Len(t, results, 3)
+
+	for _, i := range []int{0, 2} {
+		assert.NoError(t, results[i].Err)
+		restored := bytes.TrimRight(codec.RemoveEmptyByteFromPaddedBytes(results[i].Data), "\x00")
+		assert.Equal(t, gettysburgAddressBytes, restored)
+	}
+	assert.ErrorContains(t, results[1].Err, "failed to get blob header from all operators")
+
+	// The blob requested twice is only fetched once from each operator
+	nodeClient.AssertNumberOfCalls(t, "GetChunks", numOperators)
+}
+
+func TestOutOfAssignmentChunks(t *testing.T) {
+
+	setup(t)
+
+	// Each operator serves the chunks assigned to another operator along with its own ones
+	operatorIDs := make([]core.OperatorID, 0, len(encodedBlob.BundlesByOperator))
+	for id := range encodedBlob.BundlesByOperator {
+		operatorIDs = append(operatorIDs, id)
+	}
+	proxied := core.EncodedBlob{
+		BlobHeader:        encodedBlob.BlobHeader,
+		BundlesByOperator: make(map[core.OperatorID]core.Bundles, len(operatorIDs)),
+	}
+	for i, id := range operatorIDs {
+		other := encodedBlob.BundlesByOperator[operatorIDs[(i+1)%len(operatorIDs)]][0]
+		own := encodedBlob.BundlesByOperator[id][0]
+		proxied.BundlesByOperator[id] = core.Bundles{0: append(append(core.Bundle{}, own...), other...)}
+	}
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil).Once()
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(proxied)
+
+	indexer.On("GetObject", mock.Anything, 0).Return(mustMakeOpertatorPubKeysPair(t), nil).Once()
+	indexer.On("GetObject", mock.Anything, 1).Return(musMakeOperatorSocket(t), nil).Once()
+
+	// The chunks of all the operators are rejected, so none is left to reconstruct the blob
+	_, err := retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package retriever_test
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func confirmedReply(batchHeaderHash byte, blobIndex uint32) *disperser_rpc.BlobStatusReply {
+	return &disperser_rpc.BlobStatusReply{
+		Status: disperser_rpc.BlobStatus_CONFIRMED,
+		Info: &disperser_rpc.BlobInfo{
+			BlobVerificationProof: &disperser_rpc.BlobVerificationProof{
+				BlobIndex: blobIndex,
+				BatchMetadata: &disperser_rpc.BatchMetadata{
+					BatchHeaderHash: []byte{batchHeaderHash},
+					BatchHeader: &disperser_rpc.BatchHeader{
+						BatchRoot:            []byte{batchHeaderHash},
+						ReferenceBlockNumber: 100,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSplitBlobClient(t *testing.T) {
+	ctx := context.Background()
+	data := make([]byte, 1000)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	for i := 0; i < len(data); i += 32 {
+		data[i] = 0
+	}
+
+	disperserClient := clientsmock.NewMockDisperserClient()
+	retrievalClient := clientsmock.NewRetrievalClient()
+	status := disperser.Processing
+	disperserClient.On("DisperseBlob", data[:512], []uint8{0}).Return(&status, []byte("part0"), nil).Once()
+	disperserClient.On("DisperseBlob", data[512:], []uint8{0}).Return(&status, []byte("part1"), nil).Once()
+	disperserClient.On("GetBlobStatus", []byte("part0")).Return(&disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_PROCESSING}, nil).Once()
+	disperserClient.On("GetBlobStatus", []byte("part0")).Return(confirmedReply(1, 3), nil).Once()
+	disperserClient.On("GetBlobStatus", []byte("part1")).Return(confirmedReply(2, 4), nil).Once()
+
+	// Blobs fitting in 16 SRS points are at most 512 bytes
+	client := clients.NewSplitBlobClient(disperserClient, retrievalClient, 16, time.Millisecond)
+	manifest, err := client.DisperseBlob(ctx, data, []uint8{0})
+	require.NoError(t, err)
+	require.Len(t, manifest.Parts, 2)
+	assert.Equal(t, [32]byte{1}, manifest.Parts[0].BatchHeaderHash)
+	assert.Equal(t, uint32(3), manifest.Parts[0].BlobIndex)
+	assert.Equal(t, [32]byte{2}, manifest.Parts[1].BatchRoot)
+	assert.Equal(t, uint32(100), manifest.Parts[1].ReferenceBlockNumber)
+	disperserClient.AssertExpectations(t)
+
+	// The retrieved parts are padded to a power of 2 symbols
+	retrievalClient.On("RetrieveBlob").Return(append([]byte{}, data[:512]...), nil).Once()
+	retrievalClient.On("RetrieveBlob").Return(append(append([]byte{}, data[512:]...), make([]byte, 24)...), nil).Once()
+	retrieved, err := client.RetrieveBlob(ctx, manifest, 0)
+	require.NoError(t, err)
+	assert.Equal(t, data, retrieved)
+}
+
+func TestSplitBlobClientFailedPart(t *testing.T) {
+	data := make([]byte, 100)
+	disperserClient := clientsmock.NewMockDisperserClient()
+	status := disperser.Processing
+	disperserClient.On("DisperseBlob", mock.Anything, mock.Anything).Return(&status, []byte("part"), nil)
+	disperserClient.On("GetBlobStatus", mock.Anything).Return(&disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_FAILED}, nil)
+
+	client := clients.NewSplitBlobClient(disperserClient, clientsmock.NewRetrievalClient(), 16, time.Millisecond)
+	_, err := client.DisperseBlob(context.Background(), data, []uint8{0})
+	assert.ErrorContains(t, err, "dispersal failed")
+}
@@ -2,6 +2,7 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
@@ -31,6 +32,9 @@ const (
 var (
 	once      sync.Once
 	clientRef *Client
+
+	// ErrConditionFailed is returned when the condition of a conditional write doesn't hold
+	ErrConditionFailed = errors.New("condition failed")
 )
 
 type Item = map[string]types.AttributeValue
@@ -105,6 +109,22 @@ func (c *Client) PutItem(ctx context.Context, tableName string, item Item) (err
 	return nil
 }
 
+// PutItemWithCondition puts the item only if the condition holds on the item it replaces, returning
+// ErrConditionFailed otherwise. The condition is a DynamoDB condition expression.
+func (c *Client) PutItemWithCondition(ctx context.Context, tableName string, item Item, condition string, expAttributeValues ExpresseionValues) error {
+	_, err := c.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(tableName),
+		Item:                      item,
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeValues: expAttributeValues,
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return fmt.Errorf("%w: %s", ErrConditionFailed, conditionErr.ErrorMessage())
+	}
+	return err
+}
+
 // PutItems puts items in batches of 25 items (which is a limit DynamoDB imposes)
 // It returns the items that failed to be put.
 func (c *Client) PutItems(ctx context.Context, tableName string, items []Item) ([]Item, error) {
@@ -142,6 +162,141 @@ func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item
 	return resp.Attributes, err
 }
 
+// UpdateItemWithCondition sets the attributes of the item and removes the given attributes from it in a single
+// update, only if the condition holds on the existing item, returning ErrConditionFailed otherwise. The condition is a
+// DynamoDB condition expression whose values must not be named like the generated placeholders (":0", ":1", ...).
+func (c *Client) UpdateItemWithCondition(ctx context.Context, tableName string, key Key, item Item, remove []string, condition string, expAttributeValues ExpresseionValues) (Item, error) {
+	update := expression.UpdateBuilder{}
+	for itemKey, itemValue := range item {
+		if _, ok := key[itemKey]; ok {
+			// Cannot update the key
+			continue
+		}
+		update = update.Set(expression.Name(itemKey), expression.Value(itemValue))
+	}
+	for _, name := range remove {
+		if _, ok := item[name]; ok {
+			// An attribute can't be both set and removed
+			continue
+		}
+		update = update.Remove(expression.Name(name))
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		return nil, err
+	}
+	values := expr.Values()
+	if values == nil {
+		values = make(ExpresseionValues, len(expAttributeValues))
+	}
+	for name, value := range expAttributeValues {
+		values[name] = value
+	}
+
+	resp, err := c.dynamoClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(tableName),
+		Key:                       key,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: values,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       aws.String(condition),
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	var conditionErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionErr) {
+		return nil, fmt.Errorf("%w: %s", ErrConditionFailed, conditionErr.ErrorMessage())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Attributes, nil
+}
+
+// AddToItem atomically adds the numeric values to the attributes of the item, creating the item and the attributes
+// missing from it. It returns the updated attributes.
+func (c *Client) AddToItem(ctx context.Context, tableName string, key Key, values Item) (Item, error) {
+	update := expression.UpdateBuilder{}
+	for itemKey, itemValue := range values {
+		update = update.Add(expression.Name(itemKey), expression.Value(itemValue))
+	}
+
+	expr, err := expression.NewBuilder().WithUpdate(update).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.dynamoClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(tableName),
+		Key:                       key,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Attributes, nil
+}
+
+// AddToItemOnce atomically adds the numeric values to the attributes of the item, like AddToItem, and sets the marker
+// attribute of the existing marker item, in a single transaction. If the marker is already set, or the marker item
+// doesn't exist, nothing is written and ErrConditionFailed is returned, so that the values are added once per marker.
+func (c *Client) AddToItemOnce(ctx context.Context, tableName string, key Key, values Item, markerKey Key, marker string) error {
+	add := expression.UpdateBuilder{}
+	for itemKey, itemValue := range values {
+		add = add.Add(expression.Name(itemKey), expression.Value(itemValue))
+	}
+	addExpr, err := expression.NewBuilder().WithUpdate(add).Build()
+	if err != nil {
+		return err
+	}
+
+	condition := expression.AttributeNotExists(expression.Name(marker))
+	for name := range markerKey {
+		condition = condition.And(expression.AttributeExists(expression.Name(name)))
+	}
+	markExpr, err := expression.NewBuilder().
+		WithUpdate(expression.Set(expression.Name(marker), expression.Value(true))).
+		WithCondition(condition).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Update: &types.Update{
+					TableName:                 aws.String(tableName),
+					Key:                       markerKey,
+					ExpressionAttributeNames:  markExpr.Names(),
+					ExpressionAttributeValues: markExpr.Values(),
+					UpdateExpression:          markExpr.Update(),
+					ConditionExpression:       markExpr.Condition(),
+				},
+			},
+			{
+				Update: &types.Update{
+					TableName:                 aws.String(tableName),
+					Key:                       key,
+					ExpressionAttributeNames:  addExpr.Names(),
+					ExpressionAttributeValues: addExpr.Values(),
+					UpdateExpression:          addExpr.Update(),
+				},
+			},
+		},
+	})
+	var canceledErr *types.TransactionCanceledException
+	if errors.As(err, &canceledErr) && len(canceledErr.CancellationReasons) > 0 && aws.ToString(canceledErr.CancellationReasons[0].Code) == "ConditionalCheckFailed" {
+		return fmt.Errorf("%w: %s", ErrConditionFailed, canceledErr.ErrorMessage())
+	}
+	return err
+}
+
 func (c *Client) GetItem(ctx context.Context, tableName string, key Key) (Item, error) {
 	resp, err := c.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{Key: key, TableName: aws.String(tableName)})
 	if err != nil {
@@ -151,6 +306,28 @@ func (c *Client) GetItem(ctx context.Context, tableName string, key Key) (Item,
 	return resp.Item, nil
 }
 
+// Query returns all items in the table that match the given key
+func (c *Client) Query(ctx context.Context, tableName string, keyCondition string, expAttributeValues ExpresseionValues) ([]Item, error) {
+	var items []Item
+	var exclusiveStartKey Key
+	for {
+		response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(tableName),
+			KeyConditionExpression:    aws.String(keyCondition),
+			ExpressionAttributeValues: expAttributeValues,
+			ExclusiveStartKey:         exclusiveStartKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, response.Items...)
+		if len(response.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		exclusiveStartKey = response.LastEvaluatedKey
+	}
+}
+
 // QueryIndex returns all items in the index that match the given key
 func (c *Client) QueryIndex(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpresseionValues) ([]Item, error) {
 	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
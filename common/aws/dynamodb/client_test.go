@@ -218,6 +218,110 @@ func TestBasicOperations(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPutItemWithCondition(t *testing.T) {
+	tableName := "ConditionalPut"
+	createTable(t, tableName)
+
+	ctx := context.Background()
+	condition := "attribute_not_exists(MetadataKey) OR RequestedAt < :requestedAt"
+	values := commondynamodb.ExpresseionValues{
+		":requestedAt": &types.AttributeValueMemberN{Value: "200"},
+	}
+	err := dynamoClient.PutItemWithCondition(ctx, tableName, commondynamodb.Item{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+		"RequestedAt": &types.AttributeValueMemberN{Value: "200"},
+	}, condition, values)
+	assert.NoError(t, err)
+
+	// The existing item doesn't satisfy the condition
+	err = dynamoClient.PutItemWithCondition(ctx, tableName, commondynamodb.Item{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+		"RequestedAt": &types.AttributeValueMemberN{Value: "300"},
+	}, condition, values)
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	item, err := dynamoClient.GetItem(ctx, tableName, commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "200", item["RequestedAt"].(*types.AttributeValueMemberN).Value)
+
+	err = dynamoClient.DeleteTable(ctx, tableName)
+	assert.NoError(t, err)
+}
+
+func TestUpdateItemWithCondition(t *testing.T) {
+	tableName := "ConditionalUpdate"
+	createTable(t, tableName)
+
+	ctx := context.Background()
+	key := commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	}
+	err := dynamoClient.PutItem(ctx, tableName, commondynamodb.Item{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+		"Status":      &types.AttributeValueMemberS{Value: "confirmed"},
+		"BatchIndex":  &types.AttributeValueMemberN{Value: "1"},
+	})
+	assert.NoError(t, err)
+
+	_, err = dynamoClient.UpdateItemWithCondition(ctx, tableName, key, commondynamodb.Item{
+		"Status": &types.AttributeValueMemberS{Value: "processing"},
+	}, []string{"BatchIndex"}, "BatchIndex = :batchIndex", commondynamodb.ExpresseionValues{
+		":batchIndex": &types.AttributeValueMemberN{Value: "1"},
+	})
+	assert.NoError(t, err)
+	item, err := dynamoClient.GetItem(ctx, tableName, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "processing", item["Status"].(*types.AttributeValueMemberS).Value)
+	assert.NotContains(t, item, "BatchIndex")
+
+	// The item doesn't satisfy the condition anymore
+	_, err = dynamoClient.UpdateItemWithCondition(ctx, tableName, key, commondynamodb.Item{
+		"Status": &types.AttributeValueMemberS{Value: "failed"},
+	}, nil, "attribute_exists(BatchIndex)", nil)
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	err = dynamoClient.DeleteTable(ctx, tableName)
+	assert.NoError(t, err)
+}
+
+func TestAddToItemOnce(t *testing.T) {
+	tableName := "AddOnce"
+	createTable(t, tableName)
+
+	ctx := context.Background()
+	key := commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "counter"},
+	}
+	markerKey := commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "marker"},
+	}
+	values := commondynamodb.Item{
+		"Count": &types.AttributeValueMemberN{Value: "2"},
+	}
+
+	// The marker item doesn't exist
+	err := dynamoClient.AddToItemOnce(ctx, tableName, key, values, markerKey, "Counted")
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	err = dynamoClient.PutItem(ctx, tableName, commondynamodb.Item{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "marker"},
+	})
+	assert.NoError(t, err)
+	err = dynamoClient.AddToItemOnce(ctx, tableName, key, values, markerKey, "Counted")
+	assert.NoError(t, err)
+	err = dynamoClient.AddToItemOnce(ctx, tableName, key, values, markerKey, "Counted")
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	item, err := dynamoClient.GetItem(ctx, tableName, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", item["Count"].(*types.AttributeValueMemberN).Value)
+
+	err = dynamoClient.DeleteTable(ctx, tableName)
+	assert.NoError(t, err)
+}
+
 func TestBatchOperations(t *testing.T) {
 	tableName := "Processing"
 	createTable(t, tableName)
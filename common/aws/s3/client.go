@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"sync"
 
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
@@ -96,6 +97,10 @@ func (s *client) DownloadObject(ctx context.Context, bucket string, key string)
 }
 
 func (s *client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	return s.UploadObjectStream(ctx, bucket, key, bytes.NewReader(data))
+}
+
+func (s *client) UploadObjectStream(ctx context.Context, bucket string, key string, body io.Reader) error {
 	var partMiBs int64 = 10
 	uploader := manager.NewUploader(s.s3Client, func(u *manager.Uploader) {
 		u.PartSize = partMiBs * 1024 * 1024 // 10MB per part
@@ -105,7 +110,7 @@ func (s *client) UploadObject(ctx context.Context, bucket string, key string, da
 	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
-		Body:   bytes.NewReader(data),
+		Body:   body,
 	})
 	if err != nil {
 		return err
@@ -1,10 +1,16 @@
 package s3
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 type Client interface {
 	DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error)
 	UploadObject(ctx context.Context, bucket string, key string, data []byte) error
+	// UploadObjectStream uploads the object read from body in parts, without holding the whole object in memory.
+	// The upload is aborted if reading body fails.
+	UploadObjectStream(ctx context.Context, bucket string, key string, body io.Reader) error
 	DeleteObject(ctx context.Context, bucket string, key string) error
 	ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error)
 }
@@ -0,0 +1,73 @@
+// Package buildinfo describes the build of a service and the capabilities it's deployed with. Every service exports
+// it as the eigenda_build_info metric when it starts, and the services with an info RPC also return it to their
+// clients, so that which versions and features are actually deployed can be seen across the fleet.
+package buildinfo
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MetricName is the name of the build info metric, which is the same for every service so that a single query covers
+// the whole fleet. The services are told apart by the service label.
+const MetricName = "eigenda_build_info"
+
+// Info is the build of a service and the capabilities it's deployed with
+type Info struct {
+	// Service is the name of the service, e.g. "node" or "batcher"
+	Service string
+	// Version is the semantic version of the build
+	Version string
+	// Commit is the git commit of the build
+	Commit string
+	// EncoderBackend is the backend computing the KZG proofs, for the services encoding or verifying blobs
+	EncoderBackend string
+	// SRSOrder is the order of the SRS loaded by the service, if it loads one
+	SRSOrder uint64
+	// Features are the optional features enabled in the service
+	Features []string
+}
+
+// Register exports the info to the registry as the build_info gauge, which is always 1 and carries the info in its
+// labels. The features are sorted, and joined with commas in a single label.
+func Register(registry prometheus.Registerer, info Info) error {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: MetricName,
+		Help: "the build of the service and the capabilities it's deployed with, always 1",
+		ConstLabels: prometheus.Labels{
+			"service":         info.Service,
+			"version":         info.Version,
+			"commit":          info.Commit,
+			"encoder_backend": info.EncoderBackend,
+			"srs_order":       strconv.FormatUint(info.SRSOrder, 10),
+			"features":        strings.Join(info.sortedFeatures(), ","),
+		},
+	})
+	if err := registry.Register(gauge); err != nil {
+		return err
+	}
+	gauge.Set(1)
+	return nil
+}
+
+// ToProtobuf returns the info as returned by the info RPCs
+func (i Info) ToProtobuf() *commonpb.BuildInfo {
+	return &commonpb.BuildInfo{
+		Service:        i.Service,
+		Version:        i.Version,
+		Commit:         i.Commit,
+		EncoderBackend: i.EncoderBackend,
+		SrsOrder:       i.SRSOrder,
+		Features:       i.sortedFeatures(),
+	}
+}
+
+func (i Info) sortedFeatures() []string {
+	features := append([]string{}, i.Features...)
+	sort.Strings(features)
+	return features
+}
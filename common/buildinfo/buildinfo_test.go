@@ -0,0 +1,44 @@
+package buildinfo_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	info := buildinfo.Info{
+		Service:        "node",
+		Version:        "0.6.1",
+		Commit:         "abc123",
+		EncoderBackend: "fk20",
+		SRSOrder:       268435456,
+		Features:       []string{"retrieval_tls", "dispersal_tls"},
+	}
+	registry := prometheus.NewRegistry()
+	require.NoError(t, buildinfo.Register(registry, info))
+
+	expected := `
+# HELP eigenda_build_info the build of the service and the capabilities it's deployed with, always 1
+# TYPE eigenda_build_info gauge
+eigenda_build_info{commit="abc123",encoder_backend="fk20",features="dispersal_tls,retrieval_tls",service="node",srs_order="268435456",version="0.6.1"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), buildinfo.MetricName))
+	// The info can only be registered once per registry
+	assert.Error(t, buildinfo.Register(registry, info))
+	// The features of the info aren't reordered in place
+	assert.Equal(t, []string{"retrieval_tls", "dispersal_tls"}, info.Features)
+
+	reply := info.ToProtobuf()
+	assert.Equal(t, "node", reply.GetService())
+	assert.Equal(t, "0.6.1", reply.GetVersion())
+	assert.Equal(t, "abc123", reply.GetCommit())
+	assert.Equal(t, "fk20", reply.GetEncoderBackend())
+	assert.Equal(t, uint64(268435456), reply.GetSrsOrder())
+	assert.Equal(t, []string{"dispersal_tls", "retrieval_tls"}, reply.GetFeatures())
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// CorrelationIDHeader is the gRPC metadata key, and HTTP header, carrying the correlation ID of a blob across the
+// disperser, the encoders and the nodes, so that the log lines of every service handling a blob can be joined.
+const CorrelationIDHeader = "x-correlation-id"
+
+// MaxCorrelationIDLength is the length of the longest correlation ID accepted from a client
+const MaxCorrelationIDLength = 64
+
+type correlationIDKey struct{}
+
+// NewCorrelationID returns a new random correlation ID
+func NewCorrelationID() string {
+	return uuid.NewString()
+}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID, both for the code handling ctx and in the
+// metadata of the outgoing gRPC requests made with it. An empty ID leaves ctx unchanged.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, correlationIDKey{}, id)
+	return metadata.AppendToOutgoingContext(ctx, CorrelationIDHeader, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID set on ctx with WithCorrelationID, or else the valid
+// correlation ID in the metadata of the incoming gRPC request, or an empty string if there is none.
+func CorrelationIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationIDKey{}).(string); ok {
+		return id
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(CorrelationIDHeader)
+	if len(values) == 0 || !IsValidCorrelationID(values[0]) {
+		return ""
+	}
+	return values[0]
+}
+
+// IsValidCorrelationID returns whether the ID is non-empty, at most MaxCorrelationIDLength long, and made of
+// printable ASCII characters other than spaces
+func IsValidCorrelationID(id string) bool {
+	if id == "" || len(id) > MaxCorrelationIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
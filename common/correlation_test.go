@@ -0,0 +1,33 @@
+package common_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCorrelationID(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", common.CorrelationIDFromContext(ctx))
+
+	// The ID set on the context is sent in the metadata of the outgoing requests
+	id := common.NewCorrelationID()
+	assert.True(t, common.IsValidCorrelationID(id))
+	ctx = common.WithCorrelationID(ctx, id)
+	assert.Equal(t, id, common.CorrelationIDFromContext(ctx))
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, []string{id}, md.Get(common.CorrelationIDHeader))
+
+	// The ID is read from the metadata of the incoming requests, if it's valid
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.CorrelationIDHeader, id))
+	assert.Equal(t, id, common.CorrelationIDFromContext(incoming))
+	for _, invalid := range []string{"with space", "line\n", strings.Repeat("a", common.MaxCorrelationIDLength+1)} {
+		incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(common.CorrelationIDHeader, invalid))
+		assert.Equal(t, "", common.CorrelationIDFromContext(incoming))
+	}
+}
@@ -1,15 +1,20 @@
 package geth
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/urfave/cli"
 )
 
 var (
-	rpcUrlFlagName           = "chain.rpc"
-	privateKeyFlagName       = "chain.private-key"
-	numConfirmationsFlagName = "chain.num-confirmations"
-	numRetriesFlagName       = "chain.num-retries"
+	rpcUrlFlagName              = "chain.rpc"
+	privateKeyFlagName          = "chain.private-key"
+	numConfirmationsFlagName    = "chain.num-confirmations"
+	numRetriesFlagName          = "chain.num-retries"
+	receiptPollIntervalFlagName = "chain.receipt-poll-interval"
+	receiptTimeoutFlagName      = "chain.receipt-timeout"
+	subscribeNewHeadsFlagName   = "chain.subscribe-new-heads"
 )
 
 type EthClientConfig struct {
@@ -17,6 +22,11 @@ type EthClientConfig struct {
 	PrivateKeyString string
 	NumConfirmations int
 	NumRetries       int
+	// ReceiptPollInterval, ReceiptTimeout and SubscribeNewHeads are the policy waiting for transaction receipts,
+	// see ReceiptWaiterConfig
+	ReceiptPollInterval time.Duration
+	ReceiptTimeout      time.Duration
+	SubscribeNewHeads   bool
 }
 
 func EthClientFlags(envPrefix string) []cli.Flag {
@@ -47,6 +57,26 @@ func EthClientFlags(envPrefix string) []cli.Flag {
 			Value:    2,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_RETRIES"),
 		},
+		cli.DurationFlag{
+			Name:     receiptPollIntervalFlagName,
+			Usage:    "Interval at which transaction receipts are polled while waiting for transactions to be mined",
+			Required: false,
+			Value:    DefaultReceiptPollInterval,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "RECEIPT_POLL_INTERVAL"),
+		},
+		cli.DurationFlag{
+			Name:     receiptTimeoutFlagName,
+			Usage:    "Maximum time to wait for a transaction to be mined and confirmed. If 0, the wait is only bounded by the caller",
+			Required: false,
+			Value:    0,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "RECEIPT_TIMEOUT"),
+		},
+		cli.BoolFlag{
+			Name:     subscribeNewHeadsFlagName,
+			Usage:    "Look up transaction receipts on every new block through a subscription, in addition to polling. Requires a websocket rpc",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "SUBSCRIBE_NEW_HEADS"),
+		},
 	}
 }
 
@@ -56,6 +86,9 @@ func ReadEthClientConfig(ctx *cli.Context) EthClientConfig {
 	cfg.PrivateKeyString = ctx.GlobalString(privateKeyFlagName)
 	cfg.NumConfirmations = ctx.GlobalInt(numConfirmationsFlagName)
 	cfg.NumRetries = ctx.GlobalInt(numRetriesFlagName)
+	cfg.ReceiptPollInterval = ctx.GlobalDuration(receiptPollIntervalFlagName)
+	cfg.ReceiptTimeout = ctx.GlobalDuration(receiptTimeoutFlagName)
+	cfg.SubscribeNewHeads = ctx.GlobalBool(subscribeNewHeadsFlagName)
 
 	return cfg
 }
@@ -67,6 +100,9 @@ func ReadEthClientConfigRPCOnly(ctx *cli.Context) EthClientConfig {
 	cfg.RPCURLs = ctx.GlobalStringSlice(rpcUrlFlagName)
 	cfg.NumConfirmations = ctx.GlobalInt(numConfirmationsFlagName)
 	cfg.NumRetries = ctx.GlobalInt(numRetriesFlagName)
+	cfg.ReceiptPollInterval = ctx.GlobalDuration(receiptPollIntervalFlagName)
+	cfg.ReceiptTimeout = ctx.GlobalDuration(receiptTimeoutFlagName)
+	cfg.SubscribeNewHeads = ctx.GlobalBool(subscribeNewHeadsFlagName)
 
 	return cfg
 }
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -34,6 +33,7 @@ type EthClient struct {
 	Contracts        map[gethcommon.Address]*bind.BoundContract
 	Logger           logging.Logger
 	numConfirmations int
+	receiptWaiter    *ReceiptWaiter
 }
 
 var _ common.EthClient = (*EthClient)(nil)
@@ -85,6 +85,12 @@ func NewClient(config EthClientConfig, senderAddress gethcommon.Address, rpcInde
 		Logger:           logger,
 		numConfirmations: config.NumConfirmations,
 	}
+	c.receiptWaiter = NewReceiptWaiter(c, ReceiptWaiterConfig{
+		NumConfirmations:  config.NumConfirmations,
+		PollInterval:      config.ReceiptPollInterval,
+		Timeout:           config.ReceiptTimeout,
+		SubscribeNewHeads: config.SubscribeNewHeads,
+	}, logger)
 
 	return c, err
 }
@@ -252,43 +258,18 @@ func (c *EthClient) EnsureAnyTransactionEvaled(ctx context.Context, txs []*types
 
 // waitMined takes multiple transactions and waits for any of them to be mined on the blockchain and returns the receipt.
 // If the context times out but the receipt is available, it returns both receipt and error, noting that the transaction is confirmed but has not accumulated the required number of confirmations.
-// Taken from https://github.com/ethereum/go-ethereum/blob/master/accounts/abi/bind/util.go#L32,
-// but added a check for number of confirmations.
 func (c *EthClient) waitMined(ctx context.Context, txs []*types.Transaction) (*types.Receipt, error) {
-	queryTicker := time.NewTicker(3 * time.Second)
-	defer queryTicker.Stop()
-	var receipt *types.Receipt
-	var err error
-	for {
+	return c.receiptWaiter.WaitForReceipt(ctx, func(ctx context.Context) (*types.Receipt, error) {
+		var err error
 		for _, tx := range txs {
+			var receipt *types.Receipt
 			receipt, err = c.TransactionReceipt(ctx, tx.Hash())
 			if err == nil {
-				chainTip, err := c.BlockNumber(ctx)
-				if err == nil {
-					if receipt.BlockNumber.Uint64()+uint64(c.numConfirmations) > chainTip {
-						c.Logger.Debug("transaction has been mined but doesn't have enough confirmations at current chain head", "txnBlockNumber", receipt.BlockNumber.Uint64(), "numConfirmations", c.numConfirmations, "chainTip", chainTip)
-						break
-					} else {
-						return receipt, nil
-					}
-				} else {
-					c.Logger.Debug("failed to query block height while waiting for transaction to mine", "err", err)
-				}
+				return receipt, nil
 			}
-
-			if errors.Is(err, ethereum.NotFound) {
-				c.Logger.Debug("Transaction not yet mined", "txHash", tx.Hash().Hex())
-			} else if err != nil {
-				c.Logger.Debug("Transaction receipt retrieval failed", "err", err)
-			}
-		}
-		// Wait for the next round.
-		select {
-		case <-ctx.Done():
-			return receipt, ctx.Err()
-		case <-queryTicker.C:
 		}
-	}
+		return nil, err
+	})
 }
 
 // getGasFeeCap returns the gas fee cap for a transaction, calculated as:
@@ -0,0 +1,153 @@
+package geth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const DefaultReceiptPollInterval = 3 * time.Second
+
+// ReceiptWaiterConfig is the policy of a ReceiptWaiter
+type ReceiptWaiterConfig struct {
+	// NumConfirmations is the number of blocks that must be mined on top of the block of a receipt
+	// before it's returned
+	NumConfirmations int
+	// PollInterval is how often the receipts are looked up. With SubscribeNewHeads, it's how often they're looked up
+	// if no new head is received
+	PollInterval time.Duration
+	// Timeout bounds how long a receipt is waited for. If zero, only the context deadline applies
+	Timeout time.Duration
+	// SubscribeNewHeads looks up the receipts whenever a new head is received, rather than only polling.
+	// The waiter falls back to polling if the client can't subscribe
+	SubscribeNewHeads bool
+}
+
+// ReceiptFetcher looks up the receipt of any of the transactions being waited for. It returns ethereum.NotFound
+// if none of them is mined yet. Errors wrapping ErrTransactionFailed stop the wait, other errors are retried.
+type ReceiptFetcher func(ctx context.Context) (*types.Receipt, error)
+
+// ChainHeadReader is the part of the chain client a ReceiptWaiter needs to follow the chain head
+type ChainHeadReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
+}
+
+// ReceiptWaiter waits for transactions to be mined and to accumulate a number of confirmations. A receipt whose
+// block is reorged out before it accumulates the confirmations is discarded, and the transaction is waited for
+// again.
+type ReceiptWaiter struct {
+	client ChainHeadReader
+	config ReceiptWaiterConfig
+	logger logging.Logger
+}
+
+func NewReceiptWaiter(client ChainHeadReader, config ReceiptWaiterConfig, logger logging.Logger) *ReceiptWaiter {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultReceiptPollInterval
+	}
+	return &ReceiptWaiter{
+		client: client,
+		config: config,
+		logger: logger.With("component", "ReceiptWaiter"),
+	}
+}
+
+// WaitForReceipt waits until fetch returns a receipt with enough confirmations, and returns it.
+// If the wait times out but a receipt is available, it returns both the receipt and the error, noting that the
+// transaction is mined but has not accumulated the required number of confirmations.
+func (w *ReceiptWaiter) WaitForReceipt(ctx context.Context, fetch ReceiptFetcher) (*types.Receipt, error) {
+	if w.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.config.Timeout)
+		defer cancel()
+	}
+
+	queryTicker := time.NewTicker(w.config.PollInterval)
+	defer queryTicker.Stop()
+
+	var newHeads chan *types.Header
+	var subErr <-chan error
+	if w.config.SubscribeNewHeads {
+		newHeads = make(chan *types.Header, 1)
+		sub, err := w.client.SubscribeNewHead(ctx, newHeads)
+		if err != nil {
+			w.logger.Warn("failed to subscribe to new heads, polling for receipts", "err", err)
+			newHeads = nil
+		} else {
+			defer sub.Unsubscribe()
+			subErr = sub.Err()
+		}
+	}
+
+	var receipt *types.Receipt
+	for {
+		mined, confirmed, err := w.checkReceipt(ctx, fetch)
+		if errors.Is(err, ErrTransactionFailed) {
+			return nil, err
+		}
+		receipt = mined
+		if confirmed {
+			return receipt, nil
+		}
+
+		// Wait for the next round.
+		select {
+		case <-ctx.Done():
+			return receipt, ctx.Err()
+		case <-queryTicker.C:
+		case <-newHeads:
+		case err := <-subErr:
+			w.logger.Warn("new heads subscription failed, polling for receipts", "err", err)
+			newHeads = nil
+			subErr = nil
+		}
+	}
+}
+
+// checkReceipt fetches the receipt, and returns it along with whether it has enough confirmations
+func (w *ReceiptWaiter) checkReceipt(ctx context.Context, fetch ReceiptFetcher) (*types.Receipt, bool, error) {
+	receipt, err := fetch(ctx)
+	if errors.Is(err, ethereum.NotFound) {
+		w.logger.Debug("transaction not yet mined")
+		return nil, false, err
+	}
+	if err != nil {
+		if !errors.Is(err, ErrTransactionFailed) {
+			w.logger.Debug("transaction receipt retrieval failed", "err", err)
+		}
+		return nil, false, err
+	}
+
+	chainTip, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		w.logger.Debug("failed to query block height while waiting for transaction to mine", "err", err)
+		return receipt, false, err
+	}
+	if receipt.BlockNumber.Uint64()+uint64(w.config.NumConfirmations) > chainTip {
+		w.logger.Debug("transaction has been mined but doesn't have enough confirmations at current chain head", "txnBlockNumber", receipt.BlockNumber.Uint64(), "numConfirmations", w.config.NumConfirmations, "chainTip", chainTip)
+		return receipt, false, nil
+	}
+
+	// The receipt may have been fetched before its block was reorged out, so check its block is still canonical
+	// once it's deep enough. Without confirmations the receipt is as good as the chain head, so it isn't checked.
+	if w.config.NumConfirmations > 0 {
+		header, err := w.client.HeaderByNumber(ctx, receipt.BlockNumber)
+		if err != nil {
+			w.logger.Debug("failed to query the block of the receipt", "blockNumber", receipt.BlockNumber.Uint64(), "err", err)
+			return receipt, false, err
+		}
+		if header.Hash() != receipt.BlockHash {
+			w.logger.Warn("block of the transaction receipt was reorged out, waiting for the transaction again", "txHash", receipt.TxHash.Hex(), "blockNumber", receipt.BlockNumber.Uint64(), "blockHash", receipt.BlockHash.Hex())
+			return nil, false, fmt.Errorf("block %s of transaction %s was reorged out", receipt.BlockHash.Hex(), receipt.TxHash.Hex())
+		}
+	}
+	return receipt, true, nil
+}
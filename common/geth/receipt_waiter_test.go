@@ -0,0 +1,141 @@
+package geth_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain is a chain whose head advances by one block every time it's queried
+type fakeChain struct {
+	mu      sync.Mutex
+	head    uint64
+	headers map[uint64]*types.Header
+}
+
+func newFakeChain(head uint64) *fakeChain {
+	return &fakeChain{head: head, headers: make(map[uint64]*types.Header)}
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head++
+	return c.head, nil
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	header, ok := c.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (c *fakeChain) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return nil, errors.New("subscriptions not supported")
+}
+
+func (c *fakeChain) setHeader(header *types.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headers[header.Number.Uint64()] = header
+}
+
+func receiptInBlock(header *types.Header) *types.Receipt {
+	return &types.Receipt{
+		TxHash:      gethcommon.HexToHash("0x1"),
+		BlockNumber: header.Number,
+		BlockHash:   header.Hash(),
+	}
+}
+
+func TestWaitForReceiptConfirmations(t *testing.T) {
+	chain := newFakeChain(10)
+	header := &types.Header{Number: big.NewInt(10)}
+	chain.setHeader(header)
+	waiter := geth.NewReceiptWaiter(chain, geth.ReceiptWaiterConfig{
+		NumConfirmations:  3,
+		PollInterval:      time.Millisecond,
+		SubscribeNewHeads: true,
+	}, logging.NewNoopLogger())
+
+	numFetches := 0
+	receipt, err := waiter.WaitForReceipt(context.Background(), func(ctx context.Context) (*types.Receipt, error) {
+		numFetches++
+		if numFetches == 1 {
+			return nil, ethereum.NotFound
+		}
+		return receiptInBlock(header), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash(), receipt.BlockHash)
+	assert.GreaterOrEqual(t, chain.head, uint64(13))
+}
+
+func TestWaitForReceiptReorg(t *testing.T) {
+	chain := newFakeChain(10)
+	reorged := &types.Header{Number: big.NewInt(10), Extra: []byte("reorged")}
+	canonical := &types.Header{Number: big.NewInt(10)}
+	chain.setHeader(canonical)
+	waiter := geth.NewReceiptWaiter(chain, geth.ReceiptWaiterConfig{
+		NumConfirmations: 1,
+		PollInterval:     time.Millisecond,
+	}, logging.NewNoopLogger())
+
+	// The first receipt is in a block which was reorged out, the transaction is then included in the canonical block
+	numFetches := 0
+	receipt, err := waiter.WaitForReceipt(context.Background(), func(ctx context.Context) (*types.Receipt, error) {
+		numFetches++
+		if numFetches == 1 {
+			return receiptInBlock(reorged), nil
+		}
+		return receiptInBlock(canonical), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, canonical.Hash(), receipt.BlockHash)
+	assert.Equal(t, 2, numFetches)
+}
+
+func TestWaitForReceiptTransactionFailed(t *testing.T) {
+	waiter := geth.NewReceiptWaiter(newFakeChain(10), geth.ReceiptWaiterConfig{
+		PollInterval: time.Millisecond,
+	}, logging.NewNoopLogger())
+
+	_, err := waiter.WaitForReceipt(context.Background(), func(ctx context.Context) (*types.Receipt, error) {
+		return nil, geth.ErrTransactionFailed
+	})
+	assert.ErrorIs(t, err, geth.ErrTransactionFailed)
+}
+
+func TestWaitForReceiptTimeout(t *testing.T) {
+	chain := newFakeChain(10)
+	header := &types.Header{Number: big.NewInt(10)}
+	chain.setHeader(header)
+	waiter := geth.NewReceiptWaiter(chain, geth.ReceiptWaiterConfig{
+		NumConfirmations: 1000,
+		PollInterval:     time.Millisecond,
+		Timeout:          20 * time.Millisecond,
+	}, logging.NewNoopLogger())
+
+	// The receipt is returned along with the error if it doesn't get enough confirmations in time
+	receipt, err := waiter.WaitForReceipt(context.Background(), func(ctx context.Context) (*types.Receipt, error) {
+		return receiptInBlock(header), nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotNil(t, receipt)
+	assert.Equal(t, header.Hash(), receipt.BlockHash)
+}
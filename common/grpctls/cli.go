@@ -0,0 +1,104 @@
+package grpctls
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	CertFileFlagName       = "tls.cert-file"
+	KeyFileFlagName        = "tls.key-file"
+	ClientCAFileFlagName   = "tls.client-ca-file"
+	ReloadIntervalFlagName = "tls.reload-interval"
+
+	ClientEnabledFlagName  = "tls.enabled"
+	CAFileFlagName         = "tls.ca-file"
+	ClientCertFileFlagName = "tls.client-cert-file"
+	ClientKeyFileFlagName  = "tls.client-key-file"
+)
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, CertFileFlagName),
+			Usage:    "Path to the TLS certificate of the gRPC server. If set, the server only accepts TLS connections",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TLS_CERT_FILE"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, KeyFileFlagName),
+			Usage:    "Path to the private key of the TLS certificate of the gRPC server",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TLS_KEY_FILE"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, ClientCAFileFlagName),
+			Usage:    "Path to the PEM bundle of the CAs the client certificates are verified with. If set, the clients must present a certificate signed by one of them (mTLS)",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TLS_CLIENT_CA_FILE"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReloadIntervalFlagName),
+			Usage:    "How often the TLS files are checked for changes, e.g. after a certificate rotation. The files are only loaded at startup if 0",
+			Required: false,
+			Value:    time.Minute,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TLS_RELOAD_INTERVAL"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) (Config, error) {
+	cfg := Config{
+		CertFile:       ctx.GlobalString(common.PrefixFlag(flagPrefix, CertFileFlagName)),
+		KeyFile:        ctx.GlobalString(common.PrefixFlag(flagPrefix, KeyFileFlagName)),
+		ClientCAFile:   ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientCAFileFlagName)),
+		ReloadInterval: ctx.GlobalDuration(common.PrefixFlag(flagPrefix, ReloadIntervalFlagName)),
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func ClientCLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:   common.PrefixFlag(flagPrefix, ClientEnabledFlagName),
+			Usage:  "Connect to the gRPC servers over TLS",
+			EnvVar: common.PrefixEnvVar(envPrefix, "TLS_ENABLED"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, CAFileFlagName),
+			Usage:    "Path to the PEM bundle of the CAs the server certificates are verified with. The system roots are used if not set",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TLS_CA_FILE"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, ClientCertFileFlagName),
+			Usage:    "Path to the certificate presented to the gRPC servers requiring mTLS",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TLS_CLIENT_CERT_FILE"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, ClientKeyFileFlagName),
+			Usage:    "Path to the private key of the client certificate",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TLS_CLIENT_KEY_FILE"),
+		},
+	}
+}
+
+func ReadClientCLIConfig(ctx *cli.Context, flagPrefix string) (ClientConfig, error) {
+	cfg := ClientConfig{
+		Enabled:  ctx.GlobalBool(common.PrefixFlag(flagPrefix, ClientEnabledFlagName)),
+		CAFile:   ctx.GlobalString(common.PrefixFlag(flagPrefix, CAFileFlagName)),
+		CertFile: ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientCertFileFlagName)),
+		KeyFile:  ctx.GlobalString(common.PrefixFlag(flagPrefix, ClientKeyFileFlagName)),
+	}
+	if err := cfg.Validate(); err != nil {
+		return ClientConfig{}, err
+	}
+	return cfg, nil
+}
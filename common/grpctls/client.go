@@ -0,0 +1,60 @@
+package grpctls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ClientConfig is the TLS configuration of a gRPC client. The client connects in plaintext if TLS isn't enabled.
+type ClientConfig struct {
+	Enabled bool
+	// CAFile is the PEM bundle of the CAs the certificates of the servers are verified with. The system roots are used
+	// if empty.
+	CAFile string
+	// CertFile and KeyFile are the certificate the client presents to servers requiring mTLS.
+	CertFile string
+	KeyFile  string
+}
+
+func (c ClientConfig) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("the TLS client certificate and key files must be set together")
+	}
+	if !c.Enabled && (c.CAFile != "" || c.CertFile != "") {
+		return errors.New("the TLS files of a client require TLS to be enabled")
+	}
+	return nil
+}
+
+// DialOption returns the transport credentials of the client as a dial option.
+func (c ClientConfig) DialOption() (grpc.DialOption, error) {
+	if !c.Enabled {
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CAFile != "" {
+		data, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the TLS CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificate found in the TLS CA file %s", c.CAFile)
+		}
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(config)), nil
+}
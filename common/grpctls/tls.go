@@ -0,0 +1,209 @@
+package grpctls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Config is the TLS configuration of a gRPC server. The server serves plaintext if no certificate is set.
+type Config struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile is the PEM bundle of the CAs the certificates of the clients are verified with. If set, the server
+	// requires the clients to present a certificate signed by one of them.
+	ClientCAFile string
+	// ReloadInterval is how often the files are checked for changes, which are picked up by the next connections.
+	// The files are only loaded once if 0.
+	ReloadInterval time.Duration
+}
+
+// Enabled returns whether the server serves TLS.
+func (c Config) Enabled() bool {
+	return c.CertFile != ""
+}
+
+func (c Config) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("the TLS certificate and key files must be set together")
+	}
+	if c.ClientCAFile != "" && c.CertFile == "" {
+		return errors.New("the TLS client CA file requires a TLS certificate")
+	}
+	if c.ReloadInterval < 0 {
+		return errors.New("the TLS reload interval must not be negative")
+	}
+	return nil
+}
+
+// Reloader serves the certificate and the client CAs of a Config, reloading them when their files change so that
+// the certificates can be rotated without restarting the server. Files that fail to load, e.g. a certificate
+// rotated before its key, are retried at the next check while the previous ones are kept in use.
+type Reloader struct {
+	config Config
+	logger logging.Logger
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	versions  []fileVersion
+	lastCheck time.Time
+}
+
+// fileVersion identifies the content of a file by its modification time and size.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewReloader loads the files of the config, which must have TLS enabled.
+func NewReloader(config Config, logger logging.Logger) (*Reloader, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if !config.Enabled() {
+		return nil, errors.New("no TLS certificate configured")
+	}
+	r := &Reloader{
+		config: config,
+		logger: logger.With("component", "TLSReloader"),
+	}
+	versions, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(versions); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Certificate returns the current certificate, with its leaf parsed.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maybeReload()
+	return r.cert
+}
+
+// TLSConfig returns the server TLS config, which presents the current certificate and verifies the clients with the
+// current CAs at each handshake.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.maybeReload()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				// gRPC requires HTTP/2 to be negotiated with ALPN.
+				NextProtos: []string{"h2"},
+			}
+			if r.clientCAs != nil {
+				config.ClientCAs = r.clientCAs
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
+
+// maybeReload reloads the files if they changed since they were loaded, at most once per reload interval.
+// It must be called with the lock held.
+func (r *Reloader) maybeReload() {
+	if r.config.ReloadInterval == 0 || time.Since(r.lastCheck) < r.config.ReloadInterval {
+		return
+	}
+	r.lastCheck = time.Now()
+
+	versions, err := r.stat()
+	if err != nil {
+		r.logger.Warn("Could not check the TLS files for changes", "err", err)
+		return
+	}
+	changed := false
+	for i := range versions {
+		if versions[i] != r.versions[i] {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := r.load(versions); err != nil {
+		r.logger.Warn("Could not reload the TLS files; keeping the previous ones", "err", err)
+		return
+	}
+	r.logger.Info("Reloaded the TLS files", "cert", r.config.CertFile, "notAfter", r.cert.Leaf.NotAfter)
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+	return files
+}
+
+func (r *Reloader) stat() ([]fileVersion, error) {
+	files := r.files()
+	versions := make([]fileVersion, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		versions[i] = fileVersion{modTime: info.ModTime(), size: info.Size()}
+	}
+	return versions, nil
+}
+
+func (r *Reloader) load(versions []fileVersion) error {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("failed to parse the TLS certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.config.ClientCAFile != "" {
+		data, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the TLS client CA file: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificate found in the TLS client CA file %s", r.config.ClientCAFile)
+		}
+	}
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.versions = versions
+	return nil
+}
+
+// ServerOptions returns the options to serve gRPC with the TLS config, which are empty if TLS isn't enabled.
+func ServerOptions(config Config, logger logging.Logger) ([]grpc.ServerOption, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+	reloader, err := NewReloader(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(reloader.TLSConfig()))}, nil
+}
@@ -0,0 +1,158 @@
+package grpctls_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// writeCA writes the certificate of the CA in PEM to a file in dir and returns its path.
+func (ca *testCA) writeCA(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	return path
+}
+
+// issue writes a certificate for localhost signed by the CA, along with its key, to files in dir and returns their
+// paths.
+func (ca *testCA) issue(t *testing.T, dir string, name string, serial int64) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, name+".pem")
+	keyPath := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certPath, keyPath
+}
+
+func serveHealth(t *testing.T, config grpctls.Config) string {
+	opts, err := grpctls.ServerOptions(config, logging.NewNoopLogger())
+	require.NoError(t, err)
+	gs := grpc.NewServer(opts...)
+	grpc_health_v1.RegisterHealthServer(gs, health.NewServer())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = gs.Serve(listener) }()
+	t.Cleanup(gs.Stop)
+	return listener.Addr().String()
+}
+
+func checkHealth(addr string, config grpctls.ClientConfig) error {
+	credentials, err := config.DialOption()
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.Dial(addr, credentials)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := ca.writeCA(t, dir)
+	serverCert, serverKey := ca.issue(t, dir, "server", 2)
+	clientCert, clientKey := ca.issue(t, dir, "client", 3)
+
+	addr := serveHealth(t, grpctls.Config{CertFile: serverCert, KeyFile: serverKey, ClientCAFile: caFile})
+
+	assert.NoError(t, checkHealth(addr, grpctls.ClientConfig{Enabled: true, CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}))
+	// The clients must present a certificate signed by the client CA
+	assert.Error(t, checkHealth(addr, grpctls.ClientConfig{Enabled: true, CAFile: caFile}))
+	otherCert, otherKey := newTestCA(t).issue(t, t.TempDir(), "other", 4)
+	assert.Error(t, checkHealth(addr, grpctls.ClientConfig{Enabled: true, CAFile: caFile, CertFile: otherCert, KeyFile: otherKey}))
+	// The server only accepts TLS connections
+	assert.Error(t, checkHealth(addr, grpctls.ClientConfig{}))
+
+	// Without a client CA, the clients don't need a certificate
+	addr = serveHealth(t, grpctls.Config{CertFile: serverCert, KeyFile: serverKey})
+	assert.NoError(t, checkHealth(addr, grpctls.ClientConfig{Enabled: true, CAFile: caFile}))
+}
+
+func TestReloadCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, dir, "server", 2)
+
+	reloader, err := grpctls.NewReloader(grpctls.Config{CertFile: certFile, KeyFile: keyFile, ReloadInterval: time.Nanosecond}, logging.NewNoopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), reloader.Certificate().Leaf.SerialNumber.Int64())
+
+	// A rotated certificate is picked up once both files are updated
+	ca.issue(t, dir, "server", 3)
+	assert.Equal(t, int64(3), reloader.Certificate().Leaf.SerialNumber.Int64())
+
+	// A certificate that fails to load keeps the previous one in use
+	require.NoError(t, os.WriteFile(certFile, []byte("junk"), 0600))
+	assert.Equal(t, int64(3), reloader.Certificate().Leaf.SerialNumber.Int64())
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, grpctls.Config{}.Validate())
+	assert.NoError(t, grpctls.Config{CertFile: "cert", KeyFile: "key", ClientCAFile: "ca"}.Validate())
+	assert.Error(t, grpctls.Config{CertFile: "cert"}.Validate())
+	assert.Error(t, grpctls.Config{ClientCAFile: "ca"}.Validate())
+
+	assert.NoError(t, grpctls.ClientConfig{}.Validate())
+	assert.Error(t, grpctls.ClientConfig{CAFile: "ca"}.Validate())
+	assert.Error(t, grpctls.ClientConfig{Enabled: true, CertFile: "cert"}.Validate())
+}
@@ -9,7 +9,16 @@ import (
 // RegisterHealthServer registers the default gRPC health check server implementation
 // with the given gRPC server.
 func RegisterHealthServer(name string, server *grpc.Server) {
+	RegisterHealthServerForServices(server, name)
+}
+
+// RegisterHealthServerForServices registers the default gRPC health check server implementation
+// with the given gRPC server, reporting each of the named services as serving. A gRPC server can
+// only have one health server, so the services it serves are registered together.
+func RegisterHealthServerForServices(server *grpc.Server, names ...string) {
 	healthServer := health.NewServer()
-	healthServer.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_SERVING)
+	for _, name := range names {
+		healthServer.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_SERVING)
+	}
 	grpc_health_v1.RegisterHealthServer(server, healthServer)
 }
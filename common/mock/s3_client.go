@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"io"
 	"strings"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
@@ -30,6 +31,15 @@ func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string,
 	return nil
 }
 
+func (s *S3Client) UploadObjectStream(ctx context.Context, bucket string, key string, body io.Reader) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	s.bucket[key] = data
+	return nil
+}
+
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
 	delete(s.bucket, key)
 	return nil
@@ -0,0 +1,225 @@
+// Package nats publishes messages to a NATS server. It implements the subset of the NATS client protocol needed to
+// publish, over plain TCP: the server's INFO, the client's CONNECT, PUB, and the PING/PONG keepalive. Publishing is
+// at most once, like core NATS, and a lost connection is reestablished by the next publish.
+package nats
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultPort = "4222"
+
+// ErrTLSRequired is returned when the server only accepts TLS connections, which the publisher doesn't support
+var ErrTLSRequired = errors.New("the NATS server requires TLS")
+
+type serverInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+	MaxPayload   int  `json:"max_payload"`
+}
+
+type connectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// Publisher publishes messages to the subjects of a NATS server
+type Publisher struct {
+	address string
+	options connectOptions
+	timeout time.Duration
+
+	mu         sync.Mutex
+	conn       net.Conn
+	writer     *bufio.Writer
+	maxPayload int
+	// connErr is the error which broke the connection, reported by the server or met reading from it
+	connErr error
+}
+
+// NewPublisher returns a Publisher to the server at the url, nats://[user:pass@]host[:port] or
+// nats://token@host[:port], identifying itself with the name. The timeout bounds the connection and each publish.
+func NewPublisher(serverURL, name string, timeout time.Duration) (*Publisher, error) {
+	if !strings.Contains(serverURL, "://") {
+		serverURL = "nats://" + serverURL
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS url: %w", err)
+	}
+	if u.Scheme != "nats" {
+		return nil, fmt.Errorf("unsupported NATS url scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("the NATS url has no host")
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+
+	options := connectOptions{Name: name, Lang: "go"}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			options.User, options.Pass = u.User.Username(), pass
+		} else {
+			options.Token = u.User.Username()
+		}
+	}
+	return &Publisher{
+		address: net.JoinHostPort(u.Hostname(), port),
+		options: options,
+		timeout: timeout,
+	}, nil
+}
+
+// Publish sends the data to the subject. It connects to the server if the publisher isn't connected yet or lost its
+// connection.
+func (p *Publisher) Publish(subject string, data []byte) error {
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return fmt.Errorf("invalid NATS subject %q", subject)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.connErr != nil {
+		p.closeLocked()
+	}
+	if p.conn == nil {
+		if err := p.connectLocked(); err != nil {
+			return err
+		}
+	}
+	if p.maxPayload > 0 && len(data) > p.maxPayload {
+		return fmt.Errorf("the message of %d bytes exceeds the max payload of %d bytes of the NATS server", len(data), p.maxPayload)
+	}
+
+	_ = p.conn.SetWriteDeadline(time.Now().Add(p.timeout))
+	fmt.Fprintf(p.writer, "PUB %s %d\r\n", subject, len(data))
+	_, _ = p.writer.Write(data)
+	_, _ = p.writer.WriteString("\r\n")
+	if err := p.writer.Flush(); err != nil {
+		p.closeLocked()
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the server
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closeLocked()
+}
+
+func (p *Publisher) closeLocked() error {
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn, p.writer, p.connErr = nil, nil, nil
+	return err
+}
+
+// connectLocked opens the connection and waits for the server to acknowledge the CONNECT, so that authentication
+// errors surface before publishing
+func (p *Publisher) connectLocked() error {
+	conn, err := net.DialTimeout("tcp", p.address, p.timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS at %s: %w", p.address, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(p.timeout))
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to read the NATS server info: %w", err)
+	}
+	payload, ok := strings.CutPrefix(strings.TrimSpace(line), "INFO ")
+	if !ok {
+		conn.Close()
+		return fmt.Errorf("unexpected NATS server greeting %q", strings.TrimSpace(line))
+	}
+	var info serverInfo
+	if err := json.Unmarshal([]byte(payload), &info); err != nil {
+		conn.Close()
+		return fmt.Errorf("invalid NATS server info: %w", err)
+	}
+	if info.TLSRequired {
+		conn.Close()
+		return ErrTLSRequired
+	}
+
+	options, err := json.Marshal(p.options)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	writer := bufio.NewWriter(conn)
+	fmt.Fprintf(writer, "CONNECT %s\r\nPING\r\n", options)
+	if err := writer.Flush(); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to connect to NATS: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return fmt.Errorf("the NATS server rejected the connection: %s", line)
+		}
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	p.conn, p.writer, p.maxPayload = conn, writer, info.MaxPayload
+	go p.read(conn, reader)
+	return nil
+}
+
+// read answers the pings of the server, and records the error breaking the connection, if any
+func (p *Publisher) read(conn net.Conn, reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err == nil && strings.HasPrefix(line, "-ERR") {
+			err = fmt.Errorf("the NATS server reported an error: %s", strings.TrimSpace(line))
+		}
+		p.mu.Lock()
+		if p.conn != conn {
+			// The connection was closed by the publisher
+			p.mu.Unlock()
+			return
+		}
+		if err != nil {
+			p.connErr = err
+			p.mu.Unlock()
+			return
+		}
+		if strings.TrimSpace(line) == "PING" {
+			_ = conn.SetWriteDeadline(time.Now().Add(p.timeout))
+			_, _ = p.writer.WriteString("PONG\r\n")
+			_ = p.writer.Flush()
+		}
+		p.mu.Unlock()
+	}
+}
@@ -0,0 +1,103 @@
+package nats_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/nats"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeServer accepts NATS connections and sends the messages published to it on the messages channel
+type fakeServer struct {
+	listener net.Listener
+	info     string
+	connects chan string
+	messages chan string
+}
+
+func newFakeServer(t *testing.T, info string) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	s := &fakeServer{
+		listener: listener,
+		info:     info,
+		connects: make(chan string, 10),
+		messages: make(chan string, 10),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO %s\r\n", s.info)
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			s.connects <- strings.TrimPrefix(line, "CONNECT ")
+		case line == "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case strings.HasPrefix(line, "PUB "):
+			var subject string
+			var size int
+			if _, err := fmt.Sscanf(line, "PUB %s %d", &subject, &size); err != nil {
+				return
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			s.messages <- subject + " " + string(payload[:size])
+		}
+	}
+}
+
+func TestPublisher(t *testing.T) {
+	server := newFakeServer(t, `{"max_payload":16}`)
+	publisher, err := nats.NewPublisher("nats://user:pass@"+server.listener.Addr().String(), "test", time.Second)
+	assert.NoError(t, err)
+	defer publisher.Close()
+
+	assert.NoError(t, publisher.Publish("eigenda.batch_confirmed", []byte("hello")))
+	assert.Equal(t, `{"verbose":false,"pedantic":false,"name":"test","lang":"go","user":"user","pass":"pass"}`, <-server.connects)
+	assert.Equal(t, "eigenda.batch_confirmed hello", <-server.messages)
+
+	assert.ErrorContains(t, publisher.Publish("eigenda.batch_confirmed", []byte("a message larger than 16 bytes")), "exceeds the max payload")
+	assert.ErrorContains(t, publisher.Publish("invalid subject", nil), "invalid NATS subject")
+
+	// A closed connection is reopened by the next publish
+	assert.NoError(t, publisher.Close())
+	assert.NoError(t, publisher.Publish("eigenda.operator_registered", []byte("again")))
+	assert.Equal(t, "eigenda.operator_registered again", <-server.messages)
+}
+
+func TestPublisherTLSRequired(t *testing.T) {
+	server := newFakeServer(t, `{"tls_required":true}`)
+	publisher, err := nats.NewPublisher(server.listener.Addr().String(), "test", time.Second)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, publisher.Publish("eigenda.batch_confirmed", nil), nats.ErrTLSRequired)
+
+	_, err = nats.NewPublisher("kafka://localhost:9092", "test", time.Second)
+	assert.Error(t, err)
+}
@@ -19,7 +19,10 @@ type RequestParams struct {
 	RequesterID RequesterID
 	BlobSize    uint
 	Rate        RateParam
-	Info        interface{}
+	// BurstMultiplier scales the size of every bucket of the requester, allowing it to burst above the sizes given
+	// in GlobalRateParams. A value of 0 is treated as 1.
+	BurstMultiplier float32
+	Info            interface{}
 }
 
 type RateLimiter interface {
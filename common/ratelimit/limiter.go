@@ -82,7 +82,9 @@ func (d *rateLimiter) checkAllowed(ctx context.Context, params common.RequestPar
 	if err != nil {
 
 		bucketLevels := make([]time.Duration, len(d.globalRateParams.BucketSizes))
-		copy(bucketLevels, d.globalRateParams.BucketSizes)
+		for i, size := range d.globalRateParams.BucketSizes {
+			bucketLevels[i] = burstSize(size, params.BurstMultiplier)
+		}
 
 		bucketParams = &common.RateBucketParams{
 			BucketLevels:    bucketLevels,
@@ -100,6 +102,8 @@ func (d *rateLimiter) checkAllowed(ctx context.Context, params common.RequestPar
 	allowed := true
 	for i, size := range d.globalRateParams.BucketSizes {
 
+		size = burstSize(size, params.BurstMultiplier)
+
 		// Determine bucket deduction
 		deduction := time.Microsecond * time.Duration(1e6*float32(params.BlobSize)/float32(params.Rate)/d.globalRateParams.Multipliers[i])
 
@@ -116,6 +120,14 @@ func (d *rateLimiter) checkAllowed(ctx context.Context, params common.RequestPar
 
 }
 
+// burstSize returns the size of a bucket after applying the burst multiplier of the requester.
+func burstSize(bucketSize time.Duration, burstMultiplier float32) time.Duration {
+	if burstMultiplier <= 0 {
+		return bucketSize
+	}
+	return time.Duration(float64(bucketSize) * float64(burstMultiplier))
+}
+
 func getBucketLevel(bucketLevel, bucketSize, interval, deduction time.Duration) time.Duration {
 
 	newLevel := bucketLevel + interval - deduction
@@ -9,6 +9,8 @@ import (
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -58,3 +60,86 @@ func TestRatelimit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, allow)
 }
+
+func TestRatelimitBurst(t *testing.T) {
+
+	ratelimiter, err := makeTestRatelimiter()
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	params := []common.RequestParams{
+		{
+			RequesterID:     "testRetriever",
+			BlobSize:        10,
+			Rate:            100,
+			BurstMultiplier: 2,
+		},
+	}
+
+	for i := 0; i < 20; i++ {
+		allow, _, err := ratelimiter.AllowRequest(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, true, allow)
+	}
+
+	allow, _, err := ratelimiter.AllowRequest(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, false, allow)
+}
+
+func TestRedisRatelimitShared(t *testing.T) {
+
+	server := miniredis.RunT(t)
+	globalParams := common.GlobalRateParams{
+		BucketSizes: []time.Duration{time.Second, time.Minute},
+		Multipliers: []float32{1, 1},
+	}
+
+	// Two limiters backed by the same redis behave like a single limiter
+	limiters := make([]common.RateLimiter, 2)
+	for i := range limiters {
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		limiters[i] = ratelimit.NewRedisRateLimiter(globalParams, client, "{ratelimit}:", logging.NewNoopLogger())
+	}
+
+	ctx := context.Background()
+
+	params := []common.RequestParams{
+		{
+			RequesterID: "testRetriever",
+			BlobSize:    10,
+			Rate:        100,
+		},
+	}
+
+	for i := 0; i < 10; i++ {
+		allow, _, err := limiters[i%2].AllowRequest(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, true, allow)
+	}
+
+	allow, limited, err := limiters[0].AllowRequest(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, false, allow)
+	assert.Equal(t, "testRetriever", limited.RequesterID)
+
+	// Other requesters and bursting requesters are limited separately
+	params = []common.RequestParams{
+		{
+			RequesterID:     "otherRetriever",
+			BlobSize:        10,
+			Rate:            100,
+			BurstMultiplier: 2,
+		},
+	}
+	for i := 0; i < 20; i++ {
+		allow, _, err := limiters[i%2].AllowRequest(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, true, allow)
+	}
+	allow, _, err = limiters[1].AllowRequest(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, false, allow)
+	assert.True(t, server.Exists("{ratelimit}:otherRetriever"))
+}
@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/redis/go-redis/v9"
+)
+
+// allowRequestScript atomically applies a request to the buckets of every requester in KEYS. The bucket levels and
+// the time of the last request (both in microseconds) are kept in a hash per requester.
+//
+// ARGV: number of buckets, current time, count failed ("1" or "0"), key TTL in milliseconds, followed by the size of
+// each bucket for each key and then the deduction of each bucket for each key.
+//
+// The script returns 0 if the request is allowed, otherwise the 1-based index of the first key that was limited.
+// Bucket levels are only written back when the request is allowed or failed requests are counted.
+var allowRequestScript = redis.NewScript(`
+local numBuckets = tonumber(ARGV[1])
+local now = tonumber(ARGV[2])
+local countFailed = ARGV[3] == "1"
+local ttl = tonumber(ARGV[4])
+local sizeOffset = 4
+local deductionOffset = sizeOffset + #KEYS * numBuckets
+
+local fields = {"last"}
+for b = 1, numBuckets do
+	fields[b + 1] = tostring(b)
+end
+
+local limited = 0
+local updated = {}
+for k = 1, #KEYS do
+	local state = redis.call("HMGET", KEYS[k], unpack(fields))
+	local last = tonumber(state[1])
+	local interval = 0
+	if last ~= nil and now > last then
+		interval = now - last
+	end
+
+	local allowed = true
+	local levels = {}
+	for b = 1, numBuckets do
+		local size = tonumber(ARGV[sizeOffset + (k - 1) * numBuckets + b])
+		local deduction = tonumber(ARGV[deductionOffset + (k - 1) * numBuckets + b])
+		local level = size
+		if last ~= nil then
+			level = tonumber(state[b + 1]) or size
+		end
+		level = math.min(math.max(level + interval - deduction, 0), size)
+		levels[b] = level
+		allowed = allowed and level > 0
+	end
+	updated[k] = levels
+
+	if not allowed and limited == 0 then
+		limited = k
+		if not countFailed then
+			break
+		end
+	end
+end
+
+if limited == 0 or countFailed then
+	for k, levels in pairs(updated) do
+		local args = {"last", now}
+		for b = 1, numBuckets do
+			args[#args + 1] = tostring(b)
+			args[#args + 1] = levels[b]
+		end
+		redis.call("HSET", KEYS[k], unpack(args))
+		redis.call("PEXPIRE", KEYS[k], ttl)
+	end
+end
+
+return limited
+`)
+
+type redisRateLimiter struct {
+	globalRateParams common.GlobalRateParams
+	client           redis.UniversalClient
+	keyPrefix        string
+
+	logger logging.Logger
+}
+
+// NewRedisRateLimiter creates a rate limiter whose buckets are kept in Redis, so that all instances sharing the same
+// Redis deployment enforce a single set of limits. Each call to AllowRequest is evaluated atomically in a Lua script.
+// All keys of a request must hash to the same slot when Redis Cluster is used, so the key prefix should contain a
+// hash tag (e.g. "{ratelimit}:") in that case.
+func NewRedisRateLimiter(rateParams common.GlobalRateParams, client redis.UniversalClient, keyPrefix string, logger logging.Logger) common.RateLimiter {
+	return &redisRateLimiter{
+		globalRateParams: rateParams,
+		client:           client,
+		keyPrefix:        keyPrefix,
+		logger:           logger.With("component", "RedisRateLimiter"),
+	}
+}
+
+// AllowRequest has the same semantics as the in-memory rate limiter, but the check and the update of all buckets
+// happen in a single round trip to Redis.
+func (d *redisRateLimiter) AllowRequest(ctx context.Context, params []common.RequestParams) (bool, *common.RequestParams, error) {
+	if len(params) == 0 {
+		return true, nil, nil
+	}
+
+	numBuckets := len(d.globalRateParams.BucketSizes)
+	keys := make([]string, len(params))
+	sizes := make([]interface{}, 0, len(params)*numBuckets)
+	deductions := make([]interface{}, 0, len(params)*numBuckets)
+	var maxSize time.Duration
+	for i, param := range params {
+		keys[i] = d.keyPrefix + param.RequesterID
+		for j, size := range d.globalRateParams.BucketSizes {
+			size = burstSize(size, param.BurstMultiplier)
+			if size > maxSize {
+				maxSize = size
+			}
+			deduction := 1e6 * float32(param.BlobSize) / float32(param.Rate) / d.globalRateParams.Multipliers[j]
+			sizes = append(sizes, size.Microseconds())
+			deductions = append(deductions, int64(deduction))
+		}
+	}
+
+	countFailed := "0"
+	if d.globalRateParams.CountFailed {
+		countFailed = "1"
+	}
+	// A bucket that has been idle for longer than its size is full again, so its state no longer needs to be kept
+	ttl := maxSize + time.Second
+
+	args := make([]interface{}, 0, 4+len(sizes)+len(deductions))
+	args = append(args, numBuckets, time.Now().UnixMicro(), countFailed, ttl.Milliseconds())
+	args = append(args, sizes...)
+	args = append(args, deductions...)
+
+	limited, err := allowRequestScript.Run(ctx, d.client, keys, args...).Int()
+	if err != nil {
+		return false, nil, err
+	}
+	if limited == 0 {
+		return true, nil, nil
+	}
+
+	limitedParam := params[limited-1]
+	d.logger.Debug("Request limited", "key", limitedParam.RequesterID)
+	return false, &limitedParam, nil
+}
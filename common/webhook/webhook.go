@@ -0,0 +1,88 @@
+// Package webhook signs the bodies of the webhooks the EigenDA services send, and verifies them on the receiving end.
+//
+// The signature is sent in the SignatureHeader as t=<timestamp>,v1=<signature>, where the timestamp is the unix time
+// the webhook was sent at, and the signature the hex encoded HMAC-SHA256, keyed by the secret shared with the
+// receiver, of the timestamp and the body joined by a dot. The timestamp lets receivers reject replayed webhooks.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader is the HTTP header carrying the signature of a webhook
+const SignatureHeader = "X-EigenDA-Signature"
+
+// ErrInvalidSignature is returned when the signature of a webhook doesn't verify
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the signature header of the body sent at the timestamp
+func Sign(secret []byte, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac(secret, ts, body)))
+}
+
+// Verify checks that the signature header is a signature of the body with the secret, sent no more than tolerance
+// away from now
+func Verify(secret []byte, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signature = value
+		}
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing timestamp", ErrInvalidSignature)
+	}
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp %d is outside the tolerance of %v", ErrInvalidSignature, unix, tolerance)
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, mac(secret, ts, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func mac(secret []byte, ts string, body []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+// Post sends the JSON body to the webhook at the url, signed with the secret
+func Post(ctx context.Context, client *http.Client, url string, secret []byte, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now(), body))
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with status %d", url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`{"num_blobs":3}`)
+	now := time.Now()
+	header := webhook.Sign(secret, now, body)
+
+	assert.NoError(t, webhook.Verify(secret, header, body, now.Add(time.Second), time.Minute))
+	assert.ErrorIs(t, webhook.Verify([]byte("other"), header, body, now, time.Minute), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify(secret, header, []byte(`{"num_blobs":4}`), now, time.Minute), webhook.ErrInvalidSignature)
+	// Replays are rejected once outside the tolerance
+	assert.ErrorIs(t, webhook.Verify(secret, header, body, now.Add(2*time.Minute), time.Minute), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify(secret, "v1=00", body, now, time.Minute), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.Verify(secret, "", body, now, time.Minute), webhook.ErrInvalidSignature)
+}
+
+func TestPost(t *testing.T) {
+	secret := []byte("secret")
+	var verifyErr error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verifyErr = webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute)
+		if verifyErr != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	assert.NoError(t, webhook.Post(context.Background(), server.Client(), server.URL, secret, []byte(`{}`)))
+	assert.NoError(t, verifyErr)
+	assert.Error(t, webhook.Post(context.Background(), server.Client(), server.URL, []byte("other"), []byte(`{}`)))
+	assert.ErrorIs(t, verifyErr, webhook.ErrInvalidSignature)
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/core/threshold"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -26,6 +28,8 @@ type SignerMessage struct {
 	Signature *Signature
 	Operator  OperatorID
 	Err       error
+	// ResponseTime is the time the operator took to respond. It's 0 if the batch wasn't sent to the operator
+	ResponseTime time.Duration
 }
 
 // SignatureAggregation contains the results of aggregating signatures from a set of operators
@@ -51,16 +55,32 @@ type SignatureAggregation struct {
 // SignatureAggregator is an interface for aggregating the signatures returned by DA nodes so that they can be verified by the DA contract
 type SignatureAggregator interface {
 
-	// AggregateSignatures blocks until it receives a response for each operator in the operator state via messageChan, and then returns the aggregated signature.
+	// AggregateSignatures blocks until it receives a response for each operator in the operator state via messageChan, or until the attestation of every
+	// quorum is settled, and then returns the aggregated signature.
 	// If the aggregated signature is invalid, an error is returned.
 	AggregateSignatures(ctx context.Context, state *IndexedOperatorState, quorumIDs []QuorumID, message [32]byte, messageChan chan SignerMessage) (*SignatureAggregation, error)
 }
 
+// QuorumAttestationConfig is how the signatures of the operators of a quorum are collected, so that the quorums whose
+// operators respond slower than the others don't hold up the attestation of the other quorums
+type QuorumAttestationConfig struct {
+	// Timeout is how long after the aggregation starts the quorum waits for the signatures of all its operators. Past
+	// it, the attestation of the quorum is settled as soon as Threshold is signed. The quorum waits for all its
+	// operators if it's 0.
+	Timeout time.Duration
+	// Threshold is the percentage of the stake of the quorum which settles its attestation past Timeout. Below it,
+	// the quorum keeps waiting for the signatures of its operators, rather than failing the blobs of the quorum.
+	Threshold uint8
+}
+
 type StdSignatureAggregator struct {
 	Logger     logging.Logger
 	Transactor Transactor
 	// OperatorAddresses contains the ethereum addresses of the operators corresponding to their operator IDs
 	OperatorAddresses *lru.Cache[OperatorID, gethcommon.Address]
+	// QuorumConfigs are the attestation configs of the quorums. The quorums without a config wait for all their
+	// operators.
+	QuorumConfigs map[QuorumID]QuorumAttestationConfig
 }
 
 func NewStdSignatureAggregator(logger logging.Logger, transactor Transactor) (*StdSignatureAggregator, error) {
@@ -102,12 +122,51 @@ func (a *StdSignatureAggregator) AggregateSignatures(ctx context.Context, state
 
 	signerMap := make(map[OperatorID]bool)
 
+	// Track the operators each quorum is waiting for, to settle the quorums which reach their threshold past their
+	// timeout without waiting for the slowest operators of the other quorums
+	start := time.Now()
+	pending := make([]int, len(quorumIDs))
+	for ind, quorumID := range quorumIDs {
+		pending[ind] = len(state.Operators[quorumID])
+	}
+	settled := func() bool {
+		for ind, quorumID := range quorumIDs {
+			if pending[ind] == 0 {
+				continue
+			}
+			config, ok := a.QuorumConfigs[quorumID]
+			if !ok || config.Timeout == 0 || time.Since(start) < config.Timeout {
+				return false
+			}
+			if !threshold.PercentMeetsThreshold(GetSignedPercentage(state.OperatorState, quorumID, stakeSigned[ind]), config.Threshold) {
+				return false
+			}
+		}
+		return true
+	}
+
 	// Aggregate Signatures
 	numOperators := len(state.IndexedOperators)
 
-	for numReply := 0; numReply < numOperators; numReply++ {
+	for numReply := 0; numReply < numOperators; {
+		if settled() {
+			a.Logger.Info("attestation of all quorums settled before all operators responded", "numReplies", numReply, "numOperators", numOperators)
+			break
+		}
+		var r SignerMessage
+		select {
+		case r = <-messageChan:
+		case <-a.nextQuorumTimeout(start, quorumIDs, pending):
+			continue
+		}
+		numReply++
+		for ind, quorumID := range quorumIDs {
+			if _, ok := state.Operators[quorumID][r.Operator]; ok {
+				pending[ind]--
+			}
+		}
+
 		var err error
-		r := <-messageChan
 		operatorIDHex := r.Operator.Hex()
 		operatorAddr, ok := a.OperatorAddresses.Get(r.Operator)
 		if !ok && a.Transactor != nil {
@@ -259,23 +318,30 @@ func (a *StdSignatureAggregator) AggregateSignatures(ctx context.Context, state
 
 }
 
-func GetStakeThreshold(state *OperatorState, quorum QuorumID, quorumThreshold uint8) *big.Int {
-
-	// Get stake threshold
-	quorumThresholdBig := new(big.Int).SetUint64(uint64(quorumThreshold))
-	stakeThreshold := new(big.Int)
-	stakeThreshold.Mul(quorumThresholdBig, state.Totals[quorum].Stake)
-	stakeThreshold = roundUpDivideBig(stakeThreshold, new(big.Int).SetUint64(percentMultiplier))
+// nextQuorumTimeout returns a channel receiving when the next timeout of the quorums still waiting for operators
+// passes, or nil if no quorum has a timeout left
+func (a *StdSignatureAggregator) nextQuorumTimeout(start time.Time, quorumIDs []QuorumID, pending []int) <-chan time.Time {
+	next := time.Duration(0)
+	elapsed := time.Since(start)
+	for ind, quorumID := range quorumIDs {
+		config, ok := a.QuorumConfigs[quorumID]
+		if !ok || pending[ind] == 0 || config.Timeout <= elapsed {
+			continue
+		}
+		if next == 0 || config.Timeout < next {
+			next = config.Timeout
+		}
+	}
+	if next == 0 {
+		return nil
+	}
+	return time.After(next - elapsed)
+}
 
-	return stakeThreshold
+func GetStakeThreshold(state *OperatorState, quorum QuorumID, quorumThreshold uint8) *big.Int {
+	return threshold.StakeThreshold(state.Totals[quorum].Stake, quorumThreshold)
 }
 
 func GetSignedPercentage(state *OperatorState, quorum QuorumID, stakeAmount *big.Int) uint8 {
-
-	stakeAmount = stakeAmount.Mul(stakeAmount, new(big.Int).SetUint64(percentMultiplier))
-	quorumThresholdBig := stakeAmount.Div(stakeAmount, state.Totals[quorum].Stake)
-
-	quorumThreshold := uint8(quorumThresholdBig.Uint64())
-
-	return quorumThreshold
+	return threshold.SignedPercentage(stakeAmount, state.Totals[quorum].Stake)
 }
@@ -6,12 +6,14 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -204,3 +206,57 @@ func TestSortNonsigners(t *testing.T) {
 		assert.Equal(t, currHashInt.Cmp(prevHashInt), 1)
 	}
 }
+
+func TestAggregateSignaturesQuorumTimeouts(t *testing.T) {
+	state := dat.GetTotalOperatorStateWithQuorums(context.Background(), 0, []core.QuorumID{0, 1})
+	transactor := &mock.MockTransactor{}
+	transactor.On("OperatorIDToAddress").Return(gethcommon.Address{}, nil)
+	quorumAgg, err := core.NewStdSignatureAggregator(logging.NewNoopLogger(), transactor)
+	require.NoError(t, err)
+	quorumAgg.QuorumConfigs = map[core.QuorumID]core.QuorumAttestationConfig{
+		0: {Timeout: 50 * time.Millisecond, Threshold: 70},
+		1: {Timeout: 50 * time.Millisecond, Threshold: 70},
+	}
+
+	// The last operator never responds, and the attestation settles once the quorums are past their timeouts
+	message := [32]byte{1, 2, 3, 4, 5, 6}
+	update := make(chan core.SignerMessage, len(state.PrivateOperators))
+	for i := 0; i < len(state.PrivateOperators)-1; i++ {
+		id := mock.MakeOperatorId(i)
+		update <- core.SignerMessage{
+			Signature: state.PrivateOperators[id].KeyPair.SignMessage(message),
+			Operator:  id,
+		}
+	}
+
+	start := time.Now()
+	sigAgg, err := quorumAgg.AggregateSignatures(context.Background(), state.IndexedOperatorState, []core.QuorumID{0, 1}, message, update)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Len(t, sigAgg.SignerMap, len(state.PrivateOperators)-1)
+	assert.GreaterOrEqual(t, sigAgg.QuorumResults[0].PercentSigned, uint8(70))
+	assert.Equal(t, uint8(100), sigAgg.QuorumResults[1].PercentSigned)
+
+	// Below its threshold, a quorum keeps waiting for its operators past its timeout
+	quorumAgg.QuorumConfigs[0] = core.QuorumAttestationConfig{Timeout: 10 * time.Millisecond, Threshold: 100}
+	update = make(chan core.SignerMessage, len(state.PrivateOperators))
+	for i := 0; i < len(state.PrivateOperators)-1; i++ {
+		id := mock.MakeOperatorId(i)
+		update <- core.SignerMessage{
+			Signature: state.PrivateOperators[id].KeyPair.SignMessage(message),
+			Operator:  id,
+		}
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		id := mock.MakeOperatorId(len(state.PrivateOperators) - 1)
+		update <- core.SignerMessage{
+			Signature: state.PrivateOperators[id].KeyPair.SignMessage(message),
+			Operator:  id,
+		}
+	}()
+	sigAgg, err = quorumAgg.AggregateSignatures(context.Background(), state.IndexedOperatorState, []core.QuorumID{0, 1}, message, update)
+	require.NoError(t, err)
+	assert.Len(t, sigAgg.SignerMap, len(state.PrivateOperators))
+	assert.Equal(t, uint8(100), sigAgg.QuorumResults[0].PercentSigned)
+}
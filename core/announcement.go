@@ -0,0 +1,18 @@
+package core
+
+import "time"
+
+// BatchAnnouncement is the notice the disperser sends to an operator ahead of an unusually large batch, so that the
+// operator can prepare its node for it.
+type BatchAnnouncement struct {
+	// ReferenceBlockNumber is the reference block number of the batch
+	ReferenceBlockNumber uint `json:"reference_block_number"`
+	// NumBlobs is the number of blobs encoded for the batch so far
+	NumBlobs int `json:"num_blobs"`
+	// Size is the size in bytes of the chunks encoded for the batch so far, across all the operators
+	Size uint64 `json:"size"`
+	// OperatorSize is the size in bytes of the chunks of the batch assigned to the operator
+	OperatorSize uint64 `json:"operator_size"`
+	// ETA is when the batch is expected to be dispersed
+	ETA time.Time `json:"eta"`
+}
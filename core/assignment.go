@@ -7,11 +7,11 @@ import (
 	"math"
 	"math/big"
 	"strings"
+
+	"github.com/Layr-Labs/eigenda/core/threshold"
 )
 
 const (
-	percentMultiplier = 100
-
 	// minChunkLength is the minimum chunk length supported. Generally speaking, it doesn't make sense for a chunk to be
 	// smaller than the proof overhead, which is equal to one G1 point.
 	MinChunkLength = 1
@@ -117,7 +117,7 @@ func (c *StdAssignmentCoordinator) GetAssignments(state *OperatorState, blobLeng
 	for _, r := range state.Operators[quorum] {
 
 		// m_i = ceil( B*S_i / C \gamma \sum_{j=1}^N S_j )
-		num := new(big.Int).Mul(big.NewInt(int64(blobLength*percentMultiplier)), r.Stake)
+		num := new(big.Int).Mul(big.NewInt(int64(blobLength*threshold.PercentMultiplier)), r.Stake)
 
 		gammaChunkLength := big.NewInt(int64(info.ChunkLength) * int64((info.ConfirmationThreshold - info.AdversaryThreshold)))
 		if gammaChunkLength.Cmp(big.NewInt(0)) <= 0 {
@@ -130,7 +130,7 @@ func (c *StdAssignmentCoordinator) GetAssignments(state *OperatorState, blobLeng
 		if denom.Cmp(big.NewInt(0)) == 0 {
 			return nil, AssignmentInfo{}, fmt.Errorf("gammaChunkLength %d and total stake %d in quorum %d must be greater than 0", gammaChunkLength, totalStakes, quorum)
 		}
-		m := roundUpDivideBig(num, denom)
+		m := threshold.RoundUpDivideBig(num, denom)
 
 		numChunks += uint(m.Uint64())
 		chunksByOperator[r.Index] = uint(m.Uint64())
@@ -202,11 +202,11 @@ func (c *StdAssignmentCoordinator) ValidateChunkLength(state *OperatorState, blo
 		if totalStake.Cmp(big.NewInt(0)) == 0 {
 			return false, fmt.Errorf("total stake in quorum %d must be greater than 0", info.QuorumID)
 		}
-		num := new(big.Int).Mul(big.NewInt(2*int64(blobLength*percentMultiplier)), minStake)
+		num := new(big.Int).Mul(big.NewInt(2*int64(blobLength*threshold.PercentMultiplier)), minStake)
 		denom := new(big.Int).Mul(big.NewInt(int64(info.ConfirmationThreshold-info.AdversaryThreshold)), totalStake)
-		maxChunkLength := uint(roundUpDivideBig(num, denom).Uint64())
+		maxChunkLength := uint(threshold.RoundUpDivideBig(num, denom).Uint64())
 
-		maxChunkLength2 := roundUpDivide(2*blobLength*percentMultiplier, MaxRequiredNumChunks*uint(info.ConfirmationThreshold-info.AdversaryThreshold))
+		maxChunkLength2 := threshold.RoundUpDivide(2*blobLength*threshold.PercentMultiplier, MaxRequiredNumChunks*uint(info.ConfirmationThreshold-info.AdversaryThreshold))
 
 		if maxChunkLength < maxChunkLength2 {
 			maxChunkLength = maxChunkLength2
@@ -262,20 +262,6 @@ func (c *StdAssignmentCoordinator) CalculateChunkLength(state *OperatorState, bl
 
 }
 
-func roundUpDivideBig(a, b *big.Int) *big.Int {
-
-	one := new(big.Int).SetUint64(1)
-	num := new(big.Int).Sub(new(big.Int).Add(a, b), one) // a + b - 1
-	res := new(big.Int).Div(num, b)                      // (a + b - 1) / b
-	return res
-
-}
-
-func roundUpDivide(a, b uint) uint {
-	return (a + b - 1) / b
-
-}
-
 func nextPowerOf2(d uint64) uint64 {
 	nextPower := math.Ceil(math.Log2(float64(d)))
 	return uint64(math.Pow(2.0, nextPower))
@@ -2,9 +2,11 @@ package core
 
 import (
 	"crypto/rand"
+	"fmt"
 	"math/big"
 
 	bn254utils "github.com/Layr-Labs/eigenda/core/bn254"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
@@ -52,15 +54,34 @@ func (p *G1Point) Serialize() []byte {
 	return res[:]
 }
 
+// Deserialize decodes a point serialized by Serialize, or compressed, checking that it is in the subgroup
 func (p *G1Point) Deserialize(data []byte) (*G1Point, error) {
 	var point bn254.G1Affine
-	_, err := point.SetBytes(data)
+	n, err := point.SetBytes(data)
 	if err != nil {
 		return nil, err
 	}
+	if n != len(data) {
+		return nil, fmt.Errorf("invalid G1 point length %d, expected %d", len(data), n)
+	}
 	return &G1Point{&point}, nil
 }
 
+// MarshalBinary encodes the point in the versioned binary format of the encoding package
+func (p *G1Point) MarshalBinary() ([]byte, error) {
+	return (*encoding.G1Commitment)(p.G1Affine).MarshalBinary()
+}
+
+// UnmarshalBinary decodes a point encoded by MarshalBinary, checking that it is in the subgroup
+func (p *G1Point) UnmarshalBinary(data []byte) error {
+	var point encoding.G1Commitment
+	if err := point.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	p.G1Affine = (*bn254.G1Affine)(&point)
+	return nil
+}
+
 func (p *G1Point) Clone() *G1Point {
 	return &G1Point{&bn254.G1Affine{
 		X: newFpElement(p.X.BigInt(new(big.Int))),
@@ -91,15 +112,34 @@ func (p *G2Point) Serialize() []byte {
 	return res[:]
 }
 
+// Deserialize decodes a point serialized by Serialize, or compressed, checking that it is in the subgroup
 func (p *G2Point) Deserialize(data []byte) (*G2Point, error) {
 	var point bn254.G2Affine
-	_, err := point.SetBytes(data)
+	n, err := point.SetBytes(data)
 	if err != nil {
 		return nil, err
 	}
+	if n != len(data) {
+		return nil, fmt.Errorf("invalid G2 point length %d, expected %d", len(data), n)
+	}
 	return &G2Point{&point}, nil
 }
 
+// MarshalBinary encodes the point in the versioned binary format of the encoding package
+func (p *G2Point) MarshalBinary() ([]byte, error) {
+	return (*encoding.G2Commitment)(p.G2Affine).MarshalBinary()
+}
+
+// UnmarshalBinary decodes a point encoded by MarshalBinary, checking that it is in the subgroup
+func (p *G2Point) UnmarshalBinary(data []byte) error {
+	var point encoding.G2Commitment
+	if err := point.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	p.G2Affine = (*bn254.G2Affine)(&point)
+	return nil
+}
+
 func (p *G2Point) Clone() *G2Point {
 	return &G2Point{&bn254.G2Affine{
 		X: struct {
@@ -175,14 +215,15 @@ func GenRandomBlsKeys() (*KeyPair, error) {
 	return MakeKeyPair(sk), nil
 }
 
+// SignMessage signs the message with the private key. The multiplication by the key runs in constant time.
 func (k *KeyPair) SignMessage(message [32]byte) *Signature {
 	H := bn254utils.MapToCurve(message)
-	sig := new(bn254.G1Affine).ScalarMultiplication(H, k.PrivKey.BigInt(new(big.Int)))
+	sig := bn254utils.ScalarMulG1(H, k.PrivKey)
 	return &Signature{&G1Point{sig}}
 }
 
 func (k *KeyPair) SignHashedToCurveMessage(g1HashedMsg *G1Point) *Signature {
-	sig := new(bn254.G1Affine).ScalarMultiplication(g1HashedMsg.G1Affine, k.PrivKey.BigInt(new(big.Int)))
+	sig := bn254utils.ScalarMulG1(g1HashedMsg.G1Affine, k.PrivKey)
 	return &Signature{&G1Point{sig}}
 }
 
@@ -8,3 +8,10 @@ type BlobRequestSigner interface {
 	SignBlobRequest(header BlobAuthHeader) ([]byte, error)
 	GetAccountID() string
 }
+
+// RequestSigner signs dispersal requests, which authenticates them as requests of its account without the
+// DisperseBlobAuthenticated handshake.
+type RequestSigner interface {
+	SignRequest(payloadHash []byte, nonce, expiry uint64) ([]byte, error)
+	GetAccountID() string
+}
@@ -66,3 +66,35 @@ func TestAuthenticationFail(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestRequestSignature(t *testing.T) {
+	payloadHash := []byte("payload hash")
+
+	// ECDSA requests are attributed to the address of the account
+	signer := auth.NewSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	requestSigner := signer.(core.RequestSigner)
+	signature, err := requestSigner.SignRequest(payloadHash, 1, 100)
+	assert.NoError(t, err)
+	account, err := auth.VerifyRequestSignature(signer.GetAccountID(), payloadHash, 1, 100, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xFCAd0B19bB29D4674531d6f115237E16AfCE377c", account)
+
+	// The nonce and the expiry are signed
+	_, err = auth.VerifyRequestSignature(signer.GetAccountID(), payloadHash, 2, 100, signature)
+	assert.Error(t, err)
+	_, err = auth.VerifyRequestSignature(signer.GetAccountID(), payloadHash, 1, 101, signature)
+	assert.Error(t, err)
+
+	// BLS requests are attributed to the public key of the account
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	blsSigner := auth.NewBLSSigner(keyPair)
+	signature, err = blsSigner.SignRequest(payloadHash, 1, 100)
+	assert.NoError(t, err)
+	account, err = auth.VerifyRequestSignature(blsSigner.GetAccountID(), payloadHash, 1, 100, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, blsSigner.GetAccountID(), account)
+
+	_, err = auth.VerifyRequestSignature(blsSigner.GetAccountID(), []byte("other payload hash"), 1, 100, signature)
+	assert.Error(t, err)
+}
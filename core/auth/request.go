@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RequestHash returns the hash signed by an account to authenticate a dispersal request: the keccak256 hash of the
+// payload hash of the blob, the nonce and the expiry of the request, the last two encoded as 8 bytes big endian.
+func RequestHash(payloadHash []byte, nonce, expiry uint64) [32]byte {
+	buf := make([]byte, 0, len(payloadHash)+16)
+	buf = append(buf, payloadHash...)
+	buf = binary.BigEndian.AppendUint64(buf, nonce)
+	buf = binary.BigEndian.AppendUint64(buf, expiry)
+	return crypto.Keccak256Hash(buf)
+}
+
+// VerifyRequestSignature verifies the signature of a dispersal request by the account. The account ID is either the
+// hex-encoded ECDSA public key of the account or its hex-encoded BLS (bn254 G2) public key. It returns the identity
+// the request is attributed to: the ethereum address of an ECDSA account, or the account ID of a BLS account.
+func VerifyRequestSignature(accountID string, payloadHash []byte, nonce, expiry uint64, signature []byte) (string, error) {
+	publicKeyBytes, err := hexutil.Decode(accountID)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode account ID (%v): %v", accountID, err)
+	}
+	hash := RequestHash(payloadHash, nonce, expiry)
+
+	// Uncompressed ECDSA public keys are 65 bytes, BLS public keys are 64 bytes compressed and 128 bytes uncompressed
+	if len(publicKeyBytes) == 65 {
+		pubKey, err := crypto.UnmarshalPubkey(publicKeyBytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode public key (%v): %v", accountID, err)
+		}
+		if len(signature) != 65 {
+			return "", fmt.Errorf("signature length is unexpected: %d", len(signature))
+		}
+		sigPublicKey, err := crypto.SigToPub(hash[:], signature)
+		if err != nil {
+			return "", fmt.Errorf("failed to recover public key from signature: %v", err)
+		}
+		if !bytes.Equal(crypto.FromECDSAPub(pubKey), crypto.FromECDSAPub(sigPublicKey)) {
+			return "", errors.New("signature doesn't match with provided public key")
+		}
+		return crypto.PubkeyToAddress(*pubKey).String(), nil
+	}
+
+	pubKey, err := new(core.G2Point).Deserialize(publicKeyBytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode public key (%v): %v", accountID, err)
+	}
+	sigPoint, err := new(core.G1Point).Deserialize(signature)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode signature: %v", err)
+	}
+	if !(&core.Signature{G1Point: sigPoint}).Verify(pubKey, hash) {
+		return "", errors.New("signature doesn't match with provided public key")
+	}
+	return accountID, nil
+}
+
+func (s *signer) SignRequest(payloadHash []byte, nonce, expiry uint64) ([]byte, error) {
+	hash := RequestHash(payloadHash, nonce, expiry)
+	sig, err := crypto.Sign(hash[:], s.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash: %v", err)
+	}
+	return sig, nil
+}
+
+type blsSigner struct {
+	keyPair *core.KeyPair
+}
+
+// NewBLSSigner returns a signer of dispersal requests for the account of the BLS key pair, whose account ID is the
+// hex-encoded G2 public key of the pair.
+func NewBLSSigner(keyPair *core.KeyPair) core.RequestSigner {
+	return &blsSigner{
+		keyPair: keyPair,
+	}
+}
+
+func (s *blsSigner) SignRequest(payloadHash []byte, nonce, expiry uint64) ([]byte, error) {
+	return s.keyPair.SignMessage(RequestHash(payloadHash, nonce, expiry)).Serialize(), nil
+}
+
+func (s *blsSigner) GetAccountID() string {
+	return hexutil.Encode(s.keyPair.GetPubKeyG2().Serialize())
+}
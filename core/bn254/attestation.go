@@ -83,14 +83,14 @@ func GetG2Generator() *bn254.G2Affine {
 	return g2Gen
 }
 
+// MulByGeneratorG1 returns [a]g1, in constant time since a is usually a secret key
 func MulByGeneratorG1(a *fr.Element) *bn254.G1Affine {
-	g1Gen := GetG1Generator()
-	return new(bn254.G1Affine).ScalarMultiplication(g1Gen, a.BigInt(new(big.Int)))
+	return ScalarMulG1(GetG1Generator(), a)
 }
 
+// MulByGeneratorG2 returns [a]g2, in constant time since a is usually a secret key
 func MulByGeneratorG2(a *fr.Element) *bn254.G2Affine {
-	g2Gen := GetG2Generator()
-	return new(bn254.G2Affine).ScalarMultiplication(g2Gen, a.BigInt(new(big.Int)))
+	return ScalarMulG2(GetG2Generator(), a)
 }
 
 func MakePubkeyRegistrationData(privKey *fr.Element, operatorAddress common.Address) *bn254.G1Affine {
@@ -106,5 +106,5 @@ func MakePubkeyRegistrationData(privKey *fr.Element, operatorAddress common.Addr
 	// hash to G1
 	hashToSign := MapToCurve(msgHash32)
 
-	return new(bn254.G1Affine).ScalarMultiplication(hashToSign, privKey.BigInt(new(big.Int)))
+	return ScalarMulG1(hashToSign, privKey)
 }
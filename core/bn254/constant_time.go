@@ -0,0 +1,107 @@
+package bn254
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// ladderBits is the bit length of the scalars the ladders run on: the scalars are shifted by a multiple of the group
+// order into [2^254, 2^255), so that every ladder runs the same number of steps
+const ladderBits = 255
+
+// groupOrder is the order r of G1 and G2, in little-endian 64 bit limbs
+var groupOrder = func() [4]uint64 {
+	var be [fr.Bytes]byte
+	fr.Modulus().FillBytes(be[:])
+	var limbs [4]uint64
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(be[fr.Bytes-8*(i+1):])
+	}
+	return limbs
+}()
+
+// ScalarMulG1 returns [s]p computed with a Montgomery ladder, whose sequence of group operations and memory accesses
+// doesn't depend on the value of s, unlike bn254.G1Affine.ScalarMultiplication. It's meant for the multiplications
+// by secret keys, e.g. signing, where the timing of the operation mustn't leak the key.
+func ScalarMulG1(p *bn254.G1Affine, s *fr.Element) *bn254.G1Affine {
+	k := ladderScalar(s)
+
+	var r0, r1 bn254.G1Jac
+	r0.FromAffine(p)
+	r1.Double(&r0)
+	for i := ladderBits - 2; i >= 0; i-- {
+		mask := -((k[i/64] >> (i % 64)) & 1)
+		condSwapG1(&r0, &r1, mask)
+		r1.AddAssign(&r0)
+		r0.DoubleAssign()
+		condSwapG1(&r0, &r1, mask)
+	}
+	return new(bn254.G1Affine).FromJacobian(&r0)
+}
+
+// ScalarMulG2 returns [s]p computed with a Montgomery ladder, like ScalarMulG1
+func ScalarMulG2(p *bn254.G2Affine, s *fr.Element) *bn254.G2Affine {
+	k := ladderScalar(s)
+
+	var r0, r1 bn254.G2Jac
+	r0.FromAffine(p)
+	r1.Double(&r0)
+	for i := ladderBits - 2; i >= 0; i-- {
+		mask := -((k[i/64] >> (i % 64)) & 1)
+		condSwapG2(&r0, &r1, mask)
+		r1.AddAssign(&r0)
+		r0.DoubleAssign()
+		condSwapG2(&r0, &r1, mask)
+	}
+	return new(bn254.G2Affine).FromJacobian(&r0)
+}
+
+// ladderScalar returns s + r or s + 2r, whichever has bit 254 set, where r is the group order. Both are congruent to
+// s, and since r < 2^254 < 2r, exactly one of them is in [2^254, 2^255). The choice is made without branching.
+func ladderScalar(s *fr.Element) [4]uint64 {
+	sBits := s.Bits()
+	var plusR, plus2R [4]uint64
+	var carry, carry2 uint64
+	for i := range sBits {
+		plusR[i], carry = bits.Add64(sBits[i], groupOrder[i], carry)
+	}
+	for i := range sBits {
+		plus2R[i], carry2 = bits.Add64(plusR[i], groupOrder[i], carry2)
+	}
+	// s + r < 2r < 2^255 and s + 2r < 3r < 2^256, so neither sum carries out of the limbs
+	mask := -((plusR[3] >> 62) & 1)
+	var k [4]uint64
+	for i := range k {
+		k[i] = (plusR[i] & mask) | (plus2R[i] &^ mask)
+	}
+	return k
+}
+
+// condSwapG1 swaps a and b if mask is all ones, and leaves them unchanged if it's zero
+func condSwapG1(a, b *bn254.G1Jac, mask uint64) {
+	condSwapFp(&a.X, &b.X, mask)
+	condSwapFp(&a.Y, &b.Y, mask)
+	condSwapFp(&a.Z, &b.Z, mask)
+}
+
+// condSwapG2 swaps a and b if mask is all ones, and leaves them unchanged if it's zero
+func condSwapG2(a, b *bn254.G2Jac, mask uint64) {
+	condSwapFp(&a.X.A0, &b.X.A0, mask)
+	condSwapFp(&a.X.A1, &b.X.A1, mask)
+	condSwapFp(&a.Y.A0, &b.Y.A0, mask)
+	condSwapFp(&a.Y.A1, &b.Y.A1, mask)
+	condSwapFp(&a.Z.A0, &b.Z.A0, mask)
+	condSwapFp(&a.Z.A1, &b.Z.A1, mask)
+}
+
+func condSwapFp(a, b *fp.Element, mask uint64) {
+	for i := range a {
+		t := mask & (a[i] ^ b[i])
+		a[i] ^= t
+		b[i] ^= t
+	}
+}
@@ -0,0 +1,45 @@
+package bn254_test
+
+import (
+	"math/big"
+	"testing"
+
+	bn254utils "github.com/Layr-Labs/eigenda/core/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalarMulConstantTime(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	var p1 bn254.G1Affine
+	p1.ScalarMultiplication(&g1Gen, big.NewInt(12345))
+	var p2 bn254.G2Affine
+	p2.ScalarMultiplication(&g2Gen, big.NewInt(12345))
+
+	var zero, one, minusOne, small, large fr.Element
+	one.SetOne()
+	minusOne.Neg(&one)
+	small.SetUint64(3)
+	// A scalar whose sum with the group order is just above 2^254
+	large.SetBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 254), fr.Modulus()))
+	scalars := []fr.Element{zero, one, minusOne, small, large}
+	for i := 0; i < 20; i++ {
+		var s fr.Element
+		_, err := s.SetRandom()
+		require.NoError(t, err)
+		scalars = append(scalars, s)
+	}
+
+	for _, s := range scalars {
+		sBig := s.BigInt(new(big.Int))
+		var expected1 bn254.G1Affine
+		expected1.ScalarMultiplication(&p1, sBig)
+		assert.True(t, expected1.Equal(bn254utils.ScalarMulG1(&p1, &s)), "scalar %s", s.String())
+
+		var expected2 bn254.G2Affine
+		expected2.ScalarMultiplication(&p2, sBig)
+		assert.True(t, expected2.Equal(bn254utils.ScalarMulG2(&p2, &s)), "scalar %s", s.String())
+	}
+}
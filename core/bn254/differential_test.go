@@ -0,0 +1,99 @@
+package bn254_test
+
+import (
+	"math/big"
+	"testing"
+
+	bn254utils "github.com/Layr-Labs/eigenda/core/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file cross-check the helpers of this package against the arithmetic of gnark-crypto, so that a
+// regression of either side is caught.
+
+func TestGeneratorsMatchGnark(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	assert.True(t, g1Gen.Equal(bn254utils.GetG1Generator()))
+	assert.True(t, g2Gen.Equal(bn254utils.GetG2Generator()))
+}
+
+// pairingsEqual returns whether e(a1, b1) == e(a2, b2), computed with the pairing of gnark-crypto
+func pairingsEqual(t *testing.T, a1 *bn254.G1Affine, b1 *bn254.G2Affine, a2 *bn254.G1Affine, b2 *bn254.G2Affine) bool {
+	left, err := bn254.Pair([]bn254.G1Affine{*a1}, []bn254.G2Affine{*b1})
+	require.NoError(t, err)
+	right, err := bn254.Pair([]bn254.G1Affine{*a2}, []bn254.G2Affine{*b2})
+	require.NoError(t, err)
+	return left.Equal(&right)
+}
+
+func TestPairingChecksMatchGnark(t *testing.T) {
+	g1Gen, g2Gen := bn254utils.GetG1Generator(), bn254utils.GetG2Generator()
+	for i := 0; i < 8; i++ {
+		var sk, other fr.Element
+		_, err := sk.SetRandom()
+		require.NoError(t, err)
+		_, err = other.SetRandom()
+		require.NoError(t, err)
+		pkG1, pkG2 := bn254utils.MulByGeneratorG1(&sk), bn254utils.MulByGeneratorG2(&sk)
+		otherG2 := bn254utils.MulByGeneratorG2(&other)
+
+		ok, err := bn254utils.CheckG1AndG2DiscreteLogEquality(pkG1, pkG2)
+		require.NoError(t, err)
+		assert.Equal(t, pairingsEqual(t, pkG1, g2Gen, g1Gen, pkG2), ok)
+		assert.True(t, ok)
+		ok, err = bn254utils.CheckG1AndG2DiscreteLogEquality(pkG1, otherG2)
+		require.NoError(t, err)
+		assert.Equal(t, pairingsEqual(t, pkG1, g2Gen, g1Gen, otherG2), ok)
+		assert.False(t, ok)
+
+		msg := [32]byte{byte(i)}
+		hashed := bn254utils.MapToCurve(msg)
+		sig := bn254utils.ScalarMulG1(hashed, &sk)
+		for _, pk := range []*bn254.G2Affine{pkG2, otherG2} {
+			ok, err := bn254utils.VerifySig(sig, pk, msg)
+			require.NoError(t, err)
+			assert.Equal(t, pairingsEqual(t, sig, g2Gen, hashed, pk), ok)
+		}
+	}
+}
+
+func FuzzScalarMulMatchesGnark(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add(fr.Modulus().Bytes())
+	f.Add(new(big.Int).Sub(fr.Modulus(), big.NewInt(1)).Bytes())
+
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// SetBytes reduces the value modulo the group order, as the scalars of the keys are
+		var s fr.Element
+		s.SetBytes(data)
+		sBig := s.BigInt(new(big.Int))
+
+		var expectedG1 bn254.G1Affine
+		expectedG1.ScalarMultiplication(&g1Gen, sBig)
+		require.True(t, expectedG1.Equal(bn254utils.MulByGeneratorG1(&s)))
+		var expectedG2 bn254.G2Affine
+		expectedG2.ScalarMultiplication(&g2Gen, sBig)
+		require.True(t, expectedG2.Equal(bn254utils.MulByGeneratorG2(&s)))
+	})
+}
+
+func FuzzMapToCurve(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 32))
+	f.Add([]byte("a message to sign"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var digest [32]byte
+		copy(digest[:], data)
+		point := bn254utils.MapToCurve(digest)
+		require.True(t, point.IsOnCurve())
+		require.True(t, point.IsInSubGroup())
+		// Mapping is deterministic
+		require.True(t, point.Equal(bn254utils.MapToCurve(digest)))
+	})
+}
@@ -1,10 +1,11 @@
 package core
 
 import (
-	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core/threshold"
 	"github.com/Layr-Labs/eigenda/encoding"
 )
 
@@ -73,19 +74,32 @@ type BlobRequestHeader struct {
 	BlobAuthHeader `json:"blob_auth_header"`
 	// For a blob to be accepted by EigenDA, it satisfy the AdversaryThreshold of each quorum contained in SecurityParams
 	SecurityParams []*SecurityParam `json:"security_params"`
+	// RetentionPeriod is how long the operators are asked to keep the blob after it's confirmed. The store duration of
+	// EigenDA is used if it's 0.
+	RetentionPeriod time.Duration `json:"retention_period"`
+	// Priority is the priority lane of the blob, 0 being the default lane
+	Priority uint32 `json:"priority"`
+	// CorrelationID identifies the blob in the logs of every service handling it, see common.CorrelationIDHeader
+	CorrelationID string `json:"correlation_id"`
+	// GroupID identifies the blobs dispersed in the same request, which the batcher keeps in the same batch when
+	// they fit in it. It's empty for the blobs dispersed alone.
+	GroupID string `json:"group_id"`
+	// TenantID is the tenant which dispersed the blob, whose requests are the only ones the blob is visible to. It's
+	// empty for the blobs of the default tenant.
+	TenantID string `json:"tenant_id"`
+	// AttestationTimeout is the attestation window requested for the blob, which shortens the attestation window of
+	// its batch. The attestation timeout of the batcher is used if it's 0.
+	AttestationTimeout time.Duration `json:"attestation_timeout"`
+	// CallbackURL is the URL the status of the blob is posted to when it's confirmed and when it's finalized. No
+	// notification is sent if it's empty.
+	CallbackURL string `json:"callback_url"`
+	// CallbackSecret is the secret the notifications to CallbackURL are signed with, see common/webhook. It's kept out
+	// of the JSON encoding, so that it's never served back.
+	CallbackSecret []byte `json:"-"`
 }
 
 func ValidateSecurityParam(confirmationThreshold, adversaryThreshold uint32) error {
-	if confirmationThreshold > 100 {
-		return errors.New("confimration threshold exceeds 100")
-	}
-	if adversaryThreshold == 0 {
-		return errors.New("adversary threshold equals 0")
-	}
-	if confirmationThreshold < adversaryThreshold || confirmationThreshold-adversaryThreshold < 10 {
-		return errors.New("confirmation threshold must be >= 10 + adversary threshold")
-	}
-	return nil
+	return threshold.ValidateSecurityParam(confirmationThreshold, adversaryThreshold)
 }
 
 func (sp *SecurityParam) Validate() error {
@@ -107,6 +121,13 @@ type BlobHeader struct {
 
 	// AccountID is the account that is paying for the blob to be stored
 	AccountID AccountID
+
+	// RetentionPeriod is how long the operators are asked to keep the blob after it's confirmed, if it's shorter than
+	// the store duration of EigenDA. It's not part of the blob header hash.
+	RetentionPeriod time.Duration
+	// CorrelationID identifies the blob in the logs of every service handling it. It's not part of the blob header
+	// hash.
+	CorrelationID string
 }
 
 func (b *BlobHeader) GetQuorumInfo(quorum QuorumID) *BlobQuorumInfo {
@@ -123,7 +144,7 @@ func (b *BlobHeader) EncodedSizeAllQuorums() int64 {
 	size := int64(0)
 	for _, quorum := range b.QuorumInfos {
 
-		size += int64(roundUpDivide(b.Length*percentMultiplier*encoding.BYTES_PER_SYMBOL, uint(quorum.ConfirmationThreshold-quorum.AdversaryThreshold)))
+		size += int64(threshold.EncodedLength(b.Length*encoding.BYTES_PER_SYMBOL, quorum.ConfirmationThreshold, quorum.AdversaryThreshold))
 	}
 	return size
 }
@@ -167,10 +188,15 @@ func (b Bundle) Size() uint64 {
 
 // Serialize encodes a batch of chunks into a byte array
 func (cb Bundles) Serialize() (map[uint32][][]byte, error) {
+	return cb.SerializeWithFormat(encoding.GobChunkEncodingFormat)
+}
+
+// SerializeWithFormat encodes a batch of chunks into a byte array using the given chunk encoding format
+func (cb Bundles) SerializeWithFormat(format encoding.ChunkEncodingFormat) (map[uint32][][]byte, error) {
 	data := make(map[uint32][][]byte, len(cb))
 	for quorumID, bundle := range cb {
 		for _, chunk := range bundle {
-			chunkData, err := chunk.Serialize()
+			chunkData, err := chunk.SerializeWithFormat(format)
 			if err != nil {
 				return nil, err
 			}
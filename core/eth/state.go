@@ -2,7 +2,6 @@ package eth
 
 import (
 	"context"
-	"math/big"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
@@ -52,32 +51,5 @@ func (cs *ChainState) GetCurrentBlockNumber() (uint, error) {
 }
 
 func getOperatorState(operatorsByQuorum core.OperatorStakes, blockNumber uint32) (*core.OperatorState, error) {
-	operators := make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo)
-	totals := make(map[core.QuorumID]*core.OperatorInfo)
-
-	for quorumID, quorum := range operatorsByQuorum {
-		totalStake := big.NewInt(0)
-		operators[quorumID] = make(map[core.OperatorID]*core.OperatorInfo)
-
-		for ind, op := range quorum {
-			operators[quorumID][op.OperatorID] = &core.OperatorInfo{
-				Stake: op.Stake,
-				Index: core.OperatorIndex(ind),
-			}
-			totalStake.Add(totalStake, op.Stake)
-		}
-
-		totals[quorumID] = &core.OperatorInfo{
-			Stake: totalStake,
-			Index: core.OperatorIndex(len(quorum)),
-		}
-	}
-
-	state := &core.OperatorState{
-		Operators:   operators,
-		Totals:      totals,
-		BlockNumber: uint(blockNumber),
-	}
-
-	return state, nil
+	return core.NewOperatorStateFromStakes(operatorsByQuorum, uint(blockNumber)), nil
 }
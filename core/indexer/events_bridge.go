@@ -0,0 +1,193 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	eigendasrvmg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	ejectionmg "github.com/Layr-Labs/eigenda/contracts/bindings/EjectionManager"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	stakereg "github.com/Layr-Labs/eigenda/contracts/bindings/StakeRegistry"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EventPublisher publishes the messages of the events bridge to the subjects of a message broker
+type EventPublisher interface {
+	Publish(subject string, data []byte) error
+}
+
+// BridgeEvent is the JSON message published by the events bridge for an indexed event. The fields which don't apply
+// to the type of the event are omitted.
+type BridgeEvent struct {
+	Type        string `json:"type"`
+	BlockNumber uint64 `json:"block_number"`
+	BlockHash   string `json:"block_hash"`
+	TxHash      string `json:"tx_hash"`
+	LogIndex    uint   `json:"log_index"`
+
+	// BatchHeaderHash and BatchID are set for the batch_confirmed events
+	BatchHeaderHash string  `json:"batch_header_hash,omitempty"`
+	BatchID         *uint32 `json:"batch_id,omitempty"`
+
+	// Operator is set for the operator_registered and operator_deregistered events
+	Operator string `json:"operator,omitempty"`
+	// OperatorID is set for all the operator events
+	OperatorID string `json:"operator_id,omitempty"`
+	// QuorumNumber is set for the operator_ejected and operator_stake_update events
+	QuorumNumber *uint8 `json:"quorum_number,omitempty"`
+	// Stake is the decimal stake of the operator in the quorum, for the operator_stake_update events
+	Stake string `json:"stake,omitempty"`
+}
+
+// EventsBridgeState counts the events handled by the events bridge
+type EventsBridgeState struct {
+	Published uint64
+	Dropped   uint64
+}
+
+// EventsBridgeAccumulator publishes the indexed events to <SubjectPrefix>.<event type>, so that external systems can
+// react to them without polling. The events are published at most once: an event which fails to publish is logged
+// and dropped, instead of stopping the indexing.
+type EventsBridgeAccumulator struct {
+	Publisher     EventPublisher
+	SubjectPrefix string
+	Logger        logging.Logger
+}
+
+func NewEventsBridgeAccumulator(publisher EventPublisher, subjectPrefix string, logger logging.Logger) *EventsBridgeAccumulator {
+	return &EventsBridgeAccumulator{
+		Publisher:     publisher,
+		SubjectPrefix: subjectPrefix,
+		Logger:        logger.With("component", "EventsBridge"),
+	}
+}
+
+func (a *EventsBridgeAccumulator) InitializeObject(header indexer.Header) (indexer.AccumulatorObject, error) {
+	return EventsBridgeState{}, nil
+}
+
+func (a *EventsBridgeAccumulator) UpdateObject(object indexer.AccumulatorObject, header *indexer.Header, event indexer.Event) (indexer.AccumulatorObject, error) {
+	state, ok := object.(EventsBridgeState)
+	if !ok {
+		return object, ErrIncorrectObject
+	}
+
+	bridgeEvent, err := NewBridgeEvent(event)
+	if err != nil {
+		return object, err
+	}
+	data, err := json.Marshal(bridgeEvent)
+	if err != nil {
+		return object, err
+	}
+
+	subject := a.SubjectPrefix + "." + event.Type
+	if err := a.Publisher.Publish(subject, data); err != nil {
+		a.Logger.Error("Failed to publish event", "subject", subject, "block", bridgeEvent.BlockNumber, "err", err)
+		state.Dropped++
+		return state, nil
+	}
+	state.Published++
+	return state, nil
+}
+
+// NewBridgeEvent returns the message of an event filtered by the events filterer
+func NewBridgeEvent(event indexer.Event) (*BridgeEvent, error) {
+	var (
+		bridgeEvent *BridgeEvent
+		log         types.Log
+	)
+	switch payload := event.Payload.(type) {
+	case *eigendasrvmg.ContractEigenDAServiceManagerBatchConfirmed:
+		batchID := payload.BatchId
+		bridgeEvent = &BridgeEvent{
+			BatchHeaderHash: hexutil.Encode(payload.BatchHeaderHash[:]),
+			BatchID:         &batchID,
+		}
+		log = payload.Raw
+	case *regcoord.ContractRegistryCoordinatorOperatorRegistered:
+		bridgeEvent = &BridgeEvent{
+			Operator:   payload.Operator.Hex(),
+			OperatorID: hexutil.Encode(payload.OperatorId[:]),
+		}
+		log = payload.Raw
+	case *regcoord.ContractRegistryCoordinatorOperatorDeregistered:
+		bridgeEvent = &BridgeEvent{
+			Operator:   payload.Operator.Hex(),
+			OperatorID: hexutil.Encode(payload.OperatorId[:]),
+		}
+		log = payload.Raw
+	case *ejectionmg.ContractEjectionManagerOperatorEjected:
+		quorum := payload.QuorumNumber
+		bridgeEvent = &BridgeEvent{
+			OperatorID:   hexutil.Encode(payload.OperatorId[:]),
+			QuorumNumber: &quorum,
+		}
+		log = payload.Raw
+	case *stakereg.ContractStakeRegistryOperatorStakeUpdate:
+		quorum := payload.QuorumNumber
+		bridgeEvent = &BridgeEvent{
+			OperatorID:   hexutil.Encode(payload.OperatorId[:]),
+			QuorumNumber: &quorum,
+		}
+		if payload.Stake != nil {
+			bridgeEvent.Stake = payload.Stake.String()
+		}
+		log = payload.Raw
+	default:
+		return nil, ErrIncorrectEvent
+	}
+
+	bridgeEvent.Type = event.Type
+	bridgeEvent.BlockNumber = log.BlockNumber
+	bridgeEvent.BlockHash = log.BlockHash.Hex()
+	bridgeEvent.TxHash = log.TxHash.Hex()
+	bridgeEvent.LogIndex = log.Index
+	return bridgeEvent, nil
+}
+
+func (a *EventsBridgeAccumulator) SerializeObject(object indexer.AccumulatorObject, fork indexer.UpgradeFork) ([]byte, error) {
+	switch fork {
+	case "genesis":
+		obj, ok := object.(EventsBridgeState)
+		if !ok {
+			return nil, ErrIncorrectObject
+		}
+
+		var (
+			buff bytes.Buffer
+			enc  = gob.NewEncoder(&buff)
+		)
+
+		if err := enc.Encode(obj); err != nil {
+			return nil, err
+		}
+
+		return buff.Bytes(), nil
+	default:
+		return nil, ErrUnrecognizedFork
+	}
+}
+
+func (a *EventsBridgeAccumulator) DeserializeObject(data []byte, fork indexer.UpgradeFork) (indexer.AccumulatorObject, error) {
+	switch fork {
+	case "genesis":
+		var (
+			obj EventsBridgeState
+			buf = bytes.NewBuffer(data)
+			dec = gob.NewDecoder(buf)
+		)
+
+		if err := dec.Decode(&obj); err != nil {
+			return nil, err
+		}
+
+		return obj, nil
+	default:
+		return nil, ErrUnrecognizedFork
+	}
+}
@@ -0,0 +1,77 @@
+package indexer_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	eigendasrvmg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	stakereg "github.com/Layr-Labs/eigenda/contracts/bindings/StakeRegistry"
+	coreindexer "github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type publishedMessage struct {
+	subject string
+	data    string
+}
+
+type recordingPublisher struct {
+	messages []publishedMessage
+	err      error
+}
+
+func (p *recordingPublisher) Publish(subject string, data []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, publishedMessage{subject: subject, data: string(data)})
+	return nil
+}
+
+func TestEventsBridgeAccumulator(t *testing.T) {
+	publisher := &recordingPublisher{}
+	acc := coreindexer.NewEventsBridgeAccumulator(publisher, "eigenda", logging.NewNoopLogger())
+	header := &indexer.Header{Number: 10}
+	object, err := acc.InitializeObject(*header)
+	assert.NoError(t, err)
+
+	raw := types.Log{BlockNumber: 10, BlockHash: gethcommon.Hash{1}, TxHash: gethcommon.Hash{2}, Index: 3}
+	events := []indexer.Event{
+		{Type: coreindexer.BatchConfirmed, Payload: &eigendasrvmg.ContractEigenDAServiceManagerBatchConfirmed{BatchHeaderHash: [32]byte{4}, BatchId: 5, Raw: raw}},
+		{Type: coreindexer.OperatorRegistered, Payload: &regcoord.ContractRegistryCoordinatorOperatorRegistered{Operator: gethcommon.Address{6}, OperatorId: [32]byte{7}, Raw: raw}},
+		{Type: coreindexer.OperatorStakeUpdate, Payload: &stakereg.ContractStakeRegistryOperatorStakeUpdate{OperatorId: [32]byte{7}, QuorumNumber: 0, Stake: big.NewInt(1000), Raw: raw}},
+	}
+	for _, event := range events {
+		object, err = acc.UpdateObject(object, header, event)
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, publisher.messages, 3)
+	assert.Equal(t, "eigenda.batch_confirmed", publisher.messages[0].subject)
+	assert.JSONEq(t, `{"type":"batch_confirmed","block_number":10,"block_hash":"0x0100000000000000000000000000000000000000000000000000000000000000","tx_hash":"0x0200000000000000000000000000000000000000000000000000000000000000","log_index":3,"batch_header_hash":"0x0400000000000000000000000000000000000000000000000000000000000000","batch_id":5}`, publisher.messages[0].data)
+	assert.Equal(t, "eigenda.operator_registered", publisher.messages[1].subject)
+	assert.JSONEq(t, `{"type":"operator_registered","block_number":10,"block_hash":"0x0100000000000000000000000000000000000000000000000000000000000000","tx_hash":"0x0200000000000000000000000000000000000000000000000000000000000000","log_index":3,"operator":"0x0600000000000000000000000000000000000000","operator_id":"0x0700000000000000000000000000000000000000000000000000000000000000"}`, publisher.messages[1].data)
+	assert.Equal(t, "eigenda.operator_stake_update", publisher.messages[2].subject)
+	assert.JSONEq(t, `{"type":"operator_stake_update","block_number":10,"block_hash":"0x0100000000000000000000000000000000000000000000000000000000000000","tx_hash":"0x0200000000000000000000000000000000000000000000000000000000000000","log_index":3,"operator_id":"0x0700000000000000000000000000000000000000000000000000000000000000","quorum_number":0,"stake":"1000"}`, publisher.messages[2].data)
+
+	// The events which fail to publish are dropped without stopping the indexing
+	publisher.err = errors.New("connection refused")
+	object, err = acc.UpdateObject(object, header, events[0])
+	assert.NoError(t, err)
+	assert.Equal(t, coreindexer.EventsBridgeState{Published: 3, Dropped: 1}, object)
+
+	_, err = acc.UpdateObject(object, header, indexer.Event{Type: coreindexer.OperatorSocketUpdate, Payload: "socket"})
+	assert.ErrorIs(t, err, coreindexer.ErrIncorrectEvent)
+
+	data, err := acc.SerializeObject(object, "genesis")
+	assert.NoError(t, err)
+	deserialized, err := acc.DeserializeObject(data, "genesis")
+	assert.NoError(t, err)
+	assert.Equal(t, object, deserialized)
+}
@@ -0,0 +1,232 @@
+package indexer
+
+import (
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/common"
+	eigendasrvmg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	ejectionmg "github.com/Layr-Labs/eigenda/contracts/bindings/EjectionManager"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	stakereg "github.com/Layr-Labs/eigenda/contracts/bindings/StakeRegistry"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	BatchConfirmed       = "batch_confirmed"
+	OperatorRegistered   = "operator_registered"
+	OperatorDeregistered = "operator_deregistered"
+	OperatorEjected      = "operator_ejected"
+	OperatorStakeUpdate  = "operator_stake_update"
+)
+
+// eventIterator is the iterator of the logs of a contract event returned by the bindings
+type eventIterator interface {
+	Next() bool
+	Error() error
+	Close() error
+}
+
+// loggedEvent is an event along with the log it was emitted in, which orders the events
+type loggedEvent struct {
+	log   types.Log
+	event indexer.Event
+}
+
+// eventsFilterer filters the batch confirmations and the operator registrations, deregistrations, ejections and
+// stake updates, for the events bridge. It only filters the events of the new blocks, as the bridge doesn't replay
+// the history.
+type eventsFilterer struct {
+	Filterer bind.ContractFilterer
+
+	ServiceManagerAddress      gethcommon.Address
+	RegistryCoordinatorAddress gethcommon.Address
+	StakeRegistryAddress       gethcommon.Address
+	EjectionManagerAddress     gethcommon.Address
+
+	FastMode bool
+}
+
+func NewEventsFilterer(eigenDAServiceManagerAddr gethcommon.Address, client common.EthClient) (*eventsFilterer, error) {
+	contractEigenDAServiceManager, err := eigendasrvmg.NewContractEigenDAServiceManager(eigenDAServiceManagerAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinatorAddr, err := contractEigenDAServiceManager.RegistryCoordinator(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	contractRegistryCoordinator, err := regcoord.NewContractRegistryCoordinator(registryCoordinatorAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	stakeRegistryAddr, err := contractRegistryCoordinator.StakeRegistry(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	ejectionManagerAddr, err := contractRegistryCoordinator.Ejector(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventsFilterer{
+		Filterer:                   client,
+		ServiceManagerAddress:      eigenDAServiceManagerAddr,
+		RegistryCoordinatorAddress: registryCoordinatorAddr,
+		StakeRegistryAddress:       stakeRegistryAddr,
+		EjectionManagerAddress:     ejectionManagerAddr,
+		FastMode:                   false,
+	}, nil
+}
+
+func (f *eventsFilterer) FilterHeaders(headers indexer.Headers) ([]indexer.HeaderAndEvents, error) {
+	if err := headers.OK(); err != nil {
+		return nil, err
+	}
+
+	serviceManager, err := eigendasrvmg.NewContractEigenDAServiceManagerFilterer(f.ServiceManagerAddress, f.Filterer)
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinatorFilterer(f.RegistryCoordinatorAddress, f.Filterer)
+	if err != nil {
+		return nil, err
+	}
+	stakeRegistry, err := stakereg.NewContractStakeRegistryFilterer(f.StakeRegistryAddress, f.Filterer)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.FilterOpts{
+		Start: headers.First().Number,
+		End:   &headers.Last().Number,
+	}
+
+	var events []loggedEvent
+	batchConfirmed, err := serviceManager.FilterBatchConfirmed(opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	events, err = collectEvents(events, batchConfirmed, BatchConfirmed, func() (interface{}, types.Log) {
+		return batchConfirmed.Event, batchConfirmed.Event.Raw
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	registered, err := registryCoordinator.FilterOperatorRegistered(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	events, err = collectEvents(events, registered, OperatorRegistered, func() (interface{}, types.Log) {
+		return registered.Event, registered.Event.Raw
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deregistered, err := registryCoordinator.FilterOperatorDeregistered(opts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	events, err = collectEvents(events, deregistered, OperatorDeregistered, func() (interface{}, types.Log) {
+		return deregistered.Event, deregistered.Event.Raw
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stakeUpdates, err := stakeRegistry.FilterOperatorStakeUpdate(opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	events, err = collectEvents(events, stakeUpdates, OperatorStakeUpdate, func() (interface{}, types.Log) {
+		return stakeUpdates.Event, stakeUpdates.Event.Raw
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The ejector of the registry coordinator may not be an ejection manager contract
+	if f.EjectionManagerAddress != (gethcommon.Address{}) {
+		ejectionManager, err := ejectionmg.NewContractEjectionManagerFilterer(f.EjectionManagerAddress, f.Filterer)
+		if err != nil {
+			return nil, err
+		}
+		ejected, err := ejectionManager.FilterOperatorEjected(opts)
+		if err != nil {
+			return nil, err
+		}
+		events, err = collectEvents(events, ejected, OperatorEjected, func() (interface{}, types.Log) {
+			return ejected.Event, ejected.Event.Raw
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The events are handled in the order they were emitted in
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].log.BlockNumber != events[j].log.BlockNumber {
+			return events[i].log.BlockNumber < events[j].log.BlockNumber
+		}
+		return events[i].log.Index < events[j].log.Index
+	})
+
+	var headersAndEvents []indexer.HeaderAndEvents
+	for _, event := range events {
+		header, err := headers.GetHeaderByNumber(event.log.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if !header.BlockHashIs(event.log.BlockHash.Bytes()) {
+			continue
+		}
+
+		if n := len(headersAndEvents); n > 0 && headersAndEvents[n-1].Header == header {
+			headersAndEvents[n-1].Events = append(headersAndEvents[n-1].Events, event.event)
+			continue
+		}
+		headersAndEvents = append(headersAndEvents, indexer.HeaderAndEvents{
+			Header: header,
+			Events: []indexer.Event{event.event},
+		})
+	}
+
+	return headersAndEvents, nil
+}
+
+// collectEvents appends the events of the iterator to events
+func collectEvents(events []loggedEvent, it eventIterator, eventType string, current func() (interface{}, types.Log)) ([]loggedEvent, error) {
+	defer it.Close()
+	for it.Next() {
+		payload, log := current()
+		events = append(events, loggedEvent{
+			log:   log,
+			event: indexer.Event{Type: eventType, Payload: payload},
+		})
+	}
+	return events, it.Error()
+}
+
+func (f *eventsFilterer) GetSyncPoint(latestHeader *indexer.Header) (uint64, error) {
+	return latestHeader.Number, nil
+}
+
+func (f *eventsFilterer) SetSyncPoint(latestHeader *indexer.Header) error {
+	f.FastMode = true
+	return nil
+}
+
+func (f *eventsFilterer) FilterFastMode(headers indexer.Headers) (*indexer.Header, indexer.Headers, error) {
+	if len(headers) == 0 {
+		return nil, nil, nil
+	}
+	if f.FastMode {
+		f.FastMode = false
+		return headers.First(), headers, nil
+	}
+	return nil, headers, nil
+}
@@ -2,8 +2,10 @@ package indexer
 
 import (
 	"fmt"
+	"time"
 
 	dacommon "github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/nats"
 	"github.com/Layr-Labs/eigenda/indexer"
 	indexereth "github.com/Layr-Labs/eigenda/indexer/eth"
 	inmemstore "github.com/Layr-Labs/eigenda/indexer/inmem"
@@ -11,6 +13,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// eventsBridgeTimeout bounds the connection to the NATS server of the events bridge and each publish
+const eventsBridgeTimeout = 5 * time.Second
+
 func CreateNewIndexer(
 	config *indexer.Config,
 	gethClient dacommon.EthClient,
@@ -44,6 +49,22 @@ func CreateNewIndexer(
 		},
 	}
 
+	if config.EventsBridgeURL != "" {
+		eventsFilterer, err := NewEventsFilterer(eigenDAServiceManager, gethClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new events filter: %w", err)
+		}
+		publisher, err := nats.NewPublisher(config.EventsBridgeURL, "eigenda-indexer", eventsBridgeTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the events bridge publisher: %w", err)
+		}
+		handlers = append(handlers, indexer.AccumulatorHandler{
+			Acc:      NewEventsBridgeAccumulator(publisher, config.EventsBridgeSubjectPrefix, logger),
+			Filterer: eventsFilterer,
+			Status:   indexer.Good,
+		})
+	}
+
 	var (
 		upgrader    = &Upgrader{}
 		headerStore = inmemstore.NewHeaderStore()
@@ -3,6 +3,7 @@ package indexer
 import (
 	"bytes"
 	"encoding/gob"
+	"fmt"
 	"math/big"
 
 	"github.com/Layr-Labs/eigenda/core"
@@ -86,6 +87,9 @@ func (a *OperatorPubKeysAccumulator) UpdateObject(object indexer.AccumulatorObje
 
 		p := core.G1Point{G1Affine: pubKeysPair.PubKeyG1}
 		operatorID := p.GetOperatorID()
+		if existing, ok := pubKeys.Operators[operatorID]; ok && !existing.PubKeyG1.Equal(pubKeysPair.PubKeyG1) {
+			return object, fmt.Errorf("%w: distinct public keys derive the operator ID %s", core.ErrOperatorIDCollision, operatorID.Hex())
+		}
 
 		for _, quorumID := range payload.AddedEvent.QuorumNumbers {
 
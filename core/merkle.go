@@ -0,0 +1,130 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/wealdtech/go-merkletree"
+	"golang.org/x/crypto/sha3"
+)
+
+// minParallelMerkleWork is the number of hashes below which a step of the tree construction isn't split across
+// goroutines, since the hashes are faster to compute than to schedule
+const minParallelMerkleWork = 256
+
+var ErrMerkleLeafNotFound = errors.New("leaf not found in the merkle tree")
+
+// MerkleTree is the keccak256 Merkle tree of a batch, whose leaves are the hashes of the blob headers. The tree is the
+// same as the one built by go-merkletree with the keccak256 hash, unsalted and unsorted, i.e. the leaves are padded
+// with zero hashes to a power of 2, and its proofs verify with merkletree.VerifyProofUsing. The leaves and the
+// branches of each level are hashed in parallel.
+type MerkleTree struct {
+	// data are the values the leaves are the hashes of
+	data [][]byte
+	// nodes are the branches followed by the padded leaves, with the root at index 1 and the children of node i at
+	// indices 2i and 2i+1
+	nodes [][]byte
+}
+
+// NewMerkleTree builds the Merkle tree of the data. The data must not be empty.
+func NewMerkleTree(data [][]byte) (*MerkleTree, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot build a merkle tree without data")
+	}
+
+	numLeaves := 1
+	for numLeaves < len(data) {
+		numLeaves *= 2
+	}
+	// The nodes share a single allocation, the padding leaves are left zero
+	buf := make([]byte, 2*numLeaves*32)
+	nodes := make([][]byte, 2*numLeaves)
+	for i := 1; i < len(nodes); i++ {
+		nodes[i] = buf[i*32 : (i+1)*32 : (i+1)*32]
+	}
+
+	parallelFor(len(data), func(start, end int) {
+		hasher := sha3.NewLegacyKeccak256()
+		for i := start; i < end; i++ {
+			hasher.Reset()
+			hasher.Write(data[i])
+			hasher.Sum(nodes[numLeaves+i][:0])
+		}
+	})
+	for levelStart := numLeaves / 2; levelStart > 0; levelStart /= 2 {
+		parallelFor(levelStart, func(start, end int) {
+			hasher := sha3.NewLegacyKeccak256()
+			for i := levelStart + start; i < levelStart+end; i++ {
+				hasher.Reset()
+				hasher.Write(nodes[2*i])
+				hasher.Write(nodes[2*i+1])
+				hasher.Sum(nodes[i][:0])
+			}
+		})
+	}
+
+	return &MerkleTree{
+		data:  data,
+		nodes: nodes,
+	}, nil
+}
+
+// Root returns the root of the tree
+func (t *MerkleTree) Root() []byte {
+	return t.nodes[1]
+}
+
+// GenerateProof returns the inclusion proof of the first leaf of the data
+func (t *MerkleTree) GenerateProof(data []byte) (*merkletree.Proof, error) {
+	for i := range t.data {
+		if bytes.Equal(t.data[i], data) {
+			return t.GenerateProofByIndex(uint64(i))
+		}
+	}
+	return nil, ErrMerkleLeafNotFound
+}
+
+// GenerateProofByIndex returns the inclusion proof of the leaf at the index
+func (t *MerkleTree) GenerateProofByIndex(index uint64) (*merkletree.Proof, error) {
+	if index >= uint64(len(t.data)) {
+		return nil, ErrMerkleLeafNotFound
+	}
+	hashes := make([][]byte, 0)
+	for i := index + uint64(len(t.nodes)/2); i > 1; i /= 2 {
+		hashes = append(hashes, t.nodes[i^1])
+	}
+	return &merkletree.Proof{
+		Hashes: hashes,
+		Index:  index,
+	}, nil
+}
+
+// parallelFor calls fn on contiguous ranges covering [0, n), splitting them across goroutines when n is large enough.
+// It returns once every range has been processed.
+func parallelFor(n int, fn func(start, end int)) {
+	numWorkers := runtime.GOMAXPROCS(0)
+	if n < 2*minParallelMerkleWork || numWorkers == 1 {
+		fn(0, n)
+		return
+	}
+	if maxWorkers := n / minParallelMerkleWork; numWorkers > maxWorkers {
+		numWorkers = maxWorkers
+	}
+	chunkSize := (n + numWorkers - 1) / numWorkers
+
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunkSize {
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
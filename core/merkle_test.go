@@ -0,0 +1,133 @@
+package core_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+func makeLeaves(t testing.TB, n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = make([]byte, 32)
+		_, err := rand.Read(leaves[i])
+		require.NoError(t, err)
+	}
+	return leaves
+}
+
+func TestMerkleTree(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 7, 8, 600, 1025} {
+		t.Run(fmt.Sprintf("%d leaves", n), func(t *testing.T) {
+			leaves := makeLeaves(t, n)
+			tree, err := core.NewMerkleTree(leaves)
+			require.NoError(t, err)
+
+			// The tree is the one of go-merkletree
+			expected, err := merkletree.NewTree(merkletree.WithData(leaves), merkletree.WithHashType(keccak256.New()))
+			require.NoError(t, err)
+			assert.Equal(t, expected.Root(), tree.Root())
+
+			for _, i := range []int{0, n / 2, n - 1} {
+				proof, err := tree.GenerateProof(leaves[i])
+				require.NoError(t, err)
+				expectedProof, err := expected.GenerateProof(leaves[i], 0)
+				require.NoError(t, err)
+				assert.Equal(t, expectedProof, proof)
+
+				ok, err := merkletree.VerifyProofUsing(leaves[i], false, proof, [][]byte{tree.Root()}, keccak256.New())
+				require.NoError(t, err)
+				assert.True(t, ok)
+
+				proofByIndex, err := tree.GenerateProofByIndex(uint64(i))
+				require.NoError(t, err)
+				assert.Equal(t, proof, proofByIndex)
+			}
+
+			_, err = tree.GenerateProof([]byte("missing"))
+			assert.ErrorIs(t, err, core.ErrMerkleLeafNotFound)
+			_, err = tree.GenerateProofByIndex(uint64(n))
+			assert.ErrorIs(t, err, core.ErrMerkleLeafNotFound)
+		})
+	}
+
+	_, err := core.NewMerkleTree(nil)
+	assert.Error(t, err)
+}
+
+func makeBlobHeaders(n int) []*core.BlobHeader {
+	var commitX, commitY big.Int
+	commitX.SetString("21661178944771197726808973281966770251114553549453983978976194544185382599016", 10)
+	commitY.SetString("9207254729396071334325696286939045899948985698134704137261649190717970615186", 10)
+	commitment := &encoding.G1Commitment{
+		X: *new(bn254.G1Affine).X.SetBigInt(&commitX),
+		Y: *new(bn254.G1Affine).Y.SetBigInt(&commitY),
+	}
+
+	blobHeaders := make([]*core.BlobHeader, n)
+	for i := range blobHeaders {
+		blobHeaders[i] = &core.BlobHeader{
+			BlobCommitments: encoding.BlobCommitments{
+				Commitment: commitment,
+				Length:     uint(i + 1),
+			},
+			QuorumInfos: []*core.BlobQuorumInfo{
+				{
+					SecurityParam: core.SecurityParam{
+						QuorumID:              0,
+						AdversaryThreshold:    80,
+						ConfirmationThreshold: 100,
+					},
+					ChunkLength: 10,
+				},
+			},
+		}
+	}
+	return blobHeaders
+}
+
+func TestSetBatchRoot(t *testing.T) {
+	blobHeaders := makeBlobHeaders(600)
+	leaves := make([][]byte, len(blobHeaders))
+	for i, header := range blobHeaders {
+		hash, err := header.GetBlobHeaderHash()
+		require.NoError(t, err)
+		leaves[i] = hash[:]
+	}
+	expected, err := merkletree.NewTree(merkletree.WithData(leaves), merkletree.WithHashType(keccak256.New()))
+	require.NoError(t, err)
+
+	batchHeader := &core.BatchHeader{}
+	tree, err := batchHeader.SetBatchRoot(blobHeaders)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Root(), batchHeader.BatchRoot[:])
+	assert.Equal(t, expected.Root(), tree.Root())
+}
+
+func BenchmarkSetBatchRoot(b *testing.B) {
+	blobHeaders := makeBlobHeaders(10_000)
+	batchHeader := &core.BatchHeader{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := batchHeader.SetBatchRoot(blobHeaders)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkNewMerkleTree(b *testing.B) {
+	leaves := makeLeaves(b, 10_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := core.NewMerkleTree(leaves)
+		require.NoError(b, err)
+	}
+}
@@ -23,9 +23,22 @@ func NewMockShardValidator() *MockShardValidator {
 	return &MockShardValidator{}
 }
 
-func (v *MockShardValidator) ValidateBatch(batchHeader *core.BatchHeader, blobs []*core.BlobMessage, operatorState *core.OperatorState, pool common.WorkerPool) error {
+func (v *MockShardValidator) ValidateBatch(batchHeader *core.BatchHeader, blobs []*core.BlobMessage, operatorState *core.OperatorState, pool common.WorkerPool) ([]core.BlobValidationStats, error) {
 	args := v.Called(blobs, operatorState, pool)
-	return args.Error(0)
+	var stats []core.BlobValidationStats
+	if args.Get(0) != nil {
+		stats = args.Get(0).([]core.BlobValidationStats)
+	}
+	return stats, args.Error(1)
+}
+
+func (v *MockShardValidator) ValidateBlobs(blobs []*core.BlobMessage, operatorState *core.OperatorState, pool common.WorkerPool) ([]core.BlobValidationStats, error) {
+	args := v.Called(blobs, operatorState, pool)
+	var stats []core.BlobValidationStats
+	if args.Get(0) != nil {
+		stats = args.Get(0).([]core.BlobValidationStats)
+	}
+	return stats, args.Error(1)
 }
 
 func (v *MockShardValidator) UpdateOperatorID(operatorID core.OperatorID) {
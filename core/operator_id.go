@@ -0,0 +1,65 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidPubkey       = errors.New("invalid public key")
+	ErrPubkeyMismatch      = errors.New("G1 and G2 public keys are not derived from the same private key")
+	ErrOperatorIDMismatch  = errors.New("operator ID mismatch")
+	ErrOperatorIDCollision = errors.New("operator ID collision")
+)
+
+// OperatorIDFromPubkeys derives the operator ID of an operator from its public keys, after checking that they are
+// valid keys derived from the same private key, as the registry contracts require them to be.
+func OperatorIDFromPubkeys(pubkeyG1 *G1Point, pubkeyG2 *G2Point) (OperatorID, error) {
+	if pubkeyG1 == nil || pubkeyG1.G1Affine == nil || pubkeyG1.IsInfinity() || !pubkeyG1.IsOnCurve() {
+		return OperatorID{}, fmt.Errorf("%w: the G1 public key is not a point of the curve", ErrInvalidPubkey)
+	}
+	if pubkeyG2 == nil || pubkeyG2.G2Affine == nil || pubkeyG2.IsInfinity() || !pubkeyG2.IsInSubGroup() {
+		return OperatorID{}, fmt.Errorf("%w: the G2 public key is not a point of the subgroup", ErrInvalidPubkey)
+	}
+	ok, err := pubkeyG1.VerifyEquivalence(pubkeyG2)
+	if err != nil {
+		return OperatorID{}, fmt.Errorf("failed to verify the equivalence of the public keys: %w", err)
+	}
+	if !ok {
+		return OperatorID{}, ErrPubkeyMismatch
+	}
+	return pubkeyG1.GetOperatorID(), nil
+}
+
+// VerifyOperatorID checks that the operator ID is the one derived from the G1 public key.
+func VerifyOperatorID(operatorID OperatorID, pubkeyG1 *G1Point) error {
+	if derived := pubkeyG1.GetOperatorID(); derived != operatorID {
+		return fmt.Errorf("%w: the public key derives %s, got %s", ErrOperatorIDMismatch, derived.Hex(), operatorID.Hex())
+	}
+	return nil
+}
+
+// VerifyOperatorRegistration checks that the on-chain registration of the operator with the address is consistent
+// with the operator ID derived from its local keys: the address isn't registered with another operator ID, and the
+// operator ID isn't registered by another address. An operator that isn't registered yet passes the check.
+func VerifyOperatorRegistration(ctx context.Context, tx Transactor, address gethcommon.Address, operatorID OperatorID) error {
+	registeredID, err := tx.OperatorAddressToID(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to get the operator ID of %s: %w", address.Hex(), err)
+	}
+	if registeredID != (OperatorID{}) && registeredID != operatorID {
+		return fmt.Errorf("%w: expected %s, got %s", ErrOperatorIDMismatch, operatorID.Hex(), registeredID.Hex())
+	}
+
+	registeredAddress, err := tx.OperatorIDToAddress(ctx, operatorID)
+	if err != nil {
+		return fmt.Errorf("failed to get the address of operator %s: %w", operatorID.Hex(), err)
+	}
+	if registeredAddress != (gethcommon.Address{}) && registeredAddress != address {
+		return fmt.Errorf("%w: operator ID %s is registered by %s, not %s", ErrOperatorIDCollision, operatorID.Hex(), registeredAddress.Hex(), address.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorIDFromPubkeys(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	otherKeyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+
+	operatorID, err := core.OperatorIDFromPubkeys(keyPair.GetPubKeyG1(), keyPair.GetPubKeyG2())
+	assert.NoError(t, err)
+	assert.Equal(t, keyPair.GetPubKeyG1().GetOperatorID(), operatorID)
+	assert.NoError(t, core.VerifyOperatorID(operatorID, keyPair.GetPubKeyG1()))
+	assert.ErrorIs(t, core.VerifyOperatorID(operatorID, otherKeyPair.GetPubKeyG1()), core.ErrOperatorIDMismatch)
+
+	_, err = core.OperatorIDFromPubkeys(keyPair.GetPubKeyG1(), otherKeyPair.GetPubKeyG2())
+	assert.ErrorIs(t, err, core.ErrPubkeyMismatch)
+	_, err = core.OperatorIDFromPubkeys(&core.G1Point{G1Affine: &bn254.G1Affine{}}, keyPair.GetPubKeyG2())
+	assert.ErrorIs(t, err, core.ErrInvalidPubkey)
+}
+
+func TestVerifyOperatorRegistration(t *testing.T) {
+	ctx := context.Background()
+	address := gethcommon.HexToAddress("0x1")
+	operatorID := core.OperatorID{1}
+
+	// Not registered yet
+	tx := &mock.MockTransactor{}
+	tx.On("OperatorAddressToID").Return(core.OperatorID{}, nil)
+	tx.On("OperatorIDToAddress").Return(gethcommon.Address{}, nil)
+	assert.NoError(t, core.VerifyOperatorRegistration(ctx, tx, address, operatorID))
+
+	tx = &mock.MockTransactor{}
+	tx.On("OperatorAddressToID").Return(operatorID, nil)
+	tx.On("OperatorIDToAddress").Return(address, nil)
+	assert.NoError(t, core.VerifyOperatorRegistration(ctx, tx, address, operatorID))
+
+	// The address is registered with the keys of another operator
+	tx = &mock.MockTransactor{}
+	tx.On("OperatorAddressToID").Return(core.OperatorID{2}, nil)
+	assert.ErrorIs(t, core.VerifyOperatorRegistration(ctx, tx, address, operatorID), core.ErrOperatorIDMismatch)
+
+	// The keys are registered by another operator
+	tx = &mock.MockTransactor{}
+	tx.On("OperatorAddressToID").Return(core.OperatorID{}, nil)
+	tx.On("OperatorIDToAddress").Return(gethcommon.HexToAddress("0x2"), nil)
+	assert.ErrorIs(t, core.VerifyOperatorRegistration(ctx, tx, address, operatorID), core.ErrOperatorIDCollision)
+}
@@ -14,8 +14,6 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/wealdtech/go-merkletree"
-	"github.com/wealdtech/go-merkletree/keccak256"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -38,17 +36,8 @@ func ComputeSignatoryRecordHash(referenceBlockNumber uint32, nonSignerKeys []*G1
 }
 
 // SetBatchRoot sets the BatchRoot field of the BatchHeader to the Merkle root of the blob headers in the batch (i.e. the root of the Merkle tree whose leaves are the blob headers)
-func (h *BatchHeader) SetBatchRoot(blobHeaders []*BlobHeader) (*merkletree.MerkleTree, error) {
-	leafs := make([][]byte, len(blobHeaders))
-	for i, header := range blobHeaders {
-		leaf, err := header.GetBlobHeaderHash()
-		if err != nil {
-			return nil, fmt.Errorf("failed to compute blob header hash: %w", err)
-		}
-		leafs[i] = leaf[:]
-	}
-
-	tree, err := merkletree.NewTree(merkletree.WithData(leafs), merkletree.WithHashType(keccak256.New()))
+func (h *BatchHeader) SetBatchRoot(blobHeaders []*BlobHeader) (*MerkleTree, error) {
+	tree, err := BuildBlobHeadersTree(blobHeaders)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +46,30 @@ func (h *BatchHeader) SetBatchRoot(blobHeaders []*BlobHeader) (*merkletree.Merkl
 	return tree, nil
 }
 
+// BuildBlobHeadersTree returns the Merkle tree whose leaves are the hashes of the blob headers, hashing the headers in
+// parallel
+func BuildBlobHeadersTree(blobHeaders []*BlobHeader) (*MerkleTree, error) {
+	leafs := make([][]byte, len(blobHeaders))
+	errs := make([]error, len(blobHeaders))
+	parallelFor(len(blobHeaders), func(start, end int) {
+		for i := start; i < end; i++ {
+			leaf, err := blobHeaders[i].GetBlobHeaderHash()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			leafs[i] = leaf[:]
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob header hash: %w", err)
+		}
+	}
+
+	return NewMerkleTree(leafs)
+}
+
 func (h *BatchHeader) Encode() ([]byte, error) {
 	// The order here has to match the field ordering of ReducedBatchHeader defined in IEigenDAServiceManager.sol
 	// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L43
@@ -160,6 +160,27 @@ func TestCommitmentMarshaling(t *testing.T) {
 	assert.Equal(t, recovered, commitment)
 }
 
+func TestPointBinaryMarshaling(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+
+	data, err := keyPair.GetPubKeyG1().MarshalBinary()
+	assert.NoError(t, err)
+	recoveredG1 := new(core.G1Point)
+	assert.NoError(t, recoveredG1.UnmarshalBinary(data))
+	assert.Equal(t, keyPair.GetPubKeyG1(), recoveredG1)
+
+	data, err = keyPair.GetPubKeyG2().MarshalBinary()
+	assert.NoError(t, err)
+	recoveredG2 := new(core.G2Point)
+	assert.NoError(t, recoveredG2.UnmarshalBinary(data))
+	assert.Equal(t, keyPair.GetPubKeyG2(), recoveredG2)
+
+	// Deserialize rejects trailing bytes
+	_, err = new(core.G1Point).Deserialize(append(keyPair.GetPubKeyG1().Serialize(), 0))
+	assert.Error(t, err)
+}
+
 func TestQuorumParamsHash(t *testing.T) {
 	blobHeader := &core.BlobHeader{
 		QuorumInfos: []*core.BlobQuorumInfo{
@@ -60,6 +60,36 @@ type OperatorState struct {
 	BlockNumber uint
 }
 
+// NewOperatorStateFromStakes returns the OperatorState of the operators with the given stakes at blockNumber
+func NewOperatorStateFromStakes(operatorsByQuorum OperatorStakes, blockNumber uint) *OperatorState {
+	operators := make(map[QuorumID]map[OperatorID]*OperatorInfo)
+	totals := make(map[QuorumID]*OperatorInfo)
+
+	for quorumID, quorum := range operatorsByQuorum {
+		totalStake := big.NewInt(0)
+		operators[quorumID] = make(map[OperatorID]*OperatorInfo)
+
+		for ind, op := range quorum {
+			operators[quorumID][op.OperatorID] = &OperatorInfo{
+				Stake: op.Stake,
+				Index: OperatorIndex(ind),
+			}
+			totalStake.Add(totalStake, op.Stake)
+		}
+
+		totals[quorumID] = &OperatorInfo{
+			Stake: totalStake,
+			Index: OperatorIndex(len(quorum)),
+		}
+	}
+
+	return &OperatorState{
+		Operators:   operators,
+		Totals:      totals,
+		BlockNumber: blockNumber,
+	}
+}
+
 // IndexedOperatorInfo contains information about an operator which is contained in events from the EigenDA smart contracts. Note that
 // this information does not depend on the quorum.
 type IndexedOperatorInfo struct {
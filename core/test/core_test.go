@@ -212,9 +212,19 @@ func checkBatchByUniversalVerifier(cst core.IndexedChainState, encodedBlobs []co
 				Bundles:    encodedBlob.BundlesByOperator[id],
 			}
 		}
-		err := val.ValidateBatch(&header, blobMessages, state.OperatorState, pool)
+		stats, err := val.ValidateBatch(&header, blobMessages, state.OperatorState, pool)
 		if err != nil {
 			errList = multierror.Append(errList, err)
+			continue
+		}
+		for z, blobMessage := range blobMessages {
+			numChunks := 0
+			for _, bundle := range blobMessage.Bundles {
+				numChunks += len(bundle)
+			}
+			if stats[z].NumChunks != numChunks {
+				errList = multierror.Append(errList, fmt.Errorf("blob %d: validated %d chunks, expected %d", z, stats[z].NumChunks, numChunks))
+			}
 		}
 	}
 
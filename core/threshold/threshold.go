@@ -0,0 +1,101 @@
+// Package threshold contains the arithmetic used to compare stake amounts against quorum thresholds.
+//
+// Thresholds are expressed as whole percentages of the total stake of a quorum, while stake shares reported
+// for operators are expressed in basis points. All the helpers here work on integers and document their
+// rounding, so that every component checking a threshold reaches the same result.
+package threshold
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/encoding/utils/intmath"
+	"golang.org/x/exp/constraints"
+)
+
+const (
+	// PercentMultiplier is the denominator of a percentage
+	PercentMultiplier = 100
+	// BasisPointMultiplier is the denominator of a quantity in basis points
+	BasisPointMultiplier = 10000
+
+	// MinThresholdGap is the minimum difference between the confirmation and adversary thresholds
+	MinThresholdGap = 10
+)
+
+var (
+	ErrConfirmationThresholdTooLarge = errors.New("confimration threshold exceeds 100")
+	ErrZeroAdversaryThreshold        = errors.New("adversary threshold equals 0")
+	ErrThresholdGapTooSmall          = errors.New("confirmation threshold must be >= 10 + adversary threshold")
+)
+
+// ValidateSecurityParam checks that the confirmation and adversary thresholds, in percent, are consistent.
+func ValidateSecurityParam(confirmationThreshold, adversaryThreshold uint32) error {
+	if confirmationThreshold > PercentMultiplier {
+		return ErrConfirmationThresholdTooLarge
+	}
+	if adversaryThreshold == 0 {
+		return ErrZeroAdversaryThreshold
+	}
+	if confirmationThreshold < adversaryThreshold || confirmationThreshold-adversaryThreshold < MinThresholdGap {
+		return ErrThresholdGapTooSmall
+	}
+	return nil
+}
+
+// RoundUpDivide returns ceil(a / b). b must be positive.
+func RoundUpDivide[T constraints.Integer](a, b T) T {
+	return intmath.RoundUpDivide(a, b)
+}
+
+// RoundUpDivideBig returns ceil(a / b) for non-negative a and positive b.
+func RoundUpDivideBig(a, b *big.Int) *big.Int {
+	num := new(big.Int).Add(a, b)
+	num.Sub(num, big.NewInt(1)) // a + b - 1
+	return num.Div(num, b)      // (a + b - 1) / b
+}
+
+// StakeThreshold returns the minimum stake meeting the given threshold, in percent, of the total stake,
+// i.e. ceil(totalStake * threshold / 100).
+func StakeThreshold(totalStake *big.Int, threshold uint8) *big.Int {
+	stakeThreshold := new(big.Int).Mul(big.NewInt(int64(threshold)), totalStake)
+	return RoundUpDivideBig(stakeThreshold, big.NewInt(PercentMultiplier))
+}
+
+// SignedPercentage returns the percentage of the total stake represented by the signed stake, rounded down.
+// A quorum without stake has nothing signed. The inputs are not modified.
+func SignedPercentage(signedStake, totalStake *big.Int) uint8 {
+	return uint8(ratio(signedStake, totalStake, PercentMultiplier))
+}
+
+// StakeShareBasisPoints returns the share of the total stake held by stake, in basis points rounded down.
+func StakeShareBasisPoints(stake, totalStake *big.Int) uint64 {
+	return ratio(stake, totalStake, BasisPointMultiplier)
+}
+
+// MeetsThreshold returns whether the signed stake is at least the given threshold, in percent, of the total stake.
+// The comparison is exact: it agrees with both comparing against StakeThreshold and comparing SignedPercentage
+// against the threshold with PercentMeetsThreshold.
+func MeetsThreshold(signedStake, totalStake *big.Int, threshold uint8) bool {
+	return signedStake.Cmp(StakeThreshold(totalStake, threshold)) >= 0
+}
+
+// PercentMeetsThreshold returns whether a signed percentage, as returned by SignedPercentage, meets the threshold.
+func PercentMeetsThreshold(percentSigned, threshold uint8) bool {
+	return percentSigned >= threshold
+}
+
+// EncodedLength returns the length of a blob of the given length once erasure coded at the rate implied by the
+// thresholds, i.e. ceil(length * 100 / (confirmationThreshold - adversaryThreshold)).
+// The thresholds must have been validated with ValidateSecurityParam.
+func EncodedLength[T constraints.Integer](length T, confirmationThreshold, adversaryThreshold uint8) T {
+	return intmath.EncodedLength(length, confirmationThreshold, adversaryThreshold)
+}
+
+func ratio(amount, total *big.Int, multiplier int64) uint64 {
+	if total.Sign() <= 0 {
+		return 0
+	}
+	r := new(big.Int).Mul(amount, big.NewInt(multiplier))
+	return r.Div(r, total).Uint64()
+}
@@ -0,0 +1,129 @@
+package threshold_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core/threshold"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSecurityParam(t *testing.T) {
+	for confirmation := uint32(0); confirmation <= 255; confirmation++ {
+		for adversary := uint32(0); adversary <= 255; adversary++ {
+			err := threshold.ValidateSecurityParam(confirmation, adversary)
+			valid := confirmation <= 100 && adversary > 0 && confirmation >= adversary+10
+			assert.Equal(t, valid, err == nil, "confirmation %d, adversary %d", confirmation, adversary)
+		}
+	}
+
+	assert.ErrorIs(t, threshold.ValidateSecurityParam(101, 50), threshold.ErrConfirmationThresholdTooLarge)
+	assert.ErrorIs(t, threshold.ValidateSecurityParam(50, 0), threshold.ErrZeroAdversaryThreshold)
+	assert.ErrorIs(t, threshold.ValidateSecurityParam(50, 41), threshold.ErrThresholdGapTooSmall)
+	assert.ErrorIs(t, threshold.ValidateSecurityParam(40, 50), threshold.ErrThresholdGapTooSmall)
+	assert.NoError(t, threshold.ValidateSecurityParam(50, 40))
+}
+
+func TestRoundUpDivide(t *testing.T) {
+	for a := uint(0); a <= 500; a++ {
+		for b := uint(1); b <= 100; b++ {
+			expected := a / b
+			if a%b != 0 {
+				expected++
+			}
+			assert.Equal(t, expected, threshold.RoundUpDivide(a, b))
+			assert.Equal(t, uint64(expected), threshold.RoundUpDivideBig(new(big.Int).SetUint64(uint64(a)), new(big.Int).SetUint64(uint64(b))).Uint64())
+		}
+	}
+
+	// the inputs are not modified
+	a, b := big.NewInt(7), big.NewInt(2)
+	assert.Equal(t, int64(4), threshold.RoundUpDivideBig(a, b).Int64())
+	assert.Equal(t, int64(7), a.Int64())
+	assert.Equal(t, int64(2), b.Int64())
+}
+
+func TestThresholdsAgree(t *testing.T) {
+	totals := make([]int64, 0)
+	for total := int64(0); total <= 150; total++ {
+		totals = append(totals, total)
+	}
+	totals = append(totals, 997, 1000, 1024, 9999, 10001)
+
+	for _, total := range totals {
+		totalStake := big.NewInt(total)
+		for signed := int64(0); signed <= total; signed++ {
+			signedStake := big.NewInt(signed)
+			percent := threshold.SignedPercentage(signedStake, totalStake)
+			if total > 0 {
+				assert.Equal(t, uint8(signed*100/total), percent)
+			} else {
+				assert.Equal(t, uint8(0), percent)
+			}
+			assert.Equal(t, signed, signedStake.Int64())
+
+			for q := 0; q <= 100; q++ {
+				quorumThreshold := uint8(q)
+				stakeThreshold := threshold.StakeThreshold(totalStake, quorumThreshold)
+				meets := signed*100 >= total*int64(q)
+
+				assert.Equal(t, meets, threshold.MeetsThreshold(signedStake, totalStake, quorumThreshold), "signed %d, total %d, threshold %d", signed, total, q)
+				assert.Equal(t, meets, signedStake.Cmp(stakeThreshold) >= 0, "signed %d, total %d, threshold %d", signed, total, q)
+				if total > 0 {
+					assert.Equal(t, meets, threshold.PercentMeetsThreshold(percent, quorumThreshold), "signed %d, total %d, threshold %d", signed, total, q)
+				}
+			}
+		}
+	}
+}
+
+func TestStakeThresholdIsMinimal(t *testing.T) {
+	for total := int64(1); total <= 500; total++ {
+		totalStake := big.NewInt(total)
+		for q := uint8(0); q <= 100; q++ {
+			stakeThreshold := threshold.StakeThreshold(totalStake, q)
+			assert.True(t, threshold.MeetsThreshold(stakeThreshold, totalStake, q))
+			if stakeThreshold.Sign() > 0 {
+				belowThreshold := new(big.Int).Sub(stakeThreshold, big.NewInt(1))
+				assert.False(t, threshold.MeetsThreshold(belowThreshold, totalStake, q))
+			}
+			assert.Equal(t, total, totalStake.Int64())
+		}
+	}
+}
+
+func TestStakeShareBasisPoints(t *testing.T) {
+	assert.Equal(t, uint64(0), threshold.StakeShareBasisPoints(big.NewInt(1), big.NewInt(0)))
+	assert.Equal(t, uint64(10000), threshold.StakeShareBasisPoints(big.NewInt(5), big.NewInt(5)))
+	assert.Equal(t, uint64(3333), threshold.StakeShareBasisPoints(big.NewInt(1), big.NewInt(3)))
+	assert.Equal(t, uint64(6666), threshold.StakeShareBasisPoints(big.NewInt(2), big.NewInt(3)))
+
+	for total := int64(1); total <= 300; total++ {
+		for stake := int64(0); stake <= total; stake++ {
+			bps := threshold.StakeShareBasisPoints(big.NewInt(stake), big.NewInt(total))
+			assert.Equal(t, uint64(stake*10000/total), bps)
+			// a share in basis points never rounds up past the share in percent
+			assert.LessOrEqual(t, uint64(threshold.SignedPercentage(big.NewInt(stake), big.NewInt(total)))*100, bps)
+		}
+	}
+}
+
+func TestEncodedLength(t *testing.T) {
+	for confirmation := uint8(0); confirmation <= 100; confirmation++ {
+		for adversary := uint8(0); adversary <= 100; adversary++ {
+			if threshold.ValidateSecurityParam(uint32(confirmation), uint32(adversary)) != nil {
+				continue
+			}
+			gap := uint(confirmation - adversary)
+			for length := uint(0); length <= 300; length++ {
+				encodedLength := threshold.EncodedLength(length, confirmation, adversary)
+				// the encoded length is the smallest length whose reconstruction threshold covers the original data
+				assert.GreaterOrEqual(t, encodedLength*gap, length*100)
+				if encodedLength > 0 {
+					assert.Less(t, (encodedLength-1)*gap, length*100)
+				}
+				assert.Equal(t, uint64(encodedLength), threshold.EncodedLength(uint64(length), confirmation, adversary))
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -11,10 +12,27 @@ import (
 var (
 	ErrChunkLengthMismatch = errors.New("chunk length mismatch")
 	ErrBlobQuorumSkip      = errors.New("blob skipped for a quorum before verification")
+	ErrChunkCountMismatch  = errors.New("chunk count mismatch")
+	ErrBatchRootMismatch   = errors.New("batch header root mismatch")
 )
 
+// BlobValidationStats are the diagnostics of the validation of a blob of a batch
+type BlobValidationStats struct {
+	// VerificationTime is the time spent verifying the chunks and the length proof of the blob. The chunks of the
+	// blobs with the same encoding parameters are verified together, and the time of their verification is split
+	// between the blobs by their number of chunks
+	VerificationTime time.Duration
+	// NumChunks is the number of chunks of the blob validated, across all quorums
+	NumChunks int
+}
+
 type ShardValidator interface {
-	ValidateBatch(*BatchHeader, []*BlobMessage, *OperatorState, common.WorkerPool) error
+	// ValidateBatch validates the blobs of a batch, and returns the diagnostics of the validation of each blob
+	ValidateBatch(*BatchHeader, []*BlobMessage, *OperatorState, common.WorkerPool) ([]BlobValidationStats, error)
+	// ValidateBlobs validates the blobs of a batch like ValidateBatch, except for the batch root, so that the
+	// minibatches of a batch can be validated as they're received before the batch root is checked against all the
+	// blobs with ValidateBatchHeaderRoot
+	ValidateBlobs([]*BlobMessage, *OperatorState, common.WorkerPool) ([]BlobValidationStats, error)
 	UpdateOperatorID(OperatorID)
 }
 
@@ -56,7 +74,7 @@ func (v *shardValidator) validateBlobQuorum(quorumHeader *BlobQuorumInfo, blob *
 		return nil, nil, nil, fmt.Errorf("%w: operator %s has no chunks in quorum %d", ErrBlobQuorumSkip, v.operatorID.Hex(), quorumHeader.QuorumID)
 	}
 	if assignment.NumChunks != uint(len(blob.Bundles[quorumHeader.QuorumID])) {
-		return nil, nil, nil, fmt.Errorf("number of chunks (%d) does not match assignment (%d) for quorum %d", len(blob.Bundles[quorumHeader.QuorumID]), assignment.NumChunks, quorumHeader.QuorumID)
+		return nil, nil, nil, fmt.Errorf("%w: number of chunks (%d) does not match assignment (%d) for quorum %d", ErrChunkCountMismatch, len(blob.Bundles[quorumHeader.QuorumID]), assignment.NumChunks, quorumHeader.QuorumID)
 	}
 
 	// Validate the chunkLength against the confirmation and adversary threshold parameters
@@ -78,6 +96,11 @@ func (v *shardValidator) validateBlobQuorum(quorumHeader *BlobQuorumInfo, blob *
 	if params.ChunkLength != uint64(quorumHeader.ChunkLength) {
 		return nil, nil, nil, fmt.Errorf("%w: chunk length from encoding parameters (%d) does not match quorum header (%d)", ErrChunkLengthMismatch, params.ChunkLength, quorumHeader.ChunkLength)
 	}
+	for i, chunk := range chunks {
+		if err := chunk.Validate(params); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid chunk %d for quorum %d: %w", i, quorumHeader.QuorumID, err)
+		}
+	}
 
 	return chunks, &assignment, &params, nil
 }
@@ -86,19 +109,26 @@ func (v *shardValidator) UpdateOperatorID(operatorID OperatorID) {
 	v.operatorID = operatorID
 }
 
-func (v *shardValidator) ValidateBatch(batchHeader *BatchHeader, blobs []*BlobMessage, operatorState *OperatorState, pool common.WorkerPool) error {
+func (v *shardValidator) ValidateBatch(batchHeader *BatchHeader, blobs []*BlobMessage, operatorState *OperatorState, pool common.WorkerPool) ([]BlobValidationStats, error) {
 
-	err := validateBatchHeaderRoot(batchHeader, blobs)
+	err := ValidateBatchHeaderRoot(batchHeader, blobs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	return v.ValidateBlobs(blobs, operatorState, pool)
+}
+
+func (v *shardValidator) ValidateBlobs(blobs []*BlobMessage, operatorState *OperatorState, pool common.WorkerPool) ([]BlobValidationStats, error) {
 	subBatchMap := make(map[encoding.EncodingParams]*encoding.SubBatch)
+	// the number of chunks of each blob in each subBatch, to split the time of the verification of the subBatch
+	subBatchChunks := make(map[encoding.EncodingParams]map[int]int)
 	blobCommitmentList := make([]encoding.BlobCommitments, len(blobs))
+	stats := make([]BlobValidationStats, len(blobs))
 
 	for k, blob := range blobs {
 		if len(blob.Bundles) != len(blob.BlobHeader.QuorumInfos) {
-			return fmt.Errorf("number of bundles (%d) does not match number of quorums (%d)", len(blob.Bundles), len(blob.BlobHeader.QuorumInfos))
+			return nil, fmt.Errorf("number of bundles (%d) does not match number of quorums (%d)", len(blob.Bundles), len(blob.BlobHeader.QuorumInfos))
 		}
 
 		// Saved for the blob length validation
@@ -110,7 +140,7 @@ func (v *shardValidator) ValidateBatch(batchHeader *BatchHeader, blobs []*BlobMe
 			if errors.Is(err, ErrBlobQuorumSkip) {
 				continue
 			} else if err != nil {
-				return err
+				return nil, err
 			} else {
 				// Check the received chunks against the commitment
 				blobIndex := 0
@@ -136,10 +166,13 @@ func (v *shardValidator) ValidateBatch(batchHeader *BatchHeader, blobs []*BlobMe
 						Samples:  samples,
 						NumBlobs: 1,
 					}
+					subBatchChunks[*params] = make(map[int]int)
 				} else {
 					subBatch.Samples = append(subBatch.Samples, samples...)
 					subBatch.NumBlobs += 1
 				}
+				subBatchChunks[*params][k] += len(chunks)
+				stats[k].NumChunks += len(chunks)
 			}
 		}
 	}
@@ -149,41 +182,64 @@ func (v *shardValidator) ValidateBatch(batchHeader *BatchHeader, blobs []*BlobMe
 	// create a channel to accept results, we don't use stop
 	out := make(chan error, numResult)
 
+	// Each worker records its duration at its own index before sending its result, so the durations are only
+	// read once all the results are received
+	subBatchParams := make([]encoding.EncodingParams, 0, len(subBatchMap))
+	subBatchDurations := make([]time.Duration, len(subBatchMap))
+	lengthDurations := make([]time.Duration, len(blobCommitmentList))
+
 	// parallelize subBatch verification
 	for params, subBatch := range subBatchMap {
 		params := params
 		subBatch := subBatch
+		duration := &subBatchDurations[len(subBatchParams)]
+		subBatchParams = append(subBatchParams, params)
 		pool.Submit(func() {
-			v.universalVerifyWorker(params, subBatch, out)
+			v.universalVerifyWorker(params, subBatch, duration, out)
 		})
 	}
 
 	// parallelize length proof verification
-	for _, blobCommitments := range blobCommitmentList {
+	for k, blobCommitments := range blobCommitmentList {
 		blobCommitments := blobCommitments
+		duration := &lengthDurations[k]
 		pool.Submit(func() {
-			v.VerifyBlobLengthWorker(blobCommitments, out)
+			v.VerifyBlobLengthWorker(blobCommitments, duration, out)
 		})
 	}
 	// check if commitments are equivalent
-	err = v.verifier.VerifyCommitEquivalenceBatch(blobCommitmentList)
+	start := time.Now()
+	err := v.verifier.VerifyCommitEquivalenceBatch(blobCommitmentList)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	equivalenceDuration := time.Since(start)
 
 	for i := 0; i < numResult; i++ {
 		err := <-out
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	for k := range stats {
+		stats[k].VerificationTime = lengthDurations[k] + equivalenceDuration/time.Duration(len(stats))
+	}
+	for i, params := range subBatchParams {
+		numSamples := len(subBatchMap[params].Samples)
+		for k, numChunks := range subBatchChunks[params] {
+			stats[k].VerificationTime += subBatchDurations[i] * time.Duration(numChunks) / time.Duration(numSamples)
+		}
+	}
+
+	return stats, nil
 }
 
-func (v *shardValidator) universalVerifyWorker(params encoding.EncodingParams, subBatch *encoding.SubBatch, out chan error) {
+func (v *shardValidator) universalVerifyWorker(params encoding.EncodingParams, subBatch *encoding.SubBatch, duration *time.Duration, out chan error) {
 
+	start := time.Now()
 	err := v.verifier.UniversalVerifySubBatch(params, subBatch.Samples, subBatch.NumBlobs)
+	*duration = time.Since(start)
 	if err != nil {
 		out <- err
 		return
@@ -192,8 +248,10 @@ func (v *shardValidator) universalVerifyWorker(params encoding.EncodingParams, s
 	out <- nil
 }
 
-func (v *shardValidator) VerifyBlobLengthWorker(blobCommitments encoding.BlobCommitments, out chan error) {
+func (v *shardValidator) VerifyBlobLengthWorker(blobCommitments encoding.BlobCommitments, duration *time.Duration, out chan error) {
+	start := time.Now()
 	err := v.verifier.VerifyBlobLength(blobCommitments)
+	*duration = time.Since(start)
 	if err != nil {
 		out <- err
 		return
@@ -202,7 +260,8 @@ func (v *shardValidator) VerifyBlobLengthWorker(blobCommitments encoding.BlobCom
 	out <- nil
 }
 
-func validateBatchHeaderRoot(batchHeader *BatchHeader, blobs []*BlobMessage) error {
+// ValidateBatchHeaderRoot checks that the batch root of the header is the root of the headers of the blobs
+func ValidateBatchHeaderRoot(batchHeader *BatchHeader, blobs []*BlobMessage) error {
 	// Check the batch header root
 
 	headers := make([]*BlobHeader, len(blobs))
@@ -219,7 +278,7 @@ func validateBatchHeaderRoot(batchHeader *BatchHeader, blobs []*BlobMessage) err
 	}
 
 	if batchHeader.BatchRoot != derivedHeader.BatchRoot {
-		return fmt.Errorf("batch header root does not match computed root")
+		return ErrBatchRootMismatch
 	}
 
 	return nil
@@ -0,0 +1,67 @@
+package disperser
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UsagePeriod is the length of the periods the usage of the accounts is accounted over
+const UsagePeriod = time.Hour
+
+// UsageSettler settles the usage of an account over a usage period, e.g. by charging the account off-chain or by
+// submitting the usage to a payment contract. SettleUsage must be idempotent, as the usage of a period is settled
+// again if marking it settled fails.
+type UsageSettler interface {
+	SettleUsage(ctx context.Context, usage *AccountUsage) error
+}
+
+// UsagePeriodStart returns the start of the usage period the time is in, as a unix time in seconds
+func UsagePeriodStart(t time.Time) uint64 {
+	return uint64(t.Unix()) / uint64(UsagePeriod.Seconds()) * uint64(UsagePeriod.Seconds())
+}
+
+// BlobUsage returns the usage of the confirmed blob, to be added to the usage of its account in its tenant in the usage
+// period of the time of its confirmation. It returns nil for the blobs which aren't dispersed on behalf of an account.
+func BlobUsage(metadata *BlobMetadata, cost *BlobCost, confirmedAt time.Time) *AccountUsage {
+	if metadata.RequestMetadata == nil || metadata.RequestMetadata.AccountID == "" {
+		return nil
+	}
+	usage := &AccountUsage{
+		AccountID:   TenantAccountKey(metadata.RequestMetadata.TenantID, metadata.RequestMetadata.AccountID),
+		PeriodStart: UsagePeriodStart(confirmedAt),
+		NumBlobs:    1,
+		BlobBytes:   uint64(metadata.RequestMetadata.BlobSize),
+	}
+	if cost != nil {
+		usage.DispersalBytes = cost.DispersalBytes
+		usage.EncodingTime = cost.EncodingTime
+		usage.GasFee = cost.GasFee
+	}
+	return usage
+}
+
+// SettleAccountUsage settles the usage of the account, identified by its TenantAccountKey, in the usage periods which
+// ended before the given time and aren't settled yet, from the oldest, and marks them settled. It returns the number
+// of periods settled.
+func SettleAccountUsage(ctx context.Context, store BlobStore, settler UsageSettler, accountID string, before time.Time) (int, error) {
+	usages, err := store.GetAccountUsage(ctx, accountID, 0, UsagePeriodStart(before))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the usage of account %s: %w", accountID, err)
+	}
+
+	settled := 0
+	for _, usage := range usages {
+		if usage.Settled {
+			continue
+		}
+		if err := settler.SettleUsage(ctx, usage); err != nil {
+			return settled, fmt.Errorf("failed to settle the usage of account %s in period %d: %w", accountID, usage.PeriodStart, err)
+		}
+		if err := store.MarkAccountUsageSettled(ctx, accountID, usage.PeriodStart); err != nil {
+			return settled, fmt.Errorf("failed to mark the usage of account %s in period %d settled: %w", accountID, usage.PeriodStart, err)
+		}
+		settled++
+	}
+	return settled, nil
+}
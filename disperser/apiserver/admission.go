@@ -0,0 +1,223 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	defaultAdmissionPollInterval = 5 * time.Second
+	defaultAdmissionRetryAfter   = 10 * time.Second
+
+	// encoderQueueDepthMetric is the gauge of the encoder exposing the number of queued encode requests
+	encoderQueueDepthMetric = "eigenda_encoder_queue_depth"
+)
+
+// admissionLoad is the load of the dispersal pipeline at the last poll
+type admissionLoad struct {
+	encoderQueueDepth int
+	queuedBlobs       int
+	storeLatency      time.Duration
+}
+
+// admissionController rejects the dispersal requests while the dispersal pipeline is saturated. The load of the
+// encoder, of the batcher and of the blob store is measured every poll interval, so that the requests are admitted
+// without extra work.
+type admissionController struct {
+	config     disperser.AdmissionConfig
+	blobStore  disperser.BlobStore
+	httpClient *http.Client
+	logger     logging.Logger
+
+	mu sync.Mutex
+	// overload describes why the pipeline was saturated at the last poll, if it was
+	overload string
+	// polled is closed and replaced at every poll, waking up the delayed requests
+	polled chan struct{}
+	// storeLatencySum and storeLatencyCount add up the latencies of the blob store writes since the last poll
+	storeLatencySum   time.Duration
+	storeLatencyCount int
+}
+
+func newAdmissionController(config disperser.AdmissionConfig, blobStore disperser.BlobStore, logger logging.Logger) *admissionController {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultAdmissionPollInterval
+	}
+	if config.RetryAfter <= 0 {
+		config.RetryAfter = defaultAdmissionRetryAfter
+	}
+	return &admissionController{
+		config:     config,
+		blobStore:  blobStore,
+		httpClient: &http.Client{Timeout: config.PollInterval},
+		logger:     logger.With("component", "AdmissionController"),
+		polled:     make(chan struct{}),
+	}
+}
+
+// enabled returns whether any load of the pipeline is bounded
+func (a *admissionController) enabled() bool {
+	return (a.config.MaxEncoderQueueDepth > 0 && a.config.EncoderMetricsURL != "") || a.config.MaxQueuedBlobs > 0 || a.config.MaxStoreLatency > 0
+}
+
+// Start polls the load of the pipeline until the context is done
+func (a *admissionController) Start(ctx context.Context) {
+	if !a.enabled() {
+		return
+	}
+	a.Poll(ctx)
+
+	go func() {
+		ticker := time.NewTicker(a.config.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.Poll(ctx)
+			}
+		}
+	}()
+}
+
+// Poll measures the load of the pipeline. A load which can't be measured is left out, so that the dispersals aren't
+// rejected because of a failing measurement.
+func (a *admissionController) Poll(ctx context.Context) {
+	load := admissionLoad{}
+	if a.config.MaxEncoderQueueDepth > 0 && a.config.EncoderMetricsURL != "" {
+		depth, err := a.getEncoderQueueDepth(ctx)
+		if err != nil {
+			a.logger.Warn("failed to get the queue depth of the encoder", "err", err)
+		} else {
+			load.encoderQueueDepth = depth
+		}
+	}
+	if a.config.MaxQueuedBlobs > 0 {
+		queued := 0
+		for _, status := range queuedStatuses {
+			metadatas, err := a.blobStore.GetBlobMetadataByStatus(ctx, status)
+			if err != nil {
+				a.logger.Warn("failed to count the queued blobs", "err", err)
+				queued = 0
+				break
+			}
+			queued += len(metadatas)
+		}
+		load.queuedBlobs = queued
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// The latency of the writes since the last poll, none meaning the blob store isn't the bottleneck
+	if a.storeLatencyCount > 0 {
+		load.storeLatency = a.storeLatencySum / time.Duration(a.storeLatencyCount)
+	}
+	a.storeLatencySum, a.storeLatencyCount = 0, 0
+
+	overload := a.overloadReason(load)
+	if overload != "" && a.overload == "" {
+		a.logger.Warn("the dispersal pipeline is saturated, rejecting new blobs", "reason", overload)
+	} else if overload == "" && a.overload != "" {
+		a.logger.Info("the dispersal pipeline is no longer saturated, accepting new blobs")
+	}
+	a.overload = overload
+	close(a.polled)
+	a.polled = make(chan struct{})
+}
+
+func (a *admissionController) overloadReason(load admissionLoad) string {
+	switch {
+	case a.config.MaxEncoderQueueDepth > 0 && load.encoderQueueDepth > a.config.MaxEncoderQueueDepth:
+		return fmt.Sprintf("%d encode requests are queued, above the maximum of %d", load.encoderQueueDepth, a.config.MaxEncoderQueueDepth)
+	case a.config.MaxQueuedBlobs > 0 && load.queuedBlobs > a.config.MaxQueuedBlobs:
+		return fmt.Sprintf("%d blobs are waiting to be batched, above the maximum of %d", load.queuedBlobs, a.config.MaxQueuedBlobs)
+	case a.config.MaxStoreLatency > 0 && load.storeLatency > a.config.MaxStoreLatency:
+		return fmt.Sprintf("the blob store writes take %s, above the maximum of %s", load.storeLatency, a.config.MaxStoreLatency)
+	default:
+		return ""
+	}
+}
+
+// ObserveStoreLatency records the latency of a blob store write
+func (a *admissionController) ObserveStoreLatency(latency time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.storeLatencySum += latency
+	a.storeLatencyCount++
+}
+
+// Admit returns nil if a new blob can be dispersed. While the pipeline is saturated, the request waits up to the max
+// delay for the load to drop, and is rejected with a RESOURCE_EXHAUSTED error telling the client when to retry if it
+// doesn't.
+func (a *admissionController) Admit(ctx context.Context) error {
+	a.mu.Lock()
+	overload, polled := a.overload, a.polled
+	a.mu.Unlock()
+	if overload == "" {
+		return nil
+	}
+
+	if a.config.MaxDelay > 0 {
+		timer := time.NewTimer(a.config.MaxDelay)
+		defer timer.Stop()
+		for overload != "" {
+			select {
+			case <-polled:
+			case <-timer.C:
+				return a.rejectedError(overload)
+			case <-ctx.Done():
+				return a.rejectedError(overload)
+			}
+			a.mu.Lock()
+			overload, polled = a.overload, a.polled
+			a.mu.Unlock()
+		}
+		return nil
+	}
+	return a.rejectedError(overload)
+}
+
+func (a *admissionController) rejectedError(overload string) error {
+	return api.NewResourceExhaustedErrorWithRetry(fmt.Sprintf("the disperser is overloaded, please retry: %s", overload), a.config.RetryAfter)
+}
+
+// getEncoderQueueDepth reads the queue depth gauge from the metrics of the encoder
+func (a *admissionController) getEncoderQueueDepth(ctx context.Context) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.config.EncoderMetricsURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("the encoder metrics returned status %d", resp.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the encoder metrics: %w", err)
+	}
+	family, ok := families[encoderQueueDepthMetric]
+	if !ok {
+		return 0, fmt.Errorf("the encoder metrics have no %s gauge", encoderQueueDepthMetric)
+	}
+	depth := 0.0
+	for _, metric := range family.GetMetric() {
+		depth += metric.GetGauge().GetValue()
+	}
+	return int(math.Round(depth)), nil
+}
@@ -0,0 +1,96 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdmissionController(t *testing.T) {
+	ctx := context.Background()
+	var depth atomic.Int64
+	encoder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "# TYPE eigenda_encoder_queue_depth gauge\neigenda_encoder_queue_depth %d\n", depth.Load())
+	}))
+	defer encoder.Close()
+
+	store := inmem.NewBlobStore()
+	admission := newAdmissionController(disperser.AdmissionConfig{
+		EncoderMetricsURL:    encoder.URL,
+		MaxEncoderQueueDepth: 4,
+		MaxQueuedBlobs:       2,
+		MaxStoreLatency:      time.Second,
+		RetryAfter:           3 * time.Second,
+	}, store, logging.NewNoopLogger())
+
+	admission.Poll(ctx)
+	assert.NoError(t, admission.Admit(ctx))
+
+	// A saturated encoder rejects the requests with a retry hint
+	depth.Store(5)
+	admission.Poll(ctx)
+	err := admission.Admit(ctx)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.ErrorContains(t, err, "5 encode requests are queued")
+	details := status.Convert(err).Details()
+	assert.Len(t, details, 1)
+	assert.Equal(t, 3*time.Second, details[0].(*errdetails.RetryInfo).GetRetryDelay().AsDuration())
+	depth.Store(0)
+
+	// So does a backlog of blobs waiting to be batched
+	for i := 0; i < 3; i++ {
+		_, err := store.StoreBlob(ctx, &core.Blob{Data: []byte{byte(i)}}, uint64(i))
+		assert.NoError(t, err)
+	}
+	admission.Poll(ctx)
+	assert.ErrorContains(t, admission.Admit(ctx), "3 blobs are waiting to be batched")
+	metadatas, err := store.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.NoError(t, store.MarkBlobFailed(ctx, metadatas[0].GetBlobKey()))
+
+	// And slow blob store writes, until a poll sees no slow write
+	admission.ObserveStoreLatency(3 * time.Second)
+	admission.ObserveStoreLatency(time.Second)
+	admission.Poll(ctx)
+	assert.ErrorContains(t, admission.Admit(ctx), "the blob store writes take 2s")
+	admission.Poll(ctx)
+	assert.NoError(t, admission.Admit(ctx))
+}
+
+func TestAdmissionControllerDelay(t *testing.T) {
+	ctx := context.Background()
+	admission := newAdmissionController(disperser.AdmissionConfig{
+		MaxStoreLatency: time.Second,
+		MaxDelay:        time.Minute,
+	}, inmem.NewBlobStore(), logging.NewNoopLogger())
+	admission.ObserveStoreLatency(2 * time.Second)
+	admission.Poll(ctx)
+
+	// A delayed request is admitted once the load drops
+	admitted := make(chan error)
+	go func() {
+		admitted <- admission.Admit(ctx)
+	}()
+	admission.Poll(ctx)
+	assert.NoError(t, <-admitted)
+
+	// And rejected if the context is done first
+	admission.ObserveStoreLatency(2 * time.Second)
+	admission.Poll(ctx)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(admission.Admit(cancelled)))
+}
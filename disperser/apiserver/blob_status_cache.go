@@ -0,0 +1,83 @@
+package apiserver
+
+import (
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+const (
+	defaultBlobStatusCacheSize = 10000
+	defaultBlobStatusCacheTTL  = 10 * time.Minute
+)
+
+// blobStatusCache keeps the status replies of the finalized blobs by blob key. The inclusion proof of a blob is
+// computed once its batch is confirmed and doesn't change after its batch is finalized, so the polls of finalized
+// blobs are served without reading the blob store. A nil cache keeps nothing.
+//
+// The batcher only rolls back the blobs of reorged batches while they're confirmed, never once they're finalized, but
+// it runs in another process which can't reach the cache. So that a blob whose status changed in the blob store anyway
+// isn't served stale for long, the replies expire after a TTL, and a blob read from the blob store with any other
+// status than finalized is removed from the cache.
+type blobStatusCache struct {
+	replies *lru.Cache[disperser.BlobKey, blobStatusCacheEntry]
+	ttl     time.Duration
+}
+
+// blobStatusCacheEntry is the reply of a blob with the tenant which dispersed it, the only one it's served to
+type blobStatusCacheEntry struct {
+	tenantID string
+	reply    *pb.BlobStatusReply
+	// expiresAt is the time after which the reply isn't served anymore
+	expiresAt time.Time
+}
+
+// newBlobStatusCache returns a cache of the given number of replies kept for the given TTL, or nil if the size isn't
+// positive
+func newBlobStatusCache(size int, ttl time.Duration) *blobStatusCache {
+	if size <= 0 {
+		return nil
+	}
+	replies, err := lru.New[disperser.BlobKey, blobStatusCacheEntry](size)
+	if err != nil {
+		return nil
+	}
+	return &blobStatusCache{replies: replies, ttl: ttl}
+}
+
+// get returns the reply of the blob if it's cached, hasn't expired and was dispersed by the tenant
+func (c *blobStatusCache) get(key disperser.BlobKey, tenantID string) (*pb.BlobStatusReply, bool) {
+	if c == nil {
+		return nil, false
+	}
+	entry, ok := c.replies.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		c.replies.Remove(key)
+		return nil, false
+	}
+	if entry.tenantID != tenantID {
+		return nil, false
+	}
+	return entry.reply, true
+}
+
+// add keeps the reply of the blob if it is finalized, and removes the reply cached for the blob otherwise
+func (c *blobStatusCache) add(metadata *disperser.BlobMetadata, reply *pb.BlobStatusReply) {
+	if c == nil {
+		return
+	}
+	if metadata.BlobStatus != disperser.Finalized {
+		c.replies.Remove(metadata.GetBlobKey())
+		return
+	}
+	entry := blobStatusCacheEntry{reply: reply, expiresAt: time.Now().Add(c.ttl)}
+	if metadata.RequestMetadata != nil {
+		entry.tenantID = metadata.RequestMetadata.TenantID
+	}
+	c.replies.Add(metadata.GetBlobKey(), entry)
+}
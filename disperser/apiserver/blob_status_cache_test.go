@@ -0,0 +1,49 @@
+package apiserver
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlobStatusCache(t *testing.T) {
+	cache := newBlobStatusCache(10, time.Hour)
+	metadata := &disperser.BlobMetadata{
+		BlobHash:        "blob",
+		MetadataHash:    "hash",
+		BlobStatus:      disperser.Finalized,
+		RequestMetadata: &disperser.RequestMetadata{},
+	}
+	key := metadata.GetBlobKey()
+	reply := &pb.BlobStatusReply{Status: pb.BlobStatus_FINALIZED}
+
+	// Only the finalized blobs are kept, and served to their tenant
+	cache.add(metadata, reply)
+	cached, ok := cache.get(key, "")
+	assert.True(t, ok)
+	assert.Equal(t, reply, cached)
+	_, ok = cache.get(key, "tenant")
+	assert.False(t, ok)
+
+	// A blob read again with another status is removed
+	cache.add(&disperser.BlobMetadata{BlobHash: "blob", MetadataHash: "hash", BlobStatus: disperser.Processing}, &pb.BlobStatusReply{Status: pb.BlobStatus_PROCESSING})
+	_, ok = cache.get(key, "")
+	assert.False(t, ok)
+
+	// The replies expire
+	cache = newBlobStatusCache(10, time.Millisecond)
+	cache.add(metadata, reply)
+	time.Sleep(5 * time.Millisecond)
+	_, ok = cache.get(key, "")
+	assert.False(t, ok)
+
+	// A nil cache keeps nothing
+	cache = newBlobStatusCache(0, time.Hour)
+	assert.Nil(t, cache)
+	cache.add(metadata, reply)
+	_, ok = cache.get(key, "")
+	assert.False(t, ok)
+}
@@ -0,0 +1,190 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxBlobStatusesPerRequest is the largest number of statuses returned by a single GetBlobStatuses request
+	maxBlobStatusesPerRequest = 100
+	// maxBlobStatusesTime is the largest time, in Unix seconds, of the range of a GetBlobStatuses request, so that
+	// the range can be converted to the nanoseconds of the request times of the blobs
+	maxBlobStatusesTime uint64 = math.MaxUint64 / uint64(time.Second)
+)
+
+// blobStatusesPageToken is the page token of GetBlobStatuses. It's opaque to the clients, which only pass the
+// next_page_token of a reply back to get the next page.
+type blobStatusesPageToken struct {
+	// Offset is the index of the first request ID of the page, for requests by request IDs
+	Offset int `json:"offset,omitempty"`
+	// StartKey is the key of the last blob of the previous page, for requests by account
+	StartKey *disperser.BlobStoreExclusiveStartKey `json:"start_key,omitempty"`
+}
+
+// GetBlobStatuses returns the statuses of the blobs with the given request IDs, or of the blobs dispersed by an
+// account in a time range, a page at a time.
+func (s *DispersalServer) GetBlobStatuses(ctx context.Context, req *pb.BlobStatusesRequest) (*pb.BlobStatusesReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GetBlobStatuses", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	limit, token, err := validateBlobStatusesRequest(req)
+	if err != nil {
+		s.metrics.HandleInvalidArgRpcRequest("GetBlobStatuses")
+		s.metrics.HandleInvalidArgRequest("GetBlobStatuses")
+		return nil, err
+	}
+
+	tenant, err := s.tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var reply *pb.BlobStatusesReply
+	if len(req.GetRequestIds()) > 0 {
+		reply, err = s.getBlobStatusesByRequestIDs(ctx, req.GetRequestIds(), limit, token, tenantIDOf(tenant))
+	} else {
+		reply, err = s.getBlobStatusesByAccount(ctx, req, limit, token, tenantIDOf(tenant))
+	}
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			s.metrics.HandleInvalidArgRpcRequest("GetBlobStatuses")
+			s.metrics.HandleInvalidArgRequest("GetBlobStatuses")
+		} else {
+			s.metrics.HandleInternalFailureRpcRequest("GetBlobStatuses")
+		}
+		return nil, err
+	}
+
+	s.metrics.HandleSuccessfulRpcRequest("GetBlobStatuses")
+	return reply, nil
+}
+
+func validateBlobStatusesRequest(req *pb.BlobStatusesRequest) (int, *blobStatusesPageToken, error) {
+	if (len(req.GetRequestIds()) > 0) == (req.GetAccountId() != "") {
+		return 0, nil, api.NewInvalidArgError("exactly one of request_ids and account_id must be set")
+	}
+	if len(req.GetRequestIds()) > maxBlobStatusesPerRequest {
+		return 0, nil, api.NewInvalidArgError(fmt.Sprintf("request_ids must contain at most %d request IDs, found %d", maxBlobStatusesPerRequest, len(req.GetRequestIds())))
+	}
+	if req.GetStartTime() > maxBlobStatusesTime || req.GetEndTime() > maxBlobStatusesTime {
+		return 0, nil, api.NewInvalidArgError(fmt.Sprintf("start_time and end_time must be at most %d", maxBlobStatusesTime))
+	}
+	if req.GetEndTime() != 0 && req.GetEndTime() <= req.GetStartTime() {
+		return 0, nil, api.NewInvalidArgError(fmt.Sprintf("end_time %d must be after start_time %d", req.GetEndTime(), req.GetStartTime()))
+	}
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = maxBlobStatusesPerRequest
+	}
+	if limit > maxBlobStatusesPerRequest {
+		return 0, nil, api.NewInvalidArgError(fmt.Sprintf("limit must be at most %d, got %d", maxBlobStatusesPerRequest, limit))
+	}
+
+	token := &blobStatusesPageToken{}
+	if len(req.GetPageToken()) > 0 {
+		if err := json.Unmarshal(req.GetPageToken(), token); err != nil {
+			return 0, nil, api.NewInvalidArgError(fmt.Sprintf("invalid page_token: %v", err))
+		}
+		if token.Offset < 0 || token.Offset > len(req.GetRequestIds()) {
+			return 0, nil, api.NewInvalidArgError("invalid page_token: offset out of range")
+		}
+	}
+	return limit, token, nil
+}
+
+// getBlobStatusesByRequestIDs returns the statuses of the blobs with the given request IDs. The blobs of other tenants
+// are reported as not found.
+func (s *DispersalServer) getBlobStatusesByRequestIDs(ctx context.Context, requestIDs [][]byte, limit int, token *blobStatusesPageToken, tenantID string) (*pb.BlobStatusesReply, error) {
+	end := min(token.Offset+limit, len(requestIDs))
+	reply := &pb.BlobStatusesReply{
+		Statuses: make([]*pb.BlobStatusEntry, 0, end-token.Offset),
+	}
+	for _, requestID := range requestIDs[token.Offset:end] {
+		metadataKey, err := disperser.ParseBlobKey(string(requestID))
+		if err != nil {
+			return nil, api.NewInvalidArgError(fmt.Sprintf("failed to parse the requestID %s: %s", string(requestID), err.Error()))
+		}
+
+		entry := &pb.BlobStatusEntry{RequestId: requestID}
+		if cached, ok := s.statusCache.get(metadataKey, tenantID); ok {
+			entry.Reply = cached
+			reply.Statuses = append(reply.Statuses, entry)
+			continue
+		}
+		metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+		if err != nil && !errors.Is(err, disperser.ErrMetadataNotFound) && !errors.Is(err, disperser.ErrBlobNotFound) {
+			return nil, api.NewInternalError(fmt.Sprintf("failed to get blob metadata, blobkey: %s", metadataKey.String()))
+		}
+		if err == nil && visibleToTenant(metadata, tenantID) {
+			entry.Reply, err = s.getBlobStatusReply(metadata)
+			if err != nil {
+				return nil, err
+			}
+		}
+		reply.Statuses = append(reply.Statuses, entry)
+	}
+
+	if end < len(requestIDs) {
+		nextPageToken, err := json.Marshal(&blobStatusesPageToken{Offset: end})
+		if err != nil {
+			return nil, api.NewInternalError(fmt.Sprintf("failed to encode the page token: %v", err))
+		}
+		reply.NextPageToken = nextPageToken
+	}
+	return reply, nil
+}
+
+// getBlobStatusesByAccount returns the statuses of the blobs the account dispersed for the tenant
+func (s *DispersalServer) getBlobStatusesByAccount(ctx context.Context, req *pb.BlobStatusesRequest, limit int, token *blobStatusesPageToken, tenantID string) (*pb.BlobStatusesReply, error) {
+	// The blobs are stored with their request times in nanoseconds
+	from := req.GetStartTime() * uint64(time.Second)
+	to := uint64(math.MaxUint64)
+	if req.GetEndTime() != 0 {
+		to = req.GetEndTime() * uint64(time.Second)
+	}
+
+	accountKey := disperser.TenantAccountKey(tenantID, req.GetAccountId())
+	metadatas, startKey, err := s.blobStore.GetBlobMetadataByAccountWithPagination(ctx, accountKey, from, to, int32(limit), token.StartKey)
+	if err != nil {
+		s.logger.Error("failed to get the blobs of the account", "accountID", req.GetAccountId(), "err", err)
+		return nil, api.NewInternalError(fmt.Sprintf("failed to get the blobs of account %s", req.GetAccountId()))
+	}
+
+	reply := &pb.BlobStatusesReply{
+		Statuses: make([]*pb.BlobStatusEntry, len(metadatas)),
+	}
+	for i, metadata := range metadatas {
+		statusReply, err := s.getBlobStatusReply(metadata)
+		if err != nil {
+			return nil, err
+		}
+		reply.Statuses[i] = &pb.BlobStatusEntry{
+			RequestId: []byte(metadata.GetBlobKey().String()),
+			Reply:     statusReply,
+		}
+	}
+
+	if startKey != nil {
+		nextPageToken, err := json.Marshal(&blobStatusesPageToken{StartKey: startKey})
+		if err != nil {
+			return nil, api.NewInternalError(fmt.Sprintf("failed to encode the page token: %v", err))
+		}
+		reply.NextPageToken = nextPageToken
+	}
+	return reply, nil
+}
@@ -0,0 +1,115 @@
+package apiserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// The compressions of the payloads, named as the HTTP content codings
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+var (
+	// The zstd encoder and decoder are safe to use concurrently with EncodeAll and DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(maxBlobSize))
+)
+
+func validateCompression(compression string) error {
+	switch compression {
+	case "", compressionGzip, compressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("unsupported compression %q, must be %s or %s", compression, compressionGzip, compressionZstd)
+	}
+}
+
+// decompressPayload decompresses the payload of a dispersal. The decompressed payload must be at most maxSize bytes,
+// and at most maxRatio times larger than the compressed payload if maxRatio is positive, which bounds the work spent
+// on a decompression bomb.
+func decompressPayload(compression string, data []byte, maxSize int, maxRatio int) ([]byte, error) {
+	if err := validateCompression(compression); err != nil {
+		return nil, err
+	}
+	if compression == "" {
+		return data, nil
+	}
+
+	limit := maxSize
+	if maxRatio > 0 && len(data)*maxRatio < limit {
+		limit = len(data) * maxRatio
+	}
+
+	var decompressed []byte
+	switch compression {
+	case compressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip data: %w", err)
+		}
+		// One more byte than the limit is read, to tell a payload of exactly the limit from a larger one
+		decompressed, err = io.ReadAll(io.LimitReader(reader, int64(limit)+1))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip data: %w", err)
+		}
+	case compressionZstd:
+		header := zstd.Header{}
+		if err := header.Decode(data); err != nil {
+			return nil, fmt.Errorf("invalid zstd data: %w", err)
+		}
+		// The content size is optional in the frame header, and the decoder's memory limit bounds the others
+		if header.HasFCS && header.FrameContentSize > uint64(limit) {
+			return nil, exceededError(limit, maxSize, maxRatio)
+		}
+		var err error
+		decompressed, err = zstdDecoder.DecodeAll(data, nil)
+		if errors.Is(err, zstd.ErrDecoderSizeExceeded) {
+			return nil, fmt.Errorf("the decompressed data exceeds %d bytes", maxSize)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid zstd data: %w", err)
+		}
+	}
+
+	if len(decompressed) > limit {
+		return nil, exceededError(limit, maxSize, maxRatio)
+	}
+	return decompressed, nil
+}
+
+// exceededError is the error of a payload decompressing to more than the limit
+func exceededError(limit, maxSize, maxRatio int) error {
+	if limit < maxSize {
+		return fmt.Errorf("the compression ratio of the data exceeds the maximum of %d", maxRatio)
+	}
+	return fmt.Errorf("the decompressed data exceeds %d bytes", maxSize)
+}
+
+// compressPayload compresses the payload of a retrieval
+func compressPayload(compression string, data []byte) ([]byte, error) {
+	switch compression {
+	case "":
+		return data, nil
+	case compressionGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case compressionZstd:
+		return zstdEncoder.EncodeAll(data, make([]byte, 0, len(data)/2)), nil
+	default:
+		return nil, validateCompression(compression)
+	}
+}
@@ -0,0 +1,51 @@
+package apiserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadCompression(t *testing.T) {
+	data := bytes.Repeat([]byte{0, 1, 2, 3}, 1024)
+	for _, compression := range []string{"", compressionGzip, compressionZstd} {
+		compressed, err := compressPayload(compression, data)
+		assert.NoError(t, err)
+		if compression != "" {
+			assert.Less(t, len(compressed), len(data)/10)
+		}
+
+		decompressed, err := decompressPayload(compression, compressed, maxBlobSize, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+
+		// The decompressed payloads are bounded in size, and in ratio to the compressed payloads
+		if compression != "" {
+			_, err = decompressPayload(compression, compressed, len(data)-1, 0)
+			assert.ErrorContains(t, err, "the decompressed data exceeds")
+			_, err = decompressPayload(compression, compressed, maxBlobSize, 5)
+			assert.ErrorContains(t, err, "the compression ratio of the data exceeds the maximum of 5")
+		}
+	}
+
+	_, err := decompressPayload(compressionGzip, data, maxBlobSize, 0)
+	assert.ErrorContains(t, err, "invalid gzip data")
+	_, err = decompressPayload(compressionZstd, data, maxBlobSize, 0)
+	assert.ErrorContains(t, err, "invalid zstd data")
+	_, err = decompressPayload("br", data, maxBlobSize, 0)
+	assert.ErrorContains(t, err, "unsupported compression")
+}
+
+func TestDecompressGzipWithoutSize(t *testing.T) {
+	// A gzip stream doesn't declare its size upfront, so the bomb is only read up to the limit
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	_, err := writer.Write(make([]byte, 2*maxBlobSize))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	_, err = decompressPayload(compressionGzip, buf.Bytes(), maxBlobSize, 0)
+	assert.ErrorContains(t, err, "the decompressed data exceeds")
+}
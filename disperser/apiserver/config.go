@@ -43,6 +43,9 @@ type PerUserRateInfo struct {
 	Name       string
 	Throughput common.RateParam
 	BlobRate   common.RateParam
+	// Burst scales the rate limiter buckets of the account, allowing it to send bursts above its rates.
+	// Zero means no scaling.
+	Burst float32
 }
 
 type Allowlist = map[string]map[core.QuorumID]PerUserRateInfo
@@ -53,6 +56,7 @@ type AllowlistEntry struct {
 	QuorumID uint8   `json:"quorumID"`
 	BlobRate float64 `json:"blobRate"`
 	ByteRate float64 `json:"byteRate"`
+	Burst    float64 `json:"burst"`
 }
 
 type RateConfig struct {
@@ -210,6 +214,7 @@ func parseAllowlistEntry(c *cli.Context) Allowlist {
 						Name:       entry.Name,
 						Throughput: common.RateParam(entry.ByteRate),
 						BlobRate:   common.RateParam(entry.BlobRate * blobRateMultiplier),
+						Burst:      float32(entry.Burst),
 					},
 				}
 			} else {
@@ -217,6 +222,7 @@ func parseAllowlistEntry(c *cli.Context) Allowlist {
 					Name:       entry.Name,
 					Throughput: common.RateParam(entry.ByteRate),
 					BlobRate:   common.RateParam(entry.BlobRate * blobRateMultiplier),
+					Burst:      float32(entry.Burst),
 				}
 			}
 		}
@@ -0,0 +1,168 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	adminHost           = "127.0.0.1"
+	defaultDrainTimeout = 10 * time.Minute
+	// drainRetryAfter is the delay after which clients are told to retry the blobs rejected while draining, by when
+	// they should be sent to another instance
+	drainRetryAfter = 5 * time.Second
+)
+
+// queuedStatuses are the statuses of the blobs which haven't been confirmed in a batch yet
+var queuedStatuses = []disperser.BlobStatus{disperser.Processing, disperser.Dispersing}
+
+// startDispersal registers a dispersal request, unless the server is draining. The request must be ended with
+// endDispersal.
+func (s *DispersalServer) startDispersal() bool {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+	if s.draining {
+		return false
+	}
+	s.dispersals.Add(1)
+	return true
+}
+
+func (s *DispersalServer) endDispersal() {
+	s.dispersals.Done()
+}
+
+// Drain prepares the server to be shut down without stranding the blobs it accepted. It stops accepting new blobs,
+// waits for the dispersal requests in progress to store their blobs, and then waits until all the blobs requested
+// before the drain started have been confirmed in a batch or have failed. Once it returns, Start returns as well.
+//
+// If the context is done before the queued blobs are confirmed, the server stops anyway and the error says how many
+// blobs were left. Calling Drain while the server is draining waits for the same drain to complete.
+func (s *DispersalServer) Drain(ctx context.Context) error {
+	s.drainMu.Lock()
+	if s.draining {
+		s.drainMu.Unlock()
+		select {
+		case <-s.drained:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s.draining = true
+	s.drainMu.Unlock()
+	defer close(s.drained)
+
+	drainStart := time.Now()
+	s.logger.Info("draining the server, new blobs are rejected")
+	s.dispersals.Wait()
+
+	err := s.waitForQueuedBlobs(ctx, drainStart)
+	if err != nil {
+		s.logger.Error("failed to drain the server", "err", err)
+		return err
+	}
+	s.logger.Info("drained the server", "duration", time.Since(drainStart))
+	return nil
+}
+
+// waitForQueuedBlobs polls the blob store until no blob requested before the given time is waiting to be confirmed.
+// The blobs of all the instances sharing the blob store are waited for, as the batcher flushes them together.
+func (s *DispersalServer) waitForQueuedBlobs(ctx context.Context, requestedBefore time.Time) error {
+	pollInterval := s.serverConfig.StatusPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultStatusPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	remaining := -1
+	for {
+		queued, err := s.countQueuedBlobs(ctx, uint64(requestedBefore.UnixNano()))
+		if err != nil {
+			s.logger.Warn("failed to count the queued blobs", "err", err)
+		} else {
+			if queued == 0 {
+				return nil
+			}
+			if queued != remaining {
+				s.logger.Info("waiting for the queued blobs to be confirmed", "remaining", queued)
+			}
+			remaining = queued
+		}
+
+		select {
+		case <-ctx.Done():
+			if remaining < 0 {
+				return ctx.Err()
+			}
+			return fmt.Errorf("%d blobs were not confirmed: %w", remaining, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DispersalServer) countQueuedBlobs(ctx context.Context, requestedBefore uint64) (int, error) {
+	count := 0
+	for _, status := range queuedStatuses {
+		metadatas, err := s.blobStore.GetBlobMetadataByStatus(ctx, status)
+		if err != nil {
+			return 0, err
+		}
+		for _, metadata := range metadatas {
+			if metadata.RequestMetadata == nil || metadata.RequestMetadata.RequestedAt <= requestedBefore {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// drainRejectedError is returned to the dispersal requests received while the server is draining
+func drainRejectedError() error {
+	return api.NewUnavailableError("the disperser is shutting down, please retry", drainRetryAfter)
+}
+
+// newAdminServer returns the server of the admin endpoints, on the loopback interface. POST /drain starts draining the
+// server in the background and replies right away, the server exiting once the drain completes. /limits gets and sets
+// the limits of the quorums, see handleLimits, and /tenants manages the tenants, see handleTenants.
+func (s *DispersalServer) newAdminServer() *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/limits", s.handleLimits)
+	mux.HandleFunc("/tenants", s.handleTenants)
+	mux.HandleFunc("/drain", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		go func() {
+			timeout := s.serverConfig.DrainTimeout
+			if timeout <= 0 {
+				timeout = defaultDrainTimeout
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			_ = s.Drain(ctx)
+		}()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(map[string]string{"status": "draining"}); err != nil {
+			s.logger.Debug("failed to write the reply", "err", err)
+		}
+	})
+
+	return &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", adminHost, s.serverConfig.AdminPort),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
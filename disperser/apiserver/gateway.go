@@ -0,0 +1,319 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxJSONRequestSize bounds the body of JSON requests: the base64 encoded blob plus the other fields
+const maxJSONRequestSize = maxBlobSize*4/3 + 64*1024
+
+var gatewayMarshalOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// Gateway serves the disperser API over HTTP with JSON bodies, for integrators who can't use gRPC. The requests
+// are handled by the DispersalServer of the same process, so they go through the same validation, authentication
+// and rate limits as the gRPC requests, and the gRPC errors are mapped to the matching HTTP statuses.
+//
+// The routes are:
+//   - POST /v1/blobs disperses a blob. With a JSON body, the body is a DisperseBlobRequest with the data base64
+//     encoded. With an application/octet-stream body, which may use chunked transfer encoding, the body is the raw
+//     data and the custom_quorum_numbers (comma separated) and account_id are taken from the query. The
+//     Idempotency-Key header sets the idempotency_key of the request if the body doesn't. The raw data may be
+//     compressed with gzip or zstd, as given by the Content-Encoding header.
+//   - POST /v1/blobs/batch disperses several blobs together. The body is a DisperseBlobsRequest with the data of the
+//     blobs base64 encoded.
+//   - GET /v1/blobs/{request_id}/status returns the BlobStatusReply of the blob, request_id being base64url encoded.
+//   - POST /v1/blobs/status returns the statuses of several blobs. The body is a BlobStatusesRequest, with the
+//     request_ids and page_token base64 encoded.
+//   - GET /v1/batches/{batch_header_hash}/blobs/{blob_index} retrieves a blob, batch_header_hash being hex encoded.
+//     The compression query parameter sets the compression of the data of the reply.
+//
+// Errors are returned as a JSON object with the gRPC code name and the message.
+type Gateway struct {
+	server *DispersalServer
+	logger logging.Logger
+}
+
+func NewGateway(server *DispersalServer, logger logging.Logger) *Gateway {
+	return &Gateway{
+		server: server,
+		logger: logger.With("component", "Gateway"),
+	}
+}
+
+type gatewayError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(path) == 2 && path[0] == "v1" && path[1] == "blobs":
+		if r.Method != http.MethodPost {
+			g.writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		g.disperseBlob(w, r)
+	case len(path) == 3 && path[0] == "v1" && path[1] == "blobs" && path[2] == "batch":
+		if r.Method != http.MethodPost {
+			g.writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		g.disperseBlobs(w, r)
+	case len(path) == 3 && path[0] == "v1" && path[1] == "blobs" && path[2] == "status":
+		if r.Method != http.MethodPost {
+			g.writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		g.getBlobStatuses(w, r)
+	case len(path) == 4 && path[0] == "v1" && path[1] == "blobs" && path[3] == "status":
+		if r.Method != http.MethodGet {
+			g.writeMethodNotAllowed(w, http.MethodGet)
+			return
+		}
+		g.getBlobStatus(w, r, path[2])
+	case len(path) == 5 && path[0] == "v1" && path[1] == "batches" && path[3] == "blobs":
+		if r.Method != http.MethodGet {
+			g.writeMethodNotAllowed(w, http.MethodGet)
+			return
+		}
+		g.retrieveBlob(w, r, path[2], path[4])
+	default:
+		g.writeError(w, api.NewNotFoundError(fmt.Sprintf("no route for %s", r.URL.Path)))
+	}
+}
+
+func (g *Gateway) disperseBlob(w http.ResponseWriter, r *http.Request) {
+	req := &pb.DisperseBlobRequest{}
+
+	mediaType := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+	switch mediaType {
+	case "application/octet-stream":
+		query := r.URL.Query()
+		if quorums := query.Get("custom_quorum_numbers"); quorums != "" {
+			for _, quorum := range strings.Split(quorums, ",") {
+				quorumID, err := strconv.ParseUint(strings.TrimSpace(quorum), 10, 32)
+				if err != nil {
+					g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("invalid custom_quorum_numbers: %v", err)))
+					return
+				}
+				req.CustomQuorumNumbers = append(req.CustomQuorumNumbers, uint32(quorumID))
+			}
+		}
+		req.AccountId = query.Get("account_id")
+
+		// The body is read as it arrives, so a chunked upload is rejected as soon as it exceeds the maximum blob size
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxBlobSize+1))
+		if err != nil {
+			g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("failed to read the request body: %v", err)))
+			return
+		}
+		if len(data) > maxBlobSize {
+			g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("blob size cannot exceed %v bytes", maxBlobSize)))
+			return
+		}
+		req.Data = data
+		// A compressed body is decompressed by the DispersalServer, the same as a compressed data field
+		if encoding := r.Header.Get("Content-Encoding"); encoding != "identity" {
+			req.Compression = encoding
+		}
+	case "", "application/json":
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONRequestSize))
+		if err != nil {
+			g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("failed to read the request body: %v", err)))
+			return
+		}
+		if err := protojson.Unmarshal(body, req); err != nil {
+			g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("invalid DisperseBlobRequest: %v", err)))
+			return
+		}
+	default:
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("unsupported content type %q, must be application/json or application/octet-stream", mediaType)))
+		return
+	}
+
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = r.Header.Get("Idempotency-Key")
+	}
+
+	ctx, cancel := g.requestContext(r)
+	defer cancel()
+	reply, err := g.server.DisperseBlob(ctx, req)
+	g.writeReply(w, reply, err)
+}
+
+func (g *Gateway) disperseBlobs(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBlobsPerRequest*maxJSONRequestSize))
+	if err != nil {
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("failed to read the request body: %v", err)))
+		return
+	}
+	req := &pb.DisperseBlobsRequest{}
+	if err := protojson.Unmarshal(body, req); err != nil {
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("invalid DisperseBlobsRequest: %v", err)))
+		return
+	}
+
+	ctx, cancel := g.requestContext(r)
+	defer cancel()
+	reply, err := g.server.DisperseBlobs(ctx, req)
+	g.writeReply(w, reply, err)
+}
+
+func (g *Gateway) getBlobStatus(w http.ResponseWriter, r *http.Request, encodedRequestID string) {
+	requestID, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encodedRequestID, "="))
+	if err != nil {
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("request_id must be base64url encoded: %v", err)))
+		return
+	}
+
+	ctx, cancel := g.requestContext(r)
+	defer cancel()
+	reply, err := g.server.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: requestID})
+	g.writeReply(w, reply, err)
+}
+
+func (g *Gateway) getBlobStatuses(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSONRequestSize))
+	if err != nil {
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("failed to read the request body: %v", err)))
+		return
+	}
+	req := &pb.BlobStatusesRequest{}
+	if err := protojson.Unmarshal(body, req); err != nil {
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("invalid BlobStatusesRequest: %v", err)))
+		return
+	}
+
+	ctx, cancel := g.requestContext(r)
+	defer cancel()
+	reply, err := g.server.GetBlobStatuses(ctx, req)
+	g.writeReply(w, reply, err)
+}
+
+func (g *Gateway) retrieveBlob(w http.ResponseWriter, r *http.Request, encodedBatchHeaderHash, encodedBlobIndex string) {
+	batchHeaderHash, err := hex.DecodeString(strings.TrimPrefix(encodedBatchHeaderHash, "0x"))
+	if err != nil {
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("batch_header_hash must be hex encoded: %v", err)))
+		return
+	}
+	blobIndex, err := strconv.ParseUint(encodedBlobIndex, 10, 32)
+	if err != nil {
+		g.writeError(w, api.NewInvalidArgError(fmt.Sprintf("invalid blob_index: %v", err)))
+		return
+	}
+
+	ctx, cancel := g.requestContext(r)
+	defer cancel()
+	reply, err := g.server.RetrieveBlob(ctx, &pb.RetrieveBlobRequest{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       uint32(blobIndex),
+		Compression:     r.URL.Query().Get("compression"),
+	})
+	g.writeReply(w, reply, err)
+}
+
+// requestContext returns the context the request is handled with by the DispersalServer. The client address, the
+// client IP header, the correlation ID header and the API key header are carried the way gRPC carries them, so that
+// the requests are rate limited by origin and handled for their tenant.
+func (g *Gateway) requestContext(r *http.Request) (context.Context, context.CancelFunc) {
+	ctx := r.Context()
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	md := metadata.MD{}
+	if header := g.server.rateConfig.ClientIPHeader; header != "" {
+		if values := r.Header.Values(header); len(values) > 0 {
+			md.Set(header, values...)
+		}
+	}
+	if values := r.Header.Values(common.CorrelationIDHeader); len(values) > 0 {
+		md.Set(common.CorrelationIDHeader, values...)
+	}
+	if values := r.Header.Values(APIKeyHeader); len(values) > 0 {
+		md.Set(APIKeyHeader, values...)
+	}
+	if md.Len() > 0 {
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	timeout := g.server.serverConfig.GrpcTimeout
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (g *Gateway) writeReply(w http.ResponseWriter, reply proto.Message, err error) {
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+	body, err := gatewayMarshalOptions.Marshal(reply)
+	if err != nil {
+		g.writeError(w, api.NewInternalError(fmt.Sprintf("failed to encode the reply: %v", err)))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(body); err != nil {
+		g.logger.Debug("failed to write the reply", "err", err)
+	}
+}
+
+// writeError writes the gRPC status of the error with the matching HTTP status. Errors which aren't gRPC statuses
+// are internal errors.
+func (g *Gateway) writeError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.New(codes.Internal, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		st = status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			seconds := int64(retryInfo.GetRetryDelay().AsDuration().Round(time.Second) / time.Second)
+			w.Header().Set("Retry-After", strconv.FormatInt(max(seconds, 1), 10))
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(api.HTTPStatusFromCode(st.Code()))
+	if err := json.NewEncoder(w).Encode(gatewayError{Code: st.Code().String(), Message: st.Message()}); err != nil {
+		g.logger.Debug("failed to write the error", "err", err)
+	}
+}
+
+func (g *Gateway) writeMethodNotAllowed(w http.ResponseWriter, allowed string) {
+	w.Header().Set("Allow", allowed)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	if err := json.NewEncoder(w).Encode(gatewayError{Code: codes.Unimplemented.String(), Message: "method not allowed"}); err != nil {
+		g.logger.Debug("failed to write the error", "err", err)
+	}
+}
@@ -0,0 +1,116 @@
+package apiserver_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGateway(t *testing.T) {
+	gateway := httptest.NewServer(apiserver.NewGateway(dispersalServer, logging.NewNoopLogger()))
+	defer gateway.Close()
+
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	// Disperse with a JSON body
+	body, err := protojson.Marshal(&pb.DisperseBlobRequest{Data: data, CustomQuorumNumbers: []uint32{0, 1}})
+	require.NoError(t, err)
+	resp, err := http.Post(gateway.URL+"/v1/blobs", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	reply := &pb.DisperseBlobReply{}
+	readGatewayReply(t, resp, reply)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+	assert.NotEmpty(t, reply.GetRequestId())
+
+	// Get the status of the blob
+	resp, err = http.Get(gateway.URL + "/v1/blobs/" + base64.URLEncoding.EncodeToString(reply.GetRequestId()) + "/status")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	statusReply := &pb.BlobStatusReply{}
+	readGatewayReply(t, resp, statusReply)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, statusReply.GetStatus())
+
+	// Disperse the raw data with a chunked upload
+	reader, writer := io.Pipe()
+	go func() {
+		for i := 0; i < len(data); i += 256 {
+			_, _ = writer.Write(data[i:min(i+256, len(data))])
+		}
+		_ = writer.Close()
+	}()
+	resp, err = http.Post(gateway.URL+"/v1/blobs?custom_quorum_numbers=0,1", "application/octet-stream", reader)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	reply = &pb.DisperseBlobReply{}
+	readGatewayReply(t, resp, reply)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+}
+
+func TestGatewayErrors(t *testing.T) {
+	gateway := httptest.NewServer(apiserver.NewGateway(dispersalServer, logging.NewNoopLogger()))
+	defer gateway.Close()
+
+	for _, tc := range []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        []byte
+		status      int
+		code        string
+	}{
+		{"empty blob", http.MethodPost, "/v1/blobs", "application/json", []byte(`{"data": ""}`), http.StatusBadRequest, "InvalidArgument"},
+		{"invalid json", http.MethodPost, "/v1/blobs", "application/json", []byte(`{"data": 1}`), http.StatusBadRequest, "InvalidArgument"},
+		{"oversized upload", http.MethodPost, "/v1/blobs", "application/octet-stream", make([]byte, 2*1024*1024+1), http.StatusBadRequest, "InvalidArgument"},
+		{"unsupported content type", http.MethodPost, "/v1/blobs", "text/plain", []byte("data"), http.StatusBadRequest, "InvalidArgument"},
+		{"invalid request id", http.MethodGet, "/v1/blobs/!!/status", "", nil, http.StatusBadRequest, "InvalidArgument"},
+		{"invalid blob index", http.MethodGet, "/v1/batches/00/blobs/x", "", nil, http.StatusBadRequest, "InvalidArgument"},
+		{"unknown route", http.MethodGet, "/v1/operators", "", nil, http.StatusNotFound, "NotFound"},
+		{"wrong method", http.MethodGet, "/v1/blobs", "", nil, http.StatusMethodNotAllowed, "Unimplemented"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, gateway.URL+tc.path, bytes.NewReader(tc.body))
+			require.NoError(t, err)
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			var gatewayErr struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&gatewayErr))
+			assert.Equal(t, tc.code, gatewayErr.Code)
+			assert.NotEmpty(t, gatewayErr.Message)
+		})
+	}
+}
+
+func readGatewayReply(t *testing.T, resp *http.Response, reply proto.Message) {
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, protojson.Unmarshal(body, reply))
+}
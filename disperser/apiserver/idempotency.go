@@ -0,0 +1,113 @@
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	maxIdempotencyKeyLength  = 128
+	defaultIdempotencyKeyTTL = 24 * time.Hour
+	// idempotencyReservationTTL is how long the idempotency key of a request in progress is reserved, so that the key
+	// of a request which never completes isn't held for the whole TTL
+	idempotencyReservationTTL = time.Minute
+	// idempotencyRetryAfter is the delay after which the replay of a request still in progress should be retried
+	idempotencyRetryAfter = time.Second
+)
+
+// validateIdempotencyKey checks the idempotency key is made of at most maxIdempotencyKeyLength printable ASCII
+// characters
+func validateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency_key cannot exceed %d characters", maxIdempotencyKeyLength)
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return fmt.Errorf("idempotency_key must be printable ASCII, got byte 0x%02x at position %d", key[i], i)
+		}
+	}
+	return nil
+}
+
+// scopeIdempotencyKey returns the key under which the idempotency key of a request is stored. Idempotency keys are
+// only unique per client, which is the authenticated account of the request, else the account it claims, else its
+// origin, within the tenant of the request.
+func scopeIdempotencyKey(key string, origin string, authenticatedAddress string, blob *core.Blob) string {
+	var scoped string
+	switch {
+	case authenticatedAddress != "":
+		scoped = fmt.Sprintf("account:%s/%s", authenticatedAddress, key)
+	case blob.RequestHeader.AccountID != "":
+		scoped = fmt.Sprintf("account:%s/%s", blob.RequestHeader.AccountID, key)
+	default:
+		scoped = fmt.Sprintf("origin:%s/%s", origin, key)
+	}
+	if blob.RequestHeader.TenantID != "" {
+		scoped = fmt.Sprintf("tenant:%s/%s", blob.RequestHeader.TenantID, scoped)
+	}
+	return scoped
+}
+
+// reserveIdempotencyKey reserves the scoped idempotency key for the blob. If the key was reserved by an earlier
+// request for the same data, it returns the reply to that request with the current status of its blob, which the
+// request replays instead of dispersing the blob again.
+func (s *DispersalServer) reserveIdempotencyKey(ctx context.Context, key string, blob *core.Blob) (*pb.DisperseBlobReply, error) {
+	payloadHash := disperser.ComputePayloadHash(blob.Data)
+	expiry := time.Now().Add(idempotencyReservationTTL + s.serverConfig.GrpcTimeout)
+	record, err := s.blobStore.ReserveIdempotencyKey(ctx, key, payloadHash, uint64(expiry.Unix()))
+	if err != nil {
+		s.logger.Error("failed to reserve the idempotency key", "err", err)
+		return nil, api.NewInternalError("failed to check the idempotency_key, please try again later")
+	}
+	if record == nil {
+		return nil, nil
+	}
+
+	if !bytes.Equal(record.PayloadHash, payloadHash) {
+		return nil, api.NewInvalidArgError("idempotency_key was already used for different data")
+	}
+	if record.BlobKey == nil {
+		return nil, api.NewUnavailableError("a request with the same idempotency_key is in progress", idempotencyRetryAfter)
+	}
+
+	metadata, err := s.blobStore.GetBlobMetadata(ctx, *record.BlobKey)
+	if err != nil {
+		s.logger.Error("failed to get the blob metadata of the idempotency key", "blobKey", record.BlobKey.String(), "err", err)
+		return nil, api.NewInternalError("failed to check the idempotency_key, please try again later")
+	}
+	return &pb.DisperseBlobReply{
+		Result:    disperser.ToBlobStatusProto(metadata.BlobStatus),
+		RequestId: []byte(record.BlobKey.String()),
+	}, nil
+}
+
+// setIdempotencyKeyBlob binds the scoped idempotency key to the stored blob for the idempotency window. If it fails,
+// the key is released, so that a retry disperses the blob again rather than waiting for a blob which is never bound.
+func (s *DispersalServer) setIdempotencyKeyBlob(ctx context.Context, key string, blobKey disperser.BlobKey) {
+	ttl := s.serverConfig.IdempotencyKeyTTL
+	if ttl <= 0 {
+		ttl = defaultIdempotencyKeyTTL
+	}
+
+	err := s.blobStore.SetIdempotencyKeyBlob(context.WithoutCancel(ctx), key, blobKey, uint64(time.Now().Add(ttl).Unix()))
+	if err != nil {
+		s.logger.Error("failed to bind the idempotency key to the blob", "blobKey", blobKey.String(), "err", err)
+		s.releaseIdempotencyKey(ctx, key)
+	}
+}
+
+// releaseIdempotencyKey releases the scoped idempotency key of a request whose blob wasn't stored, so that the
+// request can be retried
+func (s *DispersalServer) releaseIdempotencyKey(ctx context.Context, key string) {
+	// The key is released even if the request was canceled, as it'd otherwise be held until the reservation expires
+	if err := s.blobStore.ReleaseIdempotencyKey(context.WithoutCancel(ctx), key); err != nil {
+		s.logger.Error("failed to release the idempotency key", "err", err)
+	}
+}
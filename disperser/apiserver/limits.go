@@ -0,0 +1,246 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const defaultLimitsRefreshInterval = time.Minute
+
+// QuorumLimits are the limits on the blobs dispersed to a quorum which can be changed while the server is running.
+// A zero limit leaves the corresponding limit of the server config in place.
+type QuorumLimits struct {
+	// MaxBlobSize is the size in bytes of the largest blob accepted for the quorum. It can only lower the size limit
+	// of all the blobs.
+	MaxBlobSize uint `json:"maxBlobSize"`
+	// TotalThroughput replaces the byte rate of the unauthenticated blobs of all the accounts on the quorum
+	TotalThroughput common.RateParam `json:"totalThroughput"`
+	// PerUserThroughput replaces the byte rate of the unauthenticated blobs of each account on the quorum
+	PerUserThroughput common.RateParam `json:"perUserThroughput"`
+}
+
+// LimitsSource provides the limits of the quorums, e.g. from an on-chain config
+type LimitsSource interface {
+	GetQuorumLimits(ctx context.Context) (map[core.QuorumID]QuorumLimits, error)
+}
+
+// FileLimitsSource reads the limits of the quorums from a JSON file mapping the quorum IDs to their limits, e.g.
+// {"0": {"maxBlobSize": 1048576, "totalThroughput": 4194304}}
+type FileLimitsSource struct {
+	Path string
+}
+
+var _ LimitsSource = (*FileLimitsSource)(nil)
+
+func (s *FileLimitsSource) GetQuorumLimits(ctx context.Context) (map[core.QuorumID]QuorumLimits, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the limits file: %w", err)
+	}
+	limits := make(map[core.QuorumID]QuorumLimits)
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return nil, fmt.Errorf("failed to parse the limits file: %w", err)
+	}
+	return limits, nil
+}
+
+// DynamicLimits holds the limits of the quorums, so that they take effect without restarting the server. The limits
+// are refreshed from a LimitsSource, if any, and can be overridden with the admin API. The overrides take precedence
+// over the limits from the source.
+type DynamicLimits struct {
+	source          LimitsSource
+	refreshInterval time.Duration
+	logger          logging.Logger
+
+	mu         sync.RWMutex
+	fromSource map[core.QuorumID]QuorumLimits
+	overrides  map[core.QuorumID]QuorumLimits
+}
+
+// NewDynamicLimits creates the limits refreshed from the source every refreshInterval. The source may be nil, in
+// which case the limits are only set with the admin API.
+func NewDynamicLimits(source LimitsSource, refreshInterval time.Duration, logger logging.Logger) *DynamicLimits {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultLimitsRefreshInterval
+	}
+	return &DynamicLimits{
+		source:          source,
+		refreshInterval: refreshInterval,
+		logger:          logger.With("component", "DynamicLimits"),
+		fromSource:      make(map[core.QuorumID]QuorumLimits),
+		overrides:       make(map[core.QuorumID]QuorumLimits),
+	}
+}
+
+// Start refreshes the limits from the source, and then keeps refreshing them every refresh interval until the context
+// is done. It fails if the first refresh fails, while the later failures keep the previous limits in place.
+func (l *DynamicLimits) Start(ctx context.Context) error {
+	if l.source == nil {
+		return nil
+	}
+	if err := l.Refresh(ctx); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(l.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := l.Refresh(ctx); err != nil {
+					l.logger.Warn("failed to refresh the quorum limits, keeping the previous limits", "err", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Refresh replaces the limits from the source with the current limits of the source
+func (l *DynamicLimits) Refresh(ctx context.Context) error {
+	if l.source == nil {
+		return nil
+	}
+	limits, err := l.source.GetQuorumLimits(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validateQuorumLimits(limits); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.fromSource = limits
+	l.mu.Unlock()
+	return nil
+}
+
+// SetOverrides replaces the limits overriding the limits from the source
+func (l *DynamicLimits) SetOverrides(overrides map[core.QuorumID]QuorumLimits) error {
+	if err := validateQuorumLimits(overrides); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.overrides = overrides
+	l.mu.Unlock()
+	l.logger.Info("set the quorum limit overrides", "overrides", overrides)
+	return nil
+}
+
+// Get returns the limits of the quorum, each limit set in the overrides taking precedence over the one from the source
+func (l *DynamicLimits) Get(quorumID core.QuorumID) QuorumLimits {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	limits := l.fromSource[quorumID]
+	override := l.overrides[quorumID]
+	if override.MaxBlobSize > 0 {
+		limits.MaxBlobSize = override.MaxBlobSize
+	}
+	if override.TotalThroughput > 0 {
+		limits.TotalThroughput = override.TotalThroughput
+	}
+	if override.PerUserThroughput > 0 {
+		limits.PerUserThroughput = override.PerUserThroughput
+	}
+	return limits
+}
+
+// All returns the limits of all the quorums which have limits set
+func (l *DynamicLimits) All() map[core.QuorumID]QuorumLimits {
+	l.mu.RLock()
+	quorumIDs := make([]core.QuorumID, 0, len(l.fromSource)+len(l.overrides))
+	for quorumID := range l.fromSource {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	for quorumID := range l.overrides {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	l.mu.RUnlock()
+
+	all := make(map[core.QuorumID]QuorumLimits, len(quorumIDs))
+	for _, quorumID := range quorumIDs {
+		all[quorumID] = l.Get(quorumID)
+	}
+	return all
+}
+
+func validateQuorumLimits(limits map[core.QuorumID]QuorumLimits) error {
+	for quorumID, quorumLimits := range limits {
+		if quorumID > core.MaxQuorumID {
+			return fmt.Errorf("quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, quorumID)
+		}
+		if quorumLimits.MaxBlobSize > maxBlobSize {
+			return fmt.Errorf("max blob size of quorum %d cannot exceed %d bytes, but found %d", quorumID, maxBlobSize, quorumLimits.MaxBlobSize)
+		}
+	}
+	return nil
+}
+
+// quorumRateInfo returns the rates of the quorum from the rate config, with the throughputs replaced by the ones set
+// in the limits of the quorum
+func (s *DispersalServer) quorumRateInfo(quorumID core.QuorumID) (QuorumRateInfo, bool) {
+	rates, ok := s.rateConfig.QuorumRateInfos[quorumID]
+	if !ok {
+		return QuorumRateInfo{}, false
+	}
+	limits := s.limits.Get(quorumID)
+	if limits.TotalThroughput > 0 {
+		rates.TotalUnauthThroughput = limits.TotalThroughput
+	}
+	if limits.PerUserThroughput > 0 {
+		rates.PerUserUnauthThroughput = limits.PerUserThroughput
+	}
+	return rates, true
+}
+
+// checkBlobSizeLimits checks the size of the blob against the max blob size of each of its quorums
+func (s *DispersalServer) checkBlobSizeLimits(blobSize int, params []*core.SecurityParam) error {
+	for _, param := range params {
+		limit := s.limits.Get(param.QuorumID).MaxBlobSize
+		if limit > 0 && uint(blobSize) > limit {
+			return fmt.Errorf("blob size cannot exceed %d bytes for quorum %d", limit, param.QuorumID)
+		}
+	}
+	return nil
+}
+
+// handleLimits serves the limits of the quorums on the admin endpoints. GET /limits returns the limits in effect, and
+// PUT /limits replaces the overrides of the limits from the limits source with the limits in the body.
+func (s *DispersalServer) handleLimits(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		overrides := make(map[core.QuorumID]QuorumLimits)
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024*1024)).Decode(&overrides); err != nil {
+			http.Error(w, fmt.Sprintf("failed to parse the limits: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := s.limits.SetOverrides(overrides); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.limits.All()); err != nil {
+		s.logger.Debug("failed to write the reply", "err", err)
+	}
+}
@@ -0,0 +1,65 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NonceStore remembers the nonces accepted from each account until they expire. The dispersers behind a load
+// balancer share it, so that a signed request or an answered challenge accepted by one of them can't be replayed
+// against another.
+type NonceStore interface {
+	// Add records the nonce of the account until expiry. It returns false if the nonce is already recorded and hasn't
+	// expired.
+	Add(ctx context.Context, account string, nonce uint64, expiry time.Time, now time.Time) (bool, error)
+	// Contains returns whether the nonce of the account is recorded and hasn't expired.
+	Contains(ctx context.Context, account string, nonce uint64, now time.Time) (bool, error)
+}
+
+// Add implements NonceStore for the nonces only remembered by this server
+func (a *acceptedNonces) Add(_ context.Context, account string, nonce uint64, expiry time.Time, now time.Time) (bool, error) {
+	return a.add(account, nonce, expiry, now), nil
+}
+
+// Contains implements NonceStore for the nonces only remembered by this server
+func (a *acceptedNonces) Contains(_ context.Context, account string, nonce uint64, now time.Time) (bool, error) {
+	return a.contains(account, nonce, now), nil
+}
+
+// redisNonceStore keeps a redis key per accepted nonce, which expires with the nonce
+type redisNonceStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisNonceStore returns a NonceStore shared through redis, whose keys start with keyPrefix
+func NewRedisNonceStore(client redis.UniversalClient, keyPrefix string) NonceStore {
+	return &redisNonceStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+func (r *redisNonceStore) key(account string, nonce uint64) string {
+	return fmt.Sprintf("%s%s:%d", r.keyPrefix, account, nonce)
+}
+
+func (r *redisNonceStore) Add(ctx context.Context, account string, nonce uint64, expiry time.Time, now time.Time) (bool, error) {
+	ttl := expiry.Sub(now)
+	if ttl <= 0 {
+		// The nonce already expired, there is nothing to remember
+		return true, nil
+	}
+	return r.client.SetNX(ctx, r.key(account, nonce), 1, ttl).Result()
+}
+
+func (r *redisNonceStore) Contains(ctx context.Context, account string, nonce uint64, _ time.Time) (bool, error) {
+	n, err := r.client.Exists(ctx, r.key(account, nonce)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -1,12 +1,15 @@
 package apiserver
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
+	"io"
 	"net"
-	"slices"
+	"net/http"
+	"net/url"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -14,26 +17,52 @@ import (
 	"github.com/Layr-Labs/eigenda/api"
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	healthcheck "github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/committer"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 const systemAccountKey = "system"
 
 const maxBlobSize = 2 * 1024 * 1024 // 2 MiB
 
+// maxBlobsPerRequest is the largest number of blobs dispersed in a single DisperseBlobs request
+const maxBlobsPerRequest = 16
+
+const (
+	maxCallbackURLLength  = 2048
+	maxCallbackSecretSize = 256
+)
+
+// ReasonCommitmentMismatch is the reason of the errors returned when the commitment supplied with a blob doesn't
+// match the commitment the disperser computes from its data
+const ReasonCommitmentMismatch = "COMMITMENT_MISMATCH"
+
+var errCommitmentMismatch = errors.New("commitment does not match the commitment of the data")
+
+const (
+	defaultStatusPollInterval      = time.Second
+	defaultStatusKeepaliveInterval = 30 * time.Second
+)
+
 type DispersalServer struct {
 	pb.UnimplementedDisperserServer
 	mu *sync.RWMutex
@@ -46,10 +75,23 @@ type DispersalServer struct {
 	quorumConfig QuorumConfig
 
 	ratelimiter   common.RateLimiter
+	limits        *DynamicLimits
+	tenants       *Tenants
+	committer     *committer.Committer
 	authenticator core.BlobRequestAuthenticator
+	nonces        NonceStore
+	admission     *admissionController
+	statusCache   *blobStatusCache
 
 	metrics *disperser.Metrics
 
+	// draining is set once the server starts draining, after which new blobs are rejected. dispersals tracks the
+	// dispersal requests in progress, and drained is closed once the drain completes.
+	drainMu    sync.Mutex
+	draining   bool
+	dispersals sync.WaitGroup
+	drained    chan struct{}
+
 	logger logging.Logger
 }
 
@@ -60,6 +102,11 @@ type QuorumConfig struct {
 }
 
 // NewServer creates a new Server struct with the provided parameters.
+// If limits is nil, the limits of the quorums are only set with the admin API. If tenants is nil, the tenants are only
+// set with the admin API, and all the requests belong to the default tenant until then. If committer is nil, the
+// commitments supplied with the blobs can't be cross-checked and the requests carrying one are rejected, and the blobs
+// can't be retrieved with proofs. If nonces is nil, the nonces of the signed requests and the answered challenges are
+// only remembered by this server, so they can be replayed against the other dispersers behind the same load balancer.
 //
 // Note: The Server's chunks store will be created at config.DbPath+"/chunk".
 func NewDispersalServer(
@@ -70,8 +117,18 @@ func NewDispersalServer(
 	metrics *disperser.Metrics,
 	ratelimiter common.RateLimiter,
 	rateConfig RateConfig,
+	limits *DynamicLimits,
+	tenants *Tenants,
+	committer *committer.Committer,
+	nonces NonceStore,
 ) *DispersalServer {
 	logger := _logger.With("component", "DispersalServer")
+	if limits == nil {
+		limits = NewDynamicLimits(nil, 0, _logger)
+	}
+	if tenants == nil {
+		tenants, _ = NewTenants(nil, _logger)
+	}
 	for account, rateInfoByQuorum := range rateConfig.Allowlist {
 		for quorumID, rateInfo := range rateInfoByQuorum {
 			logger.Info("[Allowlist]", "account", account, "name", rateInfo.Name, "quorumID", quorumID, "throughput", rateInfo.Throughput, "blobRate", rateInfo.BlobRate)
@@ -79,6 +136,18 @@ func NewDispersalServer(
 	}
 
 	authenticator := auth.NewAuthenticator(auth.AuthConfig{})
+	if serverConfig.ReplayWindow <= 0 {
+		serverConfig.ReplayWindow = defaultReplayWindow
+	}
+	if nonces == nil {
+		nonces = newAcceptedNonces(serverConfig.ReplayWindow)
+	}
+	if serverConfig.BlobStatusCacheSize == 0 {
+		serverConfig.BlobStatusCacheSize = defaultBlobStatusCacheSize
+	}
+	if serverConfig.BlobStatusCacheTTL <= 0 {
+		serverConfig.BlobStatusCacheTTL = defaultBlobStatusCacheTTL
+	}
 
 	return &DispersalServer{
 		serverConfig:  serverConfig,
@@ -88,9 +157,16 @@ func NewDispersalServer(
 		metrics:       metrics,
 		logger:        logger,
 		ratelimiter:   ratelimiter,
+		limits:        limits,
+		tenants:       tenants,
+		committer:     committer,
+		admission:     newAdmissionController(serverConfig.Admission, store, _logger),
+		statusCache:   newBlobStatusCache(serverConfig.BlobStatusCacheSize, serverConfig.BlobStatusCacheTTL),
 		authenticator: authenticator,
+		nonces:        nonces,
 		mu:            &sync.RWMutex{},
 		quorumConfig:  QuorumConfig{},
+		drained:       make(chan struct{}),
 	}
 }
 
@@ -121,7 +197,7 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(request.DisperseRequest.GetData()), "DisperseBlobAuthenticated")
 		}
 		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobAuthenticated")
-		return api.NewInvalidArgError(err.Error())
+		return invalidBlobError(err)
 	}
 
 	// Get the ethereum address associated with the public key. This is just for convenience so we can put addresses instead of public keys in the allowlist.
@@ -143,7 +219,12 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 	authenticatedAddress := crypto.PubkeyToAddress(*pubKey).String()
 
 	// Send back challenge to client
-	challenge := rand.Uint32()
+	challenge, err := s.newAuthChallenge(ctx, authenticatedAddress)
+	if err != nil {
+		s.metrics.HandleInternalFailureRpcRequest("DisperseBlobAuthenticated")
+		return api.NewInternalError(fmt.Sprintf("failed to generate a challenge: %v", err))
+	}
+	challengeIssuedAt := time.Now()
 	err = stream.Send(&pb.AuthenticatedReply{Payload: &pb.AuthenticatedReply_BlobAuthHeader{
 		BlobAuthHeader: &pb.BlobAuthHeader{
 			ChallengeParameter: challenge,
@@ -196,9 +277,18 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 		s.metrics.HandleInvalidArgRequest("DisperseBlobAuthenticated")
 		return api.NewInvalidArgError(fmt.Sprintf("failed to authenticate blob request: %v", err))
 	}
+	if err := s.acceptAuthChallenge(ctx, authenticatedAddress, challenge, challengeIssuedAt, "DisperseBlobAuthenticated"); err != nil {
+		if errors.Is(err, errNonceStore) {
+			s.metrics.HandleInternalFailureRpcRequest("DisperseBlobAuthenticated")
+			return api.NewInternalError(err.Error())
+		}
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobAuthenticated")
+		s.metrics.HandleInvalidArgRequest("DisperseBlobAuthenticated")
+		return api.NewInvalidArgError(fmt.Sprintf("failed to authenticate blob request: %v", err))
+	}
 
 	// Disperse the blob
-	reply, err := s.disperseBlob(ctx, blob, authenticatedAddress, "DisperseBlobAuthenticated")
+	reply, err := s.disperseBlob(ctx, blob, authenticatedAddress, request.DisperseRequest.GetIdempotencyKey(), "DisperseBlobAuthenticated")
 	if err != nil {
 		// Note the disperseBlob already updated metrics for this error.
 		s.logger.Info("failed to disperse blob", "err", err)
@@ -227,10 +317,27 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(req.GetData()), "DisperseBlob")
 		}
 		s.metrics.HandleInvalidArgRpcRequest("DisperseBlob")
-		return nil, api.NewInvalidArgError(err.Error())
+		return nil, invalidBlobError(err)
+	}
+
+	authenticatedAddress := ""
+	if len(req.GetSignature()) > 0 {
+		authenticatedAddress, err = s.authenticateSignedRequest(ctx, req, "DisperseBlob")
+		if errors.Is(err, errNonceStore) {
+			s.metrics.HandleInternalFailureRpcRequest("DisperseBlob")
+			return nil, api.NewInternalError(err.Error())
+		}
+		if err != nil {
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlob")
+			s.metrics.HandleInvalidArgRequest("DisperseBlob")
+			return nil, api.NewInvalidArgError(err.Error())
+		}
+	}
+	if err := s.requireAuthentication(authenticatedAddress, "DisperseBlob"); err != nil {
+		return nil, err
 	}
 
-	reply, err := s.disperseBlob(ctx, blob, "", "DisperseBlob")
+	reply, err := s.disperseBlob(ctx, blob, authenticatedAddress, req.GetIdempotencyKey(), "DisperseBlob")
 	if err != nil {
 		// Note the disperseBlob already updated metrics for this error.
 		s.logger.Info("failed to disperse blob", "err", err)
@@ -240,9 +347,199 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 	return reply, err
 }
 
+// streamingDisabledError is returned by the streaming RPCs if the server config disables them
+func streamingDisabledError() error {
+	return api.NewGRPCError(codes.Unimplemented, "streaming is disabled on this disperser")
+}
+
+// DisperseBlobStream assembles the data chunks of the stream in memory before dispersing the blob. The stream is
+// rejected as soon as the data exceeds maxBlobSize, so a request never holds more than maxBlobSize bytes.
+func (s *DispersalServer) DisperseBlobStream(stream pb.Disperser_DisperseBlobStreamServer) error {
+	if s.serverConfig.DisableStreaming {
+		return streamingDisabledError()
+	}
+
+	// This uses the existing deadline of stream.Context() if it is earlier.
+	ctx, cancel := context.WithTimeout(stream.Context(), s.serverConfig.GrpcTimeout)
+	defer cancel()
+
+	var data []byte
+	var header *pb.DisperseBlobRequest
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+			s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+			return api.NewInvalidArgError(fmt.Sprintf("error receiving next message: %v", err))
+		}
+		if header != nil {
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+			s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+			return api.NewInvalidArgError("received a message after the DisperseBlobRequest")
+		}
+
+		switch payload := in.GetPayload().(type) {
+		case *pb.DisperseBlobStreamRequest_DataChunk:
+			if len(data)+len(payload.DataChunk) > maxBlobSize {
+				s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+				s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+				return api.NewInvalidArgError(fmt.Sprintf("blob size cannot exceed %v bytes", maxBlobSize))
+			}
+			data = append(data, payload.DataChunk...)
+		case *pb.DisperseBlobStreamRequest_Header:
+			if payload.Header == nil || len(payload.Header.GetData()) > 0 {
+				s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+				s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+				return api.NewInvalidArgError("the DisperseBlobRequest must be set and carry no data")
+			}
+			header = payload.Header
+		default:
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+			s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+			return api.NewInvalidArgError("missing payload")
+		}
+	}
+	if header == nil {
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+		s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+		return api.NewInvalidArgError("missing DisperseBlobRequest")
+	}
+	header.Data = data
+
+	blob, err := s.validateRequestAndGetBlob(ctx, header)
+	if err != nil {
+		for _, quorumID := range header.CustomQuorumNumbers {
+			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(data), "DisperseBlobStream")
+		}
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+		return invalidBlobError(err)
+	}
+
+	authenticatedAddress := ""
+	if len(header.GetSignature()) > 0 {
+		authenticatedAddress, err = s.authenticateSignedRequest(ctx, header, "DisperseBlobStream")
+		if errors.Is(err, errNonceStore) {
+			s.metrics.HandleInternalFailureRpcRequest("DisperseBlobStream")
+			return api.NewInternalError(err.Error())
+		}
+		if err != nil {
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+			s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+			return api.NewInvalidArgError(err.Error())
+		}
+	}
+	if err := s.requireAuthentication(authenticatedAddress, "DisperseBlobStream"); err != nil {
+		return err
+	}
+
+	reply, err := s.disperseBlob(ctx, blob, authenticatedAddress, header.GetIdempotencyKey(), "DisperseBlobStream")
+	if err != nil {
+		// Note the disperseBlob already updated metrics for this error.
+		s.logger.Info("failed to disperse blob", "err", err)
+		return err
+	}
+
+	if err := stream.SendAndClose(reply); err != nil {
+		s.logger.Error("failed to send back DisperseReply", "err", err)
+		return err
+	}
+
+	s.metrics.HandleSuccessfulRpcRequest("DisperseBlobStream")
+
+	return nil
+}
+
+// DisperseBlobs validates all the blobs before storing any of them, and marks the blobs already stored as failed if
+// one of the others isn't accepted, so that either all the blobs are accepted or none of them is. The blobs share a
+// group ID, which the batcher uses to put them in the same batch.
+func (s *DispersalServer) DisperseBlobs(ctx context.Context, req *pb.DisperseBlobsRequest) (*pb.DisperseBlobsReply, error) {
+	if len(req.GetBlobs()) == 0 || len(req.GetBlobs()) > maxBlobsPerRequest {
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobs")
+		s.metrics.HandleInvalidArgRequest("DisperseBlobs")
+		return nil, api.NewInvalidArgError(fmt.Sprintf("number of blobs must be in range [1, %d], but found %d", maxBlobsPerRequest, len(req.GetBlobs())))
+	}
+
+	groupID := common.NewCorrelationID()
+	blobs := make([]*core.Blob, len(req.GetBlobs()))
+	authenticatedAddresses := make([]string, len(req.GetBlobs()))
+	for i, blobReq := range req.GetBlobs() {
+		// A replayed reply can't be withdrawn if another blob isn't accepted
+		if blobReq.GetIdempotencyKey() != "" {
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobs")
+			s.metrics.HandleInvalidArgRequest("DisperseBlobs")
+			return nil, api.NewInvalidArgError(fmt.Sprintf("blob %d: idempotency_key is not supported by DisperseBlobs", i))
+		}
+
+		blob, err := s.validateRequestAndGetBlob(ctx, blobReq)
+		if err != nil {
+			for _, quorumID := range blobReq.CustomQuorumNumbers {
+				s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(blobReq.GetData()), "DisperseBlobs")
+			}
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobs")
+			return nil, invalidBlobError(fmt.Errorf("blob %d: %w", i, err))
+		}
+		blob.RequestHeader.GroupID = groupID
+		blobs[i] = blob
+
+		if len(blobReq.GetSignature()) > 0 {
+			authenticatedAddresses[i], err = s.authenticateSignedRequest(ctx, blobReq, "DisperseBlobs")
+			if errors.Is(err, errNonceStore) {
+				s.metrics.HandleInternalFailureRpcRequest("DisperseBlobs")
+				return nil, api.NewInternalError(err.Error())
+			}
+			if err != nil {
+				s.metrics.HandleInvalidArgRpcRequest("DisperseBlobs")
+				s.metrics.HandleInvalidArgRequest("DisperseBlobs")
+				return nil, api.NewInvalidArgError(fmt.Sprintf("blob %d: %v", i, err))
+			}
+		}
+		if err := s.requireAuthentication(authenticatedAddresses[i], "DisperseBlobs"); err != nil {
+			return nil, err
+		}
+	}
+
+	replies := make([]*pb.DisperseBlobReply, 0, len(blobs))
+	for i, blob := range blobs {
+		reply, err := s.disperseBlob(ctx, blob, authenticatedAddresses[i], "", "DisperseBlobs")
+		if err != nil {
+			// Note the disperseBlob already updated metrics for this error.
+			s.logger.Info("failed to disperse blob, withdrawing the other blobs of the request", "err", err, "groupID", groupID)
+			s.withdrawBlobs(replies)
+			return nil, err
+		}
+		replies = append(replies, reply)
+	}
+
+	s.metrics.HandleSuccessfulRpcRequest("DisperseBlobs")
+	return &pb.DisperseBlobsReply{Replies: replies}, nil
+}
+
+// withdrawBlobs marks the stored blobs of a DisperseBlobs request as failed, so they're not dispersed
+func (s *DispersalServer) withdrawBlobs(replies []*pb.DisperseBlobReply) {
+	// The blobs are withdrawn even if the request is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), s.serverConfig.GrpcTimeout)
+	defer cancel()
+	for _, reply := range replies {
+		blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+		if err != nil {
+			s.logger.Error("failed to parse the key of the blob to withdraw", "err", err)
+			continue
+		}
+		if err := s.blobStore.MarkBlobFailed(ctx, blobKey); err != nil {
+			s.logger.Error("failed to withdraw blob", "err", err, "blobKey", blobKey.String())
+		}
+	}
+}
+
 // Note: disperseBlob will internally update metrics upon an error; the caller doesn't need
 // to track the error again.
-func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, authenticatedAddress string, apiMethodName string) (*pb.DisperseBlobReply, error) {
+// disperseBlob stores the blob to be dispersed. If an idempotency key is given, a request with the same key for the
+// same client within the idempotency window replays the reply to the first request instead, without being rate
+// limited again. The blob belongs to the tenant of the API key of the request, see tenantFromContext.
+func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, authenticatedAddress string, idempotencyKey string, apiMethodName string) (_ *pb.DisperseBlobReply, err error) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
 		s.metrics.ObserveLatency("DisperseBlob", f*1000) // make milliseconds
 	}))
@@ -256,6 +553,36 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 
 	blobSize := len(blob.Data)
 
+	if !s.startDispersal() {
+		for _, param := range securityParams {
+			quorumId := string(param.QuorumID)
+			s.metrics.HandleFailedRequest(codes.Unavailable.String(), quorumId, blobSize, apiMethodName)
+		}
+		return nil, drainRejectedError()
+	}
+	defer s.endDispersal()
+
+	tenant, err := s.tenantFromContext(ctx)
+	if err != nil {
+		for _, param := range securityParams {
+			quorumId := string(param.QuorumID)
+			s.metrics.HandleFailedRequest(codes.Unauthenticated.String(), quorumId, blobSize, apiMethodName)
+		}
+		return nil, err
+	}
+	blob.RequestHeader.TenantID = tenantIDOf(tenant)
+	defer func() {
+		s.metrics.HandleTenantRequest(tenantLabel(blob.RequestHeader.TenantID), status.Code(err).String(), blobSize, apiMethodName)
+	}()
+	if tenant != nil && tenant.MaxBlobSize > 0 && uint(blobSize) > tenant.MaxBlobSize {
+		for _, param := range securityParams {
+			quorumId := string(param.QuorumID)
+			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), quorumId, blobSize, apiMethodName)
+		}
+		s.metrics.HandleInvalidArgRpcRequest(apiMethodName)
+		return nil, api.NewInvalidArgError(fmt.Sprintf("blob size cannot exceed %d bytes for tenant %s", tenant.MaxBlobSize, tenant.ID))
+	}
+
 	origin, err := common.GetClientAddress(ctx, s.rateConfig.ClientIPHeader, 2, true)
 	if err != nil {
 		for _, param := range securityParams {
@@ -266,11 +593,59 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 		return nil, api.NewInvalidArgError(err.Error())
 	}
 
-	s.logger.Debug("received a new blob dispersal request", "origin", origin, "securityParams", strings.Join(securityParamsStrings, ", "))
+	// The correlation ID joins the log lines of the services handling the blob. Clients can set their own in the
+	// request metadata to join them with their logs too
+	correlationID := common.CorrelationIDFromContext(ctx)
+	if correlationID == "" {
+		correlationID = common.NewCorrelationID()
+	}
+	blob.RequestHeader.CorrelationID = correlationID
+	_ = grpc.SetHeader(ctx, metadata.Pairs(common.CorrelationIDHeader, correlationID))
+
+	s.logger.Debug("received a new blob dispersal request", "origin", origin, "securityParams", strings.Join(securityParamsStrings, ", "), "correlationID", correlationID)
+
+	if idempotencyKey != "" {
+		idempotencyKey = scopeIdempotencyKey(idempotencyKey, origin, authenticatedAddress, blob)
+		reply, err := s.reserveIdempotencyKey(ctx, idempotencyKey, blob)
+		if err != nil {
+			code := status.Code(err)
+			for _, param := range securityParams {
+				quorumId := string(param.QuorumID)
+				s.metrics.HandleFailedRequest(code.String(), quorumId, blobSize, apiMethodName)
+			}
+			if code == codes.InvalidArgument {
+				s.metrics.HandleInvalidArgRpcRequest(apiMethodName)
+			} else {
+				s.metrics.HandleInternalFailureRpcRequest(apiMethodName)
+			}
+			return nil, err
+		}
+		if reply != nil {
+			s.logger.Debug("replaying the reply to an earlier request with the same idempotency key", "origin", origin, "requestID", string(reply.GetRequestId()))
+			return reply, nil
+		}
+	}
+
+	// Blobs which would time out downstream are rejected before they count against the rate limits
+	if err := s.admission.Admit(ctx); err != nil {
+		if idempotencyKey != "" {
+			s.releaseIdempotencyKey(ctx, idempotencyKey)
+		}
+		for _, param := range securityParams {
+			quorumId := string(param.QuorumID)
+			s.metrics.HandleFailedRequest(codes.ResourceExhausted.String(), quorumId, blobSize, apiMethodName)
+		}
+		s.metrics.HandleRateLimitedRpcRequest(apiMethodName)
+		s.logger.Debug("rejected a blob as the dispersal pipeline is saturated", "origin", origin, "correlationID", correlationID)
+		return nil, err
+	}
 
 	if s.ratelimiter != nil {
 		err := s.checkRateLimitsAndAddRatesToHeader(ctx, blob, origin, authenticatedAddress, apiMethodName)
 		if err != nil {
+			if idempotencyKey != "" {
+				s.releaseIdempotencyKey(ctx, idempotencyKey)
+			}
 			// Note checkRateLimitsAndAddRatesToHeader already updated the metrics for this error.
 			return nil, err
 		}
@@ -278,20 +653,28 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 
 	requestedAt := uint64(time.Now().UnixNano())
 	metadataKey, err := s.blobStore.StoreBlob(ctx, blob, requestedAt)
+	s.admission.ObserveStoreLatency(time.Since(time.Unix(0, int64(requestedAt))))
 	if err != nil {
+		if idempotencyKey != "" {
+			s.releaseIdempotencyKey(ctx, idempotencyKey)
+		}
 		for _, param := range securityParams {
 			quorumId := string(param.QuorumID)
 			s.metrics.HandleBlobStoreFailedRequest(quorumId, blobSize, apiMethodName)
 		}
 		s.metrics.HandleStoreFailureRpcRequest(apiMethodName)
-		s.logger.Error("failed to store blob", "err", err)
+		s.logger.Error("failed to store blob", "err", err, "correlationID", correlationID)
 		return nil, api.NewInternalError("failed to store blob, please try again later")
 	}
+	if idempotencyKey != "" {
+		s.setIdempotencyKeyBlob(ctx, idempotencyKey, metadataKey)
+	}
 
 	for _, param := range securityParams {
 		quorumId := string(param.QuorumID)
 		s.metrics.HandleSuccessfulRequest(quorumId, blobSize, apiMethodName)
 	}
+	s.logger.Debug("stored a new blob", "requestID", metadataKey.String(), "correlationID", correlationID)
 
 	return &pb.DisperseBlobReply{
 		Result:    pb.BlobStatus_PROCESSING,
@@ -300,7 +683,7 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 }
 
 func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, quorumID core.QuorumID) (*PerUserRateInfo, string, error) {
-	unauthRates, ok := s.rateConfig.QuorumRateInfos[quorumID]
+	unauthRates, ok := s.quorumRateInfo(quorumID)
 	if !ok {
 		return nil, "", fmt.Errorf("no configured rate exists for quorum %d", quorumID)
 	}
@@ -325,6 +708,7 @@ func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, qu
 					rates.BlobRate = rateInfo.BlobRate
 				}
 				rates.Name = rateInfo.Name
+				rates.Burst = rateInfo.Burst
 				return rates, key, nil
 			}
 		}
@@ -353,6 +737,7 @@ func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, qu
 		if rateInfo.BlobRate > 0 {
 			rates.BlobRate = rateInfo.BlobRate
 		}
+		rates.Burst = rateInfo.Burst
 
 		break
 	}
@@ -371,6 +756,8 @@ const (
 	AccountBlobRateType
 	RetrievalThroughputType
 	RetrievalBlobRateType
+	TenantThroughputType
+	TenantBlobRateType
 )
 
 func (r RateType) String() string {
@@ -387,6 +774,10 @@ func (r RateType) String() string {
 		return "Retrieval throughput rate limit"
 	case RetrievalBlobRateType:
 		return "Retrieval blob rate limit"
+	case TenantThroughputType:
+		return "Tenant throughput rate limit"
+	case TenantBlobRateType:
+		return "Tenant blob rate limit"
 	default:
 		return "Unknown rate type"
 	}
@@ -406,6 +797,10 @@ func (r RateType) Plug() string {
 		return "retrieval_throughput"
 	case RetrievalBlobRateType:
 		return "retrieval_blob_rate"
+	case TenantThroughputType:
+		return "tenant_throughput"
+	case TenantBlobRateType:
+		return "tenant_blob_rate"
 	default:
 		return "unknown_rate_type"
 	}
@@ -420,7 +815,9 @@ type limiterInfo struct {
 // including both system and account level rates, relative to both the blob rate and the data bandwidth rate.
 // The function will check for whitelist entries for both the authenticated address (if authenticated) and the origin.
 // If no whitelist entry is found for either the origin or the authenticated address, the origin will be used as the account key
-// and unauthenticated rates will be used. If the rate limit is exceeded, the function will return a ResourceExhaustedError.
+// and unauthenticated rates will be used. The accounts of each tenant are rate limited separately, and the blobs of a tenant
+// with quotas are also rate limited across all its accounts and quorums. If the rate limit is exceeded, the function will
+// return a ResourceExhaustedError.
 // checkRateLimitsAndAddRatesToHeader will also update the blob's security params with the throughput rate for each qourum.
 //
 // This information is currently passed to the DA nodes for their use is ratelimiting retrieval requests. This retrieval ratelimiting
@@ -434,7 +831,7 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 	requesterName := ""
 	for i, param := range blob.RequestHeader.SecurityParams {
 
-		globalRates, ok := s.rateConfig.QuorumRateInfos[param.QuorumID]
+		globalRates, ok := s.quorumRateInfo(param.QuorumID)
 		if !ok {
 			s.metrics.HandleInternalFailureRpcRequest(apiMethodName)
 			return api.NewInternalError(fmt.Sprintf("no configured rate exists for quorum %d", param.QuorumID))
@@ -446,6 +843,9 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 			return api.NewInternalError(err.Error())
 		}
 		requesterName = accountRates.Name
+		if blob.RequestHeader.TenantID != "" {
+			accountKey = fmt.Sprintf("tenant:%s/%s", blob.RequestHeader.TenantID, accountKey)
+		}
 
 		// Update the quorum rate
 		blob.RequestHeader.SecurityParams[i].QuorumRate = accountRates.Throughput
@@ -480,9 +880,10 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 		// Account Level
 		key = fmt.Sprintf("%s:%d-%s", accountKey, param.QuorumID, AccountThroughputType.Plug())
 		requestParams = append(requestParams, common.RequestParams{
-			RequesterID: key,
-			BlobSize:    encodedSize,
-			Rate:        accountRates.Throughput,
+			RequesterID:     key,
+			BlobSize:        encodedSize,
+			Rate:            accountRates.Throughput,
+			BurstMultiplier: accountRates.Burst,
 			Info: limiterInfo{
 				RateType: AccountThroughputType,
 				QuorumID: param.QuorumID,
@@ -491,9 +892,10 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 
 		key = fmt.Sprintf("%s:%d-%s", accountKey, param.QuorumID, AccountBlobRateType.Plug())
 		requestParams = append(requestParams, common.RequestParams{
-			RequesterID: key,
-			BlobSize:    blobRateMultiplier,
-			Rate:        accountRates.BlobRate,
+			RequesterID:     key,
+			BlobSize:        blobRateMultiplier,
+			Rate:            accountRates.BlobRate,
+			BurstMultiplier: accountRates.Burst,
 			Info: limiterInfo{
 				RateType: AccountBlobRateType,
 				QuorumID: param.QuorumID,
@@ -502,6 +904,30 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 
 	}
 
+	// Tenant Level
+	if tenant, ok := s.tenants.Get(blob.RequestHeader.TenantID); ok {
+		if tenant.Throughput > 0 {
+			requestParams = append(requestParams, common.RequestParams{
+				RequesterID: fmt.Sprintf("tenant:%s-%s", tenant.ID, TenantThroughputType.Plug()),
+				BlobSize:    uint(blobSize),
+				Rate:        tenant.Throughput,
+				Info: limiterInfo{
+					RateType: TenantThroughputType,
+				},
+			})
+		}
+		if tenant.BlobRate > 0 {
+			requestParams = append(requestParams, common.RequestParams{
+				RequesterID: fmt.Sprintf("tenant:%s-%s", tenant.ID, TenantBlobRateType.Plug()),
+				BlobSize:    blobRateMultiplier,
+				Rate:        tenant.BlobRate,
+				Info: limiterInfo{
+					RateType: TenantBlobRateType,
+				},
+			})
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -525,6 +951,11 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 			s.metrics.HandleAccountRateLimitedRpcRequest(apiMethodName)
 			s.metrics.HandleAccountRateLimitedRequest(fmt.Sprint(info.QuorumID), blobSize, apiMethodName)
 			s.logger.Info("request ratelimited", "requesterName", requesterName, "requesterID", params.RequesterID, "rateType", info.RateType.String(), "quorum", info.QuorumID)
+		} else if info.RateType == TenantThroughputType || info.RateType == TenantBlobRateType {
+			s.metrics.HandleAccountRateLimitedRpcRequest(apiMethodName)
+			s.metrics.HandleAccountRateLimitedRequest("", blobSize, apiMethodName)
+			s.logger.Info("request ratelimited", "tenant", blob.RequestHeader.TenantID, "rateType", info.RateType.String())
+			return api.NewResourceExhaustedError(fmt.Sprintf("request ratelimited: %s", info.RateType.String()))
 		}
 		errorString := fmt.Sprintf("request ratelimited: %s for quorum %d", info.RateType.String(), info.QuorumID)
 		return api.NewResourceExhaustedError(errorString)
@@ -555,8 +986,20 @@ func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusR
 		return nil, api.NewInvalidArgError(fmt.Sprintf("failed to parse the requestID: %s", err.Error()))
 	}
 
+	tenant, err := s.tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if reply, ok := s.statusCache.get(metadataKey, tenantIDOf(tenant)); ok {
+		s.metrics.HandleSuccessfulRpcRequest("GetBlobStatus")
+		return reply, nil
+	}
+
 	s.logger.Debug("metadataKey", "metadataKey", metadataKey.String())
 	metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+	if err == nil && !visibleToTenant(metadata, tenantIDOf(tenant)) {
+		err = disperser.ErrMetadataNotFound
+	}
 	if err != nil {
 		if errors.Is(err, disperser.ErrMetadataNotFound) {
 			s.metrics.HandleNotFoundRpcRequest("GetBlobStatus")
@@ -567,85 +1010,121 @@ func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusR
 		return nil, api.NewInternalError(fmt.Sprintf("failed to get blob metadata, blobkey: %s", metadataKey.String()))
 	}
 
-	isConfirmed, err := metadata.IsConfirmed()
+	reply, err := s.getBlobStatusReply(metadata)
 	if err != nil {
 		s.metrics.HandleInternalFailureRpcRequest("GetBlobStatus")
-		return nil, api.NewInternalError(fmt.Sprintf("missing confirmation information: %s", err.Error()))
+		return nil, err
 	}
 
 	s.metrics.HandleSuccessfulRpcRequest("GetBlobStatus")
 
-	s.logger.Debug("isConfirmed", "metadataKey", metadataKey, "isConfirmed", isConfirmed)
-	if isConfirmed {
-		confirmationInfo := metadata.ConfirmationInfo
-		dataLength := uint32(confirmationInfo.BlobCommitment.Length)
-		quorumResults := confirmationInfo.QuorumResults
-		batchQuorumIDs := make([]uint8, 0, len(quorumResults))
-		for quorumID := range quorumResults {
-			batchQuorumIDs = append(batchQuorumIDs, quorumID)
-		}
-		slices.Sort(batchQuorumIDs)
-		quorumNumbers := make([]byte, len(batchQuorumIDs))
-		quorumPercentSigned := make([]byte, len(batchQuorumIDs))
-		for i, quorumID := range batchQuorumIDs {
-			quorumNumbers[i] = quorumID
-			quorumPercentSigned[i] = confirmationInfo.QuorumResults[quorumID].PercentSigned
-		}
-
-		quorumInfos := confirmationInfo.BlobQuorumInfos
-		slices.SortStableFunc[[]*core.BlobQuorumInfo](quorumInfos, func(a, b *core.BlobQuorumInfo) int {
-			return int(a.QuorumID) - int(b.QuorumID)
-		})
-		blobQuorumParams := make([]*pb.BlobQuorumParam, len(quorumInfos))
-		quorumIndexes := make([]byte, len(quorumInfos))
-		for i, quorumInfo := range quorumInfos {
-			blobQuorumParams[i] = &pb.BlobQuorumParam{
-				QuorumNumber:                    uint32(quorumInfo.QuorumID),
-				AdversaryThresholdPercentage:    uint32(quorumInfo.AdversaryThreshold),
-				ConfirmationThresholdPercentage: uint32(quorumInfo.ConfirmationThreshold),
-				ChunkLength:                     uint32(quorumInfo.ChunkLength),
+	return reply, nil
+}
+
+// SubscribeBlobStatus streams the status of the blob each time it changes, until it reaches a terminal status.
+// The status is read from the blob store every StatusPollInterval, and a keepalive message is sent when it hasn't
+// changed for StatusKeepaliveInterval.
+func (s *DispersalServer) SubscribeBlobStatus(req *pb.SubscribeBlobStatusRequest, stream pb.Disperser_SubscribeBlobStatusServer) error {
+	if s.serverConfig.DisableStreaming {
+		return streamingDisabledError()
+	}
+
+	requestID := req.GetRequestId()
+	if len(requestID) == 0 {
+		s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+		s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+		return api.NewInvalidArgError("request_id must not be empty")
+	}
+
+	s.logger.Info("received a new blob status subscription", "requestID", string(requestID), "lastStatus", req.GetLastStatus().String())
+	metadataKey, err := disperser.ParseBlobKey(string(requestID))
+	if err != nil {
+		s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+		s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+		return api.NewInvalidArgError(fmt.Sprintf("failed to parse the requestID: %s", err.Error()))
+	}
+
+	pollInterval := s.serverConfig.StatusPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultStatusPollInterval
+	}
+	keepaliveInterval := s.serverConfig.StatusKeepaliveInterval
+	if keepaliveInterval <= 0 {
+		keepaliveInterval = defaultStatusKeepaliveInterval
+	}
+	ctx := stream.Context()
+	tenant, err := s.tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	pollTicker := time.NewTicker(pollInterval)
+	defer pollTicker.Stop()
+	keepaliveTicker := time.NewTicker(keepaliveInterval)
+	defer keepaliveTicker.Stop()
+
+	lastStatus := req.GetLastStatus()
+	for {
+		metadata, err := s.blobStore.GetBlobMetadata(ctx, metadataKey)
+		if err == nil && !visibleToTenant(metadata, tenantIDOf(tenant)) {
+			err = disperser.ErrMetadataNotFound
+		}
+		if err != nil {
+			if errors.Is(err, disperser.ErrMetadataNotFound) {
+				s.metrics.HandleNotFoundRpcRequest("SubscribeBlobStatus")
+				s.metrics.HandleNotFoundRequest("SubscribeBlobStatus")
+				return api.NewNotFoundError("no metadata found for the requestID")
 			}
-			quorumIndexes[i] = byte(slices.Index(quorumNumbers, quorumInfo.QuorumID))
-		}
-
-		return &pb.BlobStatusReply{
-			Status: getResponseStatus(metadata.BlobStatus),
-			Info: &pb.BlobInfo{
-				BlobHeader: &pb.BlobHeader{
-					Commitment: &commonpb.G1Commitment{
-						X: confirmationInfo.BlobCommitment.Commitment.X.Marshal(),
-						Y: confirmationInfo.BlobCommitment.Commitment.Y.Marshal(),
-					},
-					DataLength:       dataLength,
-					BlobQuorumParams: blobQuorumParams,
-				},
-				BlobVerificationProof: &pb.BlobVerificationProof{
-					BatchId:   confirmationInfo.BatchID,
-					BlobIndex: confirmationInfo.BlobIndex,
-					BatchMetadata: &pb.BatchMetadata{
-						BatchHeader: &pb.BatchHeader{
-							BatchRoot:               confirmationInfo.BatchRoot,
-							QuorumNumbers:           quorumNumbers,
-							QuorumSignedPercentages: quorumPercentSigned,
-							ReferenceBlockNumber:    confirmationInfo.ReferenceBlockNumber,
-						},
-						SignatoryRecordHash:     confirmationInfo.SignatoryRecordHash[:],
-						Fee:                     confirmationInfo.Fee,
-						ConfirmationBlockNumber: confirmationInfo.ConfirmationBlockNumber,
-						BatchHeaderHash:         confirmationInfo.BatchHeaderHash[:],
-					},
-					InclusionProof: confirmationInfo.BlobInclusionProof,
-					// ref: api/proto/disperser/disperser.proto:BlobVerificationProof.quorum_indexes
-					QuorumIndexes: quorumIndexes,
-				},
-			},
-		}, nil
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.metrics.HandleInternalFailureRpcRequest("SubscribeBlobStatus")
+			return api.NewInternalError(fmt.Sprintf("failed to get blob metadata, blobkey: %s", metadataKey.String()))
+		}
+
+		reply, err := s.getBlobStatusReply(metadata)
+		if err != nil {
+			s.metrics.HandleInternalFailureRpcRequest("SubscribeBlobStatus")
+			return err
+		}
+
+		if reply.GetStatus() != lastStatus {
+			if err := stream.Send(&pb.BlobStatusUpdate{Reply: reply}); err != nil {
+				return err
+			}
+			lastStatus = reply.GetStatus()
+			keepaliveTicker.Reset(keepaliveInterval)
+		}
+
+		if isTerminalStatus(lastStatus) {
+			s.metrics.HandleSuccessfulRpcRequest("SubscribeBlobStatus")
+			return nil
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-keepaliveTicker.C:
+				if err := stream.Send(&pb.BlobStatusUpdate{}); err != nil {
+					return err
+				}
+			case <-pollTicker.C:
+				break wait
+			}
+		}
 	}
+}
 
-	return &pb.BlobStatusReply{
-		Status: getResponseStatus(metadata.BlobStatus),
-		Info:   &pb.BlobInfo{},
-	}, nil
+// getBlobStatusReply converts the metadata of a blob into the reply returned by GetBlobStatus.
+func (s *DispersalServer) getBlobStatusReply(metadata *disperser.BlobMetadata) (*pb.BlobStatusReply, error) {
+	reply, err := disperser.ToBlobStatusReply(metadata)
+	if err != nil {
+		return nil, api.NewInternalError(fmt.Sprintf("missing confirmation information: %s", err.Error()))
+	}
+	s.statusCache.add(metadata, reply)
+	return reply, nil
 }
 
 func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlobRequest) (*pb.RetrieveBlobReply, error) {
@@ -654,11 +1133,89 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 	}))
 	defer timer.ObserveDuration()
 
+	_, data, err := s.retrieveBlob(ctx, req, "RetrieveBlob")
+	if err != nil {
+		return nil, err
+	}
+	s.metrics.HandleSuccessfulRpcRequest("RetrieveBlob")
+	s.metrics.HandleSuccessfulRequest("", len(data), "RetrieveBlob")
+
+	data, err = compressPayload(req.GetCompression(), data)
+	if err != nil {
+		return nil, api.NewInternalError(fmt.Sprintf("failed to compress the blob: %v", err))
+	}
+
+	return &pb.RetrieveBlobReply{
+		Data: data,
+	}, nil
+}
+
+// RetrieveBlobWithProof retrieves a blob as RetrieveBlob does, and proves that it's the blob committed to by the
+// confirmed commitment, so that the clients can verify it without the DA Nodes.
+func (s *DispersalServer) RetrieveBlobWithProof(ctx context.Context, req *pb.RetrieveBlobRequest) (*pb.RetrieveBlobWithProofReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("RetrieveBlobWithProof", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if s.committer == nil {
+		s.metrics.HandleFailedRequest(codes.Unimplemented.String(), "", 0, "RetrieveBlobWithProof")
+		return nil, api.NewGRPCError(codes.Unimplemented, "retrieval proofs are not supported by this disperser")
+	}
+
+	blobMetadata, data, err := s.retrieveBlob(ctx, req, "RetrieveBlobWithProof")
+	if err != nil {
+		return nil, err
+	}
+
+	// The data is checked against the confirmed commitment, so that a corrupted blob is never served with a proof
+	commitment, proof, err := s.committer.ComputeBlobProof(data)
+	if err != nil || !(*bn254.G1Affine)(commitment).Equal((*bn254.G1Affine)(blobMetadata.ConfirmationInfo.BlobCommitment.Commitment)) {
+		s.logger.Error("The retrieved blob doesn't match its commitment", "blobKey", blobMetadata.GetBlobKey(), "err", err)
+		s.metrics.HandleInternalFailureRpcRequest("RetrieveBlobWithProof")
+		s.metrics.HandleFailedRequest(codes.Internal.String(), "", len(data), "RetrieveBlobWithProof")
+		return nil, api.NewInternalError("the retrieved blob doesn't match its commitment")
+	}
+	statusReply, err := s.getBlobStatusReply(blobMetadata)
+	if err != nil {
+		s.metrics.HandleInternalFailureRpcRequest("RetrieveBlobWithProof")
+		return nil, err
+	}
+	s.metrics.HandleSuccessfulRpcRequest("RetrieveBlobWithProof")
+	s.metrics.HandleSuccessfulRequest("", len(data), "RetrieveBlobWithProof")
+
+	data, err = compressPayload(req.GetCompression(), data)
+	if err != nil {
+		return nil, api.NewInternalError(fmt.Sprintf("failed to compress the blob: %v", err))
+	}
+
+	proofBytes := proof.Bytes()
+	return &pb.RetrieveBlobWithProofReply{
+		Data:  data,
+		Info:  statusReply.GetInfo(),
+		Proof: proofBytes[:],
+	}, nil
+}
+
+// retrieveBlob rate limits the retrieval of a confirmed blob, and returns its metadata and data. The failures are
+// recorded in the metrics as failures of the method.
+func (s *DispersalServer) retrieveBlob(ctx context.Context, req *pb.RetrieveBlobRequest, method string) (*disperser.BlobMetadata, []byte, error) {
+	if err := validateCompression(req.GetCompression()); err != nil {
+		s.metrics.HandleInvalidArgRpcRequest(method)
+		s.metrics.HandleInvalidArgRequest(method)
+		return nil, nil, api.NewInvalidArgError(err.Error())
+	}
+
 	origin, err := common.GetClientAddress(ctx, s.rateConfig.ClientIPHeader, 2, true)
 	if err != nil {
-		s.metrics.HandleInvalidArgRpcRequest("RetrieveBlob")
-		s.metrics.HandleInvalidArgRequest("RetrieveBlob")
-		return nil, api.NewInvalidArgError(err.Error())
+		s.metrics.HandleInvalidArgRpcRequest(method)
+		s.metrics.HandleInvalidArgRequest(method)
+		return nil, nil, api.NewInvalidArgError(err.Error())
+	}
+
+	tenant, err := s.tenantFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Check blob rate limit
@@ -672,18 +1229,18 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 			},
 		})
 		if err != nil {
-			s.metrics.HandleInternalFailureRpcRequest("RetrieveBlob")
-			return nil, api.NewInternalError(fmt.Sprintf("ratelimiter error: %v", err))
+			s.metrics.HandleInternalFailureRpcRequest(method)
+			return nil, nil, api.NewInternalError(fmt.Sprintf("ratelimiter error: %v", err))
 		}
 		if !allowed {
-			s.metrics.HandleRateLimitedRpcRequest("RetrieveBlob")
-			s.metrics.HandleFailedRequest(codes.ResourceExhausted.String(), "", 0, "RetrieveBlob")
+			s.metrics.HandleRateLimitedRpcRequest(method)
+			s.metrics.HandleFailedRequest(codes.ResourceExhausted.String(), "", 0, method)
 			errorString := "request ratelimited"
 			info, ok := param.Info.(string)
 			if ok {
 				errorString += ": " + info
 			}
-			return nil, api.NewResourceExhaustedError(errorString)
+			return nil, nil, api.NewResourceExhaustedError(errorString)
 		}
 	}
 
@@ -697,16 +1254,19 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 	blobIndex := req.GetBlobIndex()
 
 	blobMetadata, err := s.blobStore.GetMetadataInBatch(ctx, batchHeaderHash32, blobIndex)
+	if err == nil && !visibleToTenant(blobMetadata, tenantIDOf(tenant)) {
+		err = disperser.ErrMetadataNotFound
+	}
 	if err != nil {
 		s.logger.Error("Failed to retrieve blob metadata", "err", err)
 		if errors.Is(err, disperser.ErrMetadataNotFound) {
-			s.metrics.HandleNotFoundRpcRequest("RetrieveBlob")
-			s.metrics.HandleNotFoundRequest("RetrieveBlob")
-			return nil, api.NewNotFoundError("no metadata found for the given batch header hash and blob index")
+			s.metrics.HandleNotFoundRpcRequest(method)
+			s.metrics.HandleNotFoundRequest(method)
+			return nil, nil, api.NewNotFoundError("no metadata found for the given batch header hash and blob index")
 		}
-		s.metrics.HandleInternalFailureRpcRequest("RetrieveBlob")
-		s.metrics.IncrementFailedBlobRequestNum(codes.Internal.String(), "", "RetrieveBlob")
-		return nil, api.NewInternalError("failed to get blob metadata, please retry")
+		s.metrics.HandleInternalFailureRpcRequest(method)
+		s.metrics.IncrementFailedBlobRequestNum(codes.Internal.String(), "", method)
+		return nil, nil, api.NewInternalError("failed to get blob metadata, please retry")
 	}
 
 	// Check throughout rate limit
@@ -722,37 +1282,37 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 			},
 		})
 		if err != nil {
-			s.metrics.HandleInternalFailureRpcRequest("RetrieveBlob")
-			return nil, api.NewInternalError(fmt.Sprintf("ratelimiter error: %v", err))
+			s.metrics.HandleInternalFailureRpcRequest(method)
+			return nil, nil, api.NewInternalError(fmt.Sprintf("ratelimiter error: %v", err))
 		}
 		if !allowed {
-			s.metrics.HandleRateLimitedRpcRequest("RetrieveBlob")
-			s.metrics.HandleFailedRequest(codes.ResourceExhausted.String(), "", 0, "RetrieveBlob")
+			s.metrics.HandleRateLimitedRpcRequest(method)
+			s.metrics.HandleFailedRequest(codes.ResourceExhausted.String(), "", 0, method)
 			errorString := "request ratelimited"
 			info, ok := param.Info.(string)
 			if ok {
 				errorString += ": " + info
 			}
-			return nil, api.NewResourceExhaustedError(errorString)
+			return nil, nil, api.NewResourceExhaustedError(errorString)
 		}
 	}
 
 	data, err := s.blobStore.GetBlobContent(ctx, blobMetadata.BlobHash)
 	if err != nil {
 		s.logger.Error("Failed to retrieve blob", "err", err)
-		s.metrics.HandleInternalFailureRpcRequest("RetrieveBlob")
-		s.metrics.HandleFailedRequest(codes.Internal.String(), "", len(data), "RetrieveBlob")
-		return nil, api.NewInternalError("failed to get blob data, please retry")
+		s.metrics.HandleInternalFailureRpcRequest(method)
+		s.metrics.HandleFailedRequest(codes.Internal.String(), "", len(data), method)
+		return nil, nil, api.NewInternalError("failed to get blob data, please retry")
 	}
-	s.metrics.HandleSuccessfulRpcRequest("RetrieveBlob")
-	s.metrics.HandleSuccessfulRequest("", len(data), "RetrieveBlob")
-
-	return &pb.RetrieveBlobReply{
-		Data: data,
-	}, nil
+	return blobMetadata, data, nil
 }
 
 func (s *DispersalServer) Start(ctx context.Context) error {
+	if err := s.limits.Start(ctx); err != nil {
+		return fmt.Errorf("failed to get the quorum limits: %w", err)
+	}
+	s.admission.Start(ctx)
+
 	// Serve grpc requests
 	addr := fmt.Sprintf("%s:%s", disperser.Localhost, s.serverConfig.GrpcPort)
 	listener, err := net.Listen("tcp", addr)
@@ -760,15 +1320,48 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 		return errors.New("could not start tcp listener")
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(1024 * 1024 * 300)} // 300 MiB
+	tlsOpts, err := grpctls.ServerOptions(s.serverConfig.TLS, s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	opts = append(opts, tlsOpts...)
 
-	gs := grpc.NewServer(opt)
+	gs := grpc.NewServer(opts...)
 	reflection.Register(gs)
 	pb.RegisterDisperserServer(gs, s)
+	pbv2.RegisterDisperserServer(gs, NewDispersalServerV2(s))
 
 	// Register Server for Health Checks
-	name := pb.Disperser_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	healthcheck.RegisterHealthServerForServices(gs, pb.Disperser_ServiceDesc.ServiceName, pbv2.Disperser_ServiceDesc.ServiceName)
+
+	var httpServers []*http.Server
+	if s.serverConfig.HTTPPort != "" {
+		httpServers = append(httpServers, s.newGatewayServer())
+	}
+	if s.serverConfig.AdminPort != "" {
+		httpServers = append(httpServers, s.newAdminServer())
+	}
+	for _, httpServer := range httpServers {
+		httpServer := httpServer
+		go func() {
+			s.logger.Info("HTTP server listening", "address", httpServer.Addr)
+			if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.logger.Error("HTTP server stopped", "address", httpServer.Addr, "err", err)
+			}
+		}()
+	}
+
+	// Stop serving once the server has been drained
+	go func() {
+		<-s.drained
+		for _, httpServer := range httpServers {
+			if err := httpServer.Shutdown(context.Background()); err != nil {
+				s.logger.Warn("failed to shut down the HTTP server", "address", httpServer.Addr, "err", err)
+			}
+		}
+		gs.GracefulStop()
+	}()
 
 	s.logger.Info("port", s.serverConfig.GrpcPort, "address", listener.Addr().String(), "GRPC Listening")
 	if err := gs.Serve(listener); err != nil {
@@ -778,11 +1371,32 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 	return nil
 }
 
+// newGatewayServer returns the server of the HTTP/JSON gateway to the API on the HTTP port.
+func (s *DispersalServer) newGatewayServer() *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf("%s:%s", disperser.Localhost, s.serverConfig.HTTPPort),
+		Handler:           NewGateway(s, s.logger),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
 // updateQuorumConfig updates the quorum config and returns the updated quorum config. If the update fails,
 // it will fallback to the old quorumConfig if it is set. This is to improve the robustness of the disperser to
 // RPC failures since the quorum config is rarely updated. In the event that quorumConfig is incorrect, this will
 // not result in a safety failure since all parameters are separately validated on the smart contract.
 
+// startGateway serves the HTTP/JSON gateway to the API on the HTTP port.
+func (s *DispersalServer) startGateway() error {
+	addr := fmt.Sprintf("%s:%s", disperser.Localhost, s.serverConfig.HTTPPort)
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           NewGateway(s, s.logger),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.logger.Info("port", s.serverConfig.HTTPPort, "address", addr, "HTTP gateway listening")
+	return httpServer.ListenAndServe()
+}
+
 func (s *DispersalServer) updateQuorumConfig(ctx context.Context) (QuorumConfig, error) {
 
 	s.mu.RLock()
@@ -841,25 +1455,30 @@ func (s *DispersalServer) updateQuorumConfig(ctx context.Context) (QuorumConfig,
 	return newConfig, nil
 }
 
-func getResponseStatus(status disperser.BlobStatus) pb.BlobStatus {
+// isTerminalStatus returns whether a blob with the given status will not be updated to a different status.
+func isTerminalStatus(status pb.BlobStatus) bool {
 	switch status {
-	case disperser.Dispersing, disperser.Processing:
-		return pb.BlobStatus_PROCESSING
-	case disperser.Confirmed:
-		return pb.BlobStatus_CONFIRMED
-	case disperser.Failed:
-		return pb.BlobStatus_FAILED
-	case disperser.Finalized:
-		return pb.BlobStatus_FINALIZED
-	case disperser.InsufficientSignatures:
-		return pb.BlobStatus_INSUFFICIENT_SIGNATURES
+	case pb.BlobStatus_FINALIZED, pb.BlobStatus_FAILED, pb.BlobStatus_INSUFFICIENT_SIGNATURES:
+		return true
 	default:
-		return pb.BlobStatus_UNKNOWN
+		return false
 	}
 }
 
 func (s *DispersalServer) validateRequestAndGetBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*core.Blob, error) {
 
+	if req.GetCompression() != "" {
+		if len(req.GetData()) > maxBlobSize {
+			return nil, fmt.Errorf("blob size cannot exceed 2 MiB")
+		}
+		decompressed, err := decompressPayload(req.GetCompression(), req.GetData(), maxBlobSize, s.serverConfig.MaxDecompressionRatio)
+		if err != nil {
+			return nil, err
+		}
+		// The rest of the request, e.g. its payload hash and signature, applies to the decompressed data
+		req.Data, req.Compression = decompressed, ""
+	}
+
 	data := req.GetData()
 	blobSize := len(data)
 	// The blob size in bytes must be in range [1, maxBlobSize].
@@ -874,11 +1493,32 @@ func (s *DispersalServer) validateRequestAndGetBlob(ctx context.Context, req *pb
 		return nil, errors.New("number of custom_quorum_numbers must not exceed 256")
 	}
 
-	// validate every 32 bytes is a valid field element
-	_, err := rs.ToFrArray(data)
-	if err != nil {
-		s.logger.Error("failed to convert a 32bytes as a field element", "err", err)
-		return nil, api.NewInvalidArgError("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617")
+	if err := validateIdempotencyKey(req.GetIdempotencyKey()); err != nil {
+		return nil, err
+	}
+
+	if err := validateCallback(req.GetCallbackUrl(), req.GetCallbackSecret()); err != nil {
+		return nil, err
+	}
+
+	if req.GetPriority() > s.serverConfig.MaxBlobPriority {
+		return nil, fmt.Errorf("priority must be at most %d, got %d", s.serverConfig.MaxBlobPriority, req.GetPriority())
+	}
+
+	attestationTimeout := time.Duration(req.GetAttestationTimeoutMs()) * time.Millisecond
+	if attestationTimeout > 0 && attestationTimeout < s.serverConfig.MinAttestationTimeout {
+		return nil, fmt.Errorf("attestation_timeout_ms must be at least %d, got %d", s.serverConfig.MinAttestationTimeout.Milliseconds(), req.GetAttestationTimeoutMs())
+	}
+
+	// The payload hash is optional, but if it's provided it must match the data
+	if len(req.GetPayloadHash()) > 0 && !bytes.Equal(req.GetPayloadHash(), disperser.ComputePayloadHash(data)) {
+		return nil, errors.New("payload_hash does not match the keccak256 hash of data")
+	}
+
+	// validate every 32 bytes is a valid field element, before the blob is stored and fails to encode
+	if err := rs.ValidateSymbols(data); err != nil {
+		s.logger.Warn("rejecting blob with a non-canonical field element", "err", err)
+		return nil, api.NewInvalidArgError(fmt.Sprintf("%v, please use the correct format where every 32bytes(big-endian) is less than %v, e.g. by padding every 31 bytes with a zero byte", err, fr.Modulus()))
 	}
 
 	quorumConfig, err := s.updateQuorumConfig(ctx)
@@ -946,11 +1586,31 @@ func (s *DispersalServer) validateRequestAndGetBlob(ctx context.Context, req *pb
 		i++
 	}
 
+	if err := s.applySecurityParams(ctx, req.GetSecurityParams(), params, blobSize); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlobSizeLimits(blobSize, params); err != nil {
+		return nil, err
+	}
+
+	// The commitment is checked last, as computing it is the most expensive part of the validation
+	if req.GetCommitment() != nil {
+		if err := s.checkCommitment(req.GetCommitment(), data); err != nil {
+			return nil, err
+		}
+	}
+
 	header := core.BlobRequestHeader{
 		BlobAuthHeader: core.BlobAuthHeader{
 			AccountID: req.AccountId,
 		},
-		SecurityParams: params,
+		SecurityParams:     params,
+		RetentionPeriod:    time.Duration(req.GetRetentionPeriodSeconds()) * time.Second,
+		Priority:           req.GetPriority(),
+		AttestationTimeout: attestationTimeout,
+		CallbackURL:        req.GetCallbackUrl(),
+		CallbackSecret:     req.GetCallbackSecret(),
 	}
 
 	blob := &core.Blob{
@@ -960,3 +1620,70 @@ func (s *DispersalServer) validateRequestAndGetBlob(ctx context.Context, req *pb
 
 	return blob, nil
 }
+
+// validateCallback checks the callback URL of a request is an absolute http or https URL, and that the notifications
+// to it are signed with a secret
+func validateCallback(callbackURL string, secret []byte) error {
+	if callbackURL == "" {
+		if len(secret) > 0 {
+			return errors.New("callback_secret is only allowed with a callback_url")
+		}
+		return nil
+	}
+	if len(callbackURL) > maxCallbackURLLength {
+		return fmt.Errorf("callback_url cannot exceed %d characters", maxCallbackURLLength)
+	}
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return fmt.Errorf("invalid callback_url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("callback_url must be an absolute http or https URL, got %q", callbackURL)
+	}
+	if len(secret) == 0 {
+		return errors.New("callback_secret is required with a callback_url")
+	}
+	if len(secret) > maxCallbackSecretSize {
+		return fmt.Errorf("callback_secret cannot exceed %d bytes", maxCallbackSecretSize)
+	}
+	return nil
+}
+
+// NewBlobCommitter creates the committer the commitments supplied with the blobs are cross-checked with, loading the
+// G1 points needed for the largest blobs accepted by the server.
+func NewBlobCommitter(g1Path string, srsOrder uint64) (*committer.Committer, error) {
+	return committer.NewCommitter(committer.Config{
+		G1Path:        g1Path,
+		SRSOrder:      srsOrder,
+		MaxBlobLength: maxBlobSize / encoding.BYTES_PER_SYMBOL,
+		NumWorker:     uint64(runtime.GOMAXPROCS(0)),
+	})
+}
+
+// checkCommitment checks that the commitment supplied with a blob matches the commitment of its data.
+func (s *DispersalServer) checkCommitment(commitment *commonpb.G1Commitment, data []byte) error {
+	if s.committer == nil {
+		return errors.New("commitment is not supported by this disperser")
+	}
+	supplied, err := encoding.G1CommitmentFromCoordinates(commitment.GetX(), commitment.GetY())
+	if err != nil {
+		return fmt.Errorf("invalid commitment: %w", err)
+	}
+	computed, err := s.committer.ComputeCommitment(data)
+	if err != nil {
+		return fmt.Errorf("failed to compute the commitment of the data: %w", err)
+	}
+	if !(*bn254.G1Affine)(supplied).Equal((*bn254.G1Affine)(computed)) {
+		return errCommitmentMismatch
+	}
+	return nil
+}
+
+// invalidBlobError converts an error validating a blob to an InvalidArgument error, carrying the reason of the
+// errors the clients are expected to handle.
+func invalidBlobError(err error) error {
+	if errors.Is(err, errCommitmentMismatch) {
+		return api.NewInvalidArgErrorWithReason(err.Error(), ReasonCommitmentMismatch)
+	}
+	return api.NewInvalidArgError(err.Error())
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"flag"
 	"fmt"
+	"math/big"
 	"net"
 	"os"
 	"testing"
@@ -15,6 +16,9 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/committer"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/eip4844"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -22,6 +26,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/urfave/cli"
 
+	"github.com/Layr-Labs/eigenda/api"
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
@@ -32,15 +38,21 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/inabox/deploy"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
 	tmock "github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	grpcstatus "google.golang.org/grpc/status"
 )
 
 var (
 	queue           disperser.BlobStore
+	limits          *apiserver.DynamicLimits
+	blobCommitter   *committer.Committer
 	dispersalServer *apiserver.DispersalServer
 
 	dockertestPool     *dockertest.Pool
@@ -94,6 +106,62 @@ func TestDisperseBlobAuth(t *testing.T) {
 
 }
 
+func TestDisperseBlobSigned(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	signRequest := func(privateKeyHex string, nonce uint64, expiry time.Time) *pb.DisperseBlobRequest {
+		signer := auth.NewSigner(privateKeyHex)
+		request := &pb.DisperseBlobRequest{
+			Data:                data,
+			CustomQuorumNumbers: []uint32{0, 1},
+			AccountId:           signer.GetAccountID(),
+			Nonce:               nonce,
+			Expiry:              uint64(expiry.Unix()),
+		}
+		request.Signature, err = signer.(core.RequestSigner).SignRequest(disperser.ComputePayloadHash(data), request.Nonce, request.Expiry)
+		assert.NoError(t, err)
+		return request
+	}
+
+	// The account of the key is registered in the allowlist
+	registeredKey := "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded"
+	nonce := uint64(time.Now().UnixNano())
+	request := signRequest(registeredKey, nonce, time.Now().Add(time.Minute))
+	reply, err := dispersalServer.DisperseBlob(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+
+	// The request can't be replayed
+	_, err = dispersalServer.DisperseBlob(ctx, request)
+	assert.ErrorContains(t, err, "was already used")
+
+	// The signature covers the data
+	request = signRequest(registeredKey, nonce+1, time.Now().Add(time.Minute))
+	request.Data = codec.ConvertByPaddingEmptyByte([]byte("other data"))
+	_, err = dispersalServer.DisperseBlob(ctx, request)
+	assert.ErrorContains(t, err, "failed to authenticate the signed request")
+
+	_, err = dispersalServer.DisperseBlob(ctx, signRequest(registeredKey, nonce+2, time.Now().Add(-time.Minute)))
+	assert.ErrorContains(t, err, "expired")
+	_, err = dispersalServer.DisperseBlob(ctx, signRequest(registeredKey, nonce+3, time.Now().Add(time.Hour)))
+	assert.ErrorContains(t, err, "must be at most")
+
+	unregisteredKey := "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdeb"
+	_, err = dispersalServer.DisperseBlob(ctx, signRequest(unregisteredKey, nonce, time.Now().Add(time.Minute)))
+	assert.ErrorContains(t, err, "is not registered")
+}
+
 func TestDisperseBlobAuthTimeout(t *testing.T) {
 
 	data1KiB := make([]byte, 1024)
@@ -216,6 +284,345 @@ func TestDisperseBlobWithInvalidQuorum(t *testing.T) {
 
 }
 
+func TestDisperseBlobIdempotencyKey(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	idempotencyKey := uuid.NewString()
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		IdempotencyKey:      idempotencyKey,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+	requestID := reply.GetRequestId()
+
+	// A retry replays the reply to the first request
+	reply, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		IdempotencyKey:      idempotencyKey,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+	assert.Equal(t, requestID, reply.GetRequestId())
+
+	// The replay reports the current status of the blob
+	blobKey, err := disperser.ParseBlobKey(string(requestID))
+	assert.NoError(t, err)
+	assert.NoError(t, queue.MarkBlobFailed(context.Background(), blobKey))
+	reply, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		IdempotencyKey:      idempotencyKey,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_FAILED, reply.GetResult())
+	assert.Equal(t, requestID, reply.GetRequestId())
+
+	// The key can't be reused for different data
+	otherData := make([]byte, 1024)
+	_, err = rand.Read(otherData)
+	assert.NoError(t, err)
+	otherData = codec.ConvertByPaddingEmptyByte(otherData)
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                otherData,
+		CustomQuorumNumbers: []uint32{0, 1},
+		IdempotencyKey:      idempotencyKey,
+	})
+	assert.ErrorContains(t, err, "idempotency_key was already used for different data")
+
+	// Another key disperses the data again
+	reply, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		IdempotencyKey:      uuid.NewString(),
+	})
+	assert.NoError(t, err)
+	assert.NotEqual(t, requestID, reply.GetRequestId())
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		IdempotencyKey:      "key\n",
+	})
+	assert.ErrorContains(t, err, "idempotency_key must be printable ASCII")
+}
+
+func TestDisperseBlobWithPriority(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		Priority:            1,
+	})
+	assert.NoError(t, err)
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	metadata, err := queue.GetBlobMetadata(context.Background(), blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), metadata.RequestMetadata.Priority)
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		Priority:            2,
+	})
+	assert.ErrorContains(t, err, "priority must be at most 1")
+}
+
+func TestDisperseBlobWithAttestationTimeout(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                 data,
+		CustomQuorumNumbers:  []uint32{0, 1},
+		AttestationTimeoutMs: 2000,
+	})
+	assert.NoError(t, err)
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	metadata, err := queue.GetBlobMetadata(context.Background(), blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Second, metadata.RequestMetadata.AttestationTimeout)
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                 data,
+		CustomQuorumNumbers:  []uint32{0, 1},
+		AttestationTimeoutMs: 500,
+	})
+	assert.ErrorContains(t, err, "attestation_timeout_ms must be at least 1000, got 500")
+}
+
+func TestDisperseBlobWithCallback(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		CallbackUrl:         "https://sequencer.example.com/eigenda",
+		CallbackSecret:      []byte("secret"),
+	})
+	assert.NoError(t, err)
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	metadata, err := queue.GetBlobMetadata(context.Background(), blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://sequencer.example.com/eigenda", metadata.RequestMetadata.CallbackURL)
+	assert.Equal(t, []byte("secret"), metadata.RequestMetadata.CallbackSecret)
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		CallbackUrl:         "ftp://sequencer.example.com",
+		CallbackSecret:      []byte("secret"),
+	})
+	assert.ErrorContains(t, err, "callback_url must be an absolute http or https URL")
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		CallbackUrl:         "https://sequencer.example.com/eigenda",
+	})
+	assert.ErrorContains(t, err, "callback_secret is required with a callback_url")
+}
+
+func TestDisperseBlobWithSecurityParams(t *testing.T) {
+	transactor := &mock.MockTransactor{}
+	transactor.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	transactor.On("GetQuorumCount").Return(uint8(2), nil)
+	quorumParams := []core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+	}
+	transactor.On("GetQuorumSecurityParams", tmock.Anything).Return(quorumParams, nil)
+	transactor.On("GetRequiredQuorumNumbers", tmock.Anything).Return([]uint8{}, nil)
+	transactor.On("GetOperatorStakesForQuorums").Return(core.OperatorStakes{
+		0: {
+			0: {OperatorID: core.OperatorID{0}, Stake: big.NewInt(1)},
+			1: {OperatorID: core.OperatorID{1}, Stake: big.NewInt(2)},
+		},
+		1: {},
+	}, nil)
+	dispersalServer := newTestServer(transactor)
+
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0},
+		SecurityParams: []*pb.QuorumSecurityParams{
+			{QuorumId: 0, AdversaryThresholdPercentage: 40, ConfirmationThresholdPercentage: 80},
+		},
+	})
+	assert.NoError(t, err)
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	metadata, err := queue.GetBlobMetadata(context.Background(), blobKey)
+	assert.NoError(t, err)
+	assert.Len(t, metadata.RequestMetadata.SecurityParams, 1)
+	assert.Equal(t, uint8(40), metadata.RequestMetadata.SecurityParams[0].AdversaryThreshold)
+	assert.Equal(t, uint8(80), metadata.RequestMetadata.SecurityParams[0].ConfirmationThreshold)
+
+	// The thresholds can't be looser than the thresholds on the contract
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0},
+		SecurityParams: []*pb.QuorumSecurityParams{
+			{QuorumId: 0, AdversaryThresholdPercentage: 20, ConfirmationThresholdPercentage: 80},
+		},
+	})
+	assert.ErrorContains(t, err, "adversary_threshold_percentage of quorum 0 must be at least 33")
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0},
+		SecurityParams: []*pb.QuorumSecurityParams{
+			{QuorumId: 1, AdversaryThresholdPercentage: 40, ConfirmationThresholdPercentage: 80},
+		},
+	})
+	assert.ErrorContains(t, err, "security_params quorum 1 is not among the quorums of the blob")
+
+	// The quorum must have operators to assign the blob to
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{1},
+		SecurityParams: []*pb.QuorumSecurityParams{
+			{QuorumId: 1, AdversaryThresholdPercentage: 40, ConfirmationThresholdPercentage: 80},
+		},
+	})
+	assert.ErrorContains(t, err, "quorum 1 has no operators")
+}
+
+func TestDisperseBlobWithPayloadHash(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		PayloadHash:         crypto.Keccak256([]byte("not the data")),
+	})
+	assert.ErrorContains(t, err, "payload_hash does not match the keccak256 hash of data")
+
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		PayloadHash:         crypto.Keccak256(data),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, reply.GetResult(), pb.BlobStatus_PROCESSING)
+
+	statusReply, err := dispersalServer.GetBlobStatus(context.Background(), &pb.BlobStatusRequest{
+		RequestId: reply.GetRequestId(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, statusReply.GetStatus(), pb.BlobStatus_PROCESSING)
+}
+
+func TestDisperseBlobWithCommitment(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	commitment, err := blobCommitter.ComputeCommitment(data)
+	assert.NoError(t, err)
+	otherCommitment, err := blobCommitter.ComputeCommitment(data[:len(data)-32])
+	assert.NoError(t, err)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		Commitment:          &commonpb.G1Commitment{X: commitment.X.Marshal(), Y: commitment.Y.Marshal()},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, reply.GetResult(), pb.BlobStatus_PROCESSING)
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		Commitment:          &commonpb.G1Commitment{X: otherCommitment.X.Marshal(), Y: otherCommitment.Y.Marshal()},
+	})
+	assert.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+	assert.Equal(t, apiserver.ReasonCommitmentMismatch, api.ErrorReason(err))
+}
+
+func TestDisperseBlobs(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	reply, err := dispersalServer.DisperseBlobs(ctx, &pb.DisperseBlobsRequest{
+		Blobs: []*pb.DisperseBlobRequest{
+			{Data: data, CustomQuorumNumbers: []uint32{0, 1}},
+			{Data: data, CustomQuorumNumbers: []uint32{0}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, reply.GetReplies(), 2)
+
+	groupIDs := make(map[string]struct{})
+	for _, blobReply := range reply.GetReplies() {
+		assert.Equal(t, pb.BlobStatus_PROCESSING, blobReply.GetResult())
+		blobKey, err := disperser.ParseBlobKey(string(blobReply.GetRequestId()))
+		assert.NoError(t, err)
+		metadata, err := queue.GetBlobMetadata(context.Background(), blobKey)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, metadata.RequestMetadata.GroupID)
+		groupIDs[metadata.RequestMetadata.GroupID] = struct{}{}
+	}
+	assert.Len(t, groupIDs, 1)
+
+	// No blob is stored if one of them is invalid
+	_, err = dispersalServer.DisperseBlobs(ctx, &pb.DisperseBlobsRequest{
+		Blobs: []*pb.DisperseBlobRequest{
+			{Data: data, CustomQuorumNumbers: []uint32{0}},
+			{Data: data, CustomQuorumNumbers: []uint32{0, 0}},
+		},
+	})
+	assert.ErrorContains(t, err, "blob 1: custom_quorum_numbers must not contain duplicates")
+
+	_, err = dispersalServer.DisperseBlobs(ctx, &pb.DisperseBlobsRequest{})
+	assert.ErrorContains(t, err, "number of blobs must be in range [1, 16], but found 0")
+}
+
 func TestGetBlobStatus(t *testing.T) {
 	data := make([]byte, 1024)
 	_, err := rand.Read(data)
@@ -258,6 +665,7 @@ func TestGetBlobStatus(t *testing.T) {
 	assert.Equal(t, actualCommitX, confirmedMetadata.ConfirmationInfo.BlobCommitment.Commitment.X.Marshal())
 	assert.Equal(t, actualCommitY, confirmedMetadata.ConfirmationInfo.BlobCommitment.Commitment.Y.Marshal())
 	assert.Equal(t, reply.GetInfo().GetBlobHeader().GetDataLength(), uint32(confirmedMetadata.ConfirmationInfo.BlobCommitment.Length))
+	assert.Equal(t, reply.GetInfo().GetBlobHeader().GetPayloadHash(), crypto.Keccak256(data))
 
 	actualBlobQuorumParams := make([]*pb.BlobQuorumParam, len(securityParams))
 	quorumNumbers := make([]byte, len(securityParams))
@@ -294,6 +702,131 @@ func TestGetBlobStatus(t *testing.T) {
 	assert.Equal(t, reply.GetInfo().GetBlobVerificationProof().GetQuorumIndexes(), quorumIndexes)
 }
 
+func TestGetBlobStatusFinalizedFromCache(t *testing.T) {
+	ctx := context.Background()
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	_, blobSize, requestID := disperseBlob(t, dispersalServer, data)
+	securityParams := []*core.SecurityParam{
+		{
+			QuorumID:              0,
+			AdversaryThreshold:    80,
+			ConfirmationThreshold: 100,
+		},
+	}
+	confirmedMetadata := simulateBlobConfirmation(t, requestID, blobSize, securityParams, 0)
+	metadataKey := confirmedMetadata.GetBlobKey()
+
+	// the status of a confirmed blob is read again from the store, as it changes once its batch is finalized
+	reply, err := dispersalServer.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: requestID})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_CONFIRMED, reply.GetStatus())
+	err = queue.MarkBlobFinalized(ctx, metadataKey)
+	assert.NoError(t, err)
+	reply, err = dispersalServer.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: requestID})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_FINALIZED, reply.GetStatus())
+
+	// the status of a finalized blob is served from the cache, without reading the store
+	err = queue.MarkBlobFailed(ctx, metadataKey)
+	assert.NoError(t, err)
+	reply, err = dispersalServer.GetBlobStatus(ctx, &pb.BlobStatusRequest{RequestId: requestID})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_FINALIZED, reply.GetStatus())
+	assert.Equal(t, confirmedMetadata.ConfirmationInfo.BlobInclusionProof, reply.GetInfo().GetBlobVerificationProof().GetInclusionProof())
+
+	statuses, err := dispersalServer.GetBlobStatuses(ctx, &pb.BlobStatusesRequest{RequestIds: [][]byte{requestID}})
+	assert.NoError(t, err)
+	assert.Len(t, statuses.GetStatuses(), 1)
+	assert.Equal(t, pb.BlobStatus_FINALIZED, statuses.GetStatuses()[0].GetReply().GetStatus())
+}
+
+func TestGetBlobStatuses(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	})
+	accountID := fmt.Sprintf("statuses-%d", time.Now().UnixNano())
+	start := uint64(time.Now().Unix())
+
+	requestIDs := make([][]byte, 3)
+	for i := range requestIDs {
+		data := make([]byte, 1024)
+		_, err := rand.Read(data)
+		assert.NoError(t, err)
+		reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+			Data:                codec.ConvertByPaddingEmptyByte(data),
+			CustomQuorumNumbers: []uint32{0, 1},
+			AccountId:           accountID,
+		})
+		assert.NoError(t, err)
+		requestIDs[i] = reply.GetRequestId()
+	}
+
+	// By request IDs, in the order of the request, with no reply for an unknown blob
+	unknownID := []byte(disperser.BlobKey{BlobHash: "unknown", MetadataHash: "unknown"}.String())
+	reply, err := dispersalServer.GetBlobStatuses(ctx, &pb.BlobStatusesRequest{
+		RequestIds: [][]byte{requestIDs[2], unknownID, requestIDs[0]},
+		Limit:      2,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, reply.GetStatuses(), 2)
+	assert.Equal(t, requestIDs[2], reply.GetStatuses()[0].GetRequestId())
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetStatuses()[0].GetReply().GetStatus())
+	assert.Equal(t, unknownID, reply.GetStatuses()[1].GetRequestId())
+	assert.Nil(t, reply.GetStatuses()[1].GetReply())
+	assert.NotEmpty(t, reply.GetNextPageToken())
+
+	reply, err = dispersalServer.GetBlobStatuses(ctx, &pb.BlobStatusesRequest{
+		RequestIds: [][]byte{requestIDs[2], unknownID, requestIDs[0]},
+		Limit:      2,
+		PageToken:  reply.GetNextPageToken(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, reply.GetStatuses(), 1)
+	assert.Equal(t, requestIDs[0], reply.GetStatuses()[0].GetRequestId())
+	assert.Empty(t, reply.GetNextPageToken())
+
+	// By account, in the order the blobs were dispersed
+	found := make([][]byte, 0)
+	var pageToken []byte
+	for {
+		reply, err = dispersalServer.GetBlobStatuses(ctx, &pb.BlobStatusesRequest{
+			AccountId: accountID,
+			StartTime: start,
+			Limit:     2,
+			PageToken: pageToken,
+		})
+		assert.NoError(t, err)
+		for _, status := range reply.GetStatuses() {
+			found = append(found, status.GetRequestId())
+			assert.Equal(t, pb.BlobStatus_PROCESSING, status.GetReply().GetStatus())
+		}
+		pageToken = reply.GetNextPageToken()
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+	assert.Equal(t, requestIDs, found)
+
+	_, err = dispersalServer.GetBlobStatuses(ctx, &pb.BlobStatusesRequest{
+		RequestIds: requestIDs,
+		AccountId:  accountID,
+	})
+	assert.ErrorContains(t, err, "exactly one of request_ids and account_id must be set")
+	_, err = dispersalServer.GetBlobStatuses(ctx, &pb.BlobStatusesRequest{
+		AccountId: accountID,
+		Limit:     101,
+	})
+	assert.ErrorContains(t, err, "limit must be at most 100")
+}
+
 func TestGetBlobDispersingStatus(t *testing.T) {
 	data := make([]byte, 1024)
 	_, err := rand.Read(data)
@@ -319,6 +852,50 @@ func TestGetBlobDispersingStatus(t *testing.T) {
 	assert.Equal(t, reply.GetStatus(), pb.BlobStatus_PROCESSING)
 }
 
+// blobStatusStream is the server side of a SubscribeBlobStatus stream, recording the updates sent
+type blobStatusStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates []*pb.BlobStatusUpdate
+}
+
+func (s *blobStatusStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *blobStatusStream) Send(update *pb.BlobStatusUpdate) error {
+	s.updates = append(s.updates, update)
+	return nil
+}
+
+func TestSubscribeBlobStatus(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	_, _, requestID := disperseBlob(t, dispersalServer, data)
+	blobKey, err := disperser.ParseBlobKey(string(requestID))
+	assert.NoError(t, err)
+	err = queue.MarkBlobFailed(context.Background(), blobKey)
+	assert.NoError(t, err)
+
+	// The subscription ends with the terminal status, without repeating the last status received
+	stream := &blobStatusStream{ctx: context.Background()}
+	err = dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{
+		RequestId:  requestID,
+		LastStatus: pb.BlobStatus_PROCESSING,
+	}, stream)
+	assert.NoError(t, err)
+	assert.Len(t, stream.updates, 1)
+	assert.Equal(t, pb.BlobStatus_FAILED, stream.updates[0].GetReply().GetStatus())
+
+	stream = &blobStatusStream{ctx: context.Background()}
+	err = dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{}, stream)
+	assert.ErrorContains(t, err, "request_id must not be empty")
+}
+
 func TestRetrieveBlob(t *testing.T) {
 
 	for i := 0; i < 3; i++ {
@@ -372,6 +949,51 @@ func TestRetrieveBlob(t *testing.T) {
 
 }
 
+func TestRetrieveBlobWithProof(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitment, err := blobCommitter.ComputeCommitment(data)
+	assert.NoError(t, err)
+	g2, err := kzg.ReadG2Points("../../inabox/resources/kzg/g2.point", 2, 1)
+	assert.NoError(t, err)
+
+	securityParams := []*core.SecurityParam{
+		{
+			QuorumID:              0,
+			AdversaryThreshold:    80,
+			ConfirmationThreshold: 100,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+
+	// The proof of a blob confirmed with its commitment verifies against the data
+	_, blobSize, requestID := disperseBlob(t, dispersalServer, data)
+	_ = simulateBlobConfirmationWithCommitment(t, requestID, blobSize, securityParams, 3, commitment)
+	reply, err := dispersalServer.RetrieveBlobWithProof(ctx, &pb.RetrieveBlobRequest{
+		BatchHeaderHash: crypto.Keccak256(requestID),
+		BlobIndex:       3,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, data, reply.GetData())
+	assert.Equal(t, commitment.X.Marshal(), reply.GetInfo().GetBlobHeader().GetCommitment().GetX())
+	var proof bn254.G1Affine
+	_, err = proof.SetBytes(reply.GetProof())
+	assert.NoError(t, err)
+	assert.NoError(t, eip4844.VerifyBlobProof(&g2[1], reply.GetData(), (*bn254.G1Affine)(commitment), &proof))
+
+	// A blob which doesn't match its confirmed commitment isn't served
+	_, blobSize, requestID = disperseBlob(t, dispersalServer, data)
+	_ = simulateBlobConfirmation(t, requestID, blobSize, securityParams, 4)
+	_, err = dispersalServer.RetrieveBlobWithProof(ctx, &pb.RetrieveBlobRequest{
+		BatchHeaderHash: crypto.Keccak256(requestID),
+		BlobIndex:       4,
+	})
+	assert.Equal(t, codes.Internal, grpcstatus.Code(err))
+}
+
 func TestRetrieveBlobFailsWhenBlobNotConfirmed(t *testing.T) {
 	// Create random data
 	data := make([]byte, 1024)
@@ -421,6 +1043,46 @@ func TestDisperseBlobWithExceedSizeLimit(t *testing.T) {
 	assert.Equal(t, err.Error(), "rpc error: code = InvalidArgument desc = blob size cannot exceed 2 MiB")
 }
 
+func TestDisperseBlobWithQuorumLimits(t *testing.T) {
+	transactor := &mock.MockTransactor{}
+	transactor.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	transactor.On("GetQuorumCount").Return(uint8(2), nil)
+	quorumParams := []core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+	}
+	transactor.On("GetQuorumSecurityParams", tmock.Anything).Return(quorumParams, nil)
+	transactor.On("GetRequiredQuorumNumbers", tmock.Anything).Return([]uint8{}, nil)
+	limitsServer := newTestServer(transactor)
+
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+
+	// The limits above the hard limit of the blob size are rejected
+	err = limits.SetOverrides(map[core.QuorumID]apiserver.QuorumLimits{1: {MaxBlobSize: 4 * 1024 * 1024}})
+	assert.Error(t, err)
+
+	// Lowering the max blob size of quorum 1 takes effect without restarting the server
+	err = limits.SetOverrides(map[core.QuorumID]apiserver.QuorumLimits{1: {MaxBlobSize: 512}})
+	assert.NoError(t, err)
+	_, err = limitsServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{Data: data, CustomQuorumNumbers: []uint32{0, 1}})
+	assert.Error(t, err)
+	assert.Equal(t, "rpc error: code = InvalidArgument desc = blob size cannot exceed 512 bytes for quorum 1", err.Error())
+
+	// The limit only applies to the blobs dispersed to the quorum
+	_, err = limitsServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{Data: data, CustomQuorumNumbers: []uint32{0}})
+	assert.NoError(t, err)
+
+	err = limits.SetOverrides(map[core.QuorumID]apiserver.QuorumLimits{})
+	assert.NoError(t, err)
+	_, err = limitsServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{Data: data, CustomQuorumNumbers: []uint32{0, 1}})
+	assert.NoError(t, err)
+}
+
 func TestParseAllowlist(t *testing.T) {
 	fs := flag.NewFlagSet("disperser", flag.ContinueOnError)
 	allowlistFlag := apiserver.AllowlistFlag("disperser")
@@ -498,6 +1160,62 @@ func TestParseAllowlist(t *testing.T) {
 	assert.Equal(t, rateConfig.Allowlist["5.5.5.5"][1].Throughput, uint32(4092))
 }
 
+func TestDrain(t *testing.T) {
+	transactor := &mock.MockTransactor{}
+	transactor.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	transactor.On("GetQuorumCount").Return(uint8(2), nil)
+	quorumParams := []core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+	}
+	transactor.On("GetQuorumSecurityParams", tmock.Anything).Return(quorumParams, nil)
+	transactor.On("GetRequiredQuorumNumbers", tmock.Anything).Return([]uint8{}, nil)
+	drainServer := newTestServer(transactor)
+
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	status, _, _ := disperseBlob(t, drainServer, data)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, status)
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- drainServer.Drain(context.Background())
+	}()
+
+	// New blobs are rejected once the server is draining
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	assert.Eventually(t, func() bool {
+		_, err := drainServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{Data: data, CustomQuorumNumbers: []uint32{0, 1}})
+		return grpcstatus.Code(err) == codes.Unavailable
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The drain waits for the queued blobs
+	select {
+	case <-drained:
+		t.Fatal("drain completed with queued blobs")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Flush the queued blobs
+	for _, blobStatus := range []disperser.BlobStatus{disperser.Processing, disperser.Dispersing} {
+		metadatas, err := queue.GetBlobMetadataByStatus(context.Background(), blobStatus)
+		assert.NoError(t, err)
+		for _, metadata := range metadatas {
+			assert.NoError(t, queue.MarkBlobFailed(context.Background(), metadata.GetBlobKey()))
+		}
+	}
+
+	select {
+	case err := <-drained:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("drain didn't complete after the queued blobs were flushed")
+	}
+}
+
 func setup(m *testing.M) {
 
 	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
@@ -615,11 +1333,22 @@ func newTestServer(transactor core.Transactor) *apiserver.DispersalServer {
 	}
 
 	queue = blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	limits = apiserver.NewDynamicLimits(nil, 0, logger)
+	blobCommitter, err = committer.NewCommitter(committer.Config{
+		G1Path:        "../../inabox/resources/kzg/g1.point",
+		SRSOrder:      3000,
+		MaxBlobLength: 3000,
+	})
+	if err != nil {
+		panic("failed to create committer")
+	}
 
 	return apiserver.NewDispersalServer(disperser.ServerConfig{
-		GrpcPort:    "51001",
-		GrpcTimeout: 1 * time.Second,
-	}, queue, transactor, logger, disperser.NewMetrics("9001", logger), ratelimiter, rateConfig)
+		GrpcPort:              "51001",
+		GrpcTimeout:           1 * time.Second,
+		MaxBlobPriority:       1,
+		MinAttestationTimeout: time.Second,
+	}, queue, transactor, logger, disperser.NewMetrics("9001", logger), ratelimiter, rateConfig, limits, nil, blobCommitter, nil)
 }
 
 func disperseBlob(t *testing.T, server *apiserver.DispersalServer, data []byte) (pb.BlobStatus, uint, []byte) {
@@ -661,6 +1390,21 @@ func retrieveBlob(server *apiserver.DispersalServer, requestID []byte, blobIndex
 }
 
 func simulateBlobConfirmation(t *testing.T, requestID []byte, blobSize uint, securityParams []*core.SecurityParam, blobIndex uint32) *disperser.BlobMetadata {
+	var commitX, commitY fp.Element
+	_, err := commitX.SetString("21661178944771197726808973281966770251114553549453983978976194544185382599016")
+	assert.NoError(t, err)
+
+	_, err = commitY.SetString("9207254729396071334325696286939045899948985698134704137261649190717970615186")
+	assert.NoError(t, err)
+
+	commitment := &encoding.G1Commitment{
+		X: commitX,
+		Y: commitY,
+	}
+	return simulateBlobConfirmationWithCommitment(t, requestID, blobSize, securityParams, blobIndex, commitment)
+}
+
+func simulateBlobConfirmationWithCommitment(t *testing.T, requestID []byte, blobSize uint, securityParams []*core.SecurityParam, blobIndex uint32, commitment *encoding.G1Commitment) *disperser.BlobMetadata {
 	ctx := context.Background()
 
 	metadataKey, err := disperser.ParseBlobKey(string(requestID))
@@ -670,21 +1414,13 @@ func simulateBlobConfirmation(t *testing.T, requestID []byte, blobSize uint, sec
 	err = queue.MarkBlobProcessing(ctx, metadataKey)
 	assert.NoError(t, err)
 
+	storedMetadata, err := queue.GetBlobMetadata(ctx, metadataKey)
+	assert.NoError(t, err)
+
 	// simulate blob confirmation
 	batchHeaderHash := crypto.Keccak256Hash(requestID)
 
 	requestedAt := uint64(time.Now().Nanosecond())
-	var commitX, commitY fp.Element
-	_, err = commitX.SetString("21661178944771197726808973281966770251114553549453983978976194544185382599016")
-	assert.NoError(t, err)
-
-	_, err = commitY.SetString("9207254729396071334325696286939045899948985698134704137261649190717970615186")
-	assert.NoError(t, err)
-
-	commitment := &encoding.G1Commitment{
-		X: commitX,
-		Y: commitY,
-	}
 	dataLength := 32
 	batchID := uint32(99)
 	batchRoot := []byte("hello")
@@ -736,6 +1472,7 @@ func simulateBlobConfirmation(t *testing.T, requestID []byte, blobSize uint, sec
 			},
 			RequestedAt: requestedAt,
 			BlobSize:    blobSize,
+			PayloadHash: storedMetadata.RequestMetadata.PayloadHash,
 		},
 	}
 	updated, err := queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo)
@@ -0,0 +1,124 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+)
+
+// ProtocolVersion is the highest version of the disperser API served by the server
+const ProtocolVersion = 2
+
+// supportedProtocolVersions are the versions of the disperser API served by the server. The v1 API is the Disperser
+// service of api/proto/disperser, and the v2 API the Disperser service of api/proto/disperser/v2.
+var supportedProtocolVersions = []uint32{1, ProtocolVersion}
+
+// DispersalServerV2 serves the v2 disperser API. Its RPCs are served by the v1 server, except for GetCapabilities.
+type DispersalServerV2 struct {
+	pbv2.UnimplementedDisperserServer
+
+	server *DispersalServer
+}
+
+// NewDispersalServerV2 creates the server of the v2 disperser API on top of the v1 server.
+func NewDispersalServerV2(server *DispersalServer) *DispersalServerV2 {
+	return &DispersalServerV2{server: server}
+}
+
+// GetCapabilities negotiates the highest protocol version supported by both the client and the server, and returns
+// the capabilities of the server for the client. The max blob size is the one of the blobs the client can disperse:
+// it's lowered by the limits of the required quorums, which every blob is dispersed to, and of the tenant of the API
+// key of the request. The auth modes and streaming follow the server config.
+func (s *DispersalServerV2) GetCapabilities(ctx context.Context, req *pbv2.GetCapabilitiesRequest) (*pbv2.GetCapabilitiesReply, error) {
+	if req.GetProtocolVersion() < supportedProtocolVersions[0] {
+		s.server.metrics.HandleInvalidArgRpcRequest("GetCapabilities")
+		return nil, api.NewInvalidArgError(fmt.Sprintf("protocol_version must be at least %d, got %d", supportedProtocolVersions[0], req.GetProtocolVersion()))
+	}
+
+	tenant, err := s.server.tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quorumConfig, err := s.server.updateQuorumConfig(ctx)
+	if err != nil {
+		s.server.metrics.HandleInternalFailureRpcRequest("GetCapabilities")
+		return nil, api.NewInternalError(fmt.Sprintf("failed to get quorum config: %v", err))
+	}
+	quorumIDs := make([]uint32, quorumConfig.QuorumCount)
+	for i := range quorumIDs {
+		quorumIDs[i] = uint32(i)
+	}
+	blobSizeLimit := uint(maxBlobSize)
+	requiredQuorumIDs := make([]uint32, 0, len(quorumConfig.RequiredQuorums))
+	for _, quorumID := range quorumConfig.RequiredQuorums {
+		// Only the quorum 0 is required if dual quorum staking isn't enabled, see validateRequestAndGetBlob
+		if s.server.serverConfig.EnableDualQuorums || quorumID == 0 {
+			requiredQuorumIDs = append(requiredQuorumIDs, uint32(quorumID))
+			if limit := s.server.limits.Get(quorumID).MaxBlobSize; limit > 0 {
+				blobSizeLimit = min(blobSizeLimit, limit)
+			}
+		}
+	}
+	if tenant != nil && tenant.MaxBlobSize > 0 {
+		blobSizeLimit = min(blobSizeLimit, tenant.MaxBlobSize)
+	}
+
+	authModes := make([]pbv2.AuthMode, 0, 3)
+	if !s.server.serverConfig.RequireAuthentication {
+		authModes = append(authModes, pbv2.AuthMode_AUTH_MODE_NONE)
+	}
+	authModes = append(authModes, pbv2.AuthMode_AUTH_MODE_CHALLENGE, pbv2.AuthMode_AUTH_MODE_SIGNED_REQUEST)
+
+	s.server.metrics.HandleSuccessfulRpcRequest("GetCapabilities")
+	return &pbv2.GetCapabilitiesReply{
+		ProtocolVersion:           min(req.GetProtocolVersion(), ProtocolVersion),
+		SupportedProtocolVersions: supportedProtocolVersions,
+		MaxBlobSize:               uint32(blobSizeLimit),
+		MaxBlobsPerRequest:        maxBlobsPerRequest,
+		QuorumIds:                 quorumIDs,
+		RequiredQuorumIds:         requiredQuorumIDs,
+		AuthModes:                 authModes,
+		Streaming:                 !s.server.serverConfig.DisableStreaming,
+		BuildInfo:                 s.server.serverConfig.BuildInfo.ToProtobuf(),
+	}, nil
+}
+
+func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
+	return s.server.DisperseBlob(ctx, req)
+}
+
+func (s *DispersalServerV2) DisperseBlobAuthenticated(stream pbv2.Disperser_DisperseBlobAuthenticatedServer) error {
+	return s.server.DisperseBlobAuthenticated(stream)
+}
+
+func (s *DispersalServerV2) DisperseBlobStream(stream pbv2.Disperser_DisperseBlobStreamServer) error {
+	return s.server.DisperseBlobStream(stream)
+}
+
+func (s *DispersalServerV2) DisperseBlobs(ctx context.Context, req *pb.DisperseBlobsRequest) (*pb.DisperseBlobsReply, error) {
+	return s.server.DisperseBlobs(ctx, req)
+}
+
+func (s *DispersalServerV2) GetBlobStatus(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, error) {
+	return s.server.GetBlobStatus(ctx, req)
+}
+
+func (s *DispersalServerV2) GetBlobStatuses(ctx context.Context, req *pb.BlobStatusesRequest) (*pb.BlobStatusesReply, error) {
+	return s.server.GetBlobStatuses(ctx, req)
+}
+
+func (s *DispersalServerV2) SubscribeBlobStatus(req *pb.SubscribeBlobStatusRequest, stream pbv2.Disperser_SubscribeBlobStatusServer) error {
+	return s.server.SubscribeBlobStatus(req, stream)
+}
+
+func (s *DispersalServerV2) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlobRequest) (*pb.RetrieveBlobReply, error) {
+	return s.server.RetrieveBlob(ctx, req)
+}
+
+func (s *DispersalServerV2) RetrieveBlobWithProof(ctx context.Context, req *pb.RetrieveBlobRequest) (*pb.RetrieveBlobWithProofReply, error) {
+	return s.server.RetrieveBlobWithProof(ctx, req)
+}
@@ -0,0 +1,114 @@
+package apiserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	transactor := &mock.MockTransactor{}
+	transactor.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	transactor.On("GetQuorumCount").Return(uint8(3), nil)
+	transactor.On("GetQuorumSecurityParams", tmock.Anything).Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		{QuorumID: 2, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+	}, nil)
+	transactor.On("GetRequiredQuorumNumbers", tmock.Anything).Return([]uint8{0, 1}, nil)
+	server := apiserver.NewDispersalServerV2(newTestServer(transactor))
+
+	reply, err := server.GetCapabilities(context.Background(), &pbv2.GetCapabilitiesRequest{ProtocolVersion: 3})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(apiserver.ProtocolVersion), reply.GetProtocolVersion())
+	assert.Equal(t, []uint32{1, 2}, reply.GetSupportedProtocolVersions())
+	assert.Equal(t, []uint32{0, 1, 2}, reply.GetQuorumIds())
+	// Dual quorum staking isn't enabled, so only the quorum 0 is required
+	assert.Equal(t, []uint32{0}, reply.GetRequiredQuorumIds())
+	assert.Equal(t, uint32(2*1024*1024), reply.GetMaxBlobSize())
+	assert.True(t, reply.GetStreaming())
+	assert.Equal(t, []pbv2.AuthMode{
+		pbv2.AuthMode_AUTH_MODE_NONE,
+		pbv2.AuthMode_AUTH_MODE_CHALLENGE,
+		pbv2.AuthMode_AUTH_MODE_SIGNED_REQUEST,
+	}, reply.GetAuthModes())
+	assert.NotNil(t, reply.GetBuildInfo())
+
+	// An older client negotiates its own version
+	reply, err = server.GetCapabilities(context.Background(), &pbv2.GetCapabilitiesRequest{ProtocolVersion: 1})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), reply.GetProtocolVersion())
+
+	_, err = server.GetCapabilities(context.Background(), &pbv2.GetCapabilitiesRequest{})
+	assert.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+}
+
+func TestGetCapabilitiesFromConfig(t *testing.T) {
+	transactor := &mock.MockTransactor{}
+	transactor.On("GetCurrentBlockNumber").Return(uint32(100), nil)
+	transactor.On("GetQuorumCount").Return(uint8(2), nil)
+	transactor.On("GetQuorumSecurityParams", tmock.Anything).Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+	}, nil)
+	transactor.On("GetRequiredQuorumNumbers", tmock.Anything).Return([]uint8{0, 1}, nil)
+
+	logger := logging.NewNoopLogger()
+	limits := apiserver.NewDynamicLimits(nil, 0, logger)
+	tenants, err := apiserver.NewTenants([]*apiserver.Tenant{
+		{ID: "small", APIKeys: []string{"key-small"}, MaxBlobSize: 1024},
+	}, logger)
+	require.NoError(t, err)
+	v1 := apiserver.NewDispersalServer(disperser.ServerConfig{
+		GrpcTimeout:           time.Second,
+		EnableDualQuorums:     true,
+		RequireAuthentication: true,
+		DisableStreaming:      true,
+	}, inmem.NewBlobStore(), transactor, logger, disperser.NewMetrics("9101", logger), nil, apiserver.RateConfig{}, limits, tenants, nil, nil)
+	server := apiserver.NewDispersalServerV2(v1)
+	req := &pbv2.GetCapabilitiesRequest{ProtocolVersion: apiserver.ProtocolVersion}
+
+	reply, err := server.GetCapabilities(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{0, 1}, reply.GetRequiredQuorumIds())
+	assert.Equal(t, uint32(2*1024*1024), reply.GetMaxBlobSize())
+	assert.Equal(t, []pbv2.AuthMode{pbv2.AuthMode_AUTH_MODE_CHALLENGE, pbv2.AuthMode_AUTH_MODE_SIGNED_REQUEST}, reply.GetAuthModes())
+	assert.False(t, reply.GetStreaming())
+
+	// The limits of the required quorums lower the max blob size, but not the ones of the other quorums
+	require.NoError(t, limits.SetOverrides(map[core.QuorumID]apiserver.QuorumLimits{1: {MaxBlobSize: 4096}, 2: {MaxBlobSize: 512}}))
+	reply, err = server.GetCapabilities(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(4096), reply.GetMaxBlobSize())
+
+	// So does the limit of the tenant of the API key
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiserver.APIKeyHeader, "key-small"))
+	reply, err = server.GetCapabilities(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1024), reply.GetMaxBlobSize())
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiserver.APIKeyHeader, "unknown"))
+	_, err = server.GetCapabilities(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, grpcstatus.Code(err))
+
+	// The config is enforced by the RPCs
+	_, err = v1.DisperseBlob(context.Background(), &pb.DisperseBlobRequest{Data: make([]byte, 32)})
+	assert.Equal(t, codes.Unauthenticated, grpcstatus.Code(err))
+	err = v1.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{RequestId: []byte("id")}, nil)
+	assert.Equal(t, codes.Unimplemented, grpcstatus.Code(err))
+}
@@ -0,0 +1,176 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"google.golang.org/grpc/codes"
+)
+
+// defaultReplayWindow is how far ahead of the current time the expiry of a signed request can be, and how long the
+// challenges of the authenticated dispersals are remembered, if the server config doesn't set it
+const defaultReplayWindow = 10 * time.Minute
+
+// The reasons the replayed requests are rejected for, in the metrics
+const (
+	replayReasonReusedNonce     = "reused_nonce"
+	replayReasonExpired         = "expired"
+	replayReasonReusedChallenge = "reused_challenge"
+)
+
+// errNonceStore is wrapped by the errors of the nonce store, which don't tell anything about the request
+var errNonceStore = errors.New("nonce store failure")
+
+// requireAuthentication rejects a dispersal which wasn't authenticated if the server config requires authentication
+func (s *DispersalServer) requireAuthentication(authenticatedAddress string, method string) error {
+	if authenticatedAddress != "" || !s.serverConfig.RequireAuthentication {
+		return nil
+	}
+	s.metrics.HandleInvalidArgRpcRequest(method)
+	s.metrics.HandleInvalidArgRequest(method)
+	return api.NewGRPCError(codes.Unauthenticated, "the request must be signed, or dispersed with DisperseBlobAuthenticated")
+}
+
+// acceptedNonces remembers the nonces of the signed requests accepted from each account until the requests expire,
+// so that a signed request can't be replayed.
+type acceptedNonces struct {
+	mu        sync.Mutex
+	window    time.Duration
+	expiries  map[string]map[uint64]time.Time
+	lastPrune time.Time
+}
+
+// newAcceptedNonces returns the nonces of the requests expiring at most window ahead
+func newAcceptedNonces(window time.Duration) *acceptedNonces {
+	return &acceptedNonces{
+		window:    window,
+		expiries:  make(map[string]map[uint64]time.Time),
+		lastPrune: time.Now(),
+	}
+}
+
+// add records the nonce of a request of the account expiring at expiry. It returns false if the nonce was already
+// accepted from the account.
+func (a *acceptedNonces) add(account string, nonce uint64, expiry time.Time, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if now.Sub(a.lastPrune) > a.window {
+		for acc, nonces := range a.expiries {
+			for n, exp := range nonces {
+				if !exp.After(now) {
+					delete(nonces, n)
+				}
+			}
+			if len(nonces) == 0 {
+				delete(a.expiries, acc)
+			}
+		}
+		a.lastPrune = now
+	}
+
+	nonces, ok := a.expiries[account]
+	if !ok {
+		nonces = make(map[uint64]time.Time)
+		a.expiries[account] = nonces
+	}
+	if exp, ok := nonces[nonce]; ok && exp.After(now) {
+		return false
+	}
+	nonces[nonce] = expiry
+	return true
+}
+
+// contains returns whether the nonce was accepted from the account and hasn't expired
+func (a *acceptedNonces) contains(account string, nonce uint64, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	exp, ok := a.expiries[account][nonce]
+	return ok && exp.After(now)
+}
+
+// challengeAccount is the account the challenges answered by an account are recorded under in the nonce store,
+// apart from the nonces of its signed requests
+func challengeAccount(account string) string {
+	return "challenge:" + account
+}
+
+// newAuthChallenge returns a random challenge for an authenticated dispersal of the account, which wasn't sent to
+// the account within the replay window, so that a leaked signature of a challenge can't be replayed
+func (s *DispersalServer) newAuthChallenge(ctx context.Context, account string) (uint32, error) {
+	buf := make([]byte, 4)
+	for {
+		if _, err := rand.Read(buf); err != nil {
+			return 0, err
+		}
+		challenge := binary.BigEndian.Uint32(buf)
+		answered, err := s.nonces.Contains(ctx, challengeAccount(account), uint64(challenge), time.Now())
+		if err != nil {
+			return 0, fmt.Errorf("%w: failed to read the answered challenges: %v", errNonceStore, err)
+		}
+		if !answered {
+			return challenge, nil
+		}
+	}
+}
+
+// acceptAuthChallenge checks the challenge sent at issuedAt was answered in time, and remembers it for the replay
+// window. It fails if the challenge was already answered by the account, which concurrent dispersals can race for.
+func (s *DispersalServer) acceptAuthChallenge(ctx context.Context, account string, challenge uint32, issuedAt time.Time, method string) error {
+	now := time.Now()
+	if timeout := s.serverConfig.AuthChallengeTimeout; timeout > 0 && now.Sub(issuedAt) > timeout {
+		s.metrics.HandleReplayedRequest(replayReasonExpired, method)
+		return fmt.Errorf("the challenge wasn't answered within %v", timeout)
+	}
+	added, err := s.nonces.Add(ctx, challengeAccount(account), uint64(challenge), now.Add(s.serverConfig.ReplayWindow), now)
+	if err != nil {
+		return fmt.Errorf("%w: failed to record the answered challenge: %v", errNonceStore, err)
+	}
+	if !added {
+		s.metrics.HandleReplayedRequest(replayReasonReusedChallenge, method)
+		return fmt.Errorf("challenge %d was already answered by account %s", challenge, account)
+	}
+	return nil
+}
+
+// authenticateSignedRequest verifies the signature of a signed dispersal request, and returns the registered account
+// the request is attributed to.
+func (s *DispersalServer) authenticateSignedRequest(ctx context.Context, req *pb.DisperseBlobRequest, method string) (string, error) {
+	now := time.Now()
+	expiry := time.Unix(int64(req.GetExpiry()), 0)
+	if !expiry.After(now) {
+		s.metrics.HandleReplayedRequest(replayReasonExpired, method)
+		return "", fmt.Errorf("the signed request expired at %v", expiry.UTC())
+	}
+	if expiry.After(now.Add(s.serverConfig.ReplayWindow)) {
+		return "", fmt.Errorf("the expiry of a signed request must be at most %v ahead", s.serverConfig.ReplayWindow)
+	}
+
+	account, err := auth.VerifyRequestSignature(req.GetAccountId(), disperser.ComputePayloadHash(req.GetData()), req.GetNonce(), req.GetExpiry(), req.GetSignature())
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate the signed request: %w", err)
+	}
+	if _, ok := s.rateConfig.Allowlist[account]; !ok {
+		return "", fmt.Errorf("account %s is not registered", account)
+	}
+
+	added, err := s.nonces.Add(ctx, account, req.GetNonce(), expiry, now)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to record the nonce of the signed request: %v", errNonceStore, err)
+	}
+	if !added {
+		s.metrics.HandleReplayedRequest(replayReasonReusedNonce, method)
+		return "", fmt.Errorf("nonce %d was already used by account %s", req.GetNonce(), account)
+	}
+	return account, nil
+}
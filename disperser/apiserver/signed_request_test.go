@@ -0,0 +1,202 @@
+package apiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReplayTestServer(config disperser.ServerConfig, rateConfig RateConfig) *DispersalServer {
+	return newReplayTestServerWithNonces(config, rateConfig, nil)
+}
+
+func newReplayTestServerWithNonces(config disperser.ServerConfig, rateConfig RateConfig, nonces NonceStore) *DispersalServer {
+	logger := logging.NewNoopLogger()
+	return NewDispersalServer(config, inmem.NewBlobStore(), nil, logger, disperser.NewMetrics("9100", logger), nil, rateConfig, nil, nil, nil, nonces)
+}
+
+func TestAcceptedNonces(t *testing.T) {
+	now := time.Now()
+	nonces := newAcceptedNonces(time.Minute)
+	assert.True(t, nonces.add("account", 1, now.Add(time.Minute), now))
+	assert.False(t, nonces.add("account", 1, now.Add(time.Minute), now))
+	assert.True(t, nonces.add("other", 1, now.Add(time.Minute), now))
+	assert.True(t, nonces.contains("account", 1, now))
+	assert.False(t, nonces.contains("account", 2, now))
+
+	// The expired nonces can be used again, and are pruned after the window
+	later := now.Add(2 * time.Minute)
+	assert.False(t, nonces.contains("account", 1, later))
+	assert.True(t, nonces.add("account", 1, later.Add(time.Minute), later))
+	assert.NotContains(t, nonces.expiries, "other")
+}
+
+func TestRedisNonceStore(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	nonces := NewRedisNonceStore(redis.NewClient(&redis.Options{Addr: server.Addr()}), "{replay}:")
+
+	now := time.Now()
+	added, err := nonces.Add(ctx, "account", 1, now.Add(time.Minute), now)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	added, err = nonces.Add(ctx, "account", 1, now.Add(time.Minute), now)
+	assert.NoError(t, err)
+	assert.False(t, added)
+	added, err = nonces.Add(ctx, "other", 1, now.Add(time.Minute), now)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	contains, err := nonces.Contains(ctx, "account", 1, now)
+	assert.NoError(t, err)
+	assert.True(t, contains)
+	contains, err = nonces.Contains(ctx, "account", 2, now)
+	assert.NoError(t, err)
+	assert.False(t, contains)
+
+	// The nonces are forgotten once they expire
+	assert.Equal(t, time.Minute, server.TTL("{replay}:account:1"))
+	server.FastForward(time.Minute)
+	contains, err = nonces.Contains(ctx, "account", 1, now)
+	assert.NoError(t, err)
+	assert.False(t, contains)
+	added, err = nonces.Add(ctx, "account", 1, now.Add(time.Minute), now)
+	assert.NoError(t, err)
+	assert.True(t, added)
+
+	// A failing redis fails the requests
+	server.Close()
+	_, err = nonces.Add(ctx, "account", 2, now.Add(time.Minute), now)
+	assert.Error(t, err)
+}
+
+func TestSignedRequestReplayAcrossServers(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey).String()
+	rateConfig := RateConfig{
+		Allowlist: map[string]map[core.QuorumID]PerUserRateInfo{address: {}},
+	}
+	redisServer := miniredis.RunT(t)
+	nonces := NewRedisNonceStore(redis.NewClient(&redis.Options{Addr: redisServer.Addr()}), "{replay}:")
+	servers := []*DispersalServer{
+		newReplayTestServerWithNonces(disperser.ServerConfig{ReplayWindow: time.Minute}, rateConfig, nonces),
+		newReplayTestServerWithNonces(disperser.ServerConfig{ReplayWindow: time.Minute}, rateConfig, nonces),
+	}
+
+	// A signed request accepted by a server is rejected by the other one
+	data := []byte{1, 2, 3}
+	expiry := time.Now().Add(30 * time.Second)
+	hash := auth.RequestHash(disperser.ComputePayloadHash(data), 7, uint64(expiry.Unix()))
+	signature, err := crypto.Sign(hash[:], key)
+	assert.NoError(t, err)
+	request := &pb.DisperseBlobRequest{
+		Data:      data,
+		AccountId: hexutil.Encode(crypto.FromECDSAPub(&key.PublicKey)),
+		Nonce:     7,
+		Expiry:    uint64(expiry.Unix()),
+		Signature: signature,
+	}
+	_, err = servers[0].authenticateSignedRequest(context.Background(), request, "DisperseBlob")
+	assert.NoError(t, err)
+	_, err = servers[1].authenticateSignedRequest(context.Background(), request, "DisperseBlob")
+	assert.ErrorContains(t, err, "nonce 7 was already used")
+
+	// So is a challenge answered on the other server
+	assert.NoError(t, servers[0].acceptAuthChallenge(context.Background(), "account", 7, time.Now(), "DisperseBlobAuthenticated"))
+	err = servers[1].acceptAuthChallenge(context.Background(), "account", 7, time.Now(), "DisperseBlobAuthenticated")
+	assert.ErrorContains(t, err, "was already answered")
+
+	// The nonces and the challenges of an account don't collide
+	request.Nonce = 8
+	hash = auth.RequestHash(disperser.ComputePayloadHash(data), 8, uint64(expiry.Unix()))
+	request.Signature, err = crypto.Sign(hash[:], key)
+	assert.NoError(t, err)
+	assert.NoError(t, servers[1].acceptAuthChallenge(context.Background(), address, 8, time.Now(), "DisperseBlobAuthenticated"))
+	_, err = servers[0].authenticateSignedRequest(context.Background(), request, "DisperseBlob")
+	assert.NoError(t, err)
+
+	// The requests fail without being attributed to the client if redis fails
+	redisServer.Close()
+	request.Nonce = 9
+	hash = auth.RequestHash(disperser.ComputePayloadHash(data), 9, uint64(expiry.Unix()))
+	request.Signature, err = crypto.Sign(hash[:], key)
+	assert.NoError(t, err)
+	_, err = servers[0].authenticateSignedRequest(context.Background(), request, "DisperseBlob")
+	assert.ErrorIs(t, err, errNonceStore)
+}
+
+func TestSignedRequestReplay(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey).String()
+	server := newReplayTestServer(disperser.ServerConfig{ReplayWindow: time.Minute}, RateConfig{
+		Allowlist: map[string]map[core.QuorumID]PerUserRateInfo{address: {}},
+	})
+
+	sign := func(data []byte, nonce uint64, expiry time.Time) *pb.DisperseBlobRequest {
+		hash := auth.RequestHash(disperser.ComputePayloadHash(data), nonce, uint64(expiry.Unix()))
+		signature, err := crypto.Sign(hash[:], key)
+		assert.NoError(t, err)
+		return &pb.DisperseBlobRequest{
+			Data:      data,
+			AccountId: hexutil.Encode(crypto.FromECDSAPub(&key.PublicKey)),
+			Nonce:     nonce,
+			Expiry:    uint64(expiry.Unix()),
+			Signature: signature,
+		}
+	}
+
+	request := sign([]byte{1, 2, 3}, 7, time.Now().Add(30*time.Second))
+	account, err := server.authenticateSignedRequest(context.Background(), request, "DisperseBlob")
+	assert.NoError(t, err)
+	assert.Equal(t, address, account)
+
+	_, err = server.authenticateSignedRequest(context.Background(), request, "DisperseBlob")
+	assert.ErrorContains(t, err, "nonce 7 was already used")
+	assert.Equal(t, 1.0, testutil.ToFloat64(server.metrics.ReplayedRequests.WithLabelValues(replayReasonReusedNonce, "DisperseBlob")))
+
+	_, err = server.authenticateSignedRequest(context.Background(), sign([]byte{1, 2, 3}, 8, time.Now().Add(-time.Second)), "DisperseBlob")
+	assert.ErrorContains(t, err, "expired")
+	assert.Equal(t, 1.0, testutil.ToFloat64(server.metrics.ReplayedRequests.WithLabelValues(replayReasonExpired, "DisperseBlob")))
+
+	// The expiry can't be further ahead than the replay window, past which the nonce would be forgotten
+	_, err = server.authenticateSignedRequest(context.Background(), sign([]byte{1, 2, 3}, 9, time.Now().Add(2*time.Minute)), "DisperseBlob")
+	assert.ErrorContains(t, err, "must be at most 1m0s ahead")
+}
+
+func TestAuthChallengeReplay(t *testing.T) {
+	server := newReplayTestServer(disperser.ServerConfig{AuthChallengeTimeout: time.Second}, RateConfig{})
+	assert.Equal(t, defaultReplayWindow, server.serverConfig.ReplayWindow)
+
+	challenge, err := server.newAuthChallenge(context.Background(), "account")
+	assert.NoError(t, err)
+	assert.NoError(t, server.acceptAuthChallenge(context.Background(), "account", challenge, time.Now(), "DisperseBlobAuthenticated"))
+	answered, err := server.nonces.Contains(context.Background(), challengeAccount("account"), uint64(challenge), time.Now())
+	assert.NoError(t, err)
+	assert.True(t, answered)
+
+	// A challenge answered by the account can't be answered again within the replay window
+	err = server.acceptAuthChallenge(context.Background(), "account", challenge, time.Now(), "DisperseBlobAuthenticated")
+	assert.ErrorContains(t, err, "was already answered")
+	assert.Equal(t, 1.0, testutil.ToFloat64(server.metrics.ReplayedRequests.WithLabelValues(replayReasonReusedChallenge, "DisperseBlobAuthenticated")))
+	assert.NoError(t, server.acceptAuthChallenge(context.Background(), "other", challenge, time.Now(), "DisperseBlobAuthenticated"))
+
+	challenge, err = server.newAuthChallenge(context.Background(), "account")
+	assert.NoError(t, err)
+	err = server.acceptAuthChallenge(context.Background(), "account", challenge, time.Now().Add(-2*time.Second), "DisperseBlobAuthenticated")
+	assert.ErrorContains(t, err, "wasn't answered within 1s")
+	assert.Equal(t, 1.0, testutil.ToFloat64(server.metrics.ReplayedRequests.WithLabelValues(replayReasonExpired, "DisperseBlobAuthenticated")))
+}
@@ -0,0 +1,277 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// APIKeyHeader is the gRPC metadata key, and HTTP header, carrying the API key which identifies the tenant of a
+	// request. The requests without an API key belong to the default tenant.
+	APIKeyHeader = "x-api-key"
+
+	// defaultTenantLabel is the tenant label of the metrics of the default tenant
+	defaultTenantLabel = "default"
+	// maxTenantIDLength is the length of the longest tenant ID
+	maxTenantIDLength = 64
+)
+
+// Tenant is a customer of the disperser, identified by its API keys. The blobs dispersed by a tenant are only visible
+// to the requests of the same tenant, and are rate limited and metered separately from the blobs of the other tenants.
+// A zero quota leaves the corresponding limit of the server in place.
+type Tenant struct {
+	ID string `json:"id"`
+	// APIKeys are the API keys of the tenant. They're never served back by the admin API.
+	APIKeys []string `json:"apiKeys,omitempty"`
+	// MaxBlobSize is the size in bytes of the largest blob accepted from the tenant
+	MaxBlobSize uint `json:"maxBlobSize"`
+	// Throughput is the byte rate of the blobs of the tenant across all its accounts and quorums
+	Throughput common.RateParam `json:"throughput"`
+	// BlobRate is the blob rate, in blobs per second, of the tenant across all its accounts and quorums
+	BlobRate common.RateParam `json:"blobRate"`
+}
+
+func (t *Tenant) validate() error {
+	if t.ID == "" || len(t.ID) > maxTenantIDLength {
+		return fmt.Errorf("tenant ID must be between 1 and %d characters long", maxTenantIDLength)
+	}
+	if strings.ContainsAny(t.ID, ":/ ") {
+		return fmt.Errorf("tenant ID %q must not contain ':', '/' or spaces", t.ID)
+	}
+	if len(t.APIKeys) == 0 {
+		return fmt.Errorf("tenant %s must have at least one API key", t.ID)
+	}
+	for _, key := range t.APIKeys {
+		if key == "" {
+			return fmt.Errorf("tenant %s has an empty API key", t.ID)
+		}
+	}
+	if t.MaxBlobSize > maxBlobSize {
+		return fmt.Errorf("max blob size of tenant %s cannot exceed %d bytes, but found %d", t.ID, maxBlobSize, t.MaxBlobSize)
+	}
+	return nil
+}
+
+// redacted returns a copy of the tenant without its API keys
+func (t *Tenant) redacted() *Tenant {
+	tenant := *t
+	tenant.APIKeys = nil
+	return &tenant
+}
+
+// LoadTenantsFile reads the tenants from a JSON file holding a list of tenants, e.g.
+// [{"id": "rollup-a", "apiKeys": ["..."], "maxBlobSize": 1048576, "throughput": 4194304}]
+func LoadTenantsFile(path string) ([]*Tenant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tenants file: %w", err)
+	}
+	var tenants []*Tenant
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("failed to parse the tenants file: %w", err)
+	}
+	return tenants, nil
+}
+
+// Tenants holds the tenants of the disperser, which can be changed with the admin API while the server is running.
+// The API keys are kept as hashes, so that they can't be read back from the server.
+type Tenants struct {
+	logger logging.Logger
+
+	mu    sync.RWMutex
+	byID  map[string]*Tenant
+	byKey map[[32]byte]string
+}
+
+// NewTenants creates the registry of the given tenants
+func NewTenants(tenants []*Tenant, logger logging.Logger) (*Tenants, error) {
+	t := &Tenants{
+		logger: logger.With("component", "Tenants"),
+		byID:   make(map[string]*Tenant),
+		byKey:  make(map[[32]byte]string),
+	}
+	for _, tenant := range tenants {
+		if _, ok := t.byID[tenant.ID]; ok {
+			return nil, fmt.Errorf("duplicate tenant %s", tenant.ID)
+		}
+		if err := t.Set(tenant); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func hashAPIKey(key string) [32]byte {
+	return sha256.Sum256([]byte(key))
+}
+
+// Set adds the tenant, or replaces the tenant with the same ID and its API keys
+func (t *Tenants) Set(tenant *Tenant) error {
+	if err := tenant.validate(); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range tenant.APIKeys {
+		if owner, ok := t.byKey[hashAPIKey(key)]; ok && owner != tenant.ID {
+			return fmt.Errorf("an API key of tenant %s is already used by tenant %s", tenant.ID, owner)
+		}
+	}
+	t.deleteLocked(tenant.ID)
+	for _, key := range tenant.APIKeys {
+		t.byKey[hashAPIKey(key)] = tenant.ID
+	}
+	t.byID[tenant.ID] = tenant.redacted()
+	t.logger.Info("set a tenant", "tenant", tenant.ID, "numAPIKeys", len(tenant.APIKeys), "maxBlobSize", tenant.MaxBlobSize, "throughput", tenant.Throughput, "blobRate", tenant.BlobRate)
+	return nil
+}
+
+// Delete removes the tenant and revokes its API keys. It returns whether the tenant existed.
+func (t *Tenants) Delete(id string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.deleteLocked(id) {
+		return false
+	}
+	t.logger.Info("deleted a tenant", "tenant", id)
+	return true
+}
+
+func (t *Tenants) deleteLocked(id string) bool {
+	if _, ok := t.byID[id]; !ok {
+		return false
+	}
+	delete(t.byID, id)
+	for hash, owner := range t.byKey {
+		if owner == id {
+			delete(t.byKey, hash)
+		}
+	}
+	return true
+}
+
+// Get returns the tenant with the given ID, without its API keys
+func (t *Tenants) Get(id string) (*Tenant, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	tenant, ok := t.byID[id]
+	return tenant, ok
+}
+
+// GetByAPIKey returns the tenant the API key belongs to, without its API keys
+func (t *Tenants) GetByAPIKey(key string) (*Tenant, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	id, ok := t.byKey[hashAPIKey(key)]
+	if !ok {
+		return nil, false
+	}
+	return t.byID[id], true
+}
+
+// All returns the tenants ordered by ID, without their API keys
+func (t *Tenants) All() []*Tenant {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	tenants := make([]*Tenant, 0, len(t.byID))
+	for _, tenant := range t.byID {
+		tenants = append(tenants, tenant)
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].ID < tenants[j].ID })
+	return tenants
+}
+
+// tenantFromContext returns the tenant of the API key in the metadata of the request, or nil for the requests of the
+// default tenant, which have no API key. The requests with an unknown API key are rejected.
+func (s *DispersalServer) tenantFromContext(ctx context.Context) (*Tenant, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	values := md.Get(APIKeyHeader)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tenant, ok := s.tenants.GetByAPIKey(values[0])
+	if !ok {
+		return nil, api.NewGRPCError(codes.Unauthenticated, "unknown API key")
+	}
+	return tenant, nil
+}
+
+// tenantIDOf returns the ID of the tenant, which is empty for the default tenant
+func tenantIDOf(tenant *Tenant) string {
+	if tenant == nil {
+		return ""
+	}
+	return tenant.ID
+}
+
+// tenantLabel returns the label of the tenant in the metrics
+func tenantLabel(tenantID string) string {
+	if tenantID == "" {
+		return defaultTenantLabel
+	}
+	return tenantID
+}
+
+// visibleToTenant returns whether the blob was dispersed by the tenant. The blobs stored before the tenants were
+// recorded belong to the default tenant.
+func visibleToTenant(metadata *disperser.BlobMetadata, tenantID string) bool {
+	if metadata.RequestMetadata == nil {
+		return tenantID == ""
+	}
+	return metadata.RequestMetadata.TenantID == tenantID
+}
+
+// handleTenants manages the tenants on the admin endpoints. GET /tenants returns the tenants without their API keys,
+// PUT /tenants adds or replaces the tenant in the body, and DELETE /tenants?id=<id> removes a tenant.
+func (s *DispersalServer) handleTenants(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		tenant := &Tenant{}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024*1024)).Decode(tenant); err != nil {
+			http.Error(w, fmt.Sprintf("failed to parse the tenant: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := s.tenants.Set(tenant); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodDelete:
+		id := r.URL.Query().Get("id")
+		if id == "" {
+			http.Error(w, "the id of the tenant must be set", http.StatusBadRequest)
+			return
+		}
+		if !s.tenants.Delete(id) {
+			http.Error(w, fmt.Sprintf("no tenant %s", id), http.StatusNotFound)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.tenants.All()); err != nil {
+		s.logger.Debug("failed to write the reply", "err", err)
+	}
+}
@@ -0,0 +1,137 @@
+package apiserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func tenantContext(apiKey string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("0.0.0.0"), Port: 51001}})
+	if apiKey == "" {
+		return ctx
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyHeader, apiKey))
+}
+
+func TestTenants(t *testing.T) {
+	tenants, err := NewTenants([]*Tenant{{ID: "a", APIKeys: []string{"key-a"}}}, logging.NewNoopLogger())
+	assert.NoError(t, err)
+
+	_, err = NewTenants([]*Tenant{{ID: "a", APIKeys: []string{"1"}}, {ID: "a", APIKeys: []string{"2"}}}, logging.NewNoopLogger())
+	assert.ErrorContains(t, err, "duplicate tenant")
+	assert.Error(t, tenants.Set(&Tenant{ID: "b"}))
+	assert.Error(t, tenants.Set(&Tenant{ID: "b/c", APIKeys: []string{"key-b"}}))
+	assert.Error(t, tenants.Set(&Tenant{ID: "b", APIKeys: []string{"key-b"}, MaxBlobSize: maxBlobSize + 1}))
+	assert.ErrorContains(t, tenants.Set(&Tenant{ID: "b", APIKeys: []string{"key-a"}}), "already used by tenant a")
+
+	tenant, ok := tenants.GetByAPIKey("key-a")
+	assert.True(t, ok)
+	assert.Equal(t, "a", tenant.ID)
+	assert.Nil(t, tenant.APIKeys)
+
+	// Replacing a tenant revokes its previous API keys
+	assert.NoError(t, tenants.Set(&Tenant{ID: "a", APIKeys: []string{"key-a2"}}))
+	_, ok = tenants.GetByAPIKey("key-a")
+	assert.False(t, ok)
+	_, ok = tenants.GetByAPIKey("key-a2")
+	assert.True(t, ok)
+
+	assert.NoError(t, tenants.Set(&Tenant{ID: "b", APIKeys: []string{"key-a"}}))
+	assert.Equal(t, []string{"a", "b"}, []string{tenants.All()[0].ID, tenants.All()[1].ID})
+	assert.True(t, tenants.Delete("a"))
+	assert.False(t, tenants.Delete("a"))
+	_, ok = tenants.GetByAPIKey("key-a2")
+	assert.False(t, ok)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	server := newReplayTestServer(disperser.ServerConfig{}, RateConfig{})
+	assert.NoError(t, server.tenants.Set(&Tenant{ID: "rollup", APIKeys: []string{"secret"}, MaxBlobSize: 4}))
+
+	newBlob := func(size int) *core.Blob {
+		return &core.Blob{
+			RequestHeader: core.BlobRequestHeader{
+				BlobAuthHeader: core.BlobAuthHeader{AccountID: "account"},
+				SecurityParams: []*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 50, ConfirmationThreshold: 100}},
+			},
+			Data: make([]byte, size),
+		}
+	}
+
+	_, err := server.disperseBlob(tenantContext("unknown"), newBlob(3), "", "", "DisperseBlob")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = server.disperseBlob(tenantContext("secret"), newBlob(5), "", "", "DisperseBlob")
+	assert.ErrorContains(t, err, "blob size cannot exceed 4 bytes for tenant rollup")
+
+	reply, err := server.disperseBlob(tenantContext("secret"), newBlob(3), "", "", "DisperseBlob")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(server.metrics.TenantRequests.WithLabelValues("rollup", codes.OK.String(), "DisperseBlob")))
+	assert.Equal(t, 3.0, testutil.ToFloat64(server.metrics.TenantBlobBytes.WithLabelValues("rollup")))
+
+	// The blob is only visible to the requests of its tenant
+	request := &pb.BlobStatusRequest{RequestId: reply.GetRequestId()}
+	_, err = server.GetBlobStatus(tenantContext("secret"), request)
+	assert.NoError(t, err)
+	_, err = server.GetBlobStatus(tenantContext(""), request)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The accounts are partitioned by tenant
+	statuses, err := server.GetBlobStatuses(tenantContext("secret"), &pb.BlobStatusesRequest{AccountId: "account"})
+	assert.NoError(t, err)
+	assert.Len(t, statuses.GetStatuses(), 1)
+	statuses, err = server.GetBlobStatuses(tenantContext(""), &pb.BlobStatusesRequest{AccountId: "account"})
+	assert.NoError(t, err)
+	assert.Len(t, statuses.GetStatuses(), 0)
+	statuses, err = server.GetBlobStatuses(tenantContext(""), &pb.BlobStatusesRequest{RequestIds: [][]byte{reply.GetRequestId()}})
+	assert.NoError(t, err)
+	assert.Nil(t, statuses.GetStatuses()[0].GetReply())
+}
+
+func TestHandleTenants(t *testing.T) {
+	server := newReplayTestServer(disperser.ServerConfig{}, RateConfig{})
+	admin := httptest.NewServer(http.HandlerFunc(server.handleTenants))
+	defer admin.Close()
+
+	do := func(method string, url string, body string) (int, string) {
+		req, err := http.NewRequest(method, admin.URL+url, strings.NewReader(body))
+		assert.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+		return resp.StatusCode, string(data)
+	}
+
+	code, body := do(http.MethodPut, "/tenants", `{"id": "rollup", "apiKeys": ["secret"], "throughput": 1024}`)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, body, "secret")
+	assert.Contains(t, body, `"throughput":1024`)
+
+	code, _ = do(http.MethodPut, "/tenants", `{"id": "other"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, body = do(http.MethodDelete, "/tenants?id=rollup", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "[]\n", body)
+	code, _ = do(http.MethodDelete, "/tenants?id=rollup", "")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = do(http.MethodPost, "/tenants", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+}
@@ -0,0 +1,116 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// applySecurityParams replaces the thresholds of params, which are those of the quorums on the EigenDA contract, with
+// the thresholds requested for the blob. The requested thresholds can only be stricter than the on-chain thresholds,
+// and the blob must be assignable to the current operators of the quorums with them, so that it can be encoded and
+// attested like any other blob.
+func (s *DispersalServer) applySecurityParams(ctx context.Context, requested []*pb.QuorumSecurityParams, params []*core.SecurityParam, blobSize int) error {
+	if len(requested) == 0 {
+		return nil
+	}
+
+	paramsByQuorum := make(map[core.QuorumID]*core.SecurityParam, len(params))
+	for _, param := range params {
+		paramsByQuorum[param.QuorumID] = param
+	}
+
+	custom := make(map[core.QuorumID]core.SecurityParam, len(requested))
+	for _, r := range requested {
+		if r.GetQuorumId() > core.MaxQuorumID {
+			return fmt.Errorf("security_params quorum_id must be in range [0, 254], but found %d", r.GetQuorumId())
+		}
+		quorumID := core.QuorumID(r.GetQuorumId())
+		onChain, ok := paramsByQuorum[quorumID]
+		if !ok {
+			return fmt.Errorf("security_params quorum %d is not among the quorums of the blob", quorumID)
+		}
+		if _, ok := custom[quorumID]; ok {
+			return fmt.Errorf("security_params must not contain duplicates, but quorum %d was found twice", quorumID)
+		}
+
+		adversary, confirmation := r.GetAdversaryThresholdPercentage(), r.GetConfirmationThresholdPercentage()
+		if adversary < uint32(onChain.AdversaryThreshold) {
+			return fmt.Errorf("adversary_threshold_percentage of quorum %d must be at least %d, got %d", quorumID, onChain.AdversaryThreshold, adversary)
+		}
+		if confirmation < uint32(onChain.ConfirmationThreshold) {
+			return fmt.Errorf("confirmation_threshold_percentage of quorum %d must be at least %d, got %d", quorumID, onChain.ConfirmationThreshold, confirmation)
+		}
+		if err := core.ValidateSecurityParam(confirmation, adversary); err != nil {
+			return fmt.Errorf("invalid security_params of quorum %d: %w", quorumID, err)
+		}
+
+		custom[quorumID] = core.SecurityParam{
+			QuorumID:              quorumID,
+			AdversaryThreshold:    uint8(adversary),
+			ConfirmationThreshold: uint8(confirmation),
+		}
+	}
+
+	if err := s.checkAssignable(ctx, custom, blobSize); err != nil {
+		return err
+	}
+
+	for quorumID, param := range custom {
+		paramsByQuorum[quorumID].AdversaryThreshold = param.AdversaryThreshold
+		paramsByQuorum[quorumID].ConfirmationThreshold = param.ConfirmationThreshold
+	}
+	return nil
+}
+
+// checkAssignable checks that a blob of blobSize bytes can be assigned to the current operators of the quorums with
+// the given thresholds, and that the encoded blob fits in the SRS of the encoders
+func (s *DispersalServer) checkAssignable(ctx context.Context, params map[core.QuorumID]core.SecurityParam, blobSize int) error {
+	quorums := make([]core.QuorumID, 0, len(params))
+	for quorumID := range params {
+		quorums = append(quorums, quorumID)
+	}
+
+	blockNumber, err := s.tx.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the current block number: %w", err)
+	}
+	stakes, err := s.tx.GetOperatorStakesForQuorums(ctx, quorums, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get the operator stakes: %w", err)
+	}
+	state := core.NewOperatorStateFromStakes(stakes, uint(blockNumber))
+
+	coordinator := &core.StdAssignmentCoordinator{}
+	blobLength := encoding.GetBlobLength(uint(blobSize))
+	for quorumID, param := range params {
+		param := param
+		if len(state.Operators[quorumID]) == 0 {
+			return fmt.Errorf("quorum %d has no operators", quorumID)
+		}
+		chunkLength, err := coordinator.CalculateChunkLength(state, blobLength, 0, &param)
+		if err != nil {
+			return fmt.Errorf("failed to calculate the chunk length of quorum %d: %w", quorumID, err)
+		}
+		_, info, err := coordinator.GetAssignments(state, blobLength, &core.BlobQuorumInfo{
+			SecurityParam: param,
+			ChunkLength:   chunkLength,
+		})
+		if err != nil {
+			return fmt.Errorf("the blob cannot be assigned to the operators of quorum %d with the requested thresholds: %w", quorumID, err)
+		}
+		if info.TotalChunks == 0 {
+			return fmt.Errorf("the blob cannot be assigned to any chunk of quorum %d", quorumID)
+		}
+		if s.serverConfig.SRSOrder > 0 {
+			encodingParams := encoding.ParamsFromMins(chunkLength, info.TotalChunks)
+			if err := encoding.ValidateEncodingParams(encodingParams, int(blobLength), int(s.serverConfig.SRSOrder)); err != nil {
+				return fmt.Errorf("the blob is too large to be encoded for quorum %d with the requested thresholds: %w", quorumID, err)
+			}
+		}
+	}
+	return nil
+}
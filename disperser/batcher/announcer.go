@@ -0,0 +1,140 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/webhook"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// OperatorWebhook is the endpoint at which an operator receives the announcements of the upcoming large batches, along
+// with the secret shared with the operator to sign them
+type OperatorWebhook struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+// ReadOperatorWebhooksFile reads the webhooks of the operators from a JSON file such as
+//
+//	{"0x...": {"url": "https://...", "secret": "..."}}
+func ReadOperatorWebhooksFile(path string) (map[core.OperatorID]OperatorWebhook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operator webhooks file: %w", err)
+	}
+	var hooks map[string]OperatorWebhook
+	if err := json.Unmarshal(data, &hooks); err != nil {
+		return nil, fmt.Errorf("failed to parse operator webhooks file: %w", err)
+	}
+	webhooks := make(map[core.OperatorID]OperatorWebhook, len(hooks))
+	for id, hook := range hooks {
+		operatorID, err := core.OperatorIDFromHex(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operator ID %s in operator webhooks file: %w", id, err)
+		}
+		if hook.URL == "" || hook.Secret == "" {
+			return nil, fmt.Errorf("the webhook of operator %s must have a url and a secret", id)
+		}
+		webhooks[operatorID] = hook
+	}
+	return webhooks, nil
+}
+
+// BatchAnnouncer announces the upcoming batches whose encoded blobs reach a threshold size to the operators with a
+// webhook, once per batch, so that they can prepare their nodes for them. The announcements are signed with the
+// secret of each operator as described in the webhook package.
+type BatchAnnouncer struct {
+	mu sync.Mutex
+
+	// threshold is the size of the encoded blobs in bytes from which a batch is announced
+	threshold uint64
+	// batchInterval is the interval at which the batches are created, unless they're triggered by their size
+	batchInterval time.Duration
+	webhooks      map[core.OperatorID]OperatorWebhook
+	client        *http.Client
+
+	// lastBatch is when the last batch was created
+	lastBatch time.Time
+	// announced is set once the upcoming batch is announced, and reset when it's created
+	announced bool
+
+	logger logging.Logger
+}
+
+func NewBatchAnnouncer(threshold uint64, batchInterval time.Duration, webhooks map[core.OperatorID]OperatorWebhook, timeout time.Duration, logger logging.Logger) *BatchAnnouncer {
+	return &BatchAnnouncer{
+		threshold:     threshold,
+		batchInterval: batchInterval,
+		webhooks:      webhooks,
+		client:        &http.Client{Timeout: timeout},
+		lastBatch:     time.Now(),
+		logger:        logger.With("component", "BatchAnnouncer"),
+	}
+}
+
+// ShouldAnnounce returns whether the upcoming batch, whose encoded blobs have the given size, is to be announced, in
+// which case it's marked as announced
+func (a *BatchAnnouncer) ShouldAnnounce(encodedSize uint64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.announced || encodedSize < a.threshold {
+		return false
+	}
+	a.announced = true
+	return true
+}
+
+// BatchCreated records the creation of a batch, after which the next batch can be announced
+func (a *BatchAnnouncer) BatchCreated() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.lastBatch = time.Now()
+	a.announced = false
+}
+
+// ETA returns when the upcoming batch is expected to be created, which is right away if it's triggered by its size
+func (a *BatchAnnouncer) ETA(triggered bool) time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	if eta := a.lastBatch.Add(a.batchInterval); !triggered && eta.After(now) {
+		return eta
+	}
+	return now
+}
+
+// Announce sends the announcement of the upcoming batch to the operators with a webhook, filling in the size of the
+// chunks assigned to each of them. It returns once every webhook responded or timed out.
+func (a *BatchAnnouncer) Announce(ctx context.Context, announcement core.BatchAnnouncement, operatorSizes map[core.OperatorID]uint64) {
+	var wg sync.WaitGroup
+	for operatorID, hook := range a.webhooks {
+		size, ok := operatorSizes[operatorID]
+		if !ok {
+			continue
+		}
+		announcement := announcement
+		announcement.OperatorSize = size
+		body, err := json.Marshal(announcement)
+		if err != nil {
+			a.logger.Error("failed to serialize the batch announcement", "err", err)
+			return
+		}
+
+		wg.Add(1)
+		go func(operatorID core.OperatorID, hook OperatorWebhook) {
+			defer wg.Done()
+			if err := webhook.Post(ctx, a.client, hook.URL, []byte(hook.Secret), body); err != nil {
+				a.logger.Warn("failed to announce the upcoming batch to the operator", "operatorID", operatorID.Hex(), "err", err)
+			}
+		}(operatorID, hook)
+	}
+	wg.Wait()
+	a.logger.Info("announced the upcoming batch", "referenceBlockNumber", announcement.ReferenceBlockNumber, "numBlobs", announcement.NumBlobs, "size", announcement.Size, "eta", announcement.ETA)
+}
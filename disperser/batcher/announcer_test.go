@@ -0,0 +1,57 @@
+package batcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/webhook"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchAnnouncer(t *testing.T) {
+	secret := "secret"
+	received := make(chan core.BatchAnnouncement, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, webhook.Verify([]byte(secret), r.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute))
+		var announcement core.BatchAnnouncement
+		assert.NoError(t, json.Unmarshal(body, &announcement))
+		received <- announcement
+	}))
+	defer server.Close()
+
+	operatorID := core.OperatorID{1}
+	webhooks := map[core.OperatorID]batcher.OperatorWebhook{
+		operatorID:         {URL: server.URL, Secret: secret},
+		core.OperatorID{2}: {URL: server.URL, Secret: secret},
+	}
+	announcer := batcher.NewBatchAnnouncer(100, time.Minute, webhooks, time.Second, logging.NewNoopLogger())
+
+	// The batch is announced once it reaches the threshold, and only once until it's created
+	assert.False(t, announcer.ShouldAnnounce(99))
+	assert.True(t, announcer.ShouldAnnounce(100))
+	assert.False(t, announcer.ShouldAnnounce(200))
+	announcer.BatchCreated()
+	assert.True(t, announcer.ShouldAnnounce(200))
+
+	// The batch is expected at the next interval, unless it's triggered by its size
+	assert.True(t, announcer.ETA(false).After(time.Now().Add(59*time.Second)))
+	assert.False(t, announcer.ETA(true).After(time.Now()))
+
+	// Only the operators with chunks in the batch are notified
+	announcer.Announce(context.Background(), core.BatchAnnouncement{NumBlobs: 3, Size: 200}, map[core.OperatorID]uint64{operatorID: 50})
+	announcement := <-received
+	assert.Equal(t, 3, announcement.NumBlobs)
+	assert.Equal(t, uint64(200), announcement.Size)
+	assert.Equal(t, uint64(50), announcement.OperatorSize)
+	assert.Len(t, received, 0)
+}
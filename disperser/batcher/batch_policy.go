@@ -0,0 +1,235 @@
+package batcher
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+const (
+	// The reasons of the batch decisions
+	BatchReasonEmpty          = "empty"
+	BatchReasonMinInterval    = "min_interval"
+	BatchReasonMaxInterval    = "max_interval"
+	BatchReasonFull           = "full"
+	BatchReasonTargetSize     = "target_size"
+	BatchReasonLowArrivalRate = "low_arrival_rate"
+	BatchReasonWaiting        = "waiting"
+
+	// The names of the batch policies
+	FixedBatchPolicyName    = "fixed"
+	AdaptiveBatchPolicyName = "adaptive"
+
+	// signalsSmoothing is the weight of the last sample in the moving averages of the arrival rate and dispersal
+	// throughput
+	signalsSmoothing = 0.2
+)
+
+// BatchSignals is the state of the batcher from which a BatchPolicy decides when to cut a batch
+type BatchSignals struct {
+	// SinceLastBatch is the time since the last batch was cut
+	SinceLastBatch time.Duration
+	// PendingBlobs and PendingBytes are the number and the encoded size of the blobs waiting for a batch
+	PendingBlobs int
+	PendingBytes uint64
+	// ArrivalRate is the moving average of the rate at which the encoded blobs arrive, in bytes per second
+	ArrivalRate float64
+	// DispersalThroughput is the moving average of the rate at which the operators took the last batches, in bytes
+	// per second. It's zero until the first batch is dispersed
+	DispersalThroughput float64
+	// GasPrice is the current gas price of L1 in wei, nil if unknown
+	GasPrice *big.Int
+}
+
+// BatchDecision is the decision of a BatchPolicy
+type BatchDecision struct {
+	Cut bool
+	// Reason tells what drove the decision, as one of the BatchReason constants
+	Reason string
+	// TargetBytes is the batch size the policy aimed for, zero if it doesn't aim for a size
+	TargetBytes uint64
+}
+
+// BatchPolicy decides the boundaries of the batches. The batcher evaluates it periodically and whenever the encoded
+// blobs reach the batch size limit, and cuts a batch when it says so.
+type BatchPolicy interface {
+	Decide(signals BatchSignals) BatchDecision
+}
+
+// FixedBatchPolicy cuts a batch every Interval, or as soon as MaxBatchBytes are pending, which are the boundaries of
+// the batches without a policy
+type FixedBatchPolicy struct {
+	Interval      time.Duration
+	MaxBatchBytes uint64
+}
+
+var _ BatchPolicy = (*FixedBatchPolicy)(nil)
+
+func (p *FixedBatchPolicy) Decide(signals BatchSignals) BatchDecision {
+	switch {
+	case signals.PendingBlobs == 0:
+		return BatchDecision{Reason: BatchReasonEmpty}
+	case p.MaxBatchBytes > 0 && signals.PendingBytes >= p.MaxBatchBytes:
+		return BatchDecision{Cut: true, Reason: BatchReasonFull, TargetBytes: p.MaxBatchBytes}
+	case signals.SinceLastBatch >= p.Interval:
+		return BatchDecision{Cut: true, Reason: BatchReasonMaxInterval, TargetBytes: p.MaxBatchBytes}
+	default:
+		return BatchDecision{Reason: BatchReasonWaiting, TargetBytes: p.MaxBatchBytes}
+	}
+}
+
+type AdaptiveBatchPolicyConfig struct {
+	// MinInterval is the minimum time between two batches, which bounds the number of confirmation transactions
+	MinInterval time.Duration
+	// MaxInterval is the maximum time a pending blob waits for a batch
+	MaxInterval time.Duration
+	// MaxBatchBytes is the maximum encoded size of a batch. If zero, the size is only bounded by the dispersal time
+	MaxBatchBytes uint64
+	// TargetDispersalTime is how long the operators should take to receive a batch, which bounds the target size of
+	// the batches by the dispersal throughput. If zero, the target size is MaxBatchBytes
+	TargetDispersalTime time.Duration
+	// TargetGasPrice is the gas price in wei above which the batches are grown proportionally to the gas price, to
+	// spread the cost of the confirmation transaction over more blobs. Below it, a batch is cut as soon as the
+	// arrival rate can't fill it before MaxInterval. If nil, the gas price is ignored
+	TargetGasPrice *big.Int
+}
+
+// AdaptiveBatchPolicy sizes the batches from the arrival rate of the blobs, the dispersal throughput of the operators
+// and the gas price of L1. It aims for batches which the operators take in TargetDispersalTime, grown when the gas is
+// expensive, and cuts them early when they can't fill up before MaxInterval.
+type AdaptiveBatchPolicy struct {
+	AdaptiveBatchPolicyConfig
+}
+
+var _ BatchPolicy = (*AdaptiveBatchPolicy)(nil)
+
+func NewAdaptiveBatchPolicy(config AdaptiveBatchPolicyConfig) (*AdaptiveBatchPolicy, error) {
+	if config.MaxInterval <= 0 {
+		return nil, fmt.Errorf("the max interval of the adaptive batch policy must be positive")
+	}
+	if config.MinInterval > config.MaxInterval {
+		return nil, fmt.Errorf("the min interval %s of the adaptive batch policy exceeds its max interval %s", config.MinInterval, config.MaxInterval)
+	}
+	if config.TargetGasPrice != nil && config.TargetGasPrice.Sign() <= 0 {
+		return nil, fmt.Errorf("the target gas price of the adaptive batch policy must be positive")
+	}
+	return &AdaptiveBatchPolicy{AdaptiveBatchPolicyConfig: config}, nil
+}
+
+func (p *AdaptiveBatchPolicy) Decide(signals BatchSignals) BatchDecision {
+	if signals.PendingBlobs == 0 {
+		return BatchDecision{Reason: BatchReasonEmpty}
+	}
+
+	target, expensiveGas := p.targetBytes(signals)
+	decision := BatchDecision{Reason: BatchReasonWaiting}
+	if !math.IsInf(target, 1) {
+		decision.TargetBytes = uint64(target)
+	}
+
+	pending := float64(signals.PendingBytes)
+	switch {
+	case signals.SinceLastBatch < p.MinInterval:
+		decision.Reason = BatchReasonMinInterval
+	case signals.SinceLastBatch >= p.MaxInterval:
+		decision.Cut, decision.Reason = true, BatchReasonMaxInterval
+	case p.MaxBatchBytes > 0 && signals.PendingBytes >= p.MaxBatchBytes:
+		decision.Cut, decision.Reason = true, BatchReasonFull
+	case pending >= target:
+		decision.Cut, decision.Reason = true, BatchReasonTargetSize
+	case !expensiveGas && !math.IsInf(target, 1):
+		// Waiting only delays the pending blobs if the batch can't reach the target size before MaxInterval anyway
+		remaining := p.MaxInterval - signals.SinceLastBatch
+		if signals.ArrivalRate <= 0 || (target-pending)/signals.ArrivalRate > remaining.Seconds() {
+			decision.Cut, decision.Reason = true, BatchReasonLowArrivalRate
+		}
+	}
+	return decision
+}
+
+// targetBytes returns the size of the batches the policy aims for, which is infinite if neither the batch size nor
+// the dispersal throughput bound it, and whether the gas price is above the target
+func (p *AdaptiveBatchPolicy) targetBytes(signals BatchSignals) (float64, bool) {
+	limit := math.Inf(1)
+	if p.MaxBatchBytes > 0 {
+		limit = float64(p.MaxBatchBytes)
+	}
+
+	target := limit
+	if p.TargetDispersalTime > 0 && signals.DispersalThroughput > 0 {
+		target = math.Min(target, signals.DispersalThroughput*p.TargetDispersalTime.Seconds())
+	}
+
+	expensiveGas := p.TargetGasPrice != nil && signals.GasPrice != nil && signals.GasPrice.Cmp(p.TargetGasPrice) > 0
+	if expensiveGas {
+		ratio, _ := new(big.Rat).SetFrac(signals.GasPrice, p.TargetGasPrice).Float64()
+		target = math.Min(limit, target*ratio)
+	}
+	return target, expensiveGas
+}
+
+// batchSignalsTracker tracks the arrival rate of the encoded blobs and the dispersal throughput of the operators for
+// the batch policy. It's only used by the batching loop, so it isn't safe for concurrent use.
+type batchSignalsTracker struct {
+	lastBatch        time.Time
+	lastSample       time.Time
+	lastPendingBytes uint64
+
+	arrivalRate         float64
+	dispersalThroughput float64
+	// dispersalTime is how long the operators took to receive and sign the last batch
+	dispersalTime time.Duration
+}
+
+func newBatchSignalsTracker(now time.Time) *batchSignalsTracker {
+	return &batchSignalsTracker{
+		lastBatch:  now,
+		lastSample: now,
+	}
+}
+
+// sample updates the arrival rate with the encoded blobs pending at now
+func (t *batchSignalsTracker) sample(now time.Time, pendingBytes uint64) {
+	elapsed := now.Sub(t.lastSample).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	var arrived uint64
+	if pendingBytes > t.lastPendingBytes {
+		arrived = pendingBytes - t.lastPendingBytes
+	}
+	t.arrivalRate = movingAverage(t.arrivalRate, float64(arrived)/elapsed)
+	t.lastSample = now
+	t.lastPendingBytes = pendingBytes
+}
+
+// batchCut records a batch cut at now, which took the bytes out of the pending encoded blobs
+func (t *batchSignalsTracker) batchCut(now time.Time, batchBytes uint64, pendingBytes uint64) {
+	t.lastBatch = now
+	t.lastPendingBytes = pendingBytes
+	if batchBytes > 0 && t.dispersalTime > 0 {
+		throughput := float64(batchBytes) / t.dispersalTime.Seconds()
+		if t.dispersalThroughput == 0 {
+			t.dispersalThroughput = throughput
+		} else {
+			t.dispersalThroughput = movingAverage(t.dispersalThroughput, throughput)
+		}
+	}
+	t.dispersalTime = 0
+}
+
+func (t *batchSignalsTracker) signals(now time.Time, pendingBlobs int, pendingBytes uint64, gasPrice *big.Int) BatchSignals {
+	return BatchSignals{
+		SinceLastBatch:      now.Sub(t.lastBatch),
+		PendingBlobs:        pendingBlobs,
+		PendingBytes:        pendingBytes,
+		ArrivalRate:         t.arrivalRate,
+		DispersalThroughput: t.dispersalThroughput,
+		GasPrice:            gasPrice,
+	}
+}
+
+func movingAverage(average, sample float64) float64 {
+	return signalsSmoothing*sample + (1-signalsSmoothing)*average
+}
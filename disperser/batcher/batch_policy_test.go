@@ -0,0 +1,107 @@
+package batcher_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedBatchPolicy(t *testing.T) {
+	policy := &batcher.FixedBatchPolicy{Interval: time.Minute, MaxBatchBytes: 1000}
+
+	decision := policy.Decide(batcher.BatchSignals{SinceLastBatch: 2 * time.Minute})
+	assert.Equal(t, batcher.BatchDecision{Reason: batcher.BatchReasonEmpty}, decision)
+
+	decision = policy.Decide(batcher.BatchSignals{SinceLastBatch: time.Second, PendingBlobs: 1, PendingBytes: 100})
+	assert.False(t, decision.Cut)
+	assert.Equal(t, batcher.BatchReasonWaiting, decision.Reason)
+
+	decision = policy.Decide(batcher.BatchSignals{SinceLastBatch: time.Second, PendingBlobs: 5, PendingBytes: 1000})
+	assert.True(t, decision.Cut)
+	assert.Equal(t, batcher.BatchReasonFull, decision.Reason)
+
+	decision = policy.Decide(batcher.BatchSignals{SinceLastBatch: time.Minute, PendingBlobs: 1, PendingBytes: 100})
+	assert.True(t, decision.Cut)
+	assert.Equal(t, batcher.BatchReasonMaxInterval, decision.Reason)
+}
+
+func TestAdaptiveBatchPolicy(t *testing.T) {
+	policy, err := batcher.NewAdaptiveBatchPolicy(batcher.AdaptiveBatchPolicyConfig{
+		MinInterval:         10 * time.Second,
+		MaxInterval:         time.Minute,
+		MaxBatchBytes:       10_000,
+		TargetDispersalTime: 5 * time.Second,
+		TargetGasPrice:      big.NewInt(10),
+	})
+	assert.NoError(t, err)
+
+	// The operators take 200 bytes/s, so the batches aim for 1000 bytes
+	signals := batcher.BatchSignals{
+		SinceLastBatch:      20 * time.Second,
+		PendingBlobs:        2,
+		PendingBytes:        500,
+		ArrivalRate:         100,
+		DispersalThroughput: 200,
+		GasPrice:            big.NewInt(5),
+	}
+	decision := policy.Decide(signals)
+	assert.Equal(t, batcher.BatchDecision{Reason: batcher.BatchReasonWaiting, TargetBytes: 1000}, decision)
+
+	// Nothing is cut before the min interval, even a full batch
+	signals.SinceLastBatch = 5 * time.Second
+	signals.PendingBytes = 20_000
+	decision = policy.Decide(signals)
+	assert.False(t, decision.Cut)
+	assert.Equal(t, batcher.BatchReasonMinInterval, decision.Reason)
+
+	signals.SinceLastBatch = 20 * time.Second
+	signals.PendingBytes = 1000
+	decision = policy.Decide(signals)
+	assert.Equal(t, batcher.BatchDecision{Cut: true, Reason: batcher.BatchReasonTargetSize, TargetBytes: 1000}, decision)
+
+	// At 10 bytes/s, the batch can't reach 1000 bytes before the max interval
+	signals.PendingBytes = 500
+	signals.ArrivalRate = 10
+	decision = policy.Decide(signals)
+	assert.Equal(t, batcher.BatchDecision{Cut: true, Reason: batcher.BatchReasonLowArrivalRate, TargetBytes: 1000}, decision)
+
+	// Expensive gas grows the batches and waits for them to fill up
+	signals.GasPrice = big.NewInt(40)
+	decision = policy.Decide(signals)
+	assert.Equal(t, batcher.BatchDecision{Reason: batcher.BatchReasonWaiting, TargetBytes: 4000}, decision)
+
+	// The grown batches are bounded by the max batch size
+	signals.GasPrice = big.NewInt(1000)
+	signals.PendingBytes = 10_000
+	decision = policy.Decide(signals)
+	assert.Equal(t, batcher.BatchDecision{Cut: true, Reason: batcher.BatchReasonFull, TargetBytes: 10_000}, decision)
+
+	signals.PendingBytes = 500
+	signals.SinceLastBatch = time.Minute
+	decision = policy.Decide(signals)
+	assert.True(t, decision.Cut)
+	assert.Equal(t, batcher.BatchReasonMaxInterval, decision.Reason)
+
+	signals.PendingBlobs = 0
+	signals.PendingBytes = 0
+	decision = policy.Decide(signals)
+	assert.Equal(t, batcher.BatchDecision{Reason: batcher.BatchReasonEmpty}, decision)
+}
+
+func TestAdaptiveBatchPolicyConfig(t *testing.T) {
+	_, err := batcher.NewAdaptiveBatchPolicy(batcher.AdaptiveBatchPolicyConfig{})
+	assert.Error(t, err)
+	_, err = batcher.NewAdaptiveBatchPolicy(batcher.AdaptiveBatchPolicyConfig{MinInterval: time.Hour, MaxInterval: time.Minute})
+	assert.Error(t, err)
+	_, err = batcher.NewAdaptiveBatchPolicy(batcher.AdaptiveBatchPolicyConfig{MaxInterval: time.Minute, TargetGasPrice: big.NewInt(0)})
+	assert.Error(t, err)
+
+	// Without a dispersal throughput nor a max batch size, the batches aren't bounded
+	policy, err := batcher.NewAdaptiveBatchPolicy(batcher.AdaptiveBatchPolicyConfig{MaxInterval: time.Minute})
+	assert.NoError(t, err)
+	decision := policy.Decide(batcher.BatchSignals{SinceLastBatch: time.Second, PendingBlobs: 1, PendingBytes: 1 << 40})
+	assert.Equal(t, batcher.BatchDecision{Reason: batcher.BatchReasonWaiting}, decision)
+}
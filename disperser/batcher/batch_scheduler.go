@@ -0,0 +1,231 @@
+package batcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// AccountTiers assigns the accounts to quota tiers. The weight of a tier is the share of a full batch its accounts
+// get relative to the other accounts.
+type AccountTiers struct {
+	// TierWeights are the weights of the tiers. The accounts without a tier, or whose tier has no weight, have
+	// weight 1
+	TierWeights map[string]float64 `json:"tier_weights"`
+	// Accounts are the tiers of the accounts
+	Accounts map[core.AccountID]string `json:"accounts"`
+}
+
+// ReadAccountTiersFile reads the account tiers from a JSON file such as
+//
+//	{"tier_weights": {"premium": 4}, "accounts": {"0x...": "premium"}}
+func ReadAccountTiersFile(path string) (AccountTiers, error) {
+	var tiers AccountTiers
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tiers, fmt.Errorf("failed to read account tiers file: %w", err)
+	}
+	if err := json.Unmarshal(data, &tiers); err != nil {
+		return tiers, fmt.Errorf("failed to parse account tiers file: %w", err)
+	}
+	for tier, weight := range tiers.TierWeights {
+		if weight <= 0 {
+			return tiers, fmt.Errorf("weight of tier %s must be positive, got %f", tier, weight)
+		}
+	}
+	return tiers, nil
+}
+
+// Weight returns the weight of the tier of the account
+func (t AccountTiers) Weight(account core.AccountID) float64 {
+	if weight, ok := t.TierWeights[t.Accounts[account]]; ok {
+		return weight
+	}
+	return 1
+}
+
+// PendingBlob is an encoded blob waiting to be included in a batch
+type PendingBlob struct {
+	Key       disperser.BlobKey
+	AccountID core.AccountID
+	// Size is the size of the chunks of the blob in bytes
+	Size uint64
+	// RequestedAt is the time the blob was dispersed, in nanoseconds since the epoch
+	RequestedAt uint64
+	// Priority is the priority lane of the blob
+	Priority uint32
+	// GroupID identifies the blobs dispersed together, which are kept in the same batch. It's empty for the blobs
+	// dispersed alone.
+	GroupID string
+}
+
+// BatchScheduler selects the blobs of a batch when the encoded blobs don't fit in it, so that an account dispersing
+// many blobs doesn't crowd out the others. The capacity of the batch is shared with deficit round robin across the
+// priority lanes of the accounts, in proportion to the weights of their tiers times the weights of the lanes, and the
+// blobs of a lane of an account are included in the order they were dispersed.
+//
+// The blobs of a group are scheduled as a single blob of their total size, in the highest lane of their blobs, so that
+// they're either all included in the batch or all deferred.
+//
+// The deficits of the lanes whose next blob didn't fit are carried over to the next batch. The higher lanes are served
+// first, and the lanes of the same priority in the order of the age of their oldest blob, so that the deferred blobs
+// are included first in the next batches rather than being starved by smaller blobs.
+//
+// It's not safe for concurrent use.
+type BatchScheduler struct {
+	maxBatchSize uint64
+	tiers        AccountTiers
+	lanes        PriorityLanes
+	deficits     map[accountLane]float64
+}
+
+// NewBatchScheduler returns a scheduler of batches of up to maxBatchSize bytes of chunks. If maxBatchSize is zero,
+// the batches aren't limited and all the blobs are selected.
+func NewBatchScheduler(maxBatchSize uint64, tiers AccountTiers, lanes PriorityLanes) *BatchScheduler {
+	return &BatchScheduler{
+		maxBatchSize: maxBatchSize,
+		tiers:        tiers,
+		lanes:        lanes,
+		deficits:     make(map[accountLane]float64),
+	}
+}
+
+// accountLane identifies a priority lane of an account
+type accountLane struct {
+	account core.AccountID
+	lane    uint32
+}
+
+// blobGroup holds the blobs scheduled together, i.e. a single blob or the blobs of a group
+type blobGroup struct {
+	blobs       []PendingBlob
+	size        uint64
+	requestedAt uint64
+	priority    uint32
+}
+
+func (g *blobGroup) add(blob PendingBlob) {
+	if len(g.blobs) == 0 {
+		g.requestedAt = blob.RequestedAt
+	}
+	g.blobs = append(g.blobs, blob)
+	g.size += blob.Size
+	g.requestedAt = min(g.requestedAt, blob.RequestedAt)
+	g.priority = max(g.priority, blob.Priority)
+}
+
+// accountQueue holds the pending blob groups of a priority lane of an account, oldest first
+type accountQueue struct {
+	accountLane
+	weight float64
+	groups []*blobGroup
+}
+
+// Schedule splits the pending blobs into the blobs of the next batch and the deferred ones
+func (s *BatchScheduler) Schedule(blobs []PendingBlob) (selected []PendingBlob, deferred []PendingBlob) {
+	if s.maxBatchSize == 0 {
+		return blobs, nil
+	}
+
+	groups := make([]*blobGroup, 0, len(blobs))
+	groupsByID := make(map[string]*blobGroup)
+	for _, blob := range blobs {
+		if blob.GroupID == "" {
+			group := &blobGroup{}
+			group.add(blob)
+			groups = append(groups, group)
+			continue
+		}
+		group, ok := groupsByID[blob.GroupID]
+		if !ok {
+			group = &blobGroup{}
+			groupsByID[blob.GroupID] = group
+			groups = append(groups, group)
+		}
+		group.add(blob)
+	}
+
+	queuesByLane := make(map[accountLane]*accountQueue)
+	queues := make([]*accountQueue, 0)
+	for _, group := range groups {
+		account := group.blobs[0].AccountID
+		key := accountLane{account: account, lane: s.lanes.Lane(group.priority)}
+		queue, ok := queuesByLane[key]
+		if !ok {
+			queue = &accountQueue{accountLane: key, weight: s.tiers.Weight(account) * s.lanes.Weight(group.priority)}
+			queuesByLane[key] = queue
+			queues = append(queues, queue)
+		}
+		queue.groups = append(queue.groups, group)
+	}
+
+	// The deficits of the lanes without pending blobs are dropped, as in deficit round robin
+	for key := range s.deficits {
+		if _, ok := queuesByLane[key]; !ok {
+			delete(s.deficits, key)
+		}
+	}
+
+	maxWeight := 0.0
+	maxSize := uint64(1)
+	for _, queue := range queues {
+		sort.SliceStable(queue.groups, func(i, j int) bool {
+			return queue.groups[i].requestedAt < queue.groups[j].requestedAt
+		})
+		maxWeight = max(maxWeight, queue.weight)
+		for _, group := range queue.groups {
+			maxSize = max(maxSize, group.size)
+		}
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		if queues[i].lane != queues[j].lane {
+			return queues[i].lane > queues[j].lane
+		}
+		if queues[i].groups[0].requestedAt != queues[j].groups[0].requestedAt {
+			return queues[i].groups[0].requestedAt < queues[j].groups[0].requestedAt
+		}
+		return queues[i].account < queues[j].account
+	})
+
+	// Every round, an account of the largest weight can send the largest group
+	quantum := float64(maxSize) / maxWeight
+	remaining := s.maxBatchSize
+	active := queues
+	for len(active) > 0 {
+		next := active[:0]
+		for _, queue := range active {
+			s.deficits[queue.accountLane] += quantum * queue.weight
+			for len(queue.groups) > 0 {
+				group := queue.groups[0]
+				// A group larger than the batch can't be split, it's included alone rather than starved
+				fits := group.size <= remaining || (remaining == s.maxBatchSize && len(selected) == 0)
+				if !fits || float64(group.size) > s.deficits[queue.accountLane] {
+					break
+				}
+				selected = append(selected, group.blobs...)
+				queue.groups = queue.groups[1:]
+				s.deficits[queue.accountLane] -= float64(group.size)
+				remaining -= min(group.size, remaining)
+			}
+
+			switch {
+			case len(queue.groups) == 0:
+				delete(s.deficits, queue.accountLane)
+			case queue.groups[0].size <= remaining:
+				next = append(next, queue)
+			}
+		}
+		active = next
+	}
+
+	for _, queue := range queues {
+		for _, group := range queue.groups {
+			deferred = append(deferred, group.blobs...)
+		}
+	}
+	return selected, deferred
+}
@@ -0,0 +1,174 @@
+package batcher_test
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingBlobs returns count blobs of the account, dispersed in turn from requestedAt
+func pendingBlobs(account core.AccountID, count int, size uint64, requestedAt uint64) []batcher.PendingBlob {
+	blobs := make([]batcher.PendingBlob, count)
+	for i := range blobs {
+		blobs[i] = batcher.PendingBlob{
+			Key:         disperser.BlobKey{BlobHash: fmt.Sprintf("%s-%d-%d", account, requestedAt, i)},
+			AccountID:   account,
+			Size:        size,
+			RequestedAt: requestedAt + uint64(i),
+		}
+	}
+	return blobs
+}
+
+func countByAccount(blobs []batcher.PendingBlob) map[core.AccountID]int {
+	counts := make(map[core.AccountID]int)
+	for _, blob := range blobs {
+		counts[blob.AccountID]++
+	}
+	return counts
+}
+
+func TestBatchSchedulerUnlimited(t *testing.T) {
+	scheduler := batcher.NewBatchScheduler(0, batcher.AccountTiers{}, nil)
+	blobs := pendingBlobs("noisy", 100, 10, 0)
+	selected, deferred := scheduler.Schedule(blobs)
+	assert.Len(t, selected, 100)
+	assert.Empty(t, deferred)
+}
+
+func TestBatchSchedulerNoisyAccount(t *testing.T) {
+	scheduler := batcher.NewBatchScheduler(60, batcher.AccountTiers{}, nil)
+
+	// The noisy account dispersed first, but doesn't crowd out the others
+	blobs := pendingBlobs("noisy", 20, 10, 0)
+	blobs = append(blobs, pendingBlobs("quiet", 2, 10, 100)...)
+	blobs = append(blobs, pendingBlobs("occasional", 1, 10, 200)...)
+	selected, deferred := scheduler.Schedule(blobs)
+
+	assert.Equal(t, map[core.AccountID]int{"noisy": 3, "quiet": 2, "occasional": 1}, countByAccount(selected))
+	assert.Len(t, deferred, 17)
+
+	// The blobs of an account are selected in the order they were dispersed
+	for i, blob := range deferred {
+		assert.Equal(t, uint64(3+i), blob.RequestedAt)
+	}
+}
+
+func TestBatchSchedulerTierWeights(t *testing.T) {
+	tiers := batcher.AccountTiers{
+		TierWeights: map[string]float64{"premium": 3},
+		Accounts:    map[core.AccountID]string{"premium-account": "premium"},
+	}
+	scheduler := batcher.NewBatchScheduler(80, tiers, nil)
+
+	blobs := pendingBlobs("basic-account", 20, 10, 0)
+	blobs = append(blobs, pendingBlobs("premium-account", 20, 10, 0)...)
+	selected, _ := scheduler.Schedule(blobs)
+	assert.Equal(t, map[core.AccountID]int{"premium-account": 6, "basic-account": 2}, countByAccount(selected))
+}
+
+func TestBatchSchedulerNoStarvation(t *testing.T) {
+	scheduler := batcher.NewBatchScheduler(40, batcher.AccountTiers{}, nil)
+
+	// An account with a large blob competes with an account that keeps dispersing small blobs
+	var pending []batcher.PendingBlob
+	large := pendingBlobs("large", 1, 30, 5)
+	pending = append(pending, large...)
+	for batch := 0; batch < 5; batch++ {
+		pending = append(pending, pendingBlobs("small", 10, 5, uint64(batch*100))...)
+		selected, deferred := scheduler.Schedule(pending)
+		if countByAccount(selected)["large"] == 1 {
+			return
+		}
+		pending = deferred
+	}
+	t.Fatal("the large blob was starved")
+}
+
+func TestBatchSchedulerOversizedBlob(t *testing.T) {
+	scheduler := batcher.NewBatchScheduler(40, batcher.AccountTiers{}, nil)
+
+	// A blob larger than a batch is included alone once it's first in line
+	blobs := pendingBlobs("oversized", 1, 100, 0)
+	blobs = append(blobs, pendingBlobs("small", 4, 10, 50)...)
+	selected, deferred := scheduler.Schedule(blobs)
+	require.Len(t, selected, 1)
+	assert.Equal(t, core.AccountID("oversized"), selected[0].AccountID)
+	assert.Len(t, deferred, 4)
+
+	selected, deferred = scheduler.Schedule(deferred)
+	assert.Len(t, selected, 4)
+	assert.Empty(t, deferred)
+}
+
+func TestBatchSchedulerPriorityLanes(t *testing.T) {
+	scheduler := batcher.NewBatchScheduler(100, batcher.AccountTiers{}, batcher.PriorityLanes{1, 4})
+
+	// The latency-sensitive blobs jump ahead of the bulk blobs dispersed before them, which still progress
+	blobs := pendingBlobs("bulk", 20, 10, 0)
+	rollupBlobs := pendingBlobs("rollup", 10, 10, 100)
+	for i := range rollupBlobs {
+		rollupBlobs[i].Priority = 1
+	}
+	blobs = append(blobs, rollupBlobs...)
+	selected, deferred := scheduler.Schedule(blobs)
+	assert.Equal(t, map[core.AccountID]int{"rollup": 8, "bulk": 2}, countByAccount(selected))
+	assert.Len(t, deferred, 20)
+
+	// The lanes of an account are scheduled separately, and the priorities above the highest lane are in it
+	blobs = pendingBlobs("rollup", 20, 10, 0)
+	for _, blob := range pendingBlobs("rollup", 10, 10, 100) {
+		blob.Priority = 7
+		blobs = append(blobs, blob)
+	}
+	selected, _ = batcher.NewBatchScheduler(100, batcher.AccountTiers{}, batcher.PriorityLanes{1, 4}).Schedule(blobs)
+	numPriority := 0
+	for _, blob := range selected {
+		if blob.Priority > 0 {
+			numPriority++
+		}
+	}
+	assert.Equal(t, 8, numPriority)
+}
+
+func TestBatchSchedulerBlobGroups(t *testing.T) {
+	scheduler := batcher.NewBatchScheduler(60, batcher.AccountTiers{}, nil)
+
+	// The blobs of a group don't fit in what's left of the batch, so they're deferred together rather than split
+	blobs := pendingBlobs("other", 4, 10, 0)
+	group := pendingBlobs("rollup", 4, 10, 50)
+	for i := range group {
+		group[i].GroupID = "group"
+	}
+	blobs = append(blobs, group...)
+	selected, deferred := scheduler.Schedule(blobs)
+	assert.Equal(t, map[core.AccountID]int{"other": 4}, countByAccount(selected))
+	assert.Equal(t, group, deferred)
+
+	selected, deferred = scheduler.Schedule(deferred)
+	assert.Equal(t, group, selected)
+	assert.Empty(t, deferred)
+}
+
+func TestReadAccountTiersFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := path.Join(dir, "tiers.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"tier_weights": {"premium": 4}, "accounts": {"0x1234": "premium", "0x5678": "unknown"}}`), 0644))
+
+	tiers, err := batcher.ReadAccountTiersFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, 4.0, tiers.Weight("0x1234"))
+	assert.Equal(t, 1.0, tiers.Weight("0x5678"))
+	assert.Equal(t, 1.0, tiers.Weight("0x9abc"))
+
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"tier_weights": {"premium": 0}}`), 0644))
+	_, err = batcher.ReadAccountTiersFile(filePath)
+	assert.Error(t, err)
+}
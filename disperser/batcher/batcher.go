@@ -5,16 +5,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/threshold"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gammazero/workerpool"
 	"github.com/hashicorp/go-multierror"
@@ -25,6 +30,12 @@ import (
 const (
 	QuantizationFactor = uint(1)
 	indexerWarmupDelay = 2 * time.Second
+	// announcementTimeout is how long the webhooks of the operators have to respond to a batch announcement
+	announcementTimeout = 10 * time.Second
+	// notificationTimeout is how long the callbacks of the blobs have to respond to a blob status notification
+	notificationTimeout = 10 * time.Second
+	// gasPriceRefreshInterval is how often the gas price is fetched for the batch policy, about once per block
+	gasPriceRefreshInterval = 12 * time.Second
 )
 
 type BatchPlan struct {
@@ -46,6 +57,9 @@ type TimeoutConfig struct {
 	ChainWriteTimeout   time.Duration
 	ChainStateTimeout   time.Duration
 	TxnBroadcastTimeout time.Duration
+	// QuorumAttestation are the attestation configs of the quorums given to the signature aggregator. The attestation
+	// window of the batches containing a quorum with a longer timeout than AttestationTimeout is extended to it.
+	QuorumAttestation map[core.QuorumID]core.QuorumAttestationConfig
 }
 
 type Config struct {
@@ -64,6 +78,29 @@ type Config struct {
 
 	TargetNumChunks          uint
 	MaxBlobsToFetchFromStore int
+
+	// LatencySensitiveBlobSize is the size in bytes up to which blobs are encoded with latency priority
+	LatencySensitiveBlobSize uint
+
+	// AccountTiers are the quota tiers of the accounts, which weight their shares of a full batch
+	AccountTiers AccountTiers
+	// PriorityLanes are the weights of the priority lanes of the blobs, which weight their shares of the encoding
+	// and of a full batch
+	PriorityLanes PriorityLanes
+
+	// AnnouncementThreshold is the size in bytes of the encoded blobs from which the upcoming batch is announced to
+	// the operators in OperatorWebhooks, so that their nodes can prepare for it. If zero, no batch is announced
+	AnnouncementThreshold uint64
+	OperatorWebhooks      map[core.OperatorID]OperatorWebhook
+
+	// BatchPolicy decides the boundaries of the batches, evaluated every BatchPolicyInterval. If nil, a batch is cut
+	// every PullInterval or as soon as the encoded blobs reach BatchSizeMBLimit
+	BatchPolicy         BatchPolicy
+	BatchPolicyInterval time.Duration
+
+	// ServiceManagerAddr is the address of the EigenDA service manager, whose BatchConfirmed events tell whether the
+	// in-flight batches of a previous leader were confirmed
+	ServiceManagerAddr gethcommon.Address
 }
 
 type Batcher struct {
@@ -82,10 +119,19 @@ type Batcher struct {
 	TransactionManager    TxnManager
 	Metrics               *Metrics
 	HeartbeatChan         chan time.Time
+	// StateStore is the state shared with the standby batchers. If set, the batches sent for confirmation are
+	// recorded, and the blobs left dispersing by a previous leader are taken over on start.
+	StateStore StateStore
 
 	ethClient common.EthClient
 	finalizer Finalizer
+	notifier  *BlobNotifier
 	logger    logging.Logger
+
+	batchSignals *batchSignalsTracker
+	// gasPrice is the last gas price fetched for the batch policy, at gasPriceTime
+	gasPrice     *big.Int
+	gasPriceTime time.Time
 }
 
 func NewBatcher(
@@ -117,12 +163,19 @@ func NewBatcher(
 		MaxBlobsToFetchFromStore: config.MaxBlobsToFetchFromStore,
 		FinalizationBlockDelay:   config.FinalizationBlockDelay,
 		ChainStateTimeout:        timeoutConfig.ChainStateTimeout,
+		LatencySensitiveBlobSize: config.LatencySensitiveBlobSize,
+		MaxBatchSize:             uint64(config.BatchSizeMBLimit) * 1024 * 1024,
+		AccountTiers:             config.AccountTiers,
+		PriorityLanes:            config.PriorityLanes,
 	}
 	encodingWorkerPool := workerpool.New(config.NumConnections)
 	encodingStreamer, err := NewEncodingStreamer(streamerConfig, queue, chainState, encoderClient, assignmentCoordinator, batchTrigger, encodingWorkerPool, metrics.EncodingStreamerMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
+	if config.AnnouncementThreshold > 0 && len(config.OperatorWebhooks) > 0 {
+		encodingStreamer.BatchAnnouncer = NewBatchAnnouncer(config.AnnouncementThreshold, config.PullInterval, config.OperatorWebhooks, announcementTimeout, logger)
+	}
 
 	return &Batcher{
 		Config:        config,
@@ -142,8 +195,10 @@ func NewBatcher(
 
 		ethClient:     ethClient,
 		finalizer:     finalizer,
+		notifier:      NewBlobNotifier(notificationTimeout, logger),
 		logger:        logger.With("component", "Batcher"),
 		HeartbeatChan: heartbeatChan,
+		batchSignals:  newBatchSignalsTracker(time.Now()),
 	}, nil
 }
 
@@ -160,6 +215,11 @@ func (b *Batcher) Start(ctx context.Context) error {
 		return err
 	}
 	batchTrigger := b.EncodingStreamer.EncodedSizeNotifier
+	if b.StateStore != nil {
+		if err := b.recoverDispersingBlobs(ctx); err != nil {
+			return err
+		}
+	}
 
 	go func() {
 		receiptChan := b.TransactionManager.ReceiptChan()
@@ -180,6 +240,11 @@ func (b *Batcher) Start(ctx context.Context) error {
 
 	b.finalizer.Start(ctx)
 
+	if b.BatchPolicy != nil {
+		go b.runBatchPolicy(ctx, batchTrigger)
+		return nil
+	}
+
 	go func() {
 		ticker := time.NewTicker(b.PullInterval)
 		defer ticker.Stop()
@@ -214,6 +279,66 @@ func (b *Batcher) Start(ctx context.Context) error {
 	return nil
 }
 
+// runBatchPolicy cuts the batches when the batch policy says so, evaluating it every BatchPolicyInterval and whenever
+// the encoded blobs reach the batch size limit
+func (b *Batcher) runBatchPolicy(ctx context.Context, batchTrigger *EncodedSizeNotifier) {
+	ticker := time.NewTicker(b.BatchPolicyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-batchTrigger.Notify:
+		}
+
+		now := time.Now()
+		pendingBlobs, pendingBytes := b.EncodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+		b.batchSignals.sample(now, pendingBytes)
+		signals := b.batchSignals.signals(now, pendingBlobs, pendingBytes, b.currentGasPrice(ctx))
+		decision := b.BatchPolicy.Decide(signals)
+		b.Metrics.RecordBatchDecision(signals, decision)
+		if !decision.Cut {
+			continue
+		}
+
+		b.logger.Debug("cutting a batch", "reason", decision.Reason, "pendingBlobs", pendingBlobs, "pendingBytes", pendingBytes, "targetBytes", decision.TargetBytes)
+		err := b.HandleSingleBatch(ctx)
+		if err != nil {
+			if errors.Is(err, errNoEncodedResults) {
+				b.logger.Warn("no encoded results to make a batch with")
+			} else {
+				b.logger.Error("failed to process a batch", "err", err)
+			}
+		}
+		_, remainingBytes := b.EncodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+		var batchBytes uint64
+		if err == nil && pendingBytes > remainingBytes {
+			batchBytes = pendingBytes - remainingBytes
+		}
+		b.batchSignals.batchCut(time.Now(), batchBytes, remainingBytes)
+	}
+}
+
+// currentGasPrice returns the gas price of L1 for the batch policy, refreshed at most once per gasPriceRefreshInterval.
+// It returns the last known gas price, or nil, if it fails to fetch it.
+func (b *Batcher) currentGasPrice(ctx context.Context) *big.Int {
+	if b.ethClient == nil || time.Since(b.gasPriceTime) < gasPriceRefreshInterval {
+		return b.gasPrice
+	}
+	b.gasPriceTime = time.Now()
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, b.ChainReadTimeout)
+	defer cancel()
+	gasPrice, err := b.ethClient.SuggestGasPrice(ctxWithTimeout)
+	if err != nil {
+		b.logger.Warn("failed to get the gas price for the batch policy", "err", err)
+		return b.gasPrice
+	}
+	b.gasPrice = gasPrice
+	return gasPrice
+}
+
 // updateConfirmationInfo updates the confirmation info for each blob in the batch and returns failed blobs to retry.
 func (b *Batcher) updateConfirmationInfo(
 	ctx context.Context,
@@ -263,15 +388,7 @@ func (b *Batcher) updateConfirmationInfo(
 				blobsToRetry = append(blobsToRetry, batchData.blobs[blobIndex])
 				continue
 			}
-			blobHeader := batchData.blobHeaders[blobIndex]
-
-			blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
-			if err != nil {
-				b.logger.Error("HandleSingleBatch: failed to get blob header hash", "err", err)
-				blobsToRetry = append(blobsToRetry, batchData.blobs[blobIndex])
-				continue
-			}
-			merkleProof, err := batchData.merkleTree.GenerateProof(blobHeaderHash[:], 0)
+			merkleProof, err := batchData.merkleTree.GenerateProofByIndex(uint64(blobIndex))
 			if err != nil {
 				b.logger.Error("HandleSingleBatch: failed to generate blob header inclusion proof", "err", err)
 				blobsToRetry = append(blobsToRetry, batchData.blobs[blobIndex])
@@ -294,11 +411,17 @@ func (b *Batcher) updateConfirmationInfo(
 			Fee:                     []byte{0}, // No fee
 			QuorumResults:           batchData.aggSig.QuorumResults,
 			BlobQuorumInfos:         batchData.blobHeaders[blobIndex].QuorumInfos,
+			Cost:                    blobCost(batchData, blobIndex, txnReceipt),
+			AttestationTimeout:      batchData.attestationTimeout,
+			OperatorResponses:       batchData.operatorResponses,
 		}
 
 		if status == disperser.Confirmed {
-			if _, updateConfirmationInfoErr = b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
+			var confirmedMetadata *disperser.BlobMetadata
+			if confirmedMetadata, updateConfirmationInfoErr = b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
 				b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.Confirmed)
+				b.recordUsage(ctx, metadata, confirmationInfo.Cost)
+				b.notifier.Notify(ctx, confirmedMetadata)
 			}
 		} else if status == disperser.InsufficientSignatures {
 			if _, updateConfirmationInfoErr = b.Queue.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
@@ -308,11 +431,11 @@ func (b *Batcher) updateConfirmationInfo(
 			updateConfirmationInfoErr = fmt.Errorf("HandleSingleBatch: trying to update confirmation info for blob in status other than confirmed or insufficient signatures: %s", status.String())
 		}
 		if updateConfirmationInfoErr != nil {
-			b.logger.Error("HandleSingleBatch: error updating blob confirmed metadata", "err", updateConfirmationInfoErr)
+			b.logger.Error("HandleSingleBatch: error updating blob confirmed metadata", "err", updateConfirmationInfoErr, "correlationID", metadata.RequestMetadata.CorrelationID)
 			blobsToRetry = append(blobsToRetry, batchData.blobs[blobIndex])
 		}
 		requestTime := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
-		b.Metrics.ObserveLatency("E2E", float64(time.Since(requestTime).Milliseconds()))
+		b.Metrics.ObserveE2ELatency(float64(time.Since(requestTime).Milliseconds()), metadata.RequestMetadata.CorrelationID)
 	}
 
 	return blobsToRetry, nil
@@ -320,13 +443,16 @@ func (b *Batcher) updateConfirmationInfo(
 
 func (b *Batcher) ProcessConfirmedBatch(ctx context.Context, receiptOrErr *ReceiptOrErr) error {
 	if receiptOrErr.Metadata == nil {
-		return errors.New("failed to process confirmed batch: no metadata from transaction manager response")
+		return b.processRecoveredReceipt(ctx, receiptOrErr)
 	}
 	confirmationMetadata := receiptOrErr.Metadata.(confirmationMetadata)
 	blobs := confirmationMetadata.blobs
 	if len(blobs) == 0 {
 		return errors.New("failed to process confirmed batch: no blobs from transaction manager metadata")
 	}
+	if headerHash, err := confirmationMetadata.batchHeader.GetBatchHeaderHash(); err == nil {
+		defer b.untrackInflightBatch(ctx, headerHash)
+	}
 	if receiptOrErr.Err != nil {
 		_ = b.handleFailure(ctx, blobs, FailConfirmBatch)
 		return fmt.Errorf("failed to confirm batch onchain: %w", receiptOrErr.Err)
@@ -359,6 +485,28 @@ func (b *Batcher) ProcessConfirmedBatch(ctx context.Context, receiptOrErr *Recei
 	return nil
 }
 
+// recordUsage adds the usage of the confirmed blob to the usage ledger of its account, unless it was recorded when the
+// blob was confirmed in a batch later dropped by a reorg. The blob stays confirmed if its usage can't be recorded.
+func (b *Batcher) recordUsage(ctx context.Context, metadata *disperser.BlobMetadata, cost *disperser.BlobCost) {
+	usage := disperser.BlobUsage(metadata, cost, time.Now())
+	if usage == nil {
+		return
+	}
+	if err := b.Queue.AddAccountUsage(ctx, metadata.GetBlobKey(), usage); err != nil {
+		b.logger.Error("failed to record the usage of a blob", "accountID", usage.AccountID, "blobKey", metadata.GetBlobKey().String(), "err", err)
+	}
+}
+
+// blobCorrelationIDs returns the correlation IDs of the blobs, which join the log lines of the batch with those of
+// the disperser, the encoders and the nodes
+func blobCorrelationIDs(metadatas []*disperser.BlobMetadata) []string {
+	ids := make([]string, len(metadatas))
+	for i, metadata := range metadatas {
+		ids[i] = metadata.RequestMetadata.CorrelationID
+	}
+	return ids
+}
+
 func (b *Batcher) handleFailure(ctx context.Context, blobMetadatas []*disperser.BlobMetadata, reason FailReason) error {
 	var result *multierror.Error
 	numPermanentFailures := 0
@@ -366,7 +514,7 @@ func (b *Batcher) handleFailure(ctx context.Context, blobMetadatas []*disperser.
 		b.EncodingStreamer.RemoveEncodedBlob(metadata)
 		retry, err := b.Queue.HandleBlobFailure(ctx, metadata, b.MaxNumRetriesPerBlob)
 		if err != nil {
-			b.logger.Error("HandleSingleBatch: error handling blob failure", "err", err)
+			b.logger.Error("HandleSingleBatch: error handling blob failure", "err", err, "correlationID", metadata.RequestMetadata.CorrelationID)
 			// Append the error
 			result = multierror.Append(result, err)
 		}
@@ -374,6 +522,7 @@ func (b *Batcher) handleFailure(ctx context.Context, blobMetadatas []*disperser.
 		if retry {
 			continue
 		}
+		b.logger.Warn("blob failed permanently", "reason", reason, "blobKey", metadata.GetBlobKey().String(), "correlationID", metadata.RequestMetadata.CorrelationID)
 
 		if reason == FailNoSignatures {
 			b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.InsufficientSignatures)
@@ -392,8 +541,109 @@ type confirmationMetadata struct {
 	batchHeader *core.BatchHeader
 	blobs       []*disperser.BlobMetadata
 	blobHeaders []*core.BlobHeader
-	merkleTree  *merkletree.MerkleTree
+	merkleTree  *core.MerkleTree
 	aggSig      *core.SignatureAggregation
+	blobCosts   []*disperser.BlobCost
+
+	attestationTimeout time.Duration
+	operatorResponses  []*disperser.OperatorResponse
+}
+
+// batchAttestationTimeout returns the attestation window of a batch, which is the shortest of the attestation timeout
+// of the batcher and the ones requested for its blobs
+func batchAttestationTimeout(timeout time.Duration, blobs []*disperser.BlobMetadata) time.Duration {
+	for _, blob := range blobs {
+		if blob.RequestMetadata == nil {
+			continue
+		}
+		if requested := blob.RequestMetadata.AttestationTimeout; requested > 0 {
+			timeout = min(timeout, requested)
+		}
+	}
+	return timeout
+}
+
+// quorumAttestationTimeout returns the attestation window of the given quorums, which is the longest of the attestation
+// timeout of the batcher and the timeouts of the quorums
+func quorumAttestationTimeout(timeout time.Duration, configs map[core.QuorumID]core.QuorumAttestationConfig, quorumIDs []core.QuorumID) time.Duration {
+	for _, quorumID := range quorumIDs {
+		if config, ok := configs[quorumID]; ok {
+			timeout = max(timeout, config.Timeout)
+		}
+	}
+	return timeout
+}
+
+// recordResponseTimes forwards the messages of the operators to the signature aggregator, recording their response
+// times. The returned function reads the response times recorded so far, since the aggregator can return before all
+// the operators responded.
+func recordResponseTimes(update chan core.SignerMessage, numOperators int) (chan core.SignerMessage, func() map[core.OperatorID]time.Duration) {
+	forwarded := make(chan core.SignerMessage, numOperators)
+	var mu sync.Mutex
+	responseTimes := make(map[core.OperatorID]time.Duration, numOperators)
+	go func() {
+		for i := 0; i < numOperators; i++ {
+			msg := <-update
+			if msg.ResponseTime > 0 {
+				mu.Lock()
+				responseTimes[msg.Operator] = msg.ResponseTime
+				mu.Unlock()
+			}
+			forwarded <- msg
+		}
+	}()
+	return forwarded, func() map[core.OperatorID]time.Duration {
+		mu.Lock()
+		defer mu.Unlock()
+		return maps.Clone(responseTimes)
+	}
+}
+
+// operatorResponses reports the responses of the operators the batch was sent to against its attestation window,
+// ordered by operator ID
+func operatorResponses(responseTimes map[core.OperatorID]time.Duration, signers map[core.OperatorID]bool, attestationTimeout time.Duration) []*disperser.OperatorResponse {
+	responses := make([]*disperser.OperatorResponse, 0, len(responseTimes))
+	for operatorID, responseTime := range responseTimes {
+		signed := signers[operatorID]
+		responses = append(responses, &disperser.OperatorResponse{
+			OperatorID:   operatorID,
+			ResponseTime: min(responseTime, attestationTimeout),
+			Signed:       signed,
+			WithinSLA:    signed && responseTime <= attestationTimeout,
+		})
+	}
+	slices.SortFunc(responses, func(a, b *disperser.OperatorResponse) int {
+		return bytes.Compare(a.OperatorID[:], b.OperatorID[:])
+	})
+	return responses
+}
+
+// blobCost returns the cost of a blob of a confirmed batch, attributing it an even share of the confirmation
+// transaction.
+func blobCost(batchData confirmationMetadata, blobIndex int, txnReceipt *types.Receipt) *disperser.BlobCost {
+	var cost *disperser.BlobCost
+	if blobIndex < len(batchData.blobCosts) {
+		cost = batchData.blobCosts[blobIndex]
+	}
+	return withGasCost(cost, len(batchData.blobs), txnReceipt)
+}
+
+// withGasCost returns a copy of the cost of a blob with its share of the confirmation transaction of a batch of
+// numBlobs blobs
+func withGasCost(blobCost *disperser.BlobCost, numBlobs int, txnReceipt *types.Receipt) *disperser.BlobCost {
+	cost := &disperser.BlobCost{}
+	if blobCost != nil {
+		*cost = *blobCost
+	}
+	cost.GasUsed = txnReceipt.GasUsed / uint64(numBlobs)
+	if txnReceipt.EffectiveGasPrice != nil {
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(txnReceipt.GasUsed), txnReceipt.EffectiveGasPrice)
+		fee.Div(fee, big.NewInt(int64(numBlobs)))
+		if fee.IsUint64() {
+			cost.GasFee = fee.Uint64()
+		}
+	}
+	return cost
 }
 
 func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
@@ -416,10 +666,19 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	log.Debug("CreateBatch took", "duration", time.Since(stageTimer))
 
 	// Dispatch encoded batch
-	log.Debug("Dispatching encoded batch...")
+	log.Debug("Dispatching encoded batch...", "correlationIDs", blobCorrelationIDs(batch.BlobMetadata))
 	stageTimer = time.Now()
-	update := b.Dispatcher.DisperseBatch(ctx, batch.State, batch.EncodedBlobs, batch.BatchHeader)
-	log.Debug("DisperseBatch took", "duration", time.Since(stageTimer))
+	dispersalStart := stageTimer
+	quorumIDs := make([]core.QuorumID, 0, len(batch.State.AggKeys))
+	for quorumID := range batch.State.Operators {
+		quorumIDs = append(quorumIDs, quorumID)
+	}
+	slices.Sort(quorumIDs)
+	attestationTimeout := batchAttestationTimeout(quorumAttestationTimeout(b.AttestationTimeout, b.QuorumAttestation, quorumIDs), batch.BlobMetadata)
+	dispersalCtx, cancelDispersal := context.WithTimeout(ctx, attestationTimeout)
+	defer cancelDispersal()
+	update, responseTimes := recordResponseTimes(b.Dispatcher.DisperseBatch(dispersalCtx, batch.State, batch.EncodedBlobs, batch.BatchHeader), len(batch.State.IndexedOperators))
+	log.Debug("DisperseBatch took", "duration", time.Since(stageTimer), "attestationTimeout", attestationTimeout)
 
 	// Get the batch header hash
 	log.Debug("Getting batch header hash...")
@@ -431,14 +690,6 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 
 	// Aggregate the signatures
 	log.Debug("Aggregating signatures...")
-
-	// construct quorumParams
-	quorumIDs := make([]core.QuorumID, 0, len(batch.State.AggKeys))
-	for quorumID := range batch.State.Operators {
-		quorumIDs = append(quorumIDs, quorumID)
-	}
-	slices.Sort(quorumIDs)
-
 	stageTimer = time.Now()
 	aggSig, err := b.Aggregator.AggregateSignatures(ctx, batch.State, quorumIDs, headerHash, update)
 	if err != nil {
@@ -447,6 +698,9 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	}
 	log.Debug("AggregateSignatures took", "duration", time.Since(stageTimer))
 	b.Metrics.ObserveLatency("AggregateSignatures", float64(time.Since(stageTimer).Milliseconds()))
+	if b.batchSignals != nil {
+		b.batchSignals.dispersalTime = time.Since(dispersalStart)
+	}
 	operatorCount := make(map[core.QuorumID]int)
 	signerCount := make(map[core.QuorumID]int)
 	for quorumID, opState := range batch.State.Operators {
@@ -464,6 +718,7 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	for _, quorumResult := range aggSig.QuorumResults {
 		log.Info("Aggregated quorum result", "quorumID", quorumResult.QuorumID, "percentSigned", quorumResult.PercentSigned)
 	}
+	log.Info("Aggregated signatures", "batchHeaderHash", hexutil.Encode(headerHash[:]), "numSigners", len(aggSig.SignerMap), "correlationIDs", blobCorrelationIDs(batch.BlobMetadata))
 
 	numPassed := numBlobsAttested(aggSig.QuorumResults, batch.BlobHeaders)
 	// TODO(mooselumph): Determine whether to confirm the batch based on the number of successes
@@ -480,14 +735,23 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 		_ = b.handleFailure(ctx, batch.BlobMetadata, FailConfirmBatch)
 		return fmt.Errorf("HandleSingleBatch: error building confirmBatch transaction: %w", err)
 	}
+	if err := b.trackInflightBatch(ctx, headerHash, batch, aggSig, attestationTimeout); err != nil {
+		_ = b.handleFailure(ctx, batch.BlobMetadata, FailConfirmBatch)
+		return fmt.Errorf("HandleSingleBatch: error recording in-flight batch: %w", err)
+	}
 	err = b.TransactionManager.ProcessTransaction(ctx, NewTxnRequest(txn, "confirmBatch", big.NewInt(0), confirmationMetadata{
 		batchHeader: batch.BatchHeader,
 		blobs:       batch.BlobMetadata,
 		blobHeaders: batch.BlobHeaders,
 		merkleTree:  batch.MerkleTree,
 		aggSig:      aggSig,
+		blobCosts:   batch.BlobCosts,
+
+		attestationTimeout: attestationTimeout,
+		operatorResponses:  operatorResponses(responseTimes(), aggSig.SignerMap, attestationTimeout),
 	}))
 	if err != nil {
+		b.untrackInflightBatch(ctx, headerHash)
 		_ = b.handleFailure(ctx, batch.BlobMetadata, FailConfirmBatch)
 		return fmt.Errorf("HandleSingleBatch: error sending confirmBatch transaction: %w", err)
 	}
@@ -515,30 +779,35 @@ func (b *Batcher) parseBatchIDFromReceipt(txReceipt *types.Receipt) (uint32, err
 		b.logger.Debug("[getBatchIDFromReceipt] ", "sigHash", log.Topics[0].Hex())
 
 		if log.Topics[0] == common.BatchConfirmedEventSigHash {
-			smAbi, err := abi.JSON(bytes.NewReader(common.ServiceManagerAbi))
-			if err != nil {
-				return 0, fmt.Errorf("failed to parse ServiceManager ABI: %w", err)
-			}
-			eventAbi, err := smAbi.EventByID(common.BatchConfirmedEventSigHash)
-			if err != nil {
-				return 0, fmt.Errorf("failed to parse BatchConfirmed event ABI: %w", err)
-			}
-			unpackedData, err := eventAbi.Inputs.Unpack(log.Data)
-			if err != nil {
-				return 0, fmt.Errorf("failed to unpack BatchConfirmed log data: %w", err)
-			}
-
-			// There should be exactly one input in the data field, batchId.
-			// Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L17
-			if len(unpackedData) != 1 {
-				return 0, fmt.Errorf("BatchConfirmed log should contain exactly 1 inputs. Found %d", len(unpackedData))
-			}
-			return unpackedData[0].(uint32), nil
+			return parseBatchIDFromLog(log)
 		}
 	}
 	return 0, errors.New("failed to find BatchConfirmed log from the transaction")
 }
 
+// parseBatchIDFromLog returns the batch ID of a BatchConfirmed log
+func parseBatchIDFromLog(log *types.Log) (uint32, error) {
+	smAbi, err := abi.JSON(bytes.NewReader(common.ServiceManagerAbi))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ServiceManager ABI: %w", err)
+	}
+	eventAbi, err := smAbi.EventByID(common.BatchConfirmedEventSigHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse BatchConfirmed event ABI: %w", err)
+	}
+	unpackedData, err := eventAbi.Inputs.Unpack(log.Data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unpack BatchConfirmed log data: %w", err)
+	}
+
+	// There should be exactly one input in the data field, batchId.
+	// Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L17
+	if len(unpackedData) != 1 {
+		return 0, fmt.Errorf("BatchConfirmed log should contain exactly 1 inputs. Found %d", len(unpackedData))
+	}
+	return unpackedData[0].(uint32), nil
+}
+
 func (b *Batcher) getBatchID(ctx context.Context, txReceipt *types.Receipt) (uint32, error) {
 	const (
 		maxRetries = 4
@@ -585,7 +854,7 @@ func numBlobsAttested(signedQuorums map[core.QuorumID]*core.QuorumResult, header
 	for _, blob := range headers {
 		thisPassed := true
 		for _, quorum := range blob.QuorumInfos {
-			if signedQuorums[quorum.QuorumID].PercentSigned < quorum.ConfirmationThreshold {
+			if !threshold.PercentMeetsThreshold(signedQuorums[quorum.QuorumID].PercentSigned, quorum.ConfirmationThreshold) {
 				thisPassed = false
 				break
 			}
@@ -600,7 +869,7 @@ func numBlobsAttested(signedQuorums map[core.QuorumID]*core.QuorumResult, header
 
 func isBlobAttested(signedQuorums map[core.QuorumID]*core.QuorumResult, header *core.BlobHeader) bool {
 	for _, quorum := range header.QuorumInfos {
-		if signedQuorums[quorum.QuorumID].PercentSigned < quorum.ConfirmationThreshold {
+		if !threshold.PercentMeetsThreshold(signedQuorums[quorum.QuorumID].PercentSigned, quorum.ConfirmationThreshold) {
 			return false
 		}
 	}
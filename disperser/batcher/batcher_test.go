@@ -29,6 +29,7 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
 )
 
 var (
@@ -179,6 +180,8 @@ func TestBatcherIterations(t *testing.T) {
 		AdversaryThreshold:    70,
 		ConfirmationThreshold: 100,
 	}})
+	// The attestation window requested for blob2 shortens the one of the batch
+	blob2.RequestHeader.AttestationTimeout = 5 * time.Second
 	components, batcher, getHeartbeats := makeBatcher(t)
 
 	defer func() {
@@ -201,8 +204,10 @@ func TestBatcherIterations(t *testing.T) {
 				Data:   logData,
 			},
 		},
-		BlockNumber: blockNumber,
-		TxHash:      txHash,
+		BlockNumber:       blockNumber,
+		TxHash:            txHash,
+		GasUsed:           1000,
+		EffectiveGasPrice: big.NewInt(3),
 	}
 	blobStore := components.blobStore
 	ctx := context.Background()
@@ -249,6 +254,27 @@ func TestBatcherIterations(t *testing.T) {
 	assert.Equal(t, blobKey2, meta2.GetBlobKey())
 	assert.Equal(t, disperser.Confirmed, meta2.BlobStatus)
 
+	// the confirmation cost is split evenly between the blobs
+	for _, meta := range []*disperser.BlobMetadata{meta1, meta2} {
+		assert.NotNil(t, meta.ConfirmationInfo.Cost)
+		assert.Greater(t, meta.ConfirmationInfo.Cost.EncodingTime, time.Duration(0))
+		assert.Greater(t, meta.ConfirmationInfo.Cost.DispersalBytes, uint64(0))
+		assert.Equal(t, uint64(500), meta.ConfirmationInfo.Cost.GasUsed)
+		assert.Equal(t, uint64(1500), meta.ConfirmationInfo.Cost.GasFee)
+	}
+	assert.LessOrEqual(t, meta1.ConfirmationInfo.Cost.DispersalBytes+meta2.ConfirmationInfo.Cost.DispersalBytes, uint64(24576))
+
+	// the responses of the operators are reported against the attestation window of the batch
+	for _, meta := range []*disperser.BlobMetadata{meta1, meta2} {
+		assert.Equal(t, 5*time.Second, meta.ConfirmationInfo.AttestationTimeout)
+		assert.Len(t, meta.ConfirmationInfo.OperatorResponses, 10)
+		for _, response := range meta.ConfirmationInfo.OperatorResponses {
+			assert.True(t, response.Signed)
+			assert.True(t, response.WithinSLA)
+			assert.LessOrEqual(t, response.ResponseTime, 5*time.Second)
+		}
+	}
+
 	res, err := components.encodingStreamer.EncodedBlobstore.GetEncodingResult(meta1.GetBlobKey(), 0)
 	assert.ErrorContains(t, err, "no such key")
 	assert.Nil(t, res)
@@ -554,3 +580,134 @@ func TestRetryTxnReceipt(t *testing.T) {
 	assert.Equal(t, meta.ConfirmationInfo.BatchID, uint32(3))
 	components.ethClient.AssertNumberOfCalls(t, "TransactionReceipt", 3)
 }
+
+func TestTakeOverConfirmedInflightBatch(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	batcher.StateStore = bat.NewLocalStateStore()
+	blobStore := components.blobStore
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	// The batch is sent for confirmation, but the batcher stops before processing the receipt
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn").Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Dispersing, meta.BlobStatus)
+	inflightBatches, err := batcher.StateStore.GetInflightBatches(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, inflightBatches, 1)
+
+	// The batcher taking over finds the batch confirmed onchain
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	txHash := gethcommon.HexToHash("0x1234")
+	components.transactor.On("GetBlockStaleMeasure").Return(nil)
+	components.transactor.On("GetCurrentBlockNumber").Return(uint32(200), nil)
+	components.ethClient.On("FilterLogs", tmock.Anything).Return([]types.Log{{
+		Topics:      []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.Hash(inflightBatches[0].BatchHeaderHash)},
+		Data:        logData,
+		TxHash:      txHash,
+		BlockNumber: 123,
+	}}, nil)
+	components.ethClient.On("TransactionReceipt").Return(&types.Receipt{
+		TxHash:            txHash,
+		BlockNumber:       big.NewInt(123),
+		GasUsed:           1000,
+		EffectiveGasPrice: big.NewInt(3),
+	}, nil)
+	components.txnManager.On("ReceiptChan").Return(make(chan *bat.ReceiptOrErr))
+	err = batcher.Start(ctx)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		return err == nil && meta.BlobStatus == disperser.Confirmed
+	}, 5*time.Second, 50*time.Millisecond)
+	meta, err = blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), meta.ConfirmationInfo.BatchID)
+	assert.Equal(t, txHash, meta.ConfirmationInfo.ConfirmationTxnHash)
+	assert.Equal(t, uint32(123), meta.ConfirmationInfo.ConfirmationBlockNumber)
+	assert.Equal(t, inflightBatches[0].BatchHeaderHash, meta.ConfirmationInfo.BatchHeaderHash)
+	assert.NotNil(t, meta.ConfirmationInfo.BlobCommitment)
+	assert.Equal(t, uint64(1000), meta.ConfirmationInfo.Cost.GasUsed)
+	assert.Equal(t, uint64(3000), meta.ConfirmationInfo.Cost.GasFee)
+	assert.Eventually(t, func() bool {
+		inflightBatches, err := batcher.StateStore.GetInflightBatches(ctx)
+		return err == nil && len(inflightBatches) == 0
+	}, time.Second, 50*time.Millisecond)
+}
+
+func TestProcessRecoveredReceipt(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	batcher.StateStore = bat.NewLocalStateStore()
+	blobStore := components.blobStore
+	ctx := context.Background()
+
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn").Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	inflightBatches, err := batcher.StateStore.GetInflightBatches(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, inflightBatches, 1)
+
+	// The receipt of the confirmation transaction recovered after a restart comes without metadata
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	txHash := gethcommon.HexToHash("0x1234")
+	err = batcher.ProcessConfirmedBatch(ctx, &bat.ReceiptOrErr{
+		Receipt: &types.Receipt{
+			Logs: []*types.Log{{
+				Topics:      []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.Hash(inflightBatches[0].BatchHeaderHash)},
+				Data:        logData,
+				TxHash:      txHash,
+				BlockNumber: 123,
+			}},
+			TxHash:      txHash,
+			BlockNumber: big.NewInt(123),
+			GasUsed:     1000,
+		},
+	})
+	assert.NoError(t, err)
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+	assert.Equal(t, uint32(3), meta.ConfirmationInfo.BatchID)
+	assert.Equal(t, txHash, meta.ConfirmationInfo.ConfirmationTxnHash)
+	inflightBatches, err = batcher.StateStore.GetInflightBatches(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, inflightBatches)
+
+	// A failed recovered transaction leaves the blobs of its batch to be requeued once it's stale
+	err = batcher.ProcessConfirmedBatch(ctx, &bat.ReceiptOrErr{Err: errors.New("reverted")})
+	assert.ErrorContains(t, err, "recovered confirmation transaction failed")
+}
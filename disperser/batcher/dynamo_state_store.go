@@ -0,0 +1,235 @@
+package batcher
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// The items of the state table are partitioned by StateType, and identified within their type by StateID
+	leaseStateType         = "lease"
+	leaseStateID           = "batcher"
+	inflightBatchStateType = "inflight-batch"
+	inflightTxnStateType   = "inflight-txn"
+)
+
+type leaseItem struct {
+	StateType  string
+	StateID    string
+	LeaseOwner string
+	// Expiry is the unix time in milliseconds at which the lease expires
+	Expiry int64
+}
+
+type inflightBatchItem struct {
+	StateType            string
+	StateID              string
+	ReferenceBlockNumber uint
+	BlobKeys             []disperser.BlobKey
+	Blobs                []*InflightBlob
+	SignatoryRecordHash  [32]byte
+	BatchRoot            []byte
+	QuorumResults        map[core.QuorumID]*core.QuorumResult
+	AttestationTimeout   time.Duration
+}
+
+type inflightTxnItem struct {
+	StateType string
+	StateID   string
+	Tag       string
+	Tx        []byte
+	TxIDs     []walletsdk.TxID
+}
+
+type dynamoStateStore struct {
+	client    *commondynamodb.Client
+	tableName string
+}
+
+var _ StateStore = (*dynamoStateStore)(nil)
+
+// NewDynamoStateStore returns a StateStore persisted in the DynamoDB table, which can be shared by batchers running
+// on different hosts. The expiry of the lease is compared with the clock of the batchers, which must be synchronized
+// much closer than the lease duration.
+func NewDynamoStateStore(client *commondynamodb.Client, tableName string) StateStore {
+	return &dynamoStateStore{
+		client:    client,
+		tableName: tableName,
+	}
+}
+
+func (s *dynamoStateStore) AcquireLease(ctx context.Context, owner string, expiry time.Time) (bool, error) {
+	item, err := attributevalue.MarshalMap(leaseItem{
+		StateType:  leaseStateType,
+		StateID:    leaseStateID,
+		LeaseOwner: owner,
+		Expiry:     expiry.UnixMilli(),
+	})
+	if err != nil {
+		return false, err
+	}
+	err = s.client.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(StateType) OR Expiry < :now OR LeaseOwner = :owner", commondynamodb.ExpresseionValues{
+		":now": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(time.Now().UnixMilli(), 10),
+		},
+		":owner": &types.AttributeValueMemberS{
+			Value: owner,
+		},
+	})
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire the lease: %w", err)
+	}
+	return true, nil
+}
+
+func (s *dynamoStateStore) PutInflightBatch(ctx context.Context, batch *InflightBatch) error {
+	item, err := attributevalue.MarshalMap(inflightBatchItem{
+		StateType:            inflightBatchStateType,
+		StateID:              hex.EncodeToString(batch.BatchHeaderHash[:]),
+		ReferenceBlockNumber: batch.ReferenceBlockNumber,
+		BlobKeys:             batch.BlobKeys,
+		Blobs:                batch.Blobs,
+		SignatoryRecordHash:  batch.SignatoryRecordHash,
+		BatchRoot:            batch.BatchRoot,
+		QuorumResults:        batch.QuorumResults,
+		AttestationTimeout:   batch.AttestationTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.PutItem(ctx, s.tableName, item)
+}
+
+func (s *dynamoStateStore) DeleteInflightBatch(ctx context.Context, batchHeaderHash [32]byte) error {
+	return s.client.DeleteItem(ctx, s.tableName, commondynamodb.Key{
+		"StateType": &types.AttributeValueMemberS{Value: inflightBatchStateType},
+		"StateID":   &types.AttributeValueMemberS{Value: hex.EncodeToString(batchHeaderHash[:])},
+	})
+}
+
+func (s *dynamoStateStore) GetInflightBatches(ctx context.Context) ([]*InflightBatch, error) {
+	items, err := s.client.Query(ctx, s.tableName, "StateType = :type", commondynamodb.ExpresseionValues{
+		":type": &types.AttributeValueMemberS{Value: inflightBatchStateType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	batches := make([]*InflightBatch, 0, len(items))
+	for _, item := range items {
+		batchItem := inflightBatchItem{}
+		if err := attributevalue.UnmarshalMap(item, &batchItem); err != nil {
+			return nil, err
+		}
+		batchHeaderHash, err := hex.DecodeString(batchItem.StateID)
+		if err != nil || len(batchHeaderHash) != 32 {
+			return nil, fmt.Errorf("invalid batch header hash of in-flight batch: %s", batchItem.StateID)
+		}
+		batch := &InflightBatch{
+			ReferenceBlockNumber: batchItem.ReferenceBlockNumber,
+			BlobKeys:             batchItem.BlobKeys,
+			Blobs:                batchItem.Blobs,
+			SignatoryRecordHash:  batchItem.SignatoryRecordHash,
+			BatchRoot:            batchItem.BatchRoot,
+			QuorumResults:        batchItem.QuorumResults,
+			AttestationTimeout:   batchItem.AttestationTimeout,
+		}
+		copy(batch.BatchHeaderHash[:], batchHeaderHash)
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+func (s *dynamoStateStore) PutInflightTxn(ctx context.Context, txn *InflightTxn) error {
+	item, err := attributevalue.MarshalMap(inflightTxnItem{
+		StateType: inflightTxnStateType,
+		StateID:   strconv.FormatUint(txn.Nonce, 10),
+		Tag:       txn.Tag,
+		Tx:        txn.Tx,
+		TxIDs:     txn.TxIDs,
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.PutItem(ctx, s.tableName, item)
+}
+
+func (s *dynamoStateStore) DeleteInflightTxn(ctx context.Context, nonce uint64) error {
+	return s.client.DeleteItem(ctx, s.tableName, commondynamodb.Key{
+		"StateType": &types.AttributeValueMemberS{Value: inflightTxnStateType},
+		"StateID":   &types.AttributeValueMemberS{Value: strconv.FormatUint(nonce, 10)},
+	})
+}
+
+func (s *dynamoStateStore) GetInflightTxns(ctx context.Context) ([]*InflightTxn, error) {
+	items, err := s.client.Query(ctx, s.tableName, "StateType = :type", commondynamodb.ExpresseionValues{
+		":type": &types.AttributeValueMemberS{Value: inflightTxnStateType},
+	})
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]*InflightTxn, 0, len(items))
+	for _, item := range items {
+		txnItem := inflightTxnItem{}
+		if err := attributevalue.UnmarshalMap(item, &txnItem); err != nil {
+			return nil, err
+		}
+		nonce, err := strconv.ParseUint(txnItem.StateID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce of in-flight transaction: %s", txnItem.StateID)
+		}
+		txns = append(txns, &InflightTxn{
+			Nonce: nonce,
+			Tag:   txnItem.Tag,
+			Tx:    txnItem.Tx,
+			TxIDs: txnItem.TxIDs,
+		})
+	}
+	return txns, nil
+}
+
+// GenerateStateTableSchema returns the schema of the DynamoDB table of a StateStore
+func GenerateStateTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("StateType"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("StateID"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("StateType"),
+				KeyType:       types.KeyTypeHash,
+			},
+			{
+				AttributeName: aws.String("StateID"),
+				KeyType:       types.KeyTypeRange,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
@@ -3,6 +3,7 @@ package batcher
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -31,6 +32,8 @@ type EncodingResult struct {
 	Commitment           *encoding.BlobCommitments
 	Chunks               []*encoding.Frame
 	Assignments          map[core.OperatorID]core.Assignment
+	// EncodingTime is the time the encoder took to encode the blob for the quorum
+	EncodingTime time.Duration
 }
 
 // EncodingResultOrStatus is a wrapper for EncodingResult that also contains an error
@@ -165,6 +168,27 @@ func (e *encodedBlobStore) GetEncodedResultSize() (int, uint64) {
 	return len(e.encoded), e.encodedResultSize
 }
 
+// GetEncodedResultSummary returns the number of blobs in the encoded results, and the total size in bytes of the chunks
+// assigned to each operator
+func (e *encodedBlobStore) GetEncodedResultSummary() (int, map[core.OperatorID]uint64) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	blobs := make(map[disperser.BlobKey]struct{})
+	operatorSizes := make(map[core.OperatorID]uint64)
+	for _, result := range e.encoded {
+		blobs[result.BlobMetadata.GetBlobKey()] = struct{}{}
+		if len(result.Chunks) == 0 {
+			continue
+		}
+		chunkSize := getChunksSize(result) / uint64(len(result.Chunks))
+		for operatorID, assignment := range result.Assignments {
+			operatorSizes[operatorID] += uint64(assignment.NumChunks) * chunkSize
+		}
+	}
+	return len(blobs), operatorSizes
+}
+
 func getRequestID(key disperser.BlobKey, quorumID core.QuorumID) requestID {
 	return requestID(fmt.Sprintf("%s-%d", key.String(), quorumID))
 }
@@ -13,7 +13,6 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
-	"github.com/wealdtech/go-merkletree"
 )
 
 const encodingInterval = 2 * time.Second
@@ -51,6 +50,21 @@ type StreamerConfig struct {
 	MaxBlobsToFetchFromStore int
 
 	FinalizationBlockDelay uint
+
+	// LatencySensitiveBlobSize is the size in bytes up to which blobs are encoded with latency priority, so that
+	// the encoder pauses larger blobs while encoding them. The blobs of the priority lanes above the default one are
+	// always encoded with latency priority. If zero, the other blobs are encoded with bulk priority
+	LatencySensitiveBlobSize uint
+
+	// MaxBatchSize is the maximum size of the chunks of a batch in bytes. When the encoded blobs don't fit in a
+	// batch, they are selected fairly across the accounts, and the others are left for the next batches. If zero,
+	// batches aren't limited
+	MaxBatchSize uint64
+	// AccountTiers are the quota tiers of the accounts, which weight their shares of a full batch
+	AccountTiers AccountTiers
+	// PriorityLanes are the weights of the priority lanes of the blobs, which weight their shares of the encoding
+	// and of a full batch
+	PriorityLanes PriorityLanes
 }
 
 type EncodingStreamer struct {
@@ -62,6 +76,8 @@ type EncodingStreamer struct {
 	ReferenceBlockNumber uint
 	Pool                 common.WorkerPool
 	EncodedSizeNotifier  *EncodedSizeNotifier
+	// BatchAnnouncer announces the upcoming large batches to the operators. If nil, the batches aren't announced
+	BatchAnnouncer *BatchAnnouncer
 
 	blobStore             disperser.BlobStore
 	chainState            core.IndexedChainState
@@ -69,6 +85,7 @@ type EncodingStreamer struct {
 	assignmentCoordinator core.AssignmentCoordinator
 
 	encodingCtxCancelFuncs []context.CancelFunc
+	batchScheduler         *BatchScheduler
 
 	metrics *EncodingStreamerMetrics
 	logger  logging.Logger
@@ -83,7 +100,9 @@ type batch struct {
 	BlobHeaders  []*core.BlobHeader
 	BatchHeader  *core.BatchHeader
 	State        *core.IndexedOperatorState
-	MerkleTree   *merkletree.MerkleTree
+	MerkleTree   *core.MerkleTree
+	// BlobCosts are the encoding and dispersal costs of the blobs, without the confirmation costs
+	BlobCosts []*disperser.BlobCost
 }
 
 func NewEncodedSizeNotifier(notify chan struct{}, threshold uint64) *EncodedSizeNotifier {
@@ -118,6 +137,7 @@ func NewEncodingStreamer(
 		encoderClient:          encoderClient,
 		assignmentCoordinator:  assignmentCoordinator,
 		encodingCtxCancelFuncs: make([]context.CancelFunc, 0),
+		batchScheduler:         NewBatchScheduler(config.MaxBatchSize, config.AccountTiers, config.PriorityLanes),
 		metrics:                metrics,
 		logger:                 logger.With("component", "EncodingStreamer"),
 		exclusiveStartKey:      nil,
@@ -140,10 +160,11 @@ func (e *EncodingStreamer) Start(ctx context.Context) error {
 						// ignore canceled errors because canceled encoding requests are normal
 						continue
 					}
+					correlationID := response.BlobMetadata.RequestMetadata.CorrelationID
 					if strings.Contains(err.Error(), "too many requests") {
-						e.logger.Warn("encoding request ratelimited", "err", err)
+						e.logger.Warn("encoding request ratelimited", "err", err, "correlationID", correlationID)
 					} else {
-						e.logger.Error("error processing encoded blobs", "err", err)
+						e.logger.Error("error processing encoded blobs", "err", err, "correlationID", correlationID)
 					}
 				}
 			}
@@ -245,9 +266,9 @@ func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan
 		e.logger.Warn("worker pool queue is full. skipping this round of encoding requests", "waitingQueueSize", waitingQueueSize, "encodingQueueLimit", e.EncodingQueueLimit)
 		return nil
 	}
-	// only process subset of blobs so it doesn't exceed the EncodingQueueLimit
+	// only process subset of blobs so it doesn't exceed the EncodingQueueLimit, sharing it across the priority lanes
 	// TODO: this should be done at the request time and keep the cursor so that we don't fetch the same metadata every time
-	metadatas = metadatas[:numMetadatastoProcess]
+	metadatas = e.PriorityLanes.Order(metadatas)[:numMetadatastoProcess]
 
 	e.logger.Debug("new metadatas to encode", "numMetadata", len(metadatas), "duration", time.Since(stageTimer))
 
@@ -311,7 +332,7 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 
 		chunkLength, err := e.assignmentCoordinator.CalculateChunkLength(state.OperatorState, blobLength, e.StreamerConfig.TargetNumChunks, quorum)
 		if err != nil {
-			e.logger.Error("error calculating chunk length", "err", err)
+			e.logger.Error("error calculating chunk length", "err", err, "correlationID", metadata.RequestMetadata.CorrelationID)
 			continue
 		}
 
@@ -326,7 +347,7 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 		}
 		assignments, info, err := e.assignmentCoordinator.GetAssignments(state.OperatorState, blobLength, blobQuorumInfo)
 		if err != nil {
-			e.logger.Error("error getting assignments", "err", err)
+			e.logger.Error("error getting assignments", "err", err, "correlationID", metadata.RequestMetadata.CorrelationID)
 			continue
 		}
 
@@ -334,11 +355,11 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 
 		err = encoding.ValidateEncodingParams(params, int(blobLength), e.SRSOrder)
 		if err != nil {
-			e.logger.Error("invalid encoding params", "err", err)
+			e.logger.Error("invalid encoding params", "err", err, "correlationID", metadata.RequestMetadata.CorrelationID)
 			// Cancel the blob
 			err := e.blobStore.MarkBlobFailed(ctx, blobKey)
 			if err != nil {
-				e.logger.Error("error marking blob failed", "err", err)
+				e.logger.Error("error marking blob failed", "err", err, "correlationID", metadata.RequestMetadata.CorrelationID)
 			}
 			return
 		}
@@ -360,11 +381,16 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 		// If the reference block number changes, we need to cancel all outstanding encoding requests
 		// and re-request them with the new reference block number
 		encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
+		if uint(len(blob.Data)) <= e.LatencySensitiveBlobSize || metadata.RequestMetadata.Priority > 0 {
+			encodingCtx = disperser.WithEncodingPriority(encodingCtx, disperser.LatencyEncoding)
+		}
+		encodingCtx = common.WithCorrelationID(encodingCtx, metadata.RequestMetadata.CorrelationID)
 		e.mu.Lock()
 		e.encodingCtxCancelFuncs = append(e.encodingCtxCancelFuncs, cancel)
 		e.mu.Unlock()
 		e.Pool.Submit(func() {
 			defer cancel()
+			start := time.Now()
 			commits, chunks, err := e.encoderClient.EncodeBlob(encodingCtx, blob.Data, res.EncodingParams)
 			if err != nil {
 				encoderChan <- EncodingResultOrStatus{Err: err, EncodingResult: EncodingResult{
@@ -382,6 +408,7 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 					Commitment:           commits,
 					Chunks:               chunks,
 					Assignments:          res.Assignments,
+					EncodingTime:         time.Since(start),
 				},
 				Err: nil,
 			}
@@ -405,7 +432,18 @@ func (e *EncodingStreamer) ProcessEncodedBlobs(ctx context.Context, result Encod
 
 	count, encodedSize := e.EncodedBlobstore.GetEncodedResultSize()
 	e.metrics.UpdateEncodedBlobs(count, encodedSize)
-	if e.EncodedSizeNotifier.threshold > 0 && encodedSize >= e.EncodedSizeNotifier.threshold {
+	triggered := e.EncodedSizeNotifier.threshold > 0 && encodedSize >= e.EncodedSizeNotifier.threshold
+	if e.BatchAnnouncer != nil && e.BatchAnnouncer.ShouldAnnounce(encodedSize) {
+		numBlobs, operatorSizes := e.EncodedBlobstore.GetEncodedResultSummary()
+		announcement := core.BatchAnnouncement{
+			ReferenceBlockNumber: result.ReferenceBlockNumber,
+			NumBlobs:             numBlobs,
+			Size:                 encodedSize,
+			ETA:                  e.BatchAnnouncer.ETA(triggered),
+		}
+		go e.BatchAnnouncer.Announce(ctx, announcement, operatorSizes)
+	}
+	if triggered && encodedSize >= e.EncodedSizeNotifier.threshold {
 		e.EncodedSizeNotifier.mu.Lock()
 
 		if e.EncodedSizeNotifier.active {
@@ -457,6 +495,9 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 	e.EncodedSizeNotifier.mu.Lock()
 	e.EncodedSizeNotifier.active = true
 	e.EncodedSizeNotifier.mu.Unlock()
+	if e.BatchAnnouncer != nil {
+		e.BatchAnnouncer.BatchCreated()
+	}
 
 	e.logger.Info("creating a batch...", "numBlobs", len(encodedResults), "refblockNumber", e.ReferenceBlockNumber)
 	if len(encodedResults) == 0 {
@@ -467,6 +508,7 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 	blobQuorums := make(map[disperser.BlobKey][]*core.BlobQuorumInfo)
 	blobHeaderByKey := make(map[disperser.BlobKey]*core.BlobHeader)
 	metadataByKey := make(map[disperser.BlobKey]*disperser.BlobMetadata)
+	costByKey := make(map[disperser.BlobKey]*disperser.BlobCost)
 	for i := range encodedResults {
 		// each result represent an encoded result per (blob, quorum param)
 		// if the same blob has been dispersed multiple time with different security params,
@@ -478,13 +520,17 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 			blobQuorums[blobKey] = make([]*core.BlobQuorumInfo, 0)
 			blobHeader := &core.BlobHeader{
 				BlobCommitments: *result.Commitment,
+				RetentionPeriod: result.BlobMetadata.RequestMetadata.RetentionPeriod,
+				CorrelationID:   result.BlobMetadata.RequestMetadata.CorrelationID,
 			}
 			blobHeaderByKey[blobKey] = blobHeader
 			encodedBlobByKey[blobKey] = core.EncodedBlob{
 				BlobHeader:        blobHeader,
 				BundlesByOperator: make(map[core.OperatorID]core.Bundles),
 			}
+			costByKey[blobKey] = &disperser.BlobCost{}
 		}
+		costByKey[blobKey].EncodingTime += result.EncodingTime
 
 		// Populate the assigned bundles
 		for opID, assignment := range result.Assignments {
@@ -493,7 +539,9 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 				encodedBlobByKey[blobKey].BundlesByOperator[opID] = make(core.Bundles)
 				bundles = encodedBlobByKey[blobKey].BundlesByOperator[opID]
 			}
-			bundles[result.BlobQuorumInfo.QuorumID] = append(bundles[result.BlobQuorumInfo.QuorumID], result.Chunks[assignment.StartIndex:assignment.StartIndex+assignment.NumChunks]...)
+			chunks := result.Chunks[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks]
+			bundles[result.BlobQuorumInfo.QuorumID] = append(bundles[result.BlobQuorumInfo.QuorumID], chunks...)
+			costByKey[blobKey].DispersalBytes += core.Bundle(chunks).Size()
 		}
 
 		blobQuorums[blobKey] = append(blobQuorums[blobKey], result.BlobQuorumInfo)
@@ -525,10 +573,38 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 		return nil, errNoEncodedResults
 	}
 
+	// Leave the blobs which don't fit in the batch for the next ones. Their encoded results are requested again if
+	// the next batch has a different reference block number
+	pending := make([]PendingBlob, 0, len(metadataByKey))
+	for blobKey, metadata := range metadataByKey {
+		pending = append(pending, PendingBlob{
+			Key:         blobKey,
+			AccountID:   metadata.RequestMetadata.AccountID,
+			Size:        costByKey[blobKey].DispersalBytes,
+			RequestedAt: metadata.RequestMetadata.RequestedAt,
+			Priority:    metadata.RequestMetadata.Priority,
+			GroupID:     metadata.RequestMetadata.GroupID,
+		})
+	}
+	_, deferred := e.batchScheduler.Schedule(pending)
+	if len(deferred) > 0 {
+		oldest := deferred[0].RequestedAt
+		for _, blob := range deferred {
+			oldest = min(oldest, blob.RequestedAt)
+			delete(metadataByKey, blob.Key)
+		}
+		headOfLineDelay := time.Since(time.Unix(0, int64(oldest)))
+		e.logger.Info("batch is full, deferring blobs to the next batches", "numDeferred", len(deferred), "headOfLineDelay", headOfLineDelay)
+		e.metrics.UpdateDeferredBlobs(len(deferred), headOfLineDelay)
+	} else {
+		e.metrics.UpdateDeferredBlobs(0, 0)
+	}
+
 	// Transform maps to slices so orders in different slices match
 	encodedBlobs := make([]core.EncodedBlob, len(metadataByKey))
 	blobHeaders := make([]*core.BlobHeader, len(metadataByKey))
 	metadatas := make([]*disperser.BlobMetadata, len(metadataByKey))
+	blobCosts := make([]*disperser.BlobCost, len(metadataByKey))
 	i := 0
 	for key := range metadataByKey {
 		err := e.transitionBlobToDispersing(ctx, metadataByKey[key])
@@ -538,6 +614,7 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 		encodedBlobs[i] = encodedBlobByKey[key]
 		blobHeaders[i] = blobHeaderByKey[key]
 		metadatas[i] = metadataByKey[key]
+		blobCosts[i] = costByKey[key]
 		i++
 	}
 
@@ -567,6 +644,7 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 		BatchHeader:  batchHeader,
 		BlobHeaders:  blobHeaders,
 		BlobMetadata: metadatas,
+		BlobCosts:    blobCosts,
 		State:        state,
 		MerkleTree:   tree,
 	}, nil
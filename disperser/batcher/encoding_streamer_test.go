@@ -360,6 +360,61 @@ func TestEncodingFailure(t *testing.T) {
 	assert.False(t, isRequested)
 }
 
+func TestLatencySensitiveEncodingPriority(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	cst, err := coremock.MakeChainDataMock(map[uint8]int{
+		0: numOperators,
+	})
+	assert.Nil(t, err)
+	encoderClient := mock.NewMockEncoderClient()
+	asgn := &core.StdAssignmentCoordinator{}
+	sizeNotifier := batcher.NewEncodedSizeNotifier(make(chan struct{}, 1), 1e12)
+	workerpool := workerpool.New(5)
+	metrics := batcher.NewMetrics("9100", logger)
+	cst.On("GetCurrentBlockNumber").Return(uint(10), nil)
+
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+
+	for _, tc := range []struct {
+		latencySensitiveBlobSize uint
+		priority                 disperser.EncodingPriority
+	}{
+		{latencySensitiveBlobSize: 0, priority: disperser.BulkEncoding},
+		{latencySensitiveBlobSize: uint(len(blob.Data)) - 1, priority: disperser.BulkEncoding},
+		{latencySensitiveBlobSize: uint(len(blob.Data)), priority: disperser.LatencyEncoding},
+	} {
+		streamerConfig := batcher.StreamerConfig{
+			SRSOrder:                 300000,
+			EncodingRequestTimeout:   5 * time.Second,
+			EncodingQueueLimit:       100,
+			MaxBlobsToFetchFromStore: 10,
+			LatencySensitiveBlobSize: tc.latencySensitiveBlobSize,
+		}
+		blobStore := inmem.NewBlobStore()
+		encodingStreamer, err := batcher.NewEncodingStreamer(streamerConfig, blobStore, cst, encoderClient, asgn, sizeNotifier, workerpool, metrics.EncodingStreamerMetrics, logger)
+		assert.Nil(t, err)
+
+		_, err = blobStore.StoreBlob(context.Background(), &blob, uint64(time.Now().UnixNano()))
+		assert.Nil(t, err)
+
+		var priority disperser.EncodingPriority
+		encoderClient.ExpectedCalls = nil
+		encoderClient.On("EncodeBlob", tmock.Anything, tmock.Anything, tmock.Anything).Run(func(args tmock.Arguments) {
+			priority = disperser.EncodingPriorityFromContext(args.Get(0).(context.Context))
+		}).Return(nil, nil, errors.New("errrrr"))
+
+		out := make(chan batcher.EncodingResultOrStatus)
+		err = encodingStreamer.RequestEncoding(context.Background(), out)
+		assert.Nil(t, err)
+		<-out
+		assert.Equal(t, tc.priority, priority)
+	}
+}
+
 func TestPartialBlob(t *testing.T) {
 	encodingStreamer, c := createEncodingStreamer(t, 10, 1e12, streamerConfig)
 
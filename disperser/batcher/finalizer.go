@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/gammazero/workerpool"
 
@@ -20,6 +23,10 @@ import (
 const maxRetries = 3
 const baseDelay = 1 * time.Second
 
+// numAbsentChecksBeforeRollback is the number of consecutive rounds in which the confirmation transaction of a batch
+// has to be found missing from the canonical chain before the blobs of the batch are rolled back
+const numAbsentChecksBeforeRollback = 3
+
 // Finalizer runs periodically to finalize blobs that have been confirmed
 type Finalizer interface {
 	Start(ctx context.Context)
@@ -35,8 +42,10 @@ type finalizer struct {
 	maxNumRetriesPerBlob uint
 	numBlobsPerFetch     int32
 	numWorkers           int
+	notifier             *BlobNotifier
 	logger               logging.Logger
 	metrics              *FinalizerMetrics
+	absentChecks         *absentChecks
 }
 
 func NewFinalizer(
@@ -60,8 +69,10 @@ func NewFinalizer(
 		maxNumRetriesPerBlob: maxNumRetriesPerBlob,
 		numBlobsPerFetch:     numBlobsPerFetch,
 		numWorkers:           numWorkers,
+		notifier:             NewBlobNotifier(notificationTimeout, logger),
 		logger:               logger.With("component", "Finalizer"),
 		metrics:              metrics,
+		absentChecks:         &absentChecks{counts: make(map[gcommon.Hash]int)},
 	}
 }
 
@@ -85,6 +96,8 @@ func (f *finalizer) Start(ctx context.Context) {
 
 // FinalizeBlobs checks the latest finalized block and marks blobs in `confirmed` state as `finalized` if their confirmation
 // block number is less than or equal to the latest finalized block number.
+// The blobs whose confirmation transaction was dropped from the chain by a reorg are rolled back to `processing`, so that
+// the batcher disperses and confirms them again in a new batch.
 // If it failes to process some blobs, it will log the error, skip the failed blobs, and will not return an error. The function should be invoked again to retry.
 func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 	startTime := time.Now()
@@ -94,6 +107,7 @@ func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 		return fmt.Errorf("FinalizeBlobs: error getting latest finalized block: %w", err)
 	}
 	lastFinalBlock := finalizedHeader.Number.Uint64()
+	confirmations := newConfirmationChecks()
 
 	totalProcessed := 0
 	metadatas, exclusiveStartKey, err := f.blobStore.GetBlobMetadataByStatusWithPagination(ctx, disperser.Confirmed, f.numBlobsPerFetch, nil)
@@ -105,7 +119,7 @@ func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 		metas := metadatas
 		f.logger.Info("finalizing blobs", "numBlobs", len(metas), "finalizedBlockNumber", lastFinalBlock)
 		pool.Submit(func() {
-			f.updateBlobs(ctx, metas, lastFinalBlock)
+			f.updateBlobs(ctx, metas, lastFinalBlock, confirmations)
 		})
 		totalProcessed += len(metadatas)
 
@@ -127,7 +141,7 @@ func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 	return nil
 }
 
-func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.BlobMetadata, lastFinalBlock uint64) {
+func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.BlobMetadata, lastFinalBlock uint64, confirmations *confirmationChecks) {
 	// Panic recovery
 	defer func() {
 		if r := recover(); r != nil {
@@ -170,31 +184,25 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			continue
 		}
 
-		// Leave as confirmed if the confirmation block is after the latest finalized block (not yet finalized)
-		if uint64(confirmationMetadata.ConfirmationInfo.ConfirmationBlockNumber) > lastFinalBlock {
-			continue
-		}
-
-		// confirmation block number may have changed due to reorg
-		confirmationBlockNumber, err := f.getTransactionBlockNumber(ctx, confirmationMetadata.ConfirmationInfo.ConfirmationTxnHash)
-		if errors.Is(err, ethereum.NotFound) {
-			// The confirmed block is finalized, but the transaction is not found. It means the transaction should be considered forked/invalid and the blob should be considered as failed.
-			f.logger.Warn("confirmed transaction not found", "blobKey", blobKey.String(), "confirmationTxnHash", confirmationMetadata.ConfirmationInfo.ConfirmationTxnHash.Hex(), "confirmationBlockNumber", confirmationMetadata.ConfirmationInfo.ConfirmationBlockNumber)
-			err := f.blobStore.MarkBlobFailed(ctx, m.GetBlobKey())
-			if err != nil {
-				f.logger.Error("error marking blob as failed", "blobKey", blobKey.String(), "err", err)
-			}
+		// The confirmation block number may have changed due to a reorg, or the transaction may have been dropped
+		confirmationInfo := confirmationMetadata.ConfirmationInfo
+		check := confirmations.get(confirmationInfo.ConfirmationTxnHash, func() confirmationCheck {
+			return f.checkConfirmation(ctx, confirmationInfo.ConfirmationTxnHash, uint64(confirmationInfo.ConfirmationBlockNumber), lastFinalBlock)
+		})
+		if check.err != nil {
+			f.logger.Error("error checking the confirmation transaction", "blobKey", blobKey.String(), "confirmationTxnHash", confirmationInfo.ConfirmationTxnHash.Hex(), "err", check.err)
 			f.metrics.IncrementNumBlobs("failed")
 			continue
 		}
-		if err != nil {
-			f.logger.Error("error getting transaction block number", "err", err)
-			f.metrics.IncrementNumBlobs("failed")
+		if check.reorged {
+			f.rollBackBlob(ctx, confirmationMetadata)
 			continue
 		}
+		confirmationBlockNumber := check.blockNumber
 
-		// Leave as confirmed if the reorged confirmation block is after the latest finalized block (not yet finalized)
-		if uint64(confirmationBlockNumber) > lastFinalBlock {
+		// Leave as confirmed if the transaction is pending again after a reorg, or if its confirmation block is after the
+		// latest finalized block (not yet finalized)
+		if confirmationBlockNumber == 0 || confirmationBlockNumber > lastFinalBlock {
 			continue
 		}
 
@@ -206,11 +214,159 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			continue
 		}
 		f.metrics.IncrementNumBlobs("finalized")
+		confirmationMetadata.BlobStatus = disperser.Finalized
+		f.notifier.Notify(ctx, confirmationMetadata)
 		f.metrics.ObserveLatency("round", float64(time.Since(stageTimer).Milliseconds()))
 	}
 }
 
-func (f *finalizer) getTransactionBlockNumber(ctx context.Context, hash gcommon.Hash) (uint64, error) {
+// confirmationCheck is the state onchain of the confirmation transaction of a batch
+type confirmationCheck struct {
+	// blockNumber is the number of the block the transaction is mined in, or 0 if it's pending again after a reorg
+	blockNumber uint64
+	// reorged is whether the transaction was dropped from the chain by a reorg, or reverted once mined again
+	reorged bool
+	err     error
+}
+
+// confirmationChecks keeps the checks of the confirmation transactions during a round of the finalizer, so that the
+// transaction of a batch is checked once for all its blobs
+type confirmationChecks struct {
+	mu     sync.Mutex
+	checks map[gcommon.Hash]*confirmationCall
+}
+
+// confirmationCall is a check of a transaction, which is done once its channel is closed
+type confirmationCall struct {
+	done  chan struct{}
+	check confirmationCheck
+}
+
+func newConfirmationChecks() *confirmationChecks {
+	return &confirmationChecks{checks: make(map[gcommon.Hash]*confirmationCall)}
+}
+
+// get returns the check of the transaction, running it if the transaction wasn't checked yet, or waiting for the check
+// of another worker in progress. The checks of different transactions run concurrently, and the failed checks are run
+// again by the next worker.
+func (c *confirmationChecks) get(txHash gcommon.Hash, run func() confirmationCheck) confirmationCheck {
+	c.mu.Lock()
+	if call, ok := c.checks[txHash]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.check
+	}
+	call := &confirmationCall{done: make(chan struct{})}
+	c.checks[txHash] = call
+	c.mu.Unlock()
+
+	call.check = run()
+	if call.check.err != nil {
+		c.mu.Lock()
+		delete(c.checks, txHash)
+		c.mu.Unlock()
+	}
+	close(call.done)
+	return call.check
+}
+
+// checkConfirmation checks whether the confirmation transaction of a batch, recorded as mined in the given block, is
+// still on the chain. A transaction without a receipt is only considered dropped by a reorg once the recorded block is
+// finalized, the transaction is neither pending nor in that block of the canonical chain, and this was observed in
+// numAbsentChecksBeforeRollback consecutive rounds, so that a lagging RPC node missing the receipt of a transaction
+// doesn't get its batch confirmed twice. A transaction mined again after a reorg which reverted, e.g. because the
+// reference block of its batch became stale, is considered dropped as well.
+func (f *finalizer) checkConfirmation(ctx context.Context, txHash gcommon.Hash, recordedBlock uint64, lastFinalBlock uint64) confirmationCheck {
+	receipt, err := f.getTransactionReceipt(ctx, txHash)
+	if err == nil {
+		f.absentChecks.reset(txHash)
+		if receipt.Status == types.ReceiptStatusFailed {
+			f.logger.Warn("confirmation transaction reverted after a reorg", "confirmationTxnHash", txHash.Hex(), "blockNumber", receipt.BlockNumber.Uint64())
+			f.metrics.IncrementReorgedBatches()
+			return confirmationCheck{reorged: true}
+		}
+		if receipt.BlockNumber.Uint64() != recordedBlock {
+			f.logger.Info("confirmation transaction moved to another block by a reorg", "confirmationTxnHash", txHash.Hex(), "recordedBlockNumber", recordedBlock, "blockNumber", receipt.BlockNumber.Uint64())
+		}
+		return confirmationCheck{blockNumber: receipt.BlockNumber.Uint64()}
+	}
+	if !errors.Is(err, ethereum.NotFound) {
+		return confirmationCheck{err: err}
+	}
+
+	if recordedBlock > lastFinalBlock {
+		// The blob is left confirmed until the recorded block is finalized
+		f.logger.Warn("confirmation transaction has no receipt", "confirmationTxnHash", txHash.Hex(), "recordedBlockNumber", recordedBlock, "finalizedBlockNumber", lastFinalBlock)
+		return confirmationCheck{}
+	}
+	_, isPending, err := f.ethClient.TransactionByHash(ctx, txHash)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return confirmationCheck{err: err}
+	}
+	if err == nil {
+		// The transaction is known to the node: it's either in the mempool again after a reorg and may be mined again,
+		// or mined and its receipt isn't available yet
+		f.logger.Warn("confirmation transaction has no receipt but is known", "confirmationTxnHash", txHash.Hex(), "recordedBlockNumber", recordedBlock, "isPending", isPending)
+		f.absentChecks.reset(txHash)
+		return confirmationCheck{}
+	}
+	block, err := f.ethClient.BlockByNumber(ctx, new(big.Int).SetUint64(recordedBlock))
+	if err != nil {
+		return confirmationCheck{err: fmt.Errorf("failed to get the block %d of the confirmation transaction: %w", recordedBlock, err)}
+	}
+	if block.Transaction(txHash) != nil {
+		// The receipt is missing from a lagging RPC node, while the transaction is in the finalized block
+		f.absentChecks.reset(txHash)
+		return confirmationCheck{blockNumber: recordedBlock}
+	}
+
+	if n := f.absentChecks.increment(txHash); n < numAbsentChecksBeforeRollback {
+		f.logger.Warn("confirmation transaction is missing from the canonical chain", "confirmationTxnHash", txHash.Hex(), "recordedBlockNumber", recordedBlock, "numChecks", n)
+		return confirmationCheck{}
+	}
+	f.absentChecks.reset(txHash)
+	f.logger.Warn("confirmation transaction was dropped by a reorg", "confirmationTxnHash", txHash.Hex(), "recordedBlockNumber", recordedBlock, "finalizedBlockNumber", lastFinalBlock)
+	f.metrics.IncrementReorgedBatches()
+	return confirmationCheck{reorged: true}
+}
+
+// absentChecks counts, across the rounds of the finalizer, the consecutive checks which found the confirmation
+// transaction of a batch missing from the canonical chain
+type absentChecks struct {
+	mu     sync.Mutex
+	counts map[gcommon.Hash]int
+}
+
+func (c *absentChecks) increment(txHash gcommon.Hash) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[txHash]++
+	return c.counts[txHash]
+}
+
+func (c *absentChecks) reset(txHash gcommon.Hash) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.counts, txHash)
+}
+
+// rollBackBlob returns a blob whose confirmation transaction was dropped by a reorg to the processing status and
+// removes its confirmation info, so that the batcher disperses and confirms it again in a new batch. The rollback
+// isn't counted as a retry of the blob, and the client is notified of the status of the blob again.
+func (f *finalizer) rollBackBlob(ctx context.Context, metadata *disperser.BlobMetadata) {
+	blobKey := metadata.GetBlobKey()
+	updated, err := f.blobStore.MarkBlobReorged(ctx, metadata)
+	if err != nil {
+		f.logger.Error("error rolling back blob to processing", "blobKey", blobKey.String(), "err", err)
+		f.metrics.IncrementNumBlobs("failed")
+		return
+	}
+	f.logger.Warn("rolled back blob of a reorged batch to processing", "blobKey", blobKey.String(), "batchHeaderHash", hexutil.Encode(metadata.ConfirmationInfo.BatchHeaderHash[:]), "confirmationTxnHash", metadata.ConfirmationInfo.ConfirmationTxnHash.Hex(), "correlationID", metadata.RequestMetadata.CorrelationID)
+	f.metrics.IncrementNumBlobs("rolled_back")
+	f.notifier.Notify(ctx, updated)
+}
+
+func (f *finalizer) getTransactionReceipt(ctx context.Context, hash gcommon.Hash) (*types.Receipt, error) {
 	var ctxWithTimeout context.Context
 	var cancel context.CancelFunc
 	var txReceipt *types.Receipt
@@ -232,7 +388,7 @@ func (f *finalizer) getTransactionBlockNumber(ctx context.Context, hash gcommon.
 
 		if errors.Is(err, ethereum.NotFound) {
 			// If the transaction is not found, it means the transaction has been reorged out of the chain.
-			return 0, err
+			return nil, err
 		}
 
 		retrySec := math.Pow(2, float64(i))
@@ -241,10 +397,10 @@ func (f *finalizer) getTransactionBlockNumber(ctx context.Context, hash gcommon.
 	}
 
 	if err != nil {
-		return 0, fmt.Errorf("Finalizer: error getting transaction receipt after retries: %w", err)
+		return nil, fmt.Errorf("Finalizer: error getting transaction receipt after retries: %w", err)
 	}
 
-	return txReceipt.BlockNumber.Uint64(), nil
+	return txReceipt, nil
 }
 
 func (f *finalizer) getLatestFinalizedBlock(ctx context.Context) (*types.Header, error) {
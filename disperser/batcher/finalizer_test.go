@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 
 	m "github.com/stretchr/testify/mock"
@@ -36,6 +37,7 @@ func TestFinalizedBlob(t *testing.T) {
 			args[1].(*types.Header).Number = big.NewInt(latestFinalBlock)
 		}).Return(nil).Once()
 	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(&types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
 		BlockNumber: new(big.Int).SetUint64(1_000_000),
 	}, nil)
 
@@ -134,6 +136,7 @@ func TestUnfinalizedBlob(t *testing.T) {
 			args[1].(*types.Header).Number = big.NewInt(latestFinalBlock)
 		}).Return(nil).Once()
 	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(&types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
 		BlockNumber: new(big.Int).SetUint64(1_000_100),
 	}, nil)
 
@@ -206,6 +209,8 @@ func TestNoReceipt(t *testing.T) {
 			args[1].(*types.Header).Number = big.NewInt(latestFinalBlock)
 		}).Return(nil)
 	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(nil, ethereum.NotFound)
+	ethClient.On("TransactionByHash", m.Anything).Return((*types.Transaction)(nil), false, ethereum.NotFound)
+	ethClient.On("BlockByNumber").Return(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(150)}), nil)
 
 	metrics := batcher.NewMetrics("9100", logger)
 	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
@@ -251,20 +256,152 @@ func TestNoReceipt(t *testing.T) {
 	m, err := queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo)
 	assert.NoError(t, err)
 	assert.Equal(t, disperser.Confirmed, m.BlobStatus)
+
+	// The confirmation transaction is missing from the finalized block, but the blob is only rolled back once this is
+	// observed in several rounds, in case the RPC node is lagging
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, finalizer.FinalizeBlobs(context.Background()))
+		metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
+		assert.NoError(t, err)
+		assert.Len(t, metadatas, 1)
+	}
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.FinalizerMetrics.ReorgedBatches))
 	err = finalizer.FinalizeBlobs(context.Background())
 	assert.NoError(t, err)
 
-	// status should be kept at confirmed
+	// The confirmation transaction was dropped by a reorg, so the blob is rolled back to be confirmed again
 	metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Finalized)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Failed)
 	assert.NoError(t, err)
-	assert.Len(t, metadatas, 1)
+	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
 	assert.NoError(t, err)
-	assert.Len(t, metadatas, 0)
+	assert.Len(t, metadatas, 1)
+	assert.Equal(t, uint(0), metadatas[0].NumRetries)
+	// The blob doesn't show up in the reorged batch anymore
+	assert.Nil(t, metadatas[0].ConfirmationInfo)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.FinalizerMetrics.ReorgedBatches))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.FinalizerMetrics.NumBlobs.WithLabelValues("rolled_back")))
+}
+
+func TestReceiptMissingFromLaggingNode(t *testing.T) {
+	ctx := context.Background()
+	queue := inmem.NewBlobStore()
+	logger := logging.NewNoopLogger()
+	ethClient := &mock.MockEthClient{}
+	rpcClient := &mock.MockRPCEthClient{}
+
+	rpcClient.On("CallContext", m.Anything, m.Anything, "eth_getBlockByNumber", "finalized", false).
+		Run(func(args m.Arguments) {
+			args[1].(*types.Header).Number = big.NewInt(1_000_010)
+		}).Return(nil)
+	// The node has no receipt of the confirmation transaction, which is in the finalized block it was recorded in
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(nil, ethereum.NotFound)
+	ethClient.On("TransactionByHash", m.Anything).Return((*types.Transaction)(nil), false, ethereum.NotFound)
+	ethClient.On("BlockByNumber").Return(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1_000_000)}).WithBody([]*types.Transaction{tx}, nil), nil)
+
+	metrics := batcher.NewMetrics("9100", logger)
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 10, 1, logger, metrics.FinalizerMetrics)
+
+	blob := makeTestBlob([]*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 80}})
+	requestedAt := uint64(time.Now().UnixNano())
+	metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt)
+	assert.NoError(t, err)
+	_, err = queue.MarkBlobConfirmed(ctx, &disperser.BlobMetadata{
+		BlobHash:     metadataKey.BlobHash,
+		MetadataHash: metadataKey.MetadataHash,
+		BlobStatus:   disperser.Processing,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			RequestedAt:       requestedAt,
+		},
+	}, &disperser.ConfirmationInfo{
+		BatchHeaderHash:         [32]byte{1, 2, 3},
+		BlobCommitment:          &encoding.BlobCommitments{},
+		ConfirmationTxnHash:     tx.Hash(),
+		ConfirmationBlockNumber: 1_000_000,
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, finalizer.FinalizeBlobs(ctx))
+	metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Finalized)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.FinalizerMetrics.ReorgedBatches))
+}
+
+func TestReorgedUnfinalizedBlob(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		receipt   *types.Receipt
+		isPending bool
+		txErr     error
+		status    disperser.BlobStatus
+	}{
+		{name: "pending again", isPending: true, status: disperser.Confirmed},
+		// The blob isn't rolled back before the block of its confirmation is finalized
+		{name: "no receipt", txErr: ethereum.NotFound, status: disperser.Confirmed},
+		{name: "moved", receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(1_000_005)}, status: disperser.Finalized},
+		{name: "reverted", receipt: &types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(1_000_005)}, status: disperser.Processing},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			queue := inmem.NewBlobStore()
+			logger := logging.NewNoopLogger()
+			ethClient := &mock.MockEthClient{}
+			rpcClient := &mock.MockRPCEthClient{}
+
+			rpcClient.On("CallContext", m.Anything, m.Anything, "eth_getBlockByNumber", "finalized", false).
+				Run(func(args m.Arguments) {
+					args[1].(*types.Header).Number = big.NewInt(1_000_010)
+				}).Return(nil)
+			if test.receipt != nil {
+				ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(test.receipt, nil)
+			} else {
+				ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(nil, ethereum.NotFound)
+			}
+			ethClient.On("TransactionByHash", m.Anything).Return((*types.Transaction)(nil), test.isPending, test.txErr)
+
+			metrics := batcher.NewMetrics("9100", logger)
+			finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 10, 1, logger, metrics.FinalizerMetrics)
+
+			// Two blobs of a batch confirmed in a block which isn't finalized yet
+			blob := makeTestBlob([]*core.SecurityParam{{QuorumID: 0, AdversaryThreshold: 80}})
+			requestedAt := uint64(time.Now().UnixNano())
+			for i := uint64(0); i < 2; i++ {
+				metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt+i)
+				assert.NoError(t, err)
+				_, err = queue.MarkBlobConfirmed(ctx, &disperser.BlobMetadata{
+					BlobHash:     metadataKey.BlobHash,
+					MetadataHash: metadataKey.MetadataHash,
+					BlobStatus:   disperser.Processing,
+					RequestMetadata: &disperser.RequestMetadata{
+						BlobRequestHeader: blob.RequestHeader,
+						RequestedAt:       requestedAt + i,
+					},
+				}, &disperser.ConfirmationInfo{
+					BatchHeaderHash:         [32]byte{1, 2, 3},
+					BlobIndex:               uint32(i),
+					BlobCommitment:          &encoding.BlobCommitments{},
+					ConfirmationTxnHash:     common.HexToHash("0x123"),
+					ConfirmationBlockNumber: 1_000_100,
+				})
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, finalizer.FinalizeBlobs(ctx))
+			metadatas, err := queue.GetBlobMetadataByStatus(ctx, test.status)
+			assert.NoError(t, err)
+			assert.Len(t, metadatas, 2)
+
+			// The transaction of the batch is checked once for all its blobs
+			ethClient.AssertNumberOfCalls(t, "TransactionReceipt", 1)
+		})
+	}
 }
@@ -0,0 +1,225 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+)
+
+const (
+	// The names of the gas strategies
+	SuggestedGasStrategyName       = "suggested"
+	StaticGasStrategyName          = "static"
+	PercentileGasStrategyName      = "percentile"
+	InclusionTargetGasStrategyName = "inclusion-target"
+
+	// maxTipPercentile is the percentile of the tips the inclusion-target strategy pays once the target is reached
+	maxTipPercentile = 99
+)
+
+// GasPrice is the price of a transaction estimated by a GasStrategy
+type GasPrice struct {
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	// BaseFee is the base fee of the next block when the price was estimated, nil if the strategy doesn't know it
+	BaseFee *big.Int
+}
+
+// Expected returns the gas price the transaction pays if it's included in the next block, or nil if the base fee is
+// unknown
+func (p *GasPrice) Expected() *big.Int {
+	if p.BaseFee == nil {
+		return nil
+	}
+	expected := new(big.Int).Add(p.BaseFee, p.GasTipCap)
+	if expected.Cmp(p.GasFeeCap) > 0 {
+		return new(big.Int).Set(p.GasFeeCap)
+	}
+	return expected
+}
+
+// GasStrategy prices the transactions sent by the TxnManager, and the replacements of the transactions which aren't
+// mined in time. The replacements are priced at least 10% above the attempts they replace regardless of the strategy.
+type GasStrategy interface {
+	// Name identifies the strategy in the metrics
+	Name() string
+	// GasPrice returns the gas price of a transaction which was requested age ago
+	GasPrice(ctx context.Context, age time.Duration) (*GasPrice, error)
+}
+
+type GasStrategyConfig struct {
+	// Name is the name of the strategy, which is the suggested strategy if empty
+	Name string
+	// GasTipCap and GasFeeCap are the caps of the static strategy in wei
+	GasTipCap *big.Int
+	GasFeeCap *big.Int
+	// FeeHistoryBlocks is the number of recent blocks whose tips are sampled by the percentile and inclusion-target
+	// strategies
+	FeeHistoryBlocks int
+	// TipPercentile is the percentile of the tips of the recent blocks which the percentile strategy pays, and from
+	// which the inclusion-target strategy starts
+	TipPercentile float64
+	// InclusionTarget is how long the inclusion-target strategy takes to raise its percentile to the 99th
+	InclusionTarget time.Duration
+}
+
+// NewGasStrategy returns the strategy of the config
+func NewGasStrategy(config GasStrategyConfig, ethClient common.EthClient) (GasStrategy, error) {
+	switch config.Name {
+	case "", SuggestedGasStrategyName:
+		return &SuggestedGasStrategy{ethClient: ethClient}, nil
+	case StaticGasStrategyName:
+		return NewStaticGasStrategy(config.GasTipCap, config.GasFeeCap)
+	case PercentileGasStrategyName:
+		return NewPercentileGasStrategy(ethClient, config.FeeHistoryBlocks, config.TipPercentile)
+	case InclusionTargetGasStrategyName:
+		return NewInclusionTargetGasStrategy(ethClient, config.FeeHistoryBlocks, config.TipPercentile, config.InclusionTarget)
+	default:
+		return nil, fmt.Errorf("unknown gas strategy %q, must be %s, %s, %s or %s", config.Name, SuggestedGasStrategyName, StaticGasStrategyName, PercentileGasStrategyName, InclusionTargetGasStrategyName)
+	}
+}
+
+// SuggestedGasStrategy pays 25% more than the tip suggested by the node, with a fee cap of twice the base fee plus
+// the tip, which is the pricing of the batches without a strategy
+type SuggestedGasStrategy struct {
+	ethClient common.EthClient
+}
+
+var _ GasStrategy = (*SuggestedGasStrategy)(nil)
+
+func (s *SuggestedGasStrategy) Name() string {
+	return SuggestedGasStrategyName
+}
+
+func (s *SuggestedGasStrategy) GasPrice(ctx context.Context, age time.Duration) (*GasPrice, error) {
+	gasTipCap, gasFeeCap, err := s.ethClient.GetLatestGasCaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GasPrice{GasTipCap: gasTipCap, GasFeeCap: gasFeeCap}, nil
+}
+
+// StaticGasStrategy pays fixed caps, which bound the cost of the confirmations at the risk of not being mined when the
+// base fee rises above the fee cap. The replacements of the transactions still raise their caps.
+type StaticGasStrategy struct {
+	gasTipCap *big.Int
+	gasFeeCap *big.Int
+}
+
+var _ GasStrategy = (*StaticGasStrategy)(nil)
+
+func NewStaticGasStrategy(gasTipCap, gasFeeCap *big.Int) (*StaticGasStrategy, error) {
+	if gasTipCap == nil || gasFeeCap == nil || gasTipCap.Sign() <= 0 {
+		return nil, errors.New("the gas tip cap and gas fee cap of the static gas strategy must be positive")
+	}
+	if gasTipCap.Cmp(gasFeeCap) > 0 {
+		return nil, fmt.Errorf("the gas tip cap %s of the static gas strategy exceeds its gas fee cap %s", gasTipCap, gasFeeCap)
+	}
+	return &StaticGasStrategy{gasTipCap: gasTipCap, gasFeeCap: gasFeeCap}, nil
+}
+
+func (s *StaticGasStrategy) Name() string {
+	return StaticGasStrategyName
+}
+
+func (s *StaticGasStrategy) GasPrice(ctx context.Context, age time.Duration) (*GasPrice, error) {
+	return &GasPrice{GasTipCap: new(big.Int).Set(s.gasTipCap), GasFeeCap: new(big.Int).Set(s.gasFeeCap)}, nil
+}
+
+// PercentileGasStrategy pays the median over the recent blocks of a percentile of their tips, with a fee cap of twice
+// the base fee of the next block plus the tip
+type PercentileGasStrategy struct {
+	ethClient  common.EthClient
+	blocks     int
+	percentile float64
+}
+
+var _ GasStrategy = (*PercentileGasStrategy)(nil)
+
+func NewPercentileGasStrategy(ethClient common.EthClient, feeHistoryBlocks int, tipPercentile float64) (*PercentileGasStrategy, error) {
+	if feeHistoryBlocks <= 0 {
+		return nil, errors.New("the fee history of the percentile gas strategy must have at least one block")
+	}
+	if tipPercentile < 0 || tipPercentile > 100 {
+		return nil, fmt.Errorf("the tip percentile of the gas strategy must be between 0 and 100, got %v", tipPercentile)
+	}
+	return &PercentileGasStrategy{ethClient: ethClient, blocks: feeHistoryBlocks, percentile: tipPercentile}, nil
+}
+
+func (s *PercentileGasStrategy) Name() string {
+	return PercentileGasStrategyName
+}
+
+func (s *PercentileGasStrategy) GasPrice(ctx context.Context, age time.Duration) (*GasPrice, error) {
+	return s.gasPriceAt(ctx, s.percentile)
+}
+
+func (s *PercentileGasStrategy) gasPriceAt(ctx context.Context, percentile float64) (*GasPrice, error) {
+	history, err := s.ethClient.FeeHistory(ctx, uint64(s.blocks), nil, []float64{percentile})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return nil, errors.New("fee history has no base fee")
+	}
+	// The base fees of the history include the one of the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	tips := make([]*big.Int, 0, len(history.Reward))
+	for _, reward := range history.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			tips = append(tips, reward[0])
+		}
+	}
+	gasTipCap := new(big.Int).Set(geth.FallbackGasTipCap)
+	if len(tips) > 0 {
+		sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+		gasTipCap.Set(tips[len(tips)/2])
+	}
+	gasFeeCap := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), gasTipCap)
+	return &GasPrice{GasTipCap: gasTipCap, GasFeeCap: gasFeeCap, BaseFee: baseFee}, nil
+}
+
+// InclusionTargetGasStrategy aims for the transactions to be mined within a target time. It pays a percentile of the
+// recent tips which rises linearly with the age of the transaction, from TipPercentile when it's requested to the
+// 99th percentile at the target, so that a transaction which isn't mined is replaced with an increasingly
+// competitive tip.
+type InclusionTargetGasStrategy struct {
+	PercentileGasStrategy
+	target time.Duration
+}
+
+var _ GasStrategy = (*InclusionTargetGasStrategy)(nil)
+
+func NewInclusionTargetGasStrategy(ethClient common.EthClient, feeHistoryBlocks int, tipPercentile float64, inclusionTarget time.Duration) (*InclusionTargetGasStrategy, error) {
+	if inclusionTarget <= 0 {
+		return nil, errors.New("the inclusion target of the gas strategy must be positive")
+	}
+	percentileStrategy, err := NewPercentileGasStrategy(ethClient, feeHistoryBlocks, tipPercentile)
+	if err != nil {
+		return nil, err
+	}
+	return &InclusionTargetGasStrategy{PercentileGasStrategy: *percentileStrategy, target: inclusionTarget}, nil
+}
+
+func (s *InclusionTargetGasStrategy) Name() string {
+	return InclusionTargetGasStrategyName
+}
+
+func (s *InclusionTargetGasStrategy) GasPrice(ctx context.Context, age time.Duration) (*GasPrice, error) {
+	return s.gasPriceAt(ctx, s.percentileAt(age))
+}
+
+// percentileAt returns the percentile of the tips paid by a transaction of the given age
+func (s *InclusionTargetGasStrategy) percentileAt(age time.Duration) float64 {
+	if age >= s.target || s.percentile >= maxTipPercentile {
+		return max(s.percentile, maxTipPercentile)
+	}
+	return s.percentile + (maxTipPercentile-s.percentile)*float64(age)/float64(s.target)
+}
@@ -0,0 +1,104 @@
+package batcher_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+// percentileEthClient returns a fee history whose blocks all paid tips in gwei equal to the requested percentile,
+// with a base fee of 10 gwei
+type percentileEthClient struct {
+	*mock.MockEthClient
+}
+
+func (c *percentileEthClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	history := &ethereum.FeeHistory{}
+	for i := uint64(0); i <= blockCount; i++ {
+		history.BaseFee = append(history.BaseFee, gwei(10))
+	}
+	for i := uint64(0); i < blockCount; i++ {
+		tip, _ := new(big.Float).Mul(big.NewFloat(rewardPercentiles[0]), big.NewFloat(params.GWei)).Int(nil)
+		history.Reward = append(history.Reward, []*big.Int{tip})
+	}
+	return history, nil
+}
+
+func gwei(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.GWei))
+}
+
+func TestStaticGasStrategy(t *testing.T) {
+	_, err := batcher.NewStaticGasStrategy(gwei(2), gwei(1))
+	assert.Error(t, err)
+	_, err = batcher.NewStaticGasStrategy(nil, gwei(1))
+	assert.Error(t, err)
+
+	strategy, err := batcher.NewStaticGasStrategy(gwei(1), gwei(100))
+	assert.NoError(t, err)
+	gasPrice, err := strategy.GasPrice(context.Background(), time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, gwei(1), gasPrice.GasTipCap)
+	assert.Equal(t, gwei(100), gasPrice.GasFeeCap)
+	assert.Nil(t, gasPrice.Expected())
+}
+
+func TestPercentileGasStrategy(t *testing.T) {
+	ethClient := &percentileEthClient{MockEthClient: &mock.MockEthClient{}}
+	_, err := batcher.NewPercentileGasStrategy(ethClient, 0, 50)
+	assert.Error(t, err)
+	_, err = batcher.NewPercentileGasStrategy(ethClient, 10, 101)
+	assert.Error(t, err)
+
+	strategy, err := batcher.NewPercentileGasStrategy(ethClient, 10, 50)
+	assert.NoError(t, err)
+	gasPrice, err := strategy.GasPrice(context.Background(), time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, gwei(50), gasPrice.GasTipCap)
+	assert.Equal(t, gwei(70), gasPrice.GasFeeCap)
+	assert.Equal(t, gwei(10), gasPrice.BaseFee)
+	assert.Equal(t, gwei(60), gasPrice.Expected())
+}
+
+func TestInclusionTargetGasStrategy(t *testing.T) {
+	ethClient := &percentileEthClient{MockEthClient: &mock.MockEthClient{}}
+	_, err := batcher.NewInclusionTargetGasStrategy(ethClient, 10, 50, 0)
+	assert.Error(t, err)
+
+	strategy, err := batcher.NewInclusionTargetGasStrategy(ethClient, 10, 51, time.Minute)
+	assert.NoError(t, err)
+
+	// The percentile of the tips rises from 51 to 99 over the target
+	gasPrice, err := strategy.GasPrice(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gwei(51), gasPrice.GasTipCap)
+	gasPrice, err = strategy.GasPrice(context.Background(), 30*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, gwei(75), gasPrice.GasTipCap)
+	gasPrice, err = strategy.GasPrice(context.Background(), 2*time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, gwei(99), gasPrice.GasTipCap)
+}
+
+func TestNewGasStrategy(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	strategy, err := batcher.NewGasStrategy(batcher.GasStrategyConfig{}, ethClient)
+	assert.NoError(t, err)
+	assert.Equal(t, batcher.SuggestedGasStrategyName, strategy.Name())
+
+	ethClient.On("GetLatestGasCaps").Return(gwei(1), gwei(21), nil)
+	gasPrice, err := strategy.GasPrice(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, gwei(1), gasPrice.GasTipCap)
+	assert.Equal(t, gwei(21), gasPrice.GasFeeCap)
+
+	_, err = batcher.NewGasStrategy(batcher.GasStrategyConfig{Name: "unknown"}, ethClient)
+	assert.Error(t, err)
+}
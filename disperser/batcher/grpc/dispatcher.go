@@ -3,6 +3,8 @@ package dispatcher
 import (
 	"context"
 	"errors"
+	"io"
+	"sync"
 	"time"
 
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
@@ -10,31 +12,78 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/disperser/common/featuregate"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type Config struct {
 	Timeout time.Duration
+	// ChunkEncodingFormat is the format in which chunks are serialized when they are sent to the operators
+	ChunkEncodingFormat encoding.ChunkEncodingFormat
+	// FeatureGates roll out new behaviors to a subset of the operators. If nil, every feature is off
+	FeatureGates *featuregate.Gates
+	// Credentials are the transport credentials the operators are dialed with. If nil, they're dialed in plaintext
+	Credentials grpc.DialOption
+	// Retry configures the retries of the operators which fail to take a batch
+	Retry RetryConfig
+	// MinibatchSize is the size in bytes of the minibatches of chunks streamed to the operators with
+	// StoreChunksStream, so that they validate a batch while receiving it. The chunks are sent in a single
+	// StoreChunks request if 0, or if the node of the operator doesn't implement StoreChunksStream.
+	MinibatchSize uint64
+}
+
+// RetryConfig configures the retries of the dispersals to the operators, and the blacklisting of the operators which
+// keep failing
+type RetryConfig struct {
+	// MaxRetries is how many times the chunks are sent again to an operator which failed with a transient error. The
+	// retries stop at the deadline of the dispersal, which every attempt shares.
+	MaxRetries int
+	// Backoff is the delay before the first retry, doubled after each retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// BlacklistThreshold is the number of consecutive batches an operator must fail to be blacklisted for
+	// BlacklistDuration, during which it's not sent any batch. Operators are never blacklisted if 0.
+	BlacklistThreshold int
+	BlacklistDuration  time.Duration
 }
 
 type dispatcher struct {
 	*Config
 
-	logger  logging.Logger
-	metrics *batcher.DispatcherMetrics
+	failures *operatorFailures
+	// unstreamed holds the operators whose nodes don't implement StoreChunksStream, with the time until which they're
+	// sent their chunks in a single request
+	unstreamed sync.Map
+	logger     logging.Logger
+	metrics    *batcher.DispatcherMetrics
 }
 
+const (
+	// maxSendMsgSize is the largest request the dispatcher sends to an operator
+	maxSendMsgSize = 60 * 1024 * 1024 * 1024
+	// streamRetryInterval is how long the operators whose nodes don't implement StoreChunksStream are sent their
+	// chunks in a single request before streaming to them is tried again, in case they upgraded
+	streamRetryInterval = time.Hour
+)
+
 func NewDispatcher(cfg *Config, logger logging.Logger, metrics *batcher.DispatcherMetrics) *dispatcher {
 	return &dispatcher{
-		Config:  cfg,
-		logger:  logger.With("component", "Dispatcher"),
-		metrics: metrics,
+		Config:   cfg,
+		failures: newOperatorFailures(cfg.Retry.BlacklistThreshold, cfg.Retry.BlacklistDuration),
+		logger:   logger.With("component", "Dispatcher"),
+		metrics:  metrics,
 	}
 }
 
+// errOperatorBlacklisted is the error of the operators skipped because they failed too many batches in a row
+var errOperatorBlacklisted = errors.New("operator is blacklisted after failing consecutive batches")
+
 var _ disperser.Dispatcher = (*dispatcher)(nil)
 
 func (c *dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, batchHeader *core.BatchHeader) chan core.SignerMessage {
@@ -70,23 +119,38 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 				}
 				return
 			}
+			// The blacklisted operators are reported as non-signers right away, so that the attestation doesn't wait
+			// for them
+			if c.failures.blacklisted(id, time.Now()) {
+				update <- core.SignerMessage{
+					Err:       errOperatorBlacklisted,
+					Signature: nil,
+					Operator:  id,
+				}
+				c.metrics.IncrementOperatorOutcome("blacklisted")
+				return
+			}
 
 			requestedAt := time.Now()
-			sig, err := c.sendChunks(ctx, blobMessages, batchHeader, &op)
+			sig, err := c.sendChunks(ctx, blobMessages, batchHeader, &op, id)
+			responseTime := time.Since(requestedAt)
+			c.recordOutcome(ctx, id, &op, err)
 			if err != nil {
 				update <- core.SignerMessage{
-					Err:       err,
-					Signature: nil,
-					Operator:  id,
+					Err:          err,
+					Signature:    nil,
+					Operator:     id,
+					ResponseTime: responseTime,
 				}
-				c.metrics.ObserveLatency(false, float64(time.Since(requestedAt).Milliseconds()))
+				c.metrics.ObserveLatency(false, float64(responseTime.Milliseconds()))
 			} else {
 				update <- core.SignerMessage{
-					Signature: sig,
-					Operator:  id,
-					Err:       nil,
+					Signature:    sig,
+					Operator:     id,
+					Err:          nil,
+					ResponseTime: responseTime,
 				}
-				c.metrics.ObserveLatency(true, float64(time.Since(requestedAt).Milliseconds()))
+				c.metrics.ObserveLatency(true, float64(responseTime.Milliseconds()))
 			}
 
 		}(core.IndexedOperatorInfo{
@@ -97,12 +161,32 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 	}
 }
 
-func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, error) {
-	// TODO Add secure Grpc
+// recordOutcome records whether the operator took the batch, blacklisting it if it failed too many batches in a row.
+// The operators cut off because the attestation settled without them aren't counted as failing.
+func (c *dispatcher) recordOutcome(ctx context.Context, id core.OperatorID, op *core.IndexedOperatorInfo, err error) {
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+	now := time.Now()
+	if err == nil {
+		c.metrics.IncrementOperatorOutcome("signed")
+	} else {
+		c.metrics.IncrementOperatorOutcome("failed")
+	}
+	if c.failures.record(id, err == nil, now) {
+		c.logger.Warn("blacklisting operator after consecutive failed batches", "operator", id.Hex(), "socket", op.Socket, "duration", c.Retry.BlacklistDuration, "err", err)
+	}
+	c.metrics.UpdateBlacklistedOperators(c.failures.numBlacklisted(now))
+}
 
+func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo, id core.OperatorID) (*core.Signature, error) {
+	credentials := c.Credentials
+	if credentials == nil {
+		credentials = grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
 	conn, err := grpc.Dial(
 		core.OperatorSocket(op.Socket).GetDispersalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		credentials,
 	)
 	if err != nil {
 		c.logger.Warn("Disperser cannot connect to operator dispersal socket", "dispersal_socket", core.OperatorSocket(op.Socket).GetDispersalSocket(), "err", err)
@@ -111,20 +195,28 @@ func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage,
 	defer conn.Close()
 
 	gc := node.NewDispersalClient(conn)
-	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
-	defer cancel()
-	request, totalSize, err := GetStoreChunksRequest(blobs, batchHeader)
-	if err != nil {
-		return nil, err
+	format := c.ChunkEncodingFormat
+	if c.FeatureGates.Enabled(featuregate.CompressedChunks, id[:]) {
+		format = encoding.CompressedChunkEncodingFormat
 	}
 
-	opt := grpc.MaxCallSendMsgSize(60 * 1024 * 1024 * 1024)
-	c.logger.Debug("sending chunks to operator", "operator", op.Socket, "size", totalSize)
-	reply, err := gc.StoreChunks(ctx, request, opt)
-
+	var reply *node.StoreChunksReply
+	streamed := c.MinibatchSize > 0 && c.streamable(id, time.Now())
+	if streamed {
+		reply, err = c.streamChunks(ctx, gc, blobs, batchHeader, format, op)
+		if status.Code(err) == codes.Unimplemented {
+			c.logger.Info("operator doesn't implement StoreChunksStream, sending it the chunks in a single request", "operator", id.Hex(), "socket", op.Socket)
+			c.unstreamed.Store(id, time.Now().Add(streamRetryInterval))
+			streamed = false
+		}
+	}
+	if !streamed {
+		reply, err = c.sendChunksRequest(ctx, gc, blobs, batchHeader, format, op)
+	}
 	if err != nil {
 		return nil, err
 	}
+	c.observeDiagnostics(id, op, reply.GetBlobDiagnostics())
 
 	sigBytes := reply.GetSignature()
 	point, err := new(core.Signature).Deserialize(sigBytes)
@@ -135,12 +227,147 @@ func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.BlobMessage,
 	return sig, nil
 }
 
-func GetStoreChunksRequest(blobMessages []*core.BlobMessage, batchHeader *core.BatchHeader) (*node.StoreChunksRequest, int64, error) {
+// streamable returns whether the chunks can be streamed to the operator at now
+func (c *dispatcher) streamable(id core.OperatorID, now time.Time) bool {
+	until, ok := c.unstreamed.Load(id)
+	return !ok || !now.Before(until.(time.Time))
+}
+
+// sendChunksRequest sends all the chunks to the operator in a single StoreChunks request
+func (c *dispatcher) sendChunksRequest(ctx context.Context, gc node.DispersalClient, blobs []*core.BlobMessage, batchHeader *core.BatchHeader, format encoding.ChunkEncodingFormat, op *core.IndexedOperatorInfo) (*node.StoreChunksReply, error) {
+	request, totalSize, err := GetStoreChunksRequest(blobs, batchHeader, format)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Debug("sending chunks to operator", "operator", op.Socket, "size", totalSize)
+	return c.storeChunks(ctx, op, func(ctx context.Context) (*node.StoreChunksReply, error) {
+		return gc.StoreChunks(ctx, request, grpc.MaxCallSendMsgSize(maxSendMsgSize))
+	})
+}
+
+// streamChunks streams the chunks to the operator in minibatches of MinibatchSize bytes with StoreChunksStream. The
+// minibatches are serialized as they're first sent, and kept for the retries.
+func (c *dispatcher) streamChunks(ctx context.Context, gc node.DispersalClient, blobs []*core.BlobMessage, batchHeader *core.BatchHeader, format encoding.ChunkEncodingFormat, op *core.IndexedOperatorInfo) (*node.StoreChunksReply, error) {
+	minibatches := splitMinibatches(blobs, c.MinibatchSize)
+	requests := make([]*node.StoreChunksRequest, len(minibatches))
+
+	c.logger.Debug("streaming chunks to operator", "operator", op.Socket, "numMinibatches", len(minibatches))
+	return c.storeChunks(ctx, op, func(ctx context.Context) (*node.StoreChunksReply, error) {
+		stream, err := gc.StoreChunksStream(ctx, grpc.MaxCallSendMsgSize(maxSendMsgSize))
+		if err != nil {
+			return nil, err
+		}
+		for i, minibatch := range minibatches {
+			if requests[i] == nil {
+				request, _, err := GetStoreChunksRequest(minibatch, batchHeader, format)
+				if err != nil {
+					return nil, err
+				}
+				// Only the first minibatch carries the batch header
+				if i > 0 {
+					request.BatchHeader = nil
+				}
+				requests[i] = request
+			}
+			// The node closed the stream if the send fails with io.EOF, whose error is returned by CloseAndRecv
+			if err := stream.Send(requests[i]); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		return stream.CloseAndRecv()
+	})
+}
+
+// splitMinibatches splits the blobs in order into minibatches of at least size bytes of chunks, but the last one
+func splitMinibatches(blobs []*core.BlobMessage, size uint64) [][]*core.BlobMessage {
+	minibatches := make([][]*core.BlobMessage, 0)
+	start := 0
+	minibatchSize := uint64(0)
+	for i, blob := range blobs {
+		minibatchSize += blob.Bundles.Size()
+		if minibatchSize >= size {
+			minibatches = append(minibatches, blobs[start:i+1])
+			start = i + 1
+			minibatchSize = 0
+		}
+	}
+	if start < len(blobs) {
+		minibatches = append(minibatches, blobs[start:])
+	}
+	return minibatches
+}
+
+// storeChunks sends the chunks to the operator with send, retrying with an exponential backoff while it fails with a
+// transient error. All the attempts share the deadline of the dispersal, each of them lasting at most the dispersal
+// timeout.
+func (c *dispatcher) storeChunks(ctx context.Context, op *core.IndexedOperatorInfo, send func(context.Context) (*node.StoreChunksReply, error)) (*node.StoreChunksReply, error) {
+	backoff := c.Retry.Backoff
+	for attempt := 0; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+		reply, err := send(attemptCtx)
+		cancel()
+		if err == nil || attempt >= c.Retry.MaxRetries || !isRetryable(err) {
+			return reply, err
+		}
+
+		// Don't retry if the dispersal would be over before the retry is sent
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= backoff {
+			return nil, err
+		}
+		c.logger.Debug("retrying to send chunks to operator", "operator", op.Socket, "attempt", attempt+1, "backoff", backoff, "err", err)
+		c.metrics.IncrementRetries()
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if c.Retry.MaxBackoff > 0 && backoff > c.Retry.MaxBackoff {
+			backoff = c.Retry.MaxBackoff
+		}
+	}
+}
+
+// isRetryable returns whether the error of a StoreChunks request is transient, so that sending the chunks again can
+// succeed. The operators reject an invalid batch with other codes, which would fail again.
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// observeDiagnostics records the validation diagnostics an operator returned for a batch, if any, and warns if the
+// operator's validation takes long enough that it's likely to miss the dispersal timeout as the batches grow
+func (c *dispatcher) observeDiagnostics(id core.OperatorID, op *core.IndexedOperatorInfo, diagnostics []*node.BlobValidationDiagnostics) {
+	if len(diagnostics) == 0 {
+		return
+	}
+	verificationMicros := uint64(0)
+	numChunks := uint64(0)
+	for _, blob := range diagnostics {
+		verificationMicros += blob.GetVerificationMicros()
+		numChunks += uint64(blob.GetNumChunks())
+	}
+	c.metrics.ObserveOperatorVerification(id, verificationMicros, numChunks)
+
+	verificationTime := time.Duration(verificationMicros) * time.Microsecond
+	if verificationTime > c.Timeout/2 {
+		c.logger.Warn("operator validation takes more than half the dispersal timeout", "operator", id.Hex(), "socket", op.Socket, "verificationTime", verificationTime, "numChunks", numChunks, "timeout", c.Timeout)
+	}
+}
+
+func GetStoreChunksRequest(blobMessages []*core.BlobMessage, batchHeader *core.BatchHeader, format encoding.ChunkEncodingFormat) (*node.StoreChunksRequest, int64, error) {
 	blobs := make([]*node.Blob, len(blobMessages))
 	totalSize := int64(0)
 	for i, blob := range blobMessages {
 		var err error
-		blobs[i], err = getBlobMessage(blob)
+		blobs[i], err = getBlobMessage(blob, format)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -148,14 +375,15 @@ func GetStoreChunksRequest(blobMessages []*core.BlobMessage, batchHeader *core.B
 	}
 
 	request := &node.StoreChunksRequest{
-		BatchHeader: getBatchHeaderMessage(batchHeader),
-		Blobs:       blobs,
+		BatchHeader:         getBatchHeaderMessage(batchHeader),
+		Blobs:               blobs,
+		ChunkEncodingFormat: node.ChunkEncodingFormat(format),
 	}
 
 	return request, totalSize, nil
 }
 
-func getBlobMessage(blob *core.BlobMessage) (*node.Blob, error) {
+func getBlobMessage(blob *core.BlobMessage, format encoding.ChunkEncodingFormat) (*node.Blob, error) {
 	if blob.BlobHeader == nil {
 		return nil, errors.New("blob header is nil")
 	}
@@ -192,7 +420,7 @@ func getBlobMessage(blob *core.BlobMessage) (*node.Blob, error) {
 		}
 	}
 
-	data, err := blob.Bundles.Serialize()
+	data, err := blob.Bundles.SerializeWithFormat(format)
 	if err != nil {
 		return nil, err
 	}
@@ -214,11 +442,13 @@ func getBlobMessage(blob *core.BlobMessage) (*node.Blob, error) {
 
 	return &node.Blob{
 		Header: &node.BlobHeader{
-			Commitment:       commitData,
-			LengthCommitment: &lengthCommitData,
-			LengthProof:      &lengthProofData,
-			Length:           uint32(blob.BlobHeader.Length),
-			QuorumHeaders:    quorumHeaders,
+			Commitment:             commitData,
+			LengthCommitment:       &lengthCommitData,
+			LengthProof:            &lengthProofData,
+			Length:                 uint32(blob.BlobHeader.Length),
+			QuorumHeaders:          quorumHeaders,
+			RetentionPeriodSeconds: uint32(blob.BlobHeader.RetentionPeriod / time.Second),
+			CorrelationId:          blob.BlobHeader.CorrelationID,
 		},
 		Bundles: bundles,
 	}, nil
@@ -0,0 +1,205 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingClient fails the first StoreChunks requests with the given errors
+type failingClient struct {
+	node.DispersalClient
+	errs     []error
+	attempts int
+}
+
+func (c *failingClient) StoreChunks(ctx context.Context, in *node.StoreChunksRequest, opts ...grpc.CallOption) (*node.StoreChunksReply, error) {
+	c.attempts++
+	if c.attempts <= len(c.errs) {
+		return nil, c.errs[c.attempts-1]
+	}
+	return &node.StoreChunksReply{}, nil
+}
+
+func (c *failingClient) send(request *node.StoreChunksRequest) func(context.Context) (*node.StoreChunksReply, error) {
+	return func(ctx context.Context) (*node.StoreChunksReply, error) {
+		return c.StoreChunks(ctx, request)
+	}
+}
+
+// streamingClient records the requests of its StoreChunksStream streams, whose first attempt fails with err
+type streamingClient struct {
+	node.DispersalClient
+	err     error
+	streams [][]*node.StoreChunksRequest
+}
+
+func (c *streamingClient) StoreChunksStream(ctx context.Context, opts ...grpc.CallOption) (node.Dispersal_StoreChunksStreamClient, error) {
+	c.streams = append(c.streams, nil)
+	return &recordingStream{client: c, index: len(c.streams) - 1}, nil
+}
+
+type recordingStream struct {
+	node.Dispersal_StoreChunksStreamClient
+	client *streamingClient
+	index  int
+}
+
+func (s *recordingStream) Send(request *node.StoreChunksRequest) error {
+	s.client.streams[s.index] = append(s.client.streams[s.index], request)
+	return nil
+}
+
+func (s *recordingStream) CloseAndRecv() (*node.StoreChunksReply, error) {
+	if s.index == 0 && s.client.err != nil {
+		return nil, s.client.err
+	}
+	return &node.StoreChunksReply{}, nil
+}
+
+func newTestDispatcher(retry RetryConfig) *dispatcher {
+	logger := logging.NewNoopLogger()
+	return NewDispatcher(&Config{Timeout: time.Second, Retry: retry}, logger, batcher.NewMetrics("9100", logger).DispatcherMetrics)
+}
+
+func TestStoreChunksRetries(t *testing.T) {
+	ctx := context.Background()
+	op := &core.IndexedOperatorInfo{Socket: "localhost:32000;32001"}
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	c := newTestDispatcher(RetryConfig{MaxRetries: 2, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond})
+
+	// Transient errors are retried
+	client := &failingClient{errs: []error{unavailable, unavailable}}
+	_, err := c.storeChunks(ctx, op, client.send(&node.StoreChunksRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, client.attempts)
+	assert.Equal(t, 2.0, testutil.ToFloat64(c.metrics.Retries))
+
+	// Up to MaxRetries times
+	client = &failingClient{errs: []error{unavailable, unavailable, unavailable}}
+	_, err = c.storeChunks(ctx, op, client.send(&node.StoreChunksRequest{}))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, client.attempts)
+
+	// An invalid batch isn't sent again
+	client = &failingClient{errs: []error{status.Error(codes.InvalidArgument, "invalid chunks")}}
+	_, err = c.storeChunks(ctx, op, client.send(&node.StoreChunksRequest{}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, client.attempts)
+
+	// Nor is a batch whose deadline would pass during the backoff
+	c = newTestDispatcher(RetryConfig{MaxRetries: 2, Backoff: time.Minute})
+	deadlineCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	client = &failingClient{errs: []error{unavailable}}
+	_, err = c.storeChunks(deadlineCtx, op, client.send(&node.StoreChunksRequest{}))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, client.attempts)
+}
+
+func TestSplitMinibatches(t *testing.T) {
+	// blob returns a blob with the chunks of numSymbols symbols, so numSymbols*32 bytes
+	blob := func(numSymbols int) *core.BlobMessage {
+		return &core.BlobMessage{Bundles: core.Bundles{0: {{Coeffs: make([]fr.Element, numSymbols)}}}}
+	}
+	blobs := []*core.BlobMessage{blob(1), blob(0), blob(2), blob(1), blob(1), blob(0)}
+
+	minibatches := splitMinibatches(blobs, 64)
+	assert.Equal(t, [][]*core.BlobMessage{blobs[:3], blobs[3:5], blobs[5:]}, minibatches)
+
+	minibatches = splitMinibatches(blobs, 1024)
+	assert.Equal(t, [][]*core.BlobMessage{blobs}, minibatches)
+}
+
+func TestStreamChunks(t *testing.T) {
+	ctx := context.Background()
+	op := &core.IndexedOperatorInfo{Socket: "localhost:32000;32001"}
+	c := newTestDispatcher(RetryConfig{MaxRetries: 1, Backoff: time.Millisecond})
+	c.MinibatchSize = 1
+
+	header := &core.BatchHeader{ReferenceBlockNumber: 1}
+	blobs := make([]*core.BlobMessage, 3)
+	for i := range blobs {
+		blobs[i] = &core.BlobMessage{
+			BlobHeader: &core.BlobHeader{BlobCommitments: encoding.BlobCommitments{Commitment: &encoding.G1Commitment{}}},
+			Bundles:    core.Bundles{0: {{Coeffs: make([]fr.Element, 1)}}},
+		}
+	}
+
+	// The minibatches are sent again when the stream fails with a transient error
+	client := &streamingClient{err: status.Error(codes.Unavailable, "connection reset")}
+	_, err := c.streamChunks(ctx, client, blobs, header, encoding.GobChunkEncodingFormat, op)
+	assert.NoError(t, err)
+	assert.Len(t, client.streams, 2)
+	for _, requests := range client.streams {
+		assert.Len(t, requests, 3)
+		// Only the first minibatch carries the batch header
+		assert.NotNil(t, requests[0].GetBatchHeader())
+		assert.Nil(t, requests[1].GetBatchHeader())
+		assert.Nil(t, requests[2].GetBatchHeader())
+		for _, request := range requests {
+			assert.Len(t, request.GetBlobs(), 1)
+		}
+	}
+}
+
+func TestOperatorBlacklisting(t *testing.T) {
+	now := time.Now()
+	failures := newOperatorFailures(2, time.Minute)
+	id := core.OperatorID{1}
+
+	assert.False(t, failures.record(id, false, now))
+	assert.False(t, failures.blacklisted(id, now))
+	assert.True(t, failures.record(id, false, now))
+	assert.True(t, failures.blacklisted(id, now))
+	assert.Equal(t, 1, failures.numBlacklisted(now))
+
+	// After its cooldown, the operator is tried again and blacklisted right away if it fails
+	later := now.Add(2 * time.Minute)
+	assert.False(t, failures.blacklisted(id, later))
+	assert.Equal(t, 0, failures.numBlacklisted(later))
+	assert.True(t, failures.record(id, false, later))
+	assert.True(t, failures.blacklisted(id, later))
+
+	// A success clears its failures
+	assert.False(t, failures.record(id, true, later))
+	assert.False(t, failures.blacklisted(id, later))
+	assert.False(t, failures.record(id, false, later))
+
+	// Operators are never blacklisted without a threshold
+	failures = newOperatorFailures(0, time.Minute)
+	assert.False(t, failures.record(id, false, now))
+	assert.False(t, failures.blacklisted(id, now))
+}
+
+func TestDisperseBatchSkipsBlacklistedOperators(t *testing.T) {
+	c := newTestDispatcher(RetryConfig{BlacklistThreshold: 1, BlacklistDuration: time.Minute})
+	id := core.OperatorID{1}
+	c.failures.record(id, false, time.Now())
+
+	state := &core.IndexedOperatorState{
+		IndexedOperators: map[core.OperatorID]*core.IndexedOperatorInfo{id: {Socket: "localhost:32000;32001"}},
+	}
+	blobs := []core.EncodedBlob{{BundlesByOperator: map[core.OperatorID]core.Bundles{id: {}}}}
+	update := c.DisperseBatch(context.Background(), state, blobs, &core.BatchHeader{})
+	select {
+	case msg := <-update:
+		assert.ErrorIs(t, msg.Err, errOperatorBlacklisted)
+		assert.Equal(t, id, msg.Operator)
+	case <-time.After(time.Second):
+		t.Fatal("the blacklisted operator wasn't reported right away")
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.metrics.OperatorOutcomes.WithLabelValues("blacklisted")))
+}
@@ -0,0 +1,73 @@
+package dispatcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// operatorFailures tracks the consecutive batches each operator failed, and blacklists the operators which failed
+// threshold batches in a row for a cooldown, during which the batches are dispersed without them. Once its cooldown
+// is over, a blacklisted operator is sent the next batch again, and blacklisted right away if it fails it.
+type operatorFailures struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+
+	consecutive      map[core.OperatorID]int
+	blacklistedUntil map[core.OperatorID]time.Time
+}
+
+func newOperatorFailures(threshold int, cooldown time.Duration) *operatorFailures {
+	return &operatorFailures{
+		threshold:        threshold,
+		cooldown:         cooldown,
+		consecutive:      make(map[core.OperatorID]int),
+		blacklistedUntil: make(map[core.OperatorID]time.Time),
+	}
+}
+
+// blacklisted returns whether the operator is blacklisted at now
+func (f *operatorFailures) blacklisted(id core.OperatorID, now time.Time) bool {
+	if f.threshold <= 0 {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return now.Before(f.blacklistedUntil[id])
+}
+
+// record records whether the operator took a batch, and returns whether the operator was blacklisted for failing it
+func (f *operatorFailures) record(id core.OperatorID, success bool, now time.Time) bool {
+	if f.threshold <= 0 {
+		return false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if success {
+		delete(f.consecutive, id)
+		delete(f.blacklistedUntil, id)
+		return false
+	}
+	f.consecutive[id]++
+	if f.consecutive[id] < f.threshold {
+		return false
+	}
+	f.blacklistedUntil[id] = now.Add(f.cooldown)
+	return true
+}
+
+// numBlacklisted returns the number of operators blacklisted at now
+func (f *operatorFailures) numBlacklisted(now time.Time) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, until := range f.blacklistedUntil {
+		if now.Before(until) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,128 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+type LeaderElectionConfig struct {
+	// LeaseDuration is how long the lease lasts without being renewed, which bounds how long the batchers are without
+	// a leader after the leader stops
+	LeaseDuration time.Duration
+	// RenewInterval is how often the leader renews its lease, and how often the standbys try to acquire it
+	RenewInterval time.Duration
+}
+
+func (c LeaderElectionConfig) Validate() error {
+	if c.RenewInterval <= 0 {
+		return errors.New("the lease renew interval must be positive")
+	}
+	if c.LeaseDuration < 2*c.RenewInterval {
+		return errors.New("the lease duration must be at least twice the lease renew interval")
+	}
+	return nil
+}
+
+// LeaderElector elects the batcher running the batching pipeline among the batchers sharing a LeaseStore. The other
+// batchers stand by, and one of them takes over once the lease of the leader expires.
+type LeaderElector struct {
+	LeaderElectionConfig
+
+	store   LeaseStore
+	owner   string
+	logger  logging.Logger
+	metrics *Metrics
+
+	// expiry is the expiry of the lease held by the leader, only accessed by the renewing goroutine once elected
+	expiry   time.Time
+	lost     chan struct{}
+	lostOnce sync.Once
+}
+
+// NewLeaderElector creates the elector of a batcher, identified by owner among the batchers sharing the store
+func NewLeaderElector(config LeaderElectionConfig, store LeaseStore, owner string, logger logging.Logger, metrics *Metrics) *LeaderElector {
+	return &LeaderElector{
+		LeaderElectionConfig: config,
+		store:                store,
+		owner:                owner,
+		logger:               logger.With("component", "LeaderElector", "owner", owner),
+		metrics:              metrics,
+		lost:                 make(chan struct{}),
+	}
+}
+
+// Campaign blocks until the batcher acquires the lease, or the context is done. Once elected, the lease is renewed
+// until the context is done.
+func (e *LeaderElector) Campaign(ctx context.Context) error {
+	ticker := time.NewTicker(e.RenewInterval)
+	defer ticker.Stop()
+	for {
+		expiry := time.Now().Add(e.LeaseDuration)
+		acquired, err := e.store.AcquireLease(ctx, e.owner, expiry)
+		if err != nil {
+			e.logger.Warn("failed to acquire the lease", "err", err)
+		}
+		if acquired {
+			e.logger.Info("elected leader", "leaseExpiry", expiry)
+			e.expiry = expiry
+			e.metrics.UpdateLeader(true)
+			go e.renew(ctx)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Lost is closed when the leader fails to renew its lease. The leader must stop batching at once, since a standby
+// can take over as soon as the lease expires.
+func (e *LeaderElector) Lost() <-chan struct{} {
+	return e.lost
+}
+
+// renew extends the lease every RenewInterval. Leadership is given up if another batcher took the lease, or if the
+// lease would expire before the next renewal, so that the leader stops before a standby can take over.
+func (e *LeaderElector) renew(ctx context.Context) {
+	ticker := time.NewTicker(e.RenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		expiry := time.Now().Add(e.LeaseDuration)
+		acquired, err := e.store.AcquireLease(ctx, e.owner, expiry)
+		if err == nil && acquired {
+			e.expiry = expiry
+			continue
+		}
+		if err == nil {
+			e.logger.Error("lost the lease to another batcher")
+			e.loseLeadership()
+			return
+		}
+		if !time.Now().Add(e.RenewInterval).Before(e.expiry) {
+			e.logger.Error("failed to renew the lease before it expires", "leaseExpiry", e.expiry, "err", err)
+			e.loseLeadership()
+			return
+		}
+		e.logger.Warn("failed to renew the lease, retrying", "leaseExpiry", e.expiry, "err", err)
+	}
+}
+
+func (e *LeaderElector) loseLeadership() {
+	e.lostOnce.Do(func() {
+		e.metrics.UpdateLeader(false)
+		close(e.lost)
+	})
+}
@@ -0,0 +1,80 @@
+package batcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+var testElectionConfig = batcher.LeaderElectionConfig{
+	LeaseDuration: 200 * time.Millisecond,
+	RenewInterval: 50 * time.Millisecond,
+}
+
+type refusingLeaseStore struct {
+	acquired bool
+}
+
+func (s *refusingLeaseStore) AcquireLease(ctx context.Context, owner string, expiry time.Time) (bool, error) {
+	// The lease is granted once, and then taken by another batcher
+	if s.acquired {
+		return false, nil
+	}
+	s.acquired = true
+	return true, nil
+}
+
+func TestLeaderElectionConfigValidate(t *testing.T) {
+	assert.NoError(t, testElectionConfig.Validate())
+	assert.Error(t, batcher.LeaderElectionConfig{LeaseDuration: time.Second}.Validate())
+	assert.Error(t, batcher.LeaderElectionConfig{LeaseDuration: time.Second, RenewInterval: time.Second}.Validate())
+}
+
+func TestLeaderElection(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	store := batcher.NewLocalStateStore()
+	leader := batcher.NewLeaderElector(testElectionConfig, store, "leader", logger, metrics)
+	standby := batcher.NewLeaderElector(testElectionConfig, store, "standby", logger, metrics)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	defer cancelLeader()
+	assert.NoError(t, leader.Campaign(leaderCtx))
+
+	// The standby isn't elected while the leader renews its lease
+	ctx, cancel := context.WithTimeout(context.Background(), 2*testElectionConfig.LeaseDuration)
+	defer cancel()
+	assert.ErrorIs(t, standby.Campaign(ctx), context.DeadlineExceeded)
+
+	// The standby takes over once the leader stops and its lease expires
+	cancelLeader()
+	ctx, cancel = context.WithTimeout(context.Background(), 4*testElectionConfig.LeaseDuration)
+	defer cancel()
+	assert.NoError(t, standby.Campaign(ctx))
+
+	select {
+	case <-standby.Lost():
+		t.Fatal("standby lost the lease after taking over")
+	default:
+	}
+}
+
+func TestLeaderElectionLost(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	elector := batcher.NewLeaderElector(testElectionConfig, &refusingLeaseStore{}, "leader", logger, metrics)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.NoError(t, elector.Campaign(ctx))
+
+	select {
+	case <-elector.Lost():
+	case <-time.After(testElectionConfig.LeaseDuration):
+		t.Fatal("leader didn't give up the lease taken by another batcher")
+	}
+}
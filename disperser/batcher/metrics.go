@@ -3,8 +3,12 @@ package batcher
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -33,6 +37,10 @@ type MetricsConfig struct {
 
 type EncodingStreamerMetrics struct {
 	EncodedBlobs *prometheus.GaugeVec
+	// DeferredBlobs counts the encoded blobs left out of a full batch
+	DeferredBlobs prometheus.Counter
+	// HeadOfLineDelay is how long the oldest blob left out of the last batch has been waiting
+	HeadOfLineDelay prometheus.Gauge
 }
 
 type TxnManagerMetrics struct {
@@ -41,16 +49,35 @@ type TxnManagerMetrics struct {
 	SpeedUps prometheus.Gauge
 	TxQueue  prometheus.Gauge
 	NumTx    *prometheus.CounterVec
+	// GasEstimationError is the error of the gas price estimated by the gas strategy relative to the price paid
+	GasEstimationError *prometheus.SummaryVec
 }
 
 type FinalizerMetrics struct {
 	NumBlobs               *prometheus.CounterVec
 	LastSeenFinalizedBlock prometheus.Gauge
 	Latency                *prometheus.SummaryVec
+	// ReorgedBatches counts the batches whose confirmation transaction was dropped from the chain by a reorg
+	ReorgedBatches prometheus.Counter
 }
 
 type DispatcherMetrics struct {
 	Latency *prometheus.SummaryVec
+	// OperatorVerification is the validation time per chunk reported by each operator for its last batch
+	OperatorVerification *prometheus.GaugeVec
+	// OperatorOutcomes counts the dispersals to the operators by whether the operator signed the batch, failed it, or
+	// was skipped because it's blacklisted
+	OperatorOutcomes *prometheus.CounterVec
+	// Retries counts the chunks sent again to the operators after a transient failure
+	Retries prometheus.Counter
+	// BlacklistedOperators is the number of operators blacklisted after failing consecutive batches
+	BlacklistedOperators prometheus.Gauge
+}
+
+// EncoderClientMetrics records the hedging of the encoding requests across the encoder replicas
+type EncoderClientMetrics struct {
+	Hedges             *prometheus.CounterVec
+	DuplicateEncodings prometheus.Counter
 }
 
 type Metrics struct {
@@ -58,6 +85,7 @@ type Metrics struct {
 	*TxnManagerMetrics
 	*FinalizerMetrics
 	*DispatcherMetrics
+	*EncoderClientMetrics
 
 	registry *prometheus.Registry
 
@@ -67,6 +95,15 @@ type Metrics struct {
 	BatchProcLatencyHistogram *prometheus.HistogramVec
 	Attestation               *prometheus.GaugeVec
 	BatchError                *prometheus.CounterVec
+	// BatchDecisions counts the decisions of the batch policy by their reason
+	BatchDecisions *prometheus.CounterVec
+	// BatchSignals are the signals and the target batch size of the last decision of the batch policy
+	BatchSignals *prometheus.GaugeVec
+	// Leader is 1 while the batcher holds the lease of the leader, and 0 while it stands by
+	Leader prometheus.Gauge
+	// RecoveredBlobs counts the blobs left dispersing by a previous leader, by whether they were requeued or found
+	// confirmed onchain
+	RecoveredBlobs *prometheus.CounterVec
 
 	httpPort string
 	logger   logging.Logger
@@ -86,6 +123,20 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"type"},
 		),
+		DeferredBlobs: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "deferred_blobs_total",
+				Help:      "number of encoded blobs left out of a full batch",
+			},
+		),
+		HeadOfLineDelay: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "head_of_line_delay_seconds",
+				Help:      "time the oldest blob left out of the last batch has been waiting since its dispersal",
+			},
+		),
 	}
 
 	txnManagerMetrics := TxnManagerMetrics{
@@ -127,6 +178,15 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"state"},
 		),
+		GasEstimationError: promauto.With(reg).NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  namespace,
+				Name:       "gas_estimation_error",
+				Help:       "gas price paid by the mined transactions minus the price expected by the gas strategy, relative to the expected price",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"strategy"},
+		),
 	}
 
 	finalizerMetrics := FinalizerMetrics{
@@ -136,7 +196,7 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 				Name:      "finalizer_num_blobs",
 				Help:      "number of blobs in each state",
 			},
-			[]string{"state"}, // possible values are "processed", "failed", "finalized"
+			[]string{"state"}, // possible values are "processed", "failed", "finalized", "rolled_back"
 		),
 		LastSeenFinalizedBlock: promauto.With(reg).NewGauge(
 			prometheus.GaugeOpts{
@@ -154,6 +214,13 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"stage"}, // possible values are "round" and "total"
 		),
+		ReorgedBatches: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "finalizer_reorged_batches_total",
+				Help:      "number of batches whose confirmation transaction was dropped by a reorg",
+			},
+		),
 	}
 
 	dispatcherMatrics := DispatcherMetrics{
@@ -166,6 +233,54 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"status"},
 		),
+		OperatorVerification: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "operator_chunk_verification_us",
+				Help:      "time in microseconds each operator reported to verify a chunk of its last batch",
+			},
+			[]string{"operator_id"},
+		),
+		OperatorOutcomes: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operator_dispersals_total",
+				Help:      "number of dispersals to the operators by outcome",
+			},
+			[]string{"outcome"}, // possible values are "signed", "failed" and "blacklisted"
+		),
+		Retries: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "operator_dispersal_retries_total",
+				Help:      "number of chunks sent again to the operators after a transient failure",
+			},
+		),
+		BlacklistedOperators: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "blacklisted_operators",
+				Help:      "number of operators blacklisted after failing consecutive batches",
+			},
+		),
+	}
+
+	encoderClientMetrics := EncoderClientMetrics{
+		Hedges: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "encoding_hedges_total",
+				Help:      "number of encoding requests hedged to a second encoder replica",
+			},
+			[]string{"outcome"}, // possible values are "sent", "won" and "skipped"
+		),
+		DuplicateEncodings: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "duplicate_encodings_total",
+				Help:      "number of encodings abandoned because another encoder replica replied first",
+			},
+		),
 	}
 
 	metrics := &Metrics{
@@ -173,6 +288,7 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 		TxnManagerMetrics:       &txnManagerMetrics,
 		FinalizerMetrics:        &finalizerMetrics,
 		DispatcherMetrics:       &dispatcherMatrics,
+		EncoderClientMetrics:    &encoderClientMetrics,
 		Blob: promauto.With(reg).NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -224,6 +340,37 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"type"},
 		),
+		BatchDecisions: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "batch_policy_decisions_total",
+				Help:      "number of decisions of the batch policy",
+			},
+			[]string{"cut", "reason"},
+		),
+		BatchSignals: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "batch_policy_signals",
+				Help:      "signals and target batch size of the last decision of the batch policy",
+			},
+			[]string{"signal"},
+		),
+		Leader: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "leader",
+				Help:      "whether the batcher holds the lease of the leader",
+			},
+		),
+		RecoveredBlobs: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "recovered_blobs_total",
+				Help:      "number of blobs left dispersing by a previous leader",
+			},
+			[]string{"outcome"},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger.With("component", "BatcherMetrics"),
@@ -260,6 +407,34 @@ func (t *DispatcherMetrics) ObserveLatency(success bool, latencyMS float64) {
 	t.Latency.WithLabelValues(label).Observe(latencyMS)
 }
 
+// ObserveOperatorVerification records the time an operator reported to verify the chunks of a batch
+func (t *DispatcherMetrics) ObserveOperatorVerification(operatorID core.OperatorID, verificationMicros uint64, numChunks uint64) {
+	if numChunks == 0 {
+		return
+	}
+	t.OperatorVerification.WithLabelValues(operatorID.Hex()).Set(float64(verificationMicros) / float64(numChunks))
+}
+
+func (t *DispatcherMetrics) IncrementOperatorOutcome(outcome string) {
+	t.OperatorOutcomes.WithLabelValues(outcome).Inc()
+}
+
+func (t *DispatcherMetrics) IncrementRetries() {
+	t.Retries.Inc()
+}
+
+func (t *DispatcherMetrics) UpdateBlacklistedOperators(count int) {
+	t.BlacklistedOperators.Set(float64(count))
+}
+
+func (e *EncoderClientMetrics) IncrementHedges(outcome string) {
+	e.Hedges.WithLabelValues(outcome).Inc()
+}
+
+func (e *EncoderClientMetrics) IncrementDuplicateEncodings() {
+	e.DuplicateEncodings.Inc()
+}
+
 // UpdateCompletedBlob increments the number and updates size of processed blobs.
 func (g *Metrics) UpdateCompletedBlob(size int, status disperser.BlobStatus) {
 	switch status {
@@ -289,11 +464,54 @@ func (g *Metrics) UpdateBatchError(errType FailReason, numBlobs int) {
 	g.BatchError.WithLabelValues(string(errType)).Add(float64(numBlobs))
 }
 
+func (g *Metrics) RecordBatchDecision(signals BatchSignals, decision BatchDecision) {
+	g.BatchDecisions.WithLabelValues(strconv.FormatBool(decision.Cut), decision.Reason).Inc()
+	g.BatchSignals.WithLabelValues("since_last_batch_seconds").Set(signals.SinceLastBatch.Seconds())
+	g.BatchSignals.WithLabelValues("pending_blobs").Set(float64(signals.PendingBlobs))
+	g.BatchSignals.WithLabelValues("pending_bytes").Set(float64(signals.PendingBytes))
+	g.BatchSignals.WithLabelValues("arrival_rate_bytes_per_second").Set(signals.ArrivalRate)
+	g.BatchSignals.WithLabelValues("dispersal_throughput_bytes_per_second").Set(signals.DispersalThroughput)
+	g.BatchSignals.WithLabelValues("target_bytes").Set(float64(decision.TargetBytes))
+	if signals.GasPrice != nil {
+		gasPrice, _ := new(big.Float).SetInt(signals.GasPrice).Float64()
+		g.BatchSignals.WithLabelValues("gas_price_wei").Set(gasPrice)
+	}
+}
+
+func (g *Metrics) UpdateLeader(leader bool) {
+	if leader {
+		g.Leader.Set(1)
+	} else {
+		g.Leader.Set(0)
+	}
+}
+
+func (g *Metrics) IncrementRecoveredBlobs(outcome string, numBlobs int) {
+	g.RecoveredBlobs.WithLabelValues(outcome).Add(float64(numBlobs))
+}
+
 func (g *Metrics) ObserveLatency(stage string, latencyMs float64) {
 	g.BatchProcLatency.WithLabelValues(stage).Observe(latencyMs)
 	g.BatchProcLatencyHistogram.WithLabelValues(stage).Observe(latencyMs)
 }
 
+// ObserveE2ELatency records the latency of a blob from its dispersal to its confirmation, with its correlation ID
+// as the exemplar of the histogram, so that an outlier can be traced through the logs of every service
+func (g *Metrics) ObserveE2ELatency(latencyMs float64, correlationID string) {
+	g.BatchProcLatency.WithLabelValues("E2E").Observe(latencyMs)
+	observer := g.BatchProcLatencyHistogram.WithLabelValues("E2E")
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && correlationID != "" {
+		exemplarObserver.ObserveWithExemplar(latencyMs, prometheus.Labels{"correlation_id": correlationID})
+		return
+	}
+	observer.Observe(latencyMs)
+}
+
+// RegisterBuildInfo exports the build of the batcher as the build_info metric
+func (g *Metrics) RegisterBuildInfo(info buildinfo.Info) error {
+	return buildinfo.Register(g.registry, info)
+}
+
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
@@ -302,7 +520,8 @@ func (g *Metrics) Start(ctx context.Context) {
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.HandlerFor(
 			g.registry,
-			promhttp.HandlerOpts{},
+			// The exemplars are only exposed in the OpenMetrics format
+			promhttp.HandlerOpts{EnableOpenMetrics: true},
 		))
 		err := http.ListenAndServe(addr, mux)
 		log.Error("prometheus server failed", "err", err)
@@ -314,6 +533,11 @@ func (e *EncodingStreamerMetrics) UpdateEncodedBlobs(count int, size uint64) {
 	e.EncodedBlobs.WithLabelValues("number").Set(float64(count))
 }
 
+func (e *EncodingStreamerMetrics) UpdateDeferredBlobs(count int, headOfLineDelay time.Duration) {
+	e.DeferredBlobs.Add(float64(count))
+	e.HeadOfLineDelay.Set(headOfLineDelay.Seconds())
+}
+
 func (t *TxnManagerMetrics) ObserveLatency(stage string, latencyMs float64) {
 	t.Latency.WithLabelValues(stage).Observe(latencyMs)
 }
@@ -334,6 +558,10 @@ func (t *TxnManagerMetrics) IncrementTxnCount(state string) {
 	t.NumTx.WithLabelValues(state).Inc()
 }
 
+func (t *TxnManagerMetrics) ObserveGasEstimationError(strategy string, relativeError float64) {
+	t.GasEstimationError.WithLabelValues(strategy).Observe(relativeError)
+}
+
 func (f *FinalizerMetrics) IncrementNumBlobs(state string) {
 	f.NumBlobs.WithLabelValues(state).Inc()
 }
@@ -349,3 +577,7 @@ func (f *FinalizerMetrics) UpdateLastSeenFinalizedBlock(blockNumber uint64) {
 func (f *FinalizerMetrics) ObserveLatency(stage string, latencyMs float64) {
 	f.Latency.WithLabelValues(stage).Observe(latencyMs)
 }
+
+func (f *FinalizerMetrics) IncrementReorgedBatches() {
+	f.ReorgedBatches.Inc()
+}
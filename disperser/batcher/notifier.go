@@ -0,0 +1,72 @@
+package batcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/webhook"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// BlobNotification is the body of the notifications posted to the callback URL of a blob
+type BlobNotification struct {
+	// RequestID is the request ID of the blob, as returned by DisperseBlob
+	RequestID string `json:"request_id"`
+	// Status is the status of the blob as returned by GetBlobStatus, in the protobuf JSON encoding, which includes the
+	// blob verification proof
+	Status json.RawMessage `json:"status"`
+}
+
+// BlobNotifier posts the status of the blobs dispersed with a callback URL when they're confirmed and when they're
+// finalized, so that the clients don't have to poll for it. The notifications are signed with the callback secret of
+// the blob as described in the webhook package.
+type BlobNotifier struct {
+	client *http.Client
+	logger logging.Logger
+}
+
+func NewBlobNotifier(timeout time.Duration, logger logging.Logger) *BlobNotifier {
+	return &BlobNotifier{
+		client: &http.Client{Timeout: timeout},
+		logger: logger.With("component", "BlobNotifier"),
+	}
+}
+
+// Notify posts the status of the blob to its callback URL in the background, if it has one. Failed notifications
+// aren't retried: the client can still get the status of the blob with GetBlobStatus.
+func (n *BlobNotifier) Notify(ctx context.Context, metadata *disperser.BlobMetadata) {
+	header := metadata.RequestMetadata.BlobRequestHeader
+	if header.CallbackURL == "" {
+		return
+	}
+	reply, err := disperser.ToBlobStatusReply(metadata)
+	if err != nil {
+		n.logger.Error("failed to build the blob status notification", "blobKey", metadata.GetBlobKey().String(), "err", err)
+		return
+	}
+	status, err := protojson.Marshal(reply)
+	if err != nil {
+		n.logger.Error("failed to serialize the blob status", "blobKey", metadata.GetBlobKey().String(), "err", err)
+		return
+	}
+	body, err := json.Marshal(BlobNotification{
+		RequestID: metadata.GetBlobKey().String(),
+		Status:    status,
+	})
+	if err != nil {
+		n.logger.Error("failed to serialize the blob status notification", "blobKey", metadata.GetBlobKey().String(), "err", err)
+		return
+	}
+
+	// The notification outlives the handling of the blob, it's only bounded by the timeout of the client
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := webhook.Post(ctx, n.client, header.CallbackURL, header.CallbackSecret, body); err != nil {
+			n.logger.Warn("failed to notify the callback of the blob", "blobKey", metadata.GetBlobKey().String(), "status", metadata.BlobStatus.String(), "correlationID", header.CorrelationID, "err", err)
+		}
+	}()
+}
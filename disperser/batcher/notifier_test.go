@@ -0,0 +1,83 @@
+package batcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common/webhook"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestBlobNotifier(t *testing.T) {
+	secret := []byte("secret")
+	received := make(chan batcher.BlobNotification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute))
+		var notification batcher.BlobNotification
+		assert.NoError(t, json.Unmarshal(body, &notification))
+		received <- notification
+	}))
+	defer server.Close()
+
+	metadata := &disperser.BlobMetadata{
+		BlobHash:     "blobHash",
+		MetadataHash: "metadataHash",
+		BlobStatus:   disperser.Finalized,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: core.BlobRequestHeader{
+				CallbackURL:    server.URL,
+				CallbackSecret: secret,
+			},
+		},
+		ConfirmationInfo: &disperser.ConfirmationInfo{
+			BatchID:                 7,
+			BlobIndex:               3,
+			ConfirmationBlockNumber: 150,
+			BlobCommitment: &encoding.BlobCommitments{
+				Commitment: &encoding.G1Commitment{},
+				Length:     16,
+			},
+			BlobQuorumInfos: []*core.BlobQuorumInfo{{SecurityParam: core.SecurityParam{QuorumID: 0}}},
+			QuorumResults:   map[core.QuorumID]*core.QuorumResult{0: {QuorumID: 0, PercentSigned: 100}},
+		},
+	}
+
+	notifier := batcher.NewBlobNotifier(time.Second, logging.NewNoopLogger())
+	notifier.Notify(context.Background(), metadata)
+
+	select {
+	case notification := <-received:
+		assert.Equal(t, metadata.GetBlobKey().String(), notification.RequestID)
+		var reply disperser_rpc.BlobStatusReply
+		assert.NoError(t, protojson.Unmarshal(notification.Status, &reply))
+		assert.Equal(t, disperser_rpc.BlobStatus_FINALIZED, reply.GetStatus())
+		assert.Equal(t, uint32(7), reply.GetInfo().GetBlobVerificationProof().GetBatchId())
+		assert.Equal(t, uint32(3), reply.GetInfo().GetBlobVerificationProof().GetBlobIndex())
+		assert.Equal(t, uint32(150), reply.GetInfo().GetBlobVerificationProof().GetBatchMetadata().GetConfirmationBlockNumber())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the blob status wasn't posted to the callback")
+	}
+
+	// The blobs without a callback URL aren't notified
+	metadata.RequestMetadata.CallbackURL = ""
+	notifier.Notify(context.Background(), metadata)
+	select {
+	case <-received:
+		t.Fatal("unexpected notification")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,88 @@
+package batcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// PriorityLanes are the weights of the priority lanes of the blobs, indexed by priority. Under load, the blobs of
+// each lane get a share of the encoding and of the batches in proportion to the weight of the lane, and the higher
+// lanes are served first, so that latency-sensitive blobs jump ahead of bulk traffic without starving it. The blobs
+// with a priority above the highest lane are in the highest lane. Without lanes, all the blobs have weight 1.
+type PriorityLanes []float64
+
+// ParsePriorityLanes parses comma separated lane weights such as "1,4", the first one being the weight of the
+// default lane
+func ParsePriorityLanes(s string) (PriorityLanes, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(s, ",")
+	lanes := make(PriorityLanes, len(parts))
+	for i, part := range parts {
+		weight, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight of priority lane %d: %w", i, err)
+		}
+		if weight <= 0 {
+			return nil, fmt.Errorf("weight of priority lane %d must be positive, got %f", i, weight)
+		}
+		lanes[i] = weight
+	}
+	return lanes, nil
+}
+
+// Lane returns the lane of the blobs of the given priority
+func (l PriorityLanes) Lane(priority uint32) uint32 {
+	if len(l) == 0 {
+		return 0
+	}
+	return min(priority, uint32(len(l)-1))
+}
+
+// Weight returns the weight of the lane of the blobs of the given priority
+func (l PriorityLanes) Weight(priority uint32) float64 {
+	if len(l) == 0 {
+		return 1
+	}
+	return l[l.Lane(priority)]
+}
+
+// Order orders the blobs to encode with smooth weighted round robin across the lanes, keeping the order of the blobs
+// of each lane, so that any prefix of the result shares the encoding across the lanes in proportion to their weights
+func (l PriorityLanes) Order(metadatas []*disperser.BlobMetadata) []*disperser.BlobMetadata {
+	if len(l) <= 1 {
+		return metadatas
+	}
+
+	queues := make([][]*disperser.BlobMetadata, len(l))
+	for _, metadata := range metadatas {
+		lane := l.Lane(metadata.RequestMetadata.Priority)
+		queues[lane] = append(queues[lane], metadata)
+	}
+
+	ordered := make([]*disperser.BlobMetadata, 0, len(metadatas))
+	credits := make([]float64, len(l))
+	for len(ordered) < len(metadatas) {
+		total := 0.0
+		next := -1
+		// The higher lanes win the ties, so that they're served first
+		for lane := len(l) - 1; lane >= 0; lane-- {
+			if len(queues[lane]) == 0 {
+				continue
+			}
+			credits[lane] += l[lane]
+			total += l[lane]
+			if next < 0 || credits[lane] > credits[next] {
+				next = lane
+			}
+		}
+		credits[next] -= total
+		ordered = append(ordered, queues[next][0])
+		queues[next] = queues[next][1:]
+	}
+	return ordered
+}
@@ -0,0 +1,67 @@
+package batcher_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriorityLanes(t *testing.T) {
+	lanes, err := batcher.ParsePriorityLanes("1, 4")
+	require.NoError(t, err)
+	assert.Equal(t, batcher.PriorityLanes{1, 4}, lanes)
+	assert.Equal(t, 4.0, lanes.Weight(1))
+	assert.Equal(t, 4.0, lanes.Weight(5))
+	assert.Equal(t, uint32(1), lanes.Lane(5))
+
+	lanes, err = batcher.ParsePriorityLanes("")
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, lanes.Weight(3))
+	assert.Equal(t, uint32(0), lanes.Lane(3))
+
+	_, err = batcher.ParsePriorityLanes("1,0")
+	assert.Error(t, err)
+	_, err = batcher.ParsePriorityLanes("1,x")
+	assert.Error(t, err)
+}
+
+func TestPriorityLanesOrder(t *testing.T) {
+	metadatas := make([]*disperser.BlobMetadata, 0)
+	for i := 0; i < 10; i++ {
+		metadatas = append(metadatas, &disperser.BlobMetadata{
+			BlobHash:        "bulk",
+			RequestMetadata: &disperser.RequestMetadata{RequestedAt: uint64(i)},
+		})
+	}
+	for i := 0; i < 10; i++ {
+		metadatas = append(metadatas, &disperser.BlobMetadata{
+			BlobHash: "rollup",
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: core.BlobRequestHeader{Priority: 1},
+				RequestedAt:       uint64(100 + i),
+			},
+		})
+	}
+
+	ordered := batcher.PriorityLanes{1, 3}.Order(metadatas)
+	require.Len(t, ordered, 20)
+	// The first blobs share the encoding across the lanes by weight, the higher lane first
+	lanes := ""
+	for _, metadata := range ordered[:8] {
+		lanes += metadata.BlobHash[:1]
+	}
+	assert.Equal(t, "rrbrrrbr", lanes)
+	// The blobs of a lane keep their order
+	last := map[string]uint64{}
+	for _, metadata := range ordered {
+		assert.GreaterOrEqual(t, metadata.RequestMetadata.RequestedAt, last[metadata.BlobHash])
+		last[metadata.BlobHash] = metadata.RequestMetadata.RequestedAt
+	}
+
+	// Without lanes the order is unchanged
+	assert.Equal(t, metadatas, batcher.PriorityLanes{1}.Order(metadatas))
+}
@@ -0,0 +1,49 @@
+package batcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// ParseQuorumAttestationConfigs parses comma separated attestation configs of the quorums such as "1=40s/67", which
+// gives quorum 1 a 40s timeout past which its attestation is settled once 67% of its stake signed
+func ParseQuorumAttestationConfigs(s string) (map[core.QuorumID]core.QuorumAttestationConfig, error) {
+	configs := make(map[core.QuorumID]core.QuorumAttestationConfig)
+	if strings.TrimSpace(s) == "" {
+		return configs, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		quorum, config, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid quorum attestation config %q, expected quorum=timeout/threshold", part)
+		}
+		quorumID, err := strconv.ParseUint(quorum, 10, 8)
+		if err != nil || quorumID > core.MaxQuorumID {
+			return nil, fmt.Errorf("invalid quorum ID %q in quorum attestation config", quorum)
+		}
+		if _, ok := configs[core.QuorumID(quorumID)]; ok {
+			return nil, fmt.Errorf("duplicate attestation config of quorum %d", quorumID)
+		}
+		timeoutStr, thresholdStr, ok := strings.Cut(config, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid attestation config %q of quorum %d, expected timeout/threshold", config, quorumID)
+		}
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid attestation timeout %q of quorum %d", timeoutStr, quorumID)
+		}
+		threshold, err := strconv.ParseUint(thresholdStr, 10, 8)
+		if err != nil || threshold > 100 {
+			return nil, fmt.Errorf("invalid attestation threshold %q of quorum %d, expected a percentage", thresholdStr, quorumID)
+		}
+		configs[core.QuorumID(quorumID)] = core.QuorumAttestationConfig{
+			Timeout:   timeout,
+			Threshold: uint8(threshold),
+		}
+	}
+	return configs, nil
+}
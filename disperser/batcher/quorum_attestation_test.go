@@ -0,0 +1,29 @@
+package batcher_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuorumAttestationConfigs(t *testing.T) {
+	configs, err := batcher.ParseQuorumAttestationConfigs("0=10s/55, 1=40s/67")
+	require.NoError(t, err)
+	assert.Equal(t, map[core.QuorumID]core.QuorumAttestationConfig{
+		0: {Timeout: 10 * time.Second, Threshold: 55},
+		1: {Timeout: 40 * time.Second, Threshold: 67},
+	}, configs)
+
+	configs, err = batcher.ParseQuorumAttestationConfigs("")
+	require.NoError(t, err)
+	assert.Empty(t, configs)
+
+	for _, s := range []string{"1=40s", "1=40s/101", "1=0s/67", "x=40s/67", "255=40s/67", "1=40s/67,1=20s/67"} {
+		_, err = batcher.ParseQuorumAttestationConfigs(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,164 @@
+package batcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
+)
+
+// InflightBatch is a batch whose confirmation transaction was sent, until its receipt is processed. The in-flight
+// batches of a leader batcher are persisted, so that a batcher taking over knows which of the blobs left in the
+// Dispersing status may still be confirmed onchain, and can record their confirmation if they were.
+type InflightBatch struct {
+	BatchHeaderHash      [32]byte
+	ReferenceBlockNumber uint
+	BlobKeys             []disperser.BlobKey
+	// Blobs is the confirmation info of the blobs known before the confirmation transaction is sent, in the order of
+	// BlobKeys. It's empty for the batches recorded by the batchers which didn't persist it.
+	Blobs []*InflightBlob
+	// The confirmation info shared by the blobs of the batch
+	SignatoryRecordHash [32]byte
+	BatchRoot           []byte
+	QuorumResults       map[core.QuorumID]*core.QuorumResult
+	AttestationTimeout  time.Duration
+}
+
+// InflightBlob is the confirmation info of a blob of an in-flight batch known before the confirmation transaction is
+// sent. The batch ID and the confirmation transaction are taken from the BatchConfirmed event of the batch.
+type InflightBlob struct {
+	// Attested is whether the blob received enough signatures to be confirmed
+	Attested bool
+	// InclusionProof is the inclusion proof of the blob header in the batch, which is only computed for the attested
+	// blobs
+	InclusionProof []byte
+	Commitment     *encoding.BlobCommitments
+	QuorumInfos    []*core.BlobQuorumInfo
+	// Cost is the cost of the blob without the gas of the confirmation transaction
+	Cost *disperser.BlobCost
+}
+
+// InflightTxn is a transaction sent by the TxnManager, until its receipt is received or it fails. Its attempts all
+// share its nonce, so that a TxnManager restarting or taking over knows which nonces are used and can keep replacing
+// the transaction until one of its attempts is mined.
+type InflightTxn struct {
+	Nonce uint64
+	Tag   string
+	// Tx is the binary encoding of the latest attempt, which is replaced with a higher gas price if it isn't mined
+	Tx []byte
+	// TxIDs identify all the attempts to the wallet which sent them
+	TxIDs []walletsdk.TxID
+}
+
+// TxnStore persists the in-flight transactions of a TxnManager
+type TxnStore interface {
+	// PutInflightTxn records a transaction once an attempt of it is sent
+	PutInflightTxn(ctx context.Context, txn *InflightTxn) error
+	// DeleteInflightTxn removes the record of a transaction once it is confirmed or failed
+	DeleteInflightTxn(ctx context.Context, nonce uint64) error
+	// GetInflightTxns returns the transactions recorded and not removed yet
+	GetInflightTxns(ctx context.Context) ([]*InflightTxn, error)
+}
+
+// LeaseStore persists the lease of the leader batcher, which at most one batcher holds at a time
+type LeaseStore interface {
+	// AcquireLease takes the lease for the owner until expiry, or extends it if the owner already holds it. It returns
+	// false if another owner holds a lease which hasn't expired.
+	AcquireLease(ctx context.Context, owner string, expiry time.Time) (bool, error)
+}
+
+// StateStore is the state shared by the batchers of a high availability deployment
+type StateStore interface {
+	LeaseStore
+	TxnStore
+
+	// PutInflightBatch records a batch before its confirmation transaction is sent
+	PutInflightBatch(ctx context.Context, batch *InflightBatch) error
+	// DeleteInflightBatch removes the record of a batch once the receipt of its confirmation transaction is processed
+	DeleteInflightBatch(ctx context.Context, batchHeaderHash [32]byte) error
+	// GetInflightBatches returns the batches recorded and not removed yet
+	GetInflightBatches(ctx context.Context) ([]*InflightBatch, error)
+}
+
+type localStateStore struct {
+	mu          sync.Mutex
+	owner       string
+	expiry      time.Time
+	batches     map[[32]byte]*InflightBatch
+	txns        map[uint64]*InflightTxn
+	currentTime func() time.Time
+}
+
+var _ StateStore = (*localStateStore)(nil)
+
+// NewLocalStateStore returns a StateStore kept in memory, which can only be shared by the batchers of a process
+func NewLocalStateStore() StateStore {
+	return &localStateStore{
+		batches:     make(map[[32]byte]*InflightBatch),
+		txns:        make(map[uint64]*InflightTxn),
+		currentTime: time.Now,
+	}
+}
+
+func (s *localStateStore) AcquireLease(ctx context.Context, owner string, expiry time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owner != "" && s.owner != owner && s.currentTime().Before(s.expiry) {
+		return false, nil
+	}
+	s.owner = owner
+	s.expiry = expiry
+	return true, nil
+}
+
+func (s *localStateStore) PutInflightBatch(ctx context.Context, batch *InflightBatch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches[batch.BatchHeaderHash] = batch
+	return nil
+}
+
+func (s *localStateStore) DeleteInflightBatch(ctx context.Context, batchHeaderHash [32]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.batches, batchHeaderHash)
+	return nil
+}
+
+func (s *localStateStore) GetInflightBatches(ctx context.Context) ([]*InflightBatch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	batches := make([]*InflightBatch, 0, len(s.batches))
+	for _, batch := range s.batches {
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
+
+func (s *localStateStore) PutInflightTxn(ctx context.Context, txn *InflightTxn) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txns[txn.Nonce] = txn
+	return nil
+}
+
+func (s *localStateStore) DeleteInflightTxn(ctx context.Context, nonce uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.txns, nonce)
+	return nil
+}
+
+func (s *localStateStore) GetInflightTxns(ctx context.Context) ([]*InflightTxn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txns := make([]*InflightTxn, 0, len(s.txns))
+	for _, txn := range s.txns {
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
@@ -0,0 +1,284 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// trackInflightBatch records the batch before its confirmation transaction is sent, so that a batcher taking over
+// knows its blobs may still be confirmed onchain, along with what it needs to record their confirmation
+func (b *Batcher) trackInflightBatch(ctx context.Context, headerHash [32]byte, batch *batch, aggSig *core.SignatureAggregation, attestationTimeout time.Duration) error {
+	if b.StateStore == nil {
+		return nil
+	}
+	blobKeys := make([]disperser.BlobKey, len(batch.BlobMetadata))
+	blobs := make([]*InflightBlob, len(batch.BlobMetadata))
+	for i, metadata := range batch.BlobMetadata {
+		blobKeys[i] = metadata.GetBlobKey()
+		blob := &InflightBlob{
+			Attested:    isBlobAttested(aggSig.QuorumResults, batch.BlobHeaders[i]),
+			Commitment:  &batch.BlobHeaders[i].BlobCommitments,
+			QuorumInfos: batch.BlobHeaders[i].QuorumInfos,
+		}
+		if blob.Attested {
+			proof, err := batch.MerkleTree.GenerateProofByIndex(uint64(i))
+			if err != nil {
+				return fmt.Errorf("failed to generate blob header inclusion proof: %w", err)
+			}
+			blob.InclusionProof = serializeProof(proof)
+		}
+		if i < len(batch.BlobCosts) {
+			blob.Cost = batch.BlobCosts[i]
+		}
+		blobs[i] = blob
+	}
+	return b.StateStore.PutInflightBatch(ctx, &InflightBatch{
+		BatchHeaderHash:      headerHash,
+		ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
+		BlobKeys:             blobKeys,
+		Blobs:                blobs,
+		SignatoryRecordHash:  core.ComputeSignatoryRecordHash(uint32(batch.BatchHeader.ReferenceBlockNumber), aggSig.NonSigners),
+		BatchRoot:            batch.BatchHeader.BatchRoot[:],
+		QuorumResults:        aggSig.QuorumResults,
+		AttestationTimeout:   attestationTimeout,
+	})
+}
+
+// untrackInflightBatch removes the record of the batch once the receipt of its confirmation transaction is processed
+func (b *Batcher) untrackInflightBatch(ctx context.Context, headerHash [32]byte) {
+	if b.StateStore == nil {
+		return
+	}
+	if err := b.StateStore.DeleteInflightBatch(ctx, headerHash); err != nil {
+		b.logger.Error("failed to remove the record of an in-flight batch", "batchHeaderHash", hexutil.Encode(headerHash[:]), "err", err)
+	}
+}
+
+// recoverDispersingBlobs takes over the blobs left in the Dispersing status by the previous leader. The blobs which
+// weren't in a batch sent for confirmation are requeued at once. The blobs of the in-flight batches are confirmed if
+// their batch is confirmed onchain, or requeued once it can't be confirmed anymore, which is when its reference block
+// is older than BLOCK_STALE_MEASURE.
+func (b *Batcher) recoverDispersingBlobs(ctx context.Context) error {
+	inflightBatches, err := b.StateStore.GetInflightBatches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the in-flight batches: %w", err)
+	}
+	inflightBlobs := make(map[disperser.BlobKey]struct{})
+	for _, batch := range inflightBatches {
+		for _, blobKey := range batch.BlobKeys {
+			inflightBlobs[blobKey] = struct{}{}
+		}
+	}
+
+	dispersing, err := b.Queue.GetBlobMetadataByStatus(ctx, disperser.Dispersing)
+	if err != nil {
+		return fmt.Errorf("failed to get the dispersing blobs: %w", err)
+	}
+	numRequeued := 0
+	for _, metadata := range dispersing {
+		if _, ok := inflightBlobs[metadata.GetBlobKey()]; ok {
+			continue
+		}
+		if err := b.Queue.MarkBlobProcessing(ctx, metadata.GetBlobKey()); err != nil {
+			return fmt.Errorf("failed to requeue blob %s: %w", metadata.GetBlobKey().String(), err)
+		}
+		numRequeued++
+	}
+	b.Metrics.IncrementRecoveredBlobs("requeued", numRequeued)
+	b.logger.Info("took over the dispersing blobs of the previous leader", "requeued", numRequeued, "inflightBatches", len(inflightBatches))
+
+	if len(inflightBatches) == 0 {
+		return nil
+	}
+	blockStaleMeasure, err := b.Transactor.GetBlockStaleMeasure(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
+	}
+	go func() {
+		ticker := time.NewTicker(b.PullInterval)
+		defer ticker.Stop()
+		for {
+			remaining := inflightBatches[:0]
+			for _, batch := range inflightBatches {
+				resolved, err := b.resolveInflightBatch(ctx, batch, blockStaleMeasure)
+				if err != nil {
+					b.logger.Warn("failed to resolve an in-flight batch of the previous leader", "batchHeaderHash", hexutil.Encode(batch.BatchHeaderHash[:]), "err", err)
+				}
+				if !resolved {
+					remaining = append(remaining, batch)
+				}
+			}
+			inflightBatches = remaining
+			if len(inflightBatches) == 0 {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// resolveInflightBatch confirms the blobs of an in-flight batch of the previous leader if the batch was confirmed
+// onchain, or requeues them if the batch can't be confirmed anymore. It returns whether the batch is resolved.
+func (b *Batcher) resolveInflightBatch(ctx context.Context, batch *InflightBatch, blockStaleMeasure uint32) (bool, error) {
+	// The current block is read before looking for the confirmation, so that a batch seen as stale can't be
+	// confirmed after it was looked for
+	currentBlock, err := b.Transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return false, err
+	}
+	logs, err := b.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(uint64(batch.ReferenceBlockNumber)),
+		Addresses: []gethcommon.Address{b.ServiceManagerAddr},
+		Topics: [][]gethcommon.Hash{
+			{common.BatchConfirmedEventSigHash},
+			{gethcommon.Hash(batch.BatchHeaderHash)},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(logs) > 0 {
+		if len(batch.Blobs) != len(batch.BlobKeys) {
+			// The batch was recorded without the confirmation info of its blobs, which can't be recovered from the
+			// chain alone, so they're left in the Dispersing status to be reconciled
+			b.logger.Error("in-flight batch of the previous leader was confirmed onchain, its blobs must be reconciled", "batchHeaderHash", hexutil.Encode(batch.BatchHeaderHash[:]), "txHash", logs[0].TxHash.Hex(), "numBlobs", len(batch.BlobKeys))
+			return true, b.StateStore.DeleteInflightBatch(ctx, batch.BatchHeaderHash)
+		}
+		txnReceipt, err := b.ethClient.TransactionReceipt(ctx, logs[0].TxHash)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the receipt of the confirmation transaction %s: %w", logs[0].TxHash.Hex(), err)
+		}
+		if err := b.confirmInflightBatch(ctx, batch, &logs[0], txnReceipt); err != nil {
+			return false, err
+		}
+		return true, b.StateStore.DeleteInflightBatch(ctx, batch.BatchHeaderHash)
+	}
+	if uint64(currentBlock) <= uint64(batch.ReferenceBlockNumber)+uint64(blockStaleMeasure) {
+		return false, nil
+	}
+
+	numRequeued := 0
+	for _, blobKey := range batch.BlobKeys {
+		metadata, err := b.Queue.GetBlobMetadata(ctx, blobKey)
+		if err != nil {
+			return false, err
+		}
+		if metadata.BlobStatus != disperser.Dispersing {
+			continue
+		}
+		if err := b.Queue.MarkBlobProcessing(ctx, blobKey); err != nil {
+			return false, err
+		}
+		numRequeued++
+	}
+	b.logger.Info("requeued the blobs of a stale in-flight batch of the previous leader", "batchHeaderHash", hexutil.Encode(batch.BatchHeaderHash[:]), "requeued", numRequeued)
+	b.Metrics.IncrementRecoveredBlobs("requeued", numRequeued)
+	return true, b.StateStore.DeleteInflightBatch(ctx, batch.BatchHeaderHash)
+}
+
+// processRecoveredReceipt confirms the in-flight batches of the previous leader whose confirmation transaction was
+// recovered by the TxnManager. If the transaction failed, the blobs of its batch are requeued once it's stale.
+func (b *Batcher) processRecoveredReceipt(ctx context.Context, receiptOrErr *ReceiptOrErr) error {
+	if receiptOrErr.Err != nil {
+		return fmt.Errorf("recovered confirmation transaction failed: %w", receiptOrErr.Err)
+	}
+	if b.StateStore == nil {
+		return errors.New("failed to process recovered confirmation transaction: no state store")
+	}
+	inflightBatches, err := b.StateStore.GetInflightBatches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the in-flight batches: %w", err)
+	}
+	for _, log := range receiptOrErr.Receipt.Logs {
+		if len(log.Topics) < 2 || log.Topics[0] != common.BatchConfirmedEventSigHash {
+			continue
+		}
+		for _, batch := range inflightBatches {
+			if gethcommon.Hash(batch.BatchHeaderHash) != log.Topics[1] || len(batch.Blobs) != len(batch.BlobKeys) {
+				continue
+			}
+			if err := b.confirmInflightBatch(ctx, batch, log, receiptOrErr.Receipt); err != nil {
+				return err
+			}
+			if err := b.StateStore.DeleteInflightBatch(ctx, batch.BatchHeaderHash); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// confirmInflightBatch records the confirmation of the blobs of an in-flight batch of the previous leader from the
+// BatchConfirmed log of the batch and the receipt of its transaction. The blobs which aren't in the Dispersing status anymore are skipped, so that it can
+// be retried after a failure.
+func (b *Batcher) confirmInflightBatch(ctx context.Context, batch *InflightBatch, log *types.Log, txnReceipt *types.Receipt) error {
+	batchID, err := parseBatchIDFromLog(log)
+	if err != nil {
+		return err
+	}
+
+	numConfirmed := 0
+	for blobIndex, blobKey := range batch.BlobKeys {
+		metadata, err := b.Queue.GetBlobMetadata(ctx, blobKey)
+		if err != nil {
+			return err
+		}
+		if metadata.BlobStatus != disperser.Dispersing {
+			continue
+		}
+		blob := batch.Blobs[blobIndex]
+		confirmationInfo := &disperser.ConfirmationInfo{
+			BatchHeaderHash:         batch.BatchHeaderHash,
+			BlobIndex:               uint32(blobIndex),
+			SignatoryRecordHash:     batch.SignatoryRecordHash,
+			ReferenceBlockNumber:    uint32(batch.ReferenceBlockNumber),
+			BatchRoot:               batch.BatchRoot,
+			BlobInclusionProof:      blob.InclusionProof,
+			BlobCommitment:          blob.Commitment,
+			BatchID:                 batchID,
+			ConfirmationTxnHash:     log.TxHash,
+			ConfirmationBlockNumber: uint32(log.BlockNumber),
+			Fee:                     []byte{0}, // No fee
+			QuorumResults:           batch.QuorumResults,
+			BlobQuorumInfos:         blob.QuorumInfos,
+			Cost:                    withGasCost(blob.Cost, len(batch.BlobKeys), txnReceipt),
+			AttestationTimeout:      batch.AttestationTimeout,
+		}
+
+		if !blob.Attested {
+			if _, err := b.Queue.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo); err != nil {
+				return err
+			}
+			b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.InsufficientSignatures)
+			continue
+		}
+		confirmedMetadata, err := b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo)
+		if err != nil {
+			return err
+		}
+		b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.Confirmed)
+		b.recordUsage(ctx, metadata, confirmationInfo.Cost)
+		b.notifier.Notify(ctx, confirmedMetadata)
+		numConfirmed++
+	}
+	b.logger.Info("confirmed the blobs of an in-flight batch of the previous leader", "batchHeaderHash", hexutil.Encode(batch.BatchHeaderHash[:]), "txHash", log.TxHash.Hex(), "confirmed", numConfirmed)
+	b.Metrics.IncrementRecoveredBlobs("confirmed", numConfirmed)
+	return nil
+}
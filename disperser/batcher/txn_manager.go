@@ -6,13 +6,16 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
 	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -29,7 +32,10 @@ var (
 // TxnManager receives transactions from the caller, sends them to the chain, and monitors their status.
 // It also handles the case where a transaction is not mined within a certain time. In this case, it will
 // resend the transaction with a higher gas price. It is assumed that all transactions originate from the
-// same account.
+// same account, whose nonces are allocated by the TxnManager. If it is given a TxnStore, the in-flight
+// transactions are persisted so that they keep being monitored and replaced after a restart, and their receipts are
+// sent without metadata. Without a TxnStore, the nonces of the transactions still in-flight at a restart are read
+// again from the pending state of the chain, and their receipts are lost.
 type TxnManager interface {
 	Start(ctx context.Context)
 	ProcessTransaction(ctx context.Context, req *TxnRequest) error
@@ -49,6 +55,9 @@ type TxnRequest struct {
 	Metadata interface{}
 
 	requestedAt time.Time
+	// gasPrice is the price estimated for the first attempt, which is compared with the price paid once the
+	// transaction is mined
+	gasPrice *GasPrice
 	// txAttempts are the transactions that have been attempted to be mined for this request.
 	// If a transaction hasn't been confirmed within the timeout and a replacement transaction is sent,
 	// the original transaction hash will be kept in this slice
@@ -57,7 +66,8 @@ type TxnRequest struct {
 
 // ReceiptOrErr is a wrapper for a transaction receipt or an error.
 // Receipt should be nil if there is an error, and non-nil if there is no error.
-// Metadata is the metadata passed in with the transaction request.
+// Metadata is the metadata passed in with the transaction request. It's nil for the transactions recovered from a
+// previous TxnManager, whose metadata wasn't persisted.
 type ReceiptOrErr struct {
 	Receipt  *types.Receipt
 	Metadata interface{}
@@ -70,6 +80,7 @@ type txnManager struct {
 	ethClient        common.EthClient
 	wallet           walletsdk.Wallet
 	numConfirmations int
+	receiptWaiter    *geth.ReceiptWaiter
 	requestChan      chan *TxnRequest
 	logger           logging.Logger
 
@@ -77,22 +88,43 @@ type txnManager struct {
 	queueSize           int
 	txnBroadcastTimeout time.Duration
 	txnRefreshInterval  time.Duration
+	store               TxnStore
+	gasStrategy         GasStrategy
 	metrics             *TxnManagerMetrics
+
+	// sender is the account of the wallet, which is fetched on the first transaction
+	sender *gethcommon.Address
+	// nextNonce is the nonce following the last one sent. It is 0 until the first transaction is sent, or after a
+	// transaction fails to be sent, in which case the nonce is read again from the pending state of the chain.
+	nextNonce uint64
 }
 
 var _ TxnManager = (*txnManager)(nil)
 
-func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfirmations, queueSize int, txnBroadcastTimeout time.Duration, txnRefreshInterval time.Duration, logger logging.Logger, metrics *TxnManagerMetrics) TxnManager {
+// NewTxnManager returns a TxnManager sending the transactions from the account of the wallet. The store is optional,
+// without it the in-flight transactions aren't persisted. The transactions are priced by the gas strategy, which is
+// the suggested strategy if nil.
+func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfirmations, queueSize int, txnBroadcastTimeout time.Duration, txnRefreshInterval time.Duration, store TxnStore, gasStrategy GasStrategy, logger logging.Logger, metrics *TxnManagerMetrics) TxnManager {
+	logger = logger.With("component", "TxnManager")
+	if gasStrategy == nil {
+		gasStrategy = &SuggestedGasStrategy{ethClient: ethClient}
+	}
 	return &txnManager{
-		ethClient:           ethClient,
-		wallet:              wallet,
-		numConfirmations:    numConfirmations,
+		ethClient:        ethClient,
+		wallet:           wallet,
+		numConfirmations: numConfirmations,
+		receiptWaiter: geth.NewReceiptWaiter(ethClient, geth.ReceiptWaiterConfig{
+			NumConfirmations: numConfirmations,
+			PollInterval:     queryTickerDuration,
+		}, logger),
 		requestChan:         make(chan *TxnRequest, queueSize),
-		logger:              logger.With("component", "TxnManager"),
+		logger:              logger,
 		receiptChan:         make(chan *ReceiptOrErr, queueSize),
 		queueSize:           queueSize,
 		txnBroadcastTimeout: txnBroadcastTimeout,
 		txnRefreshInterval:  txnRefreshInterval,
+		store:               store,
+		gasStrategy:         gasStrategy,
 		metrics:             metrics,
 	}
 }
@@ -110,6 +142,26 @@ func NewTxnRequest(tx *types.Transaction, tag string, value *big.Int, metadata i
 }
 
 func (t *txnManager) Start(ctx context.Context) {
+	// The in-flight transactions are recovered before any nonce is allocated, so that their nonces aren't reused
+	var recovered []*TxnRequest
+	if t.store != nil {
+		recovered = t.recoverInflightTxns(ctx)
+	}
+	// The recovered transactions are monitored alongside the new ones, and their receipts are sent to the caller
+	// without metadata
+	for _, req := range recovered {
+		go func(req *TxnRequest) {
+			receipt, err := t.monitorTransaction(ctx, req)
+			t.untrackTxn(ctx, req)
+			if err != nil {
+				t.logger.Error("recovered transaction failed", "tag", req.Tag, "nonce", req.Tx.Nonce(), "err", err)
+				t.receiptChan <- &ReceiptOrErr{Err: err}
+				return
+			}
+			t.logger.Info("recovered transaction confirmed", "tag", req.Tag, "nonce", req.Tx.Nonce(), "txHash", receipt.TxHash.Hex())
+			t.receiptChan <- &ReceiptOrErr{Receipt: receipt}
+		}(req)
+	}
 	go func() {
 		for {
 			select {
@@ -117,6 +169,7 @@ func (t *txnManager) Start(ctx context.Context) {
 				return
 			case req := <-t.requestChan:
 				receipt, err := t.monitorTransaction(ctx, req)
+				t.untrackTxn(ctx, req)
 				if err != nil {
 					t.receiptChan <- &ReceiptOrErr{
 						Receipt:  nil,
@@ -132,6 +185,7 @@ func (t *txnManager) Start(ctx context.Context) {
 					if receipt.GasUsed > 0 {
 						t.metrics.UpdateGasUsed(receipt.GasUsed)
 					}
+					t.observeGasEstimationError(req, receipt)
 				}
 				t.metrics.ObserveLatency("total", float64(time.Since(req.requestedAt).Milliseconds()))
 			}
@@ -146,19 +200,24 @@ func (t *txnManager) Start(ctx context.Context) {
 func (t *txnManager) ProcessTransaction(ctx context.Context, req *TxnRequest) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
+	nonce, err := t.allocateNonce(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to allocate a nonce: %w", err)
+	}
+	req.Tx = withNonce(req.Tx, nonce)
 	t.logger.Debug("new transaction", "tag", req.Tag, "nonce", req.Tx.Nonce(), "gasFeeCap", req.Tx.GasFeeCap(), "gasTipCap", req.Tx.GasTipCap())
 
 	var txn *types.Transaction
 	var txID walletsdk.TxID
-	var err error
 	retryFromFailure := 0
 	for retryFromFailure < maxSendTransactionRetry {
-		gasTipCap, gasFeeCap, err := t.ethClient.GetLatestGasCaps(ctx)
+		gasPrice, err := t.gasStrategy.GasPrice(ctx, time.Since(req.requestedAt))
 		if err != nil {
-			return fmt.Errorf("failed to get latest gas caps: %w", err)
+			return fmt.Errorf("failed to get gas price: %w", err)
 		}
+		req.gasPrice = gasPrice
 
-		txn, err = t.ethClient.UpdateGas(ctx, req.Tx, req.Value, gasTipCap, gasFeeCap)
+		txn, err = t.ethClient.UpdateGas(ctx, req.Tx, req.Value, gasPrice.GasTipCap, gasPrice.GasFeeCap)
 		if err != nil {
 			return fmt.Errorf("failed to update gas price: %w", err)
 		}
@@ -173,6 +232,9 @@ func (t *txnManager) ProcessTransaction(ctx context.Context, req *TxnRequest) er
 			retryFromFailure++
 			continue
 		} else if err != nil {
+			// The nonce may have been used by another sender, so it's read again from the chain for the next
+			// transaction
+			t.nextNonce = 0
 			return fmt.Errorf("failed to send txn (%s) %s: %w", req.Tag, txn.Hash().Hex(), err)
 		} else {
 			t.logger.Debug("successfully sent txn", "tag", req.Tag, "txID", txID, "txHash", txn.Hash().Hex())
@@ -181,15 +243,18 @@ func (t *txnManager) ProcessTransaction(ctx context.Context, req *TxnRequest) er
 	}
 
 	if txn == nil || txID == "" {
+		t.nextNonce = 0
 		return fmt.Errorf("failed to send txn (%s) %s: %w", req.Tag, req.Tx.Hash().Hex(), err)
 	}
 
+	t.nextNonce = nonce + 1
 	req.Tx = txn
 	req.txAttempts = append(req.txAttempts, &transaction{
 		TxID:        txID,
 		Transaction: txn,
 		requestedAt: time.Now(),
 	})
+	t.trackTxn(ctx, req)
 
 	t.requestChan <- req
 	t.metrics.UpdateTxQueue(len(t.requestChan))
@@ -224,31 +289,17 @@ func (t *txnManager) ensureAnyTransactionBroadcasted(ctx context.Context, txs []
 }
 
 func (t *txnManager) ensureAnyTransactionEvaled(ctx context.Context, txs []*transaction) (*types.Receipt, error) {
-	queryTicker := time.NewTicker(queryTickerDuration)
-	defer queryTicker.Stop()
-	var receipt *types.Receipt
-	var err error
 	// transactions that need to be queried. Some transactions will be removed from this map depending on their status.
 	txnsToQuery := make(map[walletsdk.TxID]*types.Transaction, len(txs))
 	for _, tx := range txs {
 		txnsToQuery[tx.TxID] = tx.Transaction
 	}
 
-	for {
+	return t.receiptWaiter.WaitForReceipt(ctx, func(ctx context.Context) (*types.Receipt, error) {
 		for txID, tx := range txnsToQuery {
-			receipt, err = t.wallet.GetTransactionReceipt(ctx, txID)
+			receipt, err := t.wallet.GetTransactionReceipt(ctx, txID)
 			if err == nil {
-				chainTip, err := t.ethClient.BlockNumber(ctx)
-				if err == nil {
-					if receipt.BlockNumber.Uint64()+uint64(t.numConfirmations) > chainTip {
-						t.logger.Debug("transaction has been mined but don't have enough confirmations at current chain tip", "txnBlockNumber", receipt.BlockNumber.Uint64(), "numConfirmations", t.numConfirmations, "chainTip", chainTip)
-						break
-					} else {
-						return receipt, nil
-					}
-				} else {
-					t.logger.Debug("failed to get chain tip while waiting for transaction to mine", "err", err)
-				}
+				return receipt, nil
 			}
 
 			if errors.Is(err, ethereum.NotFound) || errors.Is(err, walletsdk.ErrReceiptNotYetAvailable) {
@@ -264,16 +315,10 @@ func (t *txnManager) ensureAnyTransactionEvaled(ctx context.Context, txs []*tran
 		}
 
 		if len(txnsToQuery) == 0 {
-			return nil, fmt.Errorf("all transactions failed")
-		}
-
-		// Wait for the next round.
-		select {
-		case <-ctx.Done():
-			return receipt, ctx.Err()
-		case <-queryTicker.C:
+			return nil, fmt.Errorf("all transactions failed: %w", geth.ErrTransactionFailed)
 		}
-	}
+		return nil, ethereum.NotFound
+	})
 }
 
 // monitorTransaction waits until the transaction is confirmed (or failed) and resends it with a higher gas price if it is not mined without a timeout.
@@ -342,7 +387,7 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 				continue
 			}
 			t.logger.Warn("transaction not mined within timeout, resending with higher gas price", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
-			newTx, err := t.speedUpTxn(ctx, req.Tx, req.Tag)
+			newTx, err := t.speedUpTxn(ctx, req.Tx, req.Tag, time.Since(req.requestedAt))
 			if err != nil {
 				t.logger.Error("failed to speed up transaction", "err", err)
 				t.metrics.IncrementTxnCount("failure")
@@ -367,6 +412,7 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 				TxID:        txID,
 				Transaction: newTx,
 			})
+			t.trackTxn(ctx, req)
 			numSpeedUps++
 		} else {
 			t.logger.Error("transaction failed", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "err", err)
@@ -376,16 +422,149 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 	}
 }
 
+// allocateNonce returns the nonce of the next transaction, which follows the last one sent unless the pending state of
+// the chain is ahead, e.g. if another sender used the account
+func (t *txnManager) allocateNonce(ctx context.Context) (uint64, error) {
+	if t.sender == nil {
+		sender, err := t.wallet.SenderAddress(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get the sender address: %w", err)
+		}
+		t.sender = &sender
+	}
+	pendingNonce, err := t.ethClient.PendingNonceAt(ctx, *t.sender)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the pending nonce: %w", err)
+	}
+	if pendingNonce > t.nextNonce {
+		return pendingNonce, nil
+	}
+	return t.nextNonce, nil
+}
+
+// withNonce returns a copy of the transaction with the nonce. It is unsigned, as it is signed again when its gas is
+// updated before being sent.
+func withNonce(tx *types.Transaction, nonce uint64) *types.Transaction {
+	if tx.Nonce() == nonce {
+		return tx
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   tx.ChainId(),
+		Nonce:     nonce,
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	})
+}
+
+// trackTxn records the transaction with its latest attempt once it is sent
+func (t *txnManager) trackTxn(ctx context.Context, req *TxnRequest) {
+	if t.store == nil {
+		return
+	}
+	tx, err := req.Tx.MarshalBinary()
+	if err != nil {
+		t.logger.Error("failed to encode in-flight transaction", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "err", err)
+		return
+	}
+	txIDs := make([]walletsdk.TxID, len(req.txAttempts))
+	for i, attempt := range req.txAttempts {
+		txIDs[i] = attempt.TxID
+	}
+	err = t.store.PutInflightTxn(ctx, &InflightTxn{
+		Nonce: req.Tx.Nonce(),
+		Tag:   req.Tag,
+		Tx:    tx,
+		TxIDs: txIDs,
+	})
+	if err != nil {
+		t.logger.Error("failed to record in-flight transaction", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "err", err)
+	}
+}
+
+// untrackTxn removes the record of the transaction once it is confirmed or failed
+func (t *txnManager) untrackTxn(ctx context.Context, req *TxnRequest) {
+	if t.store == nil {
+		return
+	}
+	if err := t.store.DeleteInflightTxn(ctx, req.Tx.Nonce()); err != nil {
+		t.logger.Error("failed to remove the record of an in-flight transaction", "tag", req.Tag, "nonce", req.Tx.Nonce(), "err", err)
+	}
+}
+
+// recoverInflightTxns loads the in-flight transactions left by a previous TxnManager sending from the same account,
+// ordered by nonce. The transactions whose nonce was already used onchain are removed, and the nonces of the others
+// aren't allocated again.
+func (t *txnManager) recoverInflightTxns(ctx context.Context) []*TxnRequest {
+	txns, err := t.store.GetInflightTxns(ctx)
+	if err != nil {
+		t.logger.Error("failed to get the in-flight transactions", "err", err)
+		return nil
+	}
+	if len(txns) == 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sender, err := t.wallet.SenderAddress(ctx)
+	if err != nil {
+		t.logger.Error("failed to get the sender address", "err", err)
+		return nil
+	}
+	t.sender = &sender
+	minedNonce, err := t.ethClient.NonceAt(ctx, sender, nil)
+	if err != nil {
+		t.logger.Error("failed to get the nonce of the latest block", "err", err)
+		return nil
+	}
+
+	sort.Slice(txns, func(i, j int) bool { return txns[i].Nonce < txns[j].Nonce })
+	reqs := make([]*TxnRequest, 0, len(txns))
+	for _, txn := range txns {
+		if txn.Nonce < minedNonce {
+			t.logger.Info("in-flight transaction was mined before the restart", "tag", txn.Tag, "nonce", txn.Nonce)
+			if err := t.store.DeleteInflightTxn(ctx, txn.Nonce); err != nil {
+				t.logger.Error("failed to remove the record of an in-flight transaction", "tag", txn.Tag, "nonce", txn.Nonce, "err", err)
+			}
+			continue
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(txn.Tx); err != nil {
+			t.logger.Error("failed to decode in-flight transaction", "tag", txn.Tag, "nonce", txn.Nonce, "err", err)
+			continue
+		}
+		req := NewTxnRequest(tx, txn.Tag, tx.Value(), nil)
+		for _, txID := range txn.TxIDs {
+			req.txAttempts = append(req.txAttempts, &transaction{
+				TxID:        txID,
+				Transaction: tx,
+				requestedAt: req.requestedAt,
+			})
+		}
+		if txn.Nonce >= t.nextNonce {
+			t.nextNonce = txn.Nonce + 1
+		}
+		reqs = append(reqs, req)
+	}
+	t.logger.Info("recovered in-flight transactions", "numRecovered", len(reqs), "minedNonce", minedNonce)
+	return reqs
+}
+
 // speedUpTxn increases the gas price of the existing transaction by specified percentage.
-// It makes sure the new gas price is not lower than the current gas price.
-func (t *txnManager) speedUpTxn(ctx context.Context, tx *types.Transaction, tag string) (*types.Transaction, error) {
+// It makes sure the new gas price is not lower than the gas price of the strategy for a transaction of this age.
+func (t *txnManager) speedUpTxn(ctx context.Context, tx *types.Transaction, tag string, age time.Duration) (*types.Transaction, error) {
 	prevGasTipCap := tx.GasTipCap()
 	prevGasFeeCap := tx.GasFeeCap()
 	// get the gas tip cap and gas fee cap based on current network condition
-	currentGasTipCap, currentGasFeeCap, err := t.ethClient.GetLatestGasCaps(ctx)
+	gasPrice, err := t.gasStrategy.GasPrice(ctx, age)
 	if err != nil {
 		return nil, err
 	}
+	currentGasTipCap, currentGasFeeCap := gasPrice.GasTipCap, gasPrice.GasFeeCap
 	increasedGasTipCap := increaseGasPrice(prevGasTipCap)
 	increasedGasFeeCap := increaseGasPrice(prevGasFeeCap)
 	// make sure increased gas prices are not lower than current gas prices
@@ -405,6 +584,22 @@ func (t *txnManager) speedUpTxn(ctx context.Context, tx *types.Transaction, tag
 	return t.ethClient.UpdateGas(ctx, tx, tx.Value(), newGasTipCap, newGasFeeCap)
 }
 
+// observeGasEstimationError records how far the gas price paid by a mined transaction is from the price the gas
+// strategy expected for its first attempt, relative to the expected price. It's positive when the transaction paid
+// more, e.g. because it was replaced, and isn't recorded if the strategy doesn't know the base fee.
+func (t *txnManager) observeGasEstimationError(req *TxnRequest, receipt *types.Receipt) {
+	if req.gasPrice == nil || receipt.EffectiveGasPrice == nil {
+		return
+	}
+	expected := req.gasPrice.Expected()
+	if expected == nil || expected.Sign() == 0 {
+		return
+	}
+	diff := new(big.Int).Sub(receipt.EffectiveGasPrice, expected)
+	relativeError, _ := new(big.Rat).SetFrac(diff, expected).Float64()
+	t.metrics.ObserveGasEstimationError(t.gasStrategy.Name(), relativeError)
+}
+
 // increaseGasPrice increases the gas price by specified percentage.
 // i.e. gasPrice + ((gasPrice * gasPricePercentageMultiplier + 99) / 100)
 func increaseGasPrice(gasPrice *big.Int) *big.Int {
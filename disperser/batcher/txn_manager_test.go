@@ -3,7 +3,9 @@ package batcher_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,13 +26,15 @@ func TestProcessTransaction(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, nil, nil, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txID := "1234"
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	gomock.InOrder(
@@ -74,12 +78,14 @@ func TestReplaceGasFee(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, nil, nil, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 
@@ -110,12 +116,14 @@ func TestTransactionReplacementFailure(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, nil, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
 	ethClient.On("UpdateGas").Return(txn, nil).Once()
 	// now assume that the transaction fails on retry
 	speedUpFailure := errors.New("speed up failure")
@@ -143,12 +151,14 @@ func TestSendTransactionReceiptRetry(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, nil, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -181,12 +191,14 @@ func TestSendTransactionRetrySuccess(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, nil, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -223,12 +235,14 @@ func TestSendTransactionRetryFailure(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, nil, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -261,12 +275,14 @@ func TestTransactionNotBroadcasted(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, nil, nil, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
 	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
 	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(0), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
 	ethClient.On("UpdateGas").Return(txn, nil)
 	ethClient.On("BlockNumber").Return(uint64(123), nil)
 	txID := "1234"
@@ -286,3 +302,117 @@ func TestTransactionNotBroadcasted(t *testing.T) {
 	assert.ErrorAs(t, res.Err, &batcher.ErrTransactionNotBroadcasted)
 	assert.Nil(t, res.Receipt)
 }
+
+// nonceRecordingEthClient updates the gas of a transaction without changing it, recording its nonce
+type nonceRecordingEthClient struct {
+	*mock.MockEthClient
+
+	mu     sync.Mutex
+	nonces []uint64
+}
+
+func (c *nonceRecordingEthClient) UpdateGas(ctx context.Context, tx *types.Transaction, value, gasTipCap, gasFeeCap *big.Int) (*types.Transaction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nonces = append(c.nonces, tx.Nonce())
+	return tx, nil
+}
+
+func TestNonceAllocation(t *testing.T) {
+	ethClient := &nonceRecordingEthClient{MockEthClient: &mock.MockEthClient{}}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	store := batcher.NewLocalStateStore()
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, store, nil, logger, metrics.TxnManagerMetrics)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	txnManager.Start(ctx)
+	txn := types.NewTransaction(0, common.HexToAddress("0x1"), big.NewInt(0), 100000, big.NewInt(1e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	// the pending nonce doesn't account for the transactions which weren't broadcasted yet
+	ethClient.On("PendingNonceAt").Return(uint64(5), nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil)
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return("1234", nil)
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return("4321", nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), gomock.Any()).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil).AnyTimes()
+
+	err := txnManager.ProcessTransaction(ctx, batcher.NewTxnRequest(txn, "first", big.NewInt(0), nil))
+	assert.NoError(t, err)
+	err = txnManager.ProcessTransaction(ctx, batcher.NewTxnRequest(txn, "second", big.NewInt(0), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{5, 6}, ethClient.nonces)
+
+	for i := 0; i < 2; i++ {
+		res := <-txnManager.ReceiptChan()
+		assert.NoError(t, res.Err)
+	}
+	// the transactions aren't in-flight anymore once their receipts are received
+	txns, err := store.GetInflightTxns(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, txns)
+}
+
+func TestRecoverInflightTxns(t *testing.T) {
+	ethClient := &nonceRecordingEthClient{MockEthClient: &mock.MockEthClient{}}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	// the transactions left in-flight by the previous TxnManager, the first of which was mined before the restart
+	store := batcher.NewLocalStateStore()
+	to := common.HexToAddress("0x1")
+	for _, nonce := range []uint64{3, 7} {
+		tx, err := types.NewTx(&types.DynamicFeeTx{Nonce: nonce, To: &to, Gas: 100000}).MarshalBinary()
+		assert.NoError(t, err)
+		err = store.PutInflightTxn(ctx, &batcher.InflightTxn{
+			Nonce: nonce,
+			Tag:   "confirmBatch",
+			Tx:    tx,
+			TxIDs: []walletsdk.TxID{walletsdk.TxID(fmt.Sprintf("tx-%d", nonce))},
+		})
+		assert.NoError(t, err)
+	}
+
+	ethClient.On("NonceAt").Return(uint64(5), nil)
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("PendingNonceAt").Return(uint64(5), nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil)
+	receipt := &types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), walletsdk.TxID("tx-7")).Return(receipt, nil).AnyTimes()
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return("1234", nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), walletsdk.TxID("1234")).Return(receipt, nil).AnyTimes()
+
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, store, nil, logger, metrics.TxnManagerMetrics)
+	txnManager.Start(ctx)
+
+	// the nonce of the recovered transaction isn't allocated again
+	txn := types.NewTransaction(0, to, big.NewInt(0), 100000, big.NewInt(1e9), []byte{})
+	err := txnManager.ProcessTransaction(ctx, batcher.NewTxnRequest(txn, "confirmBatch", big.NewInt(0), "new"))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{8}, ethClient.nonces)
+
+	// the receipt of the recovered transaction is sent without metadata along with the one of the new transaction
+	numRecovered := 0
+	for i := 0; i < 2; i++ {
+		res := <-txnManager.ReceiptChan()
+		assert.NoError(t, res.Err)
+		if res.Metadata == nil {
+			numRecovered++
+		}
+	}
+	assert.Equal(t, 1, numRecovered)
+	txns, err := store.GetInflightTxns(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, txns)
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
@@ -23,7 +26,22 @@ type Config struct {
 	EnableRatelimiter bool
 	BucketTableName   string
 	BucketStoreSize   int
-	EthClientConfig   geth.EthClientConfig
+	// BucketRedisAddress is the address of the redis server used to share rate limiter buckets, and the nonces of the
+	// signed requests, between dispersers.
+	BucketRedisAddress   string
+	BucketRedisKeyPrefix string
+	ReplayRedisKeyPrefix string
+	EthClientConfig      geth.EthClientConfig
+	// LimitsFile is the path of the JSON file of the limits of the quorums, which are only set with the admin
+	// endpoints if empty.
+	LimitsFile            string
+	LimitsRefreshInterval time.Duration
+	// TenantsFile is the path of the JSON file of the tenants, which are only set with the admin endpoints if empty
+	TenantsFile string
+	// CommitmentG1Path is the path of the G1 SRS the commitments supplied with the blobs are cross-checked with. The
+	// requests carrying a commitment are rejected, and the blobs can't be retrieved with proofs, if empty.
+	CommitmentG1Path   string
+	CommitmentSRSOrder uint64
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -46,12 +64,43 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, err
 	}
 
+	tlsConfig, err := grpctls.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
+
 	config := Config{
 		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		ServerConfig: disperser.ServerConfig{
-			GrpcPort:          ctx.GlobalString(flags.GrpcPortFlag.Name),
-			GrpcTimeout:       ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
-			EnableDualQuorums: ctx.GlobalBool(flags.EnableDualQuorums.Name),
+			GrpcPort:                ctx.GlobalString(flags.GrpcPortFlag.Name),
+			GrpcTimeout:             ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
+			TLS:                     tlsConfig,
+			HTTPPort:                ctx.GlobalString(flags.HTTPPortFlag.Name),
+			StatusPollInterval:      ctx.GlobalDuration(flags.StatusPollIntervalFlag.Name),
+			StatusKeepaliveInterval: ctx.GlobalDuration(flags.StatusKeepaliveIntervalFlag.Name),
+			AdminPort:               ctx.GlobalString(flags.AdminPortFlag.Name),
+			DrainTimeout:            ctx.GlobalDuration(flags.DrainTimeoutFlag.Name),
+			IdempotencyKeyTTL:       ctx.GlobalDuration(flags.IdempotencyKeyTTLFlag.Name),
+			ReplayWindow:            ctx.GlobalDuration(flags.ReplayWindowFlag.Name),
+			BlobStatusCacheSize:     ctx.GlobalInt(flags.BlobStatusCacheSizeFlag.Name),
+			BlobStatusCacheTTL:      ctx.GlobalDuration(flags.BlobStatusCacheTTLFlag.Name),
+			AuthChallengeTimeout:    ctx.GlobalDuration(flags.AuthChallengeTimeoutFlag.Name),
+			MaxBlobPriority:         uint32(ctx.GlobalUint(flags.MaxBlobPriorityFlag.Name)),
+			MinAttestationTimeout:   ctx.GlobalDuration(flags.MinAttestationTimeoutFlag.Name),
+			MaxDecompressionRatio:   ctx.GlobalInt(flags.MaxDecompressionRatioFlag.Name),
+			SRSOrder:                ctx.GlobalUint64(flags.CommitmentSRSOrderFlag.Name),
+			Admission: disperser.AdmissionConfig{
+				EncoderMetricsURL:    ctx.GlobalString(flags.AdmissionEncoderMetricsURLFlag.Name),
+				MaxEncoderQueueDepth: ctx.GlobalInt(flags.AdmissionMaxEncoderQueueDepthFlag.Name),
+				MaxQueuedBlobs:       ctx.GlobalInt(flags.AdmissionMaxQueuedBlobsFlag.Name),
+				MaxStoreLatency:      ctx.GlobalDuration(flags.AdmissionMaxStoreLatencyFlag.Name),
+				PollInterval:         ctx.GlobalDuration(flags.AdmissionPollIntervalFlag.Name),
+				MaxDelay:             ctx.GlobalDuration(flags.AdmissionMaxDelayFlag.Name),
+				RetryAfter:           ctx.GlobalDuration(flags.AdmissionRetryAfterFlag.Name),
+			},
+			EnableDualQuorums:     ctx.GlobalBool(flags.EnableDualQuorums.Name),
+			RequireAuthentication: ctx.GlobalBool(flags.RequireAuthenticationFlag.Name),
+			DisableStreaming:      ctx.GlobalBool(flags.DisableStreamingFlag.Name),
 		},
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
@@ -62,12 +111,20 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
-		RatelimiterConfig: ratelimiterConfig,
-		RateConfig:        rateConfig,
-		EnableRatelimiter: ctx.GlobalBool(flags.EnableRatelimiter.Name),
-		BucketTableName:   ctx.GlobalString(flags.BucketTableName.Name),
-		BucketStoreSize:   ctx.GlobalInt(flags.BucketStoreSize.Name),
-		EthClientConfig:   geth.ReadEthClientConfigRPCOnly(ctx),
+		RatelimiterConfig:     ratelimiterConfig,
+		RateConfig:            rateConfig,
+		EnableRatelimiter:     ctx.GlobalBool(flags.EnableRatelimiter.Name),
+		BucketTableName:       ctx.GlobalString(flags.BucketTableName.Name),
+		BucketStoreSize:       ctx.GlobalInt(flags.BucketStoreSize.Name),
+		BucketRedisAddress:    ctx.GlobalString(flags.BucketRedisAddress.Name),
+		BucketRedisKeyPrefix:  ctx.GlobalString(flags.BucketRedisKeyPrefix.Name),
+		ReplayRedisKeyPrefix:  ctx.GlobalString(flags.ReplayRedisKeyPrefix.Name),
+		EthClientConfig:       geth.ReadEthClientConfigRPCOnly(ctx),
+		LimitsFile:            ctx.GlobalString(flags.LimitsFileFlag.Name),
+		LimitsRefreshInterval: ctx.GlobalDuration(flags.LimitsRefreshIntervalFlag.Name),
+		TenantsFile:           ctx.GlobalString(flags.TenantsFileFlag.Name),
+		CommitmentG1Path:      ctx.GlobalString(flags.CommitmentG1PathFlag.Name),
+		CommitmentSRSOrder:    ctx.GlobalUint64(flags.CommitmentSRSOrderFlag.Name),
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
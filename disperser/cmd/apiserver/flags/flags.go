@@ -6,6 +6,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/urfave/cli"
@@ -80,6 +81,27 @@ var (
 		Value:  "",
 		EnvVar: common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_TABLE_NAME"),
 	}
+	BucketRedisAddress = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "rate-bucket-redis-address"),
+		Usage:    "address (host:port) of the redis server shared by all dispersers to store rate limiter buckets, and the nonces of the signed requests and the answered challenges. Takes precedence over the dynamodb table",
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_REDIS_ADDRESS"),
+		Required: false,
+	}
+	BucketRedisKeyPrefix = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "rate-bucket-redis-key-prefix"),
+		Usage:    "prefix of the redis keys holding the rate limiter buckets",
+		Value:    "{ratelimit}:",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_REDIS_KEY_PREFIX"),
+		Required: false,
+	}
+	ReplayRedisKeyPrefix = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "replay-redis-key-prefix"),
+		Usage:    "prefix of the redis keys holding the nonces of the signed requests and the answered challenges",
+		Value:    "{replay}:",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REPLAY_REDIS_KEY_PREFIX"),
+		Required: false,
+	}
 	BucketStoreSize = cli.UintFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "rate-bucket-store-size"),
 		Usage:    "size (max number of entries) of the local store to use for rate limiting buckets",
@@ -87,12 +109,189 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_STORE_SIZE"),
 		Required: false,
 	}
+	HTTPPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "http-port"),
+		Usage:    "Port at which the HTTP/JSON gateway to the API listens. The gateway is disabled if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "HTTP_PORT"),
+	}
+	StatusPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-poll-interval"),
+		Usage:    "How often the blob status streams poll the status of their blob",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_POLL_INTERVAL"),
+		Value:    time.Second,
+	}
+	StatusKeepaliveIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-keepalive-interval"),
+		Usage:    "How long the blob status streams stay idle before sending a keepalive message",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_KEEPALIVE_INTERVAL"),
+		Value:    time.Second * 30,
+	}
+	AdminPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-port"),
+		Usage:    "Port at which the admin endpoints listen on the loopback interface. The admin endpoints are disabled if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_PORT"),
+	}
+	DrainTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "drain-timeout"),
+		Usage:    "How long a drain waits for the queued blobs to be confirmed before the server exits anyway",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DRAIN_TIMEOUT"),
+		Value:    time.Minute * 10,
+	}
+	IdempotencyKeyTTLFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "idempotency-key-ttl"),
+		Usage:    "How long the idempotency key of a dispersal request identifies it, during which requests with the same key replay its reply",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "IDEMPOTENCY_KEY_TTL"),
+		Value:    time.Hour * 24,
+	}
+	BlobStatusCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-cache-size"),
+		Usage:    "Number of status replies of finalized blobs kept in memory to serve their polls without reading the blob store. Disabled if negative",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_CACHE_SIZE"),
+		Value:    10000,
+	}
+	BlobStatusCacheTTLFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-cache-ttl"),
+		Usage:    "How long the status reply of a finalized blob is served from memory before the blob store is read again",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_CACHE_TTL"),
+		Value:    10 * time.Minute,
+	}
+	ReplayWindowFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "replay-window"),
+		Usage:    "How far ahead the expiry of a signed dispersal request can be, and how long the challenges answered by the authenticated dispersals are remembered to reject their replays",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REPLAY_WINDOW"),
+		Value:    10 * time.Minute,
+	}
+	AuthChallengeTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "auth-challenge-timeout"),
+		Usage:    "How long the client of an authenticated dispersal has to answer its challenge. Only bounded by the gRPC timeout if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUTH_CHALLENGE_TIMEOUT"),
+		Value:    30 * time.Second,
+	}
+	MaxBlobPriorityFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-priority"),
+		Usage:    "The highest priority lane of the blobs, which must match the priority lanes of the batcher. Requests with a higher priority are rejected",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_PRIORITY"),
+		Value:    1,
+	}
+	MinAttestationTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-attestation-timeout"),
+		Usage:    "The shortest attestation window the clients can request for their blobs. Requests with a shorter window are rejected",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MIN_ATTESTATION_TIMEOUT"),
+		Value:    5 * time.Second,
+	}
+	MaxDecompressionRatioFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-decompression-ratio"),
+		Usage:    "The largest compression ratio of the compressed payloads of the dispersals, which bounds the work spent on decompression bombs. Not checked if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_DECOMPRESSION_RATIO"),
+		Value:    100,
+	}
+	LimitsFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "limits-file"),
+		Usage:    "Path of a JSON file mapping the quorum IDs to their max blob size and throughput limits, which is re-read while the server runs. The limits can also be set with the admin endpoints",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LIMITS_FILE"),
+	}
+	LimitsRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "limits-refresh-interval"),
+		Usage:    "How often the limits of the quorums are re-read from the limits file",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LIMITS_REFRESH_INTERVAL"),
+		Value:    time.Minute,
+	}
+	TenantsFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tenants-file"),
+		Usage:    "Path of a JSON file listing the tenants of the disperser with their API keys and quotas. The tenants can also be managed with the admin endpoints",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TENANTS_FILE"),
+	}
+	CommitmentG1PathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "commitment-g1-path"),
+		Usage:    "Path of the G1 SRS the commitments supplied with the blobs are cross-checked with. Requests carrying a commitment are rejected, and the blobs can't be retrieved with proofs, if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "COMMITMENT_G1_PATH"),
+	}
+	CommitmentSRSOrderFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "commitment-srs-order"),
+		Usage:    "Order of the SRS of the encoders, which the G1 points of the commitment cross-check are read from and which bounds the encoded length of the blobs with custom security params",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "COMMITMENT_SRS_ORDER"),
+		Value:    268435456,
+	}
+	AdmissionEncoderMetricsURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admission-encoder-metrics-url"),
+		Usage:    "URL of the Prometheus metrics of the encoder, from which its queue depth is read",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMISSION_ENCODER_METRICS_URL"),
+	}
+	AdmissionMaxEncoderQueueDepthFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admission-max-encoder-queue-depth"),
+		Usage:    "Largest number of encode requests queued at the encoder, above which new blobs are rejected. Disabled if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMISSION_MAX_ENCODER_QUEUE_DEPTH"),
+	}
+	AdmissionMaxQueuedBlobsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admission-max-queued-blobs"),
+		Usage:    "Largest number of blobs waiting to be confirmed in a batch, above which new blobs are rejected. Disabled if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMISSION_MAX_QUEUED_BLOBS"),
+	}
+	AdmissionMaxStoreLatencyFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admission-max-store-latency"),
+		Usage:    "Largest average latency of the blob store writes, above which new blobs are rejected. Disabled if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMISSION_MAX_STORE_LATENCY"),
+	}
+	AdmissionPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admission-poll-interval"),
+		Usage:    "How often the load of the dispersal pipeline is measured",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMISSION_POLL_INTERVAL"),
+		Value:    5 * time.Second,
+	}
+	AdmissionMaxDelayFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admission-max-delay"),
+		Usage:    "How long a request waits for the load to drop while the dispersal pipeline is saturated before it's rejected",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMISSION_MAX_DELAY"),
+	}
+	AdmissionRetryAfterFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admission-retry-after"),
+		Usage:    "The delay after which the clients of the requests rejected while the dispersal pipeline is saturated are told to retry",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMISSION_RETRY_AFTER"),
+		Value:    10 * time.Second,
+	}
 	EnableDualQuorums = cli.BoolTFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "enable-dual-quorums"),
 		Usage:    "Whether to enable dual quorum staking. If false, only quorum 0 is used as required quorum",
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_DUAL_QUORUMS"),
 	}
+	RequireAuthenticationFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "require-authentication"),
+		Usage:    "Whether the dispersals must be authenticated, with a signed request or DisperseBlobAuthenticated",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REQUIRE_AUTHENTICATION"),
+	}
+	DisableStreamingFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disable-streaming"),
+		Usage:    "Whether to disable the streaming RPCs, DisperseBlobStream and SubscribeBlobStatus",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISABLE_STREAMING"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -109,8 +308,38 @@ var optionalFlags = []cli.Flag{
 	EnableMetrics,
 	EnableRatelimiter,
 	BucketStoreSize,
+	BucketRedisAddress,
+	BucketRedisKeyPrefix,
+	ReplayRedisKeyPrefix,
 	GrpcTimeoutFlag,
+	HTTPPortFlag,
+	StatusPollIntervalFlag,
+	StatusKeepaliveIntervalFlag,
+	AdminPortFlag,
+	DrainTimeoutFlag,
+	IdempotencyKeyTTLFlag,
+	BlobStatusCacheSizeFlag,
+	BlobStatusCacheTTLFlag,
+	ReplayWindowFlag,
+	AuthChallengeTimeoutFlag,
+	MaxBlobPriorityFlag,
+	MinAttestationTimeoutFlag,
+	MaxDecompressionRatioFlag,
+	LimitsFileFlag,
+	LimitsRefreshIntervalFlag,
+	TenantsFileFlag,
+	CommitmentG1PathFlag,
+	CommitmentSRSOrderFlag,
+	AdmissionEncoderMetricsURLFlag,
+	AdmissionMaxEncoderQueueDepthFlag,
+	AdmissionMaxQueuedBlobsFlag,
+	AdmissionMaxStoreLatencyFlag,
+	AdmissionPollIntervalFlag,
+	AdmissionMaxDelayFlag,
+	AdmissionRetryAfterFlag,
 	EnableDualQuorums,
+	RequireAuthenticationFlag,
+	DisableStreamingFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -122,5 +351,6 @@ func init() {
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpctls.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, apiserver.CLIFlags(envVarPrefix)...)
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 
@@ -19,7 +20,9 @@ import (
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/committer"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
 	"github.com/urfave/cli"
 )
 
@@ -91,29 +94,75 @@ func RunDisperserServer(ctx *cli.Context) error {
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
 	blobStore := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
 
+	var redisClient *redis.Client
+	var nonces apiserver.NonceStore
+	if config.BucketRedisAddress != "" {
+		logger.Info("Using redis to share the nonces of the signed requests", "address", config.BucketRedisAddress)
+		redisClient = redis.NewClient(&redis.Options{Addr: config.BucketRedisAddress})
+		nonces = apiserver.NewRedisNonceStore(redisClient, config.ReplayRedisKeyPrefix)
+	}
+
 	var ratelimiter common.RateLimiter
 	if config.EnableRatelimiter {
 		globalParams := config.RatelimiterConfig.GlobalRateParams
 
-		var bucketStore common.KVStore[common.RateBucketParams]
-		if config.BucketTableName != "" {
-			dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
-			if err != nil {
-				return err
-			}
-			bucketStore = store.NewDynamoParamStore[common.RateBucketParams](dynamoClient, config.BucketTableName)
+		if redisClient != nil {
+			logger.Info("Using redis to store rate limiter buckets", "address", config.BucketRedisAddress)
+			ratelimiter = ratelimit.NewRedisRateLimiter(globalParams, redisClient, config.BucketRedisKeyPrefix, logger)
 		} else {
-			bucketStore, err = store.NewLocalParamStore[common.RateBucketParams](config.BucketStoreSize)
-			if err != nil {
-				return err
+			var bucketStore common.KVStore[common.RateBucketParams]
+			if config.BucketTableName != "" {
+				dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
+				if err != nil {
+					return err
+				}
+				bucketStore = store.NewDynamoParamStore[common.RateBucketParams](dynamoClient, config.BucketTableName)
+			} else {
+				bucketStore, err = store.NewLocalParamStore[common.RateBucketParams](config.BucketStoreSize)
+				if err != nil {
+					return err
+				}
 			}
+			ratelimiter = ratelimit.NewRateLimiter(globalParams, bucketStore, logger)
 		}
-		ratelimiter = ratelimit.NewRateLimiter(globalParams, bucketStore, logger)
 	}
 
 	// TODO: create a separate metrics for batcher
 	metrics := disperser.NewMetrics(config.MetricsConfig.HTTPPort, logger)
-	server := apiserver.NewDispersalServer(config.ServerConfig, blobStore, transactor, logger, metrics, ratelimiter, config.RateConfig)
+	config.ServerConfig.BuildInfo = newBuildInfo(config)
+	if err := metrics.RegisterBuildInfo(config.ServerConfig.BuildInfo); err != nil {
+		return fmt.Errorf("failed to register build info: %w", err)
+	}
+	var limitsSource apiserver.LimitsSource
+	if config.LimitsFile != "" {
+		logger.Info("Reading the quorum limits from file", "path", config.LimitsFile)
+		limitsSource = &apiserver.FileLimitsSource{Path: config.LimitsFile}
+	}
+	limits := apiserver.NewDynamicLimits(limitsSource, config.LimitsRefreshInterval, logger)
+
+	var tenantList []*apiserver.Tenant
+	if config.TenantsFile != "" {
+		logger.Info("Reading the tenants from file", "path", config.TenantsFile)
+		tenantList, err = apiserver.LoadTenantsFile(config.TenantsFile)
+		if err != nil {
+			return err
+		}
+	}
+	tenants, err := apiserver.NewTenants(tenantList, logger)
+	if err != nil {
+		return fmt.Errorf("invalid tenants: %w", err)
+	}
+
+	var blobCommitter *committer.Committer
+	if config.CommitmentG1Path != "" {
+		logger.Info("Cross-checking the commitments supplied with the blobs", "g1Path", config.CommitmentG1Path)
+		blobCommitter, err = apiserver.NewBlobCommitter(config.CommitmentG1Path, config.CommitmentSRSOrder)
+		if err != nil {
+			return err
+		}
+	}
+
+	server := apiserver.NewDispersalServer(config.ServerConfig, blobStore, transactor, logger, metrics, ratelimiter, config.RateConfig, limits, tenants, blobCommitter, nonces)
 
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
@@ -124,3 +173,30 @@ func RunDisperserServer(ctx *cli.Context) error {
 
 	return server.Start(context.Background())
 }
+
+// newBuildInfo returns the build of the disperser and the optional features enabled in the config
+func newBuildInfo(config Config) buildinfo.Info {
+	var features []string
+	if config.ServerConfig.EnableDualQuorums {
+		features = append(features, "dual_quorums")
+	}
+	if config.ServerConfig.TLS.Enabled() {
+		features = append(features, "tls")
+	}
+	if config.ServerConfig.HTTPPort != "" {
+		features = append(features, "http_gateway")
+	}
+	if config.EnableRatelimiter {
+		features = append(features, "ratelimiter")
+	}
+	if config.CommitmentG1Path != "" {
+		features = append(features, "commitment_check")
+	}
+	return buildinfo.Info{
+		Service:  "disperser",
+		Version:  version,
+		Commit:   gitCommit,
+		SRSOrder: config.ServerConfig.SRSOrder,
+		Features: features,
+	}
+}
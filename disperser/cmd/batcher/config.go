@@ -1,15 +1,26 @@
 package main
 
 import (
+	"fmt"
+	"math/big"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/indexer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli"
 )
 
@@ -27,8 +38,37 @@ type Config struct {
 	ChainStateConfig thegraph.Config
 	UseGraph         bool
 
+	// EncoderHedgingConfig configures the hedging of the encoding requests across the encoder replicas
+	EncoderHedgingConfig encoder.HedgingConfig
+	// DispersalRetryConfig configures the retries of the dispersals to the operators and their blacklisting
+	DispersalRetryConfig dispatcher.RetryConfig
+	// DispersalMinibatchSize is the size in bytes of the minibatches of chunks streamed to the operators, which are
+	// sent their chunks in a single request if 0
+	DispersalMinibatchSize uint64
+
+	// ChunkEncodingFormat is the format in which chunks are sent to the operators
+	ChunkEncodingFormat encoding.ChunkEncodingFormat
+
+	// FeatureGatesFile is the path of the feature gates file, reloaded every FeatureGatesReloadInterval
+	FeatureGatesFile           string
+	FeatureGatesReloadInterval time.Duration
+
 	IndexerDataDir string
 
+	// GasStrategyConfig configures the pricing of the confirmation transactions
+	GasStrategyConfig batcher.GasStrategyConfig
+
+	// StateTableName is the DynamoDB table of the state shared by the batchers, which elect a leader if it's set.
+	// The in-flight batches and confirmation transactions are only persisted in it, so they aren't recovered after a
+	// restart without it.
+	StateTableName       string
+	LeaderElectionConfig batcher.LeaderElectionConfig
+
+	// EncoderTLSConfig and NodeTLSConfig are the transport security of the connections to the encoders and to the
+	// dispersal servers of the operators
+	EncoderTLSConfig grpctls.ClientConfig
+	NodeTLSConfig    grpctls.ClientConfig
+
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 }
@@ -39,6 +79,44 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, err
 	}
 	ethClientConfig := geth.ReadEthClientConfig(ctx)
+	chunkEncodingFormat, err := encoding.ParseChunkEncodingFormat(ctx.GlobalString(flags.ChunkEncodingFormatFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	var accountTiers batcher.AccountTiers
+	if path := ctx.GlobalString(flags.AccountTiersFileFlag.Name); path != "" {
+		accountTiers, err = batcher.ReadAccountTiersFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+	var operatorWebhooks map[core.OperatorID]batcher.OperatorWebhook
+	if path := ctx.GlobalString(flags.OperatorWebhooksFileFlag.Name); path != "" {
+		operatorWebhooks, err = batcher.ReadOperatorWebhooksFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+	priorityLanes, err := batcher.ParsePriorityLanes(ctx.GlobalString(flags.PriorityLaneWeightsFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	quorumAttestation, err := batcher.ParseQuorumAttestationConfigs(ctx.GlobalString(flags.QuorumAttestationFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	batchPolicy, err := readBatchPolicy(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+	encoderTLSConfig, err := grpctls.ReadClientCLIConfig(ctx, flags.EncoderTLSFlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
+	nodeTLSConfig, err := grpctls.ReadClientCLIConfig(ctx, flags.NodeTLSFlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
 	fireblocksConfig := common.ReadFireblocksCLIConfig(ctx, flags.FlagPrefix)
 	if !fireblocksConfig.Disable {
 		ethClientConfig = geth.ReadEthClientConfigRPCOnly(ctx)
@@ -65,6 +143,13 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			TargetNumChunks:          ctx.GlobalUint(flags.TargetNumChunksFlag.Name),
 			MaxBlobsToFetchFromStore: ctx.GlobalInt(flags.MaxBlobsToFetchFromStoreFlag.Name),
 			FinalizationBlockDelay:   ctx.GlobalUint(flags.FinalizationBlockDelayFlag.Name),
+			LatencySensitiveBlobSize: ctx.GlobalUint(flags.LatencySensitiveBlobSizeFlag.Name),
+			AccountTiers:             accountTiers,
+			PriorityLanes:            priorityLanes,
+			AnnouncementThreshold:    uint64(ctx.GlobalUint(flags.AnnouncementThresholdFlag.Name)) * 1024 * 1024,
+			OperatorWebhooks:         operatorWebhooks,
+			BatchPolicy:              batchPolicy,
+			BatchPolicyInterval:      ctx.GlobalDuration(flags.BatchPolicyIntervalFlag.Name),
 		},
 		TimeoutConfig: batcher.TimeoutConfig{
 			EncodingTimeout:     ctx.GlobalDuration(flags.EncodingTimeoutFlag.Name),
@@ -73,6 +158,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			ChainWriteTimeout:   ctx.GlobalDuration(flags.ChainWriteTimeoutFlag.Name),
 			ChainStateTimeout:   ctx.GlobalDuration(flags.ChainStateTimeoutFlag.Name),
 			TxnBroadcastTimeout: ctx.GlobalDuration(flags.TransactionBroadcastTimeoutFlag.Name),
+			QuorumAttestation:   quorumAttestation,
 		},
 		MetricsConfig: batcher.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
@@ -80,11 +166,80 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		},
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
 		UseGraph:                      ctx.Bool(flags.UseGraphFlag.Name),
+		ChunkEncodingFormat:           chunkEncodingFormat,
+		FeatureGatesFile:              ctx.GlobalString(flags.FeatureGatesFileFlag.Name),
+		FeatureGatesReloadInterval:    ctx.GlobalDuration(flags.FeatureGatesReloadIntervalFlag.Name),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		IndexerDataDir:                ctx.GlobalString(flags.IndexerDataDirFlag.Name),
+		EncoderTLSConfig:              encoderTLSConfig,
+		NodeTLSConfig:                 nodeTLSConfig,
 		IndexerConfig:                 indexer.ReadIndexerConfig(ctx),
 		FireblocksConfig:              fireblocksConfig,
+		EncoderHedgingConfig: encoder.HedgingConfig{
+			Delay:  ctx.GlobalDuration(flags.EncoderHedgingDelayFlag.Name),
+			Budget: ctx.GlobalFloat64(flags.EncoderHedgingBudgetFlag.Name),
+		},
+		DispersalRetryConfig: dispatcher.RetryConfig{
+			MaxRetries:         ctx.GlobalInt(flags.DispersalMaxRetriesFlag.Name),
+			Backoff:            ctx.GlobalDuration(flags.DispersalRetryBackoffFlag.Name),
+			MaxBackoff:         ctx.GlobalDuration(flags.DispersalMaxRetryBackoffFlag.Name),
+			BlacklistThreshold: ctx.GlobalInt(flags.OperatorBlacklistThresholdFlag.Name),
+			BlacklistDuration:  ctx.GlobalDuration(flags.OperatorBlacklistDurationFlag.Name),
+		},
+		DispersalMinibatchSize: uint64(ctx.GlobalUint(flags.DispersalMinibatchSizeFlag.Name)) * 1024 * 1024,
+		GasStrategyConfig: batcher.GasStrategyConfig{
+			Name:             ctx.GlobalString(flags.GasStrategyFlag.Name),
+			GasTipCap:        gweiToWei(ctx.GlobalFloat64(flags.GasStaticTipCapFlag.Name)),
+			GasFeeCap:        gweiToWei(ctx.GlobalFloat64(flags.GasStaticFeeCapFlag.Name)),
+			FeeHistoryBlocks: int(ctx.GlobalUint(flags.GasFeeHistoryBlocksFlag.Name)),
+			TipPercentile:    ctx.GlobalFloat64(flags.GasTipPercentileFlag.Name),
+			InclusionTarget:  ctx.GlobalDuration(flags.GasInclusionTargetFlag.Name),
+		},
+		StateTableName: ctx.GlobalString(flags.StateTableNameFlag.Name),
+		LeaderElectionConfig: batcher.LeaderElectionConfig{
+			LeaseDuration: ctx.GlobalDuration(flags.LeaderLeaseDurationFlag.Name),
+			RenewInterval: ctx.GlobalDuration(flags.LeaderLeaseRenewIntervalFlag.Name),
+		},
+	}
+	config.BatcherConfig.ServiceManagerAddr = gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
+	if config.StateTableName != "" {
+		if err := config.LeaderElectionConfig.Validate(); err != nil {
+			return Config{}, err
+		}
 	}
 	return config, nil
 }
+
+// readBatchPolicy returns the batch policy of the flags, bounded by the pull interval and the batch size limit, or nil
+// if none is set
+func readBatchPolicy(ctx *cli.Context) (batcher.BatchPolicy, error) {
+	maxInterval := ctx.GlobalDuration(flags.PullIntervalFlag.Name)
+	maxBatchBytes := uint64(ctx.GlobalUint(flags.BatchSizeLimitFlag.Name)) * 1024 * 1024
+	switch name := ctx.GlobalString(flags.BatchPolicyFlag.Name); name {
+	case "":
+		return nil, nil
+	case batcher.FixedBatchPolicyName:
+		return &batcher.FixedBatchPolicy{Interval: maxInterval, MaxBatchBytes: maxBatchBytes}, nil
+	case batcher.AdaptiveBatchPolicyName:
+		var targetGasPrice *big.Int
+		if gwei := ctx.GlobalFloat64(flags.BatchPolicyTargetGasPriceFlag.Name); gwei > 0 {
+			targetGasPrice = gweiToWei(gwei)
+		}
+		return batcher.NewAdaptiveBatchPolicy(batcher.AdaptiveBatchPolicyConfig{
+			MinInterval:         ctx.GlobalDuration(flags.BatchPolicyMinIntervalFlag.Name),
+			MaxInterval:         maxInterval,
+			MaxBatchBytes:       maxBatchBytes,
+			TargetDispersalTime: ctx.GlobalDuration(flags.BatchPolicyTargetDispersalTimeFlag.Name),
+			TargetGasPrice:      targetGasPrice,
+		})
+	default:
+		return nil, fmt.Errorf("unknown batch policy %q, must be %s or %s", name, batcher.FixedBatchPolicyName, batcher.AdaptiveBatchPolicyName)
+	}
+}
+
+// gweiToWei converts a gas price in gwei into wei
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
@@ -14,6 +15,10 @@ import (
 const (
 	FlagPrefix   = "batcher"
 	envVarPrefix = "BATCHER"
+
+	// The prefixes of the TLS flags of the connections to the encoders and to the operators.
+	EncoderTLSFlagPrefix = FlagPrefix + ".encoder"
+	NodeTLSFlagPrefix    = FlagPrefix + ".node"
 )
 
 var (
@@ -50,7 +55,7 @@ var (
 	}
 	EncoderSocket = cli.StringFlag{
 		Name:     "encoder-socket",
-		Usage:    "the http ip:port which the distributed encoder server is listening",
+		Usage:    "the http ip:port which the distributed encoder server is listening, or a comma separated list of the ip:port of the encoder replicas",
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_ADDRESS"),
 	}
@@ -186,6 +191,215 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOBS_TO_FETCH_FROM_STORE"),
 		Value:    100,
 	}
+	EncoderHedgingDelayFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-hedging-delay"),
+		Usage:    "Time after which an encoding request still pending on an encoder replica is also sent to the next replica. If set to zero, or with a single replica, requests are not hedged",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_HEDGING_DELAY"),
+		Value:    0,
+	}
+	EncoderHedgingBudgetFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-hedging-budget"),
+		Usage:    "Maximum fraction of the encoding requests which are hedged, between 0 and 1",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_HEDGING_BUDGET"),
+		Value:    0.1,
+	}
+	DispersalMaxRetriesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-max-retries"),
+		Usage:    "How many times the chunks are sent again to an operator which failed with a transient error, within the attestation timeout",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_MAX_RETRIES"),
+		Value:    2,
+	}
+	DispersalRetryBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-retry-backoff"),
+		Usage:    "The delay before the first retry of a dispersal to an operator, doubled after each retry up to the max backoff",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_RETRY_BACKOFF"),
+		Value:    500 * time.Millisecond,
+	}
+	DispersalMaxRetryBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-max-retry-backoff"),
+		Usage:    "The longest delay between two retries of a dispersal to an operator",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_MAX_RETRY_BACKOFF"),
+		Value:    4 * time.Second,
+	}
+	OperatorBlacklistThresholdFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-blacklist-threshold"),
+		Usage:    "The number of consecutive batches an operator must fail to be blacklisted, during which the batches are dispersed without it. Operators are never blacklisted if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_BLACKLIST_THRESHOLD"),
+		Value:    5,
+	}
+	OperatorBlacklistDurationFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-blacklist-duration"),
+		Usage:    "How long an operator is blacklisted after failing consecutive batches",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_BLACKLIST_DURATION"),
+		Value:    10 * time.Minute,
+	}
+	DispersalMinibatchSizeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-minibatch-size"),
+		Usage:    "The size in MiB of the minibatches of chunks streamed to the operators, which validate a batch while receiving it. If 0, the chunks are sent to each operator in a single request",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_MINIBATCH_SIZE"),
+		Value:    0,
+	}
+	LatencySensitiveBlobSizeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "latency-sensitive-blob-size"),
+		Usage:    "Size in bytes up to which blobs are encoded with latency priority, pausing the encoding of larger blobs. If set to zero, all blobs are encoded with bulk priority",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LATENCY_SENSITIVE_BLOB_SIZE"),
+		Value:    0,
+	}
+	ChunkEncodingFormatFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encoding-format"),
+		Usage:    "The format in which chunks are sent to the operators. One of: gob, compressed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_ENCODING_FORMAT"),
+		Value:    "gob",
+	}
+	AccountTiersFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-tiers-file"),
+		Usage:    "Path of the JSON file assigning the accounts to quota tiers, weighting their shares of a full batch, e.g. {\"tier_weights\": {\"premium\": 4}, \"accounts\": {\"0x...\": \"premium\"}}. If empty, every account has the same share",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_TIERS_FILE"),
+	}
+	PriorityLaneWeightsFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "priority-lane-weights"),
+		Usage:    "Comma separated weights of the priority lanes of the blobs, from the default lane 0, weighting their shares of the encoding and of a full batch. The highest lane must match the max blob priority of the API server",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PRIORITY_LANE_WEIGHTS"),
+		Value:    "1,4",
+	}
+	AnnouncementThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "announcement-threshold"),
+		Usage:    "the size in MiB of the encoded blobs from which the upcoming batch is announced to the operators with a webhook. If 0, no batch is announced",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ANNOUNCEMENT_THRESHOLD"),
+		Value:    0,
+	}
+	BatchPolicyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-policy"),
+		Usage:    "The policy deciding the boundaries of the batches. One of: fixed, adaptive. If empty, a batch is cut every pull interval or as soon as the encoded blobs reach the batch size limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_POLICY"),
+	}
+	BatchPolicyIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-policy-interval"),
+		Usage:    "How often the batch policy is evaluated",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_POLICY_INTERVAL"),
+		Value:    time.Second,
+	}
+	BatchPolicyMinIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-policy-min-interval"),
+		Usage:    "The minimum time between two batches of the adaptive batch policy",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_POLICY_MIN_INTERVAL"),
+		Value:    30 * time.Second,
+	}
+	BatchPolicyTargetDispersalTimeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-policy-target-dispersal-time"),
+		Usage:    "How long the operators should take to receive a batch of the adaptive batch policy, bounding the batch size by their dispersal throughput. If 0, the target batch size is the batch size limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_POLICY_TARGET_DISPERSAL_TIME"),
+		Value:    0,
+	}
+	BatchPolicyTargetGasPriceFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-policy-target-gas-price-gwei"),
+		Usage:    "The gas price in gwei above which the adaptive batch policy grows the batches proportionally to the gas price. If 0, the gas price is ignored",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_POLICY_TARGET_GAS_PRICE_GWEI"),
+		Value:    0,
+	}
+	QuorumAttestationFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorum-attestation"),
+		Usage:    "Comma separated attestation configs of the quorums as quorum=timeout/threshold, e.g. \"1=40s/67\". Past its timeout, the attestation of a quorum is settled once the threshold percentage of its stake signed, without waiting for its remaining operators. The quorums without a config wait for all their operators until the attestation timeout",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUORUM_ATTESTATION"),
+	}
+	OperatorWebhooksFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-webhooks-file"),
+		Usage:    "Path of the JSON file of the webhooks the operators receive the announcements of the upcoming large batches at, and of the secrets they're signed with, e.g. {\"0x...\": {\"url\": \"https://...\", \"secret\": \"...\"}}",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_WEBHOOKS_FILE"),
+	}
+	FeatureGatesFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "feature-gates-file"),
+		Usage:    "Path of the JSON file of the feature gates, mapping each feature to on, off, or a percentage of the operators such as 25%. The file is reloaded when it's modified. If empty, every feature is off",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FEATURE_GATES_FILE"),
+	}
+	FeatureGatesReloadIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "feature-gates-reload-interval"),
+		Usage:    "Interval at which the feature gates file is checked for modifications",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FEATURE_GATES_RELOAD_INTERVAL"),
+		Value:    30 * time.Second,
+	}
+	GasStrategyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "gas-strategy"),
+		Usage:    "The strategy pricing the confirmation transactions: suggested, static, percentile or inclusion-target",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GAS_STRATEGY"),
+		Value:    "suggested",
+	}
+	GasStaticTipCapFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "gas-static-tip-cap-gwei"),
+		Usage:    "The gas tip cap in gwei of the static gas strategy",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GAS_STATIC_TIP_CAP_GWEI"),
+	}
+	GasStaticFeeCapFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "gas-static-fee-cap-gwei"),
+		Usage:    "The gas fee cap in gwei of the static gas strategy",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GAS_STATIC_FEE_CAP_GWEI"),
+	}
+	GasFeeHistoryBlocksFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "gas-fee-history-blocks"),
+		Usage:    "The number of recent blocks whose tips are sampled by the percentile and inclusion-target gas strategies",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GAS_FEE_HISTORY_BLOCKS"),
+		Value:    20,
+	}
+	GasTipPercentileFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "gas-tip-percentile"),
+		Usage:    "The percentile of the recent tips paid by the percentile gas strategy, and from which the inclusion-target gas strategy starts",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GAS_TIP_PERCENTILE"),
+		Value:    50,
+	}
+	GasInclusionTargetFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "gas-inclusion-target"),
+		Usage:    "How long the inclusion-target gas strategy takes to raise the tip of a transaction which isn't mined to the 99th percentile of the recent tips",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GAS_INCLUSION_TARGET"),
+		Value:    time.Minute,
+	}
+	StateTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "state-table-name"),
+		Usage:    "Name of the DynamoDB table of the state shared by the batchers of a high availability deployment. If set, the batchers elect a leader running the batching pipeline, and the others stand by to take over. If empty, the batcher runs alone, and the batches and confirmation transactions in flight at a restart aren't recovered",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATE_TABLE_NAME"),
+	}
+	LeaderLeaseDurationFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "leader-lease-duration"),
+		Usage:    "How long the lease of the leader batcher lasts without being renewed, after which a standby takes over",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LEADER_LEASE_DURATION"),
+		Value:    30 * time.Second,
+	}
+	LeaderLeaseRenewIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "leader-lease-renew-interval"),
+		Usage:    "Interval at which the leader batcher renews its lease, and the standbys try to acquire it",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LEADER_LEASE_RENEW_INTERVAL"),
+		Value:    5 * time.Second,
+	}
 	FinalizationBlockDelayFlag = cli.UintFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "finalization-block-delay"),
 		Usage:    "The block delay to use for pulling operator state in order to ensure the state is finalized",
@@ -225,6 +439,37 @@ var optionalFlags = []cli.Flag{
 	TargetNumChunksFlag,
 	MaxBlobsToFetchFromStoreFlag,
 	FinalizationBlockDelayFlag,
+	LatencySensitiveBlobSizeFlag,
+	AccountTiersFileFlag,
+	PriorityLaneWeightsFlag,
+	EncoderHedgingDelayFlag,
+	EncoderHedgingBudgetFlag,
+	DispersalMaxRetriesFlag,
+	DispersalRetryBackoffFlag,
+	DispersalMaxRetryBackoffFlag,
+	OperatorBlacklistThresholdFlag,
+	OperatorBlacklistDurationFlag,
+	DispersalMinibatchSizeFlag,
+	ChunkEncodingFormatFlag,
+	FeatureGatesFileFlag,
+	FeatureGatesReloadIntervalFlag,
+	AnnouncementThresholdFlag,
+	OperatorWebhooksFileFlag,
+	QuorumAttestationFlag,
+	BatchPolicyFlag,
+	BatchPolicyIntervalFlag,
+	BatchPolicyMinIntervalFlag,
+	BatchPolicyTargetDispersalTimeFlag,
+	BatchPolicyTargetGasPriceFlag,
+	GasStrategyFlag,
+	GasStaticTipCapFlag,
+	GasStaticFeeCapFlag,
+	GasFeeHistoryBlocksFlag,
+	GasTipPercentileFlag,
+	GasInclusionTargetFlag,
+	StateTableNameFlag,
+	LeaderLeaseDurationFlag,
+	LeaderLeaseRenewIntervalFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -238,4 +483,6 @@ func init() {
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, common.FireblocksCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, grpctls.ClientCLIFlags(envVarPrefix+"_ENCODER", EncoderTLSFlagPrefix)...)
+	Flags = append(Flags, grpctls.ClientCLIFlags(envVarPrefix+"_NODE", NodeTLSFlagPrefix)...)
 }
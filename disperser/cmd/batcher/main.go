@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	coreindexer "github.com/Layr-Labs/eigenda/core/indexer"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 
@@ -18,10 +20,12 @@ import (
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/featuregate"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/fireblocks"
 	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
@@ -29,6 +33,7 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/uuid"
 	"github.com/urfave/cli"
 )
 
@@ -99,8 +104,26 @@ func RunBatcher(ctx *cli.Context) error {
 
 	metrics := batcher.NewMetrics(config.MetricsConfig.HTTPPort, logger)
 
+	featureGates, err := featuregate.NewGates(config.FeatureGatesFile, logger)
+	if err != nil {
+		return err
+	}
+	featureGates.Start(context.Background(), config.FeatureGatesReloadInterval)
+	if err := metrics.RegisterBuildInfo(newBuildInfo(config, featureGates)); err != nil {
+		return fmt.Errorf("failed to register build info: %w", err)
+	}
+
+	nodeCredentials, err := config.NodeTLSConfig.DialOption()
+	if err != nil {
+		return err
+	}
 	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
-		Timeout: config.TimeoutConfig.AttestationTimeout,
+		Timeout:             config.TimeoutConfig.AttestationTimeout,
+		ChunkEncodingFormat: config.ChunkEncodingFormat,
+		FeatureGates:        featureGates,
+		Credentials:         nodeCredentials,
+		Retry:               config.DispersalRetryConfig,
+		MinibatchSize:       config.DispersalMinibatchSize,
 	}, logger, metrics.DispatcherMetrics)
 	asgn := &core.StdAssignmentCoordinator{}
 
@@ -122,6 +145,7 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	agg.QuorumConfigs = config.TimeoutConfig.QuorumAttestation
 	blockStaleMeasure, err := tx.GetBlockStaleMeasure(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
@@ -163,10 +187,26 @@ func RunBatcher(ctx *cli.Context) error {
 	if len(config.BatcherConfig.EncoderSocket) == 0 {
 		return errors.New("encoder socket must be specified")
 	}
-	encoderClient, err := encoder.NewEncoderClient(config.BatcherConfig.EncoderSocket, config.TimeoutConfig.EncodingTimeout)
+	encoderSockets := strings.Split(config.BatcherConfig.EncoderSocket, ",")
+	encoderReplicas := make([]disperser.EncoderClient, len(encoderSockets))
+	encoderCredentials, err := config.EncoderTLSConfig.DialOption()
 	if err != nil {
 		return err
 	}
+	for i, socket := range encoderSockets {
+		encoderReplicas[i], err = encoder.NewEncoderClient(strings.TrimSpace(socket), config.TimeoutConfig.EncodingTimeout, encoderCredentials)
+		if err != nil {
+			return err
+		}
+	}
+	encoderClient := encoderReplicas[0]
+	if len(encoderReplicas) > 1 {
+		logger.Info("Sending encoding requests to encoder replicas", "replicas", len(encoderReplicas), "delay", config.EncoderHedgingConfig.Delay, "budget", config.EncoderHedgingConfig.Budget)
+		encoderClient, err = encoder.NewHedgedEncoderClient(encoderReplicas, config.EncoderHedgingConfig, metrics.EncoderClientMetrics)
+		if err != nil {
+			return err
+		}
+	}
 	finalizer := batcher.NewFinalizer(config.TimeoutConfig.ChainReadTimeout, config.BatcherConfig.FinalizerInterval, queue, client, rpcClient, config.BatcherConfig.MaxNumRetriesPerBlob, 1000, config.BatcherConfig.FinalizerPoolSize, logger, metrics.FinalizerMetrics)
 	var wallet walletsdk.Wallet
 	if !config.FireblocksConfig.Disable {
@@ -231,11 +271,29 @@ func RunBatcher(ctx *cli.Context) error {
 		return errors.New("no wallet is configured. Either Fireblocks or PrivateKey wallet should be configured")
 	}
 
-	txnManager := batcher.NewTxnManager(client, wallet, config.EthClientConfig.NumConfirmations, 20, config.TimeoutConfig.TxnBroadcastTimeout, config.TimeoutConfig.ChainWriteTimeout, logger, metrics.TxnManagerMetrics)
+	var stateStore batcher.StateStore
+	var elector *batcher.LeaderElector
+	if config.StateTableName != "" {
+		stateStore = batcher.NewDynamoStateStore(dynamoClient, config.StateTableName)
+		owner, err := leaseOwner()
+		if err != nil {
+			return err
+		}
+		elector = batcher.NewLeaderElector(config.LeaderElectionConfig, stateStore, owner, logger, metrics)
+	} else {
+		logger.Warn("No state table is set, the batches and confirmation transactions in flight at a restart won't be recovered")
+	}
+	gasStrategy, err := batcher.NewGasStrategy(config.GasStrategyConfig, client)
+	if err != nil {
+		return err
+	}
+	logger.Info("Pricing the confirmation transactions", "gasStrategy", gasStrategy.Name())
+	txnManager := batcher.NewTxnManager(client, wallet, config.EthClientConfig.NumConfirmations, 20, config.TimeoutConfig.TxnBroadcastTimeout, config.TimeoutConfig.ChainWriteTimeout, stateStore, gasStrategy, logger, metrics.TxnManagerMetrics)
 	batcher, err := batcher.NewBatcher(config.BatcherConfig, config.TimeoutConfig, queue, dispatcher, ics, asgn, encoderClient, agg, client, finalizer, tx, txnManager, logger, metrics, handleBatchLivenessChan)
 	if err != nil {
 		return err
 	}
+	batcher.StateStore = stateStore
 
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
@@ -244,20 +302,48 @@ func RunBatcher(ctx *cli.Context) error {
 		logger.Info("Enabled metrics for Batcher", "socket", httpSocket)
 	}
 
-	err = batcher.Start(context.Background())
-	if err != nil {
-		return err
-	}
+	start := func() error {
+		err := batcher.Start(context.Background())
+		if err != nil {
+			return err
+		}
 
-	// Signal readiness
-	if _, err := os.Create(readinessProbePath); err != nil {
-		log.Printf("Failed to create readiness file: %v at path %v \n", err, readinessProbePath)
+		// Signal readiness
+		if _, err := os.Create(readinessProbePath); err != nil {
+			log.Printf("Failed to create readiness file: %v at path %v \n", err, readinessProbePath)
+		}
+		return nil
+	}
+	if elector == nil {
+		return start()
 	}
 
+	// The batcher stands by until it's elected, and exits as soon as it loses the lease, so that the batching
+	// pipeline only ever runs in the leader
+	go func() {
+		logger.Info("Standing by until elected leader", "stateTable", config.StateTableName)
+		if err := elector.Campaign(context.Background()); err != nil {
+			log.Fatalf("leader election failed: %v", err)
+		}
+		if err := start(); err != nil {
+			log.Fatalf("failed to start the batcher: %v", err)
+		}
+		<-elector.Lost()
+		log.Fatalln("lost the lease of the leader, exiting")
+	}()
 	return nil
 
 }
 
+// leaseOwner returns the identity of the batcher in the leader election, unique across its restarts
+func leaseOwner() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the hostname: %w", err)
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()), nil
+}
+
 // process liveness signal from handleBatch Go Routine
 func heartbeatMonitor(filePath string, maxStallDuration time.Duration) {
 	var lastHeartbeat time.Time
@@ -288,3 +374,24 @@ func heartbeatMonitor(filePath string, maxStallDuration time.Duration) {
 		}
 	}
 }
+
+// newBuildInfo returns the build of the batcher, with the optional features enabled in the config and the features
+// gated on at startup
+func newBuildInfo(config Config, featureGates *featuregate.Gates) buildinfo.Info {
+	var features []string
+	for _, feature := range featureGates.EnabledFeatures() {
+		features = append(features, string(feature))
+	}
+	if config.DispersalMinibatchSize > 0 {
+		features = append(features, "minibatch_dispersal")
+	}
+	if config.NodeTLSConfig.Enabled {
+		features = append(features, "node_tls")
+	}
+	return buildinfo.Info{
+		Service:  "batcher",
+		Version:  version,
+		Commit:   gitCommit,
+		Features: features,
+	}
+}
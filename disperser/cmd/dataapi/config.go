@@ -1,7 +1,10 @@
 package main
 
 import (
+	"crypto/ecdsa"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -11,6 +14,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli"
 )
 
@@ -39,6 +43,12 @@ type Config struct {
 	BatcherHealthEndpt string
 
 	TxnTimeout time.Duration
+
+	NonInclusionSigner *ecdsa.PrivateKey
+
+	ExportBucketName string
+	ExportToken      string
+	AccountingToken  string
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -55,6 +65,22 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if !fireblocksConfig.Disable {
 		ethClientConfig = geth.ReadEthClientConfigRPCOnly(ctx)
 	}
+	var nonInclusionSigner *ecdsa.PrivateKey
+	if key := ctx.GlobalString(flags.NonInclusionSignerPrivateKeyFlag.Name); key != "" {
+		nonInclusionSigner, err = crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid non-inclusion signer private key: %w", err)
+		}
+	}
+	exportBucketName := ctx.GlobalString(flags.ExportBucketNameFlag.Name)
+	exportToken := ctx.GlobalString(flags.ExportTokenFlag.Name)
+	if exportBucketName != "" && len(exportToken) < 20 {
+		return Config{}, errors.New("the export token length must be at least 20")
+	}
+	accountingToken := ctx.GlobalString(flags.AccountingTokenFlag.Name)
+	if accountingToken != "" && len(accountingToken) < 20 {
+		return Config{}, errors.New("the accounting token length must be at least 20")
+	}
 	config := Config{
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
@@ -87,6 +113,12 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		BatcherHealthEndpt: ctx.GlobalString(flags.BatcherHealthEndptFlag.Name),
 
 		TxnTimeout: ctx.GlobalDuration(flags.TxnTimeoutFlag.Name),
+
+		NonInclusionSigner: nonInclusionSigner,
+
+		ExportBucketName: exportBucketName,
+		ExportToken:      exportToken,
+		AccountingToken:  accountingToken,
 	}
 	return config, nil
 }
@@ -153,6 +153,30 @@ var (
 		Value:    6 * time.Minute,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TRANSACTION_TIMEOUT"),
 	}
+	NonInclusionSignerPrivateKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "non-inclusion-signer-private-key"),
+		Usage:    "Hex-encoded ECDSA private key used to sign blob non-inclusion statements. Non-inclusion queries fail if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NON_INCLUSION_SIGNER_PRIVATE_KEY"),
+	}
+	ExportBucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "export-bucket-name"),
+		Usage:    "Name of the bucket the analytics exports are written to. Exports are disabled if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EXPORT_BUCKET_NAME"),
+	}
+	ExportTokenFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "export-token"),
+		Usage:    "The token used for authorizing the export requests. Required if exports are enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EXPORT_TOKEN"),
+	}
+	AccountingTokenFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "accounting-token"),
+		Usage:    "The token used for authorizing the requests to the accounting endpoints. The endpoints are disabled if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNTING_TOKEN"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -180,6 +204,10 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	ServerModeFlag,
 	MetricsHTTPPort,
+	NonInclusionSignerPrivateKeyFlag,
+	ExportBucketNameFlag,
+	ExportTokenFlag,
+	AccountingTokenFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -8,11 +8,13 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/aws/secretmanager"
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
@@ -94,10 +96,25 @@ func RunDataApi(ctx *cli.Context) error {
 		return err
 	}
 
+	// The disperser retains the metadata of the blobs for the store duration and the stale measure after they are
+	// requested
+	blockStaleMeasure, err := tx.GetBlockStaleMeasure(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
+	}
+	storeDurationBlocks, err := tx.GetStoreDurationBlocks(context.Background())
+	if err != nil || storeDurationBlocks == 0 {
+		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
+	}
+
 	wallet, err := getWallet(config, client, logger)
 	if err != nil {
 		return err
 	}
+	var exportStorage s3.Client
+	if config.ExportBucketName != "" {
+		exportStorage = s3Client
+	}
 	var (
 		promClient        = dataapi.NewPrometheusClient(promApi, config.PrometheusConfig.Cluster)
 		blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, 0)
@@ -115,6 +132,12 @@ func RunDataApi(ctx *cli.Context) error {
 				DisperserHostname:  config.DisperserHostname,
 				ChurnerHostname:    config.ChurnerHostname,
 				BatcherHealthEndpt: config.BatcherHealthEndpt,
+				NonInclusionSigner: config.NonInclusionSigner,
+				MetadataRetention:  time.Duration((storeDurationBlocks+blockStaleMeasure)*12) * time.Second,
+				ExportStorage:      exportStorage,
+				ExportBucketName:   config.ExportBucketName,
+				ExportToken:        config.ExportToken,
+				AccountingToken:    config.AccountingToken,
 			},
 			sharedStorage,
 			promClient,
@@ -130,6 +153,10 @@ func RunDataApi(ctx *cli.Context) error {
 		)
 	)
 
+	if err := metrics.RegisterBuildInfo(buildinfo.Info{Service: "dataapi", Version: version, Commit: gitCommit}); err != nil {
+		return fmt.Errorf("failed to register build info: %w", err)
+	}
+
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -20,13 +21,20 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+	tlsConfig, err := grpctls.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, err
+	}
 	config := Config{
 		EncoderConfig: kzg.ReadCLIConfig(ctx),
 		LoggerConfig:  *loggerConfig,
 		ServerConfig: &encoder.ServerConfig{
-			GrpcPort:              ctx.GlobalString(flags.GrpcPortFlag.Name),
-			MaxConcurrentRequests: ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name),
-			RequestPoolSize:       ctx.GlobalInt(flags.RequestPoolSizeFlag.Name),
+			GrpcPort:                     ctx.GlobalString(flags.GrpcPortFlag.Name),
+			TLS:                          tlsConfig,
+			MaxConcurrentRequests:        ctx.GlobalInt(flags.MaxConcurrentRequestsFlag.Name),
+			RequestPoolSize:              ctx.GlobalInt(flags.RequestPoolSizeFlag.Name),
+			MaxConcurrentLatencyRequests: ctx.GlobalInt(flags.MaxConcurrentLatencyRequestsFlag.Name),
+			MaxPreemptionPause:           ctx.GlobalDuration(flags.MaxPreemptionPauseFlag.Name),
 		},
 		MetricsConfig: encoder.MetrisConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
@@ -21,6 +23,9 @@ func NewEncoderGRPCServer(config Config, _logger logging.Logger) (*EncoderGRPCSe
 	}
 
 	metrics := encoder.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	if err := metrics.RegisterBuildInfo(newBuildInfo(config)); err != nil {
+		return nil, fmt.Errorf("failed to register build info: %w", err)
+	}
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
@@ -35,6 +40,33 @@ func NewEncoderGRPCServer(config Config, _logger logging.Logger) (*EncoderGRPCSe
 	}, nil
 }
 
+// newBuildInfo returns the build of the encoder, with the algorithm computing the proofs as its backend, and the
+// optional features enabled in the config
+func newBuildInfo(config Config) buildinfo.Info {
+	proofAlgorithm := config.EncoderConfig.ProofAlgorithm
+	if proofAlgorithm == "" {
+		proofAlgorithm = kzg.FK20ProofAlgorithm
+	}
+	var features []string
+	if config.ServerConfig.TLS.Enabled() {
+		features = append(features, "tls")
+	}
+	if config.EncoderConfig.MSMTableDir != "" {
+		features = append(features, "msm_tables")
+	}
+	if config.EncoderConfig.CommitmentCacheSize > 0 {
+		features = append(features, "commitment_cache")
+	}
+	return buildinfo.Info{
+		Service:        "encoder",
+		Version:        Version,
+		Commit:         GitCommit,
+		EncoderBackend: proofAlgorithm,
+		SRSOrder:       config.EncoderConfig.SRSOrder,
+		Features:       features,
+	}
+}
+
 func (d *EncoderGRPCServer) Start(ctx context.Context) error {
 	// TODO: Start Metrics
 	return d.Server.Start()
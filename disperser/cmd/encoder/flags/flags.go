@@ -1,7 +1,10 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
 )
@@ -47,6 +50,20 @@ var (
 		Value:    32,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "REQUEST_POOL_SIZE"),
 	}
+	MaxConcurrentLatencyRequestsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-latency-requests"),
+		Usage:    "maximum number of concurrent latency sensitive requests, on top of the other concurrent requests. Defaults to max-concurrent-requests if 0",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_LATENCY_REQUESTS"),
+	}
+	MaxPreemptionPauseFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-preemption-pause"),
+		Usage:    "maximum duration a bulk encoding is paused at each of its stages while latency sensitive encodings are running. Set to 0 to never pause bulk encodings",
+		Required: false,
+		Value:    5 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_PREEMPTION_PAUSE"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -58,6 +75,8 @@ var optionalFlags = []cli.Flag{
 	EnableMetrics,
 	MaxConcurrentRequestsFlag,
 	RequestPoolSizeFlag,
+	MaxConcurrentLatencyRequestsFlag,
+	MaxPreemptionPauseFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -67,4 +86,5 @@ func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, kzg.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpctls.CLIFlags(envVarPrefix, FlagPrefix)...)
 }
@@ -2,12 +2,14 @@ package blobstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
@@ -16,16 +18,64 @@ import (
 )
 
 const (
-	statusIndexName = "StatusIndex"
-	batchIndexName  = "BatchIndex"
+	statusIndexName     = "StatusIndex"
+	batchIndexName      = "BatchIndex"
+	commitmentIndexName = "CommitmentIndex"
+
+	// commitmentIndexKeyName is the attribute used as the partition key of the commitment index.
+	// It's only set on blobs with confirmation info containing a blob commitment.
+	commitmentIndexKeyName = "CommitmentIndexKey"
+
+	accountIndexName = "AccountIndex"
+	// accountIndexKeyName is the attribute used as the partition key of the account index.
+	// It's only set on blobs with an account ID, as index keys can't be empty.
+	accountIndexKeyName = "AccountIndexKey"
+
+	// The idempotency keys are stored in the metadata table, under a partition key which can't be a blob hash. They
+	// have none of the attributes of the indexes, so they don't appear in the indexes.
+	idempotencyKeyPrefix       = "idempotency#"
+	idempotencyKeyMetadataHash = "idempotency"
+
+	// The usage of the accounts is stored in the metadata table too, under a partition key per account and a sort
+	// key per usage period which sort in the order of the periods
+	usageKeyPrefix       = "usage#"
+	usagePeriodKeyPrefix = "period#"
+	// usageRecordedName is the attribute of the metadata of the blobs whose usage was added to the usage of their
+	// account
+	usageRecordedName = "UsageRecorded"
 )
 
+// usageItem is the item of the usage of an account in a usage period in the metadata table
+type usageItem struct {
+	BlobHash       string
+	MetadataHash   string
+	NumBlobs       uint64
+	BlobBytes      uint64
+	DispersalBytes uint64
+	// EncodingTime is in nanoseconds
+	EncodingTime int64
+	GasFee       uint64
+	Settled      bool
+}
+
+// idempotencyItem is the item of an idempotency key in the metadata table
+type idempotencyItem struct {
+	BlobHash            string
+	MetadataHash        string
+	PayloadHash         []byte
+	Expiry              uint64
+	RequestBlobHash     string `dynamodbav:",omitempty"`
+	RequestMetadataHash string `dynamodbav:",omitempty"`
+}
+
 // BlobMetadataStore is a blob metadata storage backed by DynamoDB
 // The blob metadata is stored in a single table and replicated in several indexes.
 // - Metadata: (Partition Key: BlobKey, Sort Key: MetadataHash) -> Metadata
 // - Indexes
 //   - StatusIndex: (Partition Key: Status, Sort Key: RequestedAt) -> Metadata
 //   - BatchIndex: (Partition Key: BatchHeaderHash, Sort Key: BlobIndex) -> Metadata
+//   - CommitmentIndex: (Partition Key: CommitmentIndexKey, Sort Key: BatchID) -> Metadata
+//   - AccountIndex: (Partition Key: AccountIndexKey, Sort Key: RequestedAt) -> Metadata
 type BlobMetadataStore struct {
 	dynamoDBClient *commondynamodb.Client
 	logger         logging.Logger
@@ -166,6 +216,57 @@ func (s *BlobMetadataStore) GetBlobMetadataByStatusWithPagination(ctx context.Co
 	return metadata, exclusiveStartKey, nil
 }
 
+// GetBlobMetadataByAccountWithPagination returns the metadata of the blobs of the account requested in [from, to),
+// in nanoseconds, upto the specified limit, along with a pagination token that can be used to fetch the next set of
+// items
+func (s *BlobMetadataStore) GetBlobMetadataByAccountWithPagination(ctx context.Context, accountID string, from, to uint64, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	if from >= to {
+		return nil, nil, fmt.Errorf("invalid time range [%d, %d)", from, to)
+	}
+
+	// The start key of a query must have exactly the keys of the table and of the index
+	var attributeMap map[string]types.AttributeValue
+	if exclusiveStartKey != nil {
+		attributeMap = map[string]types.AttributeValue{
+			"BlobHash":          &types.AttributeValueMemberS{Value: exclusiveStartKey.BlobHash},
+			"MetadataHash":      &types.AttributeValueMemberS{Value: exclusiveStartKey.MetadataHash},
+			accountIndexKeyName: &types.AttributeValueMemberS{Value: accountID},
+			"RequestedAt":       &types.AttributeValueMemberN{Value: strconv.FormatInt(exclusiveStartKey.RequestedAt, 10)},
+		}
+	}
+
+	queryResult, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, accountIndexName, "AccountIndexKey = :account AND RequestedAt BETWEEN :from AND :to", commondynamodb.ExpresseionValues{
+		":account": &types.AttributeValueMemberS{
+			Value: accountID,
+		},
+		":from": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(from, 10),
+		},
+		":to": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(to-1, 10),
+		}}, limit, attributeMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := make([]*disperser.BlobMetadata, len(queryResult.Items))
+	for i, item := range queryResult.Items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if queryResult.LastEvaluatedKey == nil {
+		return metadata, nil, nil
+	}
+	exclusiveStartKey, err = convertToExclusiveStartKey(queryResult.LastEvaluatedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return metadata, exclusiveStartKey, nil
+}
+
 func (s *BlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
 	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, batchIndexName, "BatchHeaderHash = :batch_header_hash", commondynamodb.ExpresseionValues{
 		":batch_header_hash": &types.AttributeValueMemberB{
@@ -191,6 +292,46 @@ func (s *BlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batch
 	return metadatas, nil
 }
 
+// GetBlobMetadataByBatchWithPagination returns the metadata of the blobs in the batch ordered by blob index, upto the
+// specified limit, along with a pagination token that can be used to fetch the next set of items
+func (s *BlobMetadataStore) GetBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	// The start key of a query must have exactly the keys of the table and of the index
+	var attributeMap map[string]types.AttributeValue
+	if exclusiveStartKey != nil {
+		attributeMap = map[string]types.AttributeValue{
+			"BlobHash":        &types.AttributeValueMemberS{Value: exclusiveStartKey.BlobHash},
+			"MetadataHash":    &types.AttributeValueMemberS{Value: exclusiveStartKey.MetadataHash},
+			"BatchHeaderHash": &types.AttributeValueMemberB{Value: batchHeaderHash[:]},
+			"BlobIndex":       &types.AttributeValueMemberN{Value: strconv.FormatUint(uint64(exclusiveStartKey.BlobIndex), 10)},
+		}
+	}
+
+	queryResult, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, batchIndexName, "BatchHeaderHash = :batch_header_hash", commondynamodb.ExpresseionValues{
+		":batch_header_hash": &types.AttributeValueMemberB{
+			Value: batchHeaderHash[:],
+		}}, limit, attributeMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := make([]*disperser.BlobMetadata, len(queryResult.Items))
+	for i, item := range queryResult.Items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if queryResult.LastEvaluatedKey == nil {
+		return metadata, nil, nil
+	}
+	exclusiveStartKey, err = convertToExclusiveStartKey(queryResult.LastEvaluatedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return metadata, exclusiveStartKey, nil
+}
+
 func (s *BlobMetadataStore) GetBlobMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
 	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, batchIndexName, "BatchHeaderHash = :batch_header_hash AND BlobIndex = :blob_index", commondynamodb.ExpresseionValues{
 		":batch_header_hash": &types.AttributeValueMemberB{
@@ -218,6 +359,42 @@ func (s *BlobMetadataStore) GetBlobMetadataInBatch(ctx context.Context, batchHea
 	return metadata, nil
 }
 
+// GetBlobMetadataByCommitment returns the metadata of the confirmed or finalized blobs with the given commitment
+// whose batch ID is in the inclusive range [startBatchID, endBatchID].
+func (s *BlobMetadataStore) GetBlobMetadataByCommitment(ctx context.Context, commitment *encoding.G1Commitment, startBatchID, endBatchID uint32) ([]*disperser.BlobMetadata, error) {
+	if startBatchID > endBatchID {
+		return nil, fmt.Errorf("invalid batch range [%d, %d]", startBatchID, endBatchID)
+	}
+	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, commitmentIndexName, "CommitmentIndexKey = :commitment AND BatchID BETWEEN :start AND :end", commondynamodb.ExpresseionValues{
+		":commitment": &types.AttributeValueMemberS{
+			Value: disperser.GenerateCommitmentIndexKey(commitment),
+		},
+		":start": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(uint64(startBatchID), 10),
+		},
+		":end": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(uint64(endBatchID), 10),
+		}})
+	if err != nil {
+		return nil, err
+	}
+
+	metadatas := make([]*disperser.BlobMetadata, 0, len(items))
+	for _, item := range items {
+		metadata, err := UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, err
+		}
+		// Blobs with insufficient signatures also carry confirmation info, but were never confirmed onchain.
+		if metadata.BlobStatus != disperser.Confirmed && metadata.BlobStatus != disperser.Finalized {
+			continue
+		}
+		metadatas = append(metadatas, metadata)
+	}
+
+	return metadatas, nil
+}
+
 func (s *BlobMetadataStore) IncrementNumRetries(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
 	_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
 		"BlobHash": &types.AttributeValueMemberS{
@@ -270,6 +447,210 @@ func (s *BlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey dispe
 	return err
 }
 
+// RollBackBlobConfirmation returns a confirmed blob to the given status and removes its confirmation info, including
+// its keys in the batch and commitment indices, in a single update. It fails with ErrConditionFailed if the blob isn't
+// confirmed anymore.
+func (s *BlobMetadataStore) RollBackBlobConfirmation(ctx context.Context, existingMetadata *disperser.BlobMetadata, status disperser.BlobStatus) (*disperser.BlobMetadata, error) {
+	updated := *existingMetadata
+	updated.BlobStatus = status
+	updated.ConfirmationInfo = nil
+	item, err := MarshalBlobMetadata(&updated)
+	if err != nil {
+		return nil, err
+	}
+	confirmationInfo, err := attributevalue.MarshalMap(disperser.ConfirmationInfo{})
+	if err != nil {
+		return nil, err
+	}
+	// The attributes shared with the request metadata are set back to their request values rather than removed
+	remove := []string{commitmentIndexKeyName}
+	for name := range confirmationInfo {
+		remove = append(remove, name)
+	}
+
+	_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: existingMetadata.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: existingMetadata.MetadataHash,
+		},
+	}, item, remove, "BlobStatus = :confirmed", commondynamodb.ExpresseionValues{
+		":confirmed": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(disperser.Confirmed)),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// ReserveIdempotencyKey reserves the idempotency key unless it's already reserved by a request which hasn't expired,
+// in which case it returns the record of that request
+func (s *BlobMetadataStore) ReserveIdempotencyKey(ctx context.Context, key string, payloadHash []byte, expiry uint64) (*disperser.IdempotencyRecord, error) {
+	item, err := attributevalue.MarshalMap(idempotencyItem{
+		BlobHash:     idempotencyKeyPrefix + key,
+		MetadataHash: idempotencyKeyMetadataHash,
+		PayloadHash:  payloadHash,
+		Expiry:       expiry,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(BlobHash) OR Expiry < :now", commondynamodb.ExpresseionValues{
+		":now": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(time.Now().Unix(), 10),
+		}})
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return nil, err
+	}
+
+	item, err = s.dynamoDBClient.GetItem(ctx, s.tableName, idempotencyKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, fmt.Errorf("idempotency key %s was released while being reserved", key)
+	}
+	existing := idempotencyItem{}
+	err = attributevalue.UnmarshalMap(item, &existing)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &disperser.IdempotencyRecord{
+		PayloadHash: existing.PayloadHash,
+		Expiry:      existing.Expiry,
+	}
+	if existing.RequestBlobHash != "" {
+		record.BlobKey = &disperser.BlobKey{
+			BlobHash:     existing.RequestBlobHash,
+			MetadataHash: existing.RequestMetadataHash,
+		}
+	}
+	return record, nil
+}
+
+func (s *BlobMetadataStore) SetIdempotencyKeyBlob(ctx context.Context, key string, blobKey disperser.BlobKey, expiry uint64) error {
+	_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, idempotencyKey(key), commondynamodb.Item{
+		"RequestBlobHash": &types.AttributeValueMemberS{
+			Value: blobKey.BlobHash,
+		},
+		"RequestMetadataHash": &types.AttributeValueMemberS{
+			Value: blobKey.MetadataHash,
+		},
+		"Expiry": &types.AttributeValueMemberN{
+			Value: strconv.FormatUint(expiry, 10),
+		},
+	})
+
+	return err
+}
+
+func (s *BlobMetadataStore) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	return s.dynamoDBClient.DeleteItem(ctx, s.tableName, idempotencyKey(key))
+}
+
+// AddAccountUsage adds the usage of the blob to the usage of its account, and marks the usage of the blob recorded in
+// its metadata in the same transaction. The usage of a blob whose usage is already recorded isn't added again.
+func (s *BlobMetadataStore) AddAccountUsage(ctx context.Context, blobKey disperser.BlobKey, usage *disperser.AccountUsage) error {
+	err := s.dynamoDBClient.AddToItemOnce(ctx, s.tableName, accountUsageKey(usage.AccountID, usage.PeriodStart), commondynamodb.Item{
+		"NumBlobs":       &types.AttributeValueMemberN{Value: strconv.FormatUint(usage.NumBlobs, 10)},
+		"BlobBytes":      &types.AttributeValueMemberN{Value: strconv.FormatUint(usage.BlobBytes, 10)},
+		"DispersalBytes": &types.AttributeValueMemberN{Value: strconv.FormatUint(usage.DispersalBytes, 10)},
+		"EncodingTime":   &types.AttributeValueMemberN{Value: strconv.FormatInt(int64(usage.EncodingTime), 10)},
+		"GasFee":         &types.AttributeValueMemberN{Value: strconv.FormatUint(usage.GasFee, 10)},
+	}, commondynamodb.Key{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: blobKey.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: blobKey.MetadataHash,
+		},
+	}, usageRecordedName)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		s.logger.Debug("the usage of the blob is already recorded", "blobKey", blobKey.String(), "accountID", usage.AccountID)
+		return nil
+	}
+	return err
+}
+
+func (s *BlobMetadataStore) GetAccountUsage(ctx context.Context, accountID string, from, to uint64) ([]*disperser.AccountUsage, error) {
+	usages := make([]*disperser.AccountUsage, 0)
+	if to <= from {
+		return usages, nil
+	}
+	items, err := s.dynamoDBClient.Query(ctx, s.tableName, "BlobHash = :account AND MetadataHash BETWEEN :from AND :to", commondynamodb.ExpresseionValues{
+		":account": &types.AttributeValueMemberS{Value: usageKeyPrefix + accountID},
+		":from":    &types.AttributeValueMemberS{Value: usagePeriodKey(from)},
+		":to":      &types.AttributeValueMemberS{Value: usagePeriodKey(to - 1)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		var stored usageItem
+		if err := attributevalue.UnmarshalMap(item, &stored); err != nil {
+			return nil, err
+		}
+		periodStart, err := strconv.ParseUint(stored.MetadataHash[len(usagePeriodKeyPrefix):], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid usage period %s: %w", stored.MetadataHash, err)
+		}
+		usages = append(usages, &disperser.AccountUsage{
+			AccountID:      accountID,
+			PeriodStart:    periodStart,
+			NumBlobs:       stored.NumBlobs,
+			BlobBytes:      stored.BlobBytes,
+			DispersalBytes: stored.DispersalBytes,
+			EncodingTime:   time.Duration(stored.EncodingTime),
+			GasFee:         stored.GasFee,
+			Settled:        stored.Settled,
+		})
+	}
+	return usages, nil
+}
+
+func (s *BlobMetadataStore) MarkAccountUsageSettled(ctx context.Context, accountID string, periodStart uint64) error {
+	_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, accountUsageKey(accountID, periodStart), commondynamodb.Item{
+		"Settled": &types.AttributeValueMemberBOOL{Value: true},
+	})
+	return err
+}
+
+func accountUsageKey(accountID string, periodStart uint64) commondynamodb.Key {
+	return commondynamodb.Key{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: usageKeyPrefix + accountID,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: usagePeriodKey(periodStart),
+		},
+	}
+}
+
+// usagePeriodKey is the sort key of the usage period, zero padded so that the periods sort in order
+func usagePeriodKey(periodStart uint64) string {
+	return fmt.Sprintf("%s%020d", usagePeriodKeyPrefix, periodStart)
+}
+
+func idempotencyKey(key string) commondynamodb.Key {
+	return commondynamodb.Key{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: idempotencyKeyPrefix + key,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: idempotencyKeyMetadataHash,
+		},
+	}
+}
+
 func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
 	return &dynamodb.CreateTableInput{
 		AttributeDefinitions: []types.AttributeDefinition{
@@ -297,6 +678,18 @@ func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writ
 				AttributeName: aws.String("BlobIndex"),
 				AttributeType: types.ScalarAttributeTypeN,
 			},
+			{
+				AttributeName: aws.String(commitmentIndexKeyName),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("BatchID"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+			{
+				AttributeName: aws.String(accountIndexKeyName),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
 		},
 		KeySchema: []types.KeySchemaElement{
 			{
@@ -350,6 +743,46 @@ func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writ
 					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
 				},
 			},
+			{
+				IndexName: aws.String(commitmentIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String(commitmentIndexKeyName),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("BatchID"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
+			{
+				IndexName: aws.String(accountIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String(accountIndexKeyName),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("RequestedAt"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
 		},
 		ProvisionedThroughput: &types.ProvisionedThroughput{
 			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
@@ -378,6 +811,13 @@ func MarshalBlobMetadata(metadata *disperser.BlobMetadata) (commondynamodb.Item,
 		basicFields[k] = v
 	}
 
+	// Index the blob by its account, within its tenant
+	if metadata.RequestMetadata.AccountID != "" {
+		basicFields[accountIndexKeyName] = &types.AttributeValueMemberS{
+			Value: disperser.TenantAccountKey(metadata.RequestMetadata.TenantID, metadata.RequestMetadata.AccountID),
+		}
+	}
+
 	if metadata.ConfirmationInfo == nil {
 		return basicFields, nil
 	}
@@ -392,6 +832,13 @@ func MarshalBlobMetadata(metadata *disperser.BlobMetadata) (commondynamodb.Item,
 		basicFields[k] = v
 	}
 
+	// Index the blob by its commitment
+	if metadata.ConfirmationInfo.BlobCommitment != nil && metadata.ConfirmationInfo.BlobCommitment.Commitment != nil {
+		basicFields[commitmentIndexKeyName] = &types.AttributeValueMemberS{
+			Value: disperser.GenerateCommitmentIndexKey(metadata.ConfirmationInfo.BlobCommitment.Commitment),
+		}
+	}
+
 	return basicFields, nil
 }
 
@@ -2,6 +2,7 @@ package blobstore_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -99,6 +100,34 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int32(1), confirmedCount)
 
+	commitment := confirmedMetadata.ConfirmationInfo.BlobCommitment.Commitment
+	batchID := confirmedMetadata.ConfirmationInfo.BatchID
+	byCommitment, err := blobMetadataStore.GetBlobMetadataByCommitment(ctx, commitment, batchID-1, batchID+1)
+	assert.NoError(t, err)
+	assert.Len(t, byCommitment, 1)
+	assert.Equal(t, confirmedMetadata, byCommitment[0])
+	byCommitment, err = blobMetadataStore.GetBlobMetadataByCommitment(ctx, commitment, batchID+1, batchID+10)
+	assert.NoError(t, err)
+	assert.Len(t, byCommitment, 0)
+	_, err = blobMetadataStore.GetBlobMetadataByCommitment(ctx, commitment, batchID+1, batchID)
+	assert.Error(t, err)
+
+	// The rolled back blob is removed from the batch and commitment indices
+	rolledBack, err := blobMetadataStore.RollBackBlobConfirmation(ctx, confirmedMetadata, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Nil(t, rolledBack.ConfirmationInfo)
+	fetchedMetadata, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, rolledBack, fetchedMetadata)
+	inBatch, err := blobMetadataStore.GetAllBlobMetadataByBatch(ctx, confirmedMetadata.ConfirmationInfo.BatchHeaderHash)
+	assert.NoError(t, err)
+	assert.Len(t, inBatch, 0)
+	byCommitment, err = blobMetadataStore.GetBlobMetadataByCommitment(ctx, commitment, batchID-1, batchID+1)
+	assert.NoError(t, err)
+	assert.Len(t, byCommitment, 0)
+	_, err = blobMetadataStore.RollBackBlobConfirmation(ctx, confirmedMetadata, disperser.Processing)
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
 	deleteItems(t, []commondynamodb.Key{
 		{
 			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey1.MetadataHash},
@@ -197,6 +226,52 @@ func TestBlobMetadataStoreOperationsWithPaginationNoStoredBlob(t *testing.T) {
 	assert.Nil(t, lastEvaluatedKey)
 }
 
+func TestBlobMetadataStoreIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+	payloadHash := []byte{1, 2, 3}
+
+	record, err := blobMetadataStore.ReserveIdempotencyKey(ctx, "key", payloadHash, expiry)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	// The key is reserved, but has no blob yet
+	record, err = blobMetadataStore.ReserveIdempotencyKey(ctx, "key", []byte{4}, expiry)
+	assert.NoError(t, err)
+	assert.Equal(t, payloadHash, record.PayloadHash)
+	assert.Nil(t, record.BlobKey)
+
+	blobKey := disperser.BlobKey{BlobHash: "blob", MetadataHash: "hash"}
+	err = blobMetadataStore.SetIdempotencyKeyBlob(ctx, "key", blobKey, expiry)
+	assert.NoError(t, err)
+	record, err = blobMetadataStore.ReserveIdempotencyKey(ctx, "key", payloadHash, expiry)
+	assert.NoError(t, err)
+	assert.Equal(t, &blobKey, record.BlobKey)
+	assert.Equal(t, expiry, record.Expiry)
+
+	// The idempotency keys aren't blobs
+	processing, err := blobMetadataStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, processing, 0)
+
+	// Expired and released keys can be reserved again
+	record, err = blobMetadataStore.ReserveIdempotencyKey(ctx, "expired", payloadHash, uint64(time.Now().Add(-time.Minute).Unix()))
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	record, err = blobMetadataStore.ReserveIdempotencyKey(ctx, "expired", payloadHash, expiry)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	err = blobMetadataStore.ReleaseIdempotencyKey(ctx, "key")
+	assert.NoError(t, err)
+	record, err = blobMetadataStore.ReserveIdempotencyKey(ctx, "key", payloadHash, expiry)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+
+	assert.NoError(t, blobMetadataStore.ReleaseIdempotencyKey(ctx, "key"))
+	assert.NoError(t, blobMetadataStore.ReleaseIdempotencyKey(ctx, "expired"))
+}
+
 func deleteItems(t *testing.T, keys []commondynamodb.Key) {
 	_, err := dynamoClient.DeleteItems(context.Background(), metadataTableName, keys)
 	assert.NoError(t, err)
@@ -254,3 +329,62 @@ func getConfirmedMetadata(t *testing.T, metadataKey disperser.BlobKey) *disperse
 		},
 	}
 }
+
+func TestBlobMetadataStoreAccountUsage(t *testing.T) {
+	ctx := context.Background()
+	period := uint64(disperser.UsagePeriod.Seconds())
+
+	blobKeys := make([]disperser.BlobKey, 3)
+	for i, usage := range []*disperser.AccountUsage{
+		{AccountID: "account", PeriodStart: 10 * period, NumBlobs: 1, BlobBytes: 100, EncodingTime: time.Second},
+		{AccountID: "account", PeriodStart: 10 * period, NumBlobs: 1, BlobBytes: 200, GasFee: 3},
+		{AccountID: "account", PeriodStart: 11 * period, NumBlobs: 1, BlobBytes: 50},
+	} {
+		blobKeys[i] = disperser.BlobKey{BlobHash: fmt.Sprintf("usage-blob-%d", i), MetadataHash: "hash"}
+		assert.NoError(t, blobMetadataStore.QueueNewBlobMetadata(ctx, &disperser.BlobMetadata{
+			BlobHash:     blobKeys[i].BlobHash,
+			MetadataHash: blobKeys[i].MetadataHash,
+			BlobStatus:   disperser.Confirmed,
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: blob.RequestHeader,
+				BlobSize:          blobSize,
+			},
+		}))
+		assert.NoError(t, blobMetadataStore.AddAccountUsage(ctx, blobKeys[i], usage))
+	}
+	// The usage of a blob confirmed again after a reorg isn't added twice
+	assert.NoError(t, blobMetadataStore.AddAccountUsage(ctx, blobKeys[2], &disperser.AccountUsage{AccountID: "account", PeriodStart: 10 * period, NumBlobs: 1, BlobBytes: 50}))
+
+	usages, err := blobMetadataStore.GetAccountUsage(ctx, "account", 0, 12*period)
+	assert.NoError(t, err)
+	assert.Len(t, usages, 2)
+	assert.Equal(t, &disperser.AccountUsage{
+		AccountID:    "account",
+		PeriodStart:  10 * period,
+		NumBlobs:     2,
+		BlobBytes:    300,
+		EncodingTime: time.Second,
+		GasFee:       3,
+	}, usages[0])
+	assert.Equal(t, 11*period, usages[1].PeriodStart)
+
+	assert.NoError(t, blobMetadataStore.MarkAccountUsageSettled(ctx, "account", 10*period))
+	usages, err = blobMetadataStore.GetAccountUsage(ctx, "account", 10*period, 11*period)
+	assert.NoError(t, err)
+	assert.Len(t, usages, 1)
+	assert.True(t, usages[0].Settled)
+
+	// The usage isn't blobs
+	processing, err := blobMetadataStore.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
+	assert.Len(t, processing, 0)
+
+	keys := make([]commondynamodb.Key, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		keys[i] = commondynamodb.Key{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+		}
+	}
+	deleteItems(t, keys)
+}
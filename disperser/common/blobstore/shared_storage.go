@@ -11,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gammazero/workerpool"
 )
@@ -108,6 +109,7 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 			BlobRequestHeader: blob.RequestHeader,
 			BlobSize:          uint(len(blob.Data)),
 			RequestedAt:       requestedAt,
+			PayloadHash:       disperser.ComputePayloadHash(blob.Data),
 		},
 	}
 	err = s.blobMetadataStore.QueueNewBlobMetadata(ctx, &metadata)
@@ -119,6 +121,30 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 	return metadataKey, nil
 }
 
+func (s *SharedBlobStore) ReserveIdempotencyKey(ctx context.Context, key string, payloadHash []byte, expiry uint64) (*disperser.IdempotencyRecord, error) {
+	return s.blobMetadataStore.ReserveIdempotencyKey(ctx, key, payloadHash, expiry)
+}
+
+func (s *SharedBlobStore) SetIdempotencyKeyBlob(ctx context.Context, key string, blobKey disperser.BlobKey, expiry uint64) error {
+	return s.blobMetadataStore.SetIdempotencyKeyBlob(ctx, key, blobKey, expiry)
+}
+
+func (s *SharedBlobStore) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	return s.blobMetadataStore.ReleaseIdempotencyKey(ctx, key)
+}
+
+func (s *SharedBlobStore) AddAccountUsage(ctx context.Context, blobKey disperser.BlobKey, usage *disperser.AccountUsage) error {
+	return s.blobMetadataStore.AddAccountUsage(ctx, blobKey, usage)
+}
+
+func (s *SharedBlobStore) GetAccountUsage(ctx context.Context, accountID string, from, to uint64) ([]*disperser.AccountUsage, error) {
+	return s.blobMetadataStore.GetAccountUsage(ctx, accountID, from, to)
+}
+
+func (s *SharedBlobStore) MarkAccountUsageSettled(ctx context.Context, accountID string, periodStart uint64) error {
+	return s.blobMetadataStore.MarkAccountUsageSettled(ctx, accountID, periodStart)
+}
+
 // GetBlobContent retrieves blob content by the blob key.
 func (s *SharedBlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) ([]byte, error) {
 	return s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobHash))
@@ -175,6 +201,10 @@ func (s *SharedBlobStore) MarkBlobProcessing(ctx context.Context, metadataKey di
 	return s.blobMetadataStore.SetBlobStatus(ctx, metadataKey, disperser.Processing)
 }
 
+func (s *SharedBlobStore) MarkBlobReorged(ctx context.Context, existingMetadata *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+	return s.blobMetadataStore.RollBackBlobConfirmation(ctx, existingMetadata, disperser.Processing)
+}
+
 func (s *SharedBlobStore) MarkBlobFailed(ctx context.Context, metadataKey disperser.BlobKey) error {
 	// Log failed blob
 	s.logger.Info("marking blob as failed", "blobKey", metadataKey.String())
@@ -224,6 +254,10 @@ func (s *SharedBlobStore) GetBlobMetadataByStatusWithPagination(ctx context.Cont
 	return s.blobMetadataStore.GetBlobMetadataByStatusWithPagination(ctx, blobStatus, limit, exclusiveStartKey)
 }
 
+func (s *SharedBlobStore) GetBlobMetadataByAccountWithPagination(ctx context.Context, accountID string, from, to uint64, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	return s.blobMetadataStore.GetBlobMetadataByAccountWithPagination(ctx, accountID, from, to, limit, exclusiveStartKey)
+}
+
 func (s *SharedBlobStore) GetMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
 	return s.blobMetadataStore.GetBlobMetadataInBatch(ctx, batchHeaderHash, blobIndex)
 }
@@ -232,6 +266,14 @@ func (s *SharedBlobStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHe
 	return s.blobMetadataStore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
 }
 
+func (s *SharedBlobStore) GetBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	return s.blobMetadataStore.GetBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, limit, exclusiveStartKey)
+}
+
+func (s *SharedBlobStore) GetBlobMetadataByCommitment(ctx context.Context, commitment *encoding.G1Commitment, startBatchID, endBatchID uint32) ([]*disperser.BlobMetadata, error) {
+	return s.blobMetadataStore.GetBlobMetadataByCommitment(ctx, commitment, startBatchID, endBatchID)
+}
+
 // GetMetadata returns a blob metadata given a metadata key
 func (s *SharedBlobStore) GetBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
 	return s.blobMetadataStore.GetBlobMetadata(ctx, metadataKey)
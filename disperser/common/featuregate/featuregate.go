@@ -0,0 +1,218 @@
+// Package featuregate controls the rollout of new behaviors of the disperser. Each feature is on, off, or on for a
+// percentage of the keys it is evaluated for (e.g. operators or accounts), and the gates are reloaded from their file
+// while the disperser runs, so features can be rolled out gradually and rolled back without a redeploy.
+//
+// The gates file is a JSON object mapping features to their state, one of "on", "off", or a percentage such as
+// "25%":
+//
+//	{
+//	  "compressed_chunks": "25%"
+//	}
+package featuregate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// Feature is the name of a gated behavior
+type Feature string
+
+const (
+	// CompressedChunks sends the chunks to the operators in the compressed encoding format
+	CompressedChunks Feature = "compressed_chunks"
+)
+
+// KnownFeatures are the features the disperser gates. Gates of other features are ignored.
+var KnownFeatures = map[Feature]struct{}{
+	CompressedChunks: {},
+}
+
+// percentageScale is the resolution of the percentages, in fractions of a percent
+const percentageScale = 100
+
+// gate is the state of a feature: enabled for the keys whose bucket is below threshold, out of
+// 100*percentageScale buckets
+type gate struct {
+	threshold uint64
+}
+
+// Gates are the states of the features, reloaded from the gates file
+type Gates struct {
+	mu    sync.RWMutex
+	gates map[Feature]gate
+
+	path    string
+	modTime time.Time
+	logger  logging.Logger
+}
+
+// NewGates returns the gates read from the file at path. If path is empty, every feature is off.
+func NewGates(path string, logger logging.Logger) (*Gates, error) {
+	g := &Gates{
+		gates:  make(map[Feature]gate),
+		path:   path,
+		logger: logger.With("component", "FeatureGates"),
+	}
+	if path == "" {
+		return g, nil
+	}
+	if err := g.Reload(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Enabled returns whether the feature is enabled for the key. With a percentage rollout, a key is always in the same
+// bucket for a feature, so raising the percentage only enables the feature for more keys. A nil Gates has every
+// feature off.
+func (g *Gates) Enabled(feature Feature, key []byte) bool {
+	if g == nil {
+		return false
+	}
+	g.mu.RLock()
+	featureGate, ok := g.gates[feature]
+	g.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	return bucket(feature, key) < featureGate.threshold
+}
+
+// EnabledFeatures returns the features enabled for at least some keys, sorted by name. A nil Gates has no feature
+// enabled.
+func (g *Gates) EnabledFeatures() []Feature {
+	if g == nil {
+		return nil
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	features := make([]Feature, 0, len(g.gates))
+	for feature, featureGate := range g.gates {
+		if featureGate.threshold > 0 {
+			features = append(features, feature)
+		}
+	}
+	sort.Slice(features, func(i, j int) bool { return features[i] < features[j] })
+	return features
+}
+
+// Reload reads the gates file again. If the file is invalid, the current gates are kept.
+func (g *Gates) Reload() error {
+	info, err := os.Stat(g.path)
+	if err != nil {
+		return fmt.Errorf("failed to read feature gates file: %w", err)
+	}
+	content, err := os.ReadFile(g.path)
+	if err != nil {
+		return fmt.Errorf("failed to read feature gates file: %w", err)
+	}
+	gates, err := parseGates(content)
+	if err != nil {
+		return err
+	}
+	for feature := range gates {
+		if _, ok := KnownFeatures[feature]; !ok {
+			g.logger.Warn("ignoring the gate of an unknown feature", "feature", feature)
+			delete(gates, feature)
+		}
+	}
+
+	g.mu.Lock()
+	g.gates = gates
+	g.modTime = info.ModTime()
+	g.mu.Unlock()
+	g.logger.Info("loaded feature gates", "path", g.path, "numFeatures", len(gates))
+	return nil
+}
+
+// Start reloads the gates file whenever it's modified, checking it every interval until the context is done
+func (g *Gates) Start(ctx context.Context, interval time.Duration) {
+	if g.path == "" {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				info, err := os.Stat(g.path)
+				if err != nil {
+					g.logger.Error("failed to read feature gates file, keeping the current gates", "path", g.path, "err", err)
+					continue
+				}
+				g.mu.RLock()
+				modified := !info.ModTime().Equal(g.modTime)
+				g.mu.RUnlock()
+				if !modified {
+					continue
+				}
+				if err := g.Reload(); err != nil {
+					g.logger.Error("failed to reload feature gates, keeping the current gates", "path", g.path, "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// parseGates parses the content of a gates file
+func parseGates(content []byte) (map[Feature]gate, error) {
+	var states map[Feature]string
+	if err := json.Unmarshal(content, &states); err != nil {
+		return nil, fmt.Errorf("failed to parse feature gates: %w", err)
+	}
+	gates := make(map[Feature]gate, len(states))
+	for feature, state := range states {
+		featureGate, err := parseGate(state)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gate of feature %s: %w", feature, err)
+		}
+		gates[feature] = featureGate
+	}
+	return gates, nil
+}
+
+func parseGate(state string) (gate, error) {
+	state = strings.ToLower(strings.TrimSpace(state))
+	switch state {
+	case "on":
+		return gate{threshold: 100 * percentageScale}, nil
+	case "off":
+		return gate{threshold: 0}, nil
+	}
+	if !strings.HasSuffix(state, "%") {
+		return gate{}, fmt.Errorf("state %q must be one of on, off, or a percentage", state)
+	}
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(state, "%"), 64)
+	if err != nil {
+		return gate{}, fmt.Errorf("invalid percentage %q: %w", state, err)
+	}
+	if percentage < 0 || percentage > 100 {
+		return gate{}, errors.New("percentage must be between 0 and 100")
+	}
+	return gate{threshold: uint64(percentage * percentageScale)}, nil
+}
+
+// bucket assigns a key to one of 100*percentageScale buckets. The buckets of a key are independent across features.
+func bucket(feature Feature, key []byte) uint64 {
+	hash := sha256.New()
+	hash.Write([]byte(feature))
+	hash.Write([]byte{0})
+	hash.Write(key)
+	return binary.BigEndian.Uint64(hash.Sum(nil)[:8]) % (100 * percentageScale)
+}
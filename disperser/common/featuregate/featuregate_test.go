@@ -0,0 +1,101 @@
+package featuregate_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/featuregate"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGates(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func numEnabled(gates *featuregate.Gates, numKeys int) int {
+	enabled := 0
+	for i := 0; i < numKeys; i++ {
+		if gates.Enabled(featuregate.CompressedChunks, []byte(fmt.Sprintf("operator-%d", i))) {
+			enabled++
+		}
+	}
+	return enabled
+}
+
+func TestGates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gates.json")
+	logger := logging.NewNoopLogger()
+
+	writeGates(t, path, `{"compressed_chunks": "on", "unknown_feature": "on"}`)
+	gates, err := featuregate.NewGates(path, logger)
+	require.NoError(t, err)
+	assert.Equal(t, 100, numEnabled(gates, 100))
+	assert.False(t, gates.Enabled("unknown_feature", nil))
+	assert.Equal(t, []featuregate.Feature{featuregate.CompressedChunks}, gates.EnabledFeatures())
+
+	writeGates(t, path, `{"compressed_chunks": "off"}`)
+	require.NoError(t, gates.Reload())
+	assert.Equal(t, 0, numEnabled(gates, 100))
+	assert.Empty(t, gates.EnabledFeatures())
+
+	// A percentage enables the feature for about that share of the keys, and raising it only adds keys
+	writeGates(t, path, `{"compressed_chunks": "25%"}`)
+	require.NoError(t, gates.Reload())
+	quarter := numEnabled(gates, 10000)
+	assert.InDelta(t, 2500, quarter, 250)
+	enabledKeys := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		enabledKeys[i] = gates.Enabled(featuregate.CompressedChunks, []byte(fmt.Sprintf("operator-%d", i)))
+	}
+	writeGates(t, path, `{"compressed_chunks": "50%"}`)
+	require.NoError(t, gates.Reload())
+	for i, enabled := range enabledKeys {
+		if enabled {
+			assert.True(t, gates.Enabled(featuregate.CompressedChunks, []byte(fmt.Sprintf("operator-%d", i))))
+		}
+	}
+	assert.Greater(t, numEnabled(gates, 10000), quarter)
+
+	// An invalid file keeps the current gates
+	writeGates(t, path, `{"compressed_chunks": "150%"}`)
+	assert.Error(t, gates.Reload())
+	writeGates(t, path, `{"compressed_chunks": "sometimes"}`)
+	assert.Error(t, gates.Reload())
+	assert.Greater(t, numEnabled(gates, 10000), quarter)
+}
+
+func TestGatesDisabled(t *testing.T) {
+	gates, err := featuregate.NewGates("", logging.NewNoopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, 0, numEnabled(gates, 100))
+
+	var nilGates *featuregate.Gates
+	assert.Equal(t, 0, numEnabled(nilGates, 100))
+
+	_, err = featuregate.NewGates(filepath.Join(t.TempDir(), "missing.json"), logging.NewNoopLogger())
+	assert.Error(t, err)
+}
+
+func TestGatesHotReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gates.json")
+	writeGates(t, path, `{"compressed_chunks": "off"}`)
+	gates, err := featuregate.NewGates(path, logging.NewNoopLogger())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gates.Start(ctx, 10*time.Millisecond)
+
+	writeGates(t, path, `{"compressed_chunks": "on"}`)
+	// Make sure the modification time changes even on file systems with a coarse resolution
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+	assert.Eventually(t, func() bool {
+		return numEnabled(gates, 10) == 10
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -4,19 +4,27 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 	"sort"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
 )
 
 // BlobStore is an in-memory implementation of the BlobStore interface
 type BlobStore struct {
-	mu       sync.RWMutex
-	Blobs    map[disperser.BlobHash]*BlobHolder
-	Metadata map[disperser.BlobKey]*disperser.BlobMetadata
+	mu              sync.RWMutex
+	Blobs           map[disperser.BlobHash]*BlobHolder
+	Metadata        map[disperser.BlobKey]*disperser.BlobMetadata
+	IdempotencyKeys map[string]*disperser.IdempotencyRecord
+	// Usage is the usage of the accounts by account ID and period start
+	Usage map[string]map[uint64]*disperser.AccountUsage
+	// UsageRecorded is the set of the blobs whose usage was added to Usage
+	UsageRecorded map[disperser.BlobKey]struct{}
 }
 
 // BlobHolder stores the blob along with its status and any other metadata
@@ -29,8 +37,11 @@ var _ disperser.BlobStore = (*BlobStore)(nil)
 // NewBlobStore creates an empty BlobStore
 func NewBlobStore() disperser.BlobStore {
 	return &BlobStore{
-		Blobs:    make(map[disperser.BlobHash]*BlobHolder),
-		Metadata: make(map[disperser.BlobKey]*disperser.BlobMetadata),
+		Blobs:           make(map[disperser.BlobHash]*BlobHolder),
+		Metadata:        make(map[disperser.BlobKey]*disperser.BlobMetadata),
+		IdempotencyKeys: make(map[string]*disperser.IdempotencyRecord),
+		Usage:           make(map[string]map[uint64]*disperser.AccountUsage),
+		UsageRecorded:   make(map[disperser.BlobKey]struct{}),
 	}
 }
 
@@ -60,12 +71,107 @@ func (q *BlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt
 			BlobRequestHeader: blob.RequestHeader,
 			BlobSize:          uint(len(blob.Data)),
 			RequestedAt:       requestedAt,
+			PayloadHash:       disperser.ComputePayloadHash(blob.Data),
 		},
 	}
 
 	return blobKey, nil
 }
 
+func (q *BlobStore) ReserveIdempotencyKey(ctx context.Context, key string, payloadHash []byte, expiry uint64) (*disperser.IdempotencyRecord, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if record, ok := q.IdempotencyKeys[key]; ok && record.Expiry >= uint64(time.Now().Unix()) {
+		copied := *record
+		return &copied, nil
+	}
+	q.IdempotencyKeys[key] = &disperser.IdempotencyRecord{
+		PayloadHash: payloadHash,
+		Expiry:      expiry,
+	}
+	return nil, nil
+}
+
+func (q *BlobStore) SetIdempotencyKeyBlob(ctx context.Context, key string, blobKey disperser.BlobKey, expiry uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	record, ok := q.IdempotencyKeys[key]
+	if !ok {
+		return fmt.Errorf("idempotency key %s is not reserved", key)
+	}
+	record.BlobKey = &blobKey
+	record.Expiry = expiry
+	return nil
+}
+
+func (q *BlobStore) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.IdempotencyKeys, key)
+	return nil
+}
+
+func (q *BlobStore) AddAccountUsage(ctx context.Context, blobKey disperser.BlobKey, usage *disperser.AccountUsage) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.UsageRecorded[blobKey]; ok {
+		return nil
+	}
+	q.UsageRecorded[blobKey] = struct{}{}
+
+	periods, ok := q.Usage[usage.AccountID]
+	if !ok {
+		periods = make(map[uint64]*disperser.AccountUsage)
+		q.Usage[usage.AccountID] = periods
+	}
+	existing, ok := periods[usage.PeriodStart]
+	if !ok {
+		existing = &disperser.AccountUsage{
+			AccountID:   usage.AccountID,
+			PeriodStart: usage.PeriodStart,
+		}
+		periods[usage.PeriodStart] = existing
+	}
+	existing.NumBlobs += usage.NumBlobs
+	existing.BlobBytes += usage.BlobBytes
+	existing.DispersalBytes += usage.DispersalBytes
+	existing.EncodingTime += usage.EncodingTime
+	existing.GasFee += usage.GasFee
+	return nil
+}
+
+func (q *BlobStore) GetAccountUsage(ctx context.Context, accountID string, from, to uint64) ([]*disperser.AccountUsage, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	usages := make([]*disperser.AccountUsage, 0)
+	for periodStart, usage := range q.Usage[accountID] {
+		if periodStart >= from && periodStart < to {
+			copied := *usage
+			usages = append(usages, &copied)
+		}
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].PeriodStart < usages[j].PeriodStart
+	})
+	return usages, nil
+}
+
+func (q *BlobStore) MarkAccountUsageSettled(ctx context.Context, accountID string, periodStart uint64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	usage, ok := q.Usage[accountID][periodStart]
+	if !ok {
+		return fmt.Errorf("no usage of account %s in period %d", accountID, periodStart)
+	}
+	usage.Settled = true
+	return nil
+}
+
 func (q *BlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) ([]byte, error) {
 	q.mu.RLock()
 	defer q.mu.RUnlock()
@@ -145,6 +251,24 @@ func (q *BlobStore) MarkBlobProcessing(ctx context.Context, blobKey disperser.Bl
 	return nil
 }
 
+func (q *BlobStore) MarkBlobReorged(ctx context.Context, existingMetadata *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	blobKey := existingMetadata.GetBlobKey()
+	metadata, ok := q.Metadata[blobKey]
+	if !ok {
+		return nil, disperser.ErrBlobNotFound
+	}
+	if metadata.BlobStatus != disperser.Confirmed {
+		return nil, fmt.Errorf("blob %s is not confirmed", blobKey.String())
+	}
+	newMetadata := *existingMetadata
+	newMetadata.BlobStatus = disperser.Processing
+	newMetadata.ConfirmationInfo = nil
+	q.Metadata[blobKey] = &newMetadata
+	return &newMetadata, nil
+}
+
 func (q *BlobStore) MarkBlobFailed(ctx context.Context, blobKey disperser.BlobKey) error {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -239,6 +363,61 @@ func (q *BlobStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, s
 	return metas, nil, nil
 }
 
+func (q *BlobStore) GetBlobMetadataByAccountWithPagination(ctx context.Context, accountID string, from, to uint64, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if from >= to {
+		return nil, nil, fmt.Errorf("invalid time range [%d, %d)", from, to)
+	}
+
+	metas := make([]*disperser.BlobMetadata, 0)
+	for _, meta := range q.Metadata {
+		if meta.RequestMetadata == nil || meta.RequestMetadata.AccountID == "" || disperser.TenantAccountKey(meta.RequestMetadata.TenantID, meta.RequestMetadata.AccountID) != accountID {
+			continue
+		}
+		if meta.RequestMetadata.RequestedAt < from || meta.RequestMetadata.RequestedAt >= to {
+			continue
+		}
+		metas = append(metas, meta)
+	}
+	sort.Slice(metas, func(i, j int) bool {
+		return accountBlobBefore(metas[i], metas[j].RequestMetadata.RequestedAt, metas[j].BlobHash, metas[j].MetadataHash)
+	})
+
+	if exclusiveStartKey != nil {
+		start := sort.Search(len(metas), func(i int) bool {
+			return !accountBlobBefore(metas[i], uint64(exclusiveStartKey.RequestedAt), exclusiveStartKey.BlobHash, exclusiveStartKey.MetadataHash)
+		})
+		if start < len(metas) && metas[start].BlobHash == exclusiveStartKey.BlobHash && metas[start].MetadataHash == exclusiveStartKey.MetadataHash {
+			start++
+		}
+		metas = metas[start:]
+	}
+
+	if limit <= 0 || len(metas) <= int(limit) {
+		return metas, nil, nil
+	}
+	metas = metas[:limit]
+	last := metas[len(metas)-1]
+	return metas, &disperser.BlobStoreExclusiveStartKey{
+		BlobHash:     last.BlobHash,
+		MetadataHash: last.MetadataHash,
+		RequestedAt:  int64(last.RequestMetadata.RequestedAt),
+		AccountID:    accountID,
+	}, nil
+}
+
+// accountBlobBefore orders the blobs of an account by request time, breaking ties by key
+func accountBlobBefore(meta *disperser.BlobMetadata, requestedAt uint64, blobHash disperser.BlobHash, metadataHash disperser.MetadataHash) bool {
+	if meta.RequestMetadata.RequestedAt != requestedAt {
+		return meta.RequestMetadata.RequestedAt < requestedAt
+	}
+	if meta.BlobHash != blobHash {
+		return meta.BlobHash < blobHash
+	}
+	return meta.MetadataHash < metadataHash
+}
+
 func (q *BlobStore) GetMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
 	q.mu.RLock()
 	defer q.mu.RUnlock()
@@ -263,6 +442,61 @@ func (q *BlobStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHa
 	return metas, nil
 }
 
+func (q *BlobStore) GetBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	metas := make([]*disperser.BlobMetadata, 0)
+	for _, meta := range q.Metadata {
+		if meta.ConfirmationInfo == nil || meta.ConfirmationInfo.BatchHeaderHash != batchHeaderHash {
+			continue
+		}
+		if exclusiveStartKey != nil && meta.ConfirmationInfo.BlobIndex <= exclusiveStartKey.BlobIndex {
+			continue
+		}
+		metas = append(metas, meta)
+	}
+	sort.Slice(metas, func(i, j int) bool {
+		return metas[i].ConfirmationInfo.BlobIndex < metas[j].ConfirmationInfo.BlobIndex
+	})
+
+	if limit <= 0 || len(metas) <= int(limit) {
+		return metas, nil, nil
+	}
+	metas = metas[:limit]
+	last := metas[len(metas)-1]
+	return metas, &disperser.BlobStoreExclusiveStartKey{
+		BlobHash:     last.BlobHash,
+		MetadataHash: last.MetadataHash,
+		BlobIndex:    last.ConfirmationInfo.BlobIndex,
+	}, nil
+}
+
+func (q *BlobStore) GetBlobMetadataByCommitment(ctx context.Context, commitment *encoding.G1Commitment, startBatchID, endBatchID uint32) ([]*disperser.BlobMetadata, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if startBatchID > endBatchID {
+		return nil, fmt.Errorf("invalid batch range [%d, %d]", startBatchID, endBatchID)
+	}
+	key := disperser.GenerateCommitmentIndexKey(commitment)
+	metas := make([]*disperser.BlobMetadata, 0)
+	for _, meta := range q.Metadata {
+		if meta.BlobStatus != disperser.Confirmed && meta.BlobStatus != disperser.Finalized {
+			continue
+		}
+		info := meta.ConfirmationInfo
+		if info == nil || info.BlobCommitment == nil || info.BlobCommitment.Commitment == nil {
+			continue
+		}
+		if info.BatchID < startBatchID || info.BatchID > endBatchID {
+			continue
+		}
+		if disperser.GenerateCommitmentIndexKey(info.BlobCommitment.Commitment) == key {
+			metas = append(metas, meta)
+		}
+	}
+	return metas, nil
+}
+
 func (q *BlobStore) GetBlobMetadata(ctx context.Context, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
 	if meta, ok := q.Metadata[blobKey]; ok {
 		return meta, nil
@@ -2,6 +2,7 @@ package inmem_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -58,11 +60,14 @@ func TestBlobStore(t *testing.T) {
 	meta2, err := bs.GetBlobMetadata(ctx, blobKey2)
 	assert.Nil(t, err)
 	assert.Equal(t, meta2.BlobStatus, disperser.Processing)
+	assert.Equal(t, disperser.ComputePayloadHash(blobs[blobKey2].Data), meta2.RequestMetadata.PayloadHash)
 
 	batchHeaderHash := [32]byte{1, 2, 3}
 	blobIndex := uint32(0)
 	sigRecordHash := [32]byte{0}
 	inclusionProof := []byte{1, 2, 3, 4, 5}
+	_, _, g1Gen, _ := bn254.Generators()
+	commitment := encoding.G1Commitment(g1Gen)
 
 	confirmationInfo := &disperser.ConfirmationInfo{
 		BatchHeaderHash:         batchHeaderHash,
@@ -72,7 +77,7 @@ func TestBlobStore(t *testing.T) {
 		ReferenceBlockNumber:    132,
 		BatchRoot:               []byte("hello"),
 		BlobInclusionProof:      inclusionProof,
-		BlobCommitment:          &encoding.BlobCommitments{},
+		BlobCommitment:          &encoding.BlobCommitments{Commitment: &commitment},
 		BatchID:                 99,
 		ConfirmationTxnHash:     common.HexToHash("0x123"),
 		ConfirmationBlockNumber: uint32(150),
@@ -114,4 +119,206 @@ func TestBlobStore(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(allMeta))
 	assert.Equal(t, allMeta[0].BlobStatus, disperser.Confirmed)
+
+	byCommitment, err := bs.GetBlobMetadataByCommitment(ctx, &commitment, 90, 99)
+	assert.Nil(t, err)
+	assert.Len(t, byCommitment, 1)
+	assert.Equal(t, blobKey2, byCommitment[0].GetBlobKey())
+
+	byCommitment, err = bs.GetBlobMetadataByCommitment(ctx, &commitment, 100, 200)
+	assert.Nil(t, err)
+	assert.Len(t, byCommitment, 0)
+
+	var otherCommitment encoding.G1Commitment
+	(*bn254.G1Affine)(&otherCommitment).Double(&g1Gen)
+	byCommitment, err = bs.GetBlobMetadataByCommitment(ctx, &otherCommitment, 0, 200)
+	assert.Nil(t, err)
+	assert.Len(t, byCommitment, 0)
+
+	_, err = bs.GetBlobMetadataByCommitment(ctx, &commitment, 99, 90)
+	assert.NotNil(t, err)
+}
+
+func TestBlobStoreIdempotencyKeys(t *testing.T) {
+	bs := inmem.NewBlobStore()
+	ctx := context.Background()
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+	payloadHash := []byte{1, 2, 3}
+
+	record, err := bs.ReserveIdempotencyKey(ctx, "key", payloadHash, expiry)
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+
+	record, err = bs.ReserveIdempotencyKey(ctx, "key", []byte{4}, expiry)
+	assert.Nil(t, err)
+	assert.Equal(t, payloadHash, record.PayloadHash)
+	assert.Nil(t, record.BlobKey)
+
+	blobKey := disperser.BlobKey{BlobHash: "blob", MetadataHash: "hash"}
+	assert.Nil(t, bs.SetIdempotencyKeyBlob(ctx, "key", blobKey, expiry))
+	record, err = bs.ReserveIdempotencyKey(ctx, "key", payloadHash, expiry)
+	assert.Nil(t, err)
+	assert.Equal(t, &blobKey, record.BlobKey)
+
+	// Released and expired keys can be reserved again
+	assert.Nil(t, bs.ReleaseIdempotencyKey(ctx, "key"))
+	record, err = bs.ReserveIdempotencyKey(ctx, "key", payloadHash, uint64(time.Now().Add(-time.Minute).Unix()))
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+	record, err = bs.ReserveIdempotencyKey(ctx, "key", payloadHash, expiry)
+	assert.Nil(t, err)
+	assert.Nil(t, record)
+}
+
+type recordingSettler struct {
+	settled []*disperser.AccountUsage
+}
+
+func (s *recordingSettler) SettleUsage(ctx context.Context, usage *disperser.AccountUsage) error {
+	s.settled = append(s.settled, usage)
+	return nil
+}
+
+func TestBlobStoreAccountUsage(t *testing.T) {
+	bs := inmem.NewBlobStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	metadata := &disperser.BlobMetadata{
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: core.BlobRequestHeader{BlobAuthHeader: core.BlobAuthHeader{AccountID: "account"}},
+			BlobSize:          100,
+		},
+	}
+	cost := &disperser.BlobCost{EncodingTime: time.Second, DispersalBytes: 800, GasFee: 5}
+	for i, at := range []time.Time{now.Add(-2 * disperser.UsagePeriod), now.Add(-2 * disperser.UsagePeriod), now} {
+		blobKey := disperser.BlobKey{BlobHash: fmt.Sprintf("blob%d", i), MetadataHash: "hash"}
+		assert.NoError(t, bs.AddAccountUsage(ctx, blobKey, disperser.BlobUsage(metadata, cost, at)))
+	}
+	// The usage of a blob confirmed again after a reorg isn't added twice
+	assert.NoError(t, bs.AddAccountUsage(ctx, disperser.BlobKey{BlobHash: "blob2", MetadataHash: "hash"}, disperser.BlobUsage(metadata, cost, now)))
+	// The accounts of the tenants are billed apart
+	tenantMetadata := &disperser.BlobMetadata{
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: core.BlobRequestHeader{BlobAuthHeader: core.BlobAuthHeader{AccountID: "account"}, TenantID: "tenant"},
+			BlobSize:          100,
+		},
+	}
+	tenantUsage := disperser.BlobUsage(tenantMetadata, cost, now)
+	assert.Equal(t, "tenant/account", tenantUsage.AccountID)
+	assert.NoError(t, bs.AddAccountUsage(ctx, disperser.BlobKey{BlobHash: "tenant-blob", MetadataHash: "hash"}, tenantUsage))
+	// Blobs without an account aren't accounted
+	assert.Nil(t, disperser.BlobUsage(&disperser.BlobMetadata{RequestMetadata: &disperser.RequestMetadata{}}, cost, now))
+
+	usages, err := bs.GetAccountUsage(ctx, "account", 0, uint64(now.Unix())+1)
+	assert.NoError(t, err)
+	assert.Len(t, usages, 2)
+	assert.Equal(t, disperser.UsagePeriodStart(now.Add(-2*disperser.UsagePeriod)), usages[0].PeriodStart)
+	assert.Equal(t, uint64(2), usages[0].NumBlobs)
+	assert.Equal(t, uint64(200), usages[0].BlobBytes)
+	assert.Equal(t, uint64(1600), usages[0].DispersalBytes)
+	assert.Equal(t, 2*time.Second, usages[0].EncodingTime)
+	assert.Equal(t, uint64(10), usages[0].GasFee)
+	assert.Equal(t, uint64(1), usages[1].NumBlobs)
+
+	// Only the usage of the periods which ended is settled, once
+	settler := &recordingSettler{}
+	settled, err := disperser.SettleAccountUsage(ctx, bs, settler, "account", now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, settled)
+	assert.Len(t, settler.settled, 1)
+	assert.Equal(t, usages[0].PeriodStart, settler.settled[0].PeriodStart)
+	settled, err = disperser.SettleAccountUsage(ctx, bs, settler, "account", now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, settled)
+
+	usages, err = bs.GetAccountUsage(ctx, "account", 0, uint64(now.Unix())+1)
+	assert.NoError(t, err)
+	assert.True(t, usages[0].Settled)
+	assert.False(t, usages[1].Settled)
+}
+
+func TestBlobStoreGetBlobMetadataByAccount(t *testing.T) {
+	bs := inmem.NewBlobStore()
+	ctx := context.Background()
+
+	storeBlob := func(accountID string, data byte, requestedAt uint64) disperser.BlobKey {
+		blobKey, err := bs.StoreBlob(ctx, &core.Blob{
+			RequestHeader: core.BlobRequestHeader{
+				BlobAuthHeader: core.BlobAuthHeader{AccountID: accountID},
+				SecurityParams: []*core.SecurityParam{},
+			},
+			Data: []byte{data},
+		}, requestedAt)
+		assert.Nil(t, err)
+		return blobKey
+	}
+	keys := make([]disperser.BlobKey, 0)
+	for i := 0; i < 5; i++ {
+		keys = append(keys, storeBlob("account", byte(i), uint64(100+i)))
+	}
+	storeBlob("other", 10, 101)
+
+	// The blobs requested in [101, 104) are returned a page at a time, in the order they were requested
+	metas, startKey, err := bs.GetBlobMetadataByAccountWithPagination(ctx, "account", 101, 104, 2, nil)
+	assert.Nil(t, err)
+	assert.Len(t, metas, 2)
+	assert.Equal(t, keys[1], metas[0].GetBlobKey())
+	assert.Equal(t, keys[2], metas[1].GetBlobKey())
+	assert.NotNil(t, startKey)
+
+	metas, startKey, err = bs.GetBlobMetadataByAccountWithPagination(ctx, "account", 101, 104, 2, startKey)
+	assert.Nil(t, err)
+	assert.Len(t, metas, 1)
+	assert.Equal(t, keys[3], metas[0].GetBlobKey())
+	assert.Nil(t, startKey)
+
+	metas, startKey, err = bs.GetBlobMetadataByAccountWithPagination(ctx, "unknown", 0, 1000, 2, nil)
+	assert.Nil(t, err)
+	assert.Len(t, metas, 0)
+	assert.Nil(t, startKey)
+
+	_, _, err = bs.GetBlobMetadataByAccountWithPagination(ctx, "account", 104, 101, 2, nil)
+	assert.NotNil(t, err)
+}
+
+func TestBlobStoreGetBlobMetadataByBatch(t *testing.T) {
+	bs := inmem.NewBlobStore()
+	ctx := context.Background()
+
+	batchHeaderHash := [32]byte{1, 2, 3}
+	for i := 0; i < 3; i++ {
+		blobKey, err := bs.StoreBlob(ctx, &core.Blob{
+			RequestHeader: core.BlobRequestHeader{SecurityParams: []*core.SecurityParam{}},
+			Data:          []byte{byte(i)},
+		}, uint64(100+i))
+		assert.Nil(t, err)
+		metadata, err := bs.GetBlobMetadata(ctx, blobKey)
+		assert.Nil(t, err)
+		// The blobs are confirmed in the reverse order of their requests
+		_, err = bs.MarkBlobConfirmed(ctx, metadata, &disperser.ConfirmationInfo{
+			BatchHeaderHash: batchHeaderHash,
+			BlobIndex:       uint32(2 - i),
+		})
+		assert.Nil(t, err)
+	}
+
+	// The blobs are returned a page at a time, ordered by blob index
+	metas, startKey, err := bs.GetBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, 2, nil)
+	assert.Nil(t, err)
+	assert.Len(t, metas, 2)
+	assert.Equal(t, uint32(0), metas[0].ConfirmationInfo.BlobIndex)
+	assert.Equal(t, uint32(1), metas[1].ConfirmationInfo.BlobIndex)
+	assert.NotNil(t, startKey)
+
+	metas, startKey, err = bs.GetBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, 2, startKey)
+	assert.Nil(t, err)
+	assert.Len(t, metas, 1)
+	assert.Equal(t, uint32(2), metas[0].ConfirmationInfo.BlobIndex)
+	assert.Nil(t, startKey)
+
+	metas, startKey, err = bs.GetBlobMetadataByBatchWithPagination(ctx, [32]byte{4}, 2, nil)
+	assert.Nil(t, err)
+	assert.Len(t, metas, 0)
+	assert.Nil(t, startKey)
 }
@@ -0,0 +1,43 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/hex"
+)
+
+func (s *server) getBatches(ctx context.Context, cursor *Cursor, limit int) ([]*BatchResponse, *Cursor, error) {
+	startBatchId := uint64(0)
+	if cursor != nil {
+		startBatchId = uint64(cursor.BatchId) + 1
+	}
+	batches, err := s.subgraphClient.QueryBatchesFromBatchId(ctx, startBatchId, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := make([]*BatchResponse, 0, len(batches))
+	for _, batch := range batches {
+		batchHeaderHash, err := ConvertHexadecimalToBytes(batch.BatchHeaderHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		response := &BatchResponse{
+			BatchId:             uint32(batch.BatchId),
+			BatchHeaderHash:     hex.EncodeToString(batchHeaderHash[:]),
+			BlockNumber:         batch.BlockNumber,
+			BlockTimestamp:      batch.BlockTimestamp,
+			ConfirmationTxnHash: string(batch.TxHash),
+		}
+		if batch.GasFees != nil {
+			response.GasUsed = batch.GasFees.GasUsed
+			response.GasPrice = batch.GasFees.GasPrice
+			response.TxFee = batch.GasFees.TxFee
+		}
+		responses = append(responses, response)
+	}
+
+	if len(responses) == 0 {
+		return responses, nil, nil
+	}
+	return responses, NewBatchCursor(responses[len(responses)-1].BatchId), nil
+}
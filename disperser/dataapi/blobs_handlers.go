@@ -3,11 +3,17 @@ package dataapi
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"sort"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
 )
 
+// maxQueryPaginatedBatchesLimit is the number of batches queried at a time from the subgraph to list blobs
+const maxQueryPaginatedBatchesLimit = 10
+
 func (s *server) getBlob(ctx context.Context, key string) (*BlobMetadataResponse, error) {
 	s.logger.Info("Calling get blob", "key", key)
 	blobKey, err := disperser.ParseBlobKey(string(key))
@@ -35,6 +41,131 @@ func (s *server) getBlobs(ctx context.Context, limit int) ([]*BlobMetadataRespon
 	return s.convertBlobMetadatasToBlobMetadataResponse(ctx, blobMetadatas)
 }
 
+// getBlobsAfter lists the blobs confirmed after the cursor, in confirmation order.
+func (s *server) getBlobsAfter(ctx context.Context, cursor *Cursor, limit int) ([]*BlobMetadataResponse, *Cursor, error) {
+	startBatchId := uint64(0)
+	if cursor != nil {
+		startBatchId = uint64(cursor.BatchId)
+	}
+
+	blobMetadatas := make([]*disperser.BlobMetadata, 0, limit)
+	var next *Cursor
+	for len(blobMetadatas) < limit {
+		batches, err := s.subgraphClient.QueryBatchesFromBatchId(ctx, startBatchId, maxQueryPaginatedBatchesLimit)
+		if err != nil {
+			s.logger.Error("Failed to query batches", "error", err)
+			return nil, nil, err
+		}
+		if len(batches) == 0 {
+			break
+		}
+
+		for _, batch := range batches {
+			batchHeaderHash, err := ConvertHexadecimalToBytes(batch.BatchHeaderHash)
+			if err != nil {
+				return nil, nil, err
+			}
+			metadatas, err := s.blobstore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+			if err != nil {
+				return nil, nil, err
+			}
+			sort.Slice(metadatas, func(i, j int) bool {
+				return metadatas[i].ConfirmationInfo.BlobIndex < metadatas[j].ConfirmationInfo.BlobIndex
+			})
+
+			batchId := uint32(batch.BatchId)
+			for _, metadata := range metadatas {
+				blobIndex := metadata.ConfirmationInfo.BlobIndex
+				if cursor != nil && batchId == cursor.BatchId && blobIndex <= cursor.BlobIndex {
+					continue
+				}
+				blobMetadatas = append(blobMetadatas, metadata)
+				next = NewBlobCursor(batchId, blobIndex)
+				if len(blobMetadatas) == limit {
+					break
+				}
+			}
+			if len(blobMetadatas) == limit {
+				break
+			}
+		}
+		startBatchId = batches[len(batches)-1].BatchId + 1
+	}
+
+	responses, err := s.convertBlobMetadatasToBlobMetadataResponse(ctx, blobMetadatas)
+	if err != nil {
+		return nil, nil, err
+	}
+	return responses, next, nil
+}
+
+// getBlobExistence looks up the blobs with the commitment confirmed in the batch range, which is clamped to the last
+// confirmed batch so that the negative statements never cover batches that could still be confirmed.
+func (s *server) getBlobExistence(ctx context.Context, commitment *encoding.G1Commitment, startBatchID, endBatchID uint32) (*BlobExistenceResponse, error) {
+	lastBatchID, err := s.getLastConfirmedBatchID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if startBatchID > lastBatchID {
+		return nil, fmt.Errorf("%w: the last confirmed batch is %d", errBatchNotConfirmed, lastBatchID)
+	}
+	endBatchID = min(endBatchID, lastBatchID)
+
+	metadatas, err := s.blobstore.GetBlobMetadataByCommitment(ctx, commitment, startBatchID, endBatchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(metadatas) > 0 {
+		blobs, err := s.convertBlobMetadatasToBlobMetadataResponse(ctx, metadatas)
+		if err != nil {
+			return nil, err
+		}
+		return &BlobExistenceResponse{
+			Found: true,
+			Blobs: blobs,
+		}, nil
+	}
+
+	if s.nonInclusionSigner == nil {
+		return nil, errNonInclusionSignerNotConfigured
+	}
+	now := time.Now()
+	statement := &NonInclusionStatement{
+		BlobCommitment: commitment,
+		StartBatchID:   startBatchID,
+		EndBatchID:     endBatchID,
+		Timestamp:      uint64(now.Unix()),
+	}
+	if s.blobMetadataRetention > 0 {
+		statement.RetainedSince = uint64(now.Add(-s.blobMetadataRetention).Unix())
+	}
+	attestation, err := statement.Sign(s.nonInclusionSigner)
+	if err != nil {
+		return nil, err
+	}
+	return &BlobExistenceResponse{
+		Found:        false,
+		NonInclusion: attestation,
+	}, nil
+}
+
+// getLastConfirmedBatchID returns the ID of the last batch confirmed onchain, as indexed by the subgraph
+func (s *server) getLastConfirmedBatchID(ctx context.Context) (uint32, error) {
+	batches, err := s.subgraphClient.QueryBatchesWithLimit(ctx, 1, 0)
+	if err != nil {
+		return 0, err
+	}
+	if len(batches) == 0 {
+		return 0, fmt.Errorf("%w: no batch is confirmed", errBatchNotConfirmed)
+	}
+	var lastBatchID uint32
+	for _, batch := range batches {
+		lastBatchID = max(lastBatchID, uint32(batch.BatchId))
+	}
+	return lastBatchID, nil
+}
+
 func (s *server) convertBlobMetadatasToBlobMetadataResponse(ctx context.Context, metadatas []*disperser.BlobMetadata) ([]*BlobMetadataResponse, error) {
 	var (
 		err               error
@@ -94,5 +225,6 @@ func convertMetadataToBlobMetadataResponse(metadata *disperser.BlobMetadata) (*B
 		SecurityParams:          metadata.RequestMetadata.SecurityParams,
 		RequestAt:               ConvertNanosecondToSecond(metadata.RequestMetadata.RequestedAt),
 		BlobStatus:              metadata.BlobStatus,
+		Cost:                    metadata.ConfirmationInfo.Cost,
 	}, nil
 }
@@ -1,5 +1,12 @@
 package dataapi
 
+import (
+	"crypto/ecdsa"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+)
+
 type Config struct {
 	SocketAddr         string
 	ServerMode         string
@@ -8,4 +15,18 @@ type Config struct {
 	ChurnerHostname    string
 	BatcherHealthEndpt string
 	EjectionToken      string
+	// NonInclusionSigner signs the statements returned when a blob is not found in the queried batch range.
+	// Non-inclusion queries fail if it is not set.
+	NonInclusionSigner *ecdsa.PrivateKey
+	// MetadataRetention is how long the disperser retains the metadata of the blobs after they are requested,
+	// which bounds what the non-inclusion statements cover. The metadata is retained forever if it is 0.
+	MetadataRetention time.Duration
+	// ExportStorage is the object storage the export jobs write to, in the ExportBucketName bucket.
+	// Export jobs can't be requested if it is not set.
+	ExportStorage    s3.Client
+	ExportBucketName string
+	// ExportToken authenticates the requests to the export endpoints.
+	ExportToken string
+	// AccountingToken authenticates the requests to the accounting endpoints, which are disabled if it is not set.
+	AccountingToken string
 }
@@ -15,6 +15,77 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/accounts/{account_id}/usage": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Accounts"
+                ],
+                "summary": "Fetch the usage of an account, per usage period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Accounting token",
+                        "name": "accounting_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account ID",
+                        "name": "account_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant ID of the account [default: the default tenant]",
+                        "name": "tenant_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start unix timestamp of the first usage period [default: 1 day ago]",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End unix timestamp, exclusive [default: unix time now]",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.AccountUsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error: Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/ejector/operators": {
             "post": {
                 "produces": [
@@ -69,8 +140,290 @@ const docTemplate = `{
                 }
             }
         },
+        "/exports": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exports"
+                ],
+                "summary": "Fetch the status of the recent export jobs, most recent first",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export token",
+                        "name": "export_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ExportJobsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error: Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "error: Exports are not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The export runs in the background. Its status is returned by /exports/{job_id}, and the exported file is written to the object key of the job once it succeeds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exports"
+                ],
+                "summary": "Request the export of a dataset over a time range to object storage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export token",
+                        "name": "export_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Dataset (blobs, batches or operators_nonsigning), format (csv or parquet) and time range in unix seconds, of at most 31 days",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ExportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ExportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "error: Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "error: Too many requests",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "error: Exports are not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/{job_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exports"
+                ],
+                "summary": "Fetch the status of an export job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export token",
+                        "name": "export_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export job ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ExportJob"
+                        }
+                    },
+                    "401": {
+                        "description": "error: Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "error: Exports are not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/batches": {
+            "get": {
+                "description": "Returns the batches confirmed after the cursor in ascending order of batch ID, starting from the first batch if the cursor is empty or not set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Fetch confirmed batches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit [default: 10]",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the last batch of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.BatchesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed/blob-existence": {
+            "get": {
+                "description": "Returns the confirmed blobs if found, or a statement signed by the data API that no such blob was confirmed otherwise.\nThe range is clamped to the last confirmed batch, and rejected if it starts after it. The statement only covers the blobs requested since its retained_since time, whose metadata is retained by the disperser.\nThe existence queries are only served by the data API, the retriever has no index of the confirmed blobs to answer them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feed"
+                ],
+                "summary": "Check whether a blob with the given commitment was confirmed in a batch range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hex encoded compressed blob commitment",
+                        "name": "commitment",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First batch ID of the range (inclusive)",
+                        "name": "start_batch_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last batch ID of the range (inclusive)",
+                        "name": "end_batch_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.BlobExistenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "error: Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "error: Not found",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "error: Server error",
+                        "schema": {
+                            "$ref": "#/definitions/dataapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/feed/blobs": {
             "get": {
+                "description": "Without the cursor parameter, returns the latest blobs. With the cursor parameter, returns the blobs\nconfirmed after the cursor in confirmation order, starting from the first blob if the cursor is empty.",
                 "produces": [
                     "application/json"
                 ],
@@ -84,6 +437,12 @@ const docTemplate = `{
                         "description": "Limit [default: 10]",
                         "name": "limit",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the last blob of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -403,6 +762,18 @@ const docTemplate = `{
                         "description": "Whether return only live nonsigners [default: true]",
                         "name": "live_only",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit, if the cursor is set [default: 10]",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "If set, returns the operators after the cursor in ascending order of operator and quorum ID, starting from the first operator if the cursor is empty. The end time should be set so that the pages are consistent.",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -595,6 +966,86 @@ const docTemplate = `{
                 }
             }
         },
+        "dataapi.AccountUsageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/disperser.AccountUsage"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
+                }
+            }
+        },
+        "dataapi.BatchResponse": {
+            "type": "object",
+            "properties": {
+                "batch_header_hash": {
+                    "type": "string"
+                },
+                "batch_id": {
+                    "type": "integer"
+                },
+                "block_number": {
+                    "type": "integer"
+                },
+                "block_timestamp": {
+                    "type": "integer"
+                },
+                "confirmation_txn_hash": {
+                    "type": "string"
+                },
+                "gas_price": {
+                    "type": "integer"
+                },
+                "gas_used": {
+                    "type": "integer"
+                },
+                "tx_fee": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dataapi.BatchesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BatchResponse"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
+                }
+            }
+        },
+        "dataapi.BlobExistenceResponse": {
+            "type": "object",
+            "properties": {
+                "blobs": {
+                    "description": "Blobs are the confirmed blobs with the commitment in the batch range, set if found",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.BlobMetadataResponse"
+                    }
+                },
+                "found": {
+                    "type": "boolean"
+                },
+                "non_inclusion": {
+                    "description": "NonInclusion is the signed negative statement, set if not found",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dataapi.NonInclusionAttestation"
+                        }
+                    ]
+                }
+            }
+        },
         "dataapi.BlobMetadataResponse": {
             "type": "object",
             "properties": {
@@ -628,6 +1079,9 @@ const docTemplate = `{
                 "confirmation_txn_hash": {
                     "type": "string"
                 },
+                "cost": {
+                    "$ref": "#/definitions/disperser.BlobCost"
+                },
                 "fee": {
                     "type": "string"
                 },
@@ -704,9 +1158,124 @@ const docTemplate = `{
                 }
             }
         },
+        "dataapi.ExportDataset": {
+            "type": "string",
+            "enum": [
+                "blobs",
+                "batches",
+                "operators_nonsigning"
+            ],
+            "x-enum-varnames": [
+                "BlobsExport",
+                "BatchesExport",
+                "OperatorsNonsigningExport"
+            ]
+        },
+        "dataapi.ExportFormat": {
+            "type": "string",
+            "enum": [
+                "csv",
+                "parquet"
+            ],
+            "x-enum-varnames": [
+                "CSVExport",
+                "ParquetExport"
+            ]
+        },
+        "dataapi.ExportJob": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "dataset": {
+                    "$ref": "#/definitions/dataapi.ExportDataset"
+                },
+                "end": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "format": {
+                    "$ref": "#/definitions/dataapi.ExportFormat"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "num_rows": {
+                    "type": "integer"
+                },
+                "object_key": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/dataapi.ExportJobStatus"
+                }
+            }
+        },
+        "dataapi.ExportJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ExportPending",
+                "ExportRunning",
+                "ExportSucceeded",
+                "ExportFailed"
+            ]
+        },
+        "dataapi.ExportJobsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dataapi.ExportJob"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/dataapi.Meta"
+                }
+            }
+        },
+        "dataapi.ExportRequest": {
+            "type": "object",
+            "properties": {
+                "dataset": {
+                    "$ref": "#/definitions/dataapi.ExportDataset"
+                },
+                "end": {
+                    "type": "integer"
+                },
+                "format": {
+                    "$ref": "#/definitions/dataapi.ExportFormat"
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        },
         "dataapi.Meta": {
             "type": "object",
             "properties": {
+                "next_cursor": {
+                    "description": "NextCursor is the cursor of the last item of a page of a paginated listing, empty if the page is empty",
+                    "type": "string"
+                },
                 "size": {
                     "type": "integer"
                 }
@@ -737,6 +1306,36 @@ const docTemplate = `{
                 }
             }
         },
+        "dataapi.NonInclusionAttestation": {
+            "type": "object",
+            "properties": {
+                "blob_commitment": {
+                    "description": "BlobCommitment is the hex encoded compressed commitment",
+                    "type": "string"
+                },
+                "end_batch_id": {
+                    "type": "integer"
+                },
+                "retained_since": {
+                    "type": "integer"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "signer": {
+                    "type": "string"
+                },
+                "start_batch_id": {
+                    "type": "integer"
+                },
+                "statement_hash": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
         "dataapi.NonSigner": {
             "type": "object",
             "properties": {
@@ -844,6 +1443,71 @@ const docTemplate = `{
                 }
             }
         },
+        "disperser.AccountUsage": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "type": "string"
+                },
+                "blob_bytes": {
+                    "description": "BlobBytes is the total size of the blobs",
+                    "type": "integer"
+                },
+                "dispersal_bytes": {
+                    "description": "DispersalBytes is the total size of the chunks of the blobs sent to the operators",
+                    "type": "integer"
+                },
+                "encoding_time": {
+                    "description": "EncodingTime is the total time spent encoding the blobs",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "gas_fee": {
+                    "description": "GasFee is the total share of the fees of the confirmation transactions attributed to the blobs, in wei",
+                    "type": "integer"
+                },
+                "num_blobs": {
+                    "description": "NumBlobs is the number of blobs confirmed for the account in the period",
+                    "type": "integer"
+                },
+                "period_start": {
+                    "description": "PeriodStart is the unix time in seconds at which the usage period starts",
+                    "type": "integer"
+                },
+                "settled": {
+                    "description": "Settled is whether the usage was settled with the account, see UsageSettler",
+                    "type": "boolean"
+                }
+            }
+        },
+        "disperser.BlobCost": {
+            "type": "object",
+            "properties": {
+                "dispersal_bytes": {
+                    "description": "DispersalBytes is the total size of the chunks of the blob sent to the operators",
+                    "type": "integer"
+                },
+                "encoding_time": {
+                    "description": "EncodingTime is the total time spent encoding the blob for all its quorums",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/time.Duration"
+                        }
+                    ]
+                },
+                "gas_fee": {
+                    "description": "GasFee is the share of the fee paid for the confirmation transaction of the batch attributed to the blob, in wei",
+                    "type": "integer"
+                },
+                "gas_used": {
+                    "description": "GasUsed is the share of the gas used by the confirmation transaction of the batch attributed to the blob.\nThe confirmation cost doesn't depend on the blobs, so it's split evenly across the blobs of the batch",
+                    "type": "integer"
+                }
+            }
+        },
         "encoding.BlobCommitments": {
             "type": "object",
             "properties": {
@@ -917,6 +1581,77 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "time.Duration": {
+            "type": "integer",
+            "enum": [
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000,
+                -9223372036854775808,
+                9223372036854775807,
+                1,
+                1000,
+                1000000,
+                1000000000,
+                60000000000,
+                3600000000000
+            ],
+            "x-enum-varnames": [
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour",
+                "minDuration",
+                "maxDuration",
+                "Nanosecond",
+                "Microsecond",
+                "Millisecond",
+                "Second",
+                "Minute",
+                "Hour"
+            ]
         }
     }
 }`
@@ -0,0 +1,549 @@
+package dataapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	// exportObjectPrefix is the prefix of the keys of the exported objects in the export bucket
+	exportObjectPrefix = "exports/"
+	// exportTimeout bounds the time spent running an export job
+	exportTimeout = time.Hour
+	// maxQueuedExportJobs is the number of export jobs that can be pending or running at a time
+	maxQueuedExportJobs = 10
+	// maxRetainedExportJobs is the number of export jobs whose status is kept, the oldest completed jobs are
+	// forgotten first
+	maxRetainedExportJobs = 100
+	// maxExportRange is the longest time range of an export
+	maxExportRange = 31 * 24 * time.Hour
+	// exportPageSize is the number of blob metadata read from the blob metadata store at a time
+	exportPageSize = 100
+	// exportReadInterval is the time between the reads of the blob metadata store by an export, so that exports
+	// don't compete with the serving reads of the table
+	exportReadInterval = 50 * time.Millisecond
+)
+
+// ExportFormat is the file format of an export.
+type ExportFormat string
+
+const (
+	CSVExport     ExportFormat = "csv"
+	ParquetExport ExportFormat = "parquet"
+)
+
+// ExportDataset is the data exported by an export job.
+type ExportDataset string
+
+const (
+	// BlobsExport are the blobs confirmed in the batches of the time range
+	BlobsExport ExportDataset = "blobs"
+	// BatchesExport are the batches confirmed in the time range
+	BatchesExport ExportDataset = "batches"
+	// OperatorsNonsigningExport are the nonsigning metrics of the operators over the time range
+	OperatorsNonsigningExport ExportDataset = "operators_nonsigning"
+)
+
+// ExportJobStatus is the status of an export job.
+type ExportJobStatus string
+
+const (
+	ExportPending   ExportJobStatus = "pending"
+	ExportRunning   ExportJobStatus = "running"
+	ExportSucceeded ExportJobStatus = "succeeded"
+	ExportFailed    ExportJobStatus = "failed"
+)
+
+var (
+	errExportsNotConfigured = errors.New("exports are not configured")
+	errTooManyExportJobs    = errors.New("too many export jobs are pending")
+)
+
+type (
+	// ExportRequest requests the export of a dataset over the time range [Start, End], in unix seconds.
+	ExportRequest struct {
+		Dataset ExportDataset `json:"dataset"`
+		Format  ExportFormat  `json:"format"`
+		Start   int64         `json:"start"`
+		End     int64         `json:"end"`
+	}
+
+	// ExportJob is the status of an export job. The exported file is written to ObjectKey in Bucket once the job
+	// succeeds.
+	ExportJob struct {
+		JobId       string          `json:"job_id"`
+		Dataset     ExportDataset   `json:"dataset"`
+		Format      ExportFormat    `json:"format"`
+		Start       int64           `json:"start"`
+		End         int64           `json:"end"`
+		Status      ExportJobStatus `json:"status"`
+		Bucket      string          `json:"bucket"`
+		ObjectKey   string          `json:"object_key"`
+		NumRows     int             `json:"num_rows"`
+		Error       string          `json:"error,omitempty"`
+		CreatedAt   int64           `json:"created_at"`
+		CompletedAt int64           `json:"completed_at,omitempty"`
+	}
+
+	ExportJobsResponse struct {
+		Meta Meta         `json:"meta"`
+		Data []*ExportJob `json:"data"`
+	}
+)
+
+// Validate checks the dataset, the format and the time range of the request.
+func (r *ExportRequest) Validate() error {
+	switch r.Dataset {
+	case BlobsExport, BatchesExport, OperatorsNonsigningExport:
+	default:
+		return fmt.Errorf("invalid dataset %q", r.Dataset)
+	}
+	switch r.Format {
+	case CSVExport, ParquetExport:
+	default:
+		return fmt.Errorf("invalid format %q", r.Format)
+	}
+	if r.Start <= 0 || r.End <= r.Start {
+		return fmt.Errorf("invalid time range [%d, %d]", r.Start, r.End)
+	}
+	if time.Duration(r.End-r.Start)*time.Second > maxExportRange {
+		return fmt.Errorf("the time range of an export can be at most %v", maxExportRange)
+	}
+	return nil
+}
+
+// The rows of the exported datasets. The columns are named after the parquet tags, in both formats.
+type (
+	blobExportRow struct {
+		BlobKey                 string `parquet:"blob_key"`
+		BatchId                 uint32 `parquet:"batch_id"`
+		BatchHeaderHash         string `parquet:"batch_header_hash"`
+		BlobIndex               uint32 `parquet:"blob_index"`
+		AccountId               string `parquet:"account_id"`
+		BlobSize                uint64 `parquet:"blob_size"`
+		BlobStatus              string `parquet:"blob_status"`
+		RequestedAt             uint64 `parquet:"requested_at"`
+		ReferenceBlockNumber    uint32 `parquet:"reference_block_number"`
+		ConfirmationBlockNumber uint32 `parquet:"confirmation_block_number"`
+		EncodingTimeMs          int64  `parquet:"encoding_time_ms"`
+		DispersalBytes          uint64 `parquet:"dispersal_bytes"`
+		GasUsed                 uint64 `parquet:"gas_used"`
+		GasFee                  uint64 `parquet:"gas_fee"`
+	}
+
+	batchExportRow struct {
+		BatchId             uint32 `parquet:"batch_id"`
+		BatchHeaderHash     string `parquet:"batch_header_hash"`
+		BlockNumber         uint64 `parquet:"block_number"`
+		BlockTimestamp      uint64 `parquet:"block_timestamp"`
+		ConfirmationTxnHash string `parquet:"confirmation_txn_hash"`
+		GasUsed             uint64 `parquet:"gas_used"`
+		GasPrice            uint64 `parquet:"gas_price"`
+		TxFee               uint64 `parquet:"tx_fee"`
+	}
+
+	operatorNonsigningExportRow struct {
+		OperatorId           string  `parquet:"operator_id"`
+		OperatorAddress      string  `parquet:"operator_address"`
+		QuorumId             uint32  `parquet:"quorum_id"`
+		TotalUnsignedBatches int64   `parquet:"total_unsigned_batches"`
+		TotalBatches         int64   `parquet:"total_batches"`
+		Percentage           float64 `parquet:"percentage"`
+		StakePercentage      float64 `parquet:"stake_percentage"`
+	}
+)
+
+// exporter runs the export jobs in the background, one at a time, so that exports read the blob metadata store at
+// the pace of a single reader regardless of how many jobs are requested. The job statuses are kept in memory.
+type exporter struct {
+	storage s3.Client
+	bucket  string
+	logger  logging.Logger
+
+	// export writes the encoded rows of the job to w as they are read, and returns the number of rows
+	export func(ctx context.Context, job *ExportJob, w io.Writer) (int, error)
+
+	mu   sync.Mutex
+	jobs []*ExportJob
+	// running serializes the jobs
+	running sync.Mutex
+}
+
+func newExporter(storage s3.Client, bucket string, logger logging.Logger, export func(ctx context.Context, job *ExportJob, w io.Writer) (int, error)) *exporter {
+	return &exporter{
+		storage: storage,
+		bucket:  bucket,
+		logger:  logger,
+		export:  export,
+	}
+}
+
+// Submit queues an export job for the request and returns its initial status.
+func (e *exporter) Submit(request *ExportRequest) (*ExportJob, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	jobId := hex.EncodeToString(id)
+	job := &ExportJob{
+		JobId:     jobId,
+		Dataset:   request.Dataset,
+		Format:    request.Format,
+		Start:     request.Start,
+		End:       request.End,
+		Status:    ExportPending,
+		Bucket:    e.bucket,
+		ObjectKey: fmt.Sprintf("%s%s/%s-%d-%d.%s", exportObjectPrefix, jobId, request.Dataset, request.Start, request.End, request.Format),
+		CreatedAt: time.Now().Unix(),
+	}
+
+	e.mu.Lock()
+	queued := 0
+	for _, j := range e.jobs {
+		if j.Status == ExportPending || j.Status == ExportRunning {
+			queued++
+		}
+	}
+	if queued >= maxQueuedExportJobs {
+		e.mu.Unlock()
+		return nil, errTooManyExportJobs
+	}
+	e.jobs = append(e.jobs, job)
+	e.forgetCompletedJobs()
+	status := *job
+	e.mu.Unlock()
+
+	go e.run(job)
+	return &status, nil
+}
+
+// Job returns the status of the job with the given ID.
+func (e *exporter) Job(jobId string) (*ExportJob, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, job := range e.jobs {
+		if job.JobId == jobId {
+			status := *job
+			return &status, nil
+		}
+	}
+	return nil, errNotFound
+}
+
+// Jobs returns the status of the retained jobs, most recent first.
+func (e *exporter) Jobs() []*ExportJob {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	jobs := make([]*ExportJob, 0, len(e.jobs))
+	for i := len(e.jobs) - 1; i >= 0; i-- {
+		status := *e.jobs[i]
+		jobs = append(jobs, &status)
+	}
+	return jobs
+}
+
+// forgetCompletedJobs drops the oldest completed jobs beyond maxRetainedExportJobs. It must be called with mu held.
+func (e *exporter) forgetCompletedJobs() {
+	excess := len(e.jobs) - maxRetainedExportJobs
+	if excess <= 0 {
+		return
+	}
+	jobs := e.jobs[:0]
+	for _, job := range e.jobs {
+		if excess > 0 && (job.Status == ExportSucceeded || job.Status == ExportFailed) {
+			excess--
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	e.jobs = jobs
+}
+
+func (e *exporter) run(job *ExportJob) {
+	e.running.Lock()
+	defer e.running.Unlock()
+	e.setStatus(job, ExportRunning, 0, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	// The rows are uploaded in parts as they are encoded, so an export is never held in memory
+	start := time.Now()
+	reader, writer := io.Pipe()
+	output := &countingWriter{w: writer}
+	exported := make(chan error, 1)
+	var numRows int
+	go func() {
+		var err error
+		numRows, err = e.export(ctx, job, output)
+		writer.CloseWithError(err)
+		exported <- err
+	}()
+	err := e.storage.UploadObjectStream(ctx, e.bucket, job.ObjectKey, reader)
+	// Unblock the export if the upload stopped reading
+	reader.CloseWithError(err)
+	if exportErr := <-exported; exportErr != nil {
+		err = exportErr
+	}
+	if err != nil {
+		e.logger.Error("Export job failed", "jobId", job.JobId, "dataset", job.Dataset, "err", err)
+		e.setStatus(job, ExportFailed, 0, err)
+		return
+	}
+	e.logger.Info("Export job succeeded", "jobId", job.JobId, "dataset", job.Dataset, "rows", numRows, "bytes", output.n, "duration", time.Since(start))
+	e.setStatus(job, ExportSucceeded, numRows, nil)
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (e *exporter) setStatus(job *ExportJob, status ExportJobStatus, numRows int, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	job.Status = status
+	job.NumRows = numRows
+	if err != nil {
+		job.Error = err.Error()
+	}
+	if status == ExportSucceeded || status == ExportFailed {
+		job.CompletedAt = time.Now().Unix()
+	}
+}
+
+// exportDataset reads the rows of the dataset of the job and writes them to w in the format of the job.
+func (s *server) exportDataset(ctx context.Context, job *ExportJob, w io.Writer) (int, error) {
+	switch job.Dataset {
+	case BlobsExport:
+		rows, err := newExportWriter[blobExportRow](job.Format, w)
+		if err != nil {
+			return 0, err
+		}
+		if err := s.writeBlobExportRows(ctx, job.Start, job.End, rows); err != nil {
+			return 0, err
+		}
+		return rows.numRows, rows.Close()
+	case BatchesExport:
+		batches, err := s.getBatchExportRows(ctx, job.Start, job.End)
+		if err != nil {
+			return 0, err
+		}
+		return writeExportRows(job.Format, w, batches)
+	case OperatorsNonsigningExport:
+		nonsigning, err := s.getOperatorNonsigningExportRows(ctx, job.Start, job.End)
+		if err != nil {
+			return 0, err
+		}
+		return writeExportRows(job.Format, w, nonsigning)
+	default:
+		return 0, fmt.Errorf("invalid dataset %q", job.Dataset)
+	}
+}
+
+func (s *server) getBatchExportRows(ctx context.Context, start, end int64) ([]*batchExportRow, error) {
+	batches, err := s.subgraphClient.QueryBatchesInTimeRange(ctx, uint64(start), uint64(end))
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*batchExportRow, 0, len(batches))
+	for _, batch := range batches {
+		batchHeaderHash, err := ConvertHexadecimalToBytes(batch.BatchHeaderHash)
+		if err != nil {
+			return nil, err
+		}
+		row := &batchExportRow{
+			BatchId:             uint32(batch.BatchId),
+			BatchHeaderHash:     hex.EncodeToString(batchHeaderHash[:]),
+			BlockNumber:         batch.BlockNumber,
+			BlockTimestamp:      batch.BlockTimestamp,
+			ConfirmationTxnHash: string(batch.TxHash),
+		}
+		if batch.GasFees != nil {
+			row.GasUsed = batch.GasFees.GasUsed
+			row.GasPrice = batch.GasFees.GasPrice
+			row.TxFee = batch.GasFees.TxFee
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// writeBlobExportRows writes the blobs of the batches confirmed in the time range to rows. The blobs of each batch
+// are read a page at a time from the batch index of the blob metadata store, at most once per exportReadInterval.
+func (s *server) writeBlobExportRows(ctx context.Context, start, end int64, rows *exportWriter[blobExportRow]) error {
+	batches, err := s.subgraphClient.QueryBatchesInTimeRange(ctx, uint64(start), uint64(end))
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(exportReadInterval)
+	defer ticker.Stop()
+	for _, batch := range batches {
+		batchHeaderHash, err := ConvertHexadecimalToBytes(batch.BatchHeaderHash)
+		if err != nil {
+			return err
+		}
+		var startKey *disperser.BlobStoreExclusiveStartKey
+		for {
+			metadatas, nextKey, err := s.blobstore.GetBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, exportPageSize, startKey)
+			if err != nil {
+				return err
+			}
+			page := make([]*blobExportRow, 0, len(metadatas))
+			for _, metadata := range metadatas {
+				if metadata.ConfirmationInfo == nil || metadata.RequestMetadata == nil {
+					continue
+				}
+				row := &blobExportRow{
+					BlobKey:                 metadata.GetBlobKey().String(),
+					BatchId:                 metadata.ConfirmationInfo.BatchID,
+					BatchHeaderHash:         hex.EncodeToString(batchHeaderHash[:]),
+					BlobIndex:               metadata.ConfirmationInfo.BlobIndex,
+					AccountId:               metadata.RequestMetadata.AccountID,
+					BlobSize:                uint64(metadata.RequestMetadata.BlobSize),
+					BlobStatus:              metadata.BlobStatus.String(),
+					RequestedAt:             metadata.RequestMetadata.RequestedAt,
+					ReferenceBlockNumber:    metadata.ConfirmationInfo.ReferenceBlockNumber,
+					ConfirmationBlockNumber: metadata.ConfirmationInfo.ConfirmationBlockNumber,
+				}
+				if cost := metadata.ConfirmationInfo.Cost; cost != nil {
+					row.EncodingTimeMs = cost.EncodingTime.Milliseconds()
+					row.DispersalBytes = cost.DispersalBytes
+					row.GasUsed = cost.GasUsed
+					row.GasFee = cost.GasFee
+				}
+				page = append(page, row)
+			}
+			if err := rows.Write(page); err != nil {
+				return err
+			}
+			if nextKey == nil {
+				break
+			}
+			startKey = nextKey
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+	}
+	return nil
+}
+
+func (s *server) getOperatorNonsigningExportRows(ctx context.Context, start, end int64) ([]*operatorNonsigningExportRow, error) {
+	nonsigning, err := s.getOperatorNonsigningRate(ctx, start, end, false)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*operatorNonsigningExportRow, 0, len(nonsigning.Data))
+	for _, metric := range nonsigning.Data {
+		rows = append(rows, &operatorNonsigningExportRow{
+			OperatorId:           metric.OperatorId,
+			OperatorAddress:      metric.OperatorAddress,
+			QuorumId:             uint32(metric.QuorumId),
+			TotalUnsignedBatches: int64(metric.TotalUnsignedBatches),
+			TotalBatches:         int64(metric.TotalBatches),
+			Percentage:           metric.Percentage,
+			StakePercentage:      metric.StakePercentage,
+		})
+	}
+	return rows, nil
+}
+
+// exportWriter encodes the rows of an export to a writer as they are read. The CSV header and the parquet columns
+// are the parquet tags of the fields of the rows.
+type exportWriter[T any] struct {
+	csv     *csv.Writer
+	parquet *parquet.GenericWriter[T]
+	numRows int
+}
+
+func newExportWriter[T any](format ExportFormat, w io.Writer) (*exportWriter[T], error) {
+	switch format {
+	case CSVExport:
+		rowType := reflect.TypeOf((*T)(nil)).Elem()
+		header := make([]string, rowType.NumField())
+		for i := range header {
+			header[i], _, _ = strings.Cut(rowType.Field(i).Tag.Get("parquet"), ",")
+		}
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return nil, err
+		}
+		return &exportWriter[T]{csv: writer}, nil
+	case ParquetExport:
+		return &exportWriter[T]{parquet: parquet.NewGenericWriter[T](w)}, nil
+	default:
+		return nil, fmt.Errorf("invalid format %q", format)
+	}
+}
+
+// Write encodes the rows
+func (e *exportWriter[T]) Write(rows []*T) error {
+	if e.csv != nil {
+		record := make([]string, reflect.TypeOf((*T)(nil)).Elem().NumField())
+		for _, row := range rows {
+			value := reflect.ValueOf(row).Elem()
+			for i := range record {
+				record[i] = fmt.Sprint(value.Field(i).Interface())
+			}
+			if err := e.csv.Write(record); err != nil {
+				return err
+			}
+		}
+		e.numRows += len(rows)
+		return nil
+	}
+	values := make([]T, len(rows))
+	for i, row := range rows {
+		values[i] = *row
+	}
+	if _, err := e.parquet.Write(values); err != nil {
+		return err
+	}
+	e.numRows += len(rows)
+	return nil
+}
+
+// Close flushes the encoded rows
+func (e *exportWriter[T]) Close() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	return e.parquet.Close()
+}
+
+// writeExportRows encodes the rows in the given format to w, and returns the number of rows
+func writeExportRows[T any](format ExportFormat, w io.Writer, rows []*T) (int, error) {
+	writer, err := newExportWriter[T](format, w)
+	if err != nil {
+		return 0, err
+	}
+	if err := writer.Write(rows); err != nil {
+		return 0, err
+	}
+	return writer.numRows, writer.Close()
+}
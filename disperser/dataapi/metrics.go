@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -177,6 +178,11 @@ func (g *Metrics) IncrementNotFoundRequestNum(method string) {
 	}).Inc()
 }
 
+// RegisterBuildInfo exports the build of the data API as the build_info metric
+func (g *Metrics) RegisterBuildInfo(info buildinfo.Info) error {
+	return buildinfo.Register(g.registry, info)
+}
+
 // Start starts the metrics server
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
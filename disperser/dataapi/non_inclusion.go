@@ -0,0 +1,126 @@
+package dataapi
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// nonInclusionDomain separates the non-inclusion statement hashes from any other message signed by the same key.
+const nonInclusionDomain = "EigenDA.NonInclusionStatement"
+
+var (
+	errNonInclusionSignerNotConfigured = errors.New("non-inclusion signer is not configured")
+	errBatchNotConfirmed               = errors.New("the batch range starts after the last confirmed batch")
+)
+
+// NonInclusionStatement states that no blob with the given commitment was confirmed in a batch
+// with ID in the inclusive range [StartBatchID, EndBatchID], as known by the data API at Timestamp.
+// The range never extends past the last batch confirmed at Timestamp. The statement only covers the blobs
+// requested since RetainedSince, whose metadata is still retained by the disperser.
+type NonInclusionStatement struct {
+	BlobCommitment *encoding.G1Commitment
+	StartBatchID   uint32
+	EndBatchID     uint32
+	// Timestamp is the unix time in seconds at which the statement was made
+	Timestamp uint64
+	// RetainedSince is the unix time in seconds since which the metadata of the requested blobs is retained, or 0 if
+	// it is retained forever
+	RetainedSince uint64
+}
+
+// Hash returns the keccak256 hash of the statement, which is what the data API signs.
+// The preimage is the domain string followed by the compressed commitment, the batch range,
+// the timestamp and the retention start, with all integers in big endian.
+func (s *NonInclusionStatement) Hash() [32]byte {
+	commitment := (*bn254.G1Affine)(s.BlobCommitment).Bytes()
+	buf := make([]byte, 0, len(nonInclusionDomain)+len(commitment)+24)
+	buf = append(buf, nonInclusionDomain...)
+	buf = append(buf, commitment[:]...)
+	buf = binary.BigEndian.AppendUint32(buf, s.StartBatchID)
+	buf = binary.BigEndian.AppendUint32(buf, s.EndBatchID)
+	buf = binary.BigEndian.AppendUint64(buf, s.Timestamp)
+	buf = binary.BigEndian.AppendUint64(buf, s.RetainedSince)
+	return crypto.Keccak256Hash(buf)
+}
+
+// Sign signs the statement hash with the given key and returns the attestation to be served.
+func (s *NonInclusionStatement) Sign(key *ecdsa.PrivateKey) (*NonInclusionAttestation, error) {
+	hash := s.Hash()
+	signature, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		return nil, err
+	}
+	commitment := (*bn254.G1Affine)(s.BlobCommitment).Bytes()
+	return &NonInclusionAttestation{
+		BlobCommitment: hex.EncodeToString(commitment[:]),
+		StartBatchId:   s.StartBatchID,
+		EndBatchId:     s.EndBatchID,
+		Timestamp:      s.Timestamp,
+		RetainedSince:  s.RetainedSince,
+		StatementHash:  hex.EncodeToString(hash[:]),
+		Signer:         crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		Signature:      hex.EncodeToString(signature),
+	}, nil
+}
+
+// Statement reconstructs the signed statement from the attestation.
+func (a *NonInclusionAttestation) Statement() (*NonInclusionStatement, error) {
+	commitment, err := ParseBlobCommitment(a.BlobCommitment)
+	if err != nil {
+		return nil, err
+	}
+	return &NonInclusionStatement{
+		BlobCommitment: commitment,
+		StartBatchID:   a.StartBatchId,
+		EndBatchID:     a.EndBatchId,
+		Timestamp:      a.Timestamp,
+		RetainedSince:  a.RetainedSince,
+	}, nil
+}
+
+// Verify checks that the attestation was signed by the given signer.
+// The signer is the address of the data API's non-inclusion signing key, which must be obtained out of band.
+func (a *NonInclusionAttestation) Verify(signer gethcommon.Address) error {
+	statement, err := a.Statement()
+	if err != nil {
+		return err
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(a.Signature, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	hash := statement.Hash()
+	pubKey, err := crypto.SigToPub(hash[:], signature)
+	if err != nil {
+		return fmt.Errorf("failed to recover signer: %w", err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pubKey); recovered != signer {
+		return fmt.Errorf("statement signed by %s, expected %s", recovered.Hex(), signer.Hex())
+	}
+	return nil
+}
+
+// ParseBlobCommitment parses a hex encoded compressed G1 blob commitment.
+func ParseBlobCommitment(commitment string) (*encoding.G1Commitment, error) {
+	commitmentBytes, err := hex.DecodeString(strings.TrimPrefix(commitment, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob commitment encoding: %w", err)
+	}
+	if len(commitmentBytes) != bn254.SizeOfG1AffineCompressed {
+		return nil, fmt.Errorf("invalid blob commitment length %d, expected %d", len(commitmentBytes), bn254.SizeOfG1AffineCompressed)
+	}
+	var point bn254.G1Affine
+	if _, err := point.SetBytes(commitmentBytes); err != nil {
+		return nil, fmt.Errorf("invalid blob commitment: %w", err)
+	}
+	return (*encoding.G1Commitment)(&point), nil
+}
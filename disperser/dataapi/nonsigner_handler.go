@@ -273,3 +273,34 @@ func computeNumResponsible(batches []*BatchNonSigningInfo, operatorQuorumInterva
 	}
 	return numResponsible
 }
+
+// paginateOperatorsNonsigning returns the nonsigning metrics after the cursor, in ascending order of operator ID
+// and quorum ID.
+func paginateOperatorsNonsigning(metrics []*OperatorNonsigningPercentageMetrics, cursor *Cursor, limit int) ([]*OperatorNonsigningPercentageMetrics, *Cursor) {
+	sorted := make([]*OperatorNonsigningPercentageMetrics, len(metrics))
+	copy(sorted, metrics)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].OperatorId == sorted[j].OperatorId {
+			return sorted[i].QuorumId < sorted[j].QuorumId
+		}
+		return sorted[i].OperatorId < sorted[j].OperatorId
+	})
+
+	start := 0
+	if cursor != nil {
+		start = sort.Search(len(sorted), func(i int) bool {
+			if sorted[i].OperatorId == cursor.OperatorId {
+				return sorted[i].QuorumId > cursor.QuorumId
+			}
+			return sorted[i].OperatorId > cursor.OperatorId
+		})
+	}
+	end := min(start+limit, len(sorted))
+
+	page := sorted[start:end]
+	if len(page) == 0 {
+		return page, nil
+	}
+	last := page[len(page)-1]
+	return page, NewOperatorNonsigningCursor(last.OperatorId, last.QuorumId)
+}
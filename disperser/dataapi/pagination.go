@@ -0,0 +1,125 @@
+package dataapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 1000
+)
+
+// CursorKind is the listing a cursor belongs to.
+type CursorKind string
+
+const (
+	BlobsCursor               CursorKind = "blobs"
+	BatchesCursor             CursorKind = "batches"
+	OperatorsNonsigningCursor CursorKind = "operators_nonsigning"
+)
+
+var (
+	errInvalidCursor      = errors.New("invalid cursor parameter")
+	errCursorKindMismatch = errors.New("the cursor does not belong to this listing")
+)
+
+// Cursor is the position of an item in a paginated listing of the data API.
+//
+// Paginated listings return the items after the cursor passed in the cursor query parameter, in a stable order.
+// The next_cursor of a page is the cursor of its last item. New blobs and batches are only ever appended to their
+// listings, so these can be resumed at any time from the last item that was processed, without reading it again.
+type Cursor struct {
+	Kind CursorKind `json:"kind"`
+	// BatchId and BlobIndex are the position of the item in the blobs and batches listings, in confirmation order
+	BatchId   uint32 `json:"batch_id,omitempty"`
+	BlobIndex uint32 `json:"blob_index,omitempty"`
+	// OperatorId and QuorumId are the position of the item in the operators nonsigning listing
+	OperatorId string `json:"operator_id,omitempty"`
+	QuorumId   uint8  `json:"quorum_id,omitempty"`
+}
+
+// NewBlobCursor returns the cursor of the blob with the given index in the batch with the given ID.
+func NewBlobCursor(batchId, blobIndex uint32) *Cursor {
+	return &Cursor{Kind: BlobsCursor, BatchId: batchId, BlobIndex: blobIndex}
+}
+
+// NewBatchCursor returns the cursor of the batch with the given ID.
+func NewBatchCursor(batchId uint32) *Cursor {
+	return &Cursor{Kind: BatchesCursor, BatchId: batchId}
+}
+
+// NewOperatorNonsigningCursor returns the cursor of the nonsigning metrics of an operator in a quorum.
+func NewOperatorNonsigningCursor(operatorId string, quorumId uint8) *Cursor {
+	return &Cursor{Kind: OperatorsNonsigningCursor, OperatorId: operatorId, QuorumId: quorumId}
+}
+
+// Encode serializes the cursor to the opaque string passed in the cursor query parameter.
+func (c *Cursor) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		// the cursor only has fields that can be marshalled
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor serialized with Encode.
+func DecodeCursor(encoded string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errInvalidCursor
+	}
+	switch cursor.Kind {
+	case BlobsCursor, BatchesCursor, OperatorsNonsigningCursor:
+	default:
+		return nil, errInvalidCursor
+	}
+	return &cursor, nil
+}
+
+func newPageMeta(size int, next *Cursor) Meta {
+	meta := Meta{Size: size}
+	if next != nil {
+		meta.NextCursor = next.Encode()
+	}
+	return meta
+}
+
+// parseCursor parses the cursor query parameter of a paginated listing of the given kind. The cursor is nil if the
+// listing starts from its first item, and set is false if the parameter is not set at all.
+func parseCursor(c *gin.Context, kind CursorKind) (cursor *Cursor, set bool, err error) {
+	encoded, set := c.GetQuery("cursor")
+	if encoded == "" {
+		return nil, set, nil
+	}
+	cursor, err = DecodeCursor(encoded)
+	if err != nil {
+		return nil, true, err
+	}
+	if cursor.Kind != kind {
+		return nil, true, errCursorKindMismatch
+	}
+	return cursor, true, nil
+}
+
+// parsePageLimit parses the limit query parameter of a paginated listing.
+func parsePageLimit(c *gin.Context) (int, error) {
+	if c.Query("limit") == "" {
+		return defaultPageLimit, nil
+	}
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit < 1 || limit > maxPageLimit {
+		return 0, fmt.Errorf("the limit parameter must be between 1 and %d", maxPageLimit)
+	}
+	return limit, nil
+}
@@ -2,6 +2,7 @@ package dataapi
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -44,7 +45,9 @@ const (
 	maxThroughputAge                    = 10
 	maxMetricAage                       = 10
 	maxFeedBlobsAge                     = 10
+	maxFeedBatchesAge                   = 10
 	maxFeedBlobAage                     = 300 // this is completely static
+	maxBlobExistenceAge                 = 10
 	maxDisperserAvailabilityAge         = 3
 	maxChurnerAvailabilityAge           = 3
 	maxBatcherAvailabilityAge           = 3
@@ -78,6 +81,7 @@ type (
 		SecurityParams          []*core.SecurityParam     `json:"security_params"`
 		RequestAt               uint64                    `json:"requested_at"`
 		BlobStatus              disperser.BlobStatus      `json:"blob_status"`
+		Cost                    *disperser.BlobCost       `json:"cost,omitempty"`
 	}
 
 	Metric struct {
@@ -95,6 +99,8 @@ type (
 
 	Meta struct {
 		Size int `json:"size"`
+		// NextCursor is the cursor of the last item of a page of a paginated listing, empty if the page is empty
+		NextCursor string `json:"next_cursor,omitempty"`
 	}
 
 	BlobsResponse struct {
@@ -102,6 +108,43 @@ type (
 		Data []*BlobMetadataResponse `json:"data"`
 	}
 
+	BatchResponse struct {
+		BatchId             uint32 `json:"batch_id"`
+		BatchHeaderHash     string `json:"batch_header_hash"`
+		BlockNumber         uint64 `json:"block_number"`
+		BlockTimestamp      uint64 `json:"block_timestamp"`
+		ConfirmationTxnHash string `json:"confirmation_txn_hash"`
+		GasUsed             uint64 `json:"gas_used"`
+		GasPrice            uint64 `json:"gas_price"`
+		TxFee               uint64 `json:"tx_fee"`
+	}
+
+	BatchesResponse struct {
+		Meta Meta             `json:"meta"`
+		Data []*BatchResponse `json:"data"`
+	}
+
+	// NonInclusionAttestation is a NonInclusionStatement signed by the data API.
+	NonInclusionAttestation struct {
+		// BlobCommitment is the hex encoded compressed commitment
+		BlobCommitment string `json:"blob_commitment"`
+		StartBatchId   uint32 `json:"start_batch_id"`
+		EndBatchId     uint32 `json:"end_batch_id"`
+		Timestamp      uint64 `json:"timestamp"`
+		RetainedSince  uint64 `json:"retained_since"`
+		StatementHash  string `json:"statement_hash"`
+		Signer         string `json:"signer"`
+		Signature      string `json:"signature"`
+	}
+
+	BlobExistenceResponse struct {
+		Found bool `json:"found"`
+		// Blobs are the confirmed blobs with the commitment in the batch range, set if found
+		Blobs []*BlobMetadataResponse `json:"blobs,omitempty"`
+		// NonInclusion is the signed negative statement, set if not found
+		NonInclusion *NonInclusionAttestation `json:"non_inclusion,omitempty"`
+	}
+
 	OperatorNonsigningPercentageMetrics struct {
 		OperatorId           string  `json:"operator_id"`
 		OperatorAddress      string  `json:"operator_address"`
@@ -151,6 +194,10 @@ type (
 		DispersalOnline bool   `json:"dispersal_online"`
 		RetrievalOnline bool   `json:"retrieval_online"`
 	}
+	AccountUsageResponse struct {
+		Meta Meta                      `json:"meta"`
+		Data []*disperser.AccountUsage `json:"data"`
+	}
 	ErrorResponse struct {
 		Error string `json:"error"`
 	}
@@ -168,6 +215,14 @@ type (
 		ejector        *Ejector
 		ejectionToken  string
 
+		nonInclusionSigner    *ecdsa.PrivateKey
+		blobMetadataRetention time.Duration
+
+		exporter    *exporter
+		exportToken string
+
+		accountingToken string
+
 		metrics                   *Metrics
 		disperserHostName         string
 		churnerHostName           string
@@ -206,7 +261,7 @@ func NewServer(
 		eigenDAHttpServiceChecker = &HttpServiceAvailability{}
 	}
 
-	return &server{
+	s := &server{
 		logger:                    logger.With("component", "DataAPIServer"),
 		serverMode:                config.ServerMode,
 		socketAddr:                config.SocketAddr,
@@ -219,12 +274,20 @@ func NewServer(
 		metrics:                   metrics,
 		ejector:                   ejector,
 		ejectionToken:             config.EjectionToken,
+		nonInclusionSigner:        config.NonInclusionSigner,
+		blobMetadataRetention:     config.MetadataRetention,
 		disperserHostName:         config.DisperserHostname,
 		churnerHostName:           config.ChurnerHostname,
 		batcherHealthEndpt:        config.BatcherHealthEndpt,
 		eigenDAGRPCServiceChecker: eigenDAGRPCServiceChecker,
 		eigenDAHttpServiceChecker: eigenDAHttpServiceChecker,
+		exportToken:               config.ExportToken,
+		accountingToken:           config.AccountingToken,
 	}
+	if config.ExportStorage != nil {
+		s.exporter = newExporter(config.ExportStorage, config.ExportBucketName, s.logger, s.exportDataset)
+	}
+	return s
 }
 
 func (s *server) Start() error {
@@ -243,7 +306,9 @@ func (s *server) Start() error {
 		feed := v1.Group("/feed")
 		{
 			feed.GET("/blobs", s.FetchBlobsHandler)
+			feed.GET("/batches", s.FetchBatchesHandler)
 			feed.GET("/blobs/:blob_key", s.FetchBlobHandler)
+			feed.GET("/blob-existence", s.FetchBlobExistenceHandler)
 		}
 		operatorsInfo := v1.Group("/operators-info")
 		{
@@ -262,6 +327,16 @@ func (s *server) Start() error {
 		}
 		ejection := v1.Group("/ejection")
 		ejection.POST("/operators", s.EjectOperatorsHandler)
+		exports := v1.Group("/exports")
+		{
+			exports.POST("", s.CreateExportHandler)
+			exports.GET("", s.FetchExportsHandler)
+			exports.GET("/:job_id", s.FetchExportHandler)
+		}
+		accounts := v1.Group("/accounts")
+		{
+			accounts.GET("/:account_id/usage", s.FetchAccountUsageHandler)
+		}
 		swagger := v1.Group("/swagger")
 		{
 			swagger.GET("/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
@@ -375,6 +450,199 @@ func (s *server) EjectOperatorsHandler(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// CreateExportHandler godoc
+//
+//	@Summary		Request the export of a dataset over a time range to object storage
+//	@Description	The export runs in the background. Its status is returned by /exports/{job_id}, and the exported file is written to the object key of the job once it succeeds.
+//	@Tags			Exports
+//	@Accept			json
+//	@Produce		json
+//	@Param			export_token	header		string			true	"Export token"
+//	@Param			request			body		ExportRequest	true	"Dataset (blobs, batches or operators_nonsigning), format (csv or parquet) and time range in unix seconds, of at most 31 days"
+//	@Success		202				{object}	ExportJob
+//	@Failure		400				{object}	ErrorResponse	"error: Bad request"
+//	@Failure		401				{object}	ErrorResponse	"error: Unauthorized"
+//	@Failure		429				{object}	ErrorResponse	"error: Too many requests"
+//	@Failure		500				{object}	ErrorResponse	"error: Server error"
+//	@Failure		503				{object}	ErrorResponse	"error: Exports are not configured"
+//	@Router			/exports [post]
+func (s *server) CreateExportHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("CreateExport", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if !s.authorizeExport(c) {
+		s.metrics.IncrementFailedRequestNum("CreateExport")
+		return
+	}
+
+	var request ExportRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		s.metrics.IncrementFailedRequestNum("CreateExport")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("invalid export request: %v", err)})
+		return
+	}
+	if err := request.Validate(); err != nil {
+		s.metrics.IncrementFailedRequestNum("CreateExport")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	job, err := s.exporter.Submit(&request)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("CreateExport")
+		if errors.Is(err, errTooManyExportJobs) {
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{Error: err.Error()})
+			return
+		}
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("CreateExport")
+	c.JSON(http.StatusAccepted, job)
+}
+
+// FetchExportHandler godoc
+//
+//	@Summary	Fetch the status of an export job
+//	@Tags		Exports
+//	@Produce	json
+//	@Param		export_token	header		string	true	"Export token"
+//	@Param		job_id			path		string	true	"Export job ID"
+//	@Success	200				{object}	ExportJob
+//	@Failure	401				{object}	ErrorResponse	"error: Unauthorized"
+//	@Failure	404				{object}	ErrorResponse	"error: Not found"
+//	@Failure	500				{object}	ErrorResponse	"error: Server error"
+//	@Failure	503				{object}	ErrorResponse	"error: Exports are not configured"
+//	@Router		/exports/{job_id} [get]
+func (s *server) FetchExportHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchExport", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if !s.authorizeExport(c) {
+		s.metrics.IncrementFailedRequestNum("FetchExport")
+		return
+	}
+
+	job, err := s.exporter.Job(c.Param("job_id"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchExport")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchExport")
+	c.JSON(http.StatusOK, job)
+}
+
+// FetchExportsHandler godoc
+//
+//	@Summary	Fetch the status of the recent export jobs, most recent first
+//	@Tags		Exports
+//	@Produce	json
+//	@Param		export_token	header		string	true	"Export token"
+//	@Success	200				{object}	ExportJobsResponse
+//	@Failure	401				{object}	ErrorResponse	"error: Unauthorized"
+//	@Failure	500				{object}	ErrorResponse	"error: Server error"
+//	@Failure	503				{object}	ErrorResponse	"error: Exports are not configured"
+//	@Router		/exports [get]
+func (s *server) FetchExportsHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchExports", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if !s.authorizeExport(c) {
+		s.metrics.IncrementFailedRequestNum("FetchExports")
+		return
+	}
+
+	jobs := s.exporter.Jobs()
+	s.metrics.IncrementSuccessfulRequestNum("FetchExports")
+	c.JSON(http.StatusOK, ExportJobsResponse{
+		Meta: Meta{
+			Size: len(jobs),
+		},
+		Data: jobs,
+	})
+}
+
+// authorizeExport aborts the request if exports are not configured or the export token doesn't match.
+func (s *server) authorizeExport(c *gin.Context) bool {
+	if s.exporter == nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, ErrorResponse{Error: errExportsNotConfigured.Error()})
+		return false
+	}
+	if s.exportToken == "" || c.GetHeader("export_token") != s.exportToken {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: "Unauthorized"})
+		return false
+	}
+	return true
+}
+
+// FetchAccountUsageHandler godoc
+//
+//	@Summary	Fetch the usage of an account, per usage period
+//	@Tags		Accounts
+//	@Produce	json
+//	@Param		accounting_token	header		string	true	"Accounting token"
+//	@Param		account_id			path		string	true	"Account ID"
+//	@Param		tenant_id			query		string	false	"Tenant ID of the account [default: the default tenant]"
+//	@Param		start				query		int		false	"Start unix timestamp of the first usage period [default: 1 day ago]"
+//	@Param		end					query		int		false	"End unix timestamp, exclusive [default: unix time now]"
+//	@Success	200					{object}	AccountUsageResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	401					{object}	ErrorResponse	"error: Unauthorized"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/accounts/{account_id}/usage [get]
+func (s *server) FetchAccountUsageHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchAccountUsage", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if s.accountingToken == "" || c.GetHeader("accounting_token") != s.accountingToken {
+		s.metrics.IncrementFailedRequestNum("FetchAccountUsage")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Error: "Unauthorized"})
+		return
+	}
+
+	now := time.Now()
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = now.Add(-24 * time.Hour).Unix()
+	}
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = now.Unix()
+	}
+	if start < 0 || end < start {
+		s.metrics.IncrementFailedRequestNum("FetchAccountUsage")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("invalid time range [%d, %d)", start, end)})
+		return
+	}
+
+	accountKey := disperser.TenantAccountKey(c.Query("tenant_id"), c.Param("account_id"))
+	usages, err := s.blobstore.GetAccountUsage(c.Request.Context(), accountKey, disperser.UsagePeriodStart(time.Unix(start, 0)), uint64(end))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountUsage")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchAccountUsage")
+	c.JSON(http.StatusOK, AccountUsageResponse{
+		Meta: Meta{
+			Size: len(usages),
+		},
+		Data: usages,
+	})
+}
+
 // FetchBlobHandler godoc
 //
 //	@Summary	Fetch blob metadata by blob key
@@ -408,21 +676,53 @@ func (s *server) FetchBlobHandler(c *gin.Context) {
 
 // FetchBlobsHandler godoc
 //
-//	@Summary	Fetch blobs metadata list
-//	@Tags		Feed
-//	@Produce	json
-//	@Param		limit	query		int	false	"Limit [default: 10]"
-//	@Success	200		{object}	BlobsResponse
-//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404		{object}	ErrorResponse	"error: Not found"
-//	@Failure	500		{object}	ErrorResponse	"error: Server error"
-//	@Router		/feed/blobs [get]
+//	@Summary		Fetch blobs metadata list
+//	@Description	Without the cursor parameter, returns the latest blobs. With the cursor parameter, returns the blobs
+//	@Description	confirmed after the cursor in confirmation order, starting from the first blob if the cursor is empty.
+//	@Tags			Feed
+//	@Produce		json
+//	@Param			limit	query		int		false	"Limit [default: 10]"
+//	@Param			cursor	query		string	false	"Cursor of the last blob of the previous page"
+//	@Success		200		{object}	BlobsResponse
+//	@Failure		400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure		404		{object}	ErrorResponse	"error: Not found"
+//	@Failure		500		{object}	ErrorResponse	"error: Server error"
+//	@Router			/feed/blobs [get]
 func (s *server) FetchBlobsHandler(c *gin.Context) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
 		s.metrics.ObserveLatency("FetchBlobs", f*1000) // make milliseconds
 	}))
 	defer timer.ObserveDuration()
 
+	cursor, paginated, err := parseCursor(c, BlobsCursor)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBlobs")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if paginated {
+		limit, err := parsePageLimit(c)
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchBlobs")
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+		metadatas, next, err := s.getBlobsAfter(c.Request.Context(), cursor, limit)
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchBlobs")
+			errorResponse(c, err)
+			return
+		}
+
+		s.metrics.IncrementSuccessfulRequestNum("FetchBlobs")
+		c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxFeedBlobsAge))
+		c.JSON(http.StatusOK, BlobsResponse{
+			Meta: newPageMeta(len(metadatas), next),
+			Data: metadatas,
+		})
+		return
+	}
+
 	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	if err != nil {
 		limit = 10
@@ -445,6 +745,110 @@ func (s *server) FetchBlobsHandler(c *gin.Context) {
 	})
 }
 
+// FetchBatchesHandler godoc
+//
+//	@Summary		Fetch confirmed batches
+//	@Description	Returns the batches confirmed after the cursor in ascending order of batch ID, starting from the first batch if the cursor is empty or not set.
+//	@Tags			Feed
+//	@Produce		json
+//	@Param			limit	query		int		false	"Limit [default: 10]"
+//	@Param			cursor	query		string	false	"Cursor of the last batch of the previous page"
+//	@Success		200		{object}	BatchesResponse
+//	@Failure		400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure		500		{object}	ErrorResponse	"error: Server error"
+//	@Router			/feed/batches [get]
+func (s *server) FetchBatchesHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchBatches", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	cursor, _, err := parseCursor(c, BatchesCursor)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatches")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	limit, err := parsePageLimit(c)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatches")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	batches, next, err := s.getBatches(c.Request.Context(), cursor, limit)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatches")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchBatches")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxFeedBatchesAge))
+	c.JSON(http.StatusOK, BatchesResponse{
+		Meta: newPageMeta(len(batches), next),
+		Data: batches,
+	})
+}
+
+// FetchBlobExistenceHandler godoc
+//
+//	@Summary		Check whether a blob with the given commitment was confirmed in a batch range
+//	@Description	Returns the confirmed blobs if found, or a statement signed by the data API that no such blob was confirmed otherwise.
+//	@Description	The range is clamped to the last confirmed batch, and rejected if it starts after it. The statement only covers the blobs requested since its retained_since time, whose metadata is retained by the disperser.
+//	@Description	The existence queries are only served by the data API, the retriever has no index of the confirmed blobs to answer them.
+//	@Tags			Feed
+//	@Produce		json
+//	@Param			commitment		query		string	true	"Hex encoded compressed blob commitment"
+//	@Param			start_batch_id	query		int		true	"First batch ID of the range (inclusive)"
+//	@Param			end_batch_id	query		int		true	"Last batch ID of the range (inclusive)"
+//	@Success		200				{object}	BlobExistenceResponse
+//	@Failure		400				{object}	ErrorResponse	"error: Bad request"
+//	@Failure		404				{object}	ErrorResponse	"error: Not found"
+//	@Failure		500				{object}	ErrorResponse	"error: Server error"
+//	@Router			/feed/blob-existence [get]
+func (s *server) FetchBlobExistenceHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchBlobExistence", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	commitment, err := ParseBlobCommitment(c.Query("commitment"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBlobExistence")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	startBatchID, err := strconv.ParseUint(c.Query("start_batch_id"), 10, 32)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBlobExistence")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid start_batch_id parameter"})
+		return
+	}
+	endBatchID, err := strconv.ParseUint(c.Query("end_batch_id"), 10, 32)
+	if err != nil || endBatchID < startBatchID {
+		s.metrics.IncrementFailedRequestNum("FetchBlobExistence")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid end_batch_id parameter"})
+		return
+	}
+
+	response, err := s.getBlobExistence(c.Request.Context(), commitment, uint32(startBatchID), uint32(endBatchID))
+	if errors.Is(err, errBatchNotConfirmed) {
+		s.metrics.IncrementFailedRequestNum("FetchBlobExistence")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBlobExistence")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchBlobExistence")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxBlobExistenceAge))
+	c.JSON(http.StatusOK, response)
+}
+
 // FetchMetricsHandler godoc
 //
 //	@Summary	Fetch metrics
@@ -569,6 +973,8 @@ func (s *server) FetchNonSigners(c *gin.Context) {
 //	@Param		interval	query		int		false	"Interval to query for operators nonsigning percentage [default: 3600]"
 //	@Param		end			query		string	false	"End time (2006-01-02T15:04:05Z) to query for operators nonsigning percentage [default: now]"
 //	@Param		live_only	query		string	false	"Whether return only live nonsigners [default: true]"
+//	@Param		limit		query		int		false	"Limit, if the cursor is set [default: 10]"
+//	@Param		cursor		query		string	false	"If set, returns the operators after the cursor in ascending order of operator and quorum ID, starting from the first operator if the cursor is empty. The end time should be set so that the pages are consistent."
 //	@Success	200			{object}	OperatorsNonsigningPercentage
 //	@Failure	400			{object}	ErrorResponse	"error: Bad request"
 //	@Failure	404			{object}	ErrorResponse	"error: Not found"
@@ -605,6 +1011,19 @@ func (s *server) FetchOperatorsNonsigningPercentageHandler(c *gin.Context) {
 		}
 	}
 
+	cursor, paginated, err := parseCursor(c, OperatorsNonsigningCursor)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorsNonsigningPercentageHandler")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	limit, err := parsePageLimit(c)
+	if paginated && err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorsNonsigningPercentageHandler")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
 	startTime := endTime.Add(-time.Duration(interval) * time.Second)
 
 	metric, err := s.getOperatorNonsigningRate(c.Request.Context(), startTime.Unix(), endTime.Unix(), liveOnly == "true")
@@ -613,6 +1032,13 @@ func (s *server) FetchOperatorsNonsigningPercentageHandler(c *gin.Context) {
 		errorResponse(c, err)
 		return
 	}
+	if paginated {
+		page, next := paginateOperatorsNonsigning(metric.Data, cursor, limit)
+		metric = &OperatorsNonsigningPercentage{
+			Meta: newPageMeta(len(page), next),
+			Data: page,
+		}
+	}
 
 	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorsNonsigningPercentageHandler")
 	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorsNonsigningPercentageAge))
@@ -1,8 +1,10 @@
 package dataapi_test
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -12,6 +14,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,12 +30,16 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	sdkmock "github.com/Layr-Labs/eigensdk-go/chainio/clients/mocks"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ethereum/go-ethereum/common"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gin-gonic/gin"
+	"github.com/parquet-go/parquet-go"
 	"github.com/prometheus/common/model"
+	"github.com/shurcooL/graphql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/goleak"
@@ -82,6 +89,7 @@ var (
 	expectedConfirmationBlockNumber = uint32(150)
 	expectedSignatoryRecordHash     = [32]byte{0}
 	expectedFee                     = []byte{0}
+	expectedCost                    = &disperser.BlobCost{EncodingTime: time.Second, DispersalBytes: 4096, GasUsed: 1000, GasFee: 3000}
 	expectedInclusionProof          = []byte{1, 2, 3, 4, 5}
 	gettysburgAddressBytes          = []byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal. Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived, and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting-place for those who here gave their lives, that that nation might live. It is altogether fitting and proper that we should do this. But, in a larger sense, we cannot dedicate, we cannot consecrate—we cannot hallow—this ground. The brave men, living and dead, who struggled here, have consecrated it far above our poor power to add or detract. The world will little note, nor long remember what we say here, but it can never forget what they did here. It is for us the living, rather, to be dedicated here to the unfinished work which they who fought here have thus far so nobly advanced. It is rather for us to be here dedicated to the great task remaining before us—that from these honored dead we take increased devotion to that cause for which they here gave the last full measure of devotion—that we here highly resolve that these dead shall not have died in vain—that this nation, under God, shall have a new birth of freedom, and that government of the people, by the people, for the people, shall not perish from the earth.")
 )
@@ -188,10 +196,108 @@ func TestFetchBlobHandler(t *testing.T) {
 	assert.Equal(t, expectedConfirmationBlockNumber, uint32(response.ConfirmationBlockNumber))
 	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000123", response.ConfirmationTxnHash)
 	assert.Equal(t, hex.EncodeToString(expectedFee), response.Fee)
+	assert.Equal(t, expectedCost, response.Cost)
 	assert.Equal(t, blob.RequestHeader.SecurityParams, response.SecurityParams)
 	assert.Equal(t, uint64(5567830000), response.RequestAt)
 }
 
+func TestFetchBlobExistenceHandler(t *testing.T) {
+	r := setUpRouter()
+
+	signerKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signerConfig := config
+	signerConfig.NonInclusionSigner = signerKey
+	signerConfig.MetadataRetention = time.Hour
+	// The last confirmed batch is expectedBatchId+50
+	existenceSubgraphApi := &subgraphmock.MockSubgraphApi{}
+	existenceSubgraphApi.On("QueryBatches").Return([]*subgraph.Batches{{
+		Id:              "0x1",
+		BatchId:         graphql.String(fmt.Sprint(expectedBatchId + 50)),
+		BatchHeaderHash: "0x890588400acb4f9f7f438c0d21734acb36a6c4c75df6560827e23b452bbdcc69",
+		BlockTimestamp:  "1702666070",
+		BlockNumber:     "1",
+		TxHash:          "0x1",
+		GasFees:         subgraph.GasFees{Id: "0x1", GasPrice: "1", GasUsed: "1", TxFee: "1"},
+	}}, nil)
+	existenceSubgraphClient := dataapi.NewSubgraphClient(existenceSubgraphApi, mockLogger)
+	testServer := dataapi.NewServer(signerConfig, blobstore, prometheusClient, existenceSubgraphClient, mockTx, mockChainState, nil, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	unsignedServer := dataapi.NewServer(config, blobstore, prometheusClient, existenceSubgraphClient, mockTx, mockChainState, nil, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	r.GET("/v1/feed/blob-existence", testServer.FetchBlobExistenceHandler)
+	r.GET("/v1/feed/blob-existence-unsigned", unsignedServer.FetchBlobExistenceHandler)
+
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, blobstore)
+	markBlobConfirmed(t, &blob, key, expectedBatchHeaderHash, blobstore)
+	commitmentBytes := (*bn254.G1Affine)(expectedBlobCommitment.Commitment).Bytes()
+	commitment := hex.EncodeToString(commitmentBytes[:])
+
+	fetch := func(path string) (int, dataapi.BlobExistenceResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		var response dataapi.BlobExistenceResponse
+		if res.StatusCode == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(data, &response))
+		}
+		return res.StatusCode, response
+	}
+
+	// The blob is confirmed in batch expectedBatchId
+	status, response := fetch(fmt.Sprintf("/v1/feed/blob-existence?commitment=%s&start_batch_id=%d&end_batch_id=%d", commitment, expectedBatchId-1, expectedBatchId))
+	assert.Equal(t, http.StatusOK, status)
+	assert.True(t, response.Found)
+	assert.Nil(t, response.NonInclusion)
+	assert.NotEmpty(t, response.Blobs)
+	for _, b := range response.Blobs {
+		assert.Equal(t, expectedBatchId, b.BatchId)
+		assert.Equal(t, expectedBlobCommitment, b.BlobCommitment)
+	}
+
+	// No blob with the commitment was confirmed after expectedBatchId, up to the last confirmed batch
+	status, response = fetch(fmt.Sprintf("/v1/feed/blob-existence?commitment=0x%s&start_batch_id=%d&end_batch_id=%d", commitment, expectedBatchId+1, expectedBatchId+100))
+	assert.Equal(t, http.StatusOK, status)
+	assert.False(t, response.Found)
+	assert.Empty(t, response.Blobs)
+	assert.NotNil(t, response.NonInclusion)
+	assert.Equal(t, commitment, response.NonInclusion.BlobCommitment)
+	assert.Equal(t, expectedBatchId+1, response.NonInclusion.StartBatchId)
+	assert.Equal(t, expectedBatchId+50, response.NonInclusion.EndBatchId)
+	assert.Equal(t, time.Hour, time.Duration(response.NonInclusion.Timestamp-response.NonInclusion.RetainedSince)*time.Second)
+	signer := crypto.PubkeyToAddress(signerKey.PublicKey)
+	assert.Equal(t, signer.Hex(), response.NonInclusion.Signer)
+	assert.NoError(t, response.NonInclusion.Verify(signer))
+	otherKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	assert.Error(t, response.NonInclusion.Verify(crypto.PubkeyToAddress(otherKey.PublicKey)))
+	tampered := *response.NonInclusion
+	tampered.EndBatchId = expectedBatchId + 1
+	assert.Error(t, tampered.Verify(signer))
+	tampered = *response.NonInclusion
+	tampered.RetainedSince = 0
+	assert.Error(t, tampered.Verify(signer))
+
+	// The range can't start after the last confirmed batch
+	status, _ = fetch(fmt.Sprintf("/v1/feed/blob-existence?commitment=%s&start_batch_id=%d&end_batch_id=%d", commitment, expectedBatchId+51, expectedBatchId+100))
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// Negative statements can't be made without a signer
+	status, _ = fetch(fmt.Sprintf("/v1/feed/blob-existence-unsigned?commitment=%s&start_batch_id=%d&end_batch_id=%d", commitment, expectedBatchId+1, expectedBatchId+100))
+	assert.Equal(t, http.StatusInternalServerError, status)
+
+	// Invalid requests
+	status, _ = fetch(fmt.Sprintf("/v1/feed/blob-existence?commitment=%s&start_batch_id=1&end_batch_id=2", commitment[:10]))
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetch(fmt.Sprintf("/v1/feed/blob-existence?commitment=%s&end_batch_id=2", commitment))
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetch(fmt.Sprintf("/v1/feed/blob-existence?commitment=%s&start_batch_id=3&end_batch_id=2", commitment))
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
 func TestFetchBlobsHandler(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -233,6 +339,115 @@ func TestFetchBlobsHandler(t *testing.T) {
 	assert.Equal(t, 2, len(response.Data))
 }
 
+func TestFetchBlobsHandlerPaginated(t *testing.T) {
+	r := setUpRouter()
+	store := inmem.NewBlobStore()
+	testServer := dataapi.NewServer(config, store, prometheusClient, subgraphClient, mockTx, mockChainState, nil, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+
+	// batch 1 has no blob
+	numBlobsPerBatch := map[string]int{"0": 2, "1": 0, "2": 3}
+	for _, batch := range subgraphBatches {
+		batchHeaderHash, err := dataapi.ConvertHexadecimalToBytes([]byte(batch.BatchHeaderHash))
+		assert.NoError(t, err)
+		var batchID uint32
+		_, err = fmt.Sscan(string(batch.BatchId), &batchID)
+		assert.NoError(t, err)
+		for i := 0; i < numBlobsPerBatch[string(batch.BatchId)]; i++ {
+			blob := makeTestBlob(0, 10)
+			blob.Data = append(blob.Data, byte(batchID), byte(i))
+			key := queueBlob(t, &blob, store)
+			markBlobConfirmedInBatch(t, &blob, key, batchHeaderHash, batchID, uint32(i), store)
+		}
+	}
+	mockSubgraphApi.On("QueryBatchesFromBatchId").Return(subgraphBatches, nil)
+
+	r.GET("/v1/feed/blobs", testServer.FetchBlobsHandler)
+
+	fetch := func(query string) (int, dataapi.BlobsResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/feed/blobs?"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		var response dataapi.BlobsResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+		assert.NoError(t, err)
+		return res.StatusCode, response
+	}
+
+	type position struct {
+		batchID   uint32
+		blobIndex uint32
+	}
+	var positions []position
+	cursor := ""
+	for i := 0; i < 3; i++ {
+		status, response := fetch("limit=2&cursor=" + cursor)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, len(response.Data), response.Meta.Size)
+		for _, blob := range response.Data {
+			positions = append(positions, position{blob.BatchId, blob.BlobIndex})
+		}
+		assert.NotEmpty(t, response.Meta.NextCursor)
+		cursor = response.Meta.NextCursor
+	}
+	assert.Equal(t, []position{{0, 0}, {0, 1}, {2, 0}, {2, 1}, {2, 2}}, positions)
+
+	// the listing is resumed from the last blob, and a page past the last blob is empty
+	status, response := fetch("cursor=" + dataapi.NewBlobCursor(2, 0).Encode())
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2, response.Meta.Size)
+	assert.Equal(t, dataapi.NewBlobCursor(2, 2).Encode(), response.Meta.NextCursor)
+	status, response = fetch("cursor=" + cursor)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 0, response.Meta.Size)
+	assert.Empty(t, response.Meta.NextCursor)
+
+	status, _ = fetch("cursor=invalid")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetch("cursor=" + dataapi.NewBatchCursor(0).Encode())
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetch("limit=0&cursor=")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestFetchBatchesHandler(t *testing.T) {
+	r := setUpRouter()
+
+	mockSubgraphApi.On("QueryBatchesFromBatchId").Return(subgraphBatches, nil)
+	r.GET("/v1/feed/batches", testDataApiServer.FetchBatchesHandler)
+
+	fetch := func(query string) dataapi.BatchesResponse {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/feed/batches?"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var response dataapi.BatchesResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+		assert.NoError(t, err)
+		return response
+	}
+
+	response := fetch("limit=2")
+	assert.Equal(t, 2, response.Meta.Size)
+	assert.Equal(t, uint32(0), response.Data[0].BatchId)
+	assert.Equal(t, "e1cdae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568310", response.Data[0].BatchHeaderHash)
+	assert.Equal(t, uint64(86), response.Data[0].BlockNumber)
+	assert.Equal(t, uint64(249826325612840), response.Data[0].TxFee)
+	assert.Equal(t, uint32(1), response.Data[1].BatchId)
+	assert.Equal(t, dataapi.NewBatchCursor(1).Encode(), response.Meta.NextCursor)
+
+	response = fetch("limit=2&cursor=" + response.Meta.NextCursor)
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, uint32(2), response.Data[0].BatchId)
+
+	response = fetch("cursor=" + response.Meta.NextCursor)
+	assert.Equal(t, 0, response.Meta.Size)
+	assert.Empty(t, response.Meta.NextCursor)
+}
+
 func TestFetchMetricsHandler(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -437,6 +652,54 @@ func TestFetchUnsignedBatchesHandler(t *testing.T) {
 	assert.Equal(t, float64(25), responseData.StakePercentage)
 }
 
+func TestFetchOperatorsNonsigningPercentagePaginated(t *testing.T) {
+	r := setUpRouter()
+
+	stopTime := time.Now().UTC()
+	interval := 3600
+	startTime := stopTime.Add(-time.Duration(interval) * time.Second)
+
+	mockSubgraphApi.On("QueryBatchNonSigningInfo", startTime.Unix(), stopTime.Unix()).Return(batchNonSigningInfo, nil)
+	addr1 := gethcommon.HexToAddress("0x00000000219ab540356cbb839cbe05303d7705fa")
+	addr2 := gethcommon.HexToAddress("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2")
+	mockTx.On("BatchOperatorIDToAddress").Return([]gethcommon.Address{addr1, addr2}, nil)
+	mockTx.On("GetQuorumBitmapForOperatorsAtBlockNumber").Return([]*big.Int{big.NewInt(3), big.NewInt(0)}, nil)
+	mockSubgraphApi.On("QueryOperatorAddedToQuorum").Return(operatorAddedToQuorum, nil)
+	mockSubgraphApi.On("QueryOperatorRemovedFromQuorum").Return(operatorRemovedFromQuorum, nil)
+
+	r.GET("/v1/metrics/operator-nonsigning-percentage", testDataApiServer.FetchOperatorsNonsigningPercentageHandler)
+
+	fetch := func(cursor string) dataapi.OperatorsNonsigningPercentage {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v1/metrics/operator-nonsigning-percentage?interval=%v&end=%s&limit=1&cursor=%s", interval, stopTime.Format("2006-01-02T15:04:05Z"), cursor)
+		req := httptest.NewRequest(http.MethodGet, reqStr, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var response dataapi.OperatorsNonsigningPercentage
+		err := json.NewDecoder(res.Body).Decode(&response)
+		assert.NoError(t, err)
+		return response
+	}
+
+	operatorId := "0xe22dae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568311"
+	response := fetch("")
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, operatorId, response.Data[0].OperatorId)
+	assert.Equal(t, uint8(0), response.Data[0].QuorumId)
+	assert.Equal(t, dataapi.NewOperatorNonsigningCursor(operatorId, 0).Encode(), response.Meta.NextCursor)
+
+	response = fetch(response.Meta.NextCursor)
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, operatorId, response.Data[0].OperatorId)
+	assert.Equal(t, uint8(1), response.Data[0].QuorumId)
+
+	response = fetch(response.Meta.NextCursor)
+	assert.Equal(t, 0, response.Meta.Size)
+	assert.Empty(t, response.Meta.NextCursor)
+}
+
 type ejectorComponents struct {
 	wallet    *sdkmock.MockWallet
 	ethClient *commonmock.MockEthClient
@@ -1462,6 +1725,10 @@ func queueBlob(t *testing.T, blob *core.Blob, queue disperser.BlobStore) dispers
 }
 
 func markBlobConfirmed(t *testing.T, blob *core.Blob, key disperser.BlobKey, batchHeaderHash [32]byte, queue disperser.BlobStore) {
+	markBlobConfirmedInBatch(t, blob, key, batchHeaderHash, expectedBatchId, expectedBlobIndex, queue)
+}
+
+func markBlobConfirmedInBatch(t *testing.T, blob *core.Blob, key disperser.BlobKey, batchHeaderHash [32]byte, batchID, blobIndex uint32, queue disperser.BlobStore) {
 	// simulate blob confirmation
 	var commitX, commitY fp.Element
 	_, err := commitX.SetString("21661178944771197726808973281966770251114553549453983978976194544185382599016")
@@ -1475,7 +1742,7 @@ func markBlobConfirmed(t *testing.T, blob *core.Blob, key disperser.BlobKey, bat
 
 	confirmationInfo := &disperser.ConfirmationInfo{
 		BatchHeaderHash:      batchHeaderHash,
-		BlobIndex:            expectedBlobIndex,
+		BlobIndex:            blobIndex,
 		SignatoryRecordHash:  expectedSignatoryRecordHash,
 		ReferenceBlockNumber: expectedReferenceBlockNumber,
 		BatchRoot:            expectedBatchRoot,
@@ -1484,10 +1751,11 @@ func markBlobConfirmed(t *testing.T, blob *core.Blob, key disperser.BlobKey, bat
 			Commitment: commitment,
 			Length:     uint(expectedDataLength),
 		},
-		BatchID:                 expectedBatchId,
+		BatchID:                 batchID,
 		ConfirmationTxnHash:     common.HexToHash("0x123"),
 		ConfirmationBlockNumber: expectedConfirmationBlockNumber,
 		Fee:                     expectedFee,
+		Cost:                    expectedCost,
 	}
 	metadata := &disperser.BlobMetadata{
 		BlobHash:     key.BlobHash,
@@ -1560,3 +1828,140 @@ func getOperatorData(operatorMetadtas []*dataapi.DeregisteredOperatorMetadata, o
 	return dataapi.DeregisteredOperatorMetadata{}
 
 }
+
+func TestExportHandlers(t *testing.T) {
+	r := setUpRouter()
+	storage := commonmock.NewS3Client()
+	exportConfig := config
+	exportConfig.ExportStorage = storage
+	exportConfig.ExportBucketName = "exports"
+	exportConfig.ExportToken = "export-token"
+	testServer := dataapi.NewServer(exportConfig, blobstore, prometheusClient, subgraphClient, mockTx, mockChainState, nil, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	r.POST("/v1/exports", testServer.CreateExportHandler)
+	r.GET("/v1/exports", testServer.FetchExportsHandler)
+	r.GET("/v1/exports/:job_id", testServer.FetchExportHandler)
+	r.POST("/v1/exports-disabled", testDataApiServer.CreateExportHandler)
+
+	mockSubgraphApi.On("QueryBatchesByBlockTimestampRange").Return(subgraphBatches, nil)
+
+	serve := func(method, path, token string, body string, response any) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("export_token", token)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		if response != nil && res.StatusCode < 300 {
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(response))
+		}
+		return res.StatusCode
+	}
+	export := func(format string) dataapi.ExportJob {
+		var job dataapi.ExportJob
+		status := serve(http.MethodPost, "/v1/exports", "export-token", fmt.Sprintf(`{"dataset":"batches","format":"%s","start":1702600000,"end":1702700000}`, format), &job)
+		assert.Equal(t, http.StatusAccepted, status)
+		assert.Eventually(t, func() bool {
+			assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/v1/exports/"+job.JobId, "export-token", "", &job))
+			return job.Status == dataapi.ExportSucceeded || job.Status == dataapi.ExportFailed
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, dataapi.ExportSucceeded, job.Status)
+		assert.Equal(t, len(subgraphBatches), job.NumRows)
+		assert.Equal(t, "exports", job.Bucket)
+		return job
+	}
+
+	csvJob := export("csv")
+	data, err := storage.DownloadObject(context.Background(), "exports", csvJob.ObjectKey)
+	assert.NoError(t, err)
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, len(subgraphBatches)+1)
+	assert.Equal(t, []string{"batch_id", "batch_header_hash", "block_number", "block_timestamp", "confirmation_txn_hash", "gas_used", "gas_price", "tx_fee"}, records[0])
+	byBatchId := make(map[string][]string)
+	for _, record := range records[1:] {
+		byBatchId[record[0]] = record
+	}
+	assert.Equal(t, "890588400acb4f9f7f438c0d21734acb36a6c4c75df6560827e23b452bbdcc69", byBatchId["1"][1])
+	assert.Equal(t, "249826325612840", byBatchId["1"][7])
+
+	parquetJob := export("parquet")
+	data, err = storage.DownloadObject(context.Background(), "exports", parquetJob.ObjectKey)
+	assert.NoError(t, err)
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(subgraphBatches)), file.NumRows())
+	_, ok := file.Schema().Lookup("tx_fee")
+	assert.True(t, ok)
+
+	var jobs dataapi.ExportJobsResponse
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/v1/exports", "export-token", "", &jobs))
+	assert.Equal(t, 2, jobs.Meta.Size)
+	assert.Equal(t, parquetJob.JobId, jobs.Data[0].JobId)
+
+	// Invalid, unauthorized and unconfigured requests
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/v1/exports", "export-token", `{"dataset":"batches","format":"xlsx","start":1,"end":2}`, nil))
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/v1/exports", "export-token", `{"dataset":"batches","format":"csv","start":2,"end":1}`, nil))
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/v1/exports", "export-token", `{"dataset":"batches","format":"csv","start":1,"end":2000000000}`, nil))
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "/v1/exports", "wrong-token", `{"dataset":"batches","format":"csv","start":1,"end":2}`, nil))
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/v1/exports/unknown", "export-token", "", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodPost, "/v1/exports-disabled", "export-token", `{"dataset":"batches","format":"csv","start":1,"end":2}`, nil))
+}
+
+func TestFetchAccountUsageHandler(t *testing.T) {
+	r := setUpRouter()
+	accountingConfig := config
+	accountingConfig.AccountingToken = "accounting-token"
+	testServer := dataapi.NewServer(accountingConfig, blobstore, prometheusClient, subgraphClient, mockTx, mockChainState, nil, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	r.GET("/v1/accounts/:account_id/usage", testServer.FetchAccountUsageHandler)
+
+	period := uint64(disperser.UsagePeriod.Seconds())
+	for i, usage := range []*disperser.AccountUsage{
+		{AccountID: "account", PeriodStart: 10 * period, NumBlobs: 1, BlobBytes: 100},
+		{AccountID: "account", PeriodStart: 10 * period, NumBlobs: 2, BlobBytes: 300, GasFee: 7},
+		{AccountID: "account", PeriodStart: 12 * period, NumBlobs: 1, BlobBytes: 50},
+		{AccountID: "other", PeriodStart: 10 * period, NumBlobs: 1, BlobBytes: 10},
+		{AccountID: disperser.TenantAccountKey("tenant", "account"), PeriodStart: 10 * period, NumBlobs: 4, BlobBytes: 20},
+	} {
+		blobKey := disperser.BlobKey{BlobHash: fmt.Sprintf("usage-blob-%d", i), MetadataHash: "hash"}
+		assert.NoError(t, blobstore.AddAccountUsage(context.Background(), blobKey, usage))
+	}
+
+	serve := func(path, token string) (int, dataapi.AccountUsageResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("accounting_token", token)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		var response dataapi.AccountUsageResponse
+		if res.StatusCode == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		}
+		return res.StatusCode, response
+	}
+
+	// The usage periods overlapping the range are returned, in order
+	status, response := serve(fmt.Sprintf("/v1/accounts/account/usage?start=%d&end=%d", 10*period+1, 13*period), "accounting-token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2, response.Meta.Size)
+	assert.Equal(t, 10*period, response.Data[0].PeriodStart)
+	assert.Equal(t, uint64(3), response.Data[0].NumBlobs)
+	assert.Equal(t, uint64(400), response.Data[0].BlobBytes)
+	assert.Equal(t, uint64(7), response.Data[0].GasFee)
+	assert.Equal(t, 12*period, response.Data[1].PeriodStart)
+
+	// The account of a tenant is billed apart from the account with the same ID of the default tenant
+	status, response = serve(fmt.Sprintf("/v1/accounts/account/usage?tenant_id=tenant&start=%d&end=%d", 10*period, 13*period), "accounting-token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, uint64(4), response.Data[0].NumBlobs)
+
+	status, response = serve(fmt.Sprintf("/v1/accounts/account/usage?start=%d&end=%d", 11*period, 12*period), "accounting-token")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 0, response.Meta.Size)
+
+	status, _ = serve(fmt.Sprintf("/v1/accounts/account/usage?start=%d&end=%d", 12*period, 10*period), "accounting-token")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = serve("/v1/accounts/account/usage", "wrong-token")
+	assert.Equal(t, http.StatusUnauthorized, status)
+}
@@ -19,6 +19,7 @@ type (
 	Api interface {
 		QueryBatches(ctx context.Context, descending bool, orderByField string, first, skip int) ([]*Batches, error)
 		QueryBatchesByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*Batches, error)
+		QueryBatchesFromBatchId(ctx context.Context, startBatchId uint64, first int) ([]*Batches, error)
 		QueryOperators(ctx context.Context, first int) ([]*Operator, error)
 		QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) ([]*BatchNonSigningOperatorIds, error)
 		QueryBatchNonSigningInfo(ctx context.Context, startTime, endTime int64) ([]*BatchNonSigningInfo, error)
@@ -97,6 +98,22 @@ func (a *api) QueryBatchesByBlockTimestampRange(ctx context.Context, start, end
 	return result, nil
 }
 
+// QueryBatchesFromBatchId returns the first batches whose ID is greater than or equal to startBatchId,
+// in ascending order of batch ID.
+func (a *api) QueryBatchesFromBatchId(ctx context.Context, startBatchId uint64, first int) ([]*Batches, error) {
+	variables := map[string]any{
+		"first":       graphql.Int(first),
+		"batchId_gte": graphql.Int(startBatchId),
+	}
+	result := new(queryBatchesFromBatchId)
+	err := a.uiMonitoringGql.Query(ctx, result, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Batches, nil
+}
+
 func (a *api) QueryOperators(ctx context.Context, first int) ([]*Operator, error) {
 	variables := map[string]any{
 		"first": graphql.Int(first),
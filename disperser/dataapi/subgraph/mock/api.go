@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"context"
 	"slices"
+	"strconv"
 
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
 	"github.com/stretchr/testify/mock"
@@ -51,6 +52,33 @@ func (m *MockSubgraphApi) QueryBatchesByBlockTimestampRange(ctx context.Context,
 	return value, args.Error(1)
 }
 
+func (m *MockSubgraphApi) QueryBatchesFromBatchId(ctx context.Context, startBatchId uint64, first int) ([]*subgraph.Batches, error) {
+	args := m.Called()
+
+	var value []*subgraph.Batches
+	if args.Get(0) != nil {
+		for _, batch := range args.Get(0).([]*subgraph.Batches) {
+			batchId, err := strconv.ParseUint(string(batch.BatchId), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if batchId >= startBatchId {
+				value = append(value, batch)
+			}
+		}
+		slices.SortStableFunc(value, func(a, b *subgraph.Batches) int {
+			aId, _ := strconv.ParseUint(string(a.BatchId), 10, 64)
+			bId, _ := strconv.ParseUint(string(b.BatchId), 10, 64)
+			return cmp.Compare(aId, bId)
+		})
+		if first > 0 && len(value) > first {
+			value = value[:first]
+		}
+	}
+
+	return value, args.Error(1)
+}
+
 func (m *MockSubgraphApi) QueryOperators(ctx context.Context, first int) ([]*subgraph.Operator, error) {
 	args := m.Called()
 
@@ -82,6 +82,9 @@ type (
 	queryBatches struct {
 		Batches []*Batches `graphql:"batches(orderDirection: $orderDirection, orderBy: $orderBy, first: $first, skip: $skip)"`
 	}
+	queryBatchesFromBatchId struct {
+		Batches []*Batches `graphql:"batches(first: $first, orderBy: batchId, orderDirection: asc, where: {batchId_gte: $batchId_gte})"`
+	}
 	queryBatchesByBlockTimestampRange struct {
 		Batches []*Batches `graphql:"batches(first: $first, skip: $skip, orderBy: blockTimestamp, where: {and: [{ blockTimestamp_gte: $blockTimestamp_gte}, {blockTimestamp_lte: $blockTimestamp_lte}]})"`
 	}
@@ -22,6 +22,8 @@ const (
 type (
 	SubgraphClient interface {
 		QueryBatchesWithLimit(ctx context.Context, limit, skip int) ([]*Batch, error)
+		QueryBatchesFromBatchId(ctx context.Context, startBatchId uint64, limit int) ([]*Batch, error)
+		QueryBatchesInTimeRange(ctx context.Context, start, end uint64) ([]*Batch, error)
 		QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error)
 		QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) (map[string]int, error)
 		QueryBatchNonSigningInfoInInterval(ctx context.Context, startTime, endTime int64) ([]*BatchNonSigningInfo, error)
@@ -111,6 +113,23 @@ func (sc *subgraphClient) QueryBatchesWithLimit(ctx context.Context, limit, skip
 	return batches, nil
 }
 
+func (sc *subgraphClient) QueryBatchesFromBatchId(ctx context.Context, startBatchId uint64, limit int) ([]*Batch, error) {
+	subgraphBatches, err := sc.api.QueryBatchesFromBatchId(ctx, startBatchId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return convertBatches(subgraphBatches)
+}
+
+// QueryBatchesInTimeRange returns the batches confirmed in a block with timestamp in [start, end].
+func (sc *subgraphClient) QueryBatchesInTimeRange(ctx context.Context, start, end uint64) ([]*Batch, error) {
+	subgraphBatches, err := sc.api.QueryBatchesByBlockTimestampRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return convertBatches(subgraphBatches)
+}
+
 func (sc *subgraphClient) QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error) {
 	operatorsGql, err := sc.api.QueryOperators(ctx, limit)
 	if err != nil {
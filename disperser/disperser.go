@@ -7,13 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding"
 
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type BlobStatus uint
@@ -53,6 +56,12 @@ type BlobKey struct {
 	MetadataHash MetadataHash
 }
 
+// ComputePayloadHash returns the keccak256 hash of the blob data, which is the payload hash
+// clients can provide when dispersing and which is returned along with the blob header.
+func ComputePayloadHash(data []byte) []byte {
+	return crypto.Keccak256(data)
+}
+
 func (mk BlobKey) String() string {
 	return fmt.Sprintf("%s-%s", mk.BlobHash, mk.MetadataHash)
 }
@@ -108,6 +117,9 @@ type RequestMetadata struct {
 	core.BlobRequestHeader
 	BlobSize    uint   `json:"blob_size"`
 	RequestedAt uint64 `json:"requested_at"`
+	// PayloadHash is the keccak256 hash of the blob data, see ComputePayloadHash.
+	// It is nil for blobs stored before the hash was recorded.
+	PayloadHash []byte `json:"payload_hash"`
 }
 
 type ConfirmationInfo struct {
@@ -125,6 +137,79 @@ type ConfirmationInfo struct {
 	Fee                     []byte                               `json:"fee"`
 	QuorumResults           map[core.QuorumID]*core.QuorumResult `json:"quorum_results"`
 	BlobQuorumInfos         []*core.BlobQuorumInfo               `json:"blob_quorum_infos"`
+	// Cost is the cost of dispersing the blob. It is nil for blobs confirmed before the costs were recorded
+	Cost *BlobCost `json:"cost"`
+	// AttestationTimeout is the attestation window the operators had to sign the batch, i.e. the SLA of their
+	// responses
+	AttestationTimeout time.Duration `json:"attestation_timeout"`
+	// OperatorResponses are the responses of the operators the batch was dispersed to
+	OperatorResponses []*OperatorResponse `json:"operator_responses"`
+}
+
+// OperatorResponse is the response of an operator to the dispersal of a batch, reported against the attestation
+// window of the batch
+type OperatorResponse struct {
+	OperatorID core.OperatorID `json:"operator_id"`
+	// ResponseTime is the time the operator took to respond, capped at the attestation window
+	ResponseTime time.Duration `json:"response_time"`
+	// Signed is whether the signature of the operator is part of the attestation of the batch
+	Signed bool `json:"signed"`
+	// WithinSLA is whether the operator signed within the attestation window
+	WithinSLA bool `json:"within_sla"`
+}
+
+// BlobCost is the cost attributed to a blob for its dispersal, for usage-based billing and capacity planning
+type BlobCost struct {
+	// EncodingTime is the total time spent encoding the blob for all its quorums
+	EncodingTime time.Duration `json:"encoding_time"`
+	// DispersalBytes is the total size of the chunks of the blob sent to the operators
+	DispersalBytes uint64 `json:"dispersal_bytes"`
+	// GasUsed is the share of the gas used by the confirmation transaction of the batch attributed to the blob.
+	// The confirmation cost doesn't depend on the blobs, so it's split evenly across the blobs of the batch
+	GasUsed uint64 `json:"gas_used"`
+	// GasFee is the share of the fee paid for the confirmation transaction of the batch attributed to the blob, in wei
+	GasFee uint64 `json:"gas_fee"`
+}
+
+// AccountUsage is the usage of the disperser by an account over a usage period, which the account is billed for.
+// The usage periods are UsagePeriod long, and start at the multiples of UsagePeriod since the unix epoch.
+type AccountUsage struct {
+	// AccountID is the key of the account in its tenant, see TenantAccountKey, so that the accounts of different tenants
+	// are billed apart
+	AccountID string `json:"account_id"`
+	// PeriodStart is the unix time in seconds at which the usage period starts
+	PeriodStart uint64 `json:"period_start"`
+	// NumBlobs is the number of blobs confirmed for the account in the period
+	NumBlobs uint64 `json:"num_blobs"`
+	// BlobBytes is the total size of the blobs
+	BlobBytes uint64 `json:"blob_bytes"`
+	// DispersalBytes is the total size of the chunks of the blobs sent to the operators
+	DispersalBytes uint64 `json:"dispersal_bytes"`
+	// EncodingTime is the total time spent encoding the blobs
+	EncodingTime time.Duration `json:"encoding_time"`
+	// GasFee is the total share of the fees of the confirmation transactions attributed to the blobs, in wei
+	GasFee uint64 `json:"gas_fee"`
+	// Settled is whether the usage was settled with the account, see UsageSettler
+	Settled bool `json:"settled"`
+}
+
+// IdempotencyRecord is the record of the request which reserved an idempotency key
+type IdempotencyRecord struct {
+	// PayloadHash is the payload hash of the blob of the request, see ComputePayloadHash
+	PayloadHash []byte
+	// BlobKey is the key of the blob of the request. It is nil until the blob has been stored.
+	BlobKey *BlobKey
+	// Expiry is the unix time in seconds after which the idempotency key can be reserved again
+	Expiry uint64
+}
+
+// TenantAccountKey returns the key the blobs of the account dispersed for the tenant are indexed by, so that the
+// accounts of different tenants are kept apart. It's the account ID itself for the default tenant.
+func TenantAccountKey(tenantID string, accountID string) string {
+	if tenantID == "" {
+		return accountID
+	}
+	return tenantID + "/" + accountID
 }
 
 type BlobStoreExclusiveStartKey struct {
@@ -132,11 +217,33 @@ type BlobStoreExclusiveStartKey struct {
 	MetadataHash MetadataHash
 	BlobStatus   int32 // BlobStatus is an integer
 	RequestedAt  int64 //  RequestedAt is epoch time in seconds
+	// AccountID is the account of the blob, set only by GetBlobMetadataByAccountWithPagination
+	AccountID string `dynamodbav:"AccountIndexKey,omitempty"`
+	// BlobIndex is the index of the blob in its batch, set only by GetBlobMetadataByBatchWithPagination
+	BlobIndex uint32 `dynamodbav:"BlobIndex,omitempty"`
 }
 
 type BlobStore interface {
 	// StoreBlob adds a blob to the queue and returns a key that can be used to retrieve the blob later
 	StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (BlobKey, error)
+	// ReserveIdempotencyKey reserves the idempotency key for a request with the given payload hash until the expiry,
+	// and returns nil. If the key is reserved by a request which hasn't expired, it returns the record of that request
+	// instead.
+	ReserveIdempotencyKey(ctx context.Context, key string, payloadHash []byte, expiry uint64) (*IdempotencyRecord, error)
+	// SetIdempotencyKeyBlob records the blob stored for the request which reserved the idempotency key, and extends
+	// the reservation until the expiry
+	SetIdempotencyKeyBlob(ctx context.Context, key string, blobKey BlobKey, expiry uint64) error
+	// ReleaseIdempotencyKey releases the idempotency key of a request whose blob wasn't stored, so that it can be retried
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	// AddAccountUsage adds the usage of the blob to the usage of the account in the usage period starting at
+	// usage.PeriodStart. The usage of a blob is only added once, so that a blob confirmed again after its batch was
+	// dropped by a reorg isn't billed twice.
+	AddAccountUsage(ctx context.Context, blobKey BlobKey, usage *AccountUsage) error
+	// GetAccountUsage returns the usage of the account in the usage periods starting in [from, to), ordered by period.
+	// The account is identified by its TenantAccountKey.
+	GetAccountUsage(ctx context.Context, accountID string, from, to uint64) ([]*AccountUsage, error)
+	// MarkAccountUsageSettled marks the usage of the account in the usage period starting at periodStart as settled
+	MarkAccountUsageSettled(ctx context.Context, accountID string, periodStart uint64) error
 	// GetBlobContent retrieves a blob's content
 	GetBlobContent(ctx context.Context, blobHash BlobHash) ([]byte, error)
 	// MarkBlobConfirmed updates blob metadata to Confirmed status with confirmation info
@@ -151,6 +258,9 @@ type BlobStore interface {
 	MarkBlobFinalized(ctx context.Context, blobKey BlobKey) error
 	// MarkBlobProcessing marks a blob as processing
 	MarkBlobProcessing(ctx context.Context, blobKey BlobKey) error
+	// MarkBlobReorged returns a confirmed blob whose batch was dropped by a reorg to Processing status and removes its
+	// confirmation info. Returns the updated metadata and error
+	MarkBlobReorged(ctx context.Context, existingMetadata *BlobMetadata) (*BlobMetadata, error)
 	// MarkBlobFailed marks a blob as failed
 	MarkBlobFailed(ctx context.Context, blobKey BlobKey) error
 	// IncrementBlobRetryCount increments the retry count of a blob
@@ -164,8 +274,18 @@ type BlobStore interface {
 	// GetBlobMetadataByStatusWithPagination returns a list of blob metadata for blobs with the given status
 	// Results are limited to the given limit and the pagination token is returned
 	GetBlobMetadataByStatusWithPagination(ctx context.Context, blobStatus BlobStatus, limit int32, exclusiveStartKey *BlobStoreExclusiveStartKey) ([]*BlobMetadata, *BlobStoreExclusiveStartKey, error)
+	// GetBlobMetadataByAccountWithPagination returns the metadata of the blobs dispersed by the account that were
+	// requested in [from, to), in nanoseconds, ordered by request time. The account is scoped to its tenant with
+	// TenantAccountKey. Results are limited to the given limit and the pagination token is returned
+	GetBlobMetadataByAccountWithPagination(ctx context.Context, accountID string, from, to uint64, limit int32, exclusiveStartKey *BlobStoreExclusiveStartKey) ([]*BlobMetadata, *BlobStoreExclusiveStartKey, error)
 	// GetAllBlobMetadataByBatch returns the metadata of all the blobs in the batch.
 	GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*BlobMetadata, error)
+	// GetBlobMetadataByBatchWithPagination returns the metadata of the blobs in the batch ordered by blob index.
+	// Results are limited to the given limit and the pagination token is returned
+	GetBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *BlobStoreExclusiveStartKey) ([]*BlobMetadata, *BlobStoreExclusiveStartKey, error)
+	// GetBlobMetadataByCommitment returns the metadata of the confirmed or finalized blobs with the given commitment
+	// that were confirmed in a batch with ID in the inclusive range [startBatchID, endBatchID].
+	GetBlobMetadataByCommitment(ctx context.Context, commitment *encoding.G1Commitment, startBatchID, endBatchID uint32) ([]*BlobMetadata, error)
 	// GetBlobMetadata returns a blob metadata given a metadata key
 	GetBlobMetadata(ctx context.Context, blobKey BlobKey) (*BlobMetadata, error)
 	// HandleBlobFailure handles a blob failure by either incrementing the retry count or marking the blob as failed
@@ -189,6 +309,13 @@ func GenerateReverseIndexKey(batchHeaderHash [32]byte, blobIndex uint32) (string
 	return hex.EncodeToString(sha256.New().Sum(bytes)), nil
 }
 
+// GenerateCommitmentIndexKey returns the key used to index blobs by their commitment.
+// The key is the hex encoding of the compressed commitment point.
+func GenerateCommitmentIndexKey(commitment *encoding.G1Commitment) string {
+	commitmentBytes := (*bn254.G1Affine)(commitment).Bytes()
+	return hex.EncodeToString(commitmentBytes[:])
+}
+
 func FromBlobStatusProto(status disperser_rpc.BlobStatus) (*BlobStatus, error) {
 	var res BlobStatus
 	switch status {
@@ -13,21 +13,29 @@ import (
 )
 
 type client struct {
-	addr    string
-	timeout time.Duration
+	addr        string
+	timeout     time.Duration
+	credentials grpc.DialOption
 }
 
-func NewEncoderClient(addr string, timeout time.Duration) (disperser.EncoderClient, error) {
-	return client{
-		addr:    addr,
-		timeout: timeout,
-	}, nil
+// NewEncoderClient returns a client of the encoder at the address, which is dialed with the transport credentials if
+// given, or in plaintext otherwise.
+func NewEncoderClient(addr string, timeout time.Duration, credentials ...grpc.DialOption) (disperser.EncoderClient, error) {
+	c := client{
+		addr:        addr,
+		timeout:     timeout,
+		credentials: grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if len(credentials) > 0 {
+		c.credentials = credentials[0]
+	}
+	return c, nil
 }
 
 func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, []*encoding.Frame, error) {
 	conn, err := grpc.Dial(
 		c.addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		c.credentials,
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024*1024*1024)), // 1 GiB
 	)
 	if err != nil {
@@ -35,6 +43,11 @@ func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams enco
 	}
 	defer conn.Close()
 
+	priority := pb.Priority_BULK
+	if disperser.EncodingPriorityFromContext(ctx) == disperser.LatencyEncoding {
+		priority = pb.Priority_LATENCY
+	}
+
 	encoder := pb.NewEncoderClient(conn)
 	reply, err := encoder.EncodeBlob(ctx, &pb.EncodeBlobRequest{
 		Data: data,
@@ -42,21 +55,22 @@ func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams enco
 			ChunkLength: uint32(encodingParams.ChunkLength),
 			NumChunks:   uint32(encodingParams.NumChunks),
 		},
+		Priority: priority,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
 
-	commitment, err := new(encoding.G1Commitment).Deserialize(reply.GetCommitment().GetCommitment())
-	if err != nil {
+	var commitment encoding.G1Commitment
+	if err := commitment.UnmarshalBinary(reply.GetCommitment().GetCommitment()); err != nil {
 		return nil, nil, err
 	}
-	lengthCommitment, err := new(encoding.G2Commitment).Deserialize(reply.GetCommitment().GetLengthCommitment())
-	if err != nil {
+	var lengthCommitment encoding.G2Commitment
+	if err := lengthCommitment.UnmarshalBinary(reply.GetCommitment().GetLengthCommitment()); err != nil {
 		return nil, nil, err
 	}
-	lengthProof, err := new(encoding.LengthProof).Deserialize(reply.GetCommitment().GetLengthProof())
-	if err != nil {
+	var lengthProof encoding.LengthProof
+	if err := lengthProof.UnmarshalBinary(reply.GetCommitment().GetLengthProof()); err != nil {
 		return nil, nil, err
 	}
 	chunks := make([]*encoding.Frame, len(reply.GetChunks()))
@@ -68,9 +82,9 @@ func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams enco
 		chunks[i] = deserialized
 	}
 	return &encoding.BlobCommitments{
-		Commitment:       commitment,
-		LengthCommitment: lengthCommitment,
-		LengthProof:      lengthProof,
+		Commitment:       &commitment,
+		LengthCommitment: &lengthCommitment,
+		LengthProof:      &lengthProof,
 		Length:           uint(reply.GetCommitment().GetLength()),
 	}, chunks, nil
 }
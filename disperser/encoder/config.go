@@ -1,11 +1,25 @@
 package encoder
 
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+)
+
 const (
 	Localhost = "0.0.0.0"
 )
 
 type ServerConfig struct {
-	GrpcPort              string
+	GrpcPort string
+	// TLS is the transport security of the gRPC server, which serves plaintext if no certificate is set.
+	TLS                   grpctls.Config
 	MaxConcurrentRequests int
 	RequestPoolSize       int
+	// MaxConcurrentLatencyRequests is the number of latency sensitive requests encoded at a time, which don't take
+	// the slots of MaxConcurrentRequests. It defaults to MaxConcurrentRequests if zero
+	MaxConcurrentLatencyRequests int
+	// MaxPreemptionPause is the longest a bulk encoding is paused at each of its stages while latency sensitive
+	// encodings are running. If zero, bulk encodings are never paused
+	MaxPreemptionPause time.Duration
 }
@@ -0,0 +1,182 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// maxHedgeTokens bounds the hedges that can be sent in a burst after a period without hedging
+const maxHedgeTokens = 10
+
+type HedgingConfig struct {
+	// Delay is the time after which an encoding request still pending on a replica is also sent to the next
+	// replica. Hedging is disabled if zero.
+	Delay time.Duration
+	// Budget is the maximum fraction of the requests which are hedged, e.g. 0.1 for 10%
+	Budget float64
+}
+
+// HedgingMetrics records the outcomes of the hedged requests
+type HedgingMetrics interface {
+	// IncrementHedges counts the hedges: "sent" to a second replica, "won" when the second replica replied first,
+	// and "skipped" when the budget was exhausted
+	IncrementHedges(outcome string)
+	// IncrementDuplicateEncodings counts the encodings abandoned because another replica replied first
+	IncrementDuplicateEncodings()
+}
+
+type encodeResult struct {
+	replica     int
+	commitments *encoding.BlobCommitments
+	chunks      []*encoding.Frame
+	err         error
+}
+
+// hedgedClient sends the encoding requests to the encoder replicas in turn. A request still pending after the
+// hedging delay, or failing, is also sent to the next replica, and the first valid result is returned.
+type hedgedClient struct {
+	replicas []disperser.EncoderClient
+	delay    time.Duration
+	budget   *hedgeBudget
+	metrics  HedgingMetrics
+	next     atomic.Uint64
+}
+
+var _ disperser.EncoderClient = (*hedgedClient)(nil)
+
+func NewHedgedEncoderClient(replicas []disperser.EncoderClient, config HedgingConfig, metrics HedgingMetrics) (disperser.EncoderClient, error) {
+	if len(replicas) == 0 {
+		return nil, errors.New("at least one encoder replica is required")
+	}
+	if config.Budget < 0 || config.Budget > 1 {
+		return nil, fmt.Errorf("hedging budget must be in [0, 1], got %f", config.Budget)
+	}
+	return &hedgedClient{
+		replicas: replicas,
+		delay:    config.Delay,
+		budget:   newHedgeBudget(config.Budget),
+		metrics:  metrics,
+	}, nil
+}
+
+func (c *hedgedClient) EncodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, []*encoding.Frame, error) {
+	// The encodings still running once a result is returned are canceled
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	primary := int(c.next.Add(1)-1) % len(c.replicas)
+	canHedge := len(c.replicas) > 1 && c.delay > 0
+	c.budget.recordRequest()
+
+	// Buffered so that the abandoned encodings don't block
+	results := make(chan encodeResult, 2)
+	encode := func(replica int) {
+		go func() {
+			commitments, chunks, err := c.replicas[replica].EncodeBlob(ctx, data, encodingParams)
+			if err == nil {
+				err = validateEncodeResult(commitments, chunks, encodingParams)
+			}
+			results <- encodeResult{replica: replica, commitments: commitments, chunks: chunks, err: err}
+		}()
+	}
+	hedge := func() bool {
+		if !c.budget.tryHedge() {
+			c.metrics.IncrementHedges("skipped")
+			return false
+		}
+		c.metrics.IncrementHedges("sent")
+		encode((primary + 1) % len(c.replicas))
+		return true
+	}
+
+	encode(primary)
+	inFlight := 1
+	hedged := false
+	var hedgeTimer <-chan time.Time
+	if canHedge {
+		timer := time.NewTimer(c.delay)
+		defer timer.Stop()
+		hedgeTimer = timer.C
+	}
+
+	var err error
+	for {
+		select {
+		case <-hedgeTimer:
+			hedgeTimer = nil
+			if hedge() {
+				hedged = true
+				inFlight++
+			}
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				if inFlight > 0 {
+					c.metrics.IncrementDuplicateEncodings()
+				}
+				if result.replica != primary {
+					c.metrics.IncrementHedges("won")
+				}
+				return result.commitments, result.chunks, nil
+			}
+			err = errors.Join(err, fmt.Errorf("encoder replica %d: %w", result.replica, result.err))
+			// A failing primary is hedged right away
+			if canHedge && !hedged && ctx.Err() == nil {
+				hedgeTimer = nil
+				if hedge() {
+					hedged = true
+					inFlight++
+				}
+			}
+			if inFlight == 0 {
+				return nil, nil, err
+			}
+		}
+	}
+}
+
+// validateEncodeResult checks that the encoder replied with the commitments and the expected number of chunks
+func validateEncodeResult(commitments *encoding.BlobCommitments, chunks []*encoding.Frame, encodingParams encoding.EncodingParams) error {
+	if commitments == nil || commitments.Commitment == nil {
+		return errors.New("encoder replied without commitments")
+	}
+	if uint64(len(chunks)) != encodingParams.NumChunks {
+		return fmt.Errorf("encoder replied with %d chunks, expected %d", len(chunks), encodingParams.NumChunks)
+	}
+	return nil
+}
+
+// hedgeBudget limits the hedges to a fraction of the requests: every request earns the fraction of a token, and
+// every hedge spends a token
+type hedgeBudget struct {
+	mu       sync.Mutex
+	fraction float64
+	tokens   float64
+}
+
+func newHedgeBudget(fraction float64) *hedgeBudget {
+	return &hedgeBudget{fraction: fraction}
+}
+
+func (b *hedgeBudget) recordRequest() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+b.fraction, maxHedgeTokens)
+}
+
+func (b *hedgeBudget) tryHedge() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,157 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var hedgingTestParams = encoding.EncodingParams{ChunkLength: 1, NumChunks: 2}
+
+// fakeReplica replies after its delay, with its error if any, and records whether its encodings were canceled
+type fakeReplica struct {
+	delay time.Duration
+	err   error
+
+	mu       sync.Mutex
+	calls    int
+	canceled int
+}
+
+func (r *fakeReplica) EncodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, []*encoding.Frame, error) {
+	r.mu.Lock()
+	r.calls++
+	r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		r.mu.Lock()
+		r.canceled++
+		r.mu.Unlock()
+		return nil, nil, ctx.Err()
+	case <-time.After(r.delay):
+	}
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	return &encoding.BlobCommitments{Commitment: &encoding.G1Commitment{}}, make([]*encoding.Frame, encodingParams.NumChunks), nil
+}
+
+func (r *fakeReplica) stats() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls, r.canceled
+}
+
+type fakeHedgingMetrics struct {
+	mu         sync.Mutex
+	hedges     map[string]int
+	duplicates int
+}
+
+func (m *fakeHedgingMetrics) IncrementHedges(outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hedges[outcome]++
+}
+
+func (m *fakeHedgingMetrics) IncrementDuplicateEncodings() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.duplicates++
+}
+
+func newHedgedTestClient(t *testing.T, budget float64, replicas ...*fakeReplica) (disperser.EncoderClient, *fakeHedgingMetrics) {
+	clients := make([]disperser.EncoderClient, len(replicas))
+	for i := range replicas {
+		clients[i] = replicas[i]
+	}
+	metrics := &fakeHedgingMetrics{hedges: make(map[string]int)}
+	client, err := NewHedgedEncoderClient(clients, HedgingConfig{Delay: 20 * time.Millisecond, Budget: budget}, metrics)
+	require.NoError(t, err)
+	return client, metrics
+}
+
+func TestHedgedClientHedgesSlowReplica(t *testing.T) {
+	slow := &fakeReplica{delay: 5 * time.Second}
+	fast := &fakeReplica{delay: time.Millisecond}
+	client, metrics := newHedgedTestClient(t, 1, slow, fast)
+
+	start := time.Now()
+	commitments, chunks, err := client.EncodeBlob(context.Background(), nil, hedgingTestParams)
+	require.NoError(t, err)
+	assert.NotNil(t, commitments)
+	assert.Len(t, chunks, 2)
+	assert.Less(t, time.Since(start), time.Second)
+
+	assert.Equal(t, 1, metrics.hedges["sent"])
+	assert.Equal(t, 1, metrics.hedges["won"])
+	assert.Equal(t, 1, metrics.duplicates)
+	// The slow encoding is abandoned
+	assert.Eventually(t, func() bool {
+		_, canceled := slow.stats()
+		return canceled == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHedgedClientDoesNotHedgeFastReplica(t *testing.T) {
+	first := &fakeReplica{delay: time.Millisecond}
+	second := &fakeReplica{delay: time.Millisecond}
+	client, metrics := newHedgedTestClient(t, 1, first, second)
+
+	// The requests alternate between the replicas
+	for i := 0; i < 4; i++ {
+		_, _, err := client.EncodeBlob(context.Background(), nil, hedgingTestParams)
+		require.NoError(t, err)
+	}
+	firstCalls, _ := first.stats()
+	secondCalls, _ := second.stats()
+	assert.Equal(t, 2, firstCalls)
+	assert.Equal(t, 2, secondCalls)
+	assert.Empty(t, metrics.hedges)
+	assert.Zero(t, metrics.duplicates)
+}
+
+func TestHedgedClientHedgesFailure(t *testing.T) {
+	failing := &fakeReplica{delay: time.Millisecond, err: errors.New("encoder is down")}
+	healthy := &fakeReplica{delay: time.Millisecond}
+	client, metrics := newHedgedTestClient(t, 1, failing, healthy)
+
+	_, _, err := client.EncodeBlob(context.Background(), nil, hedgingTestParams)
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics.hedges["won"])
+	assert.Zero(t, metrics.duplicates)
+
+	// When both replicas fail, both errors are returned
+	healthy.err = errors.New("encoder is overloaded")
+	_, _, err = client.EncodeBlob(context.Background(), nil, hedgingTestParams)
+	assert.ErrorContains(t, err, "encoder is down")
+	assert.ErrorContains(t, err, "encoder is overloaded")
+}
+
+func TestHedgedClientBudget(t *testing.T) {
+	slow := &fakeReplica{delay: 100 * time.Millisecond}
+	client, metrics := newHedgedTestClient(t, 0.5, slow, &fakeReplica{delay: 100 * time.Millisecond})
+
+	// Every other request can be hedged
+	for i := 0; i < 4; i++ {
+		_, _, err := client.EncodeBlob(context.Background(), nil, hedgingTestParams)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, metrics.hedges["sent"])
+	assert.Equal(t, 2, metrics.hedges["skipped"])
+}
+
+func TestNewHedgedEncoderClientInvalidConfig(t *testing.T) {
+	_, err := NewHedgedEncoderClient(nil, HedgingConfig{Delay: time.Second, Budget: 0.1}, &fakeHedgingMetrics{})
+	assert.Error(t, err)
+	_, err = NewHedgedEncoderClient([]disperser.EncoderClient{&fakeReplica{}}, HedgingConfig{Delay: time.Second, Budget: 2}, &fakeHedgingMetrics{})
+	assert.Error(t, err)
+}
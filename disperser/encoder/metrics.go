@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -25,6 +26,9 @@ type Metrics struct {
 
 	NumEncodeBlobRequests *prometheus.CounterVec
 	Latency               *prometheus.SummaryVec
+	NumPreemptions        prometheus.Counter
+	PreemptionLatency     prometheus.Summary
+	QueueDepth            prometheus.Gauge
 }
 
 func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
@@ -53,6 +57,28 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"time"},
 		),
+		NumPreemptions: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "preemptions_total",
+				Help:      "the number of times a bulk encoding was paused for latency sensitive encodings",
+			},
+		),
+		PreemptionLatency: promauto.With(reg).NewSummary(
+			prometheus.SummaryOpts{
+				Namespace:  "eigenda_encoder",
+				Name:       "preemption_latency_ms",
+				Help:       "summary of the time bulk encodings were paused for latency sensitive encodings, in milliseconds",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+		),
+		QueueDepth: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "eigenda_encoder",
+				Name:      "queue_depth",
+				Help:      "the number of encode blob requests in the request pool, running or waiting to run",
+			},
+		),
 	}
 }
 
@@ -85,6 +111,22 @@ func (m *Metrics) TakeLatency(encoding, total time.Duration) {
 	m.Latency.WithLabelValues("total").Observe(float64(total.Milliseconds()))
 }
 
+// ObservePreemption records a bulk encoding being paused for the given duration
+func (m *Metrics) ObservePreemption(pause time.Duration) {
+	m.NumPreemptions.Inc()
+	m.PreemptionLatency.Observe(float64(pause.Milliseconds()))
+}
+
+// ObserveQueueDepth records the number of requests in the request pool
+func (m *Metrics) ObserveQueueDepth(depth int) {
+	m.QueueDepth.Set(float64(depth))
+}
+
+// RegisterBuildInfo exports the build of the encoder as the build_info metric
+func (m *Metrics) RegisterBuildInfo(info buildinfo.Info) error {
+	return buildinfo.Register(m.registry, info)
+}
+
 func (m *Metrics) Start(ctx context.Context) {
 	m.logger.Info("Starting metrics server at ", "port", m.httpPort)
 
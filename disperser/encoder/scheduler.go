@@ -0,0 +1,86 @@
+package encoder
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// scheduler pauses bulk encodings between their stages while latency sensitive encodings are running, so that
+// a large bulk blob doesn't delay the latency sensitive blobs encoded at the same time.
+// A bulk encoding is paused for at most maxPause at each stage, so that a steady stream of latency sensitive
+// blobs can't starve it. If maxPause is zero, bulk encodings are never paused.
+type scheduler struct {
+	maxPause time.Duration
+	metrics  *Metrics
+
+	mu         sync.Mutex
+	numLatency int
+	// resume is closed once no latency sensitive encoding is running
+	resume chan struct{}
+}
+
+func newScheduler(maxPause time.Duration, metrics *Metrics) *scheduler {
+	resume := make(chan struct{})
+	close(resume)
+	return &scheduler{
+		maxPause: maxPause,
+		metrics:  metrics,
+		resume:   resume,
+	}
+}
+
+// startLatencyEncoding registers a running latency sensitive encoding and returns the function to call once
+// it's done.
+func (s *scheduler) startLatencyEncoding() func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.numLatency == 0 {
+		s.resume = make(chan struct{})
+	}
+	s.numLatency++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			s.numLatency--
+			if s.numLatency == 0 {
+				close(s.resume)
+			}
+		})
+	}
+}
+
+// bulkCheckpoint returns the checkpoint of bulk encodings.
+func (s *scheduler) bulkCheckpoint() encoding.Checkpoint {
+	if s.maxPause == 0 {
+		return nil
+	}
+	return s.pause
+}
+
+// pause blocks while latency sensitive encodings are running, for at most maxPause.
+func (s *scheduler) pause() {
+	s.mu.Lock()
+	numLatency := s.numLatency
+	resume := s.resume
+	s.mu.Unlock()
+
+	if numLatency == 0 {
+		return
+	}
+
+	start := time.Now()
+	timer := time.NewTimer(s.maxPause)
+	defer timer.Stop()
+	select {
+	case <-resume:
+	case <-timer.C:
+	}
+	s.metrics.ObservePreemption(time.Since(start))
+}
@@ -0,0 +1,84 @@
+package encoder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForCheckpoint(checkpoint func()) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		checkpoint()
+		close(done)
+	}()
+	return done
+}
+
+func TestSchedulerPausesBulkEncodings(t *testing.T) {
+	metrics := NewMetrics("9000", logger)
+	s := newScheduler(time.Minute, metrics)
+	checkpoint := s.bulkCheckpoint()
+	assert.NotNil(t, checkpoint)
+
+	// without latency sensitive encodings, the checkpoint doesn't block
+	<-waitForCheckpoint(checkpoint)
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.NumPreemptions))
+
+	done1 := s.startLatencyEncoding()
+	done2 := s.startLatencyEncoding()
+	paused := waitForCheckpoint(checkpoint)
+
+	done1()
+	// calling done again has no effect
+	done1()
+	select {
+	case <-paused:
+		t.Fatal("bulk encoding resumed while a latency sensitive encoding is running")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	done2()
+	select {
+	case <-paused:
+	case <-time.After(time.Second):
+		t.Fatal("bulk encoding was not resumed")
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NumPreemptions))
+
+	// the scheduler can be reused once all the latency sensitive encodings are done
+	<-waitForCheckpoint(checkpoint)
+	done3 := s.startLatencyEncoding()
+	paused = waitForCheckpoint(checkpoint)
+	select {
+	case <-paused:
+		t.Fatal("bulk encoding resumed while a latency sensitive encoding is running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	done3()
+	<-paused
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.NumPreemptions))
+}
+
+func TestSchedulerMaxPause(t *testing.T) {
+	metrics := NewMetrics("9000", logger)
+	s := newScheduler(50*time.Millisecond, metrics)
+
+	done := s.startLatencyEncoding()
+	defer done()
+
+	start := time.Now()
+	<-waitForCheckpoint(s.bulkCheckpoint())
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NumPreemptions))
+}
+
+func TestSchedulerWithoutPreemption(t *testing.T) {
+	s := newScheduler(0, NewMetrics("9000", logger))
+
+	done := s.startLatencyEncoding()
+	defer done()
+	assert.Nil(t, s.bulkCheckpoint())
+}
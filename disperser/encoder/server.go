@@ -8,6 +8,8 @@ import (
 	"net"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/disperser"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
@@ -27,53 +29,84 @@ type Server struct {
 	metrics *Metrics
 	close   func()
 
-	runningRequests chan struct{}
-	requestPool     chan struct{}
+	runningRequests        chan struct{}
+	runningLatencyRequests chan struct{}
+	requestPool            chan struct{}
+	scheduler              *scheduler
 }
 
 func NewServer(config ServerConfig, logger logging.Logger, prover encoding.Prover, metrics *Metrics) *Server {
+	if config.MaxConcurrentLatencyRequests <= 0 {
+		config.MaxConcurrentLatencyRequests = config.MaxConcurrentRequests
+	}
 	return &Server{
 		config:  config,
 		logger:  logger.With("component", "EncoderServer"),
 		prover:  prover,
 		metrics: metrics,
 
-		runningRequests: make(chan struct{}, config.MaxConcurrentRequests),
-		requestPool:     make(chan struct{}, config.RequestPoolSize),
+		runningRequests:        make(chan struct{}, config.MaxConcurrentRequests),
+		runningLatencyRequests: make(chan struct{}, config.MaxConcurrentLatencyRequests),
+		requestPool:            make(chan struct{}, config.RequestPoolSize),
+		scheduler:              newScheduler(config.MaxPreemptionPause, metrics),
 	}
 }
 
 func (s *Server) EncodeBlob(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
+	correlationID := common.CorrelationIDFromContext(ctx)
 	select {
 	case s.requestPool <- struct{}{}:
+		s.metrics.ObserveQueueDepth(len(s.requestPool))
 	default:
 		s.metrics.IncrementRateLimitedBlobRequestNum()
-		s.logger.Warn("rate limiting as request pool is full", "requestPoolSize", s.config.RequestPoolSize, "maxConcurrentRequests", s.config.MaxConcurrentRequests)
+		s.logger.Warn("rate limiting as request pool is full", "requestPoolSize", s.config.RequestPoolSize, "maxConcurrentRequests", s.config.MaxConcurrentRequests, "correlationID", correlationID)
 		return nil, errors.New("too many requests")
 	}
-	s.runningRequests <- struct{}{}
-	defer s.popRequest()
+	defer func() {
+		<-s.requestPool
+		s.metrics.ObserveQueueDepth(len(s.requestPool))
+	}()
+
+	// Latency sensitive requests don't wait for the running bulk requests, which are paused while they're
+	// encoded instead. They have their own concurrency budget, so that they can't run unbounded
+	checkpoint := s.scheduler.bulkCheckpoint()
+	if req.GetPriority() == pb.Priority_LATENCY {
+		select {
+		case s.runningLatencyRequests <- struct{}{}:
+		case <-ctx.Done():
+			s.metrics.IncrementCanceledBlobRequestNum()
+			return nil, ctx.Err()
+		}
+		defer func() {
+			<-s.runningLatencyRequests
+		}()
+		done := s.scheduler.startLatencyEncoding()
+		defer done()
+		checkpoint = nil
+	} else {
+		s.runningRequests <- struct{}{}
+		defer func() {
+			<-s.runningRequests
+		}()
+	}
 
 	if ctx.Err() != nil {
 		s.metrics.IncrementCanceledBlobRequestNum()
 		return nil, ctx.Err()
 	}
 
-	reply, err := s.handleEncoding(ctx, req)
+	reply, err := s.handleEncoding(ctx, req, checkpoint)
 	if err != nil {
 		s.metrics.IncrementFailedBlobRequestNum()
+		s.logger.Warn("failed to encode blob", "err", err, "correlationID", correlationID)
 	} else {
 		s.metrics.IncrementSuccessfulBlobRequestNum()
+		s.logger.Debug("encoded blob", "numChunks", len(reply.GetChunks()), "correlationID", correlationID)
 	}
 	return reply, err
 }
 
-func (s *Server) popRequest() {
-	<-s.requestPool
-	<-s.runningRequests
-}
-
-func (s *Server) handleEncoding(ctx context.Context, req *pb.EncodeBlobRequest) (*pb.EncodeBlobReply, error) {
+func (s *Server) handleEncoding(ctx context.Context, req *pb.EncodeBlobRequest, checkpoint encoding.Checkpoint) (*pb.EncodeBlobReply, error) {
 	begin := time.Now()
 
 	if len(req.Data) == 0 {
@@ -91,7 +124,7 @@ func (s *Server) handleEncoding(ctx context.Context, req *pb.EncodeBlobRequest)
 		NumChunks:   uint64(req.GetEncodingParams().GetNumChunks()),
 	}
 
-	commits, chunks, err := s.prover.EncodeAndProve(req.GetData(), encodingParams)
+	commits, chunks, err := s.prover.EncodeAndProveWithCheckpoint(req.GetData(), encodingParams, checkpoint)
 
 	if err != nil {
 		return nil, err
@@ -99,17 +132,17 @@ func (s *Server) handleEncoding(ctx context.Context, req *pb.EncodeBlobRequest)
 
 	encodingTime := time.Since(begin)
 
-	commitData, err := commits.Commitment.Serialize()
+	commitData, err := commits.Commitment.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
 
-	lengthCommitData, err := commits.LengthCommitment.Serialize()
+	lengthCommitData, err := commits.LengthCommitment.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
 
-	lengthProofData, err := commits.LengthProof.Serialize()
+	lengthProofData, err := commits.LengthProof.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
@@ -148,8 +181,13 @@ func (s *Server) Start() error {
 		log.Fatalf("Could not start tcp listener: %v", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(1024 * 1024 * 300)} // 300 MiB
+	tlsOpts, err := grpctls.ServerOptions(s.config.TLS, s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	opts = append(opts, tlsOpts...)
+	gs := grpc.NewServer(opts...)
 	reflection.Register(gs)
 	pb.RegisterEncoderServer(gs, s)
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
@@ -251,6 +252,106 @@ func TestThrottling(t *testing.T) {
 	}
 }
 
+func TestLatencyEncodingPreemption(t *testing.T) {
+	metrics := NewMetrics("9000", logger)
+	encoder := &encmock.MockEncoder{
+		Delay: 200 * time.Millisecond,
+	}
+	encoder.On("EncodeAndProve", mock.Anything, mock.Anything).Return(encoding.BlobCommitments{
+		Commitment:       &encoding.G1Commitment{},
+		LengthCommitment: &encoding.G2Commitment{},
+		LengthProof:      &encoding.G2Commitment{},
+	}, []*encoding.Frame{}, nil)
+	encoderServerConfig := ServerConfig{
+		GrpcPort:              "3000",
+		MaxConcurrentRequests: 1,
+		RequestPoolSize:       4,
+		MaxPreemptionPause:    time.Minute,
+	}
+	s := NewServer(encoderServerConfig, logger, encoder, metrics)
+	testBlobData, testEncodingParams := getTestData()
+
+	request := func(priority pb.Priority) *pb.EncodeBlobRequest {
+		return &pb.EncodeBlobRequest{
+			Data: []byte(testBlobData.Data),
+			EncodingParams: &pb.EncodingParams{
+				ChunkLength: uint32(testEncodingParams.ChunkLength),
+				NumChunks:   uint32(testEncodingParams.NumChunks),
+			},
+			Priority: priority,
+		}
+	}
+
+	// the latency sensitive request doesn't wait for the bulk request holding the only concurrency slot,
+	// and the bulk request is paused until the latency sensitive request is done
+	finished := make(chan pb.Priority, 2)
+	for _, priority := range []pb.Priority{pb.Priority_LATENCY, pb.Priority_BULK} {
+		go func(priority pb.Priority) {
+			_, err := s.EncodeBlob(context.Background(), request(priority))
+			assert.NoError(t, err)
+			finished <- priority
+		}(priority)
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	assert.Equal(t, pb.Priority_LATENCY, <-finished)
+	assert.Equal(t, pb.Priority_BULK, <-finished)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NumPreemptions))
+}
+
+func TestLatencyEncodingConcurrency(t *testing.T) {
+	metrics := NewMetrics("9000", logger)
+	encoder := &encmock.MockEncoder{
+		Delay: 200 * time.Millisecond,
+	}
+	encoder.On("EncodeAndProve", mock.Anything, mock.Anything).Return(encoding.BlobCommitments{
+		Commitment:       &encoding.G1Commitment{},
+		LengthCommitment: &encoding.G2Commitment{},
+		LengthProof:      &encoding.G2Commitment{},
+	}, []*encoding.Frame{}, nil)
+	encoderServerConfig := ServerConfig{
+		GrpcPort:                     "3000",
+		MaxConcurrentRequests:        4,
+		RequestPoolSize:              4,
+		MaxConcurrentLatencyRequests: 1,
+	}
+	s := NewServer(encoderServerConfig, logger, encoder, metrics)
+	testBlobData, testEncodingParams := getTestData()
+	request := &pb.EncodeBlobRequest{
+		Data: []byte(testBlobData.Data),
+		EncodingParams: &pb.EncodingParams{
+			ChunkLength: uint32(testEncodingParams.ChunkLength),
+			NumChunks:   uint32(testEncodingParams.NumChunks),
+		},
+		Priority: pb.Priority_LATENCY,
+	}
+
+	// the latency sensitive requests are encoded one at a time
+	start := time.Now()
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := s.EncodeBlob(context.Background(), request)
+			errs <- err
+		}()
+	}
+	assert.NoError(t, <-errs)
+	assert.NoError(t, <-errs)
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// a latency sensitive request gives up waiting for its turn once canceled
+	go func() {
+		_, err := s.EncodeBlob(context.Background(), request)
+		errs <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := s.EncodeBlob(ctx, request)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NoError(t, <-errs)
+}
+
 func TestEncoderPointsLoading(t *testing.T) {
 	// encoder 1 only loads 1500 points
 	prover1, config1 := makeTestProver(1500)
@@ -6,6 +6,33 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 )
 
+// EncodingPriority is the scheduling class of an encoding request
+type EncodingPriority uint8
+
+const (
+	// BulkEncoding requests can be paused by the encoder while latency sensitive requests are being encoded
+	BulkEncoding EncodingPriority = iota
+	// LatencyEncoding requests are never paused for other requests
+	LatencyEncoding
+)
+
+type encodingPriorityKey struct{}
+
+// WithEncodingPriority returns a copy of ctx carrying the priority of the encoding requests made with it.
+// Requests are encoded with BulkEncoding priority by default.
+func WithEncodingPriority(ctx context.Context, priority EncodingPriority) context.Context {
+	return context.WithValue(ctx, encodingPriorityKey{}, priority)
+}
+
+// EncodingPriorityFromContext returns the encoding priority set on ctx with WithEncodingPriority.
+func EncodingPriorityFromContext(ctx context.Context) EncodingPriority {
+	priority, ok := ctx.Value(encodingPriorityKey{}).(EncodingPriority)
+	if !ok {
+		return BulkEncoding
+	}
+	return priority
+}
+
 type EncoderClient interface {
 	EncodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, []*encoding.Frame, error)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -25,6 +26,11 @@ type Metrics struct {
 	NumRpcRequests  *prometheus.CounterVec
 	BlobSize        *prometheus.GaugeVec
 	Latency         *prometheus.SummaryVec
+	// ReplayedRequests counts the signed and authenticated dispersal requests rejected as replays
+	ReplayedRequests *prometheus.CounterVec
+	// TenantRequests and TenantBlobBytes count the dispersal requests and the bytes of the blobs accepted per tenant
+	TenantRequests  *prometheus.CounterVec
+	TenantBlobBytes *prometheus.CounterVec
 
 	httpPort string
 	logger   logging.Logger
@@ -79,6 +85,30 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"method"},
 		),
+		ReplayedRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "replayed_requests_total",
+				Help:      "the number of dispersal requests rejected as replays",
+			},
+			[]string{"reason", "method"},
+		),
+		TenantRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tenant_requests_total",
+				Help:      "the number of dispersal requests of each tenant",
+			},
+			[]string{"tenant", "status_code", "method"},
+		),
+		TenantBlobBytes: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "tenant_blob_bytes_total",
+				Help:      "the total size in bytes of the blobs accepted for each tenant",
+			},
+			[]string{"tenant"},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger.With("component", "DisperserMetrics"),
@@ -91,6 +121,20 @@ func (g *Metrics) ObserveLatency(method string, latencyMs float64) {
 	g.Latency.WithLabelValues(method).Observe(latencyMs)
 }
 
+// HandleReplayedRequest counts a request rejected as a replay, because its nonce or challenge was already used or
+// it expired
+func (g *Metrics) HandleReplayedRequest(reason string, method string) {
+	g.ReplayedRequests.WithLabelValues(reason, method).Inc()
+}
+
+// HandleTenantRequest counts a dispersal request of the tenant, and the bytes of its blob if it was accepted
+func (g *Metrics) HandleTenantRequest(tenant string, statusCode string, blobBytes int, method string) {
+	g.TenantRequests.WithLabelValues(tenant, statusCode, method).Inc()
+	if statusCode == codes.OK.String() {
+		g.TenantBlobBytes.WithLabelValues(tenant).Add(float64(blobBytes))
+	}
+}
+
 func (g *Metrics) HandleSuccessfulRpcRequest(method string) {
 	g.NumRpcRequests.With(prometheus.Labels{
 		"status_code":   codes.OK.String(),
@@ -260,6 +304,11 @@ func (g *Metrics) HandleAccountRateLimitedRequest(quorum string, blobBytes int,
 	}).Add(float64(blobBytes))
 }
 
+// RegisterBuildInfo exports the build of the disperser as the build_info metric
+func (g *Metrics) RegisterBuildInfo(info buildinfo.Info) error {
+	return buildinfo.Register(g.registry, info)
+}
+
 // Start starts the metrics server
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/mock"
@@ -34,13 +35,15 @@ func (d *Dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOpera
 	}
 
 	go func() {
+		requestedAt := time.Now()
 		for id, op := range d.state.PrivateOperators {
 			sig := op.KeyPair.SignMessage(message)
 
 			update <- core.SignerMessage{
-				Signature: sig,
-				Operator:  id,
-				Err:       nil,
+				Signature:    sig,
+				Operator:     id,
+				Err:          nil,
+				ResponseTime: time.Since(requestedAt),
 			}
 		}
 	}()
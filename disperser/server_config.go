@@ -1,17 +1,94 @@
 package disperser
 
-import "time"
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+)
 
 const (
 	Localhost = "0.0.0.0"
 )
 
+// AdmissionConfig configures the admission control of the dispersal requests. When a stage of the dispersal
+// pipeline is saturated, new blobs are rejected with a hint to retry later rather than accepted and left to time out
+// downstream. Each limit is disabled if 0.
+type AdmissionConfig struct {
+	// EncoderMetricsURL is the Prometheus endpoint of the encoder, whose queue depth is read from it
+	EncoderMetricsURL string
+	// MaxEncoderQueueDepth is the largest number of encode requests queued at the encoder
+	MaxEncoderQueueDepth int
+	// MaxQueuedBlobs is the largest number of blobs waiting in the blob store to be confirmed in a batch
+	MaxQueuedBlobs int
+	// MaxStoreLatency is the largest average latency of the blob store writes
+	MaxStoreLatency time.Duration
+	// PollInterval is how often the load of the pipeline is measured
+	PollInterval time.Duration
+	// MaxDelay is how long a request waits for the load to drop while the pipeline is saturated before it's rejected
+	MaxDelay time.Duration
+	// RetryAfter is the delay after which the clients of the rejected requests are told to retry
+	RetryAfter time.Duration
+}
+
 type ServerConfig struct {
 	GrpcPort    string
 	GrpcTimeout time.Duration
+	// TLS is the transport security of the gRPC server, which serves plaintext if no certificate is set.
+	TLS grpctls.Config
+	// HTTPPort is the port of the HTTP/JSON gateway to the API. The gateway is disabled if empty.
+	HTTPPort string
+
+	// StatusPollInterval is how often a SubscribeBlobStatus stream reads the status of its blob from the blob store.
+	StatusPollInterval time.Duration
+	// StatusKeepaliveInterval is how long a SubscribeBlobStatus stream stays idle before sending a keepalive message.
+	StatusKeepaliveInterval time.Duration
+
+	// AdminPort is the port of the admin endpoints, which only listen on the loopback interface. They are disabled
+	// if empty.
+	AdminPort string
+	// DrainTimeout is how long a drain waits for the queued blobs to be confirmed before the server exits anyway.
+	DrainTimeout time.Duration
+	// IdempotencyKeyTTL is how long the idempotency key of a dispersal request identifies it, during which requests
+	// with the same key replay its reply.
+	IdempotencyKeyTTL time.Duration
+	// ReplayWindow is how far ahead the expiry of a signed dispersal request can be, and how long the challenges
+	// answered by the clients of authenticated dispersals are remembered, which bounds how long the server remembers
+	// the requests to reject their replays.
+	ReplayWindow time.Duration
+	// AuthChallengeTimeout is how long the client of an authenticated dispersal has to answer its challenge. It's
+	// only bounded by the gRPC timeout if 0.
+	AuthChallengeTimeout time.Duration
+	// MaxBlobPriority is the highest priority lane of the blobs, which must match the lanes of the batcher.
+	MaxBlobPriority uint32
+	// MinAttestationTimeout is the shortest attestation window the clients can request for their blobs
+	MinAttestationTimeout time.Duration
+	// MaxDecompressionRatio is the largest compression ratio of the compressed payloads of the dispersals. It's not
+	// checked if 0, the decompressed payloads still being bounded by the maximum blob size.
+	MaxDecompressionRatio int
+	// SRSOrder is the order of the SRS of the encoders, which bounds the encoded length of the blobs dispersed with
+	// custom security params. It's not checked if 0.
+	SRSOrder uint64
+	// BlobStatusCacheSize is the number of status replies of finalized blobs kept in memory, which are served without
+	// reading the blob store. The cache is disabled if negative, and defaults to 10000 entries if 0.
+	BlobStatusCacheSize int
+	// BlobStatusCacheTTL is how long a status reply is served from the cache before the blob store is read again. It
+	// bounds how long a change of the status of a finalized blob made by another process goes unnoticed. It defaults
+	// to 10 minutes if 0.
+	BlobStatusCacheTTL time.Duration
+	// Admission bounds the load of the dispersal pipeline, beyond which new blobs are rejected
+	Admission AdmissionConfig
+	// BuildInfo is the build of the disperser returned by GetCapabilities. It's set by the binary rather than read
+	// from the flags.
+	BuildInfo buildinfo.Info
 
 	// Feature flags
 	// Whether enable the dual quorums.
 	// If false, only quorum 0 will be used as required quorum.
 	EnableDualQuorums bool
+	// Whether the dispersals must be authenticated, with a signed request or DisperseBlobAuthenticated. If false, the
+	// blobs can also be dispersed without authentication.
+	RequireAuthentication bool
+	// Whether the streaming RPCs, DisperseBlobStream and SubscribeBlobStatus, are disabled.
+	DisableStreaming bool
 }
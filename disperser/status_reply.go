@@ -0,0 +1,129 @@
+package disperser
+
+import (
+	"slices"
+
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// ToBlobStatusReply converts the metadata of a blob into the reply returned by GetBlobStatus, which carries the
+// BlobInfo needed to verify the blob against the EigenDA contracts once it's confirmed.
+func ToBlobStatusReply(metadata *BlobMetadata) (*disperser_rpc.BlobStatusReply, error) {
+	isConfirmed, err := metadata.IsConfirmed()
+	if err != nil {
+		return nil, err
+	}
+
+	if isConfirmed {
+		confirmationInfo := metadata.ConfirmationInfo
+		dataLength := uint32(confirmationInfo.BlobCommitment.Length)
+		quorumResults := confirmationInfo.QuorumResults
+		batchQuorumIDs := make([]uint8, 0, len(quorumResults))
+		for quorumID := range quorumResults {
+			batchQuorumIDs = append(batchQuorumIDs, quorumID)
+		}
+		slices.Sort(batchQuorumIDs)
+		quorumNumbers := make([]byte, len(batchQuorumIDs))
+		quorumPercentSigned := make([]byte, len(batchQuorumIDs))
+		for i, quorumID := range batchQuorumIDs {
+			quorumNumbers[i] = quorumID
+			quorumPercentSigned[i] = confirmationInfo.QuorumResults[quorumID].PercentSigned
+		}
+
+		quorumInfos := confirmationInfo.BlobQuorumInfos
+		slices.SortStableFunc[[]*core.BlobQuorumInfo](quorumInfos, func(a, b *core.BlobQuorumInfo) int {
+			return int(a.QuorumID) - int(b.QuorumID)
+		})
+		blobQuorumParams := make([]*disperser_rpc.BlobQuorumParam, len(quorumInfos))
+		quorumIndexes := make([]byte, len(quorumInfos))
+		for i, quorumInfo := range quorumInfos {
+			blobQuorumParams[i] = &disperser_rpc.BlobQuorumParam{
+				QuorumNumber:                    uint32(quorumInfo.QuorumID),
+				AdversaryThresholdPercentage:    uint32(quorumInfo.AdversaryThreshold),
+				ConfirmationThresholdPercentage: uint32(quorumInfo.ConfirmationThreshold),
+				ChunkLength:                     uint32(quorumInfo.ChunkLength),
+			}
+			quorumIndexes[i] = byte(slices.Index(quorumNumbers, quorumInfo.QuorumID))
+		}
+
+		var payloadHash []byte
+		if metadata.RequestMetadata != nil {
+			payloadHash = metadata.RequestMetadata.PayloadHash
+		}
+
+		return &disperser_rpc.BlobStatusReply{
+			Status: ToBlobStatusProto(metadata.BlobStatus),
+			Info: &disperser_rpc.BlobInfo{
+				BlobHeader: &disperser_rpc.BlobHeader{
+					Commitment: &commonpb.G1Commitment{
+						X: confirmationInfo.BlobCommitment.Commitment.X.Marshal(),
+						Y: confirmationInfo.BlobCommitment.Commitment.Y.Marshal(),
+					},
+					DataLength:       dataLength,
+					BlobQuorumParams: blobQuorumParams,
+					PayloadHash:      payloadHash,
+				},
+				BlobVerificationProof: &disperser_rpc.BlobVerificationProof{
+					BatchId:   confirmationInfo.BatchID,
+					BlobIndex: confirmationInfo.BlobIndex,
+					BatchMetadata: &disperser_rpc.BatchMetadata{
+						BatchHeader: &disperser_rpc.BatchHeader{
+							BatchRoot:               confirmationInfo.BatchRoot,
+							QuorumNumbers:           quorumNumbers,
+							QuorumSignedPercentages: quorumPercentSigned,
+							ReferenceBlockNumber:    confirmationInfo.ReferenceBlockNumber,
+						},
+						SignatoryRecordHash:     confirmationInfo.SignatoryRecordHash[:],
+						Fee:                     confirmationInfo.Fee,
+						ConfirmationBlockNumber: confirmationInfo.ConfirmationBlockNumber,
+						BatchHeaderHash:         confirmationInfo.BatchHeaderHash[:],
+						AttestationTimeoutMs:    uint32(confirmationInfo.AttestationTimeout.Milliseconds()),
+						OperatorResponses:       toOperatorResponsesProto(confirmationInfo.OperatorResponses),
+					},
+					InclusionProof: confirmationInfo.BlobInclusionProof,
+					// ref: api/proto/disperser/disperser.proto:BlobVerificationProof.quorum_indexes
+					QuorumIndexes: quorumIndexes,
+				},
+			},
+		}, nil
+	}
+
+	return &disperser_rpc.BlobStatusReply{
+		Status: ToBlobStatusProto(metadata.BlobStatus),
+		Info:   &disperser_rpc.BlobInfo{},
+	}, nil
+}
+
+func toOperatorResponsesProto(responses []*OperatorResponse) []*disperser_rpc.OperatorResponse {
+	operatorResponses := make([]*disperser_rpc.OperatorResponse, len(responses))
+	for i, response := range responses {
+		operatorResponses[i] = &disperser_rpc.OperatorResponse{
+			OperatorId:     response.OperatorID[:],
+			ResponseTimeMs: uint32(response.ResponseTime.Milliseconds()),
+			Signed:         response.Signed,
+			WithinSla:      response.WithinSLA,
+		}
+	}
+	return operatorResponses
+}
+
+// ToBlobStatusProto converts the status of a blob into the status returned by the API, where the blobs being
+// dispersed are still processing.
+func ToBlobStatusProto(status BlobStatus) disperser_rpc.BlobStatus {
+	switch status {
+	case Dispersing, Processing:
+		return disperser_rpc.BlobStatus_PROCESSING
+	case Confirmed:
+		return disperser_rpc.BlobStatus_CONFIRMED
+	case Failed:
+		return disperser_rpc.BlobStatus_FAILED
+	case Finalized:
+		return disperser_rpc.BlobStatus_FINALIZED
+	case InsufficientSignatures:
+		return disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES
+	default:
+		return disperser_rpc.BlobStatus_UNKNOWN
+	}
+}
@@ -0,0 +1,143 @@
+package encoding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BundleStage identifies the stages of Encoder.EncodeAndProve reported to its progress callback
+type BundleStage uint8
+
+const (
+	// BundleStageEncoding is reported before the blob is encoded and its commitments and proofs computed
+	BundleStageEncoding BundleStage = iota
+	// BundleStageSerializing is reported before the commitments and frames are serialized
+	BundleStageSerializing
+	// BundleStageDone is reported once the bundle is complete
+	BundleStageDone
+)
+
+func (s BundleStage) String() string {
+	switch s {
+	case BundleStageEncoding:
+		return "encoding"
+	case BundleStageSerializing:
+		return "serializing"
+	case BundleStageDone:
+		return "done"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// Bundle is the complete output of encoding a blob: its commitments and frames, along with their canonical serialized
+// forms. A bundle is serialized with encoding/json, and the commitments and frames are restored from their serialized
+// forms by UnmarshalBundle.
+type Bundle struct {
+	Params EncodingParams `json:"params"`
+	// Commitments and Frames are the artifacts computed by the prover, the frame at index i being the i-th chunk
+	Commitments BlobCommitments `json:"-"`
+	Frames      []*Frame        `json:"-"`
+
+	// Length is the length of the blob in symbols
+	Length uint `json:"length"`
+	// Commitment, LengthCommitment and LengthProof are the commitments in the format of their MarshalBinary method
+	Commitment       []byte `json:"commitment"`
+	LengthCommitment []byte `json:"length_commitment"`
+	LengthProof      []byte `json:"length_proof"`
+	// Format is the wire format the frames are serialized with
+	Format           ChunkEncodingFormat `json:"format"`
+	SerializedFrames [][]byte            `json:"frames"`
+}
+
+// Encoder encodes blobs into complete bundles with a prover, e.g. to embed the encoder in a pipeline or a test without
+// handling the serialization of the artifacts.
+type Encoder struct {
+	Prover Prover
+	// Format is the wire format the frames are serialized with
+	Format ChunkEncodingFormat
+	// Progress is called, if set, when the encoding of a blob enters a stage
+	Progress func(stage BundleStage)
+}
+
+// EncodeAndProve encodes the data with the given parameters and returns the bundle of its commitments and frames.
+func (e *Encoder) EncodeAndProve(data []byte, params EncodingParams) (*Bundle, error) {
+	if e.Prover == nil {
+		return nil, errors.New("the encoder has no prover")
+	}
+
+	e.report(BundleStageEncoding)
+	commitments, frames, err := e.Prover.EncodeAndProve(data, params)
+	if err != nil {
+		return nil, err
+	}
+
+	e.report(BundleStageSerializing)
+	bundle := &Bundle{
+		Params:           params,
+		Commitments:      commitments,
+		Frames:           frames,
+		Length:           commitments.Length,
+		Format:           e.Format,
+		SerializedFrames: make([][]byte, len(frames)),
+	}
+	if bundle.Commitment, err = commitments.Commitment.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("failed to serialize the commitment: %w", err)
+	}
+	if bundle.LengthCommitment, err = commitments.LengthCommitment.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("failed to serialize the length commitment: %w", err)
+	}
+	if bundle.LengthProof, err = commitments.LengthProof.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("failed to serialize the length proof: %w", err)
+	}
+	for i, frame := range frames {
+		if bundle.SerializedFrames[i], err = frame.SerializeWithFormat(e.Format); err != nil {
+			return nil, fmt.Errorf("failed to serialize frame %d: %w", i, err)
+		}
+	}
+
+	e.report(BundleStageDone)
+	return bundle, nil
+}
+
+func (e *Encoder) report(stage BundleStage) {
+	if e.Progress != nil {
+		e.Progress(stage)
+	}
+}
+
+// UnmarshalBundle decodes a bundle serialized with encoding/json, restoring its commitments and frames from their
+// serialized forms.
+func UnmarshalBundle(data []byte) (*Bundle, error) {
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, err
+	}
+
+	bundle.Commitments = BlobCommitments{
+		Commitment:       new(G1Commitment),
+		LengthCommitment: new(G2Commitment),
+		LengthProof:      new(LengthProof),
+		Length:           bundle.Length,
+	}
+	if err := bundle.Commitments.Commitment.UnmarshalBinary(bundle.Commitment); err != nil {
+		return nil, fmt.Errorf("invalid commitment: %w", err)
+	}
+	if err := bundle.Commitments.LengthCommitment.UnmarshalBinary(bundle.LengthCommitment); err != nil {
+		return nil, fmt.Errorf("invalid length commitment: %w", err)
+	}
+	if err := bundle.Commitments.LengthProof.UnmarshalBinary(bundle.LengthProof); err != nil {
+		return nil, fmt.Errorf("invalid length proof: %w", err)
+	}
+
+	bundle.Frames = make([]*Frame, len(bundle.SerializedFrames))
+	for i, serialized := range bundle.SerializedFrames {
+		frame, err := new(Frame).DeserializeWithFormat(serialized, bundle.Format)
+		if err != nil {
+			return nil, fmt.Errorf("invalid frame %d: %w", i, err)
+		}
+		bundle.Frames[i] = frame
+	}
+	return &bundle, nil
+}
@@ -0,0 +1,55 @@
+package encoding_test
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderEncodeAndProve(t *testing.T) {
+	p, err := prover.NewProver(&kzg.KzgConfig{
+		G1Path:          "../inabox/resources/kzg/g1.point",
+		G2Path:          "../inabox/resources/kzg/g2.point",
+		G2PowerOf2Path:  "../inabox/resources/kzg/g2.point.powerOf2",
+		CacheDir:        "../inabox/resources/kzg/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 2900,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+	}, true)
+	require.NoError(t, err)
+
+	var stages []encoding.BundleStage
+	encoder := &encoding.Encoder{
+		Prover: p,
+		Format: encoding.CompressedChunkEncodingFormat,
+		Progress: func(stage encoding.BundleStage) {
+			stages = append(stages, stage)
+		},
+	}
+
+	data := codec.ConvertByPaddingEmptyByte([]byte("the bundle of a blob carries its commitments and frames"))
+	params := encoding.ParamsFromSysPar(3, 1, uint64(len(data)))
+	bundle, err := encoder.EncodeAndProve(data, params)
+	require.NoError(t, err)
+	assert.Equal(t, []encoding.BundleStage{encoding.BundleStageEncoding, encoding.BundleStageSerializing, encoding.BundleStageDone}, stages)
+	assert.Len(t, bundle.SerializedFrames, int(params.NumChunks))
+
+	serialized, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	decoded, err := encoding.UnmarshalBundle(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, bundle.Params, decoded.Params)
+	assert.Equal(t, bundle.Commitments, decoded.Commitments)
+	require.Len(t, decoded.Frames, len(bundle.Frames))
+	for i := range bundle.Frames {
+		assert.True(t, decoded.Frames[i].Proof.Equal(&bundle.Frames[i].Proof))
+		assert.Equal(t, bundle.Frames[i].Coeffs, decoded.Frames[i].Coeffs)
+	}
+}
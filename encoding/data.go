@@ -1,6 +1,8 @@
 package encoding
 
 import (
+	"fmt"
+
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
@@ -28,21 +30,126 @@ type BlobCommitments struct {
 	Length           uint          `json:"length"`
 }
 
+// ChunkForm identifies the representations of the data carried by a Frame
+type ChunkForm uint8
+
+const (
+	// CoeffChunkForm frames carry the coefficients of the interpolating polynomial of the chunk
+	CoeffChunkForm ChunkForm = iota
+	// EvalChunkForm frames carry the evaluations of the blob polynomial on the coset of the chunk
+	EvalChunkForm
+	// CoeffAndEvalChunkForm frames carry both the coefficients and the evaluations
+	CoeffAndEvalChunkForm
+)
+
+// HasCoeffs returns whether frames of this form carry the coefficients of the chunk
+func (f ChunkForm) HasCoeffs() bool {
+	return f == CoeffChunkForm || f == CoeffAndEvalChunkForm
+}
+
+// HasEvals returns whether frames of this form carry the evaluations of the chunk
+func (f ChunkForm) HasEvals() bool {
+	return f == EvalChunkForm || f == CoeffAndEvalChunkForm
+}
+
+func (f ChunkForm) String() string {
+	switch f {
+	case CoeffChunkForm:
+		return "coeff"
+	case EvalChunkForm:
+		return "eval"
+	case CoeffAndEvalChunkForm:
+		return "both"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(f))
+	}
+}
+
+// ParseChunkForm returns the ChunkForm with the given name
+func ParseChunkForm(name string) (ChunkForm, error) {
+	switch name {
+	case "", "coeff":
+		return CoeffChunkForm, nil
+	case "eval":
+		return EvalChunkForm, nil
+	case "both":
+		return CoeffAndEvalChunkForm, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidChunkForm, name)
+	}
+}
+
 // Frame is a chunk of data with the associated multi-reveal proof
 type Frame struct {
 	// Proof is the multireveal proof corresponding to the chunk
 	Proof Proof
 	// Coeffs contains the coefficience of the interpolating polynomial of the chunk
 	Coeffs []Symbol
+	// Evals contains the evaluations of the blob polynomial on the coset of the chunk, w*φ^i for i in
+	// [0, ChunkLength), with w the leading root of the coset
+	Evals []Symbol
+	// Form is the representation of the chunk carried by the frame. Frames are verified and decoded
+	// from the coefficients if the form has them, and from the evaluations otherwise
+	Form ChunkForm
 }
 
 func (f *Frame) Length() int {
+	if !f.Form.HasCoeffs() {
+		return len(f.Evals)
+	}
 	return len(f.Coeffs)
 }
 
 // Size return the size of chunks in bytes.
 func (f *Frame) Size() uint64 {
-	return uint64(f.Length() * BYTES_PER_SYMBOL)
+	return uint64((len(f.Coeffs) + len(f.Evals)) * BYTES_PER_SYMBOL)
+}
+
+// Validate checks the frame is well-formed for the chunks of the encoding params: its form is known, it carries
+// ChunkLength symbols in each representation of its form and none in the others, its symbols are canonical field
+// elements, and its proof is a point of the G1 subgroup. The checks are cheap compared to the verification of the
+// frame, so the frames received from other parties are validated before being verified.
+func (f *Frame) Validate(params EncodingParams) error {
+	if f.Form > CoeffAndEvalChunkForm {
+		return fmt.Errorf("%w: %d", ErrInvalidChunkForm, f.Form)
+	}
+	if err := validateSymbols("coefficient", f.Coeffs, f.Form, f.Form.HasCoeffs(), params.ChunkLength); err != nil {
+		return err
+	}
+	if err := validateSymbols("evaluation", f.Evals, f.Form, f.Form.HasEvals(), params.ChunkLength); err != nil {
+		return err
+	}
+	if !f.Proof.IsOnCurve() || !f.Proof.IsInSubGroup() {
+		return fmt.Errorf("%w: proof is not in the G1 subgroup", ErrInvalidProofPoint)
+	}
+	return nil
+}
+
+// validateSymbols checks a representation of a frame of the given form, which carries it or not
+func validateSymbols(name string, symbols []Symbol, form ChunkForm, carried bool, chunkLength uint64) error {
+	if !carried {
+		if len(symbols) != 0 {
+			return fmt.Errorf("%w: %s frame carries %d %ss", ErrInvalidChunkForm, form, len(symbols), name)
+		}
+		return nil
+	}
+	if uint64(len(symbols)) != chunkLength {
+		return fmt.Errorf("%w: got %d %ss for chunks of length %d", ErrInvalidFrameLength, len(symbols), name, chunkLength)
+	}
+	for i := range symbols {
+		if !isCanonical(&symbols[i]) {
+			return fmt.Errorf("%w: %s at index %d", ErrNonCanonicalSymbol, name, i)
+		}
+	}
+	return nil
+}
+
+// isCanonical returns whether the symbol is reduced, which its raw limbs may not be if it wasn't set through the
+// field operations: re-encoding it canonically must yield the same symbol
+func isCanonical(s *Symbol) bool {
+	var canonical Symbol
+	value := s.Bytes()
+	return canonical.SetBytesCanonical(value[:]) == nil && canonical.Equal(s)
 }
 
 // Sample is a chunk with associated metadata used by the Universal Batch Verifier
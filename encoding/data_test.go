@@ -0,0 +1,41 @@
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameValidate(t *testing.T) {
+	params := encoding.ParamsFromMins(4, 8)
+
+	frame := makeTestFrame(t, 4)
+	assert.NoError(t, frame.Validate(params))
+
+	frame = makeTestFrame(t, 2)
+	assert.ErrorIs(t, frame.Validate(params), encoding.ErrInvalidFrameLength)
+
+	// The frame must only carry the representations of its form
+	frame = makeTestFrame(t, 4)
+	frame.Form = encoding.EvalChunkForm
+	assert.ErrorIs(t, frame.Validate(params), encoding.ErrInvalidChunkForm)
+	frame.Evals, frame.Coeffs = frame.Coeffs, nil
+	assert.NoError(t, frame.Validate(params))
+	frame.Form = encoding.CoeffAndEvalChunkForm
+	assert.ErrorIs(t, frame.Validate(params), encoding.ErrInvalidFrameLength)
+	frame.Form = encoding.CoeffAndEvalChunkForm + 1
+	assert.ErrorIs(t, frame.Validate(params), encoding.ErrInvalidChunkForm)
+
+	// Limbs which aren't reduced modulo the field order
+	frame = makeTestFrame(t, 4)
+	frame.Coeffs[2] = fr.Element{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+	assert.ErrorIs(t, frame.Validate(params), encoding.ErrNonCanonicalSymbol)
+
+	// A proof off the curve
+	frame = makeTestFrame(t, 4)
+	frame.Proof.X.SetOne()
+	frame.Proof.Y.SetOne()
+	assert.ErrorIs(t, frame.Validate(params), encoding.ErrInvalidProofPoint)
+}
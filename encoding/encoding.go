@@ -5,12 +5,33 @@ type Decoder interface {
 	Decode(chunks []*Frame, indices []ChunkNumber, params EncodingParams, inputSize uint64) ([]byte, error)
 }
 
+// Checkpoint is called by a prover between the stages of an encoding. It may block to pause the encoding,
+// e.g. so that a more urgent encoding can use the resources in the meantime.
+type Checkpoint func()
+
+// Wait calls the checkpoint, if any.
+func (c Checkpoint) Wait() {
+	if c != nil {
+		c()
+	}
+}
+
 type Prover interface {
 	Decoder
 	// Encode takes in a blob and returns the commitments and encoded chunks. The encoding will satisfy the property that
 	// for any number M such that M*params.ChunkLength > BlobCommitments.Length, then any set of M chunks will be sufficient to
 	// reconstruct the blob.
 	EncodeAndProve(data []byte, params EncodingParams) (BlobCommitments, []*Frame, error)
+	// EncodeAndProveWithCheckpoint is EncodeAndProve, calling the checkpoint between the stages of the encoding so that
+	// the encoding can be paused.
+	EncodeAndProveWithCheckpoint(data []byte, params EncodingParams, checkpoint Checkpoint) (BlobCommitments, []*Frame, error)
+	// EncodeAndProveBatch is EncodeAndProve for a batch of blobs, encoding the i-th blob with the i-th parameters. The stages
+	// of the encodings are pipelined across the blobs, which gives a higher throughput than encoding them one at a time.
+	EncodeAndProveBatch(data [][]byte, params []EncodingParams) ([]BlobCommitments, [][]*Frame, error)
+	// EncodeChunks takes in a blob and returns only the encoded chunks with the given indices, in the order of the indices.
+	// The chunks are identical to the chunks at the same indices returned by EncodeAndProve, so this can be used to cheaply
+	// regenerate the chunks of operators that missed them.
+	EncodeChunks(data []byte, params EncodingParams, indices []ChunkNumber) ([]*Frame, error)
 }
 
 type Verifier interface {
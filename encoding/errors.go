@@ -0,0 +1,26 @@
+package encoding
+
+import "errors"
+
+// The errors of the encoding packages wrap these sentinels, so that callers can tell the kind of a failure apart
+// with errors.Is instead of matching the error messages
+var (
+	// ErrInvalidParams is returned for encoding parameters which aren't powers of 2 or are too small for the data
+	ErrInvalidParams = errors.New("invalid encoding params")
+	// ErrSRSOutOfRange is returned when an operation needs more SRS points than are loaded
+	ErrSRSOutOfRange = errors.New("not enough SRS points")
+	// ErrProofInvalid is returned when a proof, of the frames of a chunk or of the length of a blob, doesn't verify
+	ErrProofInvalid = errors.New("invalid proof")
+	// ErrInsufficientChunks is returned when there aren't enough chunks to reconstruct the data
+	ErrInsufficientChunks = errors.New("insufficient chunks")
+	// ErrNonCanonicalSymbol is returned when a 32 byte symbol of a blob, read as a big-endian integer, isn't less
+	// than the modulus of the bn254 scalar field
+	ErrNonCanonicalSymbol = errors.New("non-canonical field element")
+	// ErrInvalidChunkForm is returned for an unknown chunk form, or a frame whose data doesn't match its form
+	ErrInvalidChunkForm = errors.New("invalid chunk form")
+	// ErrInvalidFrameLength is returned for a frame which doesn't carry as many symbols as the chunks of its
+	// encoding params
+	ErrInvalidFrameLength = errors.New("invalid frame length")
+	// ErrInvalidProofPoint is returned for a proof which isn't a point of the G1 subgroup
+	ErrInvalidProofPoint = errors.New("invalid proof point")
+)
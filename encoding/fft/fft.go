@@ -28,9 +28,11 @@ package fft
 
 import (
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/utils/scratch"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 
 	"math/bits"
+	"sync"
 )
 
 // if not already a power of 2, return the next power of 2
@@ -67,24 +69,58 @@ type FFTSettings struct {
 	ExpandedRootsOfUnity []fr.Element
 	// reverse domain, same as inverse values of domain. Also starting and ending with 1.
 	ReverseRootsOfUnity []fr.Element
+	// Scratch provides the temporary slices used by the transforms and their callers
+	Scratch *scratch.Pool[fr.Element]
 }
 
+// rootsOfUnity are the expanded roots of unity of a domain and their reverse
+type rootsOfUnity struct {
+	expanded []fr.Element
+	reverse  []fr.Element
+}
+
+var (
+	// rootsOfUnityCache holds the roots of unity of every domain size used by the process, so FFTSettings of the same
+	// size, e.g. of the parametrized provers and verifiers of a blob size, share them instead of each expanding them
+	rootsOfUnityCache   = make(map[uint8]*rootsOfUnity)
+	rootsOfUnityCacheMu sync.Mutex
+)
+
+// getRootsOfUnity returns the roots of unity of the domain of size 2^maxScale, expanding them on first use. The
+// slices are shared and must not be modified; their capacity is their length so appending to them copies them.
+func getRootsOfUnity(maxScale uint8) ([]fr.Element, []fr.Element) {
+	rootsOfUnityCacheMu.Lock()
+	defer rootsOfUnityCacheMu.Unlock()
+
+	roots, ok := rootsOfUnityCache[maxScale]
+	if !ok {
+		rootz := expandRootOfUnity(&encoding.Scale2RootOfUnity[maxScale])
+
+		// reverse roots of unity
+		rootzReverse := make([]fr.Element, len(rootz))
+		copy(rootzReverse, rootz)
+		for i, j := uint64(0), uint64(len(rootz)-1); i < j; i, j = i+1, j-1 {
+			rootzReverse[i], rootzReverse[j] = rootzReverse[j], rootzReverse[i]
+		}
+
+		roots = &rootsOfUnity{expanded: rootz, reverse: rootzReverse}
+		rootsOfUnityCache[maxScale] = roots
+	}
+	return roots.expanded[:len(roots.expanded):len(roots.expanded)], roots.reverse[:len(roots.reverse):len(roots.reverse)]
+}
+
+// NewFFTSettings returns the settings of the FFTs over domains of size up to 2^maxScale. The roots of unity are shared
+// by all the settings of the same size and must not be modified.
 func NewFFTSettings(maxScale uint8) *FFTSettings {
 	width := uint64(1) << maxScale
 	root := &encoding.Scale2RootOfUnity[maxScale]
-	rootz := expandRootOfUnity(&encoding.Scale2RootOfUnity[maxScale])
-
-	// reverse roots of unity
-	rootzReverse := make([]fr.Element, len(rootz))
-	copy(rootzReverse, rootz)
-	for i, j := uint64(0), uint64(len(rootz)-1); i < j; i, j = i+1, j-1 {
-		rootzReverse[i], rootzReverse[j] = rootzReverse[j], rootzReverse[i]
-	}
+	rootz, rootzReverse := getRootsOfUnity(maxScale)
 
 	return &FFTSettings{
 		MaxWidth:             width,
 		RootOfUnity:          root,
 		ExpandedRootsOfUnity: rootz,
 		ReverseRootsOfUnity:  rootzReverse,
+		Scratch:              scratch.NewPool[fr.Element](true),
 	}
 }
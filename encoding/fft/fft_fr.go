@@ -26,6 +26,7 @@ package fft
 import (
 	"fmt"
 
+	rb "github.com/Layr-Labs/eigenda/encoding/utils/reverseBits"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
 
@@ -84,7 +85,8 @@ func (fs *FFTSettings) FFT(vals []fr.Element, inv bool) ([]fr.Element, error) {
 	}
 	n = nextPowOf2(n)
 	// We make a copy so we can mutate it during the work.
-	valsCopy := make([]fr.Element, n)
+	valsCopy := fs.Scratch.Get(int(n))
+	defer fs.Scratch.Put(valsCopy)
 	for i := 0; i < len(vals); i++ {
 		valsCopy[i].Set(&vals[i])
 
@@ -132,6 +134,55 @@ func (fs *FFTSettings) InplaceFFT(vals []fr.Element, out []fr.Element, inv bool)
 	}
 }
 
+// FFTInPlace computes the FFT of vals, or the inverse FFT if inv is true, overwriting vals with the result. Unlike
+// FFT and InplaceFFT, which write the result to a separate buffer, it allocates no buffer of the size of the input,
+// halving the peak memory of large transforms. The number of values must be a power of two.
+func (fs *FFTSettings) FFTInPlace(vals []fr.Element, inv bool) error {
+	n := uint64(len(vals))
+	if n > fs.MaxWidth {
+		return fmt.Errorf("got %d values but only have %d roots of unity", n, fs.MaxWidth)
+	}
+	if !IsPowerOfTwo(n) {
+		return fmt.Errorf("got %d values but not a power of two", n)
+	}
+
+	rootz := fs.ExpandedRootsOfUnity[:fs.MaxWidth]
+	if inv {
+		rootz = fs.ReverseRootsOfUnity[:fs.MaxWidth]
+	}
+	stride := fs.MaxWidth / n
+
+	// Iterative radix-2 Cooley-Tukey: after the bit reversal permutation, each pass merges the transforms of
+	// adjacent blocks of half the size, using the roots of unity of the block size
+	if err := rb.ReverseBitOrderFr(vals); err != nil {
+		return err
+	}
+	var yTimesRoot fr.Element
+	for size := uint64(2); size <= n; size <<= 1 {
+		half := size >> 1
+		rootStride := stride * (n / size)
+		for start := uint64(0); start < n; start += size {
+			for i := uint64(0); i < half; i++ {
+				x := &vals[start+i]
+				y := &vals[start+i+half]
+				yTimesRoot.Mul(y, &rootz[i*rootStride])
+				y.Sub(x, &yTimesRoot)
+				x.Add(x, &yTimesRoot)
+			}
+		}
+	}
+
+	if inv {
+		var invLen fr.Element
+		invLen.SetUint64(n)
+		invLen.Inverse(&invLen)
+		for i := range vals {
+			vals[i].Mul(&vals[i], &invLen)
+		}
+	}
+	return nil
+}
+
 func IsPowerOfTwo(v uint64) bool {
 	return v&(v-1) == 0
 }
@@ -100,3 +100,48 @@ func TestInvFFT(t *testing.T) {
 		assert.True(t, res[i].Equal(&expected[i]))
 	}
 }
+
+func TestSharedRootsOfUnity(t *testing.T) {
+	fs1 := NewFFTSettings(5)
+	fs2 := NewFFTSettings(5)
+	require.Len(t, fs1.ExpandedRootsOfUnity, 33)
+	assert.Same(t, &fs1.ExpandedRootsOfUnity[0], &fs2.ExpandedRootsOfUnity[0])
+	assert.Same(t, &fs1.ReverseRootsOfUnity[0], &fs2.ReverseRootsOfUnity[0])
+
+	// Appending to the roots of one settings doesn't change the roots of the other
+	var extra fr.Element
+	extra.SetInt64(7)
+	extended := append(fs1.ExpandedRootsOfUnity, extra)
+	assert.NotSame(t, &extended[0], &fs2.ExpandedRootsOfUnity[0])
+	assert.Len(t, fs2.ExpandedRootsOfUnity, 33)
+
+	// The roots are the ones of the domain
+	for i := 0; i <= 32; i++ {
+		var inverse fr.Element
+		inverse.Inverse(&fs1.ExpandedRootsOfUnity[i])
+		assert.True(t, inverse.Equal(&fs1.ReverseRootsOfUnity[i]))
+	}
+	assert.NotSame(t, &NewFFTSettings(4).ExpandedRootsOfUnity[0], &fs1.ExpandedRootsOfUnity[0])
+}
+
+func TestFFTInPlace(t *testing.T) {
+	fs := NewFFTSettings(6)
+	for _, n := range []uint64{1, 2, 4, 16, 64} {
+		data := make([]fr.Element, n)
+		for i := range data {
+			data[i].SetRandom()
+		}
+		for _, inv := range []bool{false, true} {
+			expected, err := fs.FFT(data, inv)
+			require.NoError(t, err)
+
+			vals := make([]fr.Element, n)
+			copy(vals, data)
+			require.NoError(t, fs.FFTInPlace(vals, inv))
+			assert.Equal(t, expected, vals)
+		}
+	}
+
+	assert.Error(t, fs.FFTInPlace(make([]fr.Element, 3), false))
+	assert.Error(t, fs.FFTInPlace(make([]fr.Element, 128), false))
+}
@@ -2,22 +2,39 @@ package kzg
 
 import (
 	"runtime"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
 	"github.com/urfave/cli"
 )
 
 const (
-	G1PathFlagName            = "kzg.g1-path"
-	G2PathFlagName            = "kzg.g2-path"
-	CachePathFlagName         = "kzg.cache-path"
-	SRSOrderFlagName          = "kzg.srs-order"
-	NumWorkerFlagName         = "kzg.num-workers"
-	VerboseFlagName           = "kzg.verbose"
-	PreloadEncoderFlagName    = "kzg.preload-encoder"
-	CacheEncodedBlobsFlagName = "cache-encoded-blobs"
-	SRSLoadingNumberFlagName  = "kzg.srs-load"
-	G2PowerOf2PathFlagName    = "kzg.g2-power-of-2-path"
+	G1PathFlagName              = "kzg.g1-path"
+	G2PathFlagName              = "kzg.g2-path"
+	CachePathFlagName           = "kzg.cache-path"
+	SRSOrderFlagName            = "kzg.srs-order"
+	NumWorkerFlagName           = "kzg.num-workers"
+	VerboseFlagName             = "kzg.verbose"
+	PreloadEncoderFlagName      = "kzg.preload-encoder"
+	CacheEncodedBlobsFlagName   = "cache-encoded-blobs"
+	SRSLoadingNumberFlagName    = "kzg.srs-load"
+	G2PowerOf2PathFlagName      = "kzg.g2-power-of-2-path"
+	DisableScratchPoolFlagName  = "kzg.disable-scratch-pool"
+	MSMTableDirFlagName         = "kzg.msm-table-dir"
+	MSMTableWindowFlagName      = "kzg.msm-table-window"
+	CommitmentCacheSizeFlagName = "kzg.commitment-cache-size"
+	CommitmentCacheTTLFlagName  = "kzg.commitment-cache-ttl"
+	ProofAlgorithmFlagName      = "kzg.proof-algorithm"
+	MSMCalibrationDirFlagName   = "kzg.msm-calibration-dir"
+	SharedSRSDirFlagName        = "kzg.shared-srs-dir"
+	G1URLFlagName               = "kzg.g1-url"
+	G1SHA256FlagName            = "kzg.g1-sha256"
+	G2URLFlagName               = "kzg.g2-url"
+	G2SHA256FlagName            = "kzg.g2-sha256"
+	G2PowerOf2URLFlagName       = "kzg.g2-power-of-2-url"
+	G2PowerOf2SHA256FlagName    = "kzg.g2-power-of-2-sha256"
+	ChunkFormFlagName           = "kzg.chunk-form"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -83,6 +100,101 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "G2_POWER_OF_2_PATH"),
 		},
+		cli.BoolFlag{
+			Name:     DisableScratchPoolFlagName,
+			Usage:    "Set to disable reuse of scratch buffers across encoding and verification calls",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DISABLE_SCRATCH_POOL"),
+		},
+		cli.StringFlag{
+			Name:     MSMTableDirFlagName,
+			Usage:    "Path to the directory of the precomputed MSM tables of the G1 SRS. The tables are computed on first use and are recomputed if the SRS changes. If not set, no tables are used",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "MSM_TABLE_DIR"),
+		},
+		cli.Uint64Flag{
+			Name:     MSMTableWindowFlagName,
+			Usage:    "Window size in bits of the precomputed MSM tables. Larger windows make commitments faster but the tables larger",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "MSM_TABLE_WINDOW"),
+			Value:    msm.DefaultWindow,
+		},
+		cli.Uint64Flag{
+			Name:     CommitmentCacheSizeFlagName,
+			Usage:    "Number of blobs whose commitments are cached, so that encoding a duplicate blob skips computing them. Set to 0 to disable the cache",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "COMMITMENT_CACHE_SIZE"),
+			Value:    0,
+		},
+		cli.DurationFlag{
+			Name:     CommitmentCacheTTLFlagName,
+			Usage:    "Duration for which the commitments of a blob are cached",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "COMMITMENT_CACHE_TTL"),
+			Value:    10 * time.Minute,
+		},
+		cli.StringFlag{
+			Name:     ProofAlgorithmFlagName,
+			Usage:    "Algorithm computing the proofs of the chunks: fk20, naive (faster for few chunks), or auto to pick the fastest for each blob size and encoding parameters from benchmarks run once per encoding parameters",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "PROOF_ALGORITHM"),
+			Value:    FK20ProofAlgorithm,
+		},
+		cli.StringFlag{
+			Name:     ChunkFormFlagName,
+			Usage:    "Form of the chunks output by the prover: coeff for the coefficients of their interpolating polynomials, eval for their evaluations on their cosets, or both",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "CHUNK_FORM"),
+			Value:    "coeff",
+		},
+		cli.StringFlag{
+			Name:     MSMCalibrationDirFlagName,
+			Usage:    "Path to the directory of the calibration of the MSM algorithms by input size. The algorithms are benchmarked on first use and again if the machine changes. If not set, default thresholds are used",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "MSM_CALIBRATION_DIR"),
+		},
+		cli.StringFlag{
+			Name:     SharedSRSDirFlagName,
+			Usage:    "Path to the directory of the SRS points in their in-memory layout, which is memory mapped so that the processes of a host sharing the directory share the physical memory of the SRS. The points are written on first use and again if the SRS changes. If not set, each process loads its own copy",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "SHARED_SRS_DIR"),
+		},
+		cli.StringFlag{
+			Name:     G1URLFlagName,
+			Usage:    "URL (http, https or s3://bucket/key) the G1 SRS is downloaded from if it isn't at G1_PATH",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "G1_URL"),
+		},
+		cli.StringFlag{
+			Name:     G1SHA256FlagName,
+			Usage:    "Hex encoded SHA-256 checksum the G1 SRS is verified against",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "G1_SHA256"),
+		},
+		cli.StringFlag{
+			Name:     G2URLFlagName,
+			Usage:    "URL (http, https or s3://bucket/key) the G2 SRS is downloaded from if it isn't at G2_PATH",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "G2_URL"),
+		},
+		cli.StringFlag{
+			Name:     G2SHA256FlagName,
+			Usage:    "Hex encoded SHA-256 checksum the G2 SRS is verified against",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "G2_SHA256"),
+		},
+		cli.StringFlag{
+			Name:     G2PowerOf2URLFlagName,
+			Usage:    "URL (http, https or s3://bucket/key) the G2 power of 2 SRS is downloaded from if it isn't at G2_POWER_OF_2_PATH",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "G2_POWER_OF_2_URL"),
+		},
+		cli.StringFlag{
+			Name:     G2PowerOf2SHA256FlagName,
+			Usage:    "Hex encoded SHA-256 checksum the G2 power of 2 SRS is verified against",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "G2_POWER_OF_2_SHA256"),
+		},
 	}
 }
 
@@ -97,6 +209,21 @@ func ReadCLIConfig(ctx *cli.Context) KzgConfig {
 	cfg.Verbose = ctx.GlobalBool(VerboseFlagName)
 	cfg.PreloadEncoder = ctx.GlobalBool(PreloadEncoderFlagName)
 	cfg.G2PowerOf2Path = ctx.GlobalString(G2PowerOf2PathFlagName)
+	cfg.DisableScratchPool = ctx.GlobalBool(DisableScratchPoolFlagName)
+	cfg.MSMTableDir = ctx.GlobalString(MSMTableDirFlagName)
+	cfg.MSMTableWindow = ctx.GlobalUint64(MSMTableWindowFlagName)
+	cfg.CommitmentCacheSize = ctx.GlobalUint64(CommitmentCacheSizeFlagName)
+	cfg.CommitmentCacheTTL = ctx.GlobalDuration(CommitmentCacheTTLFlagName)
+	cfg.ProofAlgorithm = ctx.GlobalString(ProofAlgorithmFlagName)
+	cfg.ChunkForm = ctx.GlobalString(ChunkFormFlagName)
+	cfg.MSMCalibrationDir = ctx.GlobalString(MSMCalibrationDirFlagName)
+	cfg.SharedSRSDir = ctx.GlobalString(SharedSRSDirFlagName)
+	cfg.G1Source = SRSSource{URL: ctx.GlobalString(G1URLFlagName), SHA256: ctx.GlobalString(G1SHA256FlagName)}
+	cfg.G2Source = SRSSource{URL: ctx.GlobalString(G2URLFlagName), SHA256: ctx.GlobalString(G2SHA256FlagName)}
+	cfg.G2PowerOf2Source = SRSSource{
+		URL:    ctx.GlobalString(G2PowerOf2URLFlagName),
+		SHA256: ctx.GlobalString(G2PowerOf2SHA256FlagName),
+	}
 
 	return cfg
 }
@@ -0,0 +1,240 @@
+// Package committer computes the commitment, length commitment and length proof of a blob. It only loads the
+// SRS points needed for blobs up to a maximum length, and none of the tables of the prover, so that clients can
+// compute the commitments of their blobs to cross-check the commitments returned by the disperser.
+package committer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/eip4844"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+type Config struct {
+	G1Path string
+	// G2Path is the path of the G2 SRS. If empty, the committer only computes the commitments of the blobs, see
+	// ComputeCommitment, and not their length commitments and proofs
+	G2Path string
+	// SRSOrder is the total size of the SRS the points are read from
+	SRSOrder uint64
+	// MaxBlobLength is the length, in symbols, of the largest blob to commit to. Only this many points of
+	// each SRS are loaded
+	MaxBlobLength uint64
+	NumWorker     uint64
+	// G1LagrangePath is the path of the G1 SRS in Lagrange form over the domain of the
+	// NextPowerOf2(MaxBlobLength)-th roots of unity, as computed by kzg.ComputeLagrangeG1 (see tools/lagrangesrs).
+	// If set, the committer can commit to blobs given as their evaluations over the domain without an IFFT. The
+	// points are checked against the G1 SRS when they are loaded
+	G1LagrangePath string
+}
+
+type Committer struct {
+	g1 []bn254.G1Affine
+	g2 []bn254.G2Affine
+	// g2Trailing are the last MaxBlobLength points of the G2 SRS, which the length proofs are computed from
+	g2Trailing []bn254.G2Affine
+	// lagrangeG1 is the G1 SRS in Lagrange form, if it was loaded
+	lagrangeG1 []bn254.G1Affine
+}
+
+func NewCommitter(config Config) (*Committer, error) {
+	if config.MaxBlobLength == 0 {
+		return nil, errors.New("max blob length must be positive")
+	}
+	if config.MaxBlobLength > config.SRSOrder {
+		return nil, fmt.Errorf("max blob length %d is larger than SRSOrder %d", config.MaxBlobLength, config.SRSOrder)
+	}
+	numWorker := max(config.NumWorker, 1)
+
+	// The Lagrange G1 SRS is checked against as many points of the G1 SRS as the size of its domain
+	numG1 := config.MaxBlobLength
+	if config.G1LagrangePath != "" {
+		numG1 = encoding.NextPowerOf2(config.MaxBlobLength)
+		if numG1 > config.SRSOrder {
+			return nil, fmt.Errorf("the Lagrange G1 SRS domain %d is larger than SRSOrder %d", numG1, config.SRSOrder)
+		}
+	}
+	g1, err := kzg.ReadG1Points(config.G1Path, numG1, numWorker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read G1 points: %w", err)
+	}
+	c := &Committer{g1: g1[:config.MaxBlobLength]}
+	if config.G2Path != "" {
+		g2, err := kzg.ReadG2Points(config.G2Path, config.MaxBlobLength, numWorker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read G2 points: %w", err)
+		}
+		g2Trailing, err := kzg.ReadG2PointSection(config.G2Path, config.SRSOrder-config.MaxBlobLength, config.SRSOrder, numWorker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trailing G2 points: %w", err)
+		}
+		c, err = NewCommitterFromPoints(g1[:config.MaxBlobLength], g2, g2Trailing)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.G1LagrangePath != "" {
+		lagrangeG1, err := kzg.ReadG1Points(config.G1LagrangePath, encoding.NextPowerOf2(config.MaxBlobLength), numWorker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Lagrange G1 points: %w", err)
+		}
+		if err := kzg.CheckLagrangeG1(g1, lagrangeG1); err != nil {
+			return nil, fmt.Errorf("failed to check Lagrange G1 points: %w", err)
+		}
+		c.lagrangeG1 = lagrangeG1
+	}
+	return c, nil
+}
+
+// NewCommitterFromPoints returns a Committer using SRS points that are already loaded. g2Trailing are the last
+// points of the G2 SRS, and must be as many as the points of g1 and g2.
+func NewCommitterFromPoints(g1 []bn254.G1Affine, g2, g2Trailing []bn254.G2Affine) (*Committer, error) {
+	if len(g1) != len(g2) || len(g2) != len(g2Trailing) {
+		return nil, fmt.Errorf("mismatched number of SRS points: %d G1, %d G2, %d trailing G2", len(g1), len(g2), len(g2Trailing))
+	}
+	return &Committer{
+		g1:         g1,
+		g2:         g2,
+		g2Trailing: g2Trailing,
+	}, nil
+}
+
+// ComputeCommitments computes the commitments of a blob, as the disperser does. Every 32 bytes of the data must be
+// a valid field element.
+func (c *Committer) ComputeCommitments(data []byte) (encoding.BlobCommitments, error) {
+	coeffs, err := rs.ToFrArray(data)
+	if err != nil {
+		return encoding.BlobCommitments{}, err
+	}
+	commit, lengthCommitment, lengthProof, err := c.Commit(coeffs)
+	if err != nil {
+		return encoding.BlobCommitments{}, err
+	}
+	return encoding.BlobCommitments{
+		Commitment:       (*encoding.G1Commitment)(commit),
+		LengthCommitment: (*encoding.G2Commitment)(lengthCommitment),
+		LengthProof:      (*encoding.LengthProof)(lengthProof),
+		Length:           uint(len(coeffs)),
+	}, nil
+}
+
+// ComputeCommitment computes the commitment of a blob, as the disperser does, without its length commitment and proof.
+// Every 32 bytes of the data must be a valid field element.
+func (c *Committer) ComputeCommitment(data []byte) (*encoding.G1Commitment, error) {
+	coeffs, err := rs.ToFrArray(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(coeffs) > len(c.g1) {
+		return nil, fmt.Errorf("poly Coeff length %v is greater than Loaded SRS points %v", len(coeffs), len(c.g1))
+	}
+	commit, err := msm.MultiExpG1(c.g1, coeffs)
+	if err != nil {
+		return nil, err
+	}
+	return (*encoding.G1Commitment)(&commit), nil
+}
+
+// ComputeBlobProof computes the commitment of a blob, along with the proof of the evaluation of the blob at its
+// challenge, which binds the data to the commitment as verified by eip4844.VerifyBlobProof. Every 32 bytes of the
+// data must be a valid field element.
+func (c *Committer) ComputeBlobProof(data []byte) (*encoding.G1Commitment, *bn254.G1Affine, error) {
+	evaluation, err := eip4844.NewProver(c.g1).ComputePointEvaluation(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return (*encoding.G1Commitment)(&evaluation.Commitment), &evaluation.Proof, nil
+}
+
+// VerifyCommitments returns an error if the commitments don't match the commitments computed from the data
+func (c *Committer) VerifyCommitments(data []byte, commitments encoding.BlobCommitments) error {
+	expected, err := c.ComputeCommitments(data)
+	if err != nil {
+		return err
+	}
+	if commitments.Length != expected.Length {
+		return fmt.Errorf("blob length %d doesn't match the data length %d", commitments.Length, expected.Length)
+	}
+	if commitments.Commitment == nil || !(*bn254.G1Affine)(commitments.Commitment).Equal((*bn254.G1Affine)(expected.Commitment)) {
+		return errors.New("commitment doesn't match the data")
+	}
+	if commitments.LengthCommitment == nil || !(*bn254.G2Affine)(commitments.LengthCommitment).Equal((*bn254.G2Affine)(expected.LengthCommitment)) {
+		return errors.New("length commitment doesn't match the data")
+	}
+	if commitments.LengthProof == nil || !(*bn254.G2Affine)(commitments.LengthProof).Equal((*bn254.G2Affine)(expected.LengthProof)) {
+		return errors.New("length proof doesn't match the data")
+	}
+	return nil
+}
+
+// Commit computes the commitment, the length commitment and the length proof of the polynomial
+func (c *Committer) Commit(coeffs []fr.Element) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, error) {
+	if len(c.g2) == 0 {
+		return nil, nil, nil, errors.New("the G2 SRS isn't loaded")
+	}
+	if len(coeffs) > len(c.g1) {
+		return nil, nil, nil, fmt.Errorf("poly Coeff length %v is greater than Loaded SRS points %v", len(coeffs), len(c.g1))
+	}
+
+	commit, err := msm.MultiExpG1(c.g1, coeffs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lengthCommitment, err := LengthCommitment(c.g2, coeffs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lengthProof, err := LengthProof(c.g2Trailing, coeffs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return &commit, lengthCommitment, lengthProof, nil
+}
+
+// CommitEvaluations computes the commitment of the polynomial given as its evaluations over the domain of the
+// Lagrange G1 SRS, which is the commitment Commit computes from the IFFT of the evaluations. It requires the
+// committer to be configured with G1LagrangePath, and as many evaluations as the size of the domain.
+func (c *Committer) CommitEvaluations(evals []fr.Element) (*bn254.G1Affine, error) {
+	if len(c.lagrangeG1) == 0 {
+		return nil, errors.New("the Lagrange G1 SRS isn't loaded")
+	}
+	if len(evals) != len(c.lagrangeG1) {
+		return nil, fmt.Errorf("got %v evaluations but the Lagrange G1 SRS domain has %v points", len(evals), len(c.lagrangeG1))
+	}
+
+	commit, err := msm.MultiExpG1(c.lagrangeG1, evals)
+	if err != nil {
+		return nil, err
+	}
+	return &commit, nil
+}
+
+// LengthCommitment computes the commitment of the polynomial in G2, from the first points of the G2 SRS
+func LengthCommitment(g2 []bn254.G2Affine, coeffs []fr.Element) (*bn254.G2Affine, error) {
+	if len(coeffs) > len(g2) {
+		return nil, fmt.Errorf("poly Coeff length %v is greater than the %v G2 points", len(coeffs), len(g2))
+	}
+	var lengthCommitment bn254.G2Affine
+	_, err := lengthCommitment.MultiExp(g2[:len(coeffs)], coeffs, ecc.MultiExpConfig{})
+	return &lengthCommitment, err
+}
+
+// LengthProof computes the proof that the polynomial has at most len(coeffs) coefficients: the commitment of the
+// polynomial shifted to the largest degree of the SRS. g2Trailing are the last points of the G2 SRS.
+func LengthProof(g2Trailing []bn254.G2Affine, coeffs []fr.Element) (*bn254.G2Affine, error) {
+	if len(coeffs) > len(g2Trailing) {
+		return nil, fmt.Errorf("poly Coeff length %v is greater than the %v trailing G2 points", len(coeffs), len(g2Trailing))
+	}
+	shiftedSecret := g2Trailing[len(g2Trailing)-len(coeffs):]
+
+	var lengthProof bn254.G2Affine
+	_, err := lengthProof.MultiExp(shiftedSecret, coeffs, ecc.MultiExpConfig{})
+	return &lengthProof, err
+}
@@ -0,0 +1,178 @@
+package committer_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/fft"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/committer"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/eip4844"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	data = codec.ConvertByPaddingEmptyByte([]byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal."))
+
+	kzgConfig = &kzg.KzgConfig{
+		G1Path:          "../../../inabox/resources/kzg/g1.point",
+		G2Path:          "../../../inabox/resources/kzg/g2.point",
+		CacheDir:        "../../../inabox/resources/kzg/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 2900,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+	}
+)
+
+func newCommitter(t *testing.T, maxBlobLength uint64) *committer.Committer {
+	c, err := committer.NewCommitter(committer.Config{
+		G1Path:        kzgConfig.G1Path,
+		G2Path:        kzgConfig.G2Path,
+		SRSOrder:      kzgConfig.SRSOrder,
+		MaxBlobLength: maxBlobLength,
+		NumWorker:     kzgConfig.NumWorker,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestCommitmentsMatchProver(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, true)
+	require.NoError(t, err)
+	v, err := verifier.NewVerifier(kzgConfig, true)
+	require.NoError(t, err)
+
+	expected, _, err := p.EncodeAndProve(data, encoding.ParamsFromMins(16, 4))
+	require.NoError(t, err)
+
+	c := newCommitter(t, 64)
+	commitments, err := c.ComputeCommitments(data)
+	require.NoError(t, err)
+	assert.Equal(t, expected, commitments)
+	assert.NoError(t, v.VerifyBlobLength(commitments))
+	assert.NoError(t, c.VerifyCommitments(data, expected))
+}
+
+func TestVerifyCommitmentsMismatch(t *testing.T) {
+	c := newCommitter(t, 64)
+	commitments, err := c.ComputeCommitments(data)
+	require.NoError(t, err)
+
+	other := append([]byte{}, data...)
+	other[1] ^= 1
+	assert.ErrorContains(t, c.VerifyCommitments(other, commitments), "commitment doesn't match")
+
+	commitments.Length++
+	assert.ErrorContains(t, c.VerifyCommitments(data, commitments), "blob length")
+}
+
+func TestComputeCommitmentWithoutG2(t *testing.T) {
+	c, err := committer.NewCommitter(committer.Config{
+		G1Path:        kzgConfig.G1Path,
+		SRSOrder:      kzgConfig.SRSOrder,
+		MaxBlobLength: 64,
+	})
+	require.NoError(t, err)
+
+	commitment, err := c.ComputeCommitment(data)
+	require.NoError(t, err)
+	expected, err := newCommitter(t, 64).ComputeCommitments(data)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Commitment, commitment)
+
+	// The length commitment and proof need the G2 SRS
+	_, err = c.ComputeCommitments(data)
+	assert.ErrorContains(t, err, "the G2 SRS isn't loaded")
+}
+
+func TestComputeBlobProof(t *testing.T) {
+	c := newCommitter(t, 64)
+	commitment, proof, err := c.ComputeBlobProof(data)
+	require.NoError(t, err)
+	expected, err := c.ComputeCommitment(data)
+	require.NoError(t, err)
+	assert.Equal(t, expected, commitment)
+
+	g2, err := kzg.ReadG2Points(kzgConfig.G2Path, 2, 1)
+	require.NoError(t, err)
+	assert.NoError(t, eip4844.VerifyBlobProof(&g2[1], data, (*bn254.G1Affine)(commitment), proof))
+
+	// The proof doesn't verify against other data
+	other := append([]byte{}, data...)
+	other[1] ^= 1
+	assert.Error(t, eip4844.VerifyBlobProof(&g2[1], other, (*bn254.G1Affine)(commitment), proof))
+}
+
+func TestCommitBlobTooLarge(t *testing.T) {
+	c := newCommitter(t, 4)
+	_, err := c.ComputeCommitments(data)
+	assert.ErrorContains(t, err, "greater than Loaded SRS points")
+}
+
+func TestCommitEvaluations(t *testing.T) {
+	g1, err := kzg.ReadG1Points(kzgConfig.G1Path, 64, kzgConfig.NumWorker)
+	require.NoError(t, err)
+	lagrangeG1, err := kzg.ComputeLagrangeG1(g1)
+	require.NoError(t, err)
+	lagrangePath := filepath.Join(t.TempDir(), "g1.lagrange.point")
+	require.NoError(t, kzg.WriteG1Points(lagrangePath, lagrangeG1))
+
+	c, err := committer.NewCommitter(committer.Config{
+		G1Path:         kzgConfig.G1Path,
+		G2Path:         kzgConfig.G2Path,
+		SRSOrder:       kzgConfig.SRSOrder,
+		MaxBlobLength:  64,
+		NumWorker:      kzgConfig.NumWorker,
+		G1LagrangePath: lagrangePath,
+	})
+	require.NoError(t, err)
+
+	evals, err := rs.ToFrArray(data)
+	require.NoError(t, err)
+	evals = append(evals, make([]fr.Element, 64-len(evals))...)
+	commit, err := c.CommitEvaluations(evals)
+	require.NoError(t, err)
+
+	coeffs, err := fft.NewFFTSettings(6).FFT(evals, true)
+	require.NoError(t, err)
+	expected, _, _, err := c.Commit(coeffs)
+	require.NoError(t, err)
+	assert.True(t, commit.Equal(expected))
+
+	_, err = c.CommitEvaluations(evals[:32])
+	assert.ErrorContains(t, err, "evaluations")
+	_, err = newCommitter(t, 64).CommitEvaluations(evals)
+	assert.ErrorContains(t, err, "isn't loaded")
+}
+
+func TestLagrangeG1Mismatch(t *testing.T) {
+	g1, err := kzg.ReadG1Points(kzgConfig.G1Path, 64, kzgConfig.NumWorker)
+	require.NoError(t, err)
+	lagrangeG1, err := kzg.ComputeLagrangeG1(g1)
+	require.NoError(t, err)
+	require.NoError(t, kzg.CheckLagrangeG1(g1, lagrangeG1))
+
+	// a Lagrange SRS with its points out of order doesn't match the G1 SRS, and isn't loaded
+	lagrangeG1[0], lagrangeG1[1] = lagrangeG1[1], lagrangeG1[0]
+	assert.ErrorIs(t, kzg.CheckLagrangeG1(g1, lagrangeG1), kzg.ErrLagrangeG1Mismatch)
+	lagrangePath := filepath.Join(t.TempDir(), "g1.lagrange.point")
+	require.NoError(t, kzg.WriteG1Points(lagrangePath, lagrangeG1))
+
+	_, err = committer.NewCommitter(committer.Config{
+		G1Path:         kzgConfig.G1Path,
+		SRSOrder:       kzgConfig.SRSOrder,
+		MaxBlobLength:  64,
+		NumWorker:      kzgConfig.NumWorker,
+		G1LagrangePath: lagrangePath,
+	})
+	assert.ErrorIs(t, err, kzg.ErrLagrangeG1Mismatch)
+}
@@ -0,0 +1,181 @@
+package kzg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+// partialSuffix is appended to the path of an SRS file while it's being downloaded, so that an interrupted
+// download is resumed from where it stopped and a partial file is never loaded as the SRS
+const partialSuffix = ".partial"
+
+// SRSSource is the location an SRS file is downloaded from
+type SRSSource struct {
+	// URL is the http(s) URL or the s3://bucket/key location of the file
+	URL string
+	// SHA256 is the hex encoded SHA-256 checksum of the file. If empty, the file isn't verified
+	SHA256 string
+}
+
+// DownloadSRS downloads the SRS files of the config whose source is set and which aren't installed yet at
+// their path. Each file is downloaded next to its path, resuming any previous partial download with ranged
+// requests, then verified against its checksum and renamed to its path, so that the provers and verifiers
+// never load a partial or corrupt SRS.
+func DownloadSRS(ctx context.Context, config *KzgConfig) error {
+	files := []struct {
+		name   string
+		path   string
+		source SRSSource
+	}{
+		{"G1", config.G1Path, config.G1Source},
+		{"G2", config.G2Path, config.G2Source},
+		{"G2 power of 2", config.G2PowerOf2Path, config.G2PowerOf2Source},
+	}
+	for _, file := range files {
+		if len(file.source.URL) == 0 {
+			continue
+		}
+		if len(file.path) == 0 {
+			return fmt.Errorf("%s SRS source is set but its path is empty", file.name)
+		}
+		if err := downloadSRSFile(ctx, http.DefaultClient, file.source, file.path); err != nil {
+			return fmt.Errorf("failed to download %s SRS: %w", file.name, err)
+		}
+	}
+	return nil
+}
+
+func downloadSRSFile(ctx context.Context, client *http.Client, source SRSSource, filePath string) error {
+	if _, err := os.Stat(filePath); err == nil {
+		// The files are only installed once verified, so an existing file only needs to be checked if it
+		// was installed manually
+		if err := verifyChecksum(filePath, source.SHA256); err != nil {
+			return fmt.Errorf("installed file %s: %w", filePath, err)
+		}
+		return nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	fileURL, err := srsSourceURL(source.URL)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(filePath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create SRS directory: %w", err)
+	}
+
+	partialPath := filePath + partialSuffix
+	log.Printf("downloading SRS file %s from %s\n", filePath, fileURL)
+	if err := resumeDownload(ctx, client, fileURL, partialPath); err != nil {
+		return err
+	}
+	if err := verifyChecksum(partialPath, source.SHA256); err != nil {
+		// Start over on the next attempt rather than resuming a corrupt download
+		_ = os.Remove(partialPath)
+		return err
+	}
+	return os.Rename(partialPath, filePath)
+}
+
+// resumeDownload downloads the file at fileURL to partialPath, requesting only the bytes missing from
+// partialPath if it exists
+func resumeDownload(ctx context.Context, client *http.Client, fileURL, partialPath string) error {
+	f, err := os.OpenFile(partialPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// The server ignored the range, so the whole file is sent again
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is already complete
+		return nil
+	default:
+		return fmt.Errorf("unexpected status downloading %s: %s", fileURL, resp.Status)
+	}
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+func verifyChecksum(filePath, expected string) error {
+	if len(expected) == 0 {
+		return nil
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(actual, strings.TrimPrefix(expected, "0x")) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// srsSourceURL returns the URL the SRS file is requested from. S3 locations are requested from the public
+// virtual-hosted endpoint of the bucket.
+func srsSourceURL(source string) (string, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid SRS source %s: %w", source, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return source, nil
+	case "s3":
+		if len(u.Host) == 0 || len(strings.TrimPrefix(u.Path, "/")) == 0 {
+			return "", fmt.Errorf("invalid S3 SRS source %s, expected s3://bucket/key", source)
+		}
+		return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", u.Host, strings.TrimPrefix(u.Path, "/")), nil
+	default:
+		return "", fmt.Errorf("unsupported SRS source scheme %s", u.Scheme)
+	}
+}
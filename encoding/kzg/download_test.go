@@ -0,0 +1,96 @@
+package kzg_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSRSServer(t *testing.T, content []byte) (*httptest.Server, *[]string) {
+	ranges := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "g1.point", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(server.Close)
+	return server, &ranges
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDownloadSRS(t *testing.T) {
+	content := bytes.Repeat([]byte("srs points"), 1000)
+	server, ranges := newSRSServer(t, content)
+	g1Path := filepath.Join(t.TempDir(), "srs", "g1.point")
+
+	config := &kzg.KzgConfig{
+		G1Path:   g1Path,
+		G1Source: kzg.SRSSource{URL: server.URL + "/g1.point", SHA256: checksum(content)},
+	}
+	require.NoError(t, kzg.DownloadSRS(context.Background(), config))
+
+	installed, err := os.ReadFile(g1Path)
+	require.NoError(t, err)
+	assert.Equal(t, content, installed)
+	assert.NoFileExists(t, g1Path+".partial")
+	assert.Equal(t, []string{""}, *ranges)
+
+	// An installed file isn't downloaded again
+	require.NoError(t, kzg.DownloadSRS(context.Background(), config))
+	assert.Len(t, *ranges, 1)
+}
+
+func TestDownloadSRSResume(t *testing.T) {
+	content := bytes.Repeat([]byte("srs points"), 1000)
+	server, ranges := newSRSServer(t, content)
+	g1Path := filepath.Join(t.TempDir(), "g1.point")
+	require.NoError(t, os.WriteFile(g1Path+".partial", content[:4000], 0644))
+
+	config := &kzg.KzgConfig{
+		G1Path:   g1Path,
+		G1Source: kzg.SRSSource{URL: server.URL + "/g1.point", SHA256: checksum(content)},
+	}
+	require.NoError(t, kzg.DownloadSRS(context.Background(), config))
+
+	installed, err := os.ReadFile(g1Path)
+	require.NoError(t, err)
+	assert.Equal(t, content, installed)
+	assert.Equal(t, []string{"bytes=4000-"}, *ranges)
+}
+
+func TestDownloadSRSChecksumMismatch(t *testing.T) {
+	content := bytes.Repeat([]byte("srs points"), 1000)
+	server, _ := newSRSServer(t, content)
+	g1Path := filepath.Join(t.TempDir(), "g1.point")
+
+	config := &kzg.KzgConfig{
+		G1Path:   g1Path,
+		G1Source: kzg.SRSSource{URL: server.URL + "/g1.point", SHA256: checksum([]byte("other points"))},
+	}
+	err := kzg.DownloadSRS(context.Background(), config)
+	assert.ErrorContains(t, err, "checksum mismatch")
+	assert.NoFileExists(t, g1Path)
+	assert.NoFileExists(t, g1Path+".partial")
+}
+
+func TestDownloadSRSUnsupportedSource(t *testing.T) {
+	config := &kzg.KzgConfig{
+		G1Path:   filepath.Join(t.TempDir(), "g1.point"),
+		G1Source: kzg.SRSSource{URL: "ftp://example.com/g1.point"},
+	}
+	assert.ErrorContains(t, kzg.DownloadSRS(context.Background(), config), "unsupported SRS source scheme")
+}
@@ -0,0 +1,234 @@
+// Package eip4844 computes, from the BN254 KZG commitment of a blob, the artifacts rollup stacks built around
+// EIP-4844 expect: the versioned hash of the commitment, the Fiat-Shamir challenge of the blob, and the proof of
+// the evaluation of the blob at the challenge, serialized as the input of the point evaluation precompile.
+//
+// The semantics are those of EIP-4844, but over BN254 rather than BLS12-381: commitments and proofs are 32 byte
+// compressed G1 points, and the blob is in coefficient form, as EigenDA commits to it.
+package eip4844
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
+	"github.com/Layr-Labs/eigenda/encoding/poly"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+const (
+	// VersionedHashVersionKzg is the version byte of the versioned hash of a KZG commitment, as in EIP-4844
+	VersionedHashVersionKzg byte = 0x01
+
+	// PointEvaluationInputSize is the size of a serialized PointEvaluation:
+	// versioned_hash | z | y | commitment | proof
+	PointEvaluationInputSize = 32 + 2*fr.Bytes + 2*bn254.SizeOfG1AffineCompressed
+)
+
+var (
+	ErrVersionedHashMismatch = errors.New("versioned hash doesn't match the commitment")
+	ErrInvalidProof          = errors.New("invalid point evaluation proof")
+)
+
+// PointEvaluation is the claim that the blob committed to by Commitment evaluates to Y at Z, along with its proof.
+// It's the input of the point evaluation precompile of EIP-4844.
+type PointEvaluation struct {
+	VersionedHash [32]byte
+	Z             fr.Element
+	Y             fr.Element
+	Commitment    bn254.G1Affine
+	Proof         bn254.G1Affine
+}
+
+// VersionedHash returns the versioned hash of a commitment: the version byte followed by the last 31 bytes of the
+// SHA-256 hash of the compressed commitment.
+func VersionedHash(commitment *bn254.G1Affine) [32]byte {
+	compressed := commitment.Bytes()
+	hash := sha256.Sum256(compressed[:])
+	hash[0] = VersionedHashVersionKzg
+	return hash
+}
+
+// ComputeChallenge returns the point the blob is evaluated at, derived from the blob and its commitment.
+func ComputeChallenge(coeffs []fr.Element, commitment *bn254.G1Affine) (fr.Element, error) {
+	// The message is the degree of the polynomial as a 16 byte big-endian integer, the blob and the commitment,
+	// as the challenge of EIP-4844
+	var msg bytes.Buffer
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], uint64(len(coeffs)))
+	msg.Write(degree[:])
+	for i := range coeffs {
+		b := coeffs[i].Bytes()
+		msg.Write(b[:])
+	}
+	compressed := commitment.Bytes()
+	msg.Write(compressed[:])
+
+	return kzg.HashToFr(msg.Bytes(), kzg.PointEvaluationDST)
+}
+
+// Serialize returns the point evaluation as the input of the point evaluation precompile
+func (p *PointEvaluation) Serialize() []byte {
+	z := p.Z.Bytes()
+	y := p.Y.Bytes()
+	commitment := p.Commitment.Bytes()
+	proof := p.Proof.Bytes()
+
+	data := make([]byte, 0, PointEvaluationInputSize)
+	data = append(data, p.VersionedHash[:]...)
+	data = append(data, z[:]...)
+	data = append(data, y[:]...)
+	data = append(data, commitment[:]...)
+	data = append(data, proof[:]...)
+	return data
+}
+
+// DeserializePointEvaluation parses the input of the point evaluation precompile. The field elements must be
+// canonical, and the points must be on the curve and in the subgroup.
+func DeserializePointEvaluation(data []byte) (*PointEvaluation, error) {
+	if len(data) != PointEvaluationInputSize {
+		return nil, fmt.Errorf("point evaluation input must be %d bytes, got %d", PointEvaluationInputSize, len(data))
+	}
+	p := &PointEvaluation{}
+	offset := copy(p.VersionedHash[:], data)
+	if err := p.Z.SetBytesCanonical(data[offset : offset+fr.Bytes]); err != nil {
+		return nil, fmt.Errorf("invalid evaluation point: %w", err)
+	}
+	offset += fr.Bytes
+	if err := p.Y.SetBytesCanonical(data[offset : offset+fr.Bytes]); err != nil {
+		return nil, fmt.Errorf("invalid evaluation: %w", err)
+	}
+	offset += fr.Bytes
+	if _, err := p.Commitment.SetBytes(data[offset : offset+bn254.SizeOfG1AffineCompressed]); err != nil {
+		return nil, fmt.Errorf("invalid commitment: %w", err)
+	}
+	offset += bn254.SizeOfG1AffineCompressed
+	if _, err := p.Proof.SetBytes(data[offset:]); err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+	return p, nil
+}
+
+// Prover computes the point evaluations of blobs. It needs as many G1 points of the SRS as the length, in
+// symbols, of the largest blob.
+type Prover struct {
+	g1 []bn254.G1Affine
+}
+
+func NewProver(g1 []bn254.G1Affine) *Prover {
+	return &Prover{g1: g1}
+}
+
+// ComputePointEvaluation commits to a blob, and evaluates it at its challenge. Every 32 bytes of the data must be
+// a valid field element.
+func (p *Prover) ComputePointEvaluation(data []byte) (*PointEvaluation, error) {
+	coeffs, err := rs.ToFrArray(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(coeffs) > len(p.g1) {
+		return nil, fmt.Errorf("poly Coeff length %v is greater than Loaded SRS points %v", len(coeffs), len(p.g1))
+	}
+
+	commitment, err := msm.MultiExpG1(p.g1, coeffs)
+	if err != nil {
+		return nil, err
+	}
+	z, err := ComputeChallenge(coeffs, &commitment)
+	if err != nil {
+		return nil, err
+	}
+	y, proof, err := p.ComputeProof(coeffs, z)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PointEvaluation{
+		VersionedHash: VersionedHash(&commitment),
+		Z:             z,
+		Y:             y,
+		Commitment:    commitment,
+		Proof:         *proof,
+	}, nil
+}
+
+// ComputeProof evaluates the polynomial at z, and returns the evaluation along with its proof: the commitment of
+// the quotient (p(X) - p(z)) / (X - z).
+func (p *Prover) ComputeProof(coeffs []fr.Element, z fr.Element) (fr.Element, *bn254.G1Affine, error) {
+	if len(coeffs) > len(p.g1) {
+		return fr.Element{}, nil, fmt.Errorf("poly Coeff length %v is greater than Loaded SRS points %v", len(coeffs), len(p.g1))
+	}
+
+	quotient, y := poly.DivideByLinear(coeffs, z)
+	proof, err := msm.MultiExpG1(p.g1, quotient)
+	if err != nil {
+		return fr.Element{}, nil, err
+	}
+	return y, &proof, nil
+}
+
+// VerifyPointEvaluation verifies the point evaluation as the precompile does: that the versioned hash is the hash
+// of the commitment, and that the committed polynomial evaluates to Y at Z. g2Tau is [tau]_2, the second point of
+// the G2 SRS.
+func VerifyPointEvaluation(g2Tau *bn254.G2Affine, p *PointEvaluation) error {
+	if VersionedHash(&p.Commitment) != p.VersionedHash {
+		return ErrVersionedHashMismatch
+	}
+
+	// e(C - [y]_1, [1]_2) = e(proof, [tau - z]_2)
+	var yG1, commitmentMinusY bn254.G1Affine
+	yG1.ScalarMultiplication(&kzg.GenG1, p.Y.BigInt(new(big.Int)))
+	commitmentMinusY.Sub(&p.Commitment, &yG1)
+
+	var zG2, tauMinusZ bn254.G2Affine
+	zG2.ScalarMultiplication(&kzg.GenG2, p.Z.BigInt(new(big.Int)))
+	tauMinusZ.Sub(g2Tau, &zG2)
+
+	var negProof bn254.G1Affine
+	negProof.Neg(&p.Proof)
+
+	ok, err := bn254.PairingCheck(
+		[]bn254.G1Affine{commitmentMinusY, negProof},
+		[]bn254.G2Affine{kzg.GenG2, tauMinusZ},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+// VerifyBlobProof verifies that the commitment and the proof are those of the blob: it recomputes the challenge
+// and the evaluation of the blob, and verifies the proof of the evaluation against the commitment.
+func VerifyBlobProof(g2Tau *bn254.G2Affine, data []byte, commitment, proof *bn254.G1Affine) error {
+	coeffs, err := rs.ToFrArray(data)
+	if err != nil {
+		return err
+	}
+	z, err := ComputeChallenge(coeffs, commitment)
+	if err != nil {
+		return err
+	}
+
+	var y fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y.Mul(&y, &z)
+		y.Add(&y, &coeffs[i])
+	}
+
+	return VerifyPointEvaluation(g2Tau, &PointEvaluation{
+		VersionedHash: VersionedHash(commitment),
+		Z:             z,
+		Y:             y,
+		Commitment:    *commitment,
+		Proof:         *proof,
+	})
+}
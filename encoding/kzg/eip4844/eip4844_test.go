@@ -0,0 +1,86 @@
+package eip4844_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/eip4844"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	data = codec.ConvertByPaddingEmptyByte([]byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal."))
+
+	kzgConfig = &kzg.KzgConfig{
+		G1Path:          "../../../inabox/resources/kzg/g1.point",
+		G2Path:          "../../../inabox/resources/kzg/g2.point",
+		CacheDir:        "../../../inabox/resources/kzg/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 2900,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+	}
+)
+
+func setup(t *testing.T) (*eip4844.Prover, *bn254.G2Affine) {
+	g1, err := kzg.ReadG1Points(kzgConfig.G1Path, 64, kzgConfig.NumWorker)
+	require.NoError(t, err)
+	g2Tau, err := kzg.ReadG2Point(1, kzgConfig)
+	require.NoError(t, err)
+	return eip4844.NewProver(g1), &g2Tau
+}
+
+func TestPointEvaluation(t *testing.T) {
+	p, g2Tau := setup(t)
+
+	evaluation, err := p.ComputePointEvaluation(data)
+	require.NoError(t, err)
+	assert.Equal(t, eip4844.VersionedHashVersionKzg, evaluation.VersionedHash[0])
+	assert.NoError(t, eip4844.VerifyPointEvaluation(g2Tau, evaluation))
+	assert.NoError(t, eip4844.VerifyBlobProof(g2Tau, data, &evaluation.Commitment, &evaluation.Proof))
+
+	// The commitment is the one of the disperser
+	group, err := prover.NewProver(kzgConfig, true)
+	require.NoError(t, err)
+	commitments, _, err := group.EncodeAndProve(data, encoding.ParamsFromMins(16, 4))
+	require.NoError(t, err)
+	assert.True(t, evaluation.Commitment.Equal((*bn254.G1Affine)(commitments.Commitment)))
+
+	serialized := evaluation.Serialize()
+	assert.Len(t, serialized, eip4844.PointEvaluationInputSize)
+	deserialized, err := eip4844.DeserializePointEvaluation(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, evaluation, deserialized)
+}
+
+func TestPointEvaluationInvalid(t *testing.T) {
+	p, g2Tau := setup(t)
+
+	evaluation, err := p.ComputePointEvaluation(data)
+	require.NoError(t, err)
+
+	wrongEvaluation := *evaluation
+	wrongEvaluation.Y.SetOne()
+	assert.ErrorIs(t, eip4844.VerifyPointEvaluation(g2Tau, &wrongEvaluation), eip4844.ErrInvalidProof)
+
+	wrongHash := *evaluation
+	wrongHash.VersionedHash[1] ^= 1
+	assert.ErrorIs(t, eip4844.VerifyPointEvaluation(g2Tau, &wrongHash), eip4844.ErrVersionedHashMismatch)
+
+	other := append([]byte{}, data...)
+	other[1] ^= 1
+	assert.ErrorIs(t, eip4844.VerifyBlobProof(g2Tau, other, &evaluation.Commitment, &evaluation.Proof), eip4844.ErrInvalidProof)
+
+	// The evaluation point must be a canonical field element
+	serialized := evaluation.Serialize()
+	for i := 32; i < 64; i++ {
+		serialized[i] = 0xff
+	}
+	_, err = eip4844.DeserializePointEvaluation(serialized)
+	assert.ErrorContains(t, err, "invalid evaluation point")
+}
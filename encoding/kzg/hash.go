@@ -0,0 +1,52 @@
+package kzg
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// Domain separation tags of the protocol randomness derived with HashToFr, HashToFrs and HashToG1.
+// Every use of the helpers must have its own tag, so that a value derived for one purpose can't be
+// replayed for another.
+var (
+	// BatchVerificationDST separates the challenges of batched frame and commitment verification.
+	BatchVerificationDST = []byte("EIGENDA_BN254_BATCH_VERIFICATION_XMD:SHA-256_")
+	// PointEvaluationDST separates the evaluation points of point-evaluation proofs.
+	PointEvaluationDST = []byte("EIGENDA_BN254_POINT_EVALUATION_XMD:SHA-256_")
+)
+
+// HashToFr hashes msg to a uniformly distributed element of the scalar field of BN254, following the
+// hash_to_field construction of RFC 9380 with expand_message_xmd and SHA-256. dst is the domain separation tag.
+//
+// It must be used to derive Fiat-Shamir challenges instead of reducing a hash modulo r, which is biased.
+func HashToFr(msg, dst []byte) (fr.Element, error) {
+	elements, err := HashToFrs(msg, dst, 1)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return elements[0], nil
+}
+
+// HashToFrs is HashToFr returning count independent elements, e.g. the challenges of a batch.
+func HashToFrs(msg, dst []byte, count int) ([]fr.Element, error) {
+	if len(dst) == 0 {
+		return nil, errors.New("the domain separation tag must not be empty")
+	}
+	if count <= 0 {
+		return nil, errors.New("the number of elements must be positive")
+	}
+	return fr.Hash(msg, dst, count)
+}
+
+// HashToG1 hashes msg to a point of G1 whose discrete logarithm is unknown, following the hash_to_curve
+// construction of RFC 9380 for BN254 (the BN254G1_XMD:SHA-256_SVDW_RO_ suite). dst is the domain separation tag.
+//
+// This is not the hashing of the EigenDA contracts, see core/bn254.MapToCurve for the hashing of attestations.
+func HashToG1(msg, dst []byte) (bn254.G1Affine, error) {
+	if len(dst) == 0 {
+		return bn254.G1Affine{}, errors.New("the domain separation tag must not be empty")
+	}
+	return bn254.HashToG1(msg, dst)
+}
@@ -0,0 +1,81 @@
+package kzg_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashToFr(t *testing.T) {
+	msg := []byte("commitments")
+
+	r, err := kzg.HashToFr(msg, kzg.BatchVerificationDST)
+	require.NoError(t, err)
+	again, err := kzg.HashToFr(msg, kzg.BatchVerificationDST)
+	require.NoError(t, err)
+	assert.Equal(t, r, again)
+	assert.False(t, r.IsZero())
+
+	// the domain separation tag and the message both change the output
+	other, err := kzg.HashToFr(msg, kzg.PointEvaluationDST)
+	require.NoError(t, err)
+	assert.NotEqual(t, r, other)
+	other, err = kzg.HashToFr([]byte("commitment"), kzg.BatchVerificationDST)
+	require.NoError(t, err)
+	assert.NotEqual(t, r, other)
+
+	_, err = kzg.HashToFr(msg, nil)
+	assert.Error(t, err)
+}
+
+func TestHashToFrs(t *testing.T) {
+	msg := []byte("commitments")
+
+	challenges, err := kzg.HashToFrs(msg, kzg.BatchVerificationDST, 4)
+	require.NoError(t, err)
+	require.Len(t, challenges, 4)
+	for i := range challenges {
+		for j := i + 1; j < len(challenges); j++ {
+			assert.NotEqual(t, challenges[i], challenges[j])
+		}
+	}
+
+	_, err = kzg.HashToFrs(msg, kzg.BatchVerificationDST, 0)
+	assert.Error(t, err)
+}
+
+func TestHashToG1(t *testing.T) {
+	msg := []byte("commitments")
+
+	point, err := kzg.HashToG1(msg, kzg.PointEvaluationDST)
+	require.NoError(t, err)
+	assert.True(t, point.IsOnCurve())
+	assert.True(t, point.IsInSubGroup())
+	assert.False(t, point.IsInfinity())
+
+	again, err := kzg.HashToG1(msg, kzg.PointEvaluationDST)
+	require.NoError(t, err)
+	assert.Equal(t, point, again)
+
+	other, err := kzg.HashToG1(msg, kzg.BatchVerificationDST)
+	require.NoError(t, err)
+	assert.NotEqual(t, point, other)
+
+	_, err = kzg.HashToG1(msg, nil)
+	assert.Error(t, err)
+}
+
+func TestHashToSingleField(t *testing.T) {
+	msg := []byte("commitments")
+
+	var r fr.Element
+	err := kzg.HashToSingleField(&r, msg)
+	require.NoError(t, err)
+
+	expected, err := fr.Hash(msg, []byte("-"), 1)
+	require.NoError(t, err)
+	assert.Equal(t, expected[0], r)
+}
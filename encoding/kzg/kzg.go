@@ -29,7 +29,7 @@ package kzg
 
 import (
 	"github.com/Layr-Labs/eigenda/encoding/fft"
-	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
@@ -53,15 +53,18 @@ func NewKZGSettings(fs *fft.FFTSettings, srs *SRS) (*KZGSettings, error) {
 
 // KZG commitment to polynomial in coefficient form
 func (ks *KZGSettings) CommitToPoly(coeffs []fr.Element) (*bn254.G1Affine, error) {
-	var commit bn254.G1Affine
-	_, err := commit.MultiExp(ks.Srs.G1[:len(coeffs)], coeffs, ecc.MultiExpConfig{})
+	commit, err := msm.MultiExpG1(ks.Srs.G1, coeffs)
 	return &commit, err
 }
 
+// HashToSingleField hashes msg to an element of the scalar field with the domain separation tag "-".
+//
+// Deprecated: use HashToFr with the domain separation tag of the protocol.
 func HashToSingleField(dst *fr.Element, msg []byte) error {
-	DST := []byte("-")
-	randomFr, err := fr.Hash(msg, DST, 1)
-	randomFrBytes := (randomFr[0]).Bytes()
-	dst.SetBytes(randomFrBytes[:])
-	return err
+	randomFr, err := HashToFr(msg, []byte("-"))
+	if err != nil {
+		return err
+	}
+	dst.Set(&randomFr)
+	return nil
 }
@@ -1,5 +1,19 @@
 package kzg
 
+import "time"
+
+// The algorithms computing the proofs of the chunks of a blob
+const (
+	// FK20ProofAlgorithm computes the proofs of all the chunks at once with the FK20 algorithm
+	FK20ProofAlgorithm = "fk20"
+	// NaiveProofAlgorithm computes the proof of each chunk separately, as the commitment to the quotient of the
+	// blob polynomial by the vanishing polynomial of the chunk's coset
+	NaiveProofAlgorithm = "naive"
+	// AutoProofAlgorithm picks the fastest of the two algorithms for each blob, from the blob size and the
+	// encoding parameters. The algorithms are benchmarked once for each set of encoding parameters
+	AutoProofAlgorithm = "auto"
+)
+
 type KzgConfig struct {
 	G1Path          string
 	G2Path          string
@@ -11,4 +25,35 @@ type KzgConfig struct {
 	SRSNumberToLoad uint64 // Number of points to be loaded from the beginning
 	Verbose         bool
 	PreloadEncoder  bool
+	// DisableScratchPool makes the provers and verifiers allocate their temporary buffers
+	// instead of reusing them across calls
+	DisableScratchPool bool
+	// MSMTableDir is the directory where the precomputed multi-scalar multiplication tables of the G1 SRS
+	// are persisted. If empty, the provers compute commitments without precomputed tables
+	MSMTableDir string
+	// MSMTableWindow is the window size, in bits, of the precomputed multi-scalar multiplication tables
+	MSMTableWindow uint64
+	// CommitmentCacheSize is the number of blobs whose commitments are cached by the prover, so that
+	// encoding the same blob again skips computing them. If zero, commitments aren't cached
+	CommitmentCacheSize uint64
+	// CommitmentCacheTTL is how long the commitments of a blob stay cached
+	CommitmentCacheTTL time.Duration
+	// ProofAlgorithm is the algorithm computing the proofs of the chunks, one of FK20ProofAlgorithm,
+	// NaiveProofAlgorithm and AutoProofAlgorithm. If empty, the proofs are computed with FK20
+	ProofAlgorithm string
+	// MSMCalibrationDir is the directory where the calibration of the multi-scalar multiplication algorithms by
+	// input size is persisted. If empty, the default calibration is used
+	MSMCalibrationDir string
+	// SharedSRSDir is the directory where the loaded SRS points are persisted in their in-memory layout, and memory
+	// mapped from, so that the processes of a host loading the same points share them. If empty, each process
+	// decompresses its own copy of the points
+	SharedSRSDir string
+	// G1Source, G2Source and G2PowerOf2Source are where the SRS files are downloaded from if they aren't at
+	// G1Path, G2Path and G2PowerOf2Path. If a source URL is empty, the file must already be at its path
+	G1Source         SRSSource
+	G2Source         SRSSource
+	G2PowerOf2Source SRSSource
+	// ChunkForm is the form of the chunks output by the prover: "coeff" for the coefficients of their interpolating
+	// polynomials, "eval" for their evaluations, or "both". If empty, the chunks carry the coefficients
+	ChunkForm string
 }
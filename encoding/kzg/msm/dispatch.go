@@ -0,0 +1,233 @@
+package msm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"os"
+	"path"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+const (
+	// CalibrationFileName is the name of the file the calibration of the machine is persisted to
+	CalibrationFileName = "msm-calibration.json"
+	// DefaultMaxCalibrationSize is the size of the largest multi-scalar multiplications benchmarked by Calibrate
+	DefaultMaxCalibrationSize = 1 << 12
+
+	// number of times each algorithm is benchmarked for each size, keeping the fastest run
+	numCalibrationRuns = 3
+	// calibrationVersion is bumped when the algorithms change, so that the persisted calibrations are discarded
+	calibrationVersion = 1
+)
+
+// Calibration holds the sizes up to which each algorithm is the fastest for the multi-scalar multiplications of
+// MultiExpG1 on this machine. Small multiplications, e.g. of the coefficients of a frame, are faster with a double
+// and add per scalar, which has no setup cost. Larger ones use the Pippenger algorithm of gnark-crypto, which picks
+// its window size from the number of scalars, on a single goroutine until the input is large enough for the
+// parallel version to pay off.
+type Calibration struct {
+	// DoubleAndAddMaxSize is the largest number of scalars multiplied with a double and add per scalar
+	DoubleAndAddMaxSize int `json:"double_and_add_max_size"`
+	// SingleTaskMaxSize is the largest number of scalars multiplied with Pippenger on a single goroutine
+	SingleTaskMaxSize int `json:"single_task_max_size"`
+}
+
+// DefaultCalibration is the calibration used until another one is set with SetCalibration
+var DefaultCalibration = Calibration{
+	DoubleAndAddMaxSize: 2,
+	SingleTaskMaxSize:   64,
+}
+
+// The calibration is a property of the machine rather than of a prover or a verifier, so it's shared by all of them
+var calibration atomic.Pointer[Calibration]
+
+func init() {
+	SetCalibration(DefaultCalibration)
+}
+
+// SetCalibration sets the calibration used by MultiExpG1
+func SetCalibration(c Calibration) {
+	calibration.Store(&c)
+}
+
+// CurrentCalibration returns the calibration used by MultiExpG1
+func CurrentCalibration() Calibration {
+	return *calibration.Load()
+}
+
+// MultiExpG1 computes the sum of scalars[i] * bases[i] with the fastest algorithm for the number of scalars,
+// according to the current calibration. There must not be more scalars than bases.
+func MultiExpG1(bases []bn254.G1Affine, scalars []fr.Element) (bn254.G1Affine, error) {
+	if len(scalars) > len(bases) {
+		return bn254.G1Affine{}, fmt.Errorf("number of scalars %v exceeds the number of bases %v", len(scalars), len(bases))
+	}
+	return calibration.Load().multiExp(bases[:len(scalars)], scalars)
+}
+
+func (c *Calibration) multiExp(bases []bn254.G1Affine, scalars []fr.Element) (bn254.G1Affine, error) {
+	var res bn254.G1Affine
+	switch n := len(scalars); {
+	case n == 0:
+		return res, nil
+	case n <= c.DoubleAndAddMaxSize:
+		return doubleAndAdd(bases, scalars), nil
+	case n <= c.SingleTaskMaxSize:
+		_, err := res.MultiExp(bases, scalars, ecc.MultiExpConfig{NbTasks: 1})
+		return res, err
+	default:
+		_, err := res.MultiExp(bases, scalars, ecc.MultiExpConfig{})
+		return res, err
+	}
+}
+
+// doubleAndAdd multiplies each base by its scalar separately and sums the products
+func doubleAndAdd(bases []bn254.G1Affine, scalars []fr.Element) bn254.G1Affine {
+	var sum, product bn254.G1Jac
+	var s big.Int
+	for i := range scalars {
+		product.ScalarMultiplicationAffine(&bases[i], scalars[i].BigInt(&s))
+		sum.AddAssign(&product)
+	}
+	var res bn254.G1Affine
+	res.FromJacobian(&sum)
+	return res
+}
+
+// Calibrate benchmarks the algorithms on random inputs of sizes the powers of 2 up to maxSize, and returns the
+// sizes up to which each of them is the fastest. It takes about a second for the default maximum size.
+func Calibrate(maxSize int) (Calibration, error) {
+	if maxSize < 1 {
+		return Calibration{}, errors.New("the maximum calibration size must be positive")
+	}
+
+	scalars := make([]fr.Element, maxSize)
+	for i := range scalars {
+		if _, err := scalars[i].SetRandom(); err != nil {
+			return Calibration{}, err
+		}
+	}
+	_, _, g1Gen, _ := bn254.Generators()
+	bases := bn254.BatchScalarMultiplicationG1(&g1Gen, scalars)
+
+	// The inputs are benchmarked in increasing sizes, and an algorithm isn't benchmarked anymore once a faster one
+	// overtakes it, as the costs of the faster ones grow more slowly
+	result := Calibration{}
+	singleTask := &Calibration{DoubleAndAddMaxSize: 0, SingleTaskMaxSize: math.MaxInt}
+	parallel := &Calibration{}
+	doubleAndAddFastest, singleTaskFastest := true, true
+	for n := 1; n <= maxSize && (doubleAndAddFastest || singleTaskFastest); n *= 2 {
+		parallelDuration, err := benchmark(parallel, bases[:n], scalars[:n])
+		if err != nil {
+			return Calibration{}, err
+		}
+		singleTaskDuration, err := benchmark(singleTask, bases[:n], scalars[:n])
+		if err != nil {
+			return Calibration{}, err
+		}
+
+		if doubleAndAddFastest {
+			doubleAndAddDuration := benchmarkDoubleAndAdd(bases[:n], scalars[:n])
+			if doubleAndAddDuration <= min(singleTaskDuration, parallelDuration) {
+				result.DoubleAndAddMaxSize = n
+			} else {
+				doubleAndAddFastest = false
+			}
+		}
+		if singleTaskFastest {
+			if singleTaskDuration <= parallelDuration {
+				result.SingleTaskMaxSize = n
+			} else {
+				singleTaskFastest = false
+			}
+		}
+	}
+	result.SingleTaskMaxSize = max(result.SingleTaskMaxSize, result.DoubleAndAddMaxSize)
+	return result, nil
+}
+
+func benchmark(c *Calibration, bases []bn254.G1Affine, scalars []fr.Element) (time.Duration, error) {
+	fastest := time.Duration(math.MaxInt64)
+	for i := 0; i < numCalibrationRuns; i++ {
+		start := time.Now()
+		if _, err := c.multiExp(bases, scalars); err != nil {
+			return 0, err
+		}
+		fastest = min(fastest, time.Since(start))
+	}
+	return fastest, nil
+}
+
+func benchmarkDoubleAndAdd(bases []bn254.G1Affine, scalars []fr.Element) time.Duration {
+	fastest := time.Duration(math.MaxInt64)
+	for i := 0; i < numCalibrationRuns; i++ {
+		start := time.Now()
+		doubleAndAdd(bases, scalars)
+		fastest = min(fastest, time.Since(start))
+	}
+	return fastest
+}
+
+// calibrationFile is the persisted calibration, along with the machine it was measured on
+type calibrationFile struct {
+	Version     int         `json:"version"`
+	GOARCH      string      `json:"goarch"`
+	NumCPU      int         `json:"num_cpu"`
+	Calibration Calibration `json:"calibration"`
+}
+
+// LoadOrCalibrate returns the calibration persisted in dir. If there is none yet, or it was measured on a machine
+// with a different architecture or number of CPUs, the algorithms are calibrated and the result is written to dir.
+func LoadOrCalibrate(dir string) (Calibration, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return Calibration{}, fmt.Errorf("failed to create msm calibration directory: %w", err)
+	}
+	filePath := path.Join(dir, CalibrationFileName)
+	machine := calibrationFile{
+		Version: calibrationVersion,
+		GOARCH:  runtime.GOARCH,
+		NumCPU:  runtime.NumCPU(),
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err == nil {
+		var persisted calibrationFile
+		if err := json.Unmarshal(data, &persisted); err != nil {
+			log.Printf("Discarding msm calibration %v: %v\n", filePath, err)
+		} else if persisted.Version == machine.Version && persisted.GOARCH == machine.GOARCH && persisted.NumCPU == machine.NumCPU {
+			log.Printf("Loaded msm calibration %v: %+v\n", filePath, persisted.Calibration)
+			return persisted.Calibration, nil
+		} else {
+			log.Printf("Discarding msm calibration %v measured on another machine\n", filePath)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Discarding msm calibration %v: %v\n", filePath, err)
+	}
+
+	start := time.Now()
+	c, err := Calibrate(DefaultMaxCalibrationSize)
+	if err != nil {
+		return Calibration{}, err
+	}
+	log.Printf("Calibrated msm algorithms in %v: %+v\n", time.Since(start), c)
+
+	// The calibration is still usable if it can't be persisted, it will be measured again on the next startup
+	machine.Calibration = c
+	data, err = json.Marshal(machine)
+	if err == nil {
+		err = os.WriteFile(filePath, data, 0644)
+	}
+	if err != nil {
+		log.Printf("Failed to write msm calibration %v: %v\n", filePath, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,67 @@
+package msm_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiExpG1Dispatch(t *testing.T) {
+	defer msm.SetCalibration(msm.DefaultCalibration)
+
+	bases := randomBases(t, 64)
+	scalars := randomScalars(t, 64)
+
+	// Every size is computed by each of the algorithms with one of the calibrations
+	calibrations := []msm.Calibration{
+		msm.DefaultCalibration,
+		{DoubleAndAddMaxSize: 64, SingleTaskMaxSize: 64},
+		{DoubleAndAddMaxSize: 0, SingleTaskMaxSize: 64},
+		{DoubleAndAddMaxSize: 0, SingleTaskMaxSize: 0},
+	}
+	for _, c := range calibrations {
+		msm.SetCalibration(c)
+		assert.Equal(t, c, msm.CurrentCalibration())
+		for _, n := range []int{0, 1, 2, 5, 17, 64} {
+			res, err := msm.MultiExpG1(bases, scalars[:n])
+			require.NoError(t, err)
+			expected := expectedMultiExp(t, bases, scalars[:n])
+			assert.True(t, expected.Equal(&res), "calibration %+v, %v scalars", c, n)
+		}
+	}
+
+	_, err := msm.MultiExpG1(bases[:2], scalars[:3])
+	assert.Error(t, err)
+}
+
+func TestLoadOrCalibrate(t *testing.T) {
+	dir := t.TempDir()
+
+	c, err := msm.LoadOrCalibrate(dir)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, c.DoubleAndAddMaxSize, c.SingleTaskMaxSize)
+	assert.LessOrEqual(t, c.SingleTaskMaxSize, msm.DefaultMaxCalibrationSize)
+
+	// The persisted calibration is loaded rather than measured again
+	filePath := path.Join(dir, msm.CalibrationFileName)
+	_, err = os.Stat(filePath)
+	require.NoError(t, err)
+	loaded, err := msm.LoadOrCalibrate(dir)
+	require.NoError(t, err)
+	assert.Equal(t, c, loaded)
+
+	// A corrupted calibration is measured again and overwritten
+	require.NoError(t, os.WriteFile(filePath, []byte("{"), 0644))
+	_, err = msm.LoadOrCalibrate(dir)
+	require.NoError(t, err)
+	rewritten, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.NotEqual(t, "{", string(rewritten))
+
+	_, err = msm.Calibrate(0)
+	assert.Error(t, err)
+}
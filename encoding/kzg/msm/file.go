@@ -0,0 +1,209 @@
+package msm
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"time"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// A table file starts with a header of headerSize bytes:
+//
+//	magic (8 bytes) | byte order mark (8 bytes) | window (8 bytes) | number of bases (8 bytes) | fingerprint (32 bytes) | checksum (32 bytes)
+//
+// followed by the in-memory representation of the points of the table, so that the file can be memory mapped.
+// The byte order mark rejects files written on a host with a different byte order, and the checksum, the sha256
+// of the points, rejects files whose content was corrupted on disk.
+const (
+	fileMagic     = "EDAMSM02"
+	byteOrderMark = uint64(0x0102030405060708)
+	headerSize    = 96
+	pointSize     = int(unsafe.Sizeof(bn254.G1Affine{}))
+)
+
+// ErrInvalidTable is returned when a table file is corrupted, or was computed for different bases or parameters.
+var ErrInvalidTable = errors.New("invalid msm table")
+
+// Fingerprint identifies a set of bases. A table file is only used for the bases it was computed from.
+func Fingerprint(bases []bn254.G1Affine) [32]byte {
+	h := sha256.New()
+	for i := range bases {
+		b := bases[i].RawBytes()
+		h.Write(b[:])
+	}
+	var fingerprint [32]byte
+	copy(fingerprint[:], h.Sum(nil))
+	return fingerprint
+}
+
+// TableFileName returns the name of the file holding the table of numBases bases with the given window.
+func TableFileName(numBases int, window uint) string {
+	return fmt.Sprintf("g1.n%v.w%v.msm", numBases, window)
+}
+
+// LoadOrPrecompute returns the table of the given bases persisted in dir. If there is no table file for the
+// bases yet, the file was computed from different bases (e.g. after the SRS changed) or its content is corrupted,
+// the table is computed and written to dir. A zero window selects DefaultWindow.
+func LoadOrPrecompute(dir string, bases []bn254.G1Affine, window uint, numWorker int) (*Table, error) {
+	window, err := validateWindow(window)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create msm table directory: %w", err)
+	}
+
+	start := time.Now()
+	filePath := path.Join(dir, TableFileName(len(bases), window))
+	fingerprint := Fingerprint(bases)
+
+	table, err := Load(filePath, fingerprint, len(bases), window, numWorker)
+	if err == nil {
+		log.Printf("Loaded precomputed msm table %v in %v\n", filePath, time.Since(start))
+		return table, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Discarding precomputed msm table %v: %v\n", filePath, err)
+	}
+
+	log.Printf("Precomputing msm table for %v bases with a %v-bit window. May take a while\n", len(bases), window)
+	table, err = Precompute(bases, window, numWorker)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("    Precomputing msm table took %v\n", time.Since(start))
+
+	// The table is still usable if it can't be persisted, it will be computed again on the next startup
+	if err := table.WriteFile(filePath, fingerprint); err != nil {
+		log.Printf("Failed to write msm table %v: %v\n", filePath, err)
+	}
+
+	return table, nil
+}
+
+// Load memory maps the table persisted at filePath. It returns ErrInvalidTable if the file doesn't hold the
+// table of numBases bases with the given fingerprint and window, or if its points don't match its checksum.
+func Load(filePath string, fingerprint [32]byte, numBases int, window uint, numWorker int) (*Table, error) {
+	window, err := validateWindow(window)
+	if err != nil {
+		return nil, err
+	}
+	if numWorker < 1 {
+		numWorker = 1
+	}
+	numWindows := NumWindows(window)
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var header [headerSize]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidTable, err)
+	}
+	if string(header[:8]) != fileMagic {
+		return nil, fmt.Errorf("%w: unknown file format", ErrInvalidTable)
+	}
+	if binary.NativeEndian.Uint64(header[8:16]) != byteOrderMark {
+		return nil, fmt.Errorf("%w: written with a different byte order", ErrInvalidTable)
+	}
+	if w := binary.NativeEndian.Uint64(header[16:24]); w != uint64(window) {
+		return nil, fmt.Errorf("%w: window %v, expected %v", ErrInvalidTable, w, window)
+	}
+	if n := binary.NativeEndian.Uint64(header[24:32]); n != uint64(numBases) {
+		return nil, fmt.Errorf("%w: %v bases, expected %v", ErrInvalidTable, n, numBases)
+	}
+	if [32]byte(header[32:64]) != fingerprint {
+		return nil, fmt.Errorf("%w: computed from different bases", ErrInvalidTable)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := int64(headerSize + numBases*numWindows*pointSize)
+	if info.Size() != size {
+		return nil, fmt.Errorf("%w: file size %v, expected %v", ErrInvalidTable, info.Size(), size)
+	}
+
+	mapping, err := mapFile(f, int(size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to map msm table: %w", err)
+	}
+	if sha256.Sum256(mapping[headerSize:]) != [32]byte(header[64:96]) {
+		_ = unmapFile(mapping)
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidTable)
+	}
+
+	var points []bn254.G1Affine
+	if numBases > 0 {
+		points = unsafe.Slice((*bn254.G1Affine)(unsafe.Pointer(&mapping[headerSize])), numBases*numWindows)
+	}
+
+	return &Table{
+		window:     window,
+		numWindows: numWindows,
+		numBases:   numBases,
+		numWorker:  numWorker,
+		points:     points,
+		mapping:    mapping,
+	}, nil
+}
+
+// WriteFile persists the table to filePath. The file is replaced atomically, so that a concurrent or
+// interrupted write never leaves a partial table behind.
+func (t *Table) WriteFile(filePath string, fingerprint [32]byte) error {
+	var header [headerSize]byte
+	copy(header[:8], fileMagic)
+	binary.NativeEndian.PutUint64(header[8:16], byteOrderMark)
+	binary.NativeEndian.PutUint64(header[16:24], uint64(t.window))
+	binary.NativeEndian.PutUint64(header[24:32], uint64(t.numBases))
+	copy(header[32:64], fingerprint[:])
+	var data []byte
+	if len(t.points) > 0 {
+		data = unsafe.Slice((*byte)(unsafe.Pointer(&t.points[0])), len(t.points)*pointSize)
+	}
+	checksum := sha256.Sum256(data)
+	copy(header[64:96], checksum[:])
+
+	f, err := os.CreateTemp(path.Dir(filePath), path.Base(filePath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(header[:]); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), filePath)
+}
+
+// Close releases the memory mapping backing the table, if any. The table must not be used afterwards.
+func (t *Table) Close() error {
+	if t.mapping == nil {
+		return nil
+	}
+	mapping := t.mapping
+	t.mapping = nil
+	t.points = nil
+	return unmapFile(mapping)
+}
@@ -0,0 +1,228 @@
+// Package msm implements multi-scalar multiplications over a fixed set of G1 bases, such as the SRS,
+// using precomputed tables.
+//
+// For a window of c bits, the table holds the multiples 2^(c*j) * B_i of every base B_i for each of the
+// ceil(254 / c) windows j of a scalar. A multi-scalar multiplication then reduces to a single bucket
+// accumulation over the windows of all the scalars, which saves the per-window bucket reductions and the
+// doublings of the Pippenger algorithm. Since computing the table is expensive, it can be persisted to disk
+// and memory mapped on startup with LoadOrPrecompute.
+package msm
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+const (
+	// DefaultWindow is the window size, in bits, used when none is specified
+	DefaultWindow = 16
+	// MinWindow is the smallest supported window size, in bits
+	MinWindow = 2
+	// MaxWindow is the largest supported window size, in bits
+	MaxWindow = 16
+
+	// number of bases whose multiples are converted to affine coordinates at once during precomputation
+	precomputeBatchSize = 64
+)
+
+// Table is a precomputed table of multiples of a fixed set of G1 bases.
+// A Table is safe for concurrent use.
+type Table struct {
+	window     uint
+	numWindows int
+	numBases   int
+	numWorker  int
+
+	// points[i*numWindows+j] is 2^(window*j) * bases[i]
+	points []bn254.G1Affine
+	// mapping is the memory mapped file backing points, if any
+	mapping []byte
+}
+
+// NumWindows returns the number of windows of the given size needed to cover a scalar.
+func NumWindows(window uint) int {
+	return int((fr.Bits + window - 1) / window)
+}
+
+// Precompute computes the table of the given bases. A zero window selects DefaultWindow.
+func Precompute(bases []bn254.G1Affine, window uint, numWorker int) (*Table, error) {
+	window, err := validateWindow(window)
+	if err != nil {
+		return nil, err
+	}
+	if numWorker < 1 {
+		numWorker = 1
+	}
+
+	numWindows := NumWindows(window)
+	points := make([]bn254.G1Affine, len(bases)*numWindows)
+
+	jobChan := make(chan int, numWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < numWorker; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			multiples := make([]bn254.G1Jac, precomputeBatchSize*numWindows)
+			for start := range jobChan {
+				end := min(start+precomputeBatchSize, len(bases))
+				batch := multiples[:(end-start)*numWindows]
+				for i := start; i < end; i++ {
+					row := batch[(i-start)*numWindows : (i-start+1)*numWindows]
+					row[0].FromAffine(&bases[i])
+					for j := 1; j < numWindows; j++ {
+						row[j].Set(&row[j-1])
+						for k := uint(0); k < window; k++ {
+							row[j].DoubleAssign()
+						}
+					}
+				}
+				copy(points[start*numWindows:end*numWindows], bn254.BatchJacobianToAffineG1(batch))
+			}
+		}()
+	}
+
+	for start := 0; start < len(bases); start += precomputeBatchSize {
+		jobChan <- start
+	}
+	close(jobChan)
+	wg.Wait()
+
+	return &Table{
+		window:     window,
+		numWindows: numWindows,
+		numBases:   len(bases),
+		numWorker:  numWorker,
+		points:     points,
+	}, nil
+}
+
+// Window returns the window size of the table, in bits.
+func (t *Table) Window() uint {
+	return t.window
+}
+
+// NumBases returns the number of bases covered by the table.
+func (t *Table) NumBases() int {
+	return t.numBases
+}
+
+// MultiExp computes the sum of scalars[i] * bases[i]. There must not be more scalars than bases.
+func (t *Table) MultiExp(scalars []fr.Element) (bn254.G1Affine, error) {
+	var res bn254.G1Affine
+	n := len(scalars)
+	if n > t.numBases {
+		return res, fmt.Errorf("number of scalars %v exceeds the %v bases of the table", n, t.numBases)
+	}
+	if n == 0 {
+		return res, nil
+	}
+
+	// With few scalars, reducing all the buckets of the window costs more than the precomputation saves
+	if n*t.numWindows < 1<<t.window {
+		bases := make([]bn254.G1Affine, n)
+		for i := range bases {
+			bases[i] = t.points[i*t.numWindows]
+		}
+		return MultiExpG1(bases, scalars)
+	}
+
+	digits := t.digits(scalars)
+
+	// Each worker accumulates and reduces a range of the buckets over all the digits
+	numBuckets := 1<<t.window - 1
+	numWorker := min(t.numWorker, numBuckets)
+	partials := make([]bn254.G1Jac, numWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < numWorker; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			low := w * numBuckets / numWorker
+			high := (w + 1) * numBuckets / numWorker
+			partials[w] = t.reduceBuckets(digits, uint16(low), uint16(high))
+		}(w)
+	}
+	wg.Wait()
+
+	var sum bn254.G1Jac
+	for i := range partials {
+		sum.AddAssign(&partials[i])
+	}
+	res.FromJacobian(&sum)
+	return res, nil
+}
+
+// digits splits every scalar into its windows. The digit of window j of scalar i is at index i*numWindows+j,
+// matching the index of the multiple it selects in the table.
+func (t *Table) digits(scalars []fr.Element) []uint16 {
+	digits := make([]uint16, len(scalars)*t.numWindows)
+	chunkSize := (len(scalars) + t.numWorker - 1) / t.numWorker
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(scalars); start += chunkSize {
+		end := min(start+chunkSize, len(scalars))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				bits := scalars[i].Bits()
+				for j := 0; j < t.numWindows; j++ {
+					digits[i*t.numWindows+j] = digit(&bits, uint(j)*t.window, t.window)
+				}
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	return digits
+}
+
+// reduceBuckets returns the sum of d * B_d over the digits d in (low, high], where the bucket B_d is the sum
+// of the multiples selected by the digit d.
+func (t *Table) reduceBuckets(digits []uint16, low, high uint16) bn254.G1Jac {
+	buckets := make([]bn254.G1Jac, high-low)
+	for i, d := range digits {
+		if d > low && d <= high {
+			buckets[d-low-1].AddMixed(&t.points[i])
+		}
+	}
+
+	// The running sum adds each bucket (d - low) times, the remaining low times are added at the end
+	var running, total bn254.G1Jac
+	for i := len(buckets) - 1; i >= 0; i-- {
+		running.AddAssign(&buckets[i])
+		total.AddAssign(&running)
+	}
+	if low > 0 {
+		var offset bn254.G1Jac
+		offset.ScalarMultiplication(&running, big.NewInt(int64(low)))
+		total.AddAssign(&offset)
+	}
+	return total
+}
+
+// digit returns the window of the given size starting at bit offset of a scalar in regular form.
+func digit(bits *[4]uint64, offset, window uint) uint16 {
+	limb := offset / 64
+	shift := offset % 64
+	d := bits[limb] >> shift
+	if shift+window > 64 && limb+1 < uint(len(bits)) {
+		d |= bits[limb+1] << (64 - shift)
+	}
+	return uint16(d & (1<<window - 1))
+}
+
+func validateWindow(window uint) (uint, error) {
+	if window == 0 {
+		return DefaultWindow, nil
+	}
+	if window < MinWindow || window > MaxWindow {
+		return 0, fmt.Errorf("msm window %v is not in range [%v, %v]", window, MinWindow, MaxWindow)
+	}
+	return window, nil
+}
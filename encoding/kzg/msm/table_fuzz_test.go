@@ -0,0 +1,46 @@
+package msm_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzMultiExpMatchesGnark cross-checks the multi-exponentiations of the precomputed table and of MultiExpG1 against
+// the multi-exponentiation of gnark-crypto, with the scalars taken from the 32-byte chunks of the input
+func FuzzMultiExpMatchesGnark(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 3*fr.Bytes))
+	f.Add(append(fr.Modulus().FillBytes(make([]byte, fr.Bytes)), new(big.Int).Sub(fr.Modulus(), big.NewInt(1)).FillBytes(make([]byte, fr.Bytes))...))
+
+	_, _, g1Gen, _ := bn254.Generators()
+	bases := make([]bn254.G1Affine, 64)
+	for i := range bases {
+		bases[i].ScalarMultiplication(&g1Gen, big.NewInt(int64(i)+7))
+	}
+	table, err := msm.Precompute(bases, 5, 1)
+	require.NoError(f, err)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		scalars := make([]fr.Element, min(len(data)/fr.Bytes, len(bases)))
+		for i := range scalars {
+			scalars[i].SetBytes(data[i*fr.Bytes : (i+1)*fr.Bytes])
+		}
+
+		var expected bn254.G1Affine
+		_, err := expected.MultiExp(bases[:len(scalars)], scalars, ecc.MultiExpConfig{})
+		require.NoError(t, err)
+
+		res, err := table.MultiExp(scalars)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(&res))
+		res, err = msm.MultiExpG1(bases, scalars)
+		require.NoError(t, err)
+		require.True(t, expected.Equal(&res))
+	})
+}
@@ -0,0 +1,177 @@
+package msm_test
+
+import (
+	"math/big"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomBases(t *testing.T, n int) []bn254.G1Affine {
+	_, _, g1Gen, _ := bn254.Generators()
+	bases := make([]bn254.G1Affine, n)
+	for i := range bases {
+		var s fr.Element
+		_, err := s.SetRandom()
+		require.NoError(t, err)
+		bases[i].ScalarMultiplication(&g1Gen, s.BigInt(new(big.Int)))
+	}
+	return bases
+}
+
+func randomScalars(t *testing.T, n int) []fr.Element {
+	scalars := make([]fr.Element, n)
+	for i := range scalars {
+		_, err := scalars[i].SetRandom()
+		require.NoError(t, err)
+	}
+	// include the edge cases of the digit decomposition
+	if n > 3 {
+		scalars[0].SetZero()
+		scalars[1].SetOne()
+		scalars[2].SetOne()
+		scalars[2].Neg(&scalars[2])
+	}
+	return scalars
+}
+
+func expectedMultiExp(t *testing.T, bases []bn254.G1Affine, scalars []fr.Element) bn254.G1Affine {
+	var expected bn254.G1Affine
+	_, err := expected.MultiExp(bases[:len(scalars)], scalars, ecc.MultiExpConfig{})
+	require.NoError(t, err)
+	return expected
+}
+
+func TestMultiExp(t *testing.T) {
+	bases := randomBases(t, 200)
+
+	for _, window := range []uint{2, 5, 8, 13} {
+		table, err := msm.Precompute(bases, window, 4)
+		require.NoError(t, err)
+		assert.Equal(t, window, table.Window())
+		assert.Equal(t, len(bases), table.NumBases())
+
+		for _, n := range []int{0, 1, 3, 10, 64, 199, 200} {
+			scalars := randomScalars(t, n)
+			res, err := table.MultiExp(scalars)
+			require.NoError(t, err)
+			expected := expectedMultiExp(t, bases, scalars)
+			assert.True(t, expected.Equal(&res), "window %v, %v scalars", window, n)
+		}
+
+		_, err = table.MultiExp(make([]fr.Element, len(bases)+1))
+		assert.Error(t, err)
+	}
+}
+
+func TestPrecomputeInvalidWindow(t *testing.T) {
+	bases := randomBases(t, 2)
+
+	_, err := msm.Precompute(bases, 1, 1)
+	assert.Error(t, err)
+	_, err = msm.Precompute(bases, msm.MaxWindow+1, 1)
+	assert.Error(t, err)
+
+	table, err := msm.Precompute(bases, 0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(msm.DefaultWindow), table.Window())
+}
+
+func TestLoadOrPrecompute(t *testing.T) {
+	dir := t.TempDir()
+	window := uint(6)
+	bases := randomBases(t, 100)
+	scalars := randomScalars(t, 100)
+	expected := expectedMultiExp(t, bases, scalars)
+	filePath := path.Join(dir, msm.TableFileName(len(bases), window))
+
+	// the first call computes and persists the table
+	table, err := msm.LoadOrPrecompute(dir, bases, window, 2)
+	require.NoError(t, err)
+	res, err := table.MultiExp(scalars)
+	require.NoError(t, err)
+	assert.True(t, expected.Equal(&res))
+	info, err := os.Stat(filePath)
+	require.NoError(t, err)
+	modTime := info.ModTime()
+
+	// the second call maps the persisted table
+	table, err = msm.Load(filePath, msm.Fingerprint(bases), len(bases), window, 2)
+	require.NoError(t, err)
+	res, err = table.MultiExp(scalars)
+	require.NoError(t, err)
+	assert.True(t, expected.Equal(&res))
+	require.NoError(t, table.Close())
+
+	table, err = msm.LoadOrPrecompute(dir, bases, window, 2)
+	require.NoError(t, err)
+	res, err = table.MultiExp(scalars)
+	require.NoError(t, err)
+	assert.True(t, expected.Equal(&res))
+	require.NoError(t, table.Close())
+	info, err = os.Stat(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, modTime, info.ModTime())
+
+	// a table for different bases of the same size is invalidated and recomputed
+	otherBases := randomBases(t, 100)
+	_, err = msm.Load(filePath, msm.Fingerprint(otherBases), len(otherBases), window, 2)
+	assert.ErrorIs(t, err, msm.ErrInvalidTable)
+
+	table, err = msm.LoadOrPrecompute(dir, otherBases, window, 2)
+	require.NoError(t, err)
+	res, err = table.MultiExp(scalars)
+	require.NoError(t, err)
+	otherExpected := expectedMultiExp(t, otherBases, scalars)
+	assert.True(t, otherExpected.Equal(&res))
+
+	table, err = msm.Load(filePath, msm.Fingerprint(otherBases), len(otherBases), window, 2)
+	require.NoError(t, err)
+	require.NoError(t, table.Close())
+
+	// a truncated table is invalidated and recomputed
+	require.NoError(t, os.Truncate(filePath, 1000))
+	_, err = msm.Load(filePath, msm.Fingerprint(otherBases), len(otherBases), window, 2)
+	assert.ErrorIs(t, err, msm.ErrInvalidTable)
+
+	table, err = msm.LoadOrPrecompute(dir, otherBases, window, 2)
+	require.NoError(t, err)
+	res, err = table.MultiExp(scalars)
+	require.NoError(t, err)
+	assert.True(t, otherExpected.Equal(&res))
+
+	require.NoError(t, table.Close())
+
+	// a table whose points were corrupted is invalidated and recomputed
+	f, err := os.OpenFile(filePath, os.O_RDWR, 0)
+	require.NoError(t, err)
+	info, err = f.Stat()
+	require.NoError(t, err)
+	_, err = f.WriteAt([]byte{0xff, 0xff, 0xff, 0xff}, info.Size()-4)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	_, err = msm.Load(filePath, msm.Fingerprint(otherBases), len(otherBases), window, 2)
+	assert.ErrorIs(t, err, msm.ErrInvalidTable)
+
+	table, err = msm.LoadOrPrecompute(dir, otherBases, window, 2)
+	require.NoError(t, err)
+	res, err = table.MultiExp(scalars)
+	require.NoError(t, err)
+	assert.True(t, otherExpected.Equal(&res))
+	require.NoError(t, table.Close())
+
+	table, err = msm.Load(filePath, msm.Fingerprint(otherBases), len(otherBases), window, 2)
+	require.NoError(t, err)
+	require.NoError(t, table.Close())
+
+	// a table with a different window is kept in a separate file
+	_, err = msm.Load(filePath, msm.Fingerprint(otherBases), len(otherBases), window+1, 2)
+	assert.ErrorIs(t, err, msm.ErrInvalidTable)
+}
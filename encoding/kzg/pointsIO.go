@@ -163,6 +163,19 @@ func ReadG1Points(filepath string, n uint64, numWorker uint64) ([]bn254.G1Affine
 	return s1Outs, nil
 }
 
+// WriteG1Points writes the points to filepath in the compressed format read by ReadG1Points
+func WriteG1Points(filepath string, points []bn254.G1Affine) error {
+	buf := make([]byte, 0, uint64(len(points))*G1PointBytes)
+	for i := range points {
+		b := points[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+	if err := os.WriteFile(filepath, buf, 0644); err != nil {
+		return fmt.Errorf("error cannot write g1 points file %w", err)
+	}
+	return nil
+}
+
 // from is inclusive, to is exclusive
 func ReadG1PointSection(filepath string, from, to uint64, numWorker uint64) ([]bn254.G1Affine, error) {
 	if to <= from {
@@ -0,0 +1,178 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+var (
+	// ErrQueueFull is returned when an encoding is submitted while the queue of the AsyncProver is full
+	ErrQueueFull = errors.New("encoding queue is full")
+	// ErrProverClosed is returned when an encoding is submitted after the AsyncProver was closed
+	ErrProverClosed = errors.New("async prover is closed")
+	// ErrUnknownJob is returned for a job that was never submitted, or whose result was already returned
+	ErrUnknownJob = errors.New("unknown encoding job")
+)
+
+// JobID identifies an encoding submitted to an AsyncProver
+type JobID uint64
+
+// JobResult is the outcome of an encoding submitted to an AsyncProver
+type JobResult struct {
+	Commitments encoding.BlobCommitments
+	Chunks      []*encoding.Frame
+	Err         error
+}
+
+type asyncJob struct {
+	id     JobID
+	data   []byte
+	params encoding.EncodingParams
+
+	// done is closed once result is set
+	done   chan struct{}
+	result JobResult
+}
+
+// AsyncProver encodes blobs in the background: the encodings are submitted to a queue, which is drained by a
+// fixed number of workers, and the callers poll or wait for the results with the returned job IDs. This lets
+// the callers acknowledge a blob as soon as it's queued, and collect the encodings in the order they complete.
+//
+// The result of a job is kept until it's returned by Poll or Wait, so every submitted job must eventually be
+// polled or waited for.
+type AsyncProver struct {
+	prover encoding.Prover
+	queue  chan *asyncJob
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	jobs   map[JobID]*asyncJob
+	nextID JobID
+	closed bool
+}
+
+// NewAsyncProver creates an AsyncProver encoding with prover on numWorkers workers. Up to queueSize encodings
+// can be waiting for a worker, beyond which SubmitEncode fails with ErrQueueFull.
+func NewAsyncProver(prover encoding.Prover, numWorkers, queueSize int) (*AsyncProver, error) {
+	if numWorkers <= 0 {
+		return nil, fmt.Errorf("the number of workers must be positive, got %v", numWorkers)
+	}
+	if queueSize < 0 {
+		return nil, fmt.Errorf("the queue size must not be negative, got %v", queueSize)
+	}
+
+	p := &AsyncProver{
+		prover: prover,
+		queue:  make(chan *asyncJob, queueSize),
+		jobs:   make(map[JobID]*asyncJob),
+	}
+	p.wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go p.worker()
+	}
+	return p, nil
+}
+
+func (p *AsyncProver) worker() {
+	defer p.wg.Done()
+	for job := range p.queue {
+		job.result.Commitments, job.result.Chunks, job.result.Err = p.prover.EncodeAndProve(job.data, job.params)
+		// the data isn't needed anymore, while the job may be kept until its result is fetched
+		job.data = nil
+		close(job.done)
+	}
+}
+
+// SubmitEncode queues the encoding of a blob with the given parameters, and returns the ID of the job without
+// waiting for the encoding.
+func (p *AsyncProver) SubmitEncode(data []byte, params encoding.EncodingParams) (JobID, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return 0, ErrProverClosed
+	}
+
+	job := &asyncJob{
+		id:     p.nextID,
+		data:   data,
+		params: params,
+		done:   make(chan struct{}),
+	}
+	select {
+	case p.queue <- job:
+	default:
+		return 0, ErrQueueFull
+	}
+	p.jobs[job.id] = job
+	p.nextID++
+	return job.id, nil
+}
+
+// Poll returns the result of a job and true if the job completed, or false if it's still queued or encoding.
+// Once returned, the result of the job is discarded.
+func (p *AsyncProver) Poll(id JobID) (*JobResult, bool, error) {
+	job, err := p.getJob(id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	select {
+	case <-job.done:
+		return p.takeResult(job), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// Wait blocks until a job completes and returns its result, or until the context is done. Once returned, the
+// result of the job is discarded.
+func (p *AsyncProver) Wait(ctx context.Context, id JobID) (*JobResult, error) {
+	job, err := p.getJob(id)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-job.done:
+		return p.takeResult(job), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (p *AsyncProver) getJob(id JobID) (*asyncJob, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	job, ok := p.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownJob, id)
+	}
+	return job, nil
+}
+
+func (p *AsyncProver) takeResult(job *asyncJob) *JobResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.jobs, job.id)
+	return &job.result
+}
+
+// Close stops accepting encodings and waits for the queued encodings to complete. Their results can still be
+// polled or waited for.
+func (p *AsyncProver) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
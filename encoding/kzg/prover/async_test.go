@@ -0,0 +1,104 @@
+package prover_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAsyncProver(t *testing.T) {
+
+	p, _ := prover.NewProver(kzgConfig, true)
+	asyncProver, err := prover.NewAsyncProver(p, 2, 4)
+	assert.NoError(t, err)
+	defer asyncProver.Close()
+
+	params := []encoding.EncodingParams{
+		encoding.ParamsFromMins(5, 5),
+		encoding.ParamsFromMins(8, 16),
+		encoding.ParamsFromMins(1, 1),
+	}
+	ids := make([]prover.JobID, len(params))
+	for i := range params {
+		ids[i], err = asyncProver.SubmitEncode(gettysburgAddressBytes, params[i])
+		assert.NoError(t, err)
+	}
+
+	for i := range params[:2] {
+		expectedCommitments, expectedChunks, err := p.EncodeAndProve(gettysburgAddressBytes, params[i])
+		assert.NoError(t, err)
+
+		result, err := asyncProver.Wait(context.Background(), ids[i])
+		assert.NoError(t, err)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, expectedCommitments, result.Commitments)
+		assert.Equal(t, expectedChunks, result.Chunks)
+	}
+
+	// the blob doesn't fit the parameters of the last job
+	result, err := asyncProver.Wait(context.Background(), ids[2])
+	assert.NoError(t, err)
+	assert.Error(t, result.Err)
+
+	// the results are discarded once returned
+	_, err = asyncProver.Wait(context.Background(), ids[0])
+	assert.ErrorIs(t, err, prover.ErrUnknownJob)
+	_, _, err = asyncProver.Poll(ids[1])
+	assert.ErrorIs(t, err, prover.ErrUnknownJob)
+}
+
+func TestAsyncProverQueue(t *testing.T) {
+
+	release := make(chan time.Time)
+	enc := &encmock.MockEncoder{}
+	enc.On("EncodeAndProve", mock.Anything, mock.Anything).WaitUntil(release).Return(encoding.BlobCommitments{Length: 1}, []*encoding.Frame{}, nil)
+
+	asyncProver, err := prover.NewAsyncProver(enc, 1, 1)
+	assert.NoError(t, err)
+
+	params := encoding.ParamsFromMins(5, 5)
+	first, err := asyncProver.SubmitEncode(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	// the worker takes the first job, so the second one waits in the queue and the third one doesn't fit
+	var second prover.JobID
+	assert.Eventually(t, func() bool {
+		second, err = asyncProver.SubmitEncode(gettysburgAddressBytes, params)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	_, err = asyncProver.SubmitEncode(gettysburgAddressBytes, params)
+	assert.ErrorIs(t, err, prover.ErrQueueFull)
+
+	_, done, err := asyncProver.Poll(first)
+	assert.NoError(t, err)
+	assert.False(t, done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = asyncProver.Wait(ctx, first)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	asyncProver.Close()
+
+	// the queued jobs complete before Close returns
+	for _, id := range []prover.JobID{first, second} {
+		result, done, err := asyncProver.Poll(id)
+		assert.NoError(t, err)
+		assert.True(t, done)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, uint(1), result.Commitments.Length)
+	}
+
+	_, err = asyncProver.SubmitEncode(gettysburgAddressBytes, params)
+	assert.ErrorIs(t, err, prover.ErrProverClosed)
+
+	_, err = prover.NewAsyncProver(enc, 0, 1)
+	assert.Error(t, err)
+}
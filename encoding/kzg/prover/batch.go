@@ -0,0 +1,149 @@
+package prover
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// batchJob carries a blob of a batch through the stages of the encoding pipeline.
+type batchJob struct {
+	index    int
+	enc      *ParametrizedProver
+	length   uint
+	blobHash *[32]byte
+
+	poly    *rs.GlobalPoly
+	frames  []rs.Frame
+	indices []uint32
+
+	commit           *bn254.G1Affine
+	lengthCommitment *bn254.G2Affine
+	lengthProof      *bn254.G2Affine
+
+	err error
+}
+
+// EncodeAndProveBatch encodes a batch of blobs, the i-th blob with the i-th encoding parameters, and returns the
+// commitments and chunks of each blob in the order of the batch.
+//
+// The stages of the encodings are pipelined across the blobs: the Reed-Solomon extension of a blob runs while
+// the commitments of the previous blob are computed, which itself runs while the proofs of the blob before are
+// generated. No single stage keeps all the cores busy, so this has a higher throughput than encoding the blobs
+// one after the other. If any blob fails to be encoded, the error of the first failing blob is returned.
+func (e *Prover) EncodeAndProveBatch(data [][]byte, params []encoding.EncodingParams) ([]encoding.BlobCommitments, [][]*encoding.Frame, error) {
+	if len(data) != len(params) {
+		return nil, nil, fmt.Errorf("the number of blobs %v does not match the number of encoding parameters %v", len(data), len(params))
+	}
+
+	extended := make(chan *batchJob, 1)
+	committed := make(chan *batchJob, 1)
+
+	go func() {
+		defer close(extended)
+		for i := range data {
+			extended <- e.extendBatchJob(i, data[i], params[i])
+		}
+	}()
+
+	go func() {
+		defer close(committed)
+		for job := range extended {
+			if job.err == nil {
+				job.commit, job.lengthCommitment, job.lengthProof, job.err = job.enc.commitments(job.poly.Coeffs, job.blobHash)
+			}
+			committed <- job
+		}
+	}()
+
+	commitments := make([]encoding.BlobCommitments, len(data))
+	chunks := make([][]*encoding.Frame, len(data))
+	errs := make([]error, len(data))
+	for job := range committed {
+		if job.err != nil {
+			errs[job.index] = job.err
+			continue
+		}
+		kzgFrames, _, err := job.enc.prove(job.poly, job.frames, job.indices, nil)
+		if err != nil {
+			errs[job.index] = err
+			continue
+		}
+		commitments[job.index] = encoding.BlobCommitments{
+			Commitment:       (*encoding.G1Commitment)(job.commit),
+			LengthCommitment: (*encoding.G2Commitment)(job.lengthCommitment),
+			LengthProof:      (*encoding.G2Commitment)(job.lengthProof),
+			Length:           job.length,
+		}
+		chunks[job.index] = toChunks(kzgFrames)
+		if err := e.setChunkForm(job.enc, chunks[job.index], nil); err != nil {
+			errs[job.index] = err
+			continue
+		}
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode blob %v of the batch: %w", i, err)
+		}
+	}
+	return commitments, chunks, nil
+}
+
+// extendBatchJob runs the first stage of the encoding of a blob of a batch.
+func (e *Prover) extendBatchJob(index int, data []byte, params encoding.EncodingParams) *batchJob {
+	job := &batchJob{index: index}
+
+	enc, err := e.GetKzgEncoder(params)
+	if err != nil {
+		job.err = err
+		return job
+	}
+	job.enc = enc
+
+	inputFr, err := rs.ToFrArray(data)
+	if err != nil {
+		job.err = fmt.Errorf("cannot convert bytes to field elements, %w", err)
+		return job
+	}
+	job.length = uint(len(inputFr))
+	if enc.CommitmentCache != nil {
+		blobHash := sha256.Sum256(data)
+		job.blobHash = &blobHash
+	}
+
+	job.poly, job.frames, job.indices, job.err = enc.extend(inputFr)
+	return job
+}
+
+func toChunks(kzgFrames []encoding.Frame) []*encoding.Frame {
+	chunks := make([]*encoding.Frame, len(kzgFrames))
+	for ind, frame := range kzgFrames {
+		chunks[ind] = &encoding.Frame{
+			Coeffs: frame.Coeffs,
+			Proof:  frame.Proof,
+		}
+	}
+	return chunks
+}
+
+// setChunkForm converts the chunks with the given chunk indices to the configured chunk form. If indices is nil,
+// the chunks are all the chunks of a blob, in the order of their indices.
+func (e *Prover) setChunkForm(enc *ParametrizedProver, chunks []*encoding.Frame, indices []encoding.ChunkNumber) error {
+	if e.chunkForm == encoding.CoeffChunkForm {
+		return nil
+	}
+	for i, chunk := range chunks {
+		index := encoding.ChunkNumber(i)
+		if indices != nil {
+			index = indices[i]
+		}
+		if err := enc.SetChunkForm(chunk, index, e.chunkForm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
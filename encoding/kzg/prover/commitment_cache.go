@@ -0,0 +1,63 @@
+package prover
+
+import (
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+type cachedCommitments struct {
+	commitment       bn254.G1Affine
+	lengthCommitment bn254.G2Affine
+	lengthProof      bn254.G2Affine
+}
+
+// CommitmentCache maps the hash of a blob to the commitment, length commitment and length proof of the blob,
+// so that they aren't computed again when the same blob is encoded several times, e.g. when a client retries
+// a dispersal. The commitments only depend on the blob data, so they're shared across encoding parameters.
+//
+// Entries expire after the TTL, and the least recently used entries are evicted once the cache is full.
+// A nil CommitmentCache never holds any entry.
+type CommitmentCache struct {
+	cache *expirable.LRU[[32]byte, cachedCommitments]
+}
+
+// NewCommitmentCache creates a CommitmentCache holding up to size entries for the duration of ttl.
+func NewCommitmentCache(size int, ttl time.Duration) *CommitmentCache {
+	return &CommitmentCache{
+		cache: expirable.NewLRU[[32]byte, cachedCommitments](size, nil, ttl),
+	}
+}
+
+// Get returns the commitments of the blob with the given hash, if they're cached.
+func (c *CommitmentCache) Get(blobHash [32]byte) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, bool) {
+	if c == nil {
+		return nil, nil, nil, false
+	}
+	entry, ok := c.cache.Get(blobHash)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	return &entry.commitment, &entry.lengthCommitment, &entry.lengthProof, true
+}
+
+// Add caches the commitments of the blob with the given hash.
+func (c *CommitmentCache) Add(blobHash [32]byte, commitment *bn254.G1Affine, lengthCommitment, lengthProof *bn254.G2Affine) {
+	if c == nil {
+		return
+	}
+	c.cache.Add(blobHash, cachedCommitments{
+		commitment:       *commitment,
+		lengthCommitment: *lengthCommitment,
+		lengthProof:      *lengthProof,
+	})
+}
+
+// Len returns the number of cached entries, including the expired entries that weren't evicted yet.
+func (c *CommitmentCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Len()
+}
@@ -0,0 +1,97 @@
+package prover_test
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitmentCache(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+
+	cache := prover.NewCommitmentCache(2, time.Minute)
+	for i := byte(0); i < 3; i++ {
+		cache.Add([32]byte{i}, &g1Gen, &g2Gen, &g2Gen)
+	}
+	assert.Equal(t, 2, cache.Len())
+
+	// the least recently used entry is evicted
+	_, _, _, ok := cache.Get([32]byte{0})
+	assert.False(t, ok)
+	commitment, lengthCommitment, lengthProof, ok := cache.Get([32]byte{2})
+	assert.True(t, ok)
+	assert.Equal(t, g1Gen, *commitment)
+	assert.Equal(t, g2Gen, *lengthCommitment)
+	assert.Equal(t, g2Gen, *lengthProof)
+
+	// the cached entries are copies
+	commitment.Double(commitment)
+	commitment, _, _, ok = cache.Get([32]byte{2})
+	assert.True(t, ok)
+	assert.Equal(t, g1Gen, *commitment)
+
+	// a nil cache holds nothing
+	var nilCache *prover.CommitmentCache
+	nilCache.Add([32]byte{0}, &g1Gen, &g2Gen, &g2Gen)
+	_, _, _, ok = nilCache.Get([32]byte{0})
+	assert.False(t, ok)
+	assert.Equal(t, 0, nilCache.Len())
+}
+
+func TestCommitmentCacheExpiry(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+
+	cache := prover.NewCommitmentCache(10, 50*time.Millisecond)
+	cache.Add([32]byte{1}, &g1Gen, &g2Gen, &g2Gen)
+	_, _, _, ok := cache.Get([32]byte{1})
+	assert.True(t, ok)
+
+	time.Sleep(100 * time.Millisecond)
+	_, _, _, ok = cache.Get([32]byte{1})
+	assert.False(t, ok)
+}
+
+func TestEncoderCommitmentCache(t *testing.T) {
+
+	cachedConfig := *kzgConfig
+	cachedConfig.CommitmentCacheSize = 10
+	cachedConfig.CommitmentCacheTTL = time.Minute
+
+	p, _ := prover.NewProver(kzgConfig, true)
+	cached, err := prover.NewProver(&cachedConfig, true)
+	assert.NoError(t, err)
+
+	params := encoding.ParamsFromMins(5, 5)
+	expectedCommitments, expectedChunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	// the commitments are cached on the first encoding and reused afterwards, including with other parameters
+	for _, params := range []encoding.EncodingParams{params, params, encoding.ParamsFromMins(8, 16)} {
+		commitments, chunks, err := cached.EncodeAndProve(gettysburgAddressBytes, params)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCommitments, commitments)
+		assert.Len(t, chunks, int(params.NumChunks))
+		assert.Equal(t, 1, cached.CommitmentCache.Len())
+	}
+	commitments, chunks, err := cached.EncodeAndProve(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCommitments, commitments)
+	assert.Equal(t, expectedChunks, chunks)
+
+	// cached commitments are returned without being computed again
+	var data []byte
+	data = append(data, gettysburgAddressBytes...)
+	data[0] = 0
+	data[1] ^= 1
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	cached.CommitmentCache.Add(sha256.Sum256(data), &g1Gen, &g2Gen, &g2Gen)
+	commitments, _, err = cached.EncodeAndProve(data, params)
+	assert.NoError(t, err)
+	assert.Equal(t, g1Gen, bn254.G1Affine(*commitments.Commitment))
+	assert.Equal(t, g2Gen, bn254.G2Affine(*commitments.LengthCommitment))
+}
@@ -1,6 +1,7 @@
 package prover
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"log"
 	"math"
@@ -10,9 +11,11 @@ import (
 
 	"github.com/Layr-Labs/eigenda/encoding/fft"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/committer"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
+	"github.com/Layr-Labs/eigenda/encoding/poly"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigenda/encoding/utils/toeplitz"
-	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
@@ -23,6 +26,13 @@ type ParametrizedProver struct {
 	*kzg.KzgConfig
 	Srs        *kzg.SRS
 	G2Trailing []bn254.G2Affine
+	G1Table    *msm.Table
+
+	CommitmentCache *CommitmentCache
+
+	// NaiveProofMaxLength is the largest input length, in symbols, whose proofs are computed with the naive
+	// algorithm rather than FK20. It is set from the proof algorithm of the config when the prover is created.
+	NaiveProofMaxLength int
 
 	Fs         *fft.FFTSettings
 	Ks         *kzg.KZGSettings
@@ -37,73 +47,134 @@ type WorkerResult struct {
 
 // just a wrapper to take bytes not Fr Element
 func (g *ParametrizedProver) EncodeBytes(inputBytes []byte) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, []encoding.Frame, []uint32, error) {
+	return g.encodeBytes(inputBytes, nil)
+}
+
+func (g *ParametrizedProver) encodeBytes(inputBytes []byte, checkpoint encoding.Checkpoint) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, []encoding.Frame, []uint32, error) {
 	inputFr, err := rs.ToFrArray(inputBytes)
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf("cannot convert bytes to field elements, %w", err)
 	}
-	return g.Encode(inputFr)
+	if g.CommitmentCache == nil {
+		return g.encode(inputFr, nil, checkpoint)
+	}
+	blobHash := sha256.Sum256(inputBytes)
+	return g.encode(inputFr, &blobHash, checkpoint)
 }
 
 func (g *ParametrizedProver) Encode(inputFr []fr.Element) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, []encoding.Frame, []uint32, error) {
+	return g.encode(inputFr, nil, nil)
+}
+
+// encode encodes the input and computes its commitments and proofs. If blobHash is not nil, the commitments
+// are looked up in the commitment cache first, and added to it once computed. The checkpoint is called
+// between the stages of the encoding.
+func (g *ParametrizedProver) encode(inputFr []fr.Element, blobHash *[32]byte, checkpoint encoding.Checkpoint) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, []encoding.Frame, []uint32, error) {
 
 	startTime := time.Now()
-	poly, frames, indices, err := g.Encoder.Encode(inputFr)
+	poly, frames, indices, err := g.extend(inputFr)
 	if err != nil {
 		return nil, nil, nil, nil, nil, err
 	}
+	checkpoint.Wait()
 
-	if len(poly.Coeffs) > int(g.KzgConfig.SRSNumberToLoad) {
-		return nil, nil, nil, nil, nil, fmt.Errorf("poly Coeff length %v is greater than Loaded SRS points %v", len(poly.Coeffs), int(g.KzgConfig.SRSNumberToLoad))
-	}
-
-	// compute commit for the full poly
-	commit, err := g.Commit(poly.Coeffs)
+	commit, lengthCommitment, lengthProof, err := g.commitments(poly.Coeffs, blobHash)
 	if err != nil {
 		return nil, nil, nil, nil, nil, err
 	}
+	checkpoint.Wait()
 
-	config := ecc.MultiExpConfig{}
-
-	var lengthCommitment bn254.G2Affine
-	_, err = lengthCommitment.MultiExp(g.Srs.G2[:len(poly.Coeffs)], poly.Coeffs, config)
+	kzgFrames, indices, err := g.prove(poly, frames, indices, checkpoint)
 	if err != nil {
 		return nil, nil, nil, nil, nil, err
 	}
 
-	intermediate := time.Now()
+	if g.Verbose {
+		log.Printf("Total encoding took      %v\n", time.Since(startTime))
+	}
+	return commit, lengthCommitment, lengthProof, kzgFrames, indices, nil
+}
 
-	chunkLength := uint64(len(inputFr))
+// extend is the first stage of an encoding: it computes the Reed-Solomon extension of the input. The extension
+// is skipped when the proofs are computed with the naive algorithm, which computes the frames along with their proofs.
+func (g *ParametrizedProver) extend(inputFr []fr.Element) (*rs.GlobalPoly, []rs.Frame, []uint32, error) {
+	if g.useNaiveProofs(len(inputFr)) {
+		if len(inputFr) > int(g.KzgConfig.SRSNumberToLoad) {
+			return nil, nil, nil, fmt.Errorf("%w: poly Coeff length %v is greater than Loaded SRS points %v", encoding.ErrSRSOutOfRange, len(inputFr), int(g.KzgConfig.SRSNumberToLoad))
+		}
+		if err := g.ValidateDataLength(uint64(len(inputFr))); err != nil {
+			return nil, nil, nil, err
+		}
+		return &rs.GlobalPoly{Coeffs: inputFr}, nil, nil, nil
+	}
 
-	if g.Verbose {
-		log.Printf("    Commiting takes  %v\n", time.Since(intermediate))
-		intermediate = time.Now()
+	poly, frames, indices, err := g.Encoder.Encode(inputFr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		log.Printf("shift %v\n", g.SRSOrder-chunkLength)
-		log.Printf("order %v\n", len(g.Srs.G2))
-		log.Println("low degree verification info")
+	if len(poly.Coeffs) > int(g.KzgConfig.SRSNumberToLoad) {
+		return nil, nil, nil, fmt.Errorf("%w: poly Coeff length %v is greater than Loaded SRS points %v", encoding.ErrSRSOutOfRange, len(poly.Coeffs), int(g.KzgConfig.SRSNumberToLoad))
 	}
+	return poly, frames, indices, nil
+}
 
-	shiftedSecret := g.G2Trailing[g.KzgConfig.SRSNumberToLoad-chunkLength:]
+// commitments is the second stage of an encoding: it computes the commitments of the polynomial, or looks
+// them up in the commitment cache if blobHash is not nil.
+func (g *ParametrizedProver) commitments(coeffs []fr.Element, blobHash *[32]byte) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, error) {
+	if blobHash != nil {
+		commit, lengthCommitment, lengthProof, ok := g.CommitmentCache.Get(*blobHash)
+		if ok {
+			if g.Verbose {
+				log.Printf("    Reusing cached commitments for blob %x\n", *blobHash)
+			}
+			return commit, lengthCommitment, lengthProof, nil
+		}
+	}
 
-	//The proof of low degree is commitment of the polynomial shifted to the largest srs degree
-	var lengthProof bn254.G2Affine
-	_, err = lengthProof.MultiExp(shiftedSecret, poly.Coeffs, config)
+	commit, lengthCommitment, lengthProof, err := g.getCommitments(coeffs)
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, nil, nil, err
+	}
+	if blobHash != nil {
+		g.CommitmentCache.Add(*blobHash, commit, lengthCommitment, lengthProof)
 	}
+	return commit, lengthCommitment, lengthProof, nil
+}
 
-	if g.Verbose {
-		log.Printf("    Generating Length Proof takes  %v\n", time.Since(intermediate))
-		intermediate = time.Now()
+// prove is the last stage of an encoding: it computes the proofs of the frames, and returns the frames along with
+// their leading coset indices.
+func (g *ParametrizedProver) prove(poly *rs.GlobalPoly, frames []rs.Frame, indices []uint32, checkpoint encoding.Checkpoint) ([]encoding.Frame, []uint32, error) {
+	if g.useNaiveProofs(len(poly.Coeffs)) {
+		intermediate := time.Now()
+		kzgFrames, indices, err := g.proveNaive(poly.Coeffs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not generate proofs: %w", err)
+		}
+		if g.Verbose {
+			log.Printf("    Naive proving takes    %v\n", time.Since(intermediate))
+		}
+		return kzgFrames, indices, nil
 	}
 
-	// compute proofs
-	paddedCoeffs := make([]fr.Element, g.NumEvaluations())
+	kzgFrames, err := g.proveFK20(poly, frames, indices, checkpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kzgFrames, indices, nil
+}
+
+// proveFK20 computes the proofs of the frames of the Reed-Solomon extension with the FK20 algorithm.
+func (g *ParametrizedProver) proveFK20(poly *rs.GlobalPoly, frames []rs.Frame, indices []uint32, checkpoint encoding.Checkpoint) ([]encoding.Frame, error) {
+	intermediate := time.Now()
+
+	paddedCoeffs := g.Fs.Scratch.Get(int(g.NumEvaluations()))
+	defer g.Fs.Scratch.Put(paddedCoeffs)
 	copy(paddedCoeffs, poly.Coeffs)
 
-	proofs, err := g.ProveAllCosetThreads(paddedCoeffs, g.NumChunks, g.ChunkLength, g.NumWorker)
+	proofs, err := g.proveAllCosetThreads(paddedCoeffs, g.NumChunks, g.ChunkLength, g.NumWorker, checkpoint)
 	if err != nil {
-		return nil, nil, nil, nil, nil, fmt.Errorf("could not generate proofs: %v", err)
+		return nil, fmt.Errorf("could not generate proofs: %v", err)
 	}
 
 	if g.Verbose {
@@ -117,19 +188,131 @@ func (g *ParametrizedProver) Encode(inputFr []fr.Element) (*bn254.G1Affine, *bn2
 			Coeffs: frames[i].Coeffs,
 		}
 	}
+	return kzgFrames, nil
+}
+
+// EncodeChunks computes only the frames with the given chunk indices along with their proofs.
+// Each proof is computed directly as the commitment to the quotient of the polynomial by the vanishing
+// polynomial of the frame's coset, which is cheaper than Encode when only a few chunks are needed.
+// The frames are returned in the order of the indices and are identical to the frames at those
+// positions returned by Encode.
+func (g *ParametrizedProver) EncodeChunks(inputFr []fr.Element, indices []encoding.ChunkNumber) ([]encoding.Frame, error) {
+	if len(inputFr) > int(g.KzgConfig.SRSNumberToLoad) {
+		return nil, fmt.Errorf("%w: poly Coeff length %v is greater than Loaded SRS points %v", encoding.ErrSRSOutOfRange, len(inputFr), int(g.KzgConfig.SRSNumberToLoad))
+	}
+
+	if err := g.ValidateDataLength(uint64(len(inputFr))); err != nil {
+		return nil, err
+	}
+
+	kzgFrames := make([]encoding.Frame, len(indices))
+	for i, index := range indices {
+		j, err := rs.GetLeadingCosetIndex(uint64(index), g.NumChunks)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk index %d: %w", index, err)
+		}
+		quotient, coeffs := g.Encoder.GetCosetQuotient(inputFr, j)
+
+		var proof bn254.G1Affine
+		if len(quotient) > 0 {
+			proof, err = g.Commit(quotient)
+			if err != nil {
+				return nil, fmt.Errorf("could not generate proof for chunk %d: %w", index, err)
+			}
+		}
+
+		kzgFrames[i] = encoding.Frame{
+			Proof:  proof,
+			Coeffs: coeffs,
+		}
+	}
+
+	return kzgFrames, nil
+}
+
+// ProveAggregated computes a single proof for the frames with the given chunk indices: the commitment to the
+// quotient of the polynomial by the vanishing polynomial of the union of their cosets. Its size doesn't depend on
+// the number of frames, which are checked against it all at once.
+func (g *ParametrizedProver) ProveAggregated(inputFr []fr.Element, indices []encoding.ChunkNumber) (bn254.G1Affine, error) {
+	if len(inputFr) > int(g.KzgConfig.SRSNumberToLoad) {
+		return bn254.G1Affine{}, fmt.Errorf("%w: poly Coeff length %v is greater than Loaded SRS points %v", encoding.ErrSRSOutOfRange, len(inputFr), int(g.KzgConfig.SRSNumberToLoad))
+	}
+
+	if err := g.ValidateDataLength(uint64(len(inputFr))); err != nil {
+		return bn254.G1Affine{}, err
+	}
+
+	shifts, err := g.Encoder.GetCosetShifts(toUint64Array(indices))
+	if err != nil {
+		return bn254.G1Affine{}, err
+	}
+	quotient, _, err := poly.DivMod(inputFr, rs.AggregateVanishing(shifts, g.ChunkLength))
+	if err != nil {
+		return bn254.G1Affine{}, err
+	}
+
+	var proof bn254.G1Affine
+	if len(quotient) > 0 {
+		proof, err = g.Commit(quotient)
+		if err != nil {
+			return bn254.G1Affine{}, fmt.Errorf("could not generate aggregated proof: %w", err)
+		}
+	}
+	return proof, nil
+}
+
+// getCommitments computes the commitment, the length commitment and the length proof of the polynomial.
+func (g *ParametrizedProver) getCommitments(coeffs []fr.Element) (*bn254.G1Affine, *bn254.G2Affine, *bn254.G2Affine, error) {
+	intermediate := time.Now()
+
+	// compute commit for the full poly
+	commit, err := g.Commit(coeffs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	lengthCommitment, err := committer.LengthCommitment(g.Srs.G2, coeffs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	chunkLength := uint64(len(coeffs))
 
 	if g.Verbose {
-		log.Printf("Total encoding took      %v\n", time.Since(startTime))
+		log.Printf("    Commiting takes  %v\n", time.Since(intermediate))
+		intermediate = time.Now()
+
+		log.Printf("shift %v\n", g.SRSOrder-chunkLength)
+		log.Printf("order %v\n", len(g.Srs.G2))
+		log.Println("low degree verification info")
+	}
+
+	//The proof of low degree is commitment of the polynomial shifted to the largest srs degree
+	lengthProof, err := committer.LengthProof(g.G2Trailing, coeffs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if g.Verbose {
+		log.Printf("    Generating Length Proof takes  %v\n", time.Since(intermediate))
 	}
-	return &commit, &lengthCommitment, &lengthProof, kzgFrames, indices, nil
+
+	return &commit, lengthCommitment, lengthProof, nil
 }
 
 func (g *ParametrizedProver) Commit(polyFr []fr.Element) (bn254.G1Affine, error) {
+	if g.G1Table != nil && len(polyFr) <= g.G1Table.NumBases() {
+		return g.G1Table.MultiExp(polyFr)
+	}
 	commit, err := g.Ks.CommitToPoly(polyFr)
 	return *commit, err
 }
 
 func (p *ParametrizedProver) ProveAllCosetThreads(polyFr []fr.Element, numChunks, chunkLen, numWorker uint64) ([]bn254.G1Affine, error) {
+	return p.proveAllCosetThreads(polyFr, numChunks, chunkLen, numWorker, nil)
+}
+
+func (p *ParametrizedProver) proveAllCosetThreads(polyFr []fr.Element, numChunks, chunkLen, numWorker uint64, checkpoint encoding.Checkpoint) ([]bn254.G1Affine, error) {
 	begin := time.Now()
 	// Robert: Standardizing this to use the same math used in precomputeSRS
 	dimE := numChunks
@@ -143,8 +326,13 @@ func (p *ParametrizedProver) ProveAllCosetThreads(polyFr []fr.Element, numChunks
 	// create storage for intermediate fft outputs
 	coeffStore := make([][]fr.Element, dimE*2)
 	for i := range coeffStore {
-		coeffStore[i] = make([]fr.Element, l)
+		coeffStore[i] = p.Fs.Scratch.Get(int(l))
 	}
+	defer func() {
+		for i := range coeffStore {
+			p.Fs.Scratch.Put(coeffStore[i])
+		}
+	}()
 
 	for w := uint64(0); w < numWorker; w++ {
 		go p.proofWorker(polyFr, jobChan, l, dimE, coeffStore, results)
@@ -167,6 +355,7 @@ func (p *ParametrizedProver) ProveAllCosetThreads(polyFr []fr.Element, numChunks
 	if err != nil {
 		return nil, fmt.Errorf("proof worker error: %v", err)
 	}
+	checkpoint.Wait()
 
 	t0 := time.Now()
 
@@ -175,7 +364,8 @@ func (p *ParametrizedProver) ProveAllCosetThreads(polyFr []fr.Element, numChunks
 	for i := uint64(0); i < dimE*2; i++ {
 
 		go func(k uint64) {
-			_, err := sumVec[k].MultiExp(p.FFTPointsT[k], coeffStore[k], ecc.MultiExpConfig{})
+			var err error
+			sumVec[k], err = msm.MultiExpG1(p.FFTPointsT[k], coeffStore[k])
 			// handle error
 			msmErrors <- err
 		}(i)
@@ -188,6 +378,7 @@ func (p *ParametrizedProver) ProveAllCosetThreads(polyFr []fr.Element, numChunks
 			return nil, err
 		}
 	}
+	checkpoint.Wait()
 
 	t1 := time.Now()
 
@@ -196,6 +387,7 @@ func (p *ParametrizedProver) ProveAllCosetThreads(polyFr []fr.Element, numChunks
 	if err != nil {
 		return nil, fmt.Errorf("fft error: %v", err)
 	}
+	checkpoint.Wait()
 
 	t2 := time.Now()
 
@@ -232,6 +424,7 @@ func (p *ParametrizedProver) proofWorker(
 			for i := 0; i < len(coeffs); i++ {
 				coeffStore[i][j] = coeffs[i]
 			}
+			p.SFs.Scratch.Put(coeffs)
 		}
 	}
 
@@ -250,7 +443,8 @@ func (p *ParametrizedProver) GetSlicesCoeff(polyFr []fr.Element, dimE, j, l uint
 	m := uint64(len(polyFr)) - 1
 	dim := (m - j) / l
 
-	toeV := make([]fr.Element, 2*dimE-1)
+	toeV := p.SFs.Scratch.Get(int(2*dimE - 1))
+	defer p.SFs.Scratch.Put(toeV)
 	for i := uint64(0); i < dim; i++ {
 
 		toeV[i].Set(&polyFr[m-(j+i*l)])
@@ -0,0 +1,107 @@
+package prover
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// numTuningRuns is the number of times each proof algorithm is benchmarked when tuning, keeping the fastest run
+const numTuningRuns = 2
+
+func validateProofAlgorithm(algorithm string) error {
+	switch algorithm {
+	case "", kzg.FK20ProofAlgorithm, kzg.NaiveProofAlgorithm, kzg.AutoProofAlgorithm:
+		return nil
+	default:
+		return fmt.Errorf("unknown proof algorithm %q, must be one of %q, %q and %q", algorithm, kzg.FK20ProofAlgorithm, kzg.NaiveProofAlgorithm, kzg.AutoProofAlgorithm)
+	}
+}
+
+// useNaiveProofs returns whether the proofs of an input with the given number of symbols are computed with the naive
+// algorithm rather than FK20.
+func (g *ParametrizedProver) useNaiveProofs(inputLength int) bool {
+	return inputLength <= g.NaiveProofMaxLength
+}
+
+// tuneProofAlgorithm sets NaiveProofMaxLength according to the configured proof algorithm. With the auto algorithm,
+// both algorithms are benchmarked with the encoding parameters of the prover.
+//
+// The cost of FK20 only depends on the encoding parameters, as the polynomial is padded to the number of evaluations,
+// while the cost of the naive algorithm is the number of chunks times a commitment of the size of the input. So the
+// naive algorithm is faster up to the input length where both costs cross, which is estimated from the cost of FK20
+// and of one naive proof with an input of half the number of evaluations.
+func (g *ParametrizedProver) tuneProofAlgorithm() error {
+	switch g.ProofAlgorithm {
+	case kzg.NaiveProofAlgorithm:
+		g.NaiveProofMaxLength = math.MaxInt
+		return nil
+	case kzg.AutoProofAlgorithm:
+	default:
+		g.NaiveProofMaxLength = -1
+		return nil
+	}
+
+	length := max(int(g.NumEvaluations())/2, 1)
+	length = min(length, int(g.SRSNumberToLoad))
+	inputFr := make([]fr.Element, length)
+	for i := range inputFr {
+		if _, err := inputFr[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	fk20Duration := time.Duration(math.MaxInt64)
+	naiveDuration := time.Duration(math.MaxInt64)
+	for i := 0; i < numTuningRuns; i++ {
+		start := time.Now()
+		poly, frames, indices, err := g.Encoder.Encode(inputFr)
+		if err != nil {
+			return err
+		}
+		if _, err := g.proveFK20(poly, frames, indices, nil); err != nil {
+			return err
+		}
+		fk20Duration = min(fk20Duration, time.Since(start))
+
+		start = time.Now()
+		if _, err := g.EncodeChunks(inputFr, []encoding.ChunkNumber{0}); err != nil {
+			return err
+		}
+		naiveDuration = min(naiveDuration, time.Since(start))
+	}
+
+	// naive cost of an input of length l: NumChunks * naiveDuration * l / length
+	naiveCostPerSymbol := float64(g.NumChunks) * float64(naiveDuration) / float64(length)
+	g.NaiveProofMaxLength = int(float64(fk20Duration) / max(naiveCostPerSymbol, 1))
+
+	log.Printf("Tuned proof algorithm for %v chunks of length %v: FK20 takes %v, naive proof of one chunk takes %v for %v symbols, naive proofs are used for up to %v symbols\n",
+		g.NumChunks, g.ChunkLength, fk20Duration, naiveDuration, length, g.NaiveProofMaxLength)
+	return nil
+}
+
+// proveNaive computes the frames and their proofs with the naive algorithm.
+func (g *ParametrizedProver) proveNaive(coeffs []fr.Element) ([]encoding.Frame, []uint32, error) {
+	chunkIndices := make([]encoding.ChunkNumber, g.NumChunks)
+	indices := make([]uint32, g.NumChunks)
+	for i := range chunkIndices {
+		chunkIndices[i] = encoding.ChunkNumber(i)
+		j, err := rs.GetLeadingCosetIndex(uint64(i), g.NumChunks)
+		if err != nil {
+			return nil, nil, err
+		}
+		indices[i] = j
+	}
+
+	frames, err := g.EncodeChunks(coeffs, chunkIndices)
+	if err != nil {
+		return nil, nil, err
+	}
+	return frames, indices, nil
+}
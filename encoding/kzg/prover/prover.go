@@ -1,6 +1,7 @@
 package prover
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -14,8 +15,11 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/fft"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/scratch"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 
 	_ "go.uber.org/automaxprocs"
 )
@@ -26,6 +30,12 @@ type Prover struct {
 	G2Trailing   []bn254.G2Affine
 	mu           sync.Mutex
 	LoadG2Points bool
+	// G1Table is the precomputed MSM table of the G1 SRS, nil if not configured
+	G1Table *msm.Table
+	// CommitmentCache caches the commitments of recently encoded blobs, nil if not configured
+	CommitmentCache *CommitmentCache
+	// chunkForm is the form of the chunks output by the prover, parsed from the config
+	chunkForm encoding.ChunkForm
 
 	ParametrizedProvers map[encoding.EncodingParams]*ParametrizedProver
 }
@@ -38,8 +48,22 @@ func NewProver(config *kzg.KzgConfig, loadG2Points bool) (*Prover, error) {
 		return nil, errors.New("SRSOrder is less than srsNumberToLoad")
 	}
 
+	if err := kzg.DownloadSRS(context.Background(), config); err != nil {
+		log.Println("failed to download SRS", err)
+		return nil, err
+	}
+
+	if err := validateProofAlgorithm(config.ProofAlgorithm); err != nil {
+		return nil, err
+	}
+
+	chunkForm, err := encoding.ParseChunkForm(config.ChunkForm)
+	if err != nil {
+		return nil, err
+	}
+
 	// read the whole order, and treat it as entire SRS for low degree proof
-	s1, err := kzg.ReadG1Points(config.G1Path, config.SRSNumberToLoad, config.NumWorker)
+	s1, err := kzg.LoadG1PointSection(config, 0, config.SRSNumberToLoad)
 	if err != nil {
 		log.Println("failed to read G1 points", err)
 		return nil, err
@@ -54,17 +78,16 @@ func NewProver(config *kzg.KzgConfig, loadG2Points bool) (*Prover, error) {
 			return nil, errors.New("G2Path is empty. However, object needs to load G2Points")
 		}
 
-		s2, err = kzg.ReadG2Points(config.G2Path, config.SRSNumberToLoad, config.NumWorker)
+		s2, err = kzg.LoadG2PointSection(config, 0, config.SRSNumberToLoad)
 		if err != nil {
 			log.Println("failed to read G2 points", err)
 			return nil, err
 		}
 
-		g2Trailing, err = kzg.ReadG2PointSection(
-			config.G2Path,
+		g2Trailing, err = kzg.LoadG2PointSection(
+			config,
 			config.SRSOrder-config.SRSNumberToLoad,
 			config.SRSOrder, // last exclusive
-			config.NumWorker,
 		)
 		if err != nil {
 			return nil, err
@@ -82,6 +105,29 @@ func NewProver(config *kzg.KzgConfig, loadG2Points bool) (*Prover, error) {
 		return nil, err
 	}
 
+	var g1Table *msm.Table
+	if len(config.MSMTableDir) > 0 {
+		g1Table, err = msm.LoadOrPrecompute(config.MSMTableDir, s1, uint(config.MSMTableWindow), int(config.NumWorker))
+		if err != nil {
+			log.Println("Could not load msm table", err)
+			return nil, err
+		}
+	}
+
+	var commitmentCache *CommitmentCache
+	if config.CommitmentCacheSize > 0 {
+		commitmentCache = NewCommitmentCache(int(config.CommitmentCacheSize), config.CommitmentCacheTTL)
+	}
+
+	if len(config.MSMCalibrationDir) > 0 {
+		calibration, err := msm.LoadOrCalibrate(config.MSMCalibrationDir)
+		if err != nil {
+			log.Println("Could not load msm calibration", err)
+			return nil, err
+		}
+		msm.SetCalibration(calibration)
+	}
+
 	fmt.Println("numthread", runtime.GOMAXPROCS(0))
 
 	encoderGroup := &Prover{
@@ -90,6 +136,9 @@ func NewProver(config *kzg.KzgConfig, loadG2Points bool) (*Prover, error) {
 		G2Trailing:          g2Trailing,
 		ParametrizedProvers: make(map[encoding.EncodingParams]*ParametrizedProver),
 		LoadG2Points:        loadG2Points,
+		G1Table:             g1Table,
+		CommitmentCache:     commitmentCache,
+		chunkForm:           chunkForm,
 	}
 
 	if config.PreloadEncoder {
@@ -137,24 +186,24 @@ func (g *Prover) PreloadAllEncoders() error {
 }
 
 func (e *Prover) EncodeAndProve(data []byte, params encoding.EncodingParams) (encoding.BlobCommitments, []*encoding.Frame, error) {
+	return e.EncodeAndProveWithCheckpoint(data, params, nil)
+}
+
+func (e *Prover) EncodeAndProveWithCheckpoint(data []byte, params encoding.EncodingParams, checkpoint encoding.Checkpoint) (encoding.BlobCommitments, []*encoding.Frame, error) {
 
 	enc, err := e.GetKzgEncoder(params)
 	if err != nil {
 		return encoding.BlobCommitments{}, nil, err
 	}
 
-	commit, lengthCommit, lengthProof, kzgFrames, _, err := enc.EncodeBytes(data)
+	commit, lengthCommit, lengthProof, kzgFrames, _, err := enc.encodeBytes(data, checkpoint)
 	if err != nil {
 		return encoding.BlobCommitments{}, nil, err
 	}
 
-	chunks := make([]*encoding.Frame, len(kzgFrames))
-	for ind, frame := range kzgFrames {
-
-		chunks[ind] = &encoding.Frame{
-			Coeffs: frame.Coeffs,
-			Proof:  frame.Proof,
-		}
+	chunks := toChunks(kzgFrames)
+	if err := e.setChunkForm(enc, chunks, nil); err != nil {
+		return encoding.BlobCommitments{}, nil, err
 	}
 
 	symbols, err := rs.ToFrArray(data)
@@ -173,6 +222,57 @@ func (e *Prover) EncodeAndProve(data []byte, params encoding.EncodingParams) (en
 	return commitments, chunks, nil
 }
 
+func (e *Prover) EncodeChunks(data []byte, params encoding.EncodingParams, indices []encoding.ChunkNumber) ([]*encoding.Frame, error) {
+
+	enc, err := e.GetKzgEncoder(params)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := rs.ToFrArray(data)
+	if err != nil {
+		return nil, err
+	}
+
+	kzgFrames, err := enc.EncodeChunks(symbols, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]*encoding.Frame, len(kzgFrames))
+	for ind, frame := range kzgFrames {
+		chunks[ind] = &encoding.Frame{
+			Coeffs: frame.Coeffs,
+			Proof:  frame.Proof,
+		}
+	}
+	if err := e.setChunkForm(enc, chunks, indices); err != nil {
+		return nil, err
+	}
+
+	return chunks, nil
+}
+
+// ProveAggregated computes the aggregated proof of the frames with the given chunk indices, which replaces
+// their individual proofs. See ParametrizedProver.ProveAggregated.
+func (e *Prover) ProveAggregated(data []byte, params encoding.EncodingParams, indices []encoding.ChunkNumber) (*encoding.Proof, error) {
+	enc, err := e.GetKzgEncoder(params)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := rs.ToFrArray(data)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := enc.ProveAggregated(symbols, indices)
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
 func (g *Prover) GetKzgEncoder(params encoding.EncodingParams) (*ParametrizedProver, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -193,7 +293,7 @@ func (g *Prover) newProver(params encoding.EncodingParams) (*ParametrizedProver,
 
 	// Check that the parameters are valid with respect to the SRS.
 	if params.ChunkLength*params.NumChunks >= g.SRSOrder {
-		return nil, fmt.Errorf("the supplied encoding parameters are not valid with respect to the SRS. ChunkLength: %d, NumChunks: %d, SRSOrder: %d", params.ChunkLength, params.NumChunks, g.SRSOrder)
+		return nil, fmt.Errorf("%w: the supplied encoding parameters are not valid with respect to the SRS. ChunkLength: %d, NumChunks: %d, SRSOrder: %d", encoding.ErrSRSOutOfRange, params.ChunkLength, params.NumChunks, g.SRSOrder)
 	}
 
 	encoder, err := rs.NewEncoder(params, g.Verbose)
@@ -236,16 +336,31 @@ func (g *Prover) newProver(params encoding.EncodingParams) (*ParametrizedProver,
 	t := uint8(math.Log2(float64(2 * encoder.NumChunks)))
 	sfs := fft.NewFFTSettings(t)
 
-	return &ParametrizedProver{
-		Encoder:    encoder,
-		KzgConfig:  g.KzgConfig,
-		Srs:        g.Srs,
-		G2Trailing: g.G2Trailing,
-		Fs:         fs,
-		Ks:         ks,
-		SFs:        sfs,
-		FFTPointsT: fftPointsT,
-	}, nil
+	// share a single scratch pool between the transforms of this prover
+	pool := scratch.NewPool[fr.Element](!g.DisableScratchPool)
+	fs.Scratch = pool
+	sfs.Scratch = pool
+	encoder.Fs.Scratch = pool
+
+	prover := &ParametrizedProver{
+		Encoder:         encoder,
+		KzgConfig:       g.KzgConfig,
+		Srs:             g.Srs,
+		G2Trailing:      g.G2Trailing,
+		G1Table:         g.G1Table,
+		CommitmentCache: g.CommitmentCache,
+		Fs:              fs,
+		Ks:              ks,
+		SFs:             sfs,
+		FFTPointsT:      fftPointsT,
+	}
+
+	err = prover.tuneProofAlgorithm()
+	if err != nil {
+		log.Println("Could not tune proof algorithm:", err)
+		return nil, err
+	}
+	return prover, nil
 }
 
 // Detect the precomputed table from the specified directory
@@ -292,17 +407,27 @@ func GetAllPrecomputedSrsMap(tableDir string) ([]encoding.EncodingParams, error)
 // Decode takes in the chunks, indices, and encoding parameters and returns the decoded blob
 // The result is trimmed to the given maxInputSize.
 func (p *Prover) Decode(chunks []*encoding.Frame, indices []encoding.ChunkNumber, params encoding.EncodingParams, maxInputSize uint64) ([]byte, error) {
+	encoder, err := p.GetKzgEncoder(params)
+	if err != nil {
+		return nil, err
+	}
 	frames := make([]encoding.Frame, len(chunks))
 	for i := range chunks {
+		coeffs := chunks[i].Coeffs
+		if chunks[i].Form != encoding.CoeffChunkForm {
+			if i >= len(indices) {
+				return nil, fmt.Errorf("no index for chunk %d", i)
+			}
+			coeffs, err = encoder.ChunkCoeffs(chunks[i], indices[i])
+			if err != nil {
+				return nil, err
+			}
+		}
 		frames[i] = encoding.Frame{
 			Proof:  chunks[i].Proof,
-			Coeffs: chunks[i].Coeffs,
+			Coeffs: coeffs,
 		}
 	}
-	encoder, err := p.GetKzgEncoder(params)
-	if err != nil {
-		return nil, err
-	}
 
 	return encoder.Decode(frames, toUint64Array(indices), maxInputSize)
 }
@@ -3,6 +3,7 @@ package prover_test
 import (
 	cryptorand "crypto/rand"
 	"log"
+	"math"
 	"math/rand"
 	"os"
 	"runtime"
@@ -111,6 +112,192 @@ func TestEncoder(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, decoded)
 }
 
+func TestEncoderScratchPool(t *testing.T) {
+
+	unpooledConfig := *kzgConfig
+	unpooledConfig.DisableScratchPool = true
+
+	p, _ := prover.NewProver(kzgConfig, true)
+	unpooled, _ := prover.NewProver(&unpooledConfig, true)
+	v, _ := verifier.NewVerifier(kzgConfig, true)
+
+	params := encoding.ParamsFromMins(5, 5)
+	expectedCommitments, expectedChunks, err := unpooled.EncodeAndProve(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	// encode repeatedly so that later calls reuse the buffers released by earlier ones
+	for i := 0; i < 3; i++ {
+		commitments, chunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCommitments, commitments)
+		assert.Equal(t, expectedChunks, chunks)
+
+		indices := make([]encoding.ChunkNumber, len(chunks))
+		for j := range indices {
+			indices[j] = encoding.ChunkNumber(j)
+		}
+		err = v.VerifyFrames(chunks, indices, commitments, params)
+		assert.NoError(t, err)
+	}
+}
+
+func TestEncoderBatch(t *testing.T) {
+
+	p, _ := prover.NewProver(kzgConfig, true)
+
+	shortBlob := gettysburgAddressBytes[:64]
+	data := [][]byte{gettysburgAddressBytes, shortBlob, gettysburgAddressBytes}
+	params := []encoding.EncodingParams{
+		encoding.ParamsFromMins(5, 5),
+		encoding.ParamsFromMins(4, 4),
+		encoding.ParamsFromMins(8, 16),
+	}
+
+	commitments, chunks, err := p.EncodeAndProveBatch(data, params)
+	assert.NoError(t, err)
+	assert.Len(t, commitments, len(data))
+	assert.Len(t, chunks, len(data))
+
+	// the blobs are encoded as if they were encoded one at a time
+	for i := range data {
+		expectedCommitments, expectedChunks, err := p.EncodeAndProve(data[i], params[i])
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCommitments, commitments[i])
+		assert.Equal(t, expectedChunks, chunks[i])
+	}
+
+	// a blob that doesn't fit its parameters fails the batch
+	params[2] = encoding.ParamsFromMins(1, 1)
+	_, _, err = p.EncodeAndProveBatch(data, params)
+	assert.ErrorContains(t, err, "blob 2")
+
+	_, _, err = p.EncodeAndProveBatch(data, params[:1])
+	assert.Error(t, err)
+
+	commitments, chunks, err = p.EncodeAndProveBatch(nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, commitments)
+	assert.Empty(t, chunks)
+}
+
+func TestEncoderMSMTable(t *testing.T) {
+
+	tableConfig := *kzgConfig
+	tableConfig.MSMTableDir = t.TempDir()
+	tableConfig.MSMTableWindow = 8
+
+	p, _ := prover.NewProver(kzgConfig, true)
+	v, _ := verifier.NewVerifier(kzgConfig, true)
+
+	params := encoding.ParamsFromMins(5, 5)
+	expectedCommitments, expectedChunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	indices := make([]encoding.ChunkNumber, len(expectedChunks))
+	for j := range indices {
+		indices[j] = encoding.ChunkNumber(j)
+	}
+	expectedPartialChunks, err := p.EncodeChunks(gettysburgAddressBytes, params, indices[1:3])
+	assert.NoError(t, err)
+
+	// the first prover precomputes and persists the table, the second one maps it
+	for i := 0; i < 2; i++ {
+		tableProver, err := prover.NewProver(&tableConfig, true)
+		assert.NoError(t, err)
+		assert.NotNil(t, tableProver.G1Table)
+
+		commitments, chunks, err := tableProver.EncodeAndProve(gettysburgAddressBytes, params)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCommitments, commitments)
+		assert.Equal(t, expectedChunks, chunks)
+
+		err = v.VerifyFrames(chunks, indices, commitments, params)
+		assert.NoError(t, err)
+
+		partialChunks, err := tableProver.EncodeChunks(gettysburgAddressBytes, params, indices[1:3])
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPartialChunks, partialChunks)
+	}
+}
+
+func TestEncodeChunks(t *testing.T) {
+
+	p, _ := prover.NewProver(kzgConfig, true)
+	v, _ := verifier.NewVerifier(kzgConfig, true)
+
+	params := encoding.ParamsFromMins(5, 5)
+	commitments, expectedChunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	indices := []encoding.ChunkNumber{6, 1, 3}
+	chunks, err := p.EncodeChunks(gettysburgAddressBytes, params, indices)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, len(indices))
+	for i, index := range indices {
+		assert.Equal(t, expectedChunks[index], chunks[i])
+	}
+
+	err = v.VerifyFrames(chunks, indices, commitments, params)
+	assert.NoError(t, err)
+
+	_, err = p.EncodeChunks(gettysburgAddressBytes, params, []encoding.ChunkNumber{encoding.ChunkNumber(len(expectedChunks))})
+	assert.Error(t, err)
+}
+
+func TestEncoderProofAlgorithm(t *testing.T) {
+
+	p, _ := prover.NewProver(kzgConfig, true)
+	v, _ := verifier.NewVerifier(kzgConfig, true)
+
+	params := encoding.ParamsFromMins(5, 5)
+	expectedCommitments, expectedChunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	indices := make([]encoding.ChunkNumber, len(expectedChunks))
+	for j := range indices {
+		indices[j] = encoding.ChunkNumber(j)
+	}
+
+	// all the algorithms compute the same chunks
+	for _, algorithm := range []string{kzg.FK20ProofAlgorithm, kzg.NaiveProofAlgorithm, kzg.AutoProofAlgorithm} {
+		algorithmConfig := *kzgConfig
+		algorithmConfig.ProofAlgorithm = algorithm
+		algorithmProver, err := prover.NewProver(&algorithmConfig, true)
+		assert.NoError(t, err)
+
+		enc, err := algorithmProver.GetKzgEncoder(params)
+		assert.NoError(t, err)
+		switch algorithm {
+		case kzg.FK20ProofAlgorithm:
+			assert.Equal(t, -1, enc.NaiveProofMaxLength)
+		case kzg.NaiveProofAlgorithm:
+			assert.Equal(t, math.MaxInt, enc.NaiveProofMaxLength)
+		}
+
+		commitments, chunks, err := algorithmProver.EncodeAndProve(gettysburgAddressBytes, params)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCommitments, commitments)
+		assert.Equal(t, expectedChunks, chunks)
+
+		err = v.VerifyFrames(chunks, indices, commitments, params)
+		assert.NoError(t, err)
+
+		batchCommitments, batchChunks, err := algorithmProver.EncodeAndProveBatch([][]byte{gettysburgAddressBytes}, []encoding.EncodingParams{params})
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCommitments, batchCommitments[0])
+		assert.Equal(t, expectedChunks, batchChunks[0])
+
+		// a blob that doesn't fit its parameters is still rejected
+		_, _, err = algorithmProver.EncodeAndProve(gettysburgAddressBytes, encoding.ParamsFromMins(1, 1))
+		assert.Error(t, err)
+	}
+
+	invalidConfig := *kzgConfig
+	invalidConfig.ProofAlgorithm = "fft"
+	_, err = prover.NewProver(&invalidConfig, true)
+	assert.ErrorContains(t, err, "unknown proof algorithm")
+}
+
 // Ballpark number for 400KiB blob encoding
 //
 // goos: darwin
@@ -142,3 +329,69 @@ func BenchmarkEncode(b *testing.B) {
 		_, _, _ = p.EncodeAndProve(blobs[i%numSamples], params)
 	}
 }
+
+func TestEncoderChunkForm(t *testing.T) {
+
+	p, _ := prover.NewProver(kzgConfig, true)
+	v, _ := verifier.NewVerifier(kzgConfig, true)
+
+	params := encoding.ParamsFromMins(5, 5)
+	expectedCommitments, coeffChunks, err := p.EncodeAndProve(gettysburgAddressBytes, params)
+	assert.NoError(t, err)
+
+	indices := make([]encoding.ChunkNumber, len(coeffChunks))
+	for j := range indices {
+		indices[j] = encoding.ChunkNumber(j)
+	}
+	maxInputSize := uint64(len(gettysburgAddressBytes))
+
+	for _, form := range []encoding.ChunkForm{encoding.EvalChunkForm, encoding.CoeffAndEvalChunkForm} {
+		formConfig := *kzgConfig
+		formConfig.ChunkForm = form.String()
+		formProver, err := prover.NewProver(&formConfig, true)
+		assert.NoError(t, err)
+
+		commitments, chunks, err := formProver.EncodeAndProve(gettysburgAddressBytes, params)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedCommitments, commitments)
+		for i, chunk := range chunks {
+			assert.Equal(t, form, chunk.Form)
+			assert.Equal(t, int(params.ChunkLength), chunk.Length())
+			assert.Equal(t, coeffChunks[i].Proof, chunk.Proof)
+			if form.HasCoeffs() {
+				assert.Equal(t, coeffChunks[i].Coeffs, chunk.Coeffs)
+			} else {
+				assert.Nil(t, chunk.Coeffs)
+			}
+		}
+
+		err = v.VerifyFrames(chunks, indices, commitments, params)
+		assert.NoError(t, err)
+		err = v.UniversalVerifySubBatch(params, []encoding.Sample{{Commitment: commitments.Commitment, Chunk: chunks[1], AssignmentIndex: 1}}, 1)
+		assert.NoError(t, err)
+
+		decoded, err := v.Decode(chunks, indices, params, maxInputSize)
+		assert.NoError(t, err)
+		assert.Equal(t, gettysburgAddressBytes, decoded)
+
+		encodedChunks, err := formProver.EncodeChunks(gettysburgAddressBytes, params, []encoding.ChunkNumber{3})
+		assert.NoError(t, err)
+		assert.Equal(t, chunks[3], encodedChunks[0])
+
+		_, batchChunks, err := formProver.EncodeAndProveBatch([][]byte{gettysburgAddressBytes}, []encoding.EncodingParams{params})
+		assert.NoError(t, err)
+		assert.Equal(t, chunks, batchChunks[0])
+
+		// an evaluation that was tampered with is rejected
+		tampered := *chunks[2]
+		tampered.Evals = append([]encoding.Symbol{}, chunks[2].Evals...)
+		tampered.Evals[0].SetOne()
+		err = v.VerifyFrames([]*encoding.Frame{&tampered}, []encoding.ChunkNumber{2}, commitments, params)
+		assert.Error(t, err)
+	}
+
+	invalidConfig := *kzgConfig
+	invalidConfig.ChunkForm = "lagrange"
+	_, err = prover.NewProver(&invalidConfig, true)
+	assert.ErrorIs(t, err, encoding.ErrInvalidChunkForm)
+}
@@ -0,0 +1,227 @@
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"time"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// A shared SRS segment file starts with a header of sharedSRSHeaderSize bytes:
+//
+//	magic (8 bytes) | byte order mark (8 bytes) | point size (8 bytes) | number of points (8 bytes) | fingerprint (32 bytes)
+//
+// followed by the in-memory representation of the points, so that the processes of a host mapping the file share
+// the physical pages of the points rather than each decompressing its own copy of the SRS. The fingerprint is the
+// SHA-256 of the compressed points the segment was decompressed from.
+const (
+	sharedSRSMagic         = "EDASRS01"
+	sharedSRSByteOrderMark = uint64(0x0102030405060708)
+	sharedSRSHeaderSize    = 64
+)
+
+// ErrInvalidSharedSRS is returned when a shared SRS segment is corrupted, or was decompressed from different points
+var ErrInvalidSharedSRS = errors.New("invalid shared srs segment")
+
+// SharedSRSFileName returns the name of the file holding the shared segment of the points of the SRS in [from, to)
+func SharedSRSFileName(group string, from, to uint64) string {
+	return fmt.Sprintf("%s.%v-%v.srs", group, from, to)
+}
+
+// ReadSharedG1PointSection returns the G1 points of the SRS file in [from, to), memory mapped read-only from their
+// shared segment in dir. If there is no segment for the points yet, or the SRS file changed, the points are read
+// from the SRS file and the segment is written to dir. The points must not be modified.
+func ReadSharedG1PointSection(dir, filepath string, from, to uint64, numWorker uint64) ([]bn254.G1Affine, error) {
+	return readSharedPointSection(dir, "g1", filepath, from, to, G1PointBytes, func() ([]bn254.G1Affine, error) {
+		return ReadG1PointSection(filepath, from, to, numWorker)
+	})
+}
+
+// ReadSharedG2PointSection is the G2 equivalent of ReadSharedG1PointSection
+func ReadSharedG2PointSection(dir, filepath string, from, to uint64, numWorker uint64) ([]bn254.G2Affine, error) {
+	return readSharedPointSection(dir, "g2", filepath, from, to, G2PointBytes, func() ([]bn254.G2Affine, error) {
+		return ReadG2PointSection(filepath, from, to, numWorker)
+	})
+}
+
+// LoadG1PointSection returns the G1 points of the SRS in [from, to), from their shared segment if
+// config.SharedSRSDir is set
+func LoadG1PointSection(config *KzgConfig, from, to uint64) ([]bn254.G1Affine, error) {
+	switch {
+	case config.SharedSRSDir != "":
+		return ReadSharedG1PointSection(config.SharedSRSDir, config.G1Path, from, to, config.NumWorker)
+	case from == 0:
+		return ReadG1Points(config.G1Path, to, config.NumWorker)
+	default:
+		return ReadG1PointSection(config.G1Path, from, to, config.NumWorker)
+	}
+}
+
+// LoadG2PointSection returns the G2 points of the SRS in [from, to), from their shared segment if
+// config.SharedSRSDir is set
+func LoadG2PointSection(config *KzgConfig, from, to uint64) ([]bn254.G2Affine, error) {
+	switch {
+	case config.SharedSRSDir != "":
+		return ReadSharedG2PointSection(config.SharedSRSDir, config.G2Path, from, to, config.NumWorker)
+	case from == 0:
+		return ReadG2Points(config.G2Path, to, config.NumWorker)
+	default:
+		return ReadG2PointSection(config.G2Path, from, to, config.NumWorker)
+	}
+}
+
+func readSharedPointSection[T any](
+	dir, group, filepath string,
+	from, to uint64,
+	compressedPointSize uint64,
+	read func() ([]T, error),
+) ([]T, error) {
+	if to <= from {
+		return nil, fmt.Errorf("the range to read is invalid, from: %v, to: %v", from, to)
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create shared srs directory: %w", err)
+	}
+
+	start := time.Now()
+	fingerprint, err := compressedPointsFingerprint(filepath, from*compressedPointSize, (to-from)*compressedPointSize)
+	if err != nil {
+		return nil, err
+	}
+
+	segmentPath := path.Join(dir, SharedSRSFileName(group, from, to))
+	points, err := mapSharedPoints[T](segmentPath, fingerprint, to-from)
+	if err == nil {
+		log.Printf("Mapped shared %v srs segment %v in %v\n", group, segmentPath, time.Since(start))
+		return points, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Discarding shared %v srs segment %v: %v\n", group, segmentPath, err)
+	}
+
+	points, err = read()
+	if err != nil {
+		return nil, err
+	}
+
+	// The points are still usable if the segment can't be written, they just aren't shared
+	if err := writeSharedPoints(segmentPath, fingerprint, points); err != nil {
+		log.Printf("Failed to write shared %v srs segment %v: %v\n", group, segmentPath, err)
+		return points, nil
+	}
+	shared, err := mapSharedPoints[T](segmentPath, fingerprint, to-from)
+	if err != nil {
+		log.Printf("Failed to map shared %v srs segment %v: %v\n", group, segmentPath, err)
+		return points, nil
+	}
+	return shared, nil
+}
+
+// compressedPointsFingerprint hashes the size bytes of the SRS file at offset
+func compressedPointsFingerprint(filepath string, offset, size uint64) ([32]byte, error) {
+	var fingerprint [32]byte
+	f, err := os.Open(filepath)
+	if err != nil {
+		return fingerprint, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.CopyN(h, io.NewSectionReader(f, int64(offset), int64(size)), int64(size)); err != nil {
+		return fingerprint, fmt.Errorf("failed to read srs points of %v: %w", filepath, err)
+	}
+	copy(fingerprint[:], h.Sum(nil))
+	return fingerprint, nil
+}
+
+// mapSharedPoints memory maps the segment at segmentPath. It returns ErrInvalidSharedSRS if the file doesn't hold
+// numPoints points decompressed from the points with the given fingerprint.
+func mapSharedPoints[T any](segmentPath string, fingerprint [32]byte, numPoints uint64) ([]T, error) {
+	pointSize := uint64(unsafe.Sizeof(*new(T)))
+
+	f, err := os.Open(segmentPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var header [sharedSRSHeaderSize]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		return nil, fmt.Errorf("%w: failed to read header: %v", ErrInvalidSharedSRS, err)
+	}
+	if string(header[:8]) != sharedSRSMagic {
+		return nil, fmt.Errorf("%w: unknown file format", ErrInvalidSharedSRS)
+	}
+	if binary.NativeEndian.Uint64(header[8:16]) != sharedSRSByteOrderMark {
+		return nil, fmt.Errorf("%w: written with a different byte order", ErrInvalidSharedSRS)
+	}
+	if s := binary.NativeEndian.Uint64(header[16:24]); s != pointSize {
+		return nil, fmt.Errorf("%w: point size %v, expected %v", ErrInvalidSharedSRS, s, pointSize)
+	}
+	if n := binary.NativeEndian.Uint64(header[24:32]); n != numPoints {
+		return nil, fmt.Errorf("%w: %v points, expected %v", ErrInvalidSharedSRS, n, numPoints)
+	}
+	if [32]byte(header[32:64]) != fingerprint {
+		return nil, fmt.Errorf("%w: decompressed from different points", ErrInvalidSharedSRS)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := int64(sharedSRSHeaderSize + numPoints*pointSize)
+	if info.Size() != size {
+		return nil, fmt.Errorf("%w: file size %v, expected %v", ErrInvalidSharedSRS, info.Size(), size)
+	}
+
+	// The mapping lives as long as the process, as the provers and verifiers keep their SRS until they exit
+	mapping, err := mapFile(f, int(size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to map shared srs segment: %w", err)
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(&mapping[sharedSRSHeaderSize])), numPoints), nil
+}
+
+// writeSharedPoints persists the points to segmentPath. The file is replaced atomically, so that the processes
+// starting concurrently never map a partial segment.
+func writeSharedPoints[T any](segmentPath string, fingerprint [32]byte, points []T) error {
+	pointSize := uint64(unsafe.Sizeof(*new(T)))
+
+	var header [sharedSRSHeaderSize]byte
+	copy(header[:8], sharedSRSMagic)
+	binary.NativeEndian.PutUint64(header[8:16], sharedSRSByteOrderMark)
+	binary.NativeEndian.PutUint64(header[16:24], pointSize)
+	binary.NativeEndian.PutUint64(header[24:32], uint64(len(points)))
+	copy(header[32:64], fingerprint[:])
+
+	f, err := os.CreateTemp(path.Dir(segmentPath), path.Base(segmentPath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(header[:]); err != nil {
+		f.Close()
+		return err
+	}
+	if len(points) > 0 {
+		data := unsafe.Slice((*byte)(unsafe.Pointer(&points[0])), uint64(len(points))*pointSize)
+		if _, err := f.Write(data); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), segmentPath)
+}
@@ -0,0 +1,71 @@
+package kzg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testG1Path = "../../inabox/resources/kzg/g1.point"
+	testG2Path = "../../inabox/resources/kzg/g2.point"
+)
+
+func TestReadSharedPointSection(t *testing.T) {
+	dir := t.TempDir()
+
+	expectedG1, err := kzg.ReadG1PointSection(testG1Path, 16, 80, 4)
+	require.NoError(t, err)
+	g1, err := kzg.ReadSharedG1PointSection(dir, testG1Path, 16, 80, 4)
+	require.NoError(t, err)
+	assert.Equal(t, expectedG1, g1)
+
+	expectedG2, err := kzg.ReadG2PointSection(testG2Path, 0, 32, 4)
+	require.NoError(t, err)
+	g2, err := kzg.ReadSharedG2PointSection(dir, testG2Path, 0, 32, 4)
+	require.NoError(t, err)
+	assert.Equal(t, expectedG2, g2)
+
+	// Other processes map the segments written by the first one
+	segmentPath := filepath.Join(dir, kzg.SharedSRSFileName("g1", 16, 80))
+	info, err := os.Stat(segmentPath)
+	require.NoError(t, err)
+	g1, err = kzg.ReadSharedG1PointSection(dir, testG1Path, 16, 80, 4)
+	require.NoError(t, err)
+	assert.Equal(t, expectedG1, g1)
+	reloaded, err := os.Stat(segmentPath)
+	require.NoError(t, err)
+	assert.Equal(t, info.ModTime(), reloaded.ModTime())
+
+	// A corrupted segment is written again
+	require.NoError(t, os.WriteFile(segmentPath, []byte("corrupted"), 0644))
+	g1, err = kzg.ReadSharedG1PointSection(dir, testG1Path, 16, 80, 4)
+	require.NoError(t, err)
+	assert.Equal(t, expectedG1, g1)
+	reloaded, err = os.Stat(segmentPath)
+	require.NoError(t, err)
+	assert.Greater(t, reloaded.Size(), int64(len("corrupted")))
+
+	// A segment of points beyond the end of the SRS file isn't written
+	_, err = kzg.ReadSharedG1PointSection(dir, testG1Path, 0, 1<<40, 4)
+	assert.Error(t, err)
+	_, err = kzg.ReadSharedG1PointSection(dir, testG1Path, 8, 8, 4)
+	assert.Error(t, err)
+}
+
+func TestLoadPointSection(t *testing.T) {
+	config := &kzg.KzgConfig{G1Path: testG1Path, G2Path: testG2Path, NumWorker: 4}
+	expected, err := kzg.LoadG1PointSection(config, 0, 64)
+	require.NoError(t, err)
+
+	config.SharedSRSDir = t.TempDir()
+	shared, err := kzg.LoadG1PointSection(config, 0, 64)
+	require.NoError(t, err)
+	assert.Equal(t, expected, shared)
+	_, err = os.Stat(filepath.Join(config.SharedSRSDir, kzg.SharedSRSFileName("g1", 0, 64)))
+	assert.NoError(t, err)
+}
@@ -25,9 +25,19 @@
 package kzg
 
 import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/Layr-Labs/eigenda/encoding/fft"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
 
+// ErrLagrangeG1Mismatch is returned by CheckLagrangeG1 when the Lagrange G1 SRS wasn't computed from the G1 SRS
+var ErrLagrangeG1Mismatch = errors.New("the Lagrange G1 SRS doesn't match the G1 SRS")
+
 type SRS struct {
 
 	// [b.multiply(b.G1, pow(s, i, MODULUS)) for i in range(WIDTH+1)],
@@ -43,3 +53,54 @@ func NewSrs(G1 []bn254.G1Affine, G2 []bn254.G2Affine) (*SRS, error) {
 		G2: G2,
 	}, nil
 }
+
+// ComputeLagrangeG1 returns the G1 SRS in Lagrange form over the domain of the len(g1)-th roots of unity, from the
+// first points of the G1 SRS in monomial form. The i-th point commits to the polynomial evaluating to 1 at the i-th
+// root of unity and to 0 at the others, so that the commitment of a polynomial given as its evaluations over the
+// domain is the multi-scalar multiplication of the evaluations with these points, without an IFFT of the
+// evaluations. len(g1) must be a power of two.
+func ComputeLagrangeG1(g1 []bn254.G1Affine) ([]bn254.G1Affine, error) {
+	n := uint64(len(g1))
+	if n == 0 || !fft.IsPowerOfTwo(n) {
+		return nil, fmt.Errorf("the number of G1 points must be a power of two, got %d", n)
+	}
+	fs := fft.NewFFTSettings(uint8(bits.TrailingZeros64(n)))
+	return fs.FFTG1(g1, true)
+}
+
+// CheckLagrangeG1 checks that lagrangeG1 is the G1 SRS in Lagrange form computed by ComputeLagrangeG1 from the first
+// len(lagrangeG1) points of g1. Rather than computing it again, it commits to a polynomial given by random
+// evaluations over the domain with both SRS, which only agree for a mismatched SRS with negligible probability.
+func CheckLagrangeG1(g1, lagrangeG1 []bn254.G1Affine) error {
+	n := uint64(len(lagrangeG1))
+	if n == 0 || !fft.IsPowerOfTwo(n) {
+		return fmt.Errorf("the number of Lagrange G1 points must be a power of two, got %d", n)
+	}
+	if uint64(len(g1)) < n {
+		return fmt.Errorf("got %d G1 points to check %d Lagrange G1 points", len(g1), n)
+	}
+
+	evals := make([]fr.Element, n)
+	for i := range evals {
+		if _, err := evals[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+	coeffs, err := fft.NewFFTSettings(uint8(bits.TrailingZeros64(n))).FFT(evals, true)
+	if err != nil {
+		return err
+	}
+
+	expected, err := msm.MultiExpG1(g1[:n], coeffs)
+	if err != nil {
+		return err
+	}
+	commit, err := msm.MultiExpG1(lagrangeG1, evals)
+	if err != nil {
+		return err
+	}
+	if !commit.Equal(&expected) {
+		return ErrLagrangeG1Mismatch
+	}
+	return nil
+}
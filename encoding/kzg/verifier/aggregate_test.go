@@ -0,0 +1,52 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAggregatedFrames(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, true)
+	require.NoError(t, err)
+	v, err := verifier.NewVerifier(kzgConfig, false)
+	require.NoError(t, err)
+
+	data := codec.ConvertByPaddingEmptyByte(gettysburgAddressBytes)
+	// Chunks much smaller than the blob, so that the aggregated proofs aren't trivial
+	params := encoding.ParamsFromMins(4, 32)
+	commitments, frames, err := p.EncodeAndProve(data, params)
+	require.NoError(t, err)
+
+	indices := []encoding.ChunkNumber{0, 3, 2}
+	selected := []*encoding.Frame{frames[0], frames[3], frames[2]}
+	proof, err := p.ProveAggregated(data, params, indices)
+	require.NoError(t, err)
+	assert.NoError(t, v.VerifyAggregatedFrames(selected, indices, commitments, params, proof))
+
+	// A single frame's aggregated proof is its individual proof
+	single, err := p.ProveAggregated(data, params, []encoding.ChunkNumber{1})
+	require.NoError(t, err)
+	assert.Equal(t, frames[1].Proof, *single)
+
+	// The proof only holds for its indices
+	assert.Error(t, v.VerifyAggregatedFrames(selected, []encoding.ChunkNumber{0, 3, 1}, commitments, params, proof))
+
+	assert.Error(t, v.VerifyAggregatedFrames(selected[:2], indices[:2], commitments, params, proof))
+
+	// Tampered frames are rejected
+	tampered := *frames[3]
+	tampered.Coeffs = append([]encoding.Symbol{}, frames[3].Coeffs...)
+	tampered.Coeffs[0].SetUint64(42)
+	assert.ErrorIs(t, v.VerifyAggregatedFrames([]*encoding.Frame{frames[0], &tampered, frames[2]}, indices, commitments, params, proof), encoding.ErrProofInvalid)
+
+	// Duplicate indices can't be aggregated
+	_, err = p.ProveAggregated(data, params, []encoding.ChunkNumber{1, 1})
+	assert.Error(t, err)
+	assert.Error(t, v.VerifyAggregatedFrames([]*encoding.Frame{frames[1], frames[1]}, []encoding.ChunkNumber{1, 1}, commitments, params, proof))
+}
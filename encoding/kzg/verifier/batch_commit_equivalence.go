@@ -6,6 +6,8 @@ import (
 
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
+	"github.com/Layr-Labs/eigenda/encoding/transcript"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -50,6 +52,25 @@ func CreateRandomnessVector(n int) ([]fr.Element, error) {
 	return randomsFr, nil
 }
 
+// CreateChallengeVector returns the powers r, r^2, ..., r^n of a challenge r squeezed from the transcript. Unlike
+// CreateRandomnessVector, the batched checks are then deterministic, so they can be reproduced and audited.
+func CreateChallengeVector(t *transcript.Transcript, label string, n int) ([]fr.Element, error) {
+	if n <= 0 {
+		return nil, errors.New("the length of vector must be positive")
+	}
+	r, err := t.ChallengeScalar(label)
+	if err != nil {
+		return nil, err
+	}
+
+	challenges := make([]fr.Element, n)
+	challenges[0].Set(&r)
+	for j := 0; j < n-1; j++ {
+		challenges[j+1].Mul(&challenges[j], &r)
+	}
+	return challenges, nil
+}
+
 func (v *Verifier) VerifyCommitEquivalenceBatch(commitments []encoding.BlobCommitments) error {
 	commitmentsPair := make([]CommitmentPair, len(commitments))
 
@@ -71,13 +92,23 @@ func (group *Verifier) BatchVerifyCommitEquivalence(commitmentsPair []Commitment
 		g2commits[i] = commitmentsPair[i].LengthCommitment
 	}
 
-	randomsFr, err := CreateRandomnessVector(len(g1commits))
+	// The challenge is bound to every commitment pair
+	t, err := transcript.New(kzg.BatchVerificationDST)
+	if err != nil {
+		return err
+	}
+	t.AppendBytes("protocol", []byte("commitment equivalence"))
+	t.AppendUint64("pairs", uint64(len(commitmentsPair)))
+	for i := range commitmentsPair {
+		t.AppendG1("commitment", &g1commits[i])
+		t.AppendG2("length commitment", &g2commits[i])
+	}
+	randomsFr, err := CreateChallengeVector(t, "equivalence", len(g1commits))
 	if err != nil {
 		return err
 	}
 
-	var lhsG1 bn254.G1Affine
-	_, err = lhsG1.MultiExp(g1commits, randomsFr, ecc.MultiExpConfig{})
+	lhsG1, err := msm.MultiExpG1(g1commits, randomsFr)
 	if err != nil {
 		return err
 	}
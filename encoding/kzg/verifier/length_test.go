@@ -36,3 +36,33 @@ func TestLengthProof(t *testing.T) {
 		assert.Error(t, v.VerifyCommit(legnthCommitment, legnthProof, uint64(length)), "low degree verification failed\n")
 	}
 }
+
+func TestLengthProofWithPowerOf2G2(t *testing.T) {
+
+	group, err := prover.NewProver(kzgConfig, true)
+	require.NoError(t, err)
+
+	// the verifier of the operators doesn't load the full G2 SRS
+	kzgConfigCopy := *kzgConfig
+	kzgConfigCopy.G2Path = ""
+	v, err := verifier.NewVerifier(&kzgConfigCopy, false)
+	require.NoError(t, err)
+
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(gettysburgAddressBytes)))
+	enc, err := group.GetKzgEncoder(params)
+	require.NoError(t, err)
+
+	inputFr, err := rs.ToFrArray(gettysburgAddressBytes)
+	require.NoError(t, err)
+	commit, lengthCommitment, lengthProof, frames, _, err := enc.Encode(inputFr)
+	require.NoError(t, err)
+
+	length := uint64(len(inputFr))
+	assert.NoError(t, v.VerifyCommit(lengthCommitment, lengthProof, length))
+	assert.ErrorIs(t, v.VerifyCommit(lengthCommitment, lengthProof, length-1), encoding.ErrProofInvalid)
+	assert.Error(t, v.VerifyCommit(lengthCommitment, lengthProof, 0))
+
+	pv, err := v.GetKzgVerifier(params)
+	require.NoError(t, err)
+	assert.NoError(t, pv.VerifyFrame(commit, &frames[0], 0))
+}
@@ -3,14 +3,14 @@ package verifier
 import (
 	"errors"
 	"fmt"
-	"math"
 	"math/big"
 
 	"github.com/Layr-Labs/eigenda/encoding"
 
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
-	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/Layr-Labs/eigenda/encoding/transcript"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
@@ -26,9 +26,12 @@ type Sample struct {
 }
 
 // the rhsG1 consists of three terms, see https://ethresear.ch/t/a-universal-verification-equation-for-data-availability-sampling/13240/1
-func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.EncodingParams, ks *kzg.KZGSettings, proofs []bn254.G1Affine) (*bn254.G1Affine, error) {
+func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.EncodingParams, verifier *ParametrizedVerifier, proofs []bn254.G1Affine) (*bn254.G1Affine, error) {
+	ks := verifier.Ks
+	frScratch := verifier.Fs.Scratch
 	n := len(samples)
-	commits := make([]bn254.G1Affine, m)
+	commits := verifier.G1Scratch.Get(m)
+	defer verifier.G1Scratch.Put(commits)
 	D := params.ChunkLength
 
 	var tmp fr.Element
@@ -37,7 +40,8 @@ func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.E
 	// get coeffs to compute the aggregated commitment
 	// note the coeff is affected by how many chunks are validated per blob
 	// if x chunks are sampled from one blob, we need to compute the sum of all x random field element corresponding to each sample
-	aggCommitCoeffs := make([]fr.Element, m)
+	aggCommitCoeffs := frScratch.Get(m)
+	defer frScratch.Put(aggCommitCoeffs)
 	setCommit := make([]bool, m)
 	for k := 0; k < n; k++ {
 		s := samples[k]
@@ -57,15 +61,15 @@ func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.E
 		}
 	}
 
-	var aggCommit bn254.G1Affine
-	_, err := aggCommit.MultiExp(commits, aggCommitCoeffs, ecc.MultiExpConfig{})
+	aggCommit, err := msm.MultiExpG1(commits, aggCommitCoeffs)
 	if err != nil {
 		return nil, err
 	}
 
 	// second term
 	// compute the aggregated interpolation polynomial
-	aggPolyCoeffs := make([]fr.Element, D)
+	aggPolyCoeffs := frScratch.Get(int(D))
+	defer frScratch.Put(aggPolyCoeffs)
 
 	// we sum over the weighted coefficients (by the random field element) over all D monomial in all n samples
 	for k := 0; k < n; k++ {
@@ -84,18 +88,19 @@ func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.E
 	}
 
 	// All samples in a subBatch has identical chunkLen
-	var aggPolyG1 bn254.G1Affine
-	_, err = aggPolyG1.MultiExp(ks.Srs.G1[:D], aggPolyCoeffs, ecc.MultiExpConfig{})
+	aggPolyG1, err := msm.MultiExpG1(ks.Srs.G1, aggPolyCoeffs)
 	if err != nil {
 		return nil, err
 	}
 
 	// third term
 	// leading coset is an evaluation index, here we compute the weighted leading coset evaluation by random fields
-	lcCoeffs := make([]fr.Element, n)
+	lcCoeffs := frScratch.Get(n)
+	defer frScratch.Put(lcCoeffs)
 
 	// get leading coset powers
-	leadingDs := make([]fr.Element, n)
+	leadingDs := frScratch.Get(n)
+	defer frScratch.Put(leadingDs)
 	bigD := big.NewInt(int64(D))
 
 	for k := 0; k < n; k++ {
@@ -114,8 +119,7 @@ func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.E
 		lcCoeffs[k].Mul(&rk, &leadingDs[k])
 	}
 
-	var offsetG1 bn254.G1Affine
-	_, err = offsetG1.MultiExp(proofs, lcCoeffs, ecc.MultiExpConfig{})
+	offsetG1, err := msm.MultiExpG1(proofs, lcCoeffs)
 	if err != nil {
 		return nil, err
 	}
@@ -130,6 +134,16 @@ func genRhsG1(samples []Sample, randomsFr []fr.Element, m int, params encoding.E
 
 // TODO(mooselumph): Cleanup this function
 func (v *Verifier) UniversalVerifySubBatch(params encoding.EncodingParams, samplesCore []encoding.Sample, numBlobs int) error {
+	for i, sc := range samplesCore {
+		if err := sc.Chunk.Validate(params); err != nil {
+			return fmt.Errorf("invalid frame of sample %d: %w", i, err)
+		}
+	}
+
+	verifier, err := v.GetKzgVerifier(params)
+	if err != nil {
+		return err
+	}
 
 	samples := make([]Sample, len(samplesCore))
 
@@ -142,11 +156,16 @@ func (v *Verifier) UniversalVerifySubBatch(params encoding.EncodingParams, sampl
 			return err
 		}
 
+		coeffs, err := verifier.ChunkCoeffs(sc.Chunk, sc.AssignmentIndex)
+		if err != nil {
+			return err
+		}
+
 		sample := Sample{
 			Commitment: (bn254.G1Affine)(*sc.Commitment),
 			Proof:      sc.Chunk.Proof,
 			RowIndex:   sc.BlobIndex,
-			Coeffs:     sc.Chunk.Coeffs,
+			Coeffs:     coeffs,
 			X:          uint(x),
 		}
 		samples[i] = sample
@@ -176,12 +195,10 @@ func (v *Verifier) UniversalVerify(params encoding.EncodingParams, samples []Sam
 	if err != nil {
 		return err
 	}
-	ks := verifier.Ks
-
 	D := params.ChunkLength
 
 	if D > v.SRSNumberToLoad {
-		return fmt.Errorf("requested chunkLen %v is larger than Loaded SRS points %v", D, v.SRSNumberToLoad)
+		return fmt.Errorf("%w: requested chunkLen %v is larger than Loaded SRS points %v", encoding.ErrSRSOutOfRange, D, v.SRSNumberToLoad)
 	}
 
 	n := len(samples)
@@ -190,14 +207,32 @@ func (v *Verifier) UniversalVerify(params encoding.EncodingParams, samples []Sam
 		return errors.New("the number of samples (i.e. chunks) must not be empty")
 	}
 
-	// generate random field elements to aggregate equality check
-	randomsFr, err := CreateRandomnessVector(n)
+	// derive the field elements to aggregate equality check from every sample, so that they can't be chosen to
+	// cancel out invalid samples
+	t, err := transcript.New(kzg.BatchVerificationDST)
+	if err != nil {
+		return err
+	}
+	t.AppendBytes("protocol", []byte("universal verification"))
+	t.AppendUint64("chunk length", params.ChunkLength)
+	t.AppendUint64("num chunks", params.NumChunks)
+	t.AppendUint64("num blobs", uint64(m))
+	t.AppendUint64("samples", uint64(n))
+	for i := range samples {
+		t.AppendUint64("row", uint64(samples[i].RowIndex))
+		t.AppendG1("commitment", &samples[i].Commitment)
+		t.AppendUint64("coset", uint64(samples[i].X))
+		t.AppendScalars("coeffs", samples[i].Coeffs)
+		t.AppendG1("proof", &samples[i].Proof)
+	}
+	randomsFr, err := CreateChallengeVector(t, "aggregation", n)
 	if err != nil {
 		return err
 	}
 
 	// array of proofs
-	proofs := make([]bn254.G1Affine, n)
+	proofs := verifier.G1Scratch.Get(n)
+	defer verifier.G1Scratch.Put(proofs)
 	for i := 0; i < n; i++ {
 
 		proofs[i].Set(&samples[i].Proof)
@@ -205,22 +240,14 @@ func (v *Verifier) UniversalVerify(params encoding.EncodingParams, samples []Sam
 
 	// lhs g1
 
-	var lhsG1 bn254.G1Affine
-	_, err = lhsG1.MultiExp(proofs, randomsFr, ecc.MultiExpConfig{})
+	lhsG1, err := msm.MultiExpG1(proofs, randomsFr)
 	if err != nil {
 		return err
 	}
 	// lhs g2
-	exponent := uint64(math.Log2(float64(D)))
-	G2atD, err := kzg.ReadG2PointOnPowerOf2(exponent, v.KzgConfig)
-
+	G2atD, err := g2AtPowerOf2(v.G2PowerOf2, D, v.KzgConfig)
 	if err != nil {
-		// then try to access if there is a full list of g2 srs
-		G2atD, err = kzg.ReadG2Point(D, v.KzgConfig)
-		if err != nil {
-			return err
-		}
-		fmt.Println("Accessed the entire G2")
+		return err
 	}
 
 	lhsG2 := &G2atD
@@ -234,7 +261,7 @@ func (v *Verifier) UniversalVerify(params encoding.EncodingParams, samples []Sam
 		randomsFr,
 		m,
 		params,
-		ks,
+		verifier,
 		proofs,
 	)
 	if err != nil {
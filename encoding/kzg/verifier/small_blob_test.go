@@ -0,0 +1,63 @@
+package verifier_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSmallBlobs encodes, verifies and decodes every payload of 1 to 32 bytes with the smallest encoding params,
+// down to a single chunk of a single symbol
+func TestSmallBlobs(t *testing.T) {
+	p, err := prover.NewProver(kzgConfig, true)
+	require.NoError(t, err)
+	v, err := verifier.NewVerifier(kzgConfig, true)
+	require.NoError(t, err)
+
+	for size := 1; size <= 32; size++ {
+		payload := make([]byte, size)
+		for i := range payload {
+			payload[i] = byte(0xff - i)
+		}
+		data := codec.ConvertByPaddingEmptyByte(payload)
+		dataLength := encoding.GetBlobLength(uint(len(data)))
+
+		for _, numChunks := range []uint64{1, 2, 4, 8} {
+			for _, chunkLength := range []uint64{1, 2, 4} {
+				params := encoding.EncodingParams{NumChunks: numChunks, ChunkLength: chunkLength}
+				t.Run(fmt.Sprintf("size=%d/chunks=%d/length=%d", size, numChunks, chunkLength), func(t *testing.T) {
+					commitments, chunks, err := p.EncodeAndProve(data, params)
+					if uint64(dataLength) > params.NumEvaluations() {
+						assert.ErrorIs(t, err, encoding.ErrInvalidParams)
+						return
+					}
+					require.NoError(t, err)
+					require.Len(t, chunks, int(numChunks))
+					assert.Equal(t, dataLength, commitments.Length)
+					assert.NoError(t, v.VerifyBlobLength(commitments))
+
+					indices := make([]encoding.ChunkNumber, numChunks)
+					for i := range indices {
+						indices[i] = encoding.ChunkNumber(i)
+					}
+					assert.NoError(t, v.VerifyFrames(chunks, indices, commitments, params))
+
+					// The data is recovered from the last chunks alone, as few as cover its symbols
+					numSys := encoding.GetNumSys(uint64(len(data)), chunkLength)
+					decoded, err := v.Decode(chunks[numChunks-numSys:], indices[numChunks-numSys:], params, uint64(len(data)))
+					require.NoError(t, err)
+					assert.Equal(t, payload, codec.RemoveEmptyByteFromPaddedBytes(decoded)[:size])
+
+					_, err = v.Decode(chunks[:numSys-1], indices[:numSys-1], params, uint64(len(data)))
+					assert.ErrorIs(t, err, encoding.ErrInsufficientChunks)
+				})
+			}
+		}
+	}
+}
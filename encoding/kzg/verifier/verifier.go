@@ -1,11 +1,13 @@
 package verifier
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"math"
 	"math/big"
+	"math/bits"
 	"runtime"
 	"sync"
 
@@ -13,7 +15,9 @@ import (
 
 	"github.com/Layr-Labs/eigenda/encoding/fft"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/msm"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/scratch"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -22,8 +26,14 @@ import (
 
 type Verifier struct {
 	*kzg.KzgConfig
-	Srs          *kzg.SRS
-	G2Trailing   []bn254.G2Affine
+	Srs        *kzg.SRS
+	G2Trailing []bn254.G2Affine
+	// G1Trailing are the last SRSNumberToLoad points of the G1 SRS, which are the challenges of the length
+	// proofs of blobs of up to SRSNumberToLoad symbols
+	G1Trailing []bn254.G1Affine
+	// G2PowerOf2 are the G2 points of the SRS at the powers of 2, which the frames are verified with. It's empty
+	// if G2PowerOf2Path isn't set
+	G2PowerOf2   []bn254.G2Affine
 	mu           sync.Mutex
 	LoadG2Points bool
 
@@ -38,15 +48,32 @@ func NewVerifier(config *kzg.KzgConfig, loadG2Points bool) (*Verifier, error) {
 		return nil, errors.New("SRSOrder is less than srsNumberToLoad")
 	}
 
+	if err := kzg.DownloadSRS(context.Background(), config); err != nil {
+		log.Println("failed to download SRS", err)
+		return nil, err
+	}
+
 	// read the whole order, and treat it as entire SRS for low degree proof
-	s1, err := kzg.ReadG1Points(config.G1Path, config.SRSNumberToLoad, config.NumWorker)
+	s1, err := kzg.LoadG1PointSection(config, 0, config.SRSNumberToLoad)
 	if err != nil {
 		log.Println("failed to read G1 points", err)
 		return nil, err
 	}
 
+	// the length proofs of all the blobs that fit in the loaded SRS are checked against the last G1 points
+	g1Trailing, err := kzg.LoadG1PointSection(
+		config,
+		config.SRSOrder-config.SRSNumberToLoad,
+		config.SRSOrder, // last exclusive
+	)
+	if err != nil {
+		log.Println("failed to read trailing G1 points", err)
+		return nil, err
+	}
+
 	s2 := make([]bn254.G2Affine, 0)
 	g2Trailing := make([]bn254.G2Affine, 0)
+	g2PowerOf2 := make([]bn254.G2Affine, 0)
 
 	// PreloadEncoder is by default not used by operator node, PreloadEncoder
 	if loadG2Points {
@@ -54,17 +81,16 @@ func NewVerifier(config *kzg.KzgConfig, loadG2Points bool) (*Verifier, error) {
 			return nil, errors.New("G2Path is empty. However, object needs to load G2Points")
 		}
 
-		s2, err = kzg.ReadG2Points(config.G2Path, config.SRSNumberToLoad, config.NumWorker)
+		s2, err = kzg.LoadG2PointSection(config, 0, config.SRSNumberToLoad)
 		if err != nil {
 			log.Println("failed to read G2 points", err)
 			return nil, err
 		}
 
-		g2Trailing, err = kzg.ReadG2PointSection(
-			config.G2Path,
+		g2Trailing, err = kzg.LoadG2PointSection(
+			config,
 			config.SRSOrder-config.SRSNumberToLoad,
 			config.SRSOrder, // last exclusive
-			config.NumWorker,
 		)
 		if err != nil {
 			return nil, err
@@ -79,8 +105,9 @@ func NewVerifier(config *kzg.KzgConfig, loadG2Points bool) (*Verifier, error) {
 				return nil, errors.New("SRS order cannot be 0")
 			}
 
-			maxPower := uint64(math.Log2(float64(config.SRSOrder)))
-			_, err := kzg.ReadG2PointSection(config.G2PowerOf2Path, 0, maxPower, 1)
+			// the file holds [tau^(2^i)] for every power of 2 up to the largest power of the SRS
+			maxPower := uint64(math.Log2(float64(config.SRSOrder-1))) + 1
+			g2PowerOf2, err = kzg.ReadG2PointSection(config.G2PowerOf2Path, 0, maxPower, 1)
 			if err != nil {
 				return nil, fmt.Errorf("file located at %v is invalid", config.G2PowerOf2Path)
 			}
@@ -94,12 +121,23 @@ func NewVerifier(config *kzg.KzgConfig, loadG2Points bool) (*Verifier, error) {
 		return nil, err
 	}
 
+	if len(config.MSMCalibrationDir) > 0 {
+		calibration, err := msm.LoadOrCalibrate(config.MSMCalibrationDir)
+		if err != nil {
+			log.Println("Could not load msm calibration", err)
+			return nil, err
+		}
+		msm.SetCalibration(calibration)
+	}
+
 	fmt.Println("numthread", runtime.GOMAXPROCS(0))
 
 	encoderGroup := &Verifier{
 		KzgConfig:             config,
 		Srs:                   srs,
 		G2Trailing:            g2Trailing,
+		G1Trailing:            g1Trailing,
+		G2PowerOf2:            g2PowerOf2,
 		ParametrizedVerifiers: make(map[encoding.EncodingParams]*ParametrizedVerifier),
 		LoadG2Points:          loadG2Points,
 	}
@@ -116,6 +154,11 @@ type ParametrizedVerifier struct {
 
 	Fs *fft.FFTSettings
 	Ks *kzg.KZGSettings
+
+	// G1Scratch provides the temporary point slices used by batch verification
+	G1Scratch *scratch.Pool[bn254.G1Affine]
+	// G2PowerOf2 are the G2 points of the SRS at the powers of 2, shared with the Verifier
+	G2PowerOf2 []bn254.G2Affine
 }
 
 func (g *Verifier) GetKzgVerifier(params encoding.EncodingParams) (*ParametrizedVerifier, error) {
@@ -165,12 +208,19 @@ func (g *Verifier) newKzgVerifier(params encoding.EncodingParams) (*Parametrized
 		return nil, err
 	}
 
+	// share a single scratch pool between the transforms of this verifier
+	pool := scratch.NewPool[fr.Element](!g.DisableScratchPool)
+	fs.Scratch = pool
+	encoder.Fs.Scratch = pool
+
 	return &ParametrizedVerifier{
-		KzgConfig: g.KzgConfig,
-		Srs:       g.Srs,
-		Encoder:   encoder,
-		Fs:        fs,
-		Ks:        ks,
+		KzgConfig:  g.KzgConfig,
+		Srs:        g.Srs,
+		Encoder:    encoder,
+		Fs:         fs,
+		Ks:         ks,
+		G1Scratch:  scratch.NewPool[bn254.G1Affine](!g.DisableScratchPool),
+		G2PowerOf2: g.G2PowerOf2,
 	}, nil
 }
 
@@ -183,19 +233,43 @@ func (v *Verifier) VerifyBlobLength(commitments encoding.BlobCommitments) error
 // we leave it as a method of the KzgEncoderGroup
 func (v *Verifier) VerifyCommit(lengthCommit *bn254.G2Affine, legnthProof *bn254.G2Affine, length uint64) error {
 
-	g1Challenge, err := kzg.ReadG1Point(v.SRSOrder-length, v.KzgConfig)
+	g1Challenge, err := v.lengthChallenge(length)
 	if err != nil {
 		return err
 	}
 
 	err = VerifyLengthProof(lengthCommit, legnthProof, &g1Challenge)
 	if err != nil {
-		return fmt.Errorf("%v . %v ", "low degree proof fails", err)
+		return fmt.Errorf("low degree proof fails: %w", err)
 	} else {
 		return nil
 	}
 }
 
+// lengthChallenge returns [tau^(SRSOrder-length)]_1, the G1 point the length proof of a blob of the given length
+// is checked against. It's only read from disk for blobs larger than the loaded SRS.
+func (v *Verifier) lengthChallenge(length uint64) (bn254.G1Affine, error) {
+	if length > 0 && length <= uint64(len(v.G1Trailing)) {
+		return v.G1Trailing[uint64(len(v.G1Trailing))-length], nil
+	}
+	if length == 0 || length > v.SRSOrder {
+		return bn254.G1Affine{}, fmt.Errorf("%w: invalid blob length %d for SRSOrder %d", encoding.ErrSRSOutOfRange, length, v.SRSOrder)
+	}
+	return kzg.ReadG1Point(v.SRSOrder-length, v.KzgConfig)
+}
+
+// g2AtPowerOf2 returns [tau^n]_2 for n a power of 2 from the preloaded points, falling back to reading it from
+// the full G2 SRS if the points at powers of 2 aren't loaded.
+func g2AtPowerOf2(g2PowerOf2 []bn254.G2Affine, n uint64, config *kzg.KzgConfig) (bn254.G2Affine, error) {
+	if n > 0 && n&(n-1) == 0 {
+		exponent := uint64(bits.TrailingZeros64(n))
+		if exponent < uint64(len(g2PowerOf2)) {
+			return g2PowerOf2[exponent], nil
+		}
+	}
+	return kzg.ReadG2Point(n, config)
+}
+
 // The function verify low degree proof against a poly commitment
 // We wish to show x^shift poly = shiftedPoly, with
 // With shift = SRSOrder - length and
@@ -207,6 +281,12 @@ func VerifyLengthProof(lengthCommit *bn254.G2Affine, proof *bn254.G2Affine, g1Ch
 }
 
 func (v *Verifier) VerifyFrames(frames []*encoding.Frame, indices []encoding.ChunkNumber, commitments encoding.BlobCommitments, params encoding.EncodingParams) error {
+	if len(frames) != len(indices) {
+		return fmt.Errorf("got %d frames for %d indices", len(frames), len(indices))
+	}
+	if err := validateFrames(frames, params); err != nil {
+		return err
+	}
 
 	verifier, err := v.GetKzgVerifier(params)
 	if err != nil {
@@ -229,6 +309,87 @@ func (v *Verifier) VerifyFrames(frames []*encoding.Frame, indices []encoding.Chu
 
 }
 
+// VerifyAggregatedFrames verifies the frames with the given chunk indices against their aggregated proof, computed
+// by the prover's ProveAggregated, rather than against their individual proofs. It checks that
+// e([commitment - interpolation(s)]_1, [1]_2) = e(proof, [Z(s)]_2), with Z the vanishing polynomial of the union of
+// the cosets of the frames and interpolation the combination of the frames' interpolating polynomials.
+func (v *Verifier) VerifyAggregatedFrames(frames []*encoding.Frame, indices []encoding.ChunkNumber, commitments encoding.BlobCommitments, params encoding.EncodingParams, proof *encoding.Proof) error {
+	if len(frames) != len(indices) {
+		return fmt.Errorf("got %d frames for %d indices", len(frames), len(indices))
+	}
+	if len(frames) == 0 {
+		return errors.New("no frames to verify")
+	}
+	if err := validateFrames(frames, params); err != nil {
+		return err
+	}
+
+	verifier, err := v.GetKzgVerifier(params)
+	if err != nil {
+		return err
+	}
+	shifts, err := verifier.Encoder.GetCosetShifts(toUint64Array(indices))
+	if err != nil {
+		return err
+	}
+	coeffs := make([][]fr.Element, len(frames))
+	for i := range frames {
+		coeffs[i], err = verifier.ChunkCoeffs(frames[i], indices[i])
+		if err != nil {
+			return err
+		}
+	}
+	interpolation, err := rs.InterpolateChunks(coeffs, shifts, params.ChunkLength)
+	if err != nil {
+		return err
+	}
+	if len(interpolation) > len(v.Srs.G1) {
+		return fmt.Errorf("%w: the frames span %d symbols, more than the loaded SRS points %d", encoding.ErrSRSOutOfRange, len(interpolation), len(v.Srs.G1))
+	}
+
+	// [interpolation(s)]_1
+	is1, err := msm.MultiExpG1(v.Srs.G1, interpolation)
+	if err != nil {
+		return err
+	}
+	var commitMinusInterpolation bn254.G1Affine
+	commitMinusInterpolation.Sub((*bn254.G1Affine)(commitments.Commitment), &is1)
+
+	// [Z(s)]_2, where Z only has terms of degree multiple of the chunk length
+	vanishing := rs.AggregateVanishing(shifts, params.ChunkLength)
+	points := make([]bn254.G2Affine, 0, len(shifts)+1)
+	scalars := make([]fr.Element, 0, len(shifts)+1)
+	for n := uint64(0); n < uint64(len(vanishing)); n += params.ChunkLength {
+		point, err := v.g2AtPower(n)
+		if err != nil {
+			return err
+		}
+		points = append(points, point)
+		scalars = append(scalars, vanishing[n])
+	}
+	var zs2 bn254.G2Affine
+	if _, err := zs2.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+
+	if err := PairingsVerify(&commitMinusInterpolation, &kzg.GenG2, proof, &zs2); err != nil {
+		return fmt.Errorf("aggregated proof verification failed: %w", err)
+	}
+	return nil
+}
+
+// g2AtPower returns [s^n]_2 from the loaded G2 SRS, falling back to the points at powers of 2 and to the G2 SRS
+// on disk
+func (v *Verifier) g2AtPower(n uint64) (bn254.G2Affine, error) {
+	if n == 0 {
+		return kzg.GenG2, nil
+	}
+	if n < uint64(len(v.Srs.G2)) {
+		return v.Srs.G2[n], nil
+	}
+	return g2AtPowerOf2(v.G2PowerOf2, n, v.KzgConfig)
+}
+
 func (v *ParametrizedVerifier) VerifyFrame(commit *bn254.G1Affine, f *encoding.Frame, index uint64) error {
 
 	j, err := rs.GetLeadingCosetIndex(
@@ -239,7 +400,16 @@ func (v *ParametrizedVerifier) VerifyFrame(commit *bn254.G1Affine, f *encoding.F
 		return err
 	}
 
-	g2Atn, err := kzg.ReadG2Point(uint64(len(f.Coeffs)), v.KzgConfig)
+	// frames which only carry the evaluations are verified from the coefficients interpolating them
+	if f.Form != encoding.CoeffChunkForm {
+		coeffs, err := v.ChunkCoeffs(f, encoding.ChunkNumber(index))
+		if err != nil {
+			return err
+		}
+		f = &encoding.Frame{Proof: f.Proof, Coeffs: coeffs}
+	}
+
+	g2Atn, err := g2AtPowerOf2(v.G2PowerOf2, uint64(len(f.Coeffs)), v.KzgConfig)
 	if err != nil {
 		return err
 	}
@@ -247,7 +417,7 @@ func (v *ParametrizedVerifier) VerifyFrame(commit *bn254.G1Affine, f *encoding.F
 	err = VerifyFrame(f, v.Ks, commit, &v.Ks.ExpandedRootsOfUnity[j], &g2Atn)
 
 	if err != nil {
-		return fmt.Errorf("%v . %v ", "VerifyFrame Error", err)
+		return fmt.Errorf("VerifyFrame Error: %w", err)
 	} else {
 		return nil
 	}
@@ -274,9 +444,7 @@ func VerifyFrame(f *encoding.Frame, ks *kzg.KZGSettings, commitment *bn254.G1Aff
 	xnMinusYn.Sub(g2Atn, &xn2)
 
 	// [interpolation_polynomial(s)]_1
-	var is1 bn254.G1Affine
-	config := ecc.MultiExpConfig{}
-	_, err := is1.MultiExp(ks.Srs.G1[:len(f.Coeffs)], f.Coeffs, config)
+	is1, err := msm.MultiExpG1(ks.Srs.G1, f.Coeffs)
 	if err != nil {
 		return err
 	}
@@ -298,16 +466,26 @@ func VerifyFrame(f *encoding.Frame, ks *kzg.KZGSettings, commitment *bn254.G1Aff
 // Decode takes in the chunks, indices, and encoding parameters and returns the decoded blob
 // The result is trimmed to the given maxInputSize.
 func (v *Verifier) Decode(chunks []*encoding.Frame, indices []encoding.ChunkNumber, params encoding.EncodingParams, maxInputSize uint64) ([]byte, error) {
+	encoder, err := v.GetKzgVerifier(params)
+	if err != nil {
+		return nil, err
+	}
 	frames := make([]rs.Frame, len(chunks))
 	for i := range chunks {
+		coeffs := chunks[i].Coeffs
+		if chunks[i].Form != encoding.CoeffChunkForm {
+			if i >= len(indices) {
+				return nil, fmt.Errorf("no index for chunk %d", i)
+			}
+			coeffs, err = encoder.ChunkCoeffs(chunks[i], indices[i])
+			if err != nil {
+				return nil, err
+			}
+		}
 		frames[i] = rs.Frame{
-			Coeffs: chunks[i].Coeffs,
+			Coeffs: coeffs,
 		}
 	}
-	encoder, err := v.GetKzgVerifier(params)
-	if err != nil {
-		return nil, err
-	}
 
 	return encoder.Decode(frames, toUint64Array(indices), maxInputSize)
 }
@@ -332,8 +510,18 @@ func PairingsVerify(a1 *bn254.G1Affine, a2 *bn254.G2Affine, b1 *bn254.G1Affine,
 		return err
 	}
 	if !ok {
-		return errors.New("PairingCheck pairing not ok. SRS is invalid")
+		return fmt.Errorf("%w: PairingCheck pairing not ok", encoding.ErrProofInvalid)
 	}
 
 	return nil
 }
+
+// validateFrames checks the frames are well-formed before running any pairing or MSM on them
+func validateFrames(frames []*encoding.Frame, params encoding.EncodingParams) error {
+	for i, frame := range frames {
+		if err := frame.Validate(params); err != nil {
+			return fmt.Errorf("invalid frame %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -23,6 +23,30 @@ func (e *MockEncoder) EncodeAndProve(data []byte, params encoding.EncodingParams
 	return args.Get(0).(encoding.BlobCommitments), args.Get(1).([]*encoding.Frame), args.Error(2)
 }
 
+func (e *MockEncoder) EncodeAndProveWithCheckpoint(data []byte, params encoding.EncodingParams, checkpoint encoding.Checkpoint) (encoding.BlobCommitments, []*encoding.Frame, error) {
+	checkpoint.Wait()
+	return e.EncodeAndProve(data, params)
+}
+
+func (e *MockEncoder) EncodeAndProveBatch(data [][]byte, params []encoding.EncodingParams) ([]encoding.BlobCommitments, [][]*encoding.Frame, error) {
+	commitments := make([]encoding.BlobCommitments, len(data))
+	chunks := make([][]*encoding.Frame, len(data))
+	for i := range data {
+		var err error
+		commitments[i], chunks[i], err = e.EncodeAndProve(data[i], params[i])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return commitments, chunks, nil
+}
+
+func (e *MockEncoder) EncodeChunks(data []byte, params encoding.EncodingParams, indices []encoding.ChunkNumber) ([]*encoding.Frame, error) {
+	args := e.Called(data, params, indices)
+	time.Sleep(e.Delay)
+	return args.Get(0).([]*encoding.Frame), args.Error(1)
+}
+
 func (e *MockEncoder) VerifyFrames(chunks []*encoding.Frame, indices []encoding.ChunkNumber, commitments encoding.BlobCommitments, params encoding.EncodingParams) error {
 	args := e.Called(chunks, indices, commitments, params)
 	time.Sleep(e.Delay)
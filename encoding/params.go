@@ -1,16 +1,12 @@
 package encoding
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/encoding/utils/intmath"
 	"golang.org/x/exp/constraints"
 )
 
-var (
-	ErrInvalidParams = errors.New("invalid encoding params")
-)
-
 type EncodingParams struct {
 	ChunkLength uint64 // ChunkSize is the length of the chunk in symbols
 	NumChunks   uint64
@@ -24,19 +20,29 @@ func (p EncodingParams) NumEvaluations() uint64 {
 	return p.NumChunks * p.ChunkLength
 }
 
+// Validate checks that the number of chunks and the chunk length are powers of 2. A single chunk of a single symbol
+// is valid, which is how the smallest blobs are encoded.
 func (p EncodingParams) Validate() error {
 
-	if NextPowerOf2(p.NumChunks) != p.NumChunks {
-		return ErrInvalidParams
+	if p.NumChunks == 0 || NextPowerOf2(p.NumChunks) != p.NumChunks {
+		return fmt.Errorf("%w: the number of chunks %d must be a positive power of 2", ErrInvalidParams, p.NumChunks)
 	}
 
-	if NextPowerOf2(p.ChunkLength) != p.ChunkLength {
-		return ErrInvalidParams
+	if p.ChunkLength == 0 || NextPowerOf2(p.ChunkLength) != p.ChunkLength {
+		return fmt.Errorf("%w: the chunk length %d must be a positive power of 2", ErrInvalidParams, p.ChunkLength)
 	}
 
 	return nil
 }
 
+// ValidateDataLength checks that the chunks can hold a blob of dataLength symbols
+func (p EncodingParams) ValidateDataLength(dataLength uint64) error {
+	if dataLength > p.NumEvaluations() {
+		return fmt.Errorf("%w: the encoding parameters are not sufficient for the size of the data input, %d chunks of length %d hold %d symbols, fewer than the %d symbols of the data", ErrInvalidParams, p.NumChunks, p.ChunkLength, p.NumEvaluations(), dataLength)
+	}
+	return nil
+}
+
 func ParamsFromMins[T constraints.Integer](minChunkLength, minNumChunks T) EncodingParams {
 	return EncodingParams{
 		NumChunks:   NextPowerOf2(uint64(minNumChunks)),
@@ -47,15 +53,17 @@ func ParamsFromMins[T constraints.Integer](minChunkLength, minNumChunks T) Encod
 func ParamsFromSysPar(numSys, numPar, dataSize uint64) EncodingParams {
 
 	numNodes := numSys + numPar
-	dataLen := roundUpDivide(dataSize, BYTES_PER_SYMBOL)
-	chunkLen := roundUpDivide(dataLen, numSys)
+	dataLen := intmath.RoundUpDivide(dataSize, BYTES_PER_SYMBOL)
+	chunkLen := intmath.RoundUpDivide(dataLen, numSys)
 	return ParamsFromMins(chunkLen, numNodes)
 
 }
 
+// GetNumSys returns the number of chunks needed to reconstruct data of dataSize bytes, which is at least one for
+// any data smaller than a chunk
 func GetNumSys(dataSize uint64, chunkLen uint64) uint64 {
-	dataLen := roundUpDivide(dataSize, BYTES_PER_SYMBOL)
-	numSys := dataLen / chunkLen
+	dataLen := intmath.RoundUpDivide(dataSize, BYTES_PER_SYMBOL)
+	numSys := intmath.RoundUpDivide(dataLen, chunkLen)
 	return numSys
 }
 
@@ -63,13 +71,9 @@ func GetNumSys(dataSize uint64, chunkLen uint64) uint64 {
 func ValidateEncodingParams(params EncodingParams, blobLength, SRSOrder int) error {
 
 	if int(params.ChunkLength*params.NumChunks) >= SRSOrder {
-		return fmt.Errorf("the supplied encoding parameters are not valid with respect to the SRS. ChunkLength: %d, NumChunks: %d, SRSOrder: %d", params.ChunkLength, params.NumChunks, SRSOrder)
-	}
-
-	if int(params.ChunkLength*params.NumChunks) < blobLength {
-		return errors.New("the supplied encoding parameters are not sufficient for the size of the data input")
+		return fmt.Errorf("%w: the supplied encoding parameters are not valid with respect to the SRS. ChunkLength: %d, NumChunks: %d, SRSOrder: %d", ErrSRSOutOfRange, params.ChunkLength, params.NumChunks, SRSOrder)
 	}
 
-	return nil
+	return params.ValidateDataLength(uint64(blobLength))
 
 }
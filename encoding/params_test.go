@@ -0,0 +1,35 @@
+package encoding_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodingParamsValidate(t *testing.T) {
+	assert.NoError(t, encoding.EncodingParams{NumChunks: 1, ChunkLength: 1}.Validate())
+	assert.NoError(t, encoding.EncodingParams{NumChunks: 8, ChunkLength: 2}.Validate())
+
+	err := encoding.EncodingParams{NumChunks: 0, ChunkLength: 1}.Validate()
+	assert.ErrorIs(t, err, encoding.ErrInvalidParams)
+	assert.ErrorContains(t, err, "the number of chunks 0 must be a positive power of 2")
+	err = encoding.EncodingParams{NumChunks: 1, ChunkLength: 0}.Validate()
+	assert.ErrorIs(t, err, encoding.ErrInvalidParams)
+	assert.ErrorContains(t, err, "the chunk length 0 must be a positive power of 2")
+	assert.ErrorIs(t, encoding.EncodingParams{NumChunks: 3, ChunkLength: 1}.Validate(), encoding.ErrInvalidParams)
+
+	params := encoding.EncodingParams{NumChunks: 1, ChunkLength: 1}
+	assert.NoError(t, params.ValidateDataLength(1))
+	assert.ErrorContains(t, params.ValidateDataLength(2), "1 chunks of length 1 hold 1 symbols, fewer than the 2 symbols of the data")
+}
+
+func TestGetNumSys(t *testing.T) {
+	// A blob smaller than a chunk still needs a chunk
+	assert.Equal(t, uint64(1), encoding.GetNumSys(1, 4))
+	assert.Equal(t, uint64(1), encoding.GetNumSys(32, 1))
+	assert.Equal(t, uint64(2), encoding.GetNumSys(33, 1))
+	// A partial last chunk counts
+	assert.Equal(t, uint64(2), encoding.GetNumSys(5*32, 4))
+	assert.Equal(t, uint64(0), encoding.GetNumSys(0, 4))
+}
@@ -0,0 +1,176 @@
+// Package poly provides arithmetic on polynomials over the bn254 scalar field, in coefficient form with the
+// coefficient of degree i at index i, and on batches of field elements. It's the arithmetic the encoding and the KZG
+// proofs are built on, exposed for the tools that need to reproduce them, e.g. to build fraud proofs or audit blobs.
+package poly
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/Layr-Labs/eigenda/encoding/fft"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// fftMulThreshold is the degree from which polynomials are multiplied with FFTs rather than term by term
+const fftMulThreshold = 64
+
+var (
+	// ErrZeroInverse is returned when inverting a zero element
+	ErrZeroInverse = errors.New("cannot invert zero")
+	// ErrZeroDivisor is returned when dividing by the zero polynomial
+	ErrZeroDivisor = errors.New("cannot divide by the zero polynomial")
+)
+
+// BatchInvert returns the inverses of the elements, computed with a single field inversion (Montgomery's trick). It
+// returns ErrZeroInverse if any element is zero.
+func BatchInvert(elements []fr.Element) ([]fr.Element, error) {
+	inverses := make([]fr.Element, len(elements))
+	if len(elements) == 0 {
+		return inverses, nil
+	}
+
+	// inverses[i] holds the product of the elements before i, until the backward pass
+	var acc fr.Element
+	acc.SetOne()
+	for i := range elements {
+		if elements[i].IsZero() {
+			return nil, fmt.Errorf("%w: element %d is zero", ErrZeroInverse, i)
+		}
+		inverses[i] = acc
+		acc.Mul(&acc, &elements[i])
+	}
+	acc.Inverse(&acc)
+	for i := len(elements) - 1; i >= 0; i-- {
+		inverses[i].Mul(&inverses[i], &acc)
+		acc.Mul(&acc, &elements[i])
+	}
+	return inverses, nil
+}
+
+// Eval returns the evaluation of the polynomial at x
+func Eval(coeffs []fr.Element, x fr.Element) fr.Element {
+	var y fr.Element
+	fft.EvalPolyAt(&y, coeffs, &x)
+	return y
+}
+
+// Mul returns the product of the polynomials, of len(a)+len(b)-1 coefficients. The product of large polynomials is
+// computed with FFTs.
+func Mul(a, b []fr.Element) []fr.Element {
+	if len(a) == 0 || len(b) == 0 {
+		return []fr.Element{}
+	}
+	productLen := len(a) + len(b) - 1
+	if min(len(a), len(b)) < fftMulThreshold {
+		product := make([]fr.Element, productLen)
+		var term fr.Element
+		for i := range a {
+			for j := range b {
+				term.Mul(&a[i], &b[j])
+				product[i+j].Add(&product[i+j], &term)
+			}
+		}
+		return product
+	}
+
+	// Evaluate both polynomials on a domain large enough for the product, multiply pointwise, and interpolate
+	scale := uint8(bits.Len64(uint64(productLen - 1)))
+	fs := fft.NewFFTSettings(scale)
+	n := uint64(1) << scale
+	aEvals := make([]fr.Element, n)
+	copy(aEvals, a)
+	bEvals := make([]fr.Element, n)
+	copy(bEvals, b)
+	// The transforms can't fail: the lengths are the power of 2 of the settings
+	_ = fs.FFTInPlace(aEvals, false)
+	_ = fs.FFTInPlace(bEvals, false)
+	for i := range aEvals {
+		aEvals[i].Mul(&aEvals[i], &bEvals[i])
+	}
+	_ = fs.FFTInPlace(aEvals, true)
+	return aEvals[:productLen]
+}
+
+// DivMod divides a by b, returning the quotient q and the remainder r such that a = q*b + r, with r of fewer
+// coefficients than b without its leading zeros. It returns ErrZeroDivisor if b is zero.
+func DivMod(a, b []fr.Element) ([]fr.Element, []fr.Element, error) {
+	degB := degree(b)
+	if degB < 0 {
+		return nil, nil, ErrZeroDivisor
+	}
+	remainder := make([]fr.Element, len(a))
+	copy(remainder, a)
+	if len(a) <= degB {
+		return []fr.Element{}, remainder, nil
+	}
+
+	var leadInv fr.Element
+	leadInv.Inverse(&b[degB])
+	quotient := make([]fr.Element, len(a)-degB)
+	var term fr.Element
+	for i := len(quotient) - 1; i >= 0; i-- {
+		// Cancel the coefficient of degree i+degB of the remainder
+		quotient[i].Mul(&remainder[i+degB], &leadInv)
+		for j := 0; j <= degB; j++ {
+			term.Mul(&quotient[i], &b[j])
+			remainder[i+j].Sub(&remainder[i+j], &term)
+		}
+	}
+	return quotient, remainder[:degB], nil
+}
+
+// DivideByLinear divides the polynomial by X - z with Horner's method, returning the quotient and the remainder,
+// which is the evaluation of the polynomial at z
+func DivideByLinear(coeffs []fr.Element, z fr.Element) ([]fr.Element, fr.Element) {
+	var y fr.Element
+	quotient := make([]fr.Element, max(len(coeffs)-1, 0))
+	// The intermediate values of Horner's method are the coefficients of the quotient
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y.Mul(&y, &z)
+		y.Add(&y, &coeffs[i])
+		if i > 0 {
+			quotient[i-1] = y
+		}
+	}
+	return quotient, y
+}
+
+// Vanishing returns the monic polynomial whose roots are the points, i.e. the product of the X - points[i]
+func Vanishing(points []fr.Element) []fr.Element {
+	if len(points) == 0 {
+		var one fr.Element
+		one.SetOne()
+		return []fr.Element{one}
+	}
+	if len(points) == 1 {
+		linear := make([]fr.Element, 2)
+		linear[0].Neg(&points[0])
+		linear[1].SetOne()
+		return linear
+	}
+	// Halving the points keeps the factors balanced, so the large products are computed with FFTs
+	half := len(points) / 2
+	return Mul(Vanishing(points[:half]), Vanishing(points[half:]))
+}
+
+// VanishingOnCoset returns X^n - shift^n, the vanishing polynomial of the coset of the subgroup of order n led by
+// shift
+func VanishingOnCoset(n uint64, shift fr.Element) []fr.Element {
+	vanishing := make([]fr.Element, n+1)
+	vanishing[0].Exp(shift, new(big.Int).SetUint64(n))
+	vanishing[0].Neg(&vanishing[0])
+	vanishing[n].SetOne()
+	return vanishing
+}
+
+// degree returns the degree of the polynomial, or -1 for the zero polynomial
+func degree(coeffs []fr.Element) int {
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		if !coeffs[i].IsZero() {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,157 @@
+package poly_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/poly"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomElements(t *testing.T, n int) []fr.Element {
+	elements := make([]fr.Element, n)
+	for i := range elements {
+		_, err := elements[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return elements
+}
+
+func TestBatchInvert(t *testing.T) {
+	elements := randomElements(t, 100)
+	inverses, err := poly.BatchInvert(elements)
+	require.NoError(t, err)
+	require.Len(t, inverses, len(elements))
+	for i := range elements {
+		var inverse fr.Element
+		inverse.Inverse(&elements[i])
+		assert.Equal(t, inverse, inverses[i])
+	}
+
+	inverses, err = poly.BatchInvert(nil)
+	require.NoError(t, err)
+	assert.Empty(t, inverses)
+
+	elements[42].SetZero()
+	_, err = poly.BatchInvert(elements)
+	assert.ErrorIs(t, err, poly.ErrZeroInverse)
+}
+
+func TestMul(t *testing.T) {
+	x := randomElements(t, 1)[0]
+	// Below and above the threshold of the FFT multiplication
+	for _, size := range [][2]int{{1, 1}, {3, 7}, {63, 200}, {64, 64}, {100, 300}} {
+		a := randomElements(t, size[0])
+		b := randomElements(t, size[1])
+		product := poly.Mul(a, b)
+		require.Len(t, product, size[0]+size[1]-1)
+
+		var expected fr.Element
+		ax, bx := poly.Eval(a, x), poly.Eval(b, x)
+		expected.Mul(&ax, &bx)
+		assert.Equal(t, expected, poly.Eval(product, x), "sizes %v", size)
+	}
+
+	assert.Empty(t, poly.Mul(nil, randomElements(t, 3)))
+}
+
+func TestMulFFTMatchesSchoolbook(t *testing.T) {
+	a := randomElements(t, 70)
+	b := randomElements(t, 90)
+	product := poly.Mul(a, b)
+
+	expected := make([]fr.Element, len(a)+len(b)-1)
+	var term fr.Element
+	for i := range a {
+		for j := range b {
+			term.Mul(&a[i], &b[j])
+			expected[i+j].Add(&expected[i+j], &term)
+		}
+	}
+	assert.Equal(t, expected, product)
+}
+
+func TestDivMod(t *testing.T) {
+	b := randomElements(t, 10)
+	q := randomElements(t, 20)
+	r := randomElements(t, 9)
+	a := poly.Mul(q, b)
+	for i := range r {
+		a[i].Add(&a[i], &r[i])
+	}
+
+	quotient, remainder, err := poly.DivMod(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, q, quotient)
+	assert.Equal(t, r, remainder)
+
+	// Leading zeros of the divisor are ignored
+	quotient, remainder, err = poly.DivMod(a, append(b, fr.Element{}, fr.Element{}))
+	require.NoError(t, err)
+	assert.Equal(t, q, quotient)
+	assert.Equal(t, r, remainder)
+
+	// A dividend of lower degree is its own remainder
+	quotient, remainder, err = poly.DivMod(r, b)
+	require.NoError(t, err)
+	assert.Empty(t, quotient)
+	assert.Equal(t, r, remainder)
+
+	_, _, err = poly.DivMod(a, make([]fr.Element, 3))
+	assert.ErrorIs(t, err, poly.ErrZeroDivisor)
+}
+
+func TestDivideByLinear(t *testing.T) {
+	coeffs := randomElements(t, 50)
+	z := randomElements(t, 1)[0]
+	quotient, y := poly.DivideByLinear(coeffs, z)
+	assert.Equal(t, poly.Eval(coeffs, z), y)
+
+	// The quotient is the one of the general division by X - z
+	var minusZ, one fr.Element
+	minusZ.Neg(&z)
+	one.SetOne()
+	expected, remainder, err := poly.DivMod(coeffs, []fr.Element{minusZ, one})
+	require.NoError(t, err)
+	assert.Equal(t, expected, quotient)
+	assert.Equal(t, []fr.Element{y}, remainder)
+
+	quotient, y = poly.DivideByLinear(nil, z)
+	assert.Empty(t, quotient)
+	assert.True(t, y.IsZero())
+}
+
+func TestVanishing(t *testing.T) {
+	var one fr.Element
+	one.SetOne()
+	for _, n := range []int{0, 1, 5, 200} {
+		points := randomElements(t, n)
+		vanishing := poly.Vanishing(points)
+		require.Len(t, vanishing, n+1)
+		assert.Equal(t, one, vanishing[n], "the vanishing polynomial is monic")
+		for i := range points {
+			y := poly.Eval(vanishing, points[i])
+			assert.True(t, y.IsZero())
+		}
+		x := randomElements(t, 1)[0]
+		y := poly.Eval(vanishing, x)
+		assert.False(t, y.IsZero())
+	}
+}
+
+func TestVanishingOnCoset(t *testing.T) {
+	shift := randomElements(t, 1)[0]
+	// The coset of the subgroup of order 4 is {shift, shift*i, -shift, -shift*i}, with i a square root of -1
+	var i, minusOne fr.Element
+	minusOne.SetOne()
+	minusOne.Neg(&minusOne)
+	i.Sqrt(&minusOne)
+	coset := make([]fr.Element, 4)
+	coset[0] = shift
+	for j := 1; j < 4; j++ {
+		coset[j].Mul(&coset[j-1], &i)
+	}
+
+	assert.Equal(t, poly.Vanishing(coset), poly.VanishingOnCoset(4, shift))
+}
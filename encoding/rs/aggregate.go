@@ -0,0 +1,91 @@
+package rs
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/encoding/poly"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// The frames of a set of chunks can be proven with a single aggregated proof. The coset of chunk i is the set of
+// roots of x^l - s_i, with l the chunk length and s_i the shift of the coset, so the union of the cosets is the set
+// of roots of Z(x) = V(x^l), with V the polynomial whose roots are the shifts. The aggregated proof is the
+// commitment to the quotient of the blob polynomial by Z, and the remainder, which the frames are checked against,
+// is the combination of the interpolating polynomials of the frames given by the Chinese remainder theorem.
+
+// GetCosetShifts returns the shifts w_j^l of the cosets of the chunks, where w_j is the leading element of the
+// coset of the chunk. The indices must be distinct.
+func (g *Encoder) GetCosetShifts(indices []uint64) ([]fr.Element, error) {
+	l := new(big.Int).SetUint64(g.ChunkLength)
+	seen := make(map[uint64]struct{}, len(indices))
+	shifts := make([]fr.Element, len(indices))
+	for i, index := range indices {
+		if _, ok := seen[index]; ok {
+			return nil, fmt.Errorf("duplicate chunk index %d", index)
+		}
+		seen[index] = struct{}{}
+
+		j, err := GetLeadingCosetIndex(index, g.NumChunks)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk index %d: %w", index, err)
+		}
+		shifts[i].Exp(g.Fs.ExpandedRootsOfUnity[j], l)
+	}
+	return shifts, nil
+}
+
+// AggregateVanishing returns the vanishing polynomial V(x^l) of the union of the cosets with the given shifts
+func AggregateVanishing(shifts []fr.Element, chunkLength uint64) []fr.Element {
+	vanishing := poly.Vanishing(shifts)
+	expanded := make([]fr.Element, uint64(len(vanishing)-1)*chunkLength+1)
+	for k := range vanishing {
+		expanded[uint64(k)*chunkLength] = vanishing[k]
+	}
+	return expanded
+}
+
+// InterpolateChunks returns the polynomial of degree less than l * len(shifts) which is congruent to the
+// interpolating polynomial of every frame modulo the vanishing polynomial of its coset, i.e. the remainder of the
+// division of the blob polynomial by the vanishing polynomial of the union of the cosets.
+//
+// Since the vanishing polynomials of the cosets are polynomials in y = x^l, the combination is
+// I(x) = sum_i I_i(x) L_i(x^l), with L_i the Lagrange polynomials of the shifts, and the coefficient of degree
+// a + l*b of I is sum_i I_i[a] L_i[b].
+func InterpolateChunks(frames [][]fr.Element, shifts []fr.Element, chunkLength uint64) ([]fr.Element, error) {
+	if len(frames) != len(shifts) {
+		return nil, fmt.Errorf("got %d frames for %d shifts", len(frames), len(shifts))
+	}
+	for i := range frames {
+		if uint64(len(frames[i])) != chunkLength {
+			return nil, fmt.Errorf("frame %d has %d coefficients, expected %d", i, len(frames[i]), chunkLength)
+		}
+	}
+
+	// L_i(y) = (V(y) / (y - s_i)) / V'(s_i), and V'(s_i) is the quotient evaluated at s_i
+	vanishing := poly.Vanishing(shifts)
+	numerators := make([][]fr.Element, len(shifts))
+	denominators := make([]fr.Element, len(shifts))
+	for i := range shifts {
+		numerators[i], _ = poly.DivideByLinear(vanishing, shifts[i])
+		denominators[i] = poly.Eval(numerators[i], shifts[i])
+	}
+	inverses, err := poly.BatchInvert(denominators)
+	if err != nil {
+		return nil, fmt.Errorf("the shifts aren't distinct: %w", err)
+	}
+
+	interpolation := make([]fr.Element, chunkLength*uint64(len(shifts)))
+	var lagrange, term fr.Element
+	for i := range shifts {
+		for b := range numerators[i] {
+			lagrange.Mul(&numerators[i][b], &inverses[i])
+			offset := uint64(b) * chunkLength
+			for a := uint64(0); a < chunkLength; a++ {
+				term.Mul(&frames[i][a], &lagrange)
+				interpolation[offset+a].Add(&interpolation[offset+a], &term)
+			}
+		}
+	}
+	return interpolation, nil
+}
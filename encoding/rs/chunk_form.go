@@ -0,0 +1,88 @@
+package rs
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// SetChunkForm converts the frame of the given chunk index to the given form, computing the evaluations from
+// the coefficients or the coefficients from the evaluations as needed, and dropping the representation the form
+// doesn't carry.
+func (g *Encoder) SetChunkForm(frame *encoding.Frame, index encoding.ChunkNumber, form encoding.ChunkForm) error {
+	if form > encoding.CoeffAndEvalChunkForm {
+		return fmt.Errorf("%w: %d", encoding.ErrInvalidChunkForm, form)
+	}
+	if form == frame.Form {
+		return nil
+	}
+
+	coeffs, err := g.ChunkCoeffs(frame, index)
+	if err != nil {
+		return err
+	}
+
+	var evals []fr.Element
+	if form.HasEvals() {
+		evals = frame.Evals
+		if !frame.Form.HasEvals() {
+			j, err := GetLeadingCosetIndex(uint64(index), g.NumChunks)
+			if err != nil {
+				return err
+			}
+			evals, err = g.GetInterpolationPolyEval(coeffs, j)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if !form.HasCoeffs() {
+		coeffs = nil
+	}
+
+	frame.Coeffs = coeffs
+	frame.Evals = evals
+	frame.Form = form
+	return nil
+}
+
+// ChunkCoeffs returns the coefficients of the interpolating polynomial of the frame of the given chunk index. They
+// are computed from the evaluations for frames which only carry the evaluations. For frames which carry both, the
+// evaluations are checked to be those of the coefficients, so that a frame verified from its coefficients can't
+// carry different evaluations.
+func (g *Encoder) ChunkCoeffs(frame *encoding.Frame, index encoding.ChunkNumber) ([]fr.Element, error) {
+	switch frame.Form {
+	case encoding.CoeffChunkForm:
+		return frame.Coeffs, nil
+	case encoding.EvalChunkForm, encoding.CoeffAndEvalChunkForm:
+	default:
+		return nil, fmt.Errorf("%w: %d", encoding.ErrInvalidChunkForm, frame.Form)
+	}
+
+	if uint64(len(frame.Evals)) != g.ChunkLength {
+		return nil, fmt.Errorf("%w: got %d evaluations for chunks of length %d", encoding.ErrInvalidChunkForm, len(frame.Evals), g.ChunkLength)
+	}
+	j, err := GetLeadingCosetIndex(uint64(index), g.NumChunks)
+	if err != nil {
+		return nil, err
+	}
+
+	if frame.Form == encoding.EvalChunkForm {
+		return g.GetInterpolationPolyCoeff(frame.Evals, j)
+	}
+
+	if uint64(len(frame.Coeffs)) != g.ChunkLength {
+		return nil, fmt.Errorf("%w: got %d coefficients for chunks of length %d", encoding.ErrInvalidChunkForm, len(frame.Coeffs), g.ChunkLength)
+	}
+	evals, err := g.GetInterpolationPolyEval(frame.Coeffs, j)
+	if err != nil {
+		return nil, err
+	}
+	for i := range evals {
+		if !evals[i].Equal(&frame.Evals[i]) {
+			return nil, fmt.Errorf("%w: evaluation %d doesn't match the coefficients", encoding.ErrInvalidChunkForm, i)
+		}
+	}
+	return frame.Coeffs, nil
+}
@@ -1,7 +1,7 @@
 package rs
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/Layr-Labs/eigenda/encoding"
 
@@ -18,10 +18,11 @@ import (
 // the frames and indices don't encode the length of the original data. If maxInputSize
 // is smaller than the original input size, decoded data will be trimmed to fit the maxInputSize.
 func (g *Encoder) Decode(frames []Frame, indices []uint64, maxInputSize uint64) ([]byte, error) {
-	numSys := encoding.GetNumSys(maxInputSize, g.ChunkLength)
+	// Even empty data needs a frame to recover the polynomial from
+	numSys := max(encoding.GetNumSys(maxInputSize, g.ChunkLength), 1)
 
 	if uint64(len(frames)) < numSys {
-		return nil, errors.New("number of frame must be sufficient")
+		return nil, fmt.Errorf("%w: got %d frames, need at least %d", encoding.ErrInsufficientChunks, len(frames), numSys)
 	}
 
 	samples := make([]*fr.Element, g.NumEvaluations())
@@ -68,9 +69,18 @@ func (g *Encoder) Decode(frames []Frame, indices []uint64, maxInputSize uint64)
 		}
 	}
 
-	reconstructedPoly, err := g.Fs.FFT(reconstructedData, true)
-	if err != nil {
-		return nil, err
+	var reconstructedPoly []fr.Element
+	if g.InPlaceFFT {
+		if err := g.Fs.FFTInPlace(reconstructedData, true); err != nil {
+			return nil, err
+		}
+		reconstructedPoly = reconstructedData
+	} else {
+		var err error
+		reconstructedPoly, err = g.Fs.FFT(reconstructedData, true)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	data := ToByteArray(reconstructedPoly, maxInputSize)
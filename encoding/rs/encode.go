@@ -1,9 +1,9 @@
 package rs
 
 import (
-	"errors"
 	"fmt"
 	"log"
+	"math/big"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -66,6 +66,61 @@ func (g *Encoder) Encode(inputFr []fr.Element) (*GlobalPoly, []Frame, []uint32,
 	return poly, frames, indices, nil
 }
 
+// EncodeChunks computes only the frames with the given chunk indices, without extending the whole
+// polynomial. The frames are returned in the order of the indices and are identical to the frames
+// at those positions returned by Encode.
+func (g *Encoder) EncodeChunks(inputFr []fr.Element, indices []encoding.ChunkNumber) ([]Frame, error) {
+	if err := g.ValidateDataLength(uint64(len(inputFr))); err != nil {
+		return nil, err
+	}
+
+	frames := make([]Frame, len(indices))
+	for i, index := range indices {
+		j, err := GetLeadingCosetIndex(uint64(index), g.NumChunks)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk index %d: %w", index, err)
+		}
+		_, frames[i].Coeffs = g.GetCosetQuotient(inputFr, j)
+	}
+
+	return frames, nil
+}
+
+// GetCosetQuotient divides the polynomial with the given coefficients by x^l - w^l, the vanishing
+// polynomial of the coset of size l = ChunkLength led by w, the j-th root of unity. The remainder
+// is the interpolation polynomial of the evaluations on that coset, i.e. the coefficients of the
+// frame with leading coset index j, and the quotient is the polynomial committed to by its proof.
+func (g *Encoder) GetCosetQuotient(coeffs []fr.Element, j uint32) ([]fr.Element, []fr.Element) {
+	l := int(g.ChunkLength)
+
+	var wl fr.Element
+	wl.Exp(g.Fs.ExpandedRootsOfUnity[j], big.NewInt(int64(l)))
+
+	// p(x) = q(x)(x^l - w^l) + r(x), so going down from the leading term,
+	// q[i-l] = p[i] + w^l q[i] and r[i] = p[i] + w^l q[i] for i < l
+	quotient := make([]fr.Element, 0)
+	if len(coeffs) > l {
+		quotient = make([]fr.Element, len(coeffs)-l)
+	}
+	remainder := make([]fr.Element, l)
+	var tmp fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		tmp.Set(&coeffs[i])
+		if i < len(quotient) {
+			var shifted fr.Element
+			shifted.Mul(&wl, &quotient[i])
+			tmp.Add(&tmp, &shifted)
+		}
+		if i >= l {
+			quotient[i-l].Set(&tmp)
+		} else {
+			remainder[i].Set(&tmp)
+		}
+	}
+
+	return quotient, remainder
+}
+
 // This Function takes extended evaluation data and bundles relevant information into Frame.
 // Every frame is verifiable to the commitment.
 func (g *Encoder) MakeFrames(
@@ -122,11 +177,12 @@ func (g *Encoder) MakeFrames(
 	return frames, indices, nil
 }
 
-// Encoding Reed Solomon using FFT
+// Encoding Reed Solomon using FFT. With InPlaceFFT, the padded coefficients are transformed in place, so only the
+// evaluations are returned and the returned coefficients are nil.
 func (g *Encoder) ExtendPolyEval(coeffs []fr.Element) ([]fr.Element, []fr.Element, error) {
 
-	if len(coeffs) > int(g.NumEvaluations()) {
-		return nil, nil, errors.New("the provided encoding parameters are not sufficient for the size of the data input")
+	if err := g.ValidateDataLength(uint64(len(coeffs))); err != nil {
+		return nil, nil, err
 	}
 
 	pdCoeffs := make([]fr.Element, g.NumEvaluations())
@@ -137,6 +193,13 @@ func (g *Encoder) ExtendPolyEval(coeffs []fr.Element) ([]fr.Element, []fr.Elemen
 		pdCoeffs[i].SetZero()
 	}
 
+	if g.InPlaceFFT {
+		if err := g.Fs.FFTInPlace(pdCoeffs, false); err != nil {
+			return nil, nil, err
+		}
+		return pdCoeffs, nil, nil
+	}
+
 	evals, err := g.Fs.FFT(pdCoeffs, false)
 	if err != nil {
 		return nil, nil, err
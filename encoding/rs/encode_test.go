@@ -58,6 +58,34 @@ func TestEncodeDecode_InvertsWhenSamplingMissingFrame(t *testing.T) {
 	assert.Equal(t, data, GETTYSBURG_ADDRESS_BYTES)
 }
 
+func TestEncodeDecode_InPlaceFFT(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	enc, _ := rs.NewEncoder(params, true)
+	require.NotNil(t, enc)
+	inPlaceEnc, _ := rs.NewEncoder(params, true)
+	require.NotNil(t, inPlaceEnc)
+	inPlaceEnc.InPlaceFFT = true
+
+	inputFr, err := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+	assert.Nil(t, err)
+	poly, frames, _, err := enc.Encode(inputFr)
+	assert.Nil(t, err)
+	inPlacePoly, inPlaceFrames, _, err := inPlaceEnc.Encode(inputFr)
+	assert.Nil(t, err)
+	assert.Equal(t, poly.Values, inPlacePoly.Values)
+	assert.Equal(t, frames, inPlaceFrames)
+
+	// sample some frames
+	samples, indices := sampleFrames(inPlaceFrames, uint64(len(inPlaceFrames)-1))
+	data, err := inPlaceEnc.Decode(samples, indices, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+
+	require.Nil(t, err)
+	assert.Equal(t, data, GETTYSBURG_ADDRESS_BYTES)
+}
+
 func TestEncodeDecode_ErrorsWhenNotEnoughSampledFrames(t *testing.T) {
 	teardownSuite := setupSuite(t)
 	defer teardownSuite(t)
@@ -80,5 +108,30 @@ func TestEncodeDecode_ErrorsWhenNotEnoughSampledFrames(t *testing.T) {
 	require.Nil(t, data)
 	require.NotNil(t, err)
 
-	assert.EqualError(t, err, "number of frame must be sufficient")
+	assert.ErrorIs(t, err, encoding.ErrInsufficientChunks)
+}
+
+func TestEncodeChunks_MatchesEncode(t *testing.T) {
+	teardownSuite := setupSuite(t)
+	defer teardownSuite(t)
+
+	params := encoding.ParamsFromSysPar(numSys, numPar, uint64(len(GETTYSBURG_ADDRESS_BYTES)))
+	enc, _ := rs.NewEncoder(params, true)
+	require.NotNil(t, enc)
+
+	inputFr, err := rs.ToFrArray(GETTYSBURG_ADDRESS_BYTES)
+	assert.Nil(t, err)
+	_, frames, _, err := enc.Encode(inputFr)
+	assert.Nil(t, err)
+
+	indices := []encoding.ChunkNumber{3, 0, encoding.ChunkNumber(len(frames) - 1)}
+	chunks, err := enc.EncodeChunks(inputFr, indices)
+	require.Nil(t, err)
+	require.Len(t, chunks, len(indices))
+	for i, index := range indices {
+		assert.Equal(t, frames[index].Coeffs, chunks[i].Coeffs)
+	}
+
+	_, err = enc.EncodeChunks(inputFr, []encoding.ChunkNumber{encoding.ChunkNumber(len(frames))})
+	assert.NotNil(t, err)
 }
@@ -16,6 +16,10 @@ type Encoder struct {
 	verbose bool
 
 	NumRSWorker int
+
+	// InPlaceFFT makes the encoder extend and recover the polynomials with in-place FFTs, which halves the peak
+	// memory of encoding and decoding large blobs
+	InPlaceFFT bool
 }
 
 // The function creates a high level struct that determines the encoding the a data of a
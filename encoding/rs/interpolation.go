@@ -22,7 +22,8 @@ func (g *Encoder) GetInterpolationPolyEval(
 ) ([]fr.Element, error) {
 	evals := make([]fr.Element, g.ChunkLength)
 	w := g.Fs.ExpandedRootsOfUnity[uint64(j)]
-	shiftedInterpolationPoly := make([]fr.Element, len(interpolationPoly))
+	shiftedInterpolationPoly := g.Fs.Scratch.Get(len(interpolationPoly))
+	defer g.Fs.Scratch.Put(shiftedInterpolationPoly)
 
 	//multiply each term of the polynomial by x^i so the fourier transform results in the desired evaluations
 	//The fourier matrix looks like
@@ -64,7 +65,8 @@ func (g *Encoder) GetInterpolationPolyEval(
 // Since both F W are invertible, c = W^-1 F^-1 d, convert it back. F W W^-1 F^-1 d = c
 func (g *Encoder) GetInterpolationPolyCoeff(chunk []fr.Element, k uint32) ([]fr.Element, error) {
 	coeffs := make([]fr.Element, g.ChunkLength)
-	shiftedInterpolationPoly := make([]fr.Element, len(chunk))
+	shiftedInterpolationPoly := g.Fs.Scratch.Get(len(chunk))
+	defer g.Fs.Scratch.Put(shiftedInterpolationPoly)
 	err := g.Fs.InplaceFFT(chunk, shiftedInterpolationPoly, true)
 	if err != nil {
 		return coeffs, err
@@ -1,13 +1,11 @@
 package rs
 
 import (
-	"errors"
-
 	"github.com/Layr-Labs/eigenda/encoding"
 )
 
 var (
-	ErrInvalidParams = errors.New("invalid encoding params")
+	ErrInvalidParams = encoding.ErrInvalidParams
 )
 
 type EncodingParams struct {
@@ -1,7 +1,7 @@
 package rs
 
 import (
-	"errors"
+	"fmt"
 	"math"
 
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -10,31 +10,55 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
 
+// ToFrArray converts the data to field elements, one per 32 byte symbol. The last symbol is padded with zeros
+// if the data isn't a whole number of symbols. Every symbol must be a canonical field element.
 func ToFrArray(data []byte) ([]fr.Element, error) {
 	numEle := GetNumElement(uint64(len(data)), encoding.BYTES_PER_SYMBOL)
 	eles := make([]fr.Element, numEle)
 
 	for i := uint64(0); i < numEle; i++ {
-		start := i * uint64(encoding.BYTES_PER_SYMBOL)
-		end := (i + 1) * uint64(encoding.BYTES_PER_SYMBOL)
-		if end >= uint64(len(data)) {
-			padded := make([]byte, encoding.BYTES_PER_SYMBOL)
-			copy(padded, data[start:])
-			err := eles[i].SetBytesCanonical(padded)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			err := eles[i].SetBytesCanonical(data[start:end])
-			if err != nil {
-				return nil, err
-			}
+		if err := setSymbol(&eles[i], data, i); err != nil {
+			return nil, err
 		}
 	}
 
 	return eles, nil
 }
 
+// ValidateSymbols checks that every 32 byte symbol of the data, read as a big-endian integer, is a canonical field
+// element, i.e. is less than the modulus of the bn254 scalar field. The last symbol is padded with zeros if the data
+// isn't a whole number of symbols. The data padded with codec.ConvertByPaddingEmptyByte, or framed with
+// codec.EncodePayload, is always valid. The error wraps encoding.ErrNonCanonicalSymbol and gives the offset of
+// the first invalid symbol.
+func ValidateSymbols(data []byte) error {
+	numEle := GetNumElement(uint64(len(data)), encoding.BYTES_PER_SYMBOL)
+	var ele fr.Element
+	for i := uint64(0); i < numEle; i++ {
+		if err := setSymbol(&ele, data, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSymbol sets the element to the i-th symbol of the data
+func setSymbol(ele *fr.Element, data []byte, i uint64) error {
+	start := i * uint64(encoding.BYTES_PER_SYMBOL)
+	end := (i + 1) * uint64(encoding.BYTES_PER_SYMBOL)
+	var symbol []byte
+	if end > uint64(len(data)) {
+		padded := make([]byte, encoding.BYTES_PER_SYMBOL)
+		copy(padded, data[start:])
+		symbol = padded
+	} else {
+		symbol = data[start:end]
+	}
+	if err := ele.SetBytesCanonical(symbol); err != nil {
+		return fmt.Errorf("%w: symbol %d at byte offset %d is not less than the bn254 scalar field modulus", encoding.ErrNonCanonicalSymbol, i, start)
+	}
+	return nil
+}
+
 // ToByteArray converts a list of Fr to a byte array
 func ToByteArray(dataFr []fr.Element, maxDataSize uint64) []byte {
 	n := len(dataFr)
@@ -82,6 +106,6 @@ func GetLeadingCosetIndex(i uint64, numChunks uint64) (uint32, error) {
 		j := rb.ReverseBitsLimited(uint32(numChunks), uint32(i))
 		return j, nil
 	} else {
-		return 0, errors.New("cannot create number of frame higher than possible")
+		return 0, fmt.Errorf("%w: cannot create number of frame higher than possible", encoding.ErrInvalidParams)
 	}
 }
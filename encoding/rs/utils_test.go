@@ -8,6 +8,8 @@ import (
 
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 )
 
 func TestGetEncodingParams(t *testing.T) {
@@ -56,3 +58,22 @@ func TestRoundUpDivision(t *testing.T) {
 	assert.Equal(t, a, uint64(1))
 	assert.Equal(t, b, uint64(5))
 }
+
+func TestValidateSymbols(t *testing.T) {
+	data := codec.ConvertByPaddingEmptyByte(GETTYSBURG_ADDRESS_BYTES)
+	assert.NoError(t, rs.ValidateSymbols(data))
+
+	// The modulus itself is the smallest non-canonical symbol
+	modulus := fr.Modulus().FillBytes(make([]byte, encoding.BYTES_PER_SYMBOL))
+	invalid := append(append([]byte{}, data[:64]...), modulus...)
+	err := rs.ValidateSymbols(invalid)
+	assert.ErrorIs(t, err, encoding.ErrNonCanonicalSymbol)
+	assert.ErrorContains(t, err, "symbol 2 at byte offset 64")
+	_, err = rs.ToFrArray(invalid)
+	assert.ErrorIs(t, err, encoding.ErrNonCanonicalSymbol)
+
+	// The last symbol is padded with zeros, so a partial symbol can be too large
+	err = rs.ValidateSymbols(append(append([]byte{}, data[:32]...), 0xff))
+	assert.ErrorContains(t, err, "symbol 1 at byte offset 32")
+	assert.NoError(t, rs.ValidateSymbols(append(append([]byte{}, data[:32]...), 0x01)))
+}
@@ -4,22 +4,144 @@ import (
 	"bytes"
 	"encoding/gob"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 )
 
+// ChunkEncodingFormat identifies the wire format used to serialize a Frame
+type ChunkEncodingFormat uint8
+
+const (
+	// GobChunkEncodingFormat gob-encodes the whole frame, with the proof as an uncompressed (64 byte) point
+	GobChunkEncodingFormat ChunkEncodingFormat = iota
+	// CompressedChunkEncodingFormat serializes the proof as a compressed (32 byte) point,
+	// followed by the coefficients as 32 byte big-endian field elements
+	CompressedChunkEncodingFormat
+)
+
+var ErrInvalidChunkEncodingFormat = errors.New("invalid chunk encoding format")
+
+// ParseChunkEncodingFormat returns the ChunkEncodingFormat with the given name
+func ParseChunkEncodingFormat(name string) (ChunkEncodingFormat, error) {
+	switch name {
+	case "gob":
+		return GobChunkEncodingFormat, nil
+	case "compressed":
+		return CompressedChunkEncodingFormat, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidChunkEncodingFormat, name)
+	}
+}
+
+func (f ChunkEncodingFormat) String() string {
+	switch f {
+	case GobChunkEncodingFormat:
+		return "gob"
+	case CompressedChunkEncodingFormat:
+		return "compressed"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(f))
+	}
+}
+
 func (c *Frame) Serialize() ([]byte, error) {
 	return encode(c)
 }
 
 func (c *Frame) Deserialize(data []byte) (*Frame, error) {
 	err := decode(data, c)
-	if !c.Proof.IsInSubGroup() {
+	if err != nil {
+		return nil, err
+	}
+
+	// gob decodes the raw limbs of the field elements, which are only valid if they're reduced: re-encoding them
+	// canonically must yield the same elements
+	var proof bn254.G1Affine
+	rawProof := c.Proof.RawBytes()
+	if _, err := proof.SetBytes(rawProof[:]); err != nil || !proof.Equal(&c.Proof) {
 		return nil, fmt.Errorf("proof is in not the subgroup")
 	}
+	for i := range c.Coeffs {
+		if !isCanonical(&c.Coeffs[i]) {
+			return nil, fmt.Errorf("invalid coefficient at index %d", i)
+		}
+	}
+	for i := range c.Evals {
+		if !isCanonical(&c.Evals[i]) {
+			return nil, fmt.Errorf("invalid evaluation at index %d", i)
+		}
+	}
+	if c.Form > CoeffAndEvalChunkForm {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidChunkForm, c.Form)
+	}
 
-	return c, err
+	return c, nil
+}
+
+// SerializeWithFormat serializes the frame in the given wire format
+func (c *Frame) SerializeWithFormat(format ChunkEncodingFormat) ([]byte, error) {
+	switch format {
+	case GobChunkEncodingFormat:
+		return c.Serialize()
+	case CompressedChunkEncodingFormat:
+		// the compressed format doesn't carry the form of the chunk
+		if c.Form != CoeffChunkForm {
+			return nil, fmt.Errorf("%w: the compressed format only supports %s chunks, got %s", ErrInvalidChunkForm, CoeffChunkForm, c.Form)
+		}
+		return c.serializeCompressed(), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidChunkEncodingFormat, format)
+	}
+}
+
+// DeserializeWithFormat deserializes a frame which was serialized in the given wire format.
+// The proof is checked to be in the subgroup regardless of the format.
+func (c *Frame) DeserializeWithFormat(data []byte, format ChunkEncodingFormat) (*Frame, error) {
+	switch format {
+	case GobChunkEncodingFormat:
+		return c.Deserialize(data)
+	case CompressedChunkEncodingFormat:
+		return c.deserializeCompressed(data)
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrInvalidChunkEncodingFormat, format)
+	}
+}
+
+func (c *Frame) serializeCompressed() []byte {
+	buf := make([]byte, 0, bn254.SizeOfG1AffineCompressed+len(c.Coeffs)*BYTES_PER_SYMBOL)
+	proof := c.Proof.Bytes()
+	buf = append(buf, proof[:]...)
+	for i := range c.Coeffs {
+		coeff := c.Coeffs[i].Bytes()
+		buf = append(buf, coeff[:]...)
+	}
+	return buf
+}
+
+func (c *Frame) deserializeCompressed(data []byte) (*Frame, error) {
+	if len(data) < bn254.SizeOfG1AffineCompressed || (len(data)-bn254.SizeOfG1AffineCompressed)%BYTES_PER_SYMBOL != 0 {
+		return nil, fmt.Errorf("invalid compressed frame length %d", len(data))
+	}
+
+	// SetBytes decompresses the point and checks that it is in the subgroup
+	_, err := c.Proof.SetBytes(data[:bn254.SizeOfG1AffineCompressed])
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof: %w", err)
+	}
+
+	data = data[bn254.SizeOfG1AffineCompressed:]
+	c.Coeffs = make([]Symbol, len(data)/BYTES_PER_SYMBOL)
+	for i := range c.Coeffs {
+		err = c.Coeffs[i].SetBytesCanonical(data[i*BYTES_PER_SYMBOL : (i+1)*BYTES_PER_SYMBOL])
+		if err != nil {
+			return nil, fmt.Errorf("invalid coefficient at index %d: %w", i, err)
+		}
+	}
+
+	return c, nil
 }
 
 func (f *Frame) Encode() ([]byte, error) {
@@ -101,6 +223,143 @@ func (c *G2Commitment) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// BinaryFormatVersion is the version of the binary format of the field elements and curve points, the first byte of
+// their MarshalBinary encoding. It's bumped whenever the layout of the encoding changes.
+const BinaryFormatVersion byte = 0
+
+var (
+	ErrUnsupportedBinaryFormatVersion = errors.New("unsupported binary format version")
+	ErrInvalidBinaryLength            = errors.New("invalid binary length")
+)
+
+// MarshalBinary encodes the commitment as the format version followed by the compressed point: the big-endian X
+// coordinate with the sign of Y in its most significant bits
+func (c *G1Commitment) MarshalBinary() ([]byte, error) {
+	compressed := (*bn254.G1Affine)(c).Bytes()
+	return append([]byte{BinaryFormatVersion}, compressed[:]...), nil
+}
+
+// UnmarshalBinary decodes a commitment encoded by MarshalBinary, checking that the point is on the curve and in the
+// subgroup
+func (c *G1Commitment) UnmarshalBinary(data []byte) error {
+	if err := checkBinaryFormat(data, bn254.SizeOfG1AffineCompressed); err != nil {
+		return err
+	}
+	var point bn254.G1Affine
+	// SetBytes checks that the coordinate is canonical and the point in the subgroup
+	if _, err := point.SetBytes(data[1:]); err != nil {
+		return fmt.Errorf("invalid G1 point: %w", err)
+	}
+	*c = G1Commitment(point)
+	return nil
+}
+
+// MarshalBinary encodes the commitment as the format version followed by the compressed point: the big-endian X
+// coordinate, A1 then A0, with the sign of Y in its most significant bits
+func (c *G2Commitment) MarshalBinary() ([]byte, error) {
+	compressed := (*bn254.G2Affine)(c).Bytes()
+	return append([]byte{BinaryFormatVersion}, compressed[:]...), nil
+}
+
+// UnmarshalBinary decodes a commitment encoded by MarshalBinary, checking that the point is on the curve and in the
+// subgroup
+func (c *G2Commitment) UnmarshalBinary(data []byte) error {
+	if err := checkBinaryFormat(data, bn254.SizeOfG2AffineCompressed); err != nil {
+		return err
+	}
+	var point bn254.G2Affine
+	if _, err := point.SetBytes(data[1:]); err != nil {
+		return fmt.Errorf("invalid G2 point: %w", err)
+	}
+	*c = G2Commitment(point)
+	return nil
+}
+
+// MarshalSymbol encodes the field element as the format version followed by its canonical big-endian value
+func MarshalSymbol(s *Symbol) []byte {
+	value := s.Bytes()
+	return append([]byte{BinaryFormatVersion}, value[:]...)
+}
+
+// UnmarshalSymbol decodes a field element encoded by MarshalSymbol, rejecting values which aren't reduced modulo the
+// field order
+func UnmarshalSymbol(data []byte) (Symbol, error) {
+	var s Symbol
+	if err := checkBinaryFormat(data, BYTES_PER_SYMBOL); err != nil {
+		return s, err
+	}
+	if err := s.SetBytesCanonical(data[1:]); err != nil {
+		return s, fmt.Errorf("invalid field element: %w", err)
+	}
+	return s, nil
+}
+
+// G1CommitmentFromCoordinates returns the commitment with the given big-endian affine coordinates, as they're
+// carried by the protobuf messages. The coordinates must be canonical and the point in the subgroup.
+func G1CommitmentFromCoordinates(x, y []byte) (*G1Commitment, error) {
+	var point bn254.G1Affine
+	if err := setCoordinate(&point.X, x); err != nil {
+		return nil, fmt.Errorf("invalid X coordinate: %w", err)
+	}
+	if err := setCoordinate(&point.Y, y); err != nil {
+		return nil, fmt.Errorf("invalid Y coordinate: %w", err)
+	}
+	if !point.IsOnCurve() || !point.IsInSubGroup() {
+		return nil, errors.New("G1 point is not in the subgroup")
+	}
+	return (*G1Commitment)(&point), nil
+}
+
+// G2CommitmentFromCoordinates returns the commitment with the given big-endian affine coordinates, as they're
+// carried by the protobuf messages. The coordinates must be canonical and the point in the subgroup.
+func G2CommitmentFromCoordinates(xA0, xA1, yA0, yA1 []byte) (*G2Commitment, error) {
+	var point bn254.G2Affine
+	for _, coordinate := range []struct {
+		name  string
+		e     *fp.Element
+		value []byte
+	}{
+		{"X.A0", &point.X.A0, xA0},
+		{"X.A1", &point.X.A1, xA1},
+		{"Y.A0", &point.Y.A0, yA0},
+		{"Y.A1", &point.Y.A1, yA1},
+	} {
+		if err := setCoordinate(coordinate.e, coordinate.value); err != nil {
+			return nil, fmt.Errorf("invalid %s coordinate: %w", coordinate.name, err)
+		}
+	}
+	if !point.IsOnCurve() || !point.IsInSubGroup() {
+		return nil, errors.New("G2 point is not in the subgroup")
+	}
+	return (*G2Commitment)(&point), nil
+}
+
+// setCoordinate sets e to the big-endian value, which must be reduced modulo the base field order. Values shorter
+// than fp.Bytes are left-padded with zeros, so a missing coordinate is zero.
+func setCoordinate(e *fp.Element, value []byte) error {
+	if len(value) > fp.Bytes {
+		return fmt.Errorf("%w: %d bytes", ErrInvalidBinaryLength, len(value))
+	}
+	var padded [fp.Bytes]byte
+	copy(padded[fp.Bytes-len(value):], value)
+	return e.SetBytesCanonical(padded[:])
+}
+
+// checkBinaryFormat checks that the data is an encoding of the current format version, of size bytes after the
+// version
+func checkBinaryFormat(data []byte, size int) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty", ErrInvalidBinaryLength)
+	}
+	if data[0] != BinaryFormatVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedBinaryFormatVersion, data[0])
+	}
+	if len(data) != 1+size {
+		return fmt.Errorf("%w: %d bytes, expected %d", ErrInvalidBinaryLength, len(data), 1+size)
+	}
+	return nil
+}
+
 func encode(obj any) ([]byte, error) {
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
@@ -0,0 +1,166 @@
+package encoding_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests in this file cross-check the serialization of the field elements and curve points against gnark-crypto,
+// so that a change of either side which silently changes the encoding is caught.
+
+func TestSerializationMatchesGnark(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	for i := 0; i < 32; i++ {
+		var scalar fr.Element
+		_, err := scalar.SetRandom()
+		require.NoError(t, err)
+		var g1 bn254.G1Affine
+		g1.ScalarMultiplication(&g1Gen, scalar.BigInt(new(big.Int)))
+		var g2 bn254.G2Affine
+		g2.ScalarMultiplication(&g2Gen, scalar.BigInt(new(big.Int)))
+
+		compressedG1 := g1.Bytes()
+		data, err := (*encoding.G1Commitment)(&g1).Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, compressedG1[:], data)
+		data, err = (*encoding.G1Commitment)(&g1).MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, compressedG1[:], data[1:])
+		rawG1 := g1.RawBytes()
+		decodedG1, err := new(encoding.G1Commitment).Deserialize(rawG1[:])
+		require.NoError(t, err)
+		assert.True(t, g1.Equal((*bn254.G1Affine)(decodedG1)))
+		x, y := g1.X.Bytes(), g1.Y.Bytes()
+		decodedG1, err = encoding.G1CommitmentFromCoordinates(x[:], y[:])
+		require.NoError(t, err)
+		assert.True(t, g1.Equal((*bn254.G1Affine)(decodedG1)))
+
+		compressedG2 := g2.Bytes()
+		data, err = (*encoding.G2Commitment)(&g2).Serialize()
+		require.NoError(t, err)
+		assert.Equal(t, compressedG2[:], data)
+		data, err = (*encoding.G2Commitment)(&g2).MarshalBinary()
+		require.NoError(t, err)
+		assert.Equal(t, compressedG2[:], data[1:])
+		rawG2 := g2.RawBytes()
+		decodedG2, err := new(encoding.G2Commitment).Deserialize(rawG2[:])
+		require.NoError(t, err)
+		assert.True(t, g2.Equal((*bn254.G2Affine)(decodedG2)))
+
+		symbol := scalar.Bytes()
+		assert.Equal(t, symbol[:], encoding.MarshalSymbol(&scalar)[1:])
+	}
+}
+
+func FuzzUnmarshalG1Commitment(f *testing.F) {
+	_, _, g1Gen, _ := bn254.Generators()
+	valid, err := (*encoding.G1Commitment)(&g1Gen).MarshalBinary()
+	require.NoError(f, err)
+	f.Add(valid)
+	f.Add(valid[:len(valid)-1])
+	f.Add(append([]byte{encoding.BinaryFormatVersion}, bytes.Repeat([]byte{0xff}, bn254.SizeOfG1AffineCompressed)...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var expected bn254.G1Affine
+		expectedErr := len(data) != 1+bn254.SizeOfG1AffineCompressed || data[0] != encoding.BinaryFormatVersion
+		if !expectedErr {
+			_, err := expected.SetBytes(data[1:])
+			expectedErr = err != nil
+		}
+
+		var c encoding.G1Commitment
+		err := c.UnmarshalBinary(data)
+		require.Equal(t, expectedErr, err != nil, "err %v", err)
+		if err == nil {
+			require.True(t, expected.Equal((*bn254.G1Affine)(&c)))
+			encoded, err := c.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, data, encoded)
+		}
+	})
+}
+
+func FuzzUnmarshalG2Commitment(f *testing.F) {
+	_, _, _, g2Gen := bn254.Generators()
+	valid, err := (*encoding.G2Commitment)(&g2Gen).MarshalBinary()
+	require.NoError(f, err)
+	f.Add(valid)
+	f.Add(valid[:len(valid)-1])
+	f.Add(append([]byte{encoding.BinaryFormatVersion}, bytes.Repeat([]byte{0xff}, bn254.SizeOfG2AffineCompressed)...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var expected bn254.G2Affine
+		expectedErr := len(data) != 1+bn254.SizeOfG2AffineCompressed || data[0] != encoding.BinaryFormatVersion
+		if !expectedErr {
+			_, err := expected.SetBytes(data[1:])
+			expectedErr = err != nil
+		}
+
+		var c encoding.G2Commitment
+		err := c.UnmarshalBinary(data)
+		require.Equal(t, expectedErr, err != nil, "err %v", err)
+		if err == nil {
+			require.True(t, expected.Equal((*bn254.G2Affine)(&c)))
+			encoded, err := c.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, data, encoded)
+		}
+	})
+}
+
+func FuzzUnmarshalSymbol(f *testing.F) {
+	var one fr.Element
+	one.SetOne()
+	f.Add(encoding.MarshalSymbol(&one))
+	f.Add(append([]byte{encoding.BinaryFormatVersion}, fr.Modulus().FillBytes(make([]byte, fr.Bytes))...))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var expected fr.Element
+		expectedErr := len(data) != 1+fr.Bytes || data[0] != encoding.BinaryFormatVersion
+		if !expectedErr {
+			expectedErr = expected.SetBytesCanonical(data[1:]) != nil
+		}
+
+		symbol, err := encoding.UnmarshalSymbol(data)
+		require.Equal(t, expectedErr, err != nil, "err %v", err)
+		if err == nil {
+			require.True(t, expected.Equal(&symbol))
+			require.Equal(t, data, encoding.MarshalSymbol(&symbol))
+		}
+	})
+}
+
+func FuzzG1CommitmentFromCoordinates(f *testing.F) {
+	_, _, g1Gen, _ := bn254.Generators()
+	x, y := g1Gen.X.Bytes(), g1Gen.Y.Bytes()
+	f.Add(x[:], y[:])
+	f.Add([]byte{}, []byte{})
+	f.Add(fp.Modulus().Bytes(), y[:])
+
+	f.Fuzz(func(t *testing.T, x, y []byte) {
+		// The coordinates are big-endian integers which must be reduced, and the point in the subgroup
+		expectedErr := len(x) > fp.Bytes || len(y) > fp.Bytes
+		var expected bn254.G1Affine
+		if !expectedErr {
+			xInt, yInt := new(big.Int).SetBytes(x), new(big.Int).SetBytes(y)
+			expectedErr = xInt.Cmp(fp.Modulus()) >= 0 || yInt.Cmp(fp.Modulus()) >= 0
+			expected.X.SetBigInt(xInt)
+			expected.Y.SetBigInt(yInt)
+			expectedErr = expectedErr || !expected.IsOnCurve() || !expected.IsInSubGroup()
+		}
+
+		c, err := encoding.G1CommitmentFromCoordinates(x, y)
+		require.Equal(t, expectedErr, err != nil, "err %v", err)
+		if err == nil {
+			require.True(t, expected.Equal((*bn254.G1Affine)(c)))
+		}
+	})
+}
@@ -0,0 +1,224 @@
+package encoding_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestFrame(t *testing.T, numCoeffs int) *encoding.Frame {
+	_, _, g1Gen, _ := bn254.Generators()
+
+	var scalar fr.Element
+	_, err := scalar.SetRandom()
+	require.NoError(t, err)
+
+	frame := &encoding.Frame{
+		Coeffs: make([]encoding.Symbol, numCoeffs),
+	}
+	frame.Proof.ScalarMultiplication(&g1Gen, scalar.BigInt(new(big.Int)))
+	for i := range frame.Coeffs {
+		_, err := frame.Coeffs[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return frame
+}
+
+func TestFrameSerializeWithFormat(t *testing.T) {
+	frame := makeTestFrame(t, 16)
+
+	for _, format := range []encoding.ChunkEncodingFormat{encoding.GobChunkEncodingFormat, encoding.CompressedChunkEncodingFormat} {
+		data, err := frame.SerializeWithFormat(format)
+		require.NoError(t, err)
+
+		decoded, err := new(encoding.Frame).DeserializeWithFormat(data, format)
+		require.NoError(t, err)
+		assert.True(t, decoded.Proof.Equal(&frame.Proof))
+		assert.Equal(t, frame.Coeffs, decoded.Coeffs)
+	}
+}
+
+func TestFrameSerializeEvalForm(t *testing.T) {
+	frame := makeTestFrame(t, 16)
+	frame.Evals, frame.Coeffs = frame.Coeffs, nil
+	frame.Form = encoding.EvalChunkForm
+
+	data, err := frame.SerializeWithFormat(encoding.GobChunkEncodingFormat)
+	require.NoError(t, err)
+	decoded, err := new(encoding.Frame).DeserializeWithFormat(data, encoding.GobChunkEncodingFormat)
+	require.NoError(t, err)
+	assert.Equal(t, encoding.EvalChunkForm, decoded.Form)
+	assert.Equal(t, frame.Evals, decoded.Evals)
+	assert.Equal(t, 16, decoded.Length())
+
+	// the compressed format can't tell the forms apart
+	_, err = frame.SerializeWithFormat(encoding.CompressedChunkEncodingFormat)
+	assert.ErrorIs(t, err, encoding.ErrInvalidChunkForm)
+}
+
+func TestCompressedFrameIsSmaller(t *testing.T) {
+	frame := makeTestFrame(t, 16)
+
+	gobData, err := frame.SerializeWithFormat(encoding.GobChunkEncodingFormat)
+	require.NoError(t, err)
+	compressedData, err := frame.SerializeWithFormat(encoding.CompressedChunkEncodingFormat)
+	require.NoError(t, err)
+
+	assert.Equal(t, bn254.SizeOfG1AffineCompressed+16*encoding.BYTES_PER_SYMBOL, len(compressedData))
+	assert.Less(t, len(compressedData), len(gobData))
+}
+
+func TestDeserializeCompressedFrameInvalid(t *testing.T) {
+	frame := makeTestFrame(t, 4)
+	data, err := frame.SerializeWithFormat(encoding.CompressedChunkEncodingFormat)
+	require.NoError(t, err)
+
+	// truncated coefficient
+	_, err = new(encoding.Frame).DeserializeWithFormat(data[:len(data)-1], encoding.CompressedChunkEncodingFormat)
+	assert.Error(t, err)
+
+	// truncated proof
+	_, err = new(encoding.Frame).DeserializeWithFormat(data[:16], encoding.CompressedChunkEncodingFormat)
+	assert.Error(t, err)
+
+	// proof whose x coordinate does not correspond to a point on the curve
+	invalid := make([]byte, len(data))
+	copy(invalid, data)
+	found := false
+	for x := byte(1); x < 255 && !found; x++ {
+		for i := 0; i < bn254.SizeOfG1AffineCompressed; i++ {
+			invalid[i] = 0
+		}
+		invalid[0] = 0x80 // compressed point with the smallest y
+		invalid[bn254.SizeOfG1AffineCompressed-1] = x
+		var p bn254.G1Affine
+		_, err = p.SetBytes(invalid[:bn254.SizeOfG1AffineCompressed])
+		found = err != nil
+	}
+	require.True(t, found)
+	_, err = new(encoding.Frame).DeserializeWithFormat(invalid, encoding.CompressedChunkEncodingFormat)
+	assert.Error(t, err)
+
+	// coefficient which is not a canonical field element
+	invalid = make([]byte, len(data))
+	copy(invalid, data)
+	for i := bn254.SizeOfG1AffineCompressed; i < bn254.SizeOfG1AffineCompressed+encoding.BYTES_PER_SYMBOL; i++ {
+		invalid[i] = 0xff
+	}
+	_, err = new(encoding.Frame).DeserializeWithFormat(invalid, encoding.CompressedChunkEncodingFormat)
+	assert.Error(t, err)
+
+	// unknown format
+	_, err = new(encoding.Frame).DeserializeWithFormat(data, encoding.ChunkEncodingFormat(100))
+	assert.ErrorIs(t, err, encoding.ErrInvalidChunkEncodingFormat)
+}
+
+func TestParseChunkEncodingFormat(t *testing.T) {
+	for _, format := range []encoding.ChunkEncodingFormat{encoding.GobChunkEncodingFormat, encoding.CompressedChunkEncodingFormat} {
+		parsed, err := encoding.ParseChunkEncodingFormat(format.String())
+		require.NoError(t, err)
+		assert.Equal(t, format, parsed)
+	}
+
+	_, err := encoding.ParseChunkEncodingFormat("unknown")
+	assert.ErrorIs(t, err, encoding.ErrInvalidChunkEncodingFormat)
+}
+
+func TestDeserializeGobFrameNonCanonical(t *testing.T) {
+	frame := makeTestFrame(t, 4)
+	// Limbs which aren't reduced modulo the field order
+	frame.Coeffs[2] = fr.Element{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+	data, err := frame.Serialize()
+	require.NoError(t, err)
+
+	_, err = new(encoding.Frame).Deserialize(data)
+	assert.Error(t, err)
+}
+
+func TestBinaryFormat(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	var scalar fr.Element
+	_, err := scalar.SetRandom()
+	require.NoError(t, err)
+	var g1 encoding.G1Commitment
+	(*bn254.G1Affine)(&g1).ScalarMultiplication(&g1Gen, scalar.BigInt(new(big.Int)))
+	var g2 encoding.G2Commitment
+	(*bn254.G2Affine)(&g2).ScalarMultiplication(&g2Gen, scalar.BigInt(new(big.Int)))
+
+	data, err := g1.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, 1+bn254.SizeOfG1AffineCompressed)
+	assert.Equal(t, encoding.BinaryFormatVersion, data[0])
+	var decodedG1 encoding.G1Commitment
+	require.NoError(t, decodedG1.UnmarshalBinary(data))
+	assert.Equal(t, g1, decodedG1)
+
+	// Trailing bytes, truncated points and unknown versions are rejected
+	assert.ErrorIs(t, decodedG1.UnmarshalBinary(append(data, 0)), encoding.ErrInvalidBinaryLength)
+	assert.ErrorIs(t, decodedG1.UnmarshalBinary(data[:len(data)-1]), encoding.ErrInvalidBinaryLength)
+	assert.ErrorIs(t, decodedG1.UnmarshalBinary(nil), encoding.ErrInvalidBinaryLength)
+	unknownVersion := append([]byte{encoding.BinaryFormatVersion + 1}, data[1:]...)
+	assert.ErrorIs(t, decodedG1.UnmarshalBinary(unknownVersion), encoding.ErrUnsupportedBinaryFormatVersion)
+
+	data, err = g2.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, 1+bn254.SizeOfG2AffineCompressed)
+	var decodedG2 encoding.G2Commitment
+	require.NoError(t, decodedG2.UnmarshalBinary(data))
+	assert.Equal(t, g2, decodedG2)
+	// A G1 point isn't a G2 point
+	g1Data, err := g1.MarshalBinary()
+	require.NoError(t, err)
+	assert.ErrorIs(t, decodedG2.UnmarshalBinary(g1Data), encoding.ErrInvalidBinaryLength)
+
+	data = encoding.MarshalSymbol(&scalar)
+	require.Len(t, data, 1+encoding.BYTES_PER_SYMBOL)
+	decodedSymbol, err := encoding.UnmarshalSymbol(data)
+	require.NoError(t, err)
+	assert.Equal(t, scalar, decodedSymbol)
+	// The field order itself isn't reduced
+	modulus := fr.Modulus().FillBytes(make([]byte, encoding.BYTES_PER_SYMBOL))
+	_, err = encoding.UnmarshalSymbol(append([]byte{encoding.BinaryFormatVersion}, modulus...))
+	assert.Error(t, err)
+}
+
+func TestCommitmentFromCoordinates(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+
+	x, y := g1Gen.X.Bytes(), g1Gen.Y.Bytes()
+	g1, err := encoding.G1CommitmentFromCoordinates(x[:], y[:])
+	require.NoError(t, err)
+	assert.Equal(t, g1Gen, bn254.G1Affine(*g1))
+
+	// Missing coordinates are the point at infinity
+	g1, err = encoding.G1CommitmentFromCoordinates(nil, nil)
+	require.NoError(t, err)
+	assert.True(t, (*bn254.G1Affine)(g1).IsInfinity())
+
+	// Coordinates which aren't reduced, or off the curve, are rejected
+	var offCurve bn254.G1Affine
+	offCurve.X.SetOne()
+	offCurve.Y.SetOne()
+	offX, offY := offCurve.X.Bytes(), offCurve.Y.Bytes()
+	_, err = encoding.G1CommitmentFromCoordinates(offX[:], offY[:])
+	assert.Error(t, err)
+	var xPlusModulus big.Int
+	xPlusModulus.Add(g1Gen.X.BigInt(new(big.Int)), fp.Modulus())
+	_, err = encoding.G1CommitmentFromCoordinates(xPlusModulus.Bytes(), y[:])
+	assert.Error(t, err)
+	_, err = encoding.G1CommitmentFromCoordinates(append([]byte{0}, x[:]...), y[:])
+	assert.ErrorIs(t, err, encoding.ErrInvalidBinaryLength)
+
+	xA0, xA1, yA0, yA1 := g2Gen.X.A0.Bytes(), g2Gen.X.A1.Bytes(), g2Gen.Y.A0.Bytes(), g2Gen.Y.A1.Bytes()
+	g2, err := encoding.G2CommitmentFromCoordinates(xA0[:], xA1[:], yA0[:], yA1[:])
+	require.NoError(t, err)
+	assert.Equal(t, g2Gen, bn254.G2Affine(*g2))
+	_, err = encoding.G2CommitmentFromCoordinates(xA1[:], xA0[:], yA0[:], yA1[:])
+	assert.Error(t, err)
+}
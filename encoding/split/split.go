@@ -0,0 +1,169 @@
+// Package split partitions blobs whose polynomial exceeds the SRS loaded by the disperser into parts that each
+// fit within it, and reassembles them. The parts are described by a manifest which commits to their data, so that
+// a blob reassembled from retrieved parts can be checked against the manifest.
+package split
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	manifestVersion = 0
+	// manifestHeaderSize is the size of the version, the blob size and the number of parts
+	manifestHeaderSize = 1 + 8 + 4
+	// partSize is the serialized size of a part: its size, hash, batch header hash, blob index,
+	// reference block number and batch root
+	partSize = 8 + 32 + 32 + 4 + 4 + 32
+)
+
+// Part describes a part of a split blob
+type Part struct {
+	// Size is the size of the part in bytes. Retrieved parts may be padded with zeros past it
+	Size uint64
+	// Hash is the keccak256 hash of the part
+	Hash [32]byte
+
+	// The location of the part once it's dispersed, needed to retrieve it
+	BatchHeaderHash      [32]byte
+	BlobIndex            uint32
+	ReferenceBlockNumber uint32
+	BatchRoot            [32]byte
+}
+
+// Manifest describes how a blob is split into parts
+type Manifest struct {
+	// Size is the size of the blob in bytes
+	Size  uint64
+	Parts []Part
+}
+
+// MaxPartSize returns the size in bytes of the largest part whose polynomial fits within srsNumberToLoad SRS points
+func MaxPartSize(srsNumberToLoad uint64) uint64 {
+	return srsNumberToLoad * encoding.BYTES_PER_SYMBOL
+}
+
+// Split partitions data into parts of at most maxPartSize bytes, and returns them with their manifest. The parts
+// are split on symbol boundaries so that each part of a valid blob is itself a valid blob. The locations of the
+// parts in the manifest are left empty until they're dispersed.
+func Split(data []byte, maxPartSize uint64) ([][]byte, *Manifest, error) {
+	maxPartSize -= maxPartSize % encoding.BYTES_PER_SYMBOL
+	if maxPartSize == 0 {
+		return nil, nil, fmt.Errorf("max part size must be at least %d bytes", encoding.BYTES_PER_SYMBOL)
+	}
+	if len(data) == 0 {
+		return nil, nil, errors.New("data is empty")
+	}
+
+	numParts := (uint64(len(data)) + maxPartSize - 1) / maxPartSize
+	parts := make([][]byte, numParts)
+	manifest := &Manifest{
+		Size:  uint64(len(data)),
+		Parts: make([]Part, numParts),
+	}
+	for i := range parts {
+		start := uint64(i) * maxPartSize
+		end := min(start+maxPartSize, uint64(len(data)))
+		parts[i] = data[start:end]
+		manifest.Parts[i] = Part{
+			Size: end - start,
+			Hash: crypto.Keccak256Hash(parts[i]),
+		}
+	}
+	return parts, manifest, nil
+}
+
+// Join reassembles the blob described by the manifest from its parts, in the order of the manifest. Trailing
+// padding past the size of each part is discarded, and each part is checked against its hash.
+func Join(manifest *Manifest, parts [][]byte) ([]byte, error) {
+	if len(parts) != len(manifest.Parts) {
+		return nil, fmt.Errorf("manifest has %d parts, got %d", len(manifest.Parts), len(parts))
+	}
+
+	data := make([]byte, 0, manifest.Size)
+	for i, part := range parts {
+		expected := manifest.Parts[i]
+		if uint64(len(part)) < expected.Size {
+			return nil, fmt.Errorf("part %d is %d bytes, expected at least %d", i, len(part), expected.Size)
+		}
+		part = part[:expected.Size]
+		if crypto.Keccak256Hash(part) != expected.Hash {
+			return nil, fmt.Errorf("part %d doesn't match its hash", i)
+		}
+		data = append(data, part...)
+	}
+	if uint64(len(data)) != manifest.Size {
+		return nil, fmt.Errorf("parts are %d bytes, expected %d", len(data), manifest.Size)
+	}
+	return data, nil
+}
+
+// Root returns the hash committing to the size of the blob and to the sizes and hashes of its parts. It doesn't
+// depend on where the parts are dispersed.
+func (m *Manifest) Root() [32]byte {
+	buf := make([]byte, 0, 8+len(m.Parts)*(8+32))
+	buf = binary.BigEndian.AppendUint64(buf, m.Size)
+	for _, part := range m.Parts {
+		buf = binary.BigEndian.AppendUint64(buf, part.Size)
+		buf = append(buf, part.Hash[:]...)
+	}
+	return crypto.Keccak256Hash(buf)
+}
+
+// Serialize encodes the manifest, so that it can be stored or dispersed as a blob itself
+func (m *Manifest) Serialize() []byte {
+	buf := make([]byte, 0, manifestHeaderSize+len(m.Parts)*partSize)
+	buf = append(buf, manifestVersion)
+	buf = binary.BigEndian.AppendUint64(buf, m.Size)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(m.Parts)))
+	for _, part := range m.Parts {
+		buf = binary.BigEndian.AppendUint64(buf, part.Size)
+		buf = append(buf, part.Hash[:]...)
+		buf = append(buf, part.BatchHeaderHash[:]...)
+		buf = binary.BigEndian.AppendUint32(buf, part.BlobIndex)
+		buf = binary.BigEndian.AppendUint32(buf, part.ReferenceBlockNumber)
+		buf = append(buf, part.BatchRoot[:]...)
+	}
+	return buf
+}
+
+// DeserializeManifest decodes a manifest encoded by Serialize. Trailing zeros are ignored, so that a manifest
+// retrieved as a padded blob can be decoded.
+func DeserializeManifest(data []byte) (*Manifest, error) {
+	if len(data) < manifestHeaderSize {
+		return nil, errors.New("manifest is too short")
+	}
+	if data[0] != manifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", data[0])
+	}
+
+	manifest := &Manifest{
+		Size: binary.BigEndian.Uint64(data[1:9]),
+	}
+	numParts := uint64(binary.BigEndian.Uint32(data[9:13]))
+	data = data[manifestHeaderSize:]
+	if uint64(len(data)) < numParts*partSize {
+		return nil, fmt.Errorf("manifest is too short for %d parts", numParts)
+	}
+	if len(bytes.Trim(data[numParts*partSize:], "\x00")) != 0 {
+		return nil, errors.New("manifest has trailing data")
+	}
+
+	manifest.Parts = make([]Part, numParts)
+	for i := range manifest.Parts {
+		part := &manifest.Parts[i]
+		part.Size = binary.BigEndian.Uint64(data[0:8])
+		copy(part.Hash[:], data[8:40])
+		copy(part.BatchHeaderHash[:], data[40:72])
+		part.BlobIndex = binary.BigEndian.Uint32(data[72:76])
+		part.ReferenceBlockNumber = binary.BigEndian.Uint32(data[76:80])
+		copy(part.BatchRoot[:], data[80:112])
+		data = data[partSize:]
+	}
+	return manifest, nil
+}
@@ -0,0 +1,79 @@
+package split_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/split"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitJoin(t *testing.T) {
+	data := make([]byte, 1000)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	// The max part size is rounded down to a whole number of symbols
+	parts, manifest, err := split.Split(data, 300)
+	require.NoError(t, err)
+	require.Len(t, parts, 4)
+	assert.Equal(t, uint64(1000), manifest.Size)
+	for i, size := range []uint64{288, 288, 288, 136} {
+		assert.Len(t, parts[i], int(size))
+		assert.Equal(t, size, manifest.Parts[i].Size)
+	}
+
+	// Retrieved parts are padded with zeros
+	padded := make([][]byte, len(parts))
+	for i, part := range parts {
+		padded[i] = append(append([]byte{}, part...), make([]byte, 32)...)
+	}
+	joined, err := split.Join(manifest, padded)
+	require.NoError(t, err)
+	assert.Equal(t, data, joined)
+}
+
+func TestJoinCorruptPart(t *testing.T) {
+	data := make([]byte, 1000)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	parts, manifest, err := split.Split(data, split.MaxPartSize(16))
+	require.NoError(t, err)
+	parts[1] = append([]byte{}, parts[1]...)
+	parts[1][0] ^= 1
+	_, err = split.Join(manifest, parts)
+	assert.ErrorContains(t, err, "part 1 doesn't match its hash")
+
+	_, err = split.Join(manifest, parts[:1])
+	assert.ErrorContains(t, err, "manifest has 2 parts")
+}
+
+func TestManifestSerialization(t *testing.T) {
+	data := make([]byte, 1000)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	_, manifest, err := split.Split(data, 512)
+	require.NoError(t, err)
+	manifest.Parts[0].BatchHeaderHash = [32]byte{1}
+	manifest.Parts[0].BlobIndex = 2
+	manifest.Parts[1].ReferenceBlockNumber = 3
+	manifest.Parts[1].BatchRoot = [32]byte{4}
+
+	serialized := append(manifest.Serialize(), make([]byte, 64)...)
+	deserialized, err := split.DeserializeManifest(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, deserialized)
+
+	// The root only commits to the data of the parts
+	root := manifest.Root()
+	manifest.Parts[0].BlobIndex = 5
+	assert.Equal(t, root, manifest.Root())
+	manifest.Parts[0].Hash[0] ^= 1
+	assert.NotEqual(t, root, manifest.Root())
+
+	_, err = split.DeserializeManifest(serialized[:20])
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+// Package transcript derives Fiat-Shamir challenges from the messages of a protocol.
+//
+// A Transcript is a sponge over a hash function: the messages are absorbed with a label, and the challenges are
+// squeezed as uniformly distributed elements of the scalar field of BN254. Every absorbed message is framed with its
+// label and length, so two different sequences of messages can't be absorbed as the same bytes, and every challenge
+// is absorbed back, so the challenges squeezed in turn are independent.
+//
+// The challenges depend on every message absorbed before them, so a verifier must absorb everything the prover could
+// choose, e.g. the commitments, the evaluations and the proofs, before squeezing the challenges it checks them with.
+package transcript
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+// Transcript is the state of the challenge derivation of a protocol. It's not safe for concurrent use.
+type Transcript struct {
+	domain []byte
+	state  hash.Hash
+}
+
+// New returns a transcript over SHA-256. The domain separates the challenges of the protocol from the challenges of
+// the other protocols, and must not be empty.
+func New(domain []byte) (*Transcript, error) {
+	return newTranscript(domain, sha256.New())
+}
+
+// NewKeccak returns a transcript over keccak256, e.g. for challenges which are also derived by the contracts
+func NewKeccak(domain []byte) (*Transcript, error) {
+	return newTranscript(domain, sha3.NewLegacyKeccak256())
+}
+
+func newTranscript(domain []byte, state hash.Hash) (*Transcript, error) {
+	if len(domain) == 0 {
+		return nil, errors.New("the domain separation tag must not be empty")
+	}
+	t := &Transcript{
+		domain: append([]byte(nil), domain...),
+		state:  state,
+	}
+	t.AppendBytes("domain", domain)
+	return t, nil
+}
+
+// AppendBytes absorbs a message
+func (t *Transcript) AppendBytes(label string, data []byte) {
+	t.writeFramed([]byte(label))
+	t.writeFramed(data)
+}
+
+// AppendUint64 absorbs an integer, e.g. a length or an index
+func (t *Transcript) AppendUint64(label string, value uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], value)
+	t.AppendBytes(label, b[:])
+}
+
+// AppendScalar absorbs a field element in its canonical big-endian form
+func (t *Transcript) AppendScalar(label string, s *fr.Element) {
+	b := s.Bytes()
+	t.AppendBytes(label, b[:])
+}
+
+// AppendScalars absorbs a vector of field elements as a single message
+func (t *Transcript) AppendScalars(label string, s []fr.Element) {
+	data := make([]byte, 0, len(s)*fr.Bytes)
+	for i := range s {
+		b := s[i].Bytes()
+		data = append(data, b[:]...)
+	}
+	t.AppendBytes(label, data)
+}
+
+// AppendG1 absorbs a point of G1 in its compressed form
+func (t *Transcript) AppendG1(label string, p *bn254.G1Affine) {
+	b := p.Bytes()
+	t.AppendBytes(label, b[:])
+}
+
+// AppendG2 absorbs a point of G2 in its compressed form
+func (t *Transcript) AppendG2(label string, p *bn254.G2Affine) {
+	b := p.Bytes()
+	t.AppendBytes(label, b[:])
+}
+
+// ChallengeScalar squeezes a challenge
+func (t *Transcript) ChallengeScalar(label string) (fr.Element, error) {
+	challenges, err := t.ChallengeScalars(label, 1)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return challenges[0], nil
+}
+
+// ChallengeScalars squeezes count independent challenges. The digest of the transcript is mapped to the field with
+// the hash_to_field construction of RFC 9380, which unlike the reduction of a hash modulo r isn't biased.
+func (t *Transcript) ChallengeScalars(label string, count int) ([]fr.Element, error) {
+	if count <= 0 {
+		return nil, errors.New("the number of challenges must be positive")
+	}
+	t.AppendBytes("challenge", []byte(label))
+	digest := t.state.Sum(nil)
+	challenges, err := fr.Hash(digest, t.domain, count)
+	if err != nil {
+		return nil, err
+	}
+
+	// The state is ratcheted, so that the next challenges depend on these ones
+	t.state.Reset()
+	t.AppendBytes("digest", digest)
+	return challenges, nil
+}
+
+// writeFramed writes the length of data followed by data, so that the boundaries of the messages are unambiguous
+func (t *Transcript) writeFramed(data []byte) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+	t.state.Write(length[:])
+	t.state.Write(data)
+}
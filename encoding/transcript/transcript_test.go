@@ -0,0 +1,87 @@
+package transcript_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/transcript"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDomain = []byte("EIGENDA_TRANSCRIPT_TEST")
+
+func challenge(t *testing.T, tr *transcript.Transcript) fr.Element {
+	c, err := tr.ChallengeScalar("challenge")
+	require.NoError(t, err)
+	return c
+}
+
+func TestTranscriptIsDeterministic(t *testing.T) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	var s fr.Element
+	s.SetUint64(42)
+
+	build := func() *transcript.Transcript {
+		tr, err := transcript.New(testDomain)
+		require.NoError(t, err)
+		tr.AppendBytes("bytes", []byte("message"))
+		tr.AppendUint64("length", 7)
+		tr.AppendScalar("scalar", &s)
+		tr.AppendScalars("scalars", []fr.Element{s, s})
+		tr.AppendG1("g1", &g1Gen)
+		tr.AppendG2("g2", &g2Gen)
+		return tr
+	}
+
+	first, second := build(), build()
+	c := challenge(t, first)
+	assert.Equal(t, c, challenge(t, second))
+
+	// The next challenges depend on the previous ones
+	next := challenge(t, first)
+	assert.NotEqual(t, c, next)
+	assert.Equal(t, next, challenge(t, second))
+}
+
+func TestTranscriptSeparation(t *testing.T) {
+	absorb := func(domain []byte, keccak bool, messages ...[2]string) fr.Element {
+		var tr *transcript.Transcript
+		var err error
+		if keccak {
+			tr, err = transcript.NewKeccak(domain)
+		} else {
+			tr, err = transcript.New(domain)
+		}
+		require.NoError(t, err)
+		for _, m := range messages {
+			tr.AppendBytes(m[0], []byte(m[1]))
+		}
+		return challenge(t, tr)
+	}
+
+	c := absorb(testDomain, false, [2]string{"a", "bc"})
+	assert.NotEqual(t, c, absorb([]byte("OTHER_DOMAIN"), false, [2]string{"a", "bc"}))
+	assert.NotEqual(t, c, absorb(testDomain, true, [2]string{"a", "bc"}))
+	assert.NotEqual(t, c, absorb(testDomain, false, [2]string{"b", "bc"}))
+	assert.NotEqual(t, c, absorb(testDomain, false, [2]string{"a", "bd"}))
+	// The messages are framed, so moving bytes across labels and messages changes the challenge
+	assert.NotEqual(t, c, absorb(testDomain, false, [2]string{"ab", "c"}))
+	assert.NotEqual(t, c, absorb(testDomain, false, [2]string{"a", "b"}, [2]string{"", "c"}))
+}
+
+func TestTranscriptChallengeScalars(t *testing.T) {
+	tr, err := transcript.New(testDomain)
+	require.NoError(t, err)
+	challenges, err := tr.ChallengeScalars("challenges", 3)
+	require.NoError(t, err)
+	require.Len(t, challenges, 3)
+	assert.NotEqual(t, challenges[0], challenges[1])
+	assert.NotEqual(t, challenges[1], challenges[2])
+
+	_, err = tr.ChallengeScalars("challenges", 0)
+	assert.Error(t, err)
+	_, err = transcript.New(nil)
+	assert.Error(t, err)
+}
@@ -1,9 +1,9 @@
 package encoding
 
 import (
-	"golang.org/x/exp/constraints"
-
 	"math"
+
+	"github.com/Layr-Labs/eigenda/encoding/utils/intmath"
 )
 
 // GetBlobLength converts from blob size in bytes to blob size in symbols
@@ -19,14 +19,10 @@ func GetBlobSize(blobLength uint) uint {
 
 // GetBlobLength converts from blob size in bytes to blob size in symbols
 func GetEncodedBlobLength(blobLength uint, quorumThreshold, advThreshold uint8) uint {
-	return roundUpDivide(blobLength*100, uint(quorumThreshold-advThreshold))
+	return intmath.EncodedLength(blobLength, quorumThreshold, advThreshold)
 }
 
 func NextPowerOf2(d uint64) uint64 {
 	nextPower := math.Ceil(math.Log2(float64(d)))
 	return uint64(math.Pow(2.0, nextPower))
 }
-
-func roundUpDivide[T constraints.Integer](a, b T) T {
-	return (a + b - 1) / b
-}
@@ -0,0 +1,93 @@
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+// PayloadEncodingVersion0 frames a payload with a header symbol holding its length in bytes, followed by the
+// payload padded with ConvertByPaddingEmptyByte.
+const PayloadEncodingVersion0 byte = 0x0
+
+// payloadHeaderLength is the number of bytes of the header symbol used by the version and the payload length:
+// the empty byte, the version byte, and the length as a 4 byte big-endian integer. The rest of the symbol is zero.
+const payloadHeaderLength = 6
+
+var (
+	// ErrInvalidPayloadEncoding is returned when a blob isn't a payload framed by EncodePayload
+	ErrInvalidPayloadEncoding = errors.New("invalid payload encoding")
+	// ErrNonCanonicalPayload is returned when a blob holds a framed payload, but isn't the unique encoding of that
+	// payload, e.g. its padding isn't zero
+	ErrNonCanonicalPayload = errors.New("non-canonical payload encoding")
+)
+
+// EncodePayload frames the payload with its length, so that payloads differing only by trailing zero bytes map to
+// different blobs, and pads it so every 32 bytes are a valid field element. The output is a whole number of symbols.
+func EncodePayload(payload []byte) ([]byte, error) {
+	if uint64(len(payload)) > math.MaxUint32 {
+		return nil, fmt.Errorf("payload of %d bytes is too large to encode", len(payload))
+	}
+
+	data := make([]byte, EncodedPayloadLength(len(payload))*encoding.BYTES_PER_SYMBOL)
+	data[1] = PayloadEncodingVersion0
+	binary.BigEndian.PutUint32(data[2:payloadHeaderLength], uint32(len(payload)))
+	copy(data[encoding.BYTES_PER_SYMBOL:], ConvertByPaddingEmptyByte(payload))
+	return data, nil
+}
+
+// EncodedPayloadLength returns the length, in symbols, of the blob encoding a payload of payloadSize bytes
+func EncodedPayloadLength(payloadSize int) uint {
+	parseSize := encoding.BYTES_PER_SYMBOL - 1
+	return 1 + uint((payloadSize+parseSize-1)/parseSize)
+}
+
+// DecodePayload returns the payload framed in the data by EncodePayload. The data may be followed by zero
+// padding, e.g. up to a power of 2 symbols, but every byte past the payload must be zero.
+func DecodePayload(data []byte) ([]byte, error) {
+	if len(data) < encoding.BYTES_PER_SYMBOL {
+		return nil, fmt.Errorf("%w: %d bytes is shorter than the header", ErrInvalidPayloadEncoding, len(data))
+	}
+	if data[0] != 0 {
+		return nil, fmt.Errorf("%w: the first byte of the header must be zero", ErrInvalidPayloadEncoding)
+	}
+	if data[1] != PayloadEncodingVersion0 {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidPayloadEncoding, data[1])
+	}
+	if !isZero(data[payloadHeaderLength:encoding.BYTES_PER_SYMBOL]) {
+		return nil, fmt.Errorf("%w: the header padding isn't zero", ErrNonCanonicalPayload)
+	}
+
+	payloadSize := int(binary.BigEndian.Uint32(data[2:payloadHeaderLength]))
+	encodedSize := int(EncodedPayloadLength(payloadSize)) * encoding.BYTES_PER_SYMBOL
+	if encodedSize > len(data) {
+		return nil, fmt.Errorf("%w: payload of %d bytes doesn't fit in %d bytes", ErrInvalidPayloadEncoding, payloadSize, len(data))
+	}
+	if !isZero(data[encodedSize:]) {
+		return nil, fmt.Errorf("%w: the padding after the payload isn't zero", ErrNonCanonicalPayload)
+	}
+
+	body := data[encoding.BYTES_PER_SYMBOL:encodedSize]
+	for i := 0; i < len(body); i += encoding.BYTES_PER_SYMBOL {
+		if body[i] != 0 {
+			return nil, fmt.Errorf("%w: the first byte of symbol %d isn't zero", ErrNonCanonicalPayload, i/encoding.BYTES_PER_SYMBOL+1)
+		}
+	}
+	unpadded := RemoveEmptyByteFromPaddedBytes(body)
+	if !isZero(unpadded[payloadSize:]) {
+		return nil, fmt.Errorf("%w: the bytes after the payload aren't zero", ErrNonCanonicalPayload)
+	}
+	return unpadded[:payloadSize], nil
+}
+
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
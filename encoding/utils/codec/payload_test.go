@@ -0,0 +1,89 @@
+package codec_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayloadEncoding(t *testing.T) {
+	// Sizes at the boundaries of the symbols
+	for _, size := range []int{0, 1, 30, 31, 32, 61, 62, 63, 93, 1000} {
+		payload := make([]byte, size)
+		_, err := rand.Read(payload)
+		require.NoError(t, err)
+
+		data, err := codec.EncodePayload(payload)
+		require.NoError(t, err)
+		assert.Len(t, data, int(codec.EncodedPayloadLength(size))*encoding.BYTES_PER_SYMBOL)
+		_, err = rs.ToFrArray(data)
+		require.NoError(t, err)
+
+		decoded, err := codec.DecodePayload(data)
+		require.NoError(t, err)
+		assert.Equal(t, payload, decoded)
+
+		// Zero padding after the payload, e.g. up to a power of 2 symbols, is ignored
+		decoded, err = codec.DecodePayload(append(data, make([]byte, 2*encoding.BYTES_PER_SYMBOL)...))
+		require.NoError(t, err)
+		assert.Equal(t, payload, decoded)
+	}
+}
+
+func TestPayloadEncodingTrailingZeros(t *testing.T) {
+	// Payloads differing only by trailing zero bytes are encoded into different blobs
+	for _, size := range []int{1, 30, 31, 62} {
+		payload := make([]byte, size)
+		payload[0] = 1
+		padded := append(append([]byte{}, payload...), 0)
+
+		data, err := codec.EncodePayload(payload)
+		require.NoError(t, err)
+		paddedData, err := codec.EncodePayload(padded)
+		require.NoError(t, err)
+		assert.NotEqual(t, data, paddedData)
+
+		decoded, err := codec.DecodePayload(paddedData)
+		require.NoError(t, err)
+		assert.Equal(t, padded, decoded)
+	}
+}
+
+func TestDecodePayloadInvalid(t *testing.T) {
+	payload := []byte("Fourscore and seven years ago")
+	data, err := codec.EncodePayload(payload)
+	require.NoError(t, err)
+
+	_, err = codec.DecodePayload(data[:encoding.BYTES_PER_SYMBOL-1])
+	assert.ErrorIs(t, err, codec.ErrInvalidPayloadEncoding)
+
+	_, err = codec.DecodePayload(data[:encoding.BYTES_PER_SYMBOL])
+	assert.ErrorIs(t, err, codec.ErrInvalidPayloadEncoding)
+
+	tampered := append([]byte{}, data...)
+	tampered[1] = 1
+	_, err = codec.DecodePayload(tampered)
+	assert.ErrorIs(t, err, codec.ErrInvalidPayloadEncoding)
+
+	// A non-zero byte after the payload, within its last symbol
+	tampered = append([]byte{}, data...)
+	tampered[len(tampered)-1] = 1
+	_, err = codec.DecodePayload(tampered)
+	assert.ErrorIs(t, err, codec.ErrNonCanonicalPayload)
+
+	// A non-zero byte in the padding after the payload
+	tampered = append(append([]byte{}, data...), make([]byte, encoding.BYTES_PER_SYMBOL)...)
+	tampered[len(tampered)-1] = 1
+	_, err = codec.DecodePayload(tampered)
+	assert.ErrorIs(t, err, codec.ErrNonCanonicalPayload)
+
+	tampered = append([]byte{}, data...)
+	tampered[encoding.BYTES_PER_SYMBOL-1] = 1
+	_, err = codec.DecodePayload(tampered)
+	assert.ErrorIs(t, err, codec.ErrNonCanonicalPayload)
+}
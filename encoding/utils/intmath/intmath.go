@@ -0,0 +1,17 @@
+// Package intmath contains the integer arithmetic shared by the encoding and the threshold checks. It has no
+// dependency on either, so that both can use it.
+package intmath
+
+import "golang.org/x/exp/constraints"
+
+// RoundUpDivide returns ceil(a / b). b must be positive.
+func RoundUpDivide[T constraints.Integer](a, b T) T {
+	return (a + b - 1) / b
+}
+
+// EncodedLength returns the length of data of the given length once erasure coded at the rate implied by the
+// confirmation and adversary thresholds, in percent, i.e. ceil(length * 100 / (confirmationThreshold - adversaryThreshold)).
+// The confirmation threshold must exceed the adversary threshold.
+func EncodedLength[T constraints.Integer](length T, confirmationThreshold, adversaryThreshold uint8) T {
+	return RoundUpDivide(length*100, T(confirmationThreshold-adversaryThreshold))
+}
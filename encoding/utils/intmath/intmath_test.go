@@ -0,0 +1,20 @@
+package intmath_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/utils/intmath"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundUpDivide(t *testing.T) {
+	assert.Equal(t, 0, intmath.RoundUpDivide(0, 3))
+	assert.Equal(t, 1, intmath.RoundUpDivide(1, 3))
+	assert.Equal(t, 1, intmath.RoundUpDivide(3, 3))
+	assert.Equal(t, uint64(2), intmath.RoundUpDivide(uint64(4), 3))
+}
+
+func TestEncodedLength(t *testing.T) {
+	assert.Equal(t, uint(100), intmath.EncodedLength(uint(10), 90, 80))
+	assert.Equal(t, uint(34), intmath.EncodedLength(uint(11), 67, 34))
+}
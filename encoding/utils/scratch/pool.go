@@ -0,0 +1,72 @@
+package scratch
+
+import "sync"
+
+// Pool is an arena of scratch slices backed by one sync.Pool per slice length.
+// It's meant for the encoding and verification hot paths, which repeatedly need
+// temporary slices of the same few lengths for a given set of encoding parameters.
+//
+// A disabled or nil Pool allocates a fresh slice on every Get and drops slices on Put,
+// which is useful to rule out pooling when debugging.
+type Pool[T any] struct {
+	enabled bool
+
+	mu    sync.RWMutex
+	pools map[int]*sync.Pool
+}
+
+// NewPool creates a Pool. If enabled is false, the pool doesn't retain any slices.
+func NewPool[T any](enabled bool) *Pool[T] {
+	return &Pool[T]{
+		enabled: enabled,
+		pools:   make(map[int]*sync.Pool),
+	}
+}
+
+// Enabled returns whether slices are being reused.
+func (p *Pool[T]) Enabled() bool {
+	return p != nil && p.enabled
+}
+
+// Get returns a zeroed slice of length n.
+// The slice must not be used after it's returned to the pool with Put.
+func (p *Pool[T]) Get(n int) []T {
+	if !p.Enabled() || n == 0 {
+		return make([]T, n)
+	}
+	s := p.getPool(n).Get().(*[]T)
+	clear(*s)
+	return *s
+}
+
+// Put returns a slice obtained from Get to the pool.
+func (p *Pool[T]) Put(s []T) {
+	if !p.Enabled() || len(s) == 0 {
+		return
+	}
+	s = s[:cap(s)]
+	p.getPool(len(s)).Put(&s)
+}
+
+func (p *Pool[T]) getPool(n int) *sync.Pool {
+	p.mu.RLock()
+	pool, ok := p.pools[n]
+	p.mu.RUnlock()
+	if ok {
+		return pool
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pool, ok = p.pools[n]; ok {
+		return pool
+	}
+	pool = &sync.Pool{
+		New: func() any {
+			s := make([]T, n)
+			return &s
+		},
+	}
+	p.pools[n] = pool
+	return pool
+}
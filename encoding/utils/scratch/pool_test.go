@@ -0,0 +1,59 @@
+package scratch_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/utils/scratch"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolGetIsZeroed(t *testing.T) {
+	pool := scratch.NewPool[fr.Element](true)
+	assert.True(t, pool.Enabled())
+
+	s := pool.Get(16)
+	assert.Len(t, s, 16)
+	for i := range s {
+		assert.True(t, s[i].IsZero())
+		s[i].SetUint64(uint64(i + 1))
+	}
+	pool.Put(s)
+
+	// Whether or not the slice is reused, it must come back zeroed.
+	for i := 0; i < 10; i++ {
+		s = pool.Get(16)
+		assert.Len(t, s, 16)
+		for j := range s {
+			assert.True(t, s[j].IsZero())
+			s[j].SetOne()
+		}
+		pool.Put(s)
+	}
+}
+
+func TestPoolLengths(t *testing.T) {
+	pool := scratch.NewPool[uint64](true)
+	for _, n := range []int{0, 1, 3, 64, 1024} {
+		s := pool.Get(n)
+		assert.Len(t, s, n)
+		pool.Put(s)
+	}
+
+	// A reslice of a pooled slice is returned under its original length.
+	s := pool.Get(8)
+	pool.Put(s[:4])
+	assert.Len(t, pool.Get(8), 8)
+	assert.Len(t, pool.Get(4), 4)
+}
+
+func TestDisabledPool(t *testing.T) {
+	for _, pool := range []*scratch.Pool[uint64]{scratch.NewPool[uint64](false), nil} {
+		assert.False(t, pool.Enabled())
+		s := pool.Get(8)
+		assert.Len(t, s, 8)
+		s[0] = 42
+		pool.Put(s)
+		assert.Equal(t, uint64(0), pool.Get(8)[0])
+	}
+}
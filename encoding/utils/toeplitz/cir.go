@@ -56,7 +56,8 @@ func (c *Circular) Multiply(x []fr.Element) ([]fr.Element, error) {
 func (c *Circular) GetFFTCoeff() ([]fr.Element, error) {
 	n := len(c.V)
 
-	colV := make([]fr.Element, n)
+	colV := c.Fs.Scratch.Get(n)
+	defer c.Fs.Scratch.Put(colV)
 	for i := 0; i < n; i++ {
 		colV[i] = c.V[(n-i)%n]
 	}
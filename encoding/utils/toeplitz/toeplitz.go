@@ -37,8 +37,10 @@ func NewToeplitz(v []fr.Element, fs *fft.FFTSettings) (*Toeplitz, error) {
 // https://alinush.github.io/2020/03/19/multiplying-a-vector-by-a-toeplitz-matrix.html
 func (t *Toeplitz) Multiply(x []fr.Element) ([]fr.Element, error) {
 	cv := t.ExtendCircularVec()
+	defer t.Fs.Scratch.Put(cv)
 
 	rv := t.FromColVToRowV(cv)
+	defer t.Fs.Scratch.Put(rv)
 	cir := NewCircular(rv, t.Fs)
 
 	xE := make([]fr.Element, len(cv))
@@ -61,8 +63,10 @@ func (t *Toeplitz) Multiply(x []fr.Element) ([]fr.Element, error) {
 // but carried with multi scalar multiplication
 func (t *Toeplitz) GetFFTCoeff() ([]fr.Element, error) {
 	cv := t.ExtendCircularVec()
+	defer t.Fs.Scratch.Put(cv)
 
 	rv := t.FromColVToRowV(cv)
+	defer t.Fs.Scratch.Put(rv)
 	cir := NewCircular(rv, t.Fs)
 
 	return cir.GetFFTCoeff()
@@ -70,8 +74,10 @@ func (t *Toeplitz) GetFFTCoeff() ([]fr.Element, error) {
 
 func (t *Toeplitz) GetCoeff() ([]fr.Element, error) {
 	cv := t.ExtendCircularVec()
+	defer t.Fs.Scratch.Put(cv)
 
 	rv := t.FromColVToRowV(cv)
+	defer t.Fs.Scratch.Put(rv)
 	cir := NewCircular(rv, t.Fs)
 
 	return cir.GetCoeff()
@@ -92,7 +98,7 @@ func (t *Toeplitz) GetCoeff() ([]fr.Element, error) {
 // [v_6, v_5, v_4, 0  , v_3, v_2, v_1, v_0 ]
 
 func (t *Toeplitz) ExtendCircularVec() []fr.Element {
-	E := make([]fr.Element, len(t.V)+1) // extra 1 from extended, equal to 2*dimE
+	E := t.Fs.Scratch.Get(len(t.V) + 1) // extra 1 from extended, equal to 2*dimE
 	numRow := t.GetMatDim()
 	E[0].Set(&t.V[0])
 
@@ -118,7 +124,7 @@ func (t *Toeplitz) ExtendCircularVec() []fr.Element {
 
 func (t *Toeplitz) FromColVToRowV(cv []fr.Element) []fr.Element {
 	n := len(cv)
-	rv := make([]fr.Element, n)
+	rv := t.Fs.Scratch.Get(n)
 
 	rv[0].Set(&cv[0])
 
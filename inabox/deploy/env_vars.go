@@ -136,6 +136,8 @@ type BatcherVars struct {
 
 	BATCHER_FINALIZATION_BLOCK_DELAY string
 
+	BATCHER_LATENCY_SENSITIVE_BLOB_SIZE string
+
 	BATCHER_CHAIN_RPC string
 
 	BATCHER_PRIVATE_KEY string
@@ -195,6 +197,8 @@ type EncoderVars struct {
 
 	DISPERSER_ENCODER_REQUEST_POOL_SIZE string
 
+	DISPERSER_ENCODER_MAX_PREEMPTION_PAUSE string
+
 	DISPERSER_ENCODER_G1_PATH string
 
 	DISPERSER_ENCODER_G2_PATH string
@@ -274,6 +278,10 @@ type OperatorVars struct {
 
 	NODE_EXPIRATION_POLL_INTERVAL string
 
+	NODE_RETRIEVAL_EXPIRY_GRACE_PERIOD string
+
+	NODE_OBSERVER_MODE string
+
 	NODE_ENABLE_TEST_MODE string
 
 	NODE_OVERRIDE_BLOCK_STALE_MEASURE string
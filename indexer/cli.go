@@ -8,7 +8,9 @@ import (
 )
 
 const (
-	PullIntervalFlagName = "indexer-pull-interval"
+	PullIntervalFlagName              = "indexer-pull-interval"
+	EventsBridgeURLFlagName           = "indexer-events-bridge-url"
+	EventsBridgeSubjectPrefixFlagName = "indexer-events-bridge-subject-prefix"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -20,11 +22,26 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_PULL_INTERVAL"),
 			Value:    1 * time.Second,
 		},
+		cli.StringFlag{
+			Name:     EventsBridgeURLFlagName,
+			Usage:    "URL of the NATS server, nats://[user:pass@]host[:port], the batch confirmations and the operator registrations, deregistrations, ejections and stake updates are published to. The events are not published if empty",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_EVENTS_BRIDGE_URL"),
+		},
+		cli.StringFlag{
+			Name:     EventsBridgeSubjectPrefixFlagName,
+			Usage:    "Prefix of the NATS subjects the events are published to, as <prefix>.<event type>",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "INDEXER_EVENTS_BRIDGE_SUBJECT_PREFIX"),
+			Value:    "eigenda",
+		},
 	}
 }
 
 func ReadIndexerConfig(ctx *cli.Context) Config {
 	return Config{
-		PullInterval: ctx.GlobalDuration(PullIntervalFlagName),
+		PullInterval:              ctx.GlobalDuration(PullIntervalFlagName),
+		EventsBridgeURL:           ctx.GlobalString(EventsBridgeURLFlagName),
+		EventsBridgeSubjectPrefix: ctx.GlobalString(EventsBridgeSubjectPrefixFlagName),
 	}
 }
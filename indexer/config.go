@@ -4,4 +4,10 @@ import "time"
 
 type Config struct {
 	PullInterval time.Duration
+
+	// EventsBridgeURL is the url of the NATS server the indexed events are published to. The events aren't
+	// published if empty.
+	EventsBridgeURL string
+	// EventsBridgeSubjectPrefix prefixes the subjects the events are published to, as <prefix>.<event type>
+	EventsBridgeSubjectPrefix string
 }
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// serveAdmin serves the admin endpoints of the node on their own listener, which is meant to be reachable by the
+// operator only.
+func (n *Node) serveAdmin() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/inventory", n.handleInventory)
+	server := &http.Server{
+		Addr:              n.Config.AdminAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if err := server.ListenAndServe(); err != nil {
+		n.Logger.Error("Admin server stopped", "err", err)
+	}
+}
+
+// handleInventory returns the inventory of the stored chunks, signed with the BLS key of the node, for operators and
+// auditors to reconcile against the onchain assignments of the node.
+func (n *Node) handleInventory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	inventory, err := n.GetInventory(r.Context())
+	if err != nil {
+		n.Logger.Error("Failed to take the inventory of the store", "err", err)
+		http.Error(w, "failed to take the inventory", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(inventory); err != nil {
+		n.Logger.Warn("Failed to write the inventory", "err", err)
+	}
+}
+
+// GetInventory returns the inventory of the store, signed with the BLS key of the node. The indices of the stored
+// chunks are those of the assignments of the node at the reference blocks of the batches.
+func (n *Node) GetInventory(ctx context.Context) (*Inventory, error) {
+	coordinator := &core.StdAssignmentCoordinator{}
+	states := make(map[uint]*core.OperatorState)
+	inventory, err := n.Store.GetInventory(ctx, func(ctx context.Context, batchHeader *core.BatchHeader, blobLength uint, quorumInfo *core.BlobQuorumInfo) (core.Assignment, error) {
+		state, ok := states[batchHeader.ReferenceBlockNumber]
+		if !ok {
+			var err error
+			state, err = n.ChainState.GetOperatorStateByOperator(ctx, batchHeader.ReferenceBlockNumber, n.Config.ID)
+			if err != nil {
+				return core.Assignment{}, err
+			}
+			states[batchHeader.ReferenceBlockNumber] = state
+		}
+		assignments, _, err := coordinator.GetAssignments(state, blobLength, quorumInfo)
+		if err != nil {
+			return core.Assignment{}, err
+		}
+		assignment, ok := assignments[n.Config.ID]
+		if !ok {
+			return core.Assignment{}, errors.New("the operator has no assignment in the quorum")
+		}
+		return assignment, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := inventory.Sign(n.KeyPair); err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
@@ -0,0 +1,65 @@
+package node
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/webhook"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+const (
+	// The max size of the body of an announcement.
+	maxAnnouncementSize = 1 << 16
+	// The max difference between the time an announcement is signed and the time it's received.
+	announcementTolerance = 5 * time.Minute
+)
+
+// serveAnnouncements receives the announcements of the upcoming large batches from the disperser.
+func (n *Node) serveAnnouncements() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", n.handleAnnouncement)
+	server := &http.Server{
+		Addr:              ":" + n.Config.AnnouncementPort,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if err := server.ListenAndServe(); err != nil {
+		n.Logger.Error("Batch announcement server stopped", "err", err)
+	}
+}
+
+// handleAnnouncement verifies an announcement and prepares the node for the announced batch, by removing the
+// expired batches ahead of the next expiration cycle to free up space for it.
+func (n *Node) handleAnnouncement(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAnnouncementSize))
+	if err != nil {
+		http.Error(w, "failed to read the body", http.StatusBadRequest)
+		return
+	}
+	if err := webhook.Verify(n.Config.AnnouncementSecret, r.Header.Get(webhook.SignatureHeader), body, time.Now(), announcementTolerance); err != nil {
+		n.Logger.Warn("Rejected a batch announcement", "err", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var announcement core.BatchAnnouncement
+	if err := json.Unmarshal(body, &announcement); err != nil {
+		http.Error(w, "invalid announcement", http.StatusBadRequest)
+		return
+	}
+
+	n.Logger.Info("Received the announcement of an upcoming batch", "referenceBlockNumber", announcement.ReferenceBlockNumber,
+		"numBlobs", announcement.NumBlobs, "size", announcement.Size, "operatorSize", announcement.OperatorSize, "eta", announcement.ETA)
+	n.Metrics.RecordBatchAnnouncement(announcement.Size, announcement.OperatorSize)
+	select {
+	case n.expireNow <- struct{}{}:
+	default:
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
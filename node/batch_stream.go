@@ -0,0 +1,158 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/gammazero/workerpool"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MaxBatchSize is the max number of bytes of the blobs of a batch, whether they're received in a single
+	// StoreChunks request or in the minibatches of a StoreChunksStream
+	MaxBatchSize = 60 * 1024 * 1024 * 1024 // 60 GiB
+	// DefaultMaxBatchNumBlobs is the max number of blobs of a batch received in a StoreChunksStream, if the config
+	// doesn't set it
+	DefaultMaxBatchNumBlobs = 10_000
+)
+
+// ErrBatchTooLarge is returned when the minibatches of a batch exceed the size or the number of blobs of a batch
+var ErrBatchTooLarge = errors.New("batch too large")
+
+// BatchStream receives the blobs of a batch in minibatches, and validates each minibatch as soon as it's added, so
+// that the validation of the batch overlaps with its transfer. The batch root is only checked against all the blobs
+// once the batch is complete.
+type BatchStream struct {
+	node   *Node
+	ctx    context.Context
+	header *core.BatchHeader
+
+	operatorState *core.OperatorState
+	pool          *workerpool.WorkerPool
+
+	blobs       []*core.BlobMessage
+	rawBlobs    []*node.Blob
+	minibatches []*minibatchResult
+	// size is the number of bytes of the blobs received so far
+	size int
+
+	wg  sync.WaitGroup
+	mu  sync.Mutex
+	err error
+}
+
+// minibatchResult is the result of the validation of a minibatch, only read once all the minibatches are validated
+type minibatchResult struct {
+	stats []core.BlobValidationStats
+}
+
+// NewBatchStream starts receiving the batch with the header, whose blobs are added with Add. The stream must be
+// closed once the batch is processed or abandoned.
+func (n *Node) NewBatchStream(ctx context.Context, header *core.BatchHeader) (*BatchStream, error) {
+	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, n.Config.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	numValidators := n.Config.NumBatchValidators
+	if n.ValidatorTuner != nil {
+		numValidators = n.ValidatorTuner.NumValidators()
+	}
+	return &BatchStream{
+		node:          n,
+		ctx:           ctx,
+		header:        header,
+		operatorState: operatorState,
+		pool:          workerpool.New(numValidators),
+	}, nil
+}
+
+// Add adds the next minibatch of blobs of the batch and starts validating it. It returns the error of the first
+// minibatch which failed its validation, if any, so that the stream can be aborted without waiting for the rest of
+// the batch, and ErrBatchTooLarge once the batch exceeds MaxBatchSize or the max number of blobs of a batch.
+func (s *BatchStream) Add(blobs []*core.BlobMessage, rawBlobs []*node.Blob) error {
+	if len(blobs) == 0 {
+		return errors.New("minibatch must have at least one blob")
+	}
+	if len(blobs) != len(rawBlobs) {
+		return errors.New("number of parsed blobs must be the same as number of blobs from protobuf request")
+	}
+	if err := s.failed(); err != nil {
+		return err
+	}
+	maxNumBlobs := s.node.Config.MaxBatchNumBlobs
+	if maxNumBlobs <= 0 {
+		maxNumBlobs = DefaultMaxBatchNumBlobs
+	}
+	if len(s.blobs)+len(blobs) > maxNumBlobs {
+		return fmt.Errorf("%w: more than %d blobs", ErrBatchTooLarge, maxNumBlobs)
+	}
+	for _, blob := range rawBlobs {
+		s.size += proto.Size(blob)
+	}
+	if s.size > MaxBatchSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrBatchTooLarge, MaxBatchSize)
+	}
+
+	index := len(s.minibatches)
+	result := &minibatchResult{}
+	s.minibatches = append(s.minibatches, result)
+	s.blobs = append(s.blobs, blobs...)
+	s.rawBlobs = append(s.rawBlobs, rawBlobs...)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		stats, err := s.node.Validator.ValidateBlobs(blobs, s.operatorState, s.pool)
+		if err != nil {
+			s.mu.Lock()
+			if s.err == nil {
+				s.err = fmt.Errorf("failed to validate minibatch %d: %w", index, err)
+			}
+			s.mu.Unlock()
+			return
+		}
+		result.stats = stats
+	}()
+	return nil
+}
+
+// Process stores and signs the batch like ProcessBatch, once all its blobs are added
+func (s *BatchStream) Process() (*core.Signature, []core.BlobValidationStats, error) {
+	return s.node.processBatch(s.ctx, s.header, s.blobs, s.rawBlobs, s.validate)
+}
+
+// Close waits for the validation of the minibatches and releases the workers validating them
+func (s *BatchStream) Close() {
+	s.wg.Wait()
+	s.pool.Stop()
+}
+
+func (s *BatchStream) failed() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// validate waits for the validation of the minibatches and checks the batch root against all the blobs. The
+// validation isn't observed by the validator tuner, since its duration mostly depends on the transfer of the batch.
+func (s *BatchStream) validate() ([]core.BlobValidationStats, error) {
+	s.wg.Wait()
+	if err := s.failed(); err != nil {
+		return nil, err
+	}
+	if err := core.ValidateBatchHeaderRoot(s.header, s.blobs); err != nil {
+		return nil, err
+	}
+
+	stats := make([]core.BlobValidationStats, 0, len(s.blobs))
+	for _, result := range s.minibatches {
+		stats = append(stats, result.stats...)
+	}
+	return stats, nil
+}
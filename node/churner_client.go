@@ -22,6 +22,10 @@ type ChurnerClient interface {
 	// The quorumIDs cannot be empty, but may contain quorums that the operator is already registered in.
 	// If the operator is already registered in a quorum, the churner will ignore it and continue with the other quorums.
 	Churn(ctx context.Context, operatorAddress string, keyPair *core.KeyPair, quorumIDs []core.QuorumID) (*churnerpb.ChurnReply, error)
+	// RefreshChurn asks the churner service to re-validate the unexpired churn approval it gave to the operator for the
+	// quorumIDs against the current stakes, and returns the approval signed again if it still holds.
+	// The error is one for which churner.IsStakeChangedError is true if the stakes changed since the approval.
+	RefreshChurn(ctx context.Context, operatorAddress string, keyPair *core.KeyPair, quorumIDs []core.QuorumID) (*churnerpb.ChurnReply, error)
 }
 
 type churnerClient struct {
@@ -41,6 +45,21 @@ func NewChurnerClient(churnerURL string, useSecureGrpc bool, timeout time.Durati
 }
 
 func (c *churnerClient) Churn(ctx context.Context, operatorAddress string, keyPair *core.KeyPair, quorumIDs []core.QuorumID) (*churnerpb.ChurnReply, error) {
+	return c.call(ctx, operatorAddress, keyPair, quorumIDs, churnerpb.ChurnerClient.Churn)
+}
+
+func (c *churnerClient) RefreshChurn(ctx context.Context, operatorAddress string, keyPair *core.KeyPair, quorumIDs []core.QuorumID) (*churnerpb.ChurnReply, error) {
+	return c.call(ctx, operatorAddress, keyPair, quorumIDs, churnerpb.ChurnerClient.RefreshChurn)
+}
+
+// call makes a signed churn request for the quorumIDs with the given method of the churner service
+func (c *churnerClient) call(
+	ctx context.Context,
+	operatorAddress string,
+	keyPair *core.KeyPair,
+	quorumIDs []core.QuorumID,
+	method func(churnerpb.ChurnerClient, context.Context, *churnerpb.ChurnRequest, ...grpc.CallOption) (*churnerpb.ChurnReply, error),
+) (*churnerpb.ChurnReply, error) {
 	if len(quorumIDs) == 0 {
 		return nil, errors.New("quorumIDs cannot be empty")
 	}
@@ -96,5 +115,5 @@ func (c *churnerClient) Churn(ctx context.Context, operatorAddress string, keyPa
 
 	opt := grpc.MaxCallSendMsgSize(1024 * 1024 * 300)
 
-	return gc.Churn(ctx, churnRequestPb, opt)
+	return method(gc, ctx, churnRequestPb, opt)
 }
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/flags"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli"
+)
+
+var topFlag = cli.IntFlag{
+	Name:  "top",
+	Usage: "Number of the largest batches to list (all batches if not positive)",
+	Value: 10,
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "store"
+	app.Usage = "Inspect the local store of an EigenDA Node"
+	app.Version = fmt.Sprintf("%s-%s-%s", node.SemVer, node.GitCommit, node.GitDate)
+	app.Commands = []cli.Command{
+		{
+			Name:        "stats",
+			Usage:       "summarize the storage footprint of the stored batches",
+			Description: "The store is locked by a running node, so the node has to be stopped first.",
+			Flags:       []cli.Flag{flags.DbPathFlag, topFlag},
+			Action:      StoreStats,
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+// openStore opens the chunks store of the node, which the node keeps in the "chunk" directory under its db path.
+func openStore(ctx *cli.Context) (*node.Store, error) {
+	path := ctx.String(flags.DbPathFlag.Name) + "/chunk"
+	store, err := node.NewLevelDBStore(path, logging.NewNoopLogger(), nil, 0, 0, 0, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the store at %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// StoreStats prints the total footprint of the stored batches, followed by the largest batches.
+func StoreStats(ctx *cli.Context) error {
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	batches, err := store.GetLargestBatches(context.Background(), 0)
+	if err != nil {
+		return err
+	}
+	total := node.BatchStats{}
+	for _, batch := range batches {
+		total.NumBlobs += batch.NumBlobs
+		total.LogicalBytes += batch.LogicalBytes
+		total.PhysicalBytes += batch.PhysicalBytes
+	}
+	fmt.Printf("batches: %d, blobs: %d, logical bytes: %d, physical bytes: %d, amplification: %.3f\n\n",
+		len(batches), total.NumBlobs, total.LogicalBytes, total.PhysicalBytes, total.Amplification())
+
+	if top := ctx.Int(topFlag.Name); top > 0 && len(batches) > top {
+		batches = batches[:top]
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BATCH HEADER HASH\tBLOBS\tLOGICAL BYTES\tPHYSICAL BYTES\tAMPLIFICATION")
+	for _, batch := range batches {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%.3f\n", hexutil.Encode(batch.BatchHeaderHash[:]), batch.NumBlobs, batch.LogicalBytes, batch.PhysicalBytes, batch.Amplification())
+	}
+	return w.Flush()
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/node/flags"
@@ -51,7 +53,10 @@ type Config struct {
 	OnchainMetricsInterval        int64
 	Timeout                       time.Duration
 	RegisterNodeAtStart           bool
+	ObserverMode                  bool
 	ExpirationPollIntervalSec     uint64
+	RetrievalExpiryGracePeriod    time.Duration
+	MinBlobRetentionPeriod        time.Duration
 	EnableTestMode                bool
 	OverrideBlockStaleMeasure     int64
 	OverrideStoreDurationBlocks   int64
@@ -66,8 +71,23 @@ type Config struct {
 	PubIPCheckInterval            time.Duration
 	ChurnerUrl                    string
 	NumBatchValidators            int
+	MinNumBatchValidators         int
+	MaxBatchNumBlobs              int
 	ClientIPHeader                string
 	UseSecureGrpc                 bool
+	RetrievalTLS                  grpctls.Config
+	DispersalTLS                  grpctls.Config
+	// AnnouncementPort is the port at which the announcements of the upcoming large batches are received, if set
+	AnnouncementPort string
+	// AnnouncementSecret is the secret the announcements are signed with
+	AnnouncementSecret []byte
+	// AdminAddress is the address at which the admin endpoints are served, if set
+	AdminAddress string
+	// ChunkEncryptionKeyFile and ChunkEncryptionKeySecret are where the key the chunks are encrypted with is read
+	// from. The chunks are stored in the clear if neither is set.
+	ChunkEncryptionKeyFile         string
+	ChunkEncryptionKeySecret       string
+	ChunkEncryptionKeySecretRegion string
 
 	EthClientConfig geth.EthClientConfig
 	LoggerConfig    common.LoggerConfig
@@ -106,12 +126,56 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	registerNodeAtStart := ctx.GlobalBool(flags.RegisterAtNodeStartFlag.Name)
 	pubIPCheckInterval := ctx.GlobalDuration(flags.PubIPCheckIntervalFlag.Name)
 	needECDSAKey := registerNodeAtStart || pubIPCheckInterval > 0
+	observerMode := ctx.GlobalBool(flags.ObserverModeFlag.Name)
+	if registerNodeAtStart && observerMode {
+		return nil, fmt.Errorf("%s cannot be enabled in %s", flags.RegisterAtNodeStartFlag.Name, flags.ObserverModeFlag.Name)
+	}
 	if registerNodeAtStart && (ctx.GlobalString(flags.EcdsaKeyFileFlag.Name) == "" || ctx.GlobalString(flags.EcdsaKeyPasswordFlag.Name) == "") {
 		return nil, fmt.Errorf("%s and %s are required if %s is enabled", flags.EcdsaKeyFileFlag.Name, flags.EcdsaKeyPasswordFlag.Name, flags.RegisterAtNodeStartFlag.Name)
 	}
 	if pubIPCheckInterval > 0 && (ctx.GlobalString(flags.EcdsaKeyFileFlag.Name) == "" || ctx.GlobalString(flags.EcdsaKeyPasswordFlag.Name) == "") {
 		return nil, fmt.Errorf("%s and %s are required if %s is > 0", flags.EcdsaKeyFileFlag.Name, flags.EcdsaKeyPasswordFlag.Name, flags.PubIPCheckIntervalFlag.Name)
 	}
+	numBatchValidators := ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name)
+	minNumBatchValidators := ctx.GlobalInt(flags.MinNumBatchValidatorsFlag.Name)
+	if minNumBatchValidators < 0 || minNumBatchValidators > numBatchValidators {
+		return nil, fmt.Errorf("%s must be between 0 and %s", flags.MinNumBatchValidatorsFlag.Name, flags.NumBatchValidatorsFlag.Name)
+	}
+	retrievalTLS := grpctls.Config{
+		CertFile:       ctx.GlobalString(flags.RetrievalTLSCertFileFlag.Name),
+		KeyFile:        ctx.GlobalString(flags.RetrievalTLSKeyFileFlag.Name),
+		ClientCAFile:   ctx.GlobalString(flags.RetrievalTLSClientCAFileFlag.Name),
+		ReloadInterval: ctx.GlobalDuration(flags.TLSReloadIntervalFlag.Name),
+	}
+	if err := retrievalTLS.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retrieval TLS config: %w", err)
+	}
+	dispersalTLS := grpctls.Config{
+		CertFile:       ctx.GlobalString(flags.DispersalTLSCertFileFlag.Name),
+		KeyFile:        ctx.GlobalString(flags.DispersalTLSKeyFileFlag.Name),
+		ClientCAFile:   ctx.GlobalString(flags.DispersalTLSClientCAFileFlag.Name),
+		ReloadInterval: ctx.GlobalDuration(flags.TLSReloadIntervalFlag.Name),
+	}
+	if err := dispersalTLS.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid dispersal TLS config: %w", err)
+	}
+
+	var announcementSecret []byte
+	announcementPort := ctx.GlobalString(flags.AnnouncementPortFlag.Name)
+	if announcementPort != "" {
+		secretFile := ctx.GlobalString(flags.AnnouncementSecretFileFlag.Name)
+		if secretFile == "" {
+			return nil, fmt.Errorf("%s is required if %s is set", flags.AnnouncementSecretFileFlag.Name, flags.AnnouncementPortFlag.Name)
+		}
+		secret, err := os.ReadFile(secretFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read announcement secret file: %v", err)
+		}
+		announcementSecret = bytes.TrimSpace(secret)
+		if len(announcementSecret) == 0 {
+			return nil, fmt.Errorf("the announcement secret file %s is empty", secretFile)
+		}
+	}
 
 	var ethClientConfig geth.EthClientConfig
 	if !testMode {
@@ -159,35 +223,48 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	}
 
 	return &Config{
-		Hostname:                      ctx.GlobalString(flags.HostnameFlag.Name),
-		DispersalPort:                 ctx.GlobalString(flags.DispersalPortFlag.Name),
-		RetrievalPort:                 ctx.GlobalString(flags.RetrievalPortFlag.Name),
-		InternalDispersalPort:         internalDispersalFlag,
-		InternalRetrievalPort:         internalRetrievalFlag,
-		EnableNodeApi:                 ctx.GlobalBool(flags.EnableNodeApiFlag.Name),
-		NodeApiPort:                   ctx.GlobalString(flags.NodeApiPortFlag.Name),
-		EnableMetrics:                 ctx.GlobalBool(flags.EnableMetricsFlag.Name),
-		MetricsPort:                   ctx.GlobalString(flags.MetricsPortFlag.Name),
-		OnchainMetricsInterval:        ctx.GlobalInt64(flags.OnchainMetricsIntervalFlag.Name),
-		Timeout:                       timeout,
-		RegisterNodeAtStart:           registerNodeAtStart,
-		ExpirationPollIntervalSec:     expirationPollIntervalSec,
-		EnableTestMode:                testMode,
-		OverrideBlockStaleMeasure:     ctx.GlobalInt64(flags.OverrideBlockStaleMeasureFlag.Name),
-		OverrideStoreDurationBlocks:   ctx.GlobalInt64(flags.OverrideStoreDurationBlocksFlag.Name),
-		QuorumIDList:                  ids,
-		DbPath:                        ctx.GlobalString(flags.DbPathFlag.Name),
-		PrivateBls:                    privateBls,
-		EthClientConfig:               ethClientConfig,
-		EncoderConfig:                 kzg.ReadCLIConfig(ctx),
-		LoggerConfig:                  *loggerConfig,
-		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
-		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
-		PubIPProvider:                 ctx.GlobalString(flags.PubIPProviderFlag.Name),
-		PubIPCheckInterval:            pubIPCheckInterval,
-		ChurnerUrl:                    ctx.GlobalString(flags.ChurnerUrlFlag.Name),
-		NumBatchValidators:            ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name),
-		ClientIPHeader:                ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
-		UseSecureGrpc:                 ctx.GlobalBoolT(flags.ChurnerUseSecureGRPC.Name),
+		Hostname:                       ctx.GlobalString(flags.HostnameFlag.Name),
+		DispersalPort:                  ctx.GlobalString(flags.DispersalPortFlag.Name),
+		RetrievalPort:                  ctx.GlobalString(flags.RetrievalPortFlag.Name),
+		InternalDispersalPort:          internalDispersalFlag,
+		InternalRetrievalPort:          internalRetrievalFlag,
+		EnableNodeApi:                  ctx.GlobalBool(flags.EnableNodeApiFlag.Name),
+		NodeApiPort:                    ctx.GlobalString(flags.NodeApiPortFlag.Name),
+		EnableMetrics:                  ctx.GlobalBool(flags.EnableMetricsFlag.Name),
+		MetricsPort:                    ctx.GlobalString(flags.MetricsPortFlag.Name),
+		OnchainMetricsInterval:         ctx.GlobalInt64(flags.OnchainMetricsIntervalFlag.Name),
+		Timeout:                        timeout,
+		RegisterNodeAtStart:            registerNodeAtStart,
+		ObserverMode:                   observerMode,
+		ExpirationPollIntervalSec:      expirationPollIntervalSec,
+		RetrievalExpiryGracePeriod:     ctx.GlobalDuration(flags.RetrievalExpiryGracePeriodFlag.Name),
+		MinBlobRetentionPeriod:         ctx.GlobalDuration(flags.MinBlobRetentionPeriodFlag.Name),
+		EnableTestMode:                 testMode,
+		OverrideBlockStaleMeasure:      ctx.GlobalInt64(flags.OverrideBlockStaleMeasureFlag.Name),
+		OverrideStoreDurationBlocks:    ctx.GlobalInt64(flags.OverrideStoreDurationBlocksFlag.Name),
+		QuorumIDList:                   ids,
+		DbPath:                         ctx.GlobalString(flags.DbPathFlag.Name),
+		PrivateBls:                     privateBls,
+		EthClientConfig:                ethClientConfig,
+		EncoderConfig:                  kzg.ReadCLIConfig(ctx),
+		LoggerConfig:                   *loggerConfig,
+		BLSOperatorStateRetrieverAddr:  ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:      ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+		PubIPProvider:                  ctx.GlobalString(flags.PubIPProviderFlag.Name),
+		PubIPCheckInterval:             pubIPCheckInterval,
+		ChurnerUrl:                     ctx.GlobalString(flags.ChurnerUrlFlag.Name),
+		NumBatchValidators:             numBatchValidators,
+		MinNumBatchValidators:          minNumBatchValidators,
+		MaxBatchNumBlobs:               ctx.GlobalInt(flags.MaxBatchNumBlobsFlag.Name),
+		ClientIPHeader:                 ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
+		UseSecureGrpc:                  ctx.GlobalBoolT(flags.ChurnerUseSecureGRPC.Name),
+		RetrievalTLS:                   retrievalTLS,
+		DispersalTLS:                   dispersalTLS,
+		AnnouncementPort:               announcementPort,
+		AnnouncementSecret:             announcementSecret,
+		AdminAddress:                   ctx.GlobalString(flags.AdminAddressFlag.Name),
+		ChunkEncryptionKeyFile:         ctx.GlobalString(flags.ChunkEncryptionKeyFileFlag.Name),
+		ChunkEncryptionKeySecret:       ctx.GlobalString(flags.ChunkEncryptionKeySecretFlag.Name),
+		ChunkEncryptionKeySecretRegion: ctx.GlobalString(flags.ChunkEncryptionKeySecretRegionFlag.Name),
 	}, nil
 }
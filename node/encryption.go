@@ -0,0 +1,194 @@
+package node
+
+import (
+	"container/list"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/common/aws/secretmanager"
+)
+
+const (
+	// chunkKeySize is the size of the master key and of the data keys, for AES-256
+	chunkKeySize = 32
+	// dataKeyCacheSize is the number of unwrapped data keys kept in memory, so that the reads of the chunks of a
+	// batch only unwrap its data key once
+	dataKeyCacheSize = 1024
+)
+
+var ErrChunkDecryption = errors.New("failed to decrypt the chunks")
+
+// ChunkCipher encrypts the chunks stored by the node with AES-256-GCM. Each batch is encrypted with its own random
+// data key, which is stored with the batch wrapped by the master key of the node. Reading the chunks of a batch then
+// costs one unwrap of its data key, which is cached, and the AES-GCM decryption of the chunks, which runs at memory
+// speed on CPUs with AES instructions.
+//
+// The ciphertext of the chunks is bound to their key in the store, so that it can't be swapped with the ciphertext
+// of other chunks.
+type ChunkCipher struct {
+	masterKey cipher.AEAD
+
+	mu          sync.Mutex
+	dataKeys    map[[32]byte]*list.Element
+	dataKeysLRU *list.List
+}
+
+type cachedDataKey struct {
+	batchHeaderHash [32]byte
+	aead            cipher.AEAD
+}
+
+// NewChunkCipher returns a ChunkCipher wrapping the data keys with the given 32 bytes master key.
+func NewChunkCipher(masterKey []byte) (*ChunkCipher, error) {
+	if len(masterKey) != chunkKeySize {
+		return nil, fmt.Errorf("the chunk encryption key must be %d bytes, got %d", chunkKeySize, len(masterKey))
+	}
+	aead, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ChunkCipher{
+		masterKey:   aead,
+		dataKeys:    make(map[[32]byte]*list.Element),
+		dataKeysLRU: list.New(),
+	}, nil
+}
+
+// LoadChunkEncryptionKey reads the hex encoded master key of the chunk encryption from the key file if it's set, or
+// else from the AWS Secrets Manager secret, which is encrypted at rest with KMS.
+func LoadChunkEncryptionKey(ctx context.Context, keyFile, secretName, region string) ([]byte, error) {
+	var encoded string
+	switch {
+	case keyFile != "":
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the chunk encryption key file: %w", err)
+		}
+		encoded = string(data)
+	case secretName != "":
+		secret, err := secretmanager.ReadStringFromSecretManager(ctx, secretName, region)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the chunk encryption key secret: %w", err)
+		}
+		encoded = secret
+	default:
+		return nil, errors.New("neither a chunk encryption key file nor secret is set")
+	}
+
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(encoded), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("the chunk encryption key must be hex encoded: %w", err)
+	}
+	return key, nil
+}
+
+// NewDataKey generates the data key of a batch, and returns it along with its wrapped form, to be stored with the
+// batch. The data key isn't cached, since the batch may fail to be stored with it.
+func (c *ChunkCipher) NewDataKey(batchHeaderHash [32]byte) (cipher.AEAD, []byte, error) {
+	key := make([]byte, chunkKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	wrapped, err := seal(c.masterKey, key, batchHeaderHash[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, wrapped, nil
+}
+
+// OpenDataKey returns the data key of a batch from its wrapped form.
+func (c *ChunkCipher) OpenDataKey(batchHeaderHash [32]byte, wrapped []byte) (cipher.AEAD, error) {
+	if aead, ok := c.lookupDataKey(batchHeaderHash); ok {
+		return aead, nil
+	}
+
+	key, err := open(c.masterKey, wrapped, batchHeaderHash[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap the data key of the batch: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	c.cacheDataKey(batchHeaderHash, aead)
+	return aead, nil
+}
+
+// ForgetDataKey drops the data key of a deleted batch from the cache
+func (c *ChunkCipher) ForgetDataKey(batchHeaderHash [32]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.dataKeys[batchHeaderHash]; ok {
+		c.dataKeysLRU.Remove(elem)
+		delete(c.dataKeys, batchHeaderHash)
+	}
+}
+
+// lookupDataKey returns the data key of a batch if it's cached
+func (c *ChunkCipher) lookupDataKey(batchHeaderHash [32]byte) (cipher.AEAD, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.dataKeys[batchHeaderHash]
+	if !ok {
+		return nil, false
+	}
+	c.dataKeysLRU.MoveToFront(elem)
+	return elem.Value.(*cachedDataKey).aead, true
+}
+
+func (c *ChunkCipher) cacheDataKey(batchHeaderHash [32]byte, aead cipher.AEAD) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.dataKeys[batchHeaderHash]; ok {
+		elem.Value.(*cachedDataKey).aead = aead
+		c.dataKeysLRU.MoveToFront(elem)
+		return
+	}
+	c.dataKeys[batchHeaderHash] = c.dataKeysLRU.PushFront(&cachedDataKey{batchHeaderHash: batchHeaderHash, aead: aead})
+	if c.dataKeysLRU.Len() > dataKeyCacheSize {
+		oldest := c.dataKeysLRU.Back()
+		c.dataKeysLRU.Remove(oldest)
+		delete(c.dataKeys, oldest.Value.(*cachedDataKey).batchHeaderHash)
+	}
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext with a random nonce, which is prepended to the ciphertext
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	out := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(out); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, out, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrChunkDecryption
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, ErrChunkDecryption
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,161 @@
+package node_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core/mock"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newChunkCipher(t testing.TB) *node.ChunkCipher {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	assert.NoError(t, err)
+	chunkCipher, err := node.NewChunkCipher(key)
+	assert.NoError(t, err)
+	return chunkCipher
+}
+
+func newEncryptionTestStore(t testing.TB, chunkCipher *node.ChunkCipher) *node.Store {
+	logger := logging.NewNoopLogger()
+	operatorId := [32]byte(hexutil.MustDecode("0x3fbfefcdc76462d2cdb7d0cea75f27223829481b8b4aa6881c94cb2126a316ad"))
+	dat, err := mock.MakeChainDataMock(map[uint8]int{
+		0: 6,
+		1: 3,
+	})
+	assert.NoError(t, err)
+	nodeMetrics := node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), logger, ":9090", operatorId, -1, &coremock.MockTransactor{}, dat)
+	s, err := node.NewLevelDBStore(t.TempDir(), logger, nodeMetrics, 1, 1, 0, 0, chunkCipher)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestChunkCipherDataKeys(t *testing.T) {
+	_, err := node.NewChunkCipher(make([]byte, 16))
+	assert.ErrorContains(t, err, "must be 32 bytes")
+
+	chunkCipher := newChunkCipher(t)
+	batchHeaderHash := [32]byte{1}
+	_, wrapped, err := chunkCipher.NewDataKey(batchHeaderHash)
+	assert.NoError(t, err)
+
+	// The data key can only be unwrapped with the master key, for the batch it was generated for
+	_, err = newChunkCipher(t).OpenDataKey(batchHeaderHash, wrapped)
+	assert.Error(t, err)
+	chunkCipher.ForgetDataKey(batchHeaderHash)
+	_, err = chunkCipher.OpenDataKey([32]byte{2}, wrapped)
+	assert.Error(t, err)
+	_, err = chunkCipher.OpenDataKey(batchHeaderHash, wrapped)
+	assert.NoError(t, err)
+}
+
+func TestLoadChunkEncryptionKey(t *testing.T) {
+	keyFile := t.TempDir() + "/chunk.key"
+	assert.NoError(t, os.WriteFile(keyFile, []byte("0x"+strings.Repeat("ab", 32)+"\n"), 0600))
+	key, err := node.LoadChunkEncryptionKey(context.Background(), keyFile, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0xab}, 32), key)
+
+	_, err = node.LoadChunkEncryptionKey(context.Background(), "", "", "")
+	assert.Error(t, err)
+}
+
+func TestStoringEncryptedBatch(t *testing.T) {
+	ctx := context.Background()
+	plaintext := newEncryptionTestStore(t, nil)
+	encrypted := newEncryptionTestStore(t, newChunkCipher(t))
+
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	_, err = plaintext.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.NoError(t, err)
+	_, err = encrypted.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.NoError(t, err)
+	dataKeyKey := node.EncodeBatchDataKeyKey(batchHeaderHash)
+	assert.False(t, plaintext.HasKey(ctx, dataKeyKey))
+	assert.True(t, encrypted.HasKey(ctx, dataKeyKey))
+
+	// The encrypted chunks read the same as the plaintext ones
+	for idx := range blobs {
+		for quorumID := range blobs[idx].Bundles {
+			expected, ok := plaintext.GetChunks(ctx, batchHeaderHash, idx, quorumID)
+			assert.True(t, ok)
+			chunks, ok := encrypted.GetChunks(ctx, batchHeaderHash, idx, quorumID)
+			assert.True(t, ok)
+			assert.Equal(t, expected, chunks)
+		}
+	}
+
+	// The data key expires with the batch
+	numDeleted, err := encrypted.DeleteExpiredEntries(time.Now().Unix()+100, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numDeleted)
+	assert.False(t, encrypted.HasKey(ctx, dataKeyKey))
+}
+
+func TestEncryptedBatchStaleDataKey(t *testing.T) {
+	ctx := context.Background()
+	chunkCipher := newChunkCipher(t)
+	s := newEncryptionTestStore(t, chunkCipher)
+
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.NoError(t, err)
+	expected, ok := s.GetChunks(ctx, batchHeaderHash, 0, 0)
+	assert.True(t, ok)
+
+	// A data key that wasn't persisted with the batch, like the one of a concurrent store of the same batch
+	// overwritten by this one, is replaced by the persisted key when it fails to decrypt the chunks
+	chunkCipher.ForgetDataKey(batchHeaderHash)
+	_, wrapped, err := chunkCipher.NewDataKey(batchHeaderHash)
+	assert.NoError(t, err)
+	_, err = chunkCipher.OpenDataKey(batchHeaderHash, wrapped)
+	assert.NoError(t, err)
+	chunks, ok := s.GetChunks(ctx, batchHeaderHash, 0, 0)
+	assert.True(t, ok)
+	assert.Equal(t, expected, chunks)
+}
+
+// BenchmarkGetChunks measures the cost of reading encrypted chunks against plaintext ones. The data key of the batch
+// is cached after the first read, so the overhead is the AES-GCM decryption of the chunks.
+func BenchmarkGetChunks(b *testing.B) {
+	for _, bc := range []struct {
+		name        string
+		chunkCipher *node.ChunkCipher
+	}{
+		{name: "plaintext"},
+		{name: "encrypted", chunkCipher: newChunkCipher(b)},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := context.Background()
+			s := newEncryptionTestStore(b, bc.chunkCipher)
+			batchHeader, blobs, blobsProto := CreateBatch(b)
+			_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+			assert.NoError(b, err)
+			batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+			assert.NoError(b, err)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, ok := s.GetChunks(ctx, batchHeaderHash, 0, 0); !ok {
+					b.Fatal("failed to get the chunks")
+				}
+			}
+		})
+	}
+}
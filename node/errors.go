@@ -9,4 +9,5 @@ var (
 	ErrKeyNotFound          = errors.New("commit not found in db")
 	ErrKeyExpired           = errors.New("commit is expired")
 	ErrKeyNotFoundOrExpired = errors.New("data is either expired or not found")
+	ErrObserverMode         = errors.New("node is in observer mode, batches are validated but neither stored nor signed")
 )
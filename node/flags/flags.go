@@ -181,6 +181,27 @@ var (
 		Value:    "180",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EXPIRATION_POLL_INTERVAL"),
 	}
+	RetrievalExpiryGracePeriodFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-expiry-grace-period"),
+		Usage:    "How long past its expiry the removal of a batch can be deferred while chunks of the batch are being retrieved",
+		Required: false,
+		Value:    2 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_EXPIRY_GRACE_PERIOD"),
+	}
+	MinBlobRetentionPeriodFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-blob-retention-period"),
+		Usage:    "The minimum period for which the blobs requested with a shorter retention period than the store duration are kept after they're confirmed",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MIN_BLOB_RETENTION_PERIOD"),
+	}
+	// The node doesn't register itself in observer mode, so it can be run by prospective operators.
+	ObserverModeFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "observer-mode"),
+		Usage:    "Whether to run the node as an observer, which validates and times the batches it receives without storing nor signing them, e.g. to benchmark hardware against real traffic before registering",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "OBSERVER_MODE"),
+	}
 	// NumBatchValidators is the maximum number of parallel workers used to
 	// validate a batch (defaults to 128).
 	NumBatchValidatorsFlag = cli.IntFlag{
@@ -190,6 +211,22 @@ var (
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NUM_BATCH_VALIDATORS"),
 		Value:    128,
 	}
+	// MinNumBatchValidators enables the auto-tuning of the number of batch validators, between this minimum and
+	// num-batch-validators, from the time the validation of the batches takes relative to their deadline.
+	MinNumBatchValidatorsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-num-batch-validators"),
+		Usage:    "If set, the number of parallel workers used to validate a batch is tuned between this minimum and num-batch-validators: it's raised when the validation of the batches gets close to their deadline, and lowered when it's far from it to leave CPU to the retrieval requests. If not set, num-batch-validators workers are always used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MIN_NUM_BATCH_VALIDATORS"),
+		Value:    0,
+	}
+	MaxBatchNumBlobsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-batch-num-blobs"),
+		Usage:    "Maximum number of blobs of a batch received in the minibatches of a StoreChunksStream, which is aborted once the batch exceeds it",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_BATCH_NUM_BLOBS"),
+		Value:    10_000,
+	}
 
 	// Test only, DO NOT USE the following flags in production
 
@@ -237,6 +274,92 @@ var (
 		Value:    "",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CLIENT_IP_HEADER"),
 	}
+	// The node signs the public key of the certificate with its BLS key, so that retrievers can verify that the chunks
+	// are served by the operator rather than by a host spoofing its socket.
+	RetrievalTLSCertFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-tls-cert-file"),
+		Usage:    "Path to the TLS certificate to serve retrievals with. If set, the retrieval server only accepts TLS connections",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_TLS_CERT_FILE"),
+	}
+	RetrievalTLSKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-tls-key-file"),
+		Usage:    "Path to the private key of the retrieval TLS certificate",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_TLS_KEY_FILE"),
+	}
+	RetrievalTLSClientCAFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-tls-client-ca-file"),
+		Usage:    "Path to the PEM bundle of the CAs the certificates of the retrieval clients are verified with. If set, the retrieval clients must present a certificate signed by one of them (mTLS)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_TLS_CLIENT_CA_FILE"),
+	}
+	DispersalTLSCertFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-tls-cert-file"),
+		Usage:    "Path to the TLS certificate to serve dispersals with. If set, the dispersal server only accepts TLS connections",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSAL_TLS_CERT_FILE"),
+	}
+	DispersalTLSKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-tls-key-file"),
+		Usage:    "Path to the private key of the dispersal TLS certificate",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSAL_TLS_KEY_FILE"),
+	}
+	DispersalTLSClientCAFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-tls-client-ca-file"),
+		Usage:    "Path to the PEM bundle of the CAs the certificates of the dispersers are verified with. If set, the dispersers must present a certificate signed by one of them (mTLS)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSAL_TLS_CLIENT_CA_FILE"),
+	}
+	TLSReloadIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-reload-interval"),
+		Usage:    "How often the TLS files of the dispersal and retrieval servers are checked for changes, e.g. after a certificate rotation. The files are only loaded at startup if 0",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_RELOAD_INTERVAL"),
+	}
+	// The disperser announces the upcoming large batches to the operators that registered a webhook with it.
+	AnnouncementPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "announcement-port"),
+		Usage:    "Port at which the node receives the announcements of the upcoming large batches from the disperser. The announcements are not received if empty",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ANNOUNCEMENT_PORT"),
+	}
+	AnnouncementSecretFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "announcement-secret-file"),
+		Usage:    "Path to the file containing the secret shared with the disperser to verify the signature of the announcements",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ANNOUNCEMENT_SECRET_FILE"),
+	}
+	AdminAddressFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-address"),
+		Usage:    "Address of the admin listener of the node, e.g. 127.0.0.1:9095, which serves the signed inventory of the stored chunks at /inventory. It must only be reachable by the operator. The admin endpoints are not served if empty",
+		Required: false,
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ADMIN_ADDRESS"),
+	}
+	// The chunks are encrypted at rest with a 32 bytes hex encoded key, read from a keystore file or from AWS Secrets
+	// Manager, if either is set.
+	ChunkEncryptionKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-key-file"),
+		Usage:    "Path to the file containing the hex encoded 32 bytes key the stored chunks are encrypted with. The chunks are stored in the clear if neither this nor the chunk encryption key secret is set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_KEY_FILE"),
+	}
+	ChunkEncryptionKeySecretFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-key-secret"),
+		Usage:    "Name of the AWS Secrets Manager secret containing the hex encoded 32 bytes key the stored chunks are encrypted with",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_KEY_SECRET"),
+	}
+	ChunkEncryptionKeySecretRegionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-key-secret-region"),
+		Usage:    "AWS region of the chunk encryption key secret",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_KEY_SECRET_REGION"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -263,14 +386,32 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	RegisterAtNodeStartFlag,
 	ExpirationPollIntervalSecFlag,
+	RetrievalExpiryGracePeriodFlag,
+	MinBlobRetentionPeriodFlag,
+	ObserverModeFlag,
 	EnableTestModeFlag,
 	OverrideBlockStaleMeasureFlag,
 	OverrideStoreDurationBlocksFlag,
 	TestPrivateBlsFlag,
 	NumBatchValidatorsFlag,
+	MinNumBatchValidatorsFlag,
+	MaxBatchNumBlobsFlag,
 	InternalDispersalPortFlag,
 	InternalRetrievalPortFlag,
 	ClientIPHeaderFlag,
+	RetrievalTLSCertFileFlag,
+	RetrievalTLSKeyFileFlag,
+	RetrievalTLSClientCAFileFlag,
+	DispersalTLSCertFileFlag,
+	DispersalTLSKeyFileFlag,
+	DispersalTLSClientCAFileFlag,
+	TLSReloadIntervalFlag,
+	AnnouncementPortFlag,
+	AnnouncementSecretFileFlag,
+	AdminAddressFlag,
+	ChunkEncryptionKeyFileFlag,
+	ChunkEncryptionKeySecretFlag,
+	ChunkEncryptionKeySecretRegionFlag,
 	ChurnerUseSecureGRPC,
 	EcdsaKeyFileFlag,
 	EcdsaKeyPasswordFlag,
@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// OperatorIdentityHeader is the gRPC header of the retrieval replies carrying the signature, by the BLS key the
+// operator registered on chain, of the public key of the TLS certificate the node serves retrievals with
+const OperatorIdentityHeader = "eigenda-operator-identity-bin"
+
+// operatorIdentityDomain separates the signatures of TLS certificates from the signatures of batches
+var operatorIdentityDomain = []byte("EigenDA operator identity v1")
+
+// ErrOperatorIdentity is returned when the host serving chunks doesn't prove it controls the identity of the operator
+var ErrOperatorIdentity = errors.New("operator identity not verified")
+
+// OperatorIdentityMessage returns the message the operator signs to bind the public key of the TLS certificate of its
+// retrieval server to its identity. Since the node proves it holds the private key of the certificate in the TLS
+// handshake, a host can only present the signature of a certificate it controls.
+func OperatorIdentityMessage(operatorID core.OperatorID, cert *x509.Certificate) [32]byte {
+	publicKeyHash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	var message [32]byte
+	copy(message[:], crypto.Keccak256(operatorIdentityDomain, operatorID[:], publicKeyHash[:]))
+	return message
+}
+
+// SignOperatorIdentity signs the binding of the TLS certificate to the identity of the operator with its BLS key
+func SignOperatorIdentity(keyPair *core.KeyPair, operatorID core.OperatorID, cert *x509.Certificate) *core.Signature {
+	return keyPair.SignMessage(OperatorIdentityMessage(operatorID, cert))
+}
+
+// VerifyOperatorIdentity checks that the peer of a retrieval call presented a TLS certificate signed by the BLS key
+// the operator registered on chain, given the peer and the header received with grpc.Peer and grpc.Header
+func VerifyOperatorIdentity(operatorID core.OperatorID, pubkey *core.G2Point, p *peer.Peer, header metadata.MD) error {
+	if pubkey == nil {
+		return fmt.Errorf("%w: no public key registered for operator %s", ErrOperatorIdentity, operatorID.Hex())
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return fmt.Errorf("%w: operator %s didn't present a TLS certificate", ErrOperatorIdentity, operatorID.Hex())
+	}
+	// The node sends the signatures of its current and previous certificates, so that the connections established
+	// before a rotation of its certificate keep being verified.
+	values := header.Get(OperatorIdentityHeader)
+	if len(values) == 0 {
+		return fmt.Errorf("%w: operator %s didn't send a signature of its TLS certificate", ErrOperatorIdentity, operatorID.Hex())
+	}
+	if len(values) > 2 {
+		return fmt.Errorf("%w: operator %s sent %d signatures of its TLS certificates", ErrOperatorIdentity, operatorID.Hex(), len(values))
+	}
+	message := OperatorIdentityMessage(operatorID, tlsInfo.State.PeerCertificates[0])
+	for _, value := range values {
+		point, err := new(core.G1Point).Deserialize([]byte(value))
+		if err != nil {
+			return fmt.Errorf("%w: invalid signature of the TLS certificate of operator %s: %v", ErrOperatorIdentity, operatorID.Hex(), err)
+		}
+		signature := &core.Signature{G1Point: point}
+		if signature.Verify(pubkey, message) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: the TLS certificate of operator %s isn't signed by its registered key", ErrOperatorIdentity, operatorID.Hex())
+}
+
+// operatorIdentitySigner signs the certificates the retrieval server is served with as they're rotated, keeping the
+// signatures of the current and the previous certificate.
+type operatorIdentitySigner struct {
+	keyPair    *core.KeyPair
+	operatorID core.OperatorID
+	reloader   *grpctls.Reloader
+
+	mu     sync.Mutex
+	leaf   *x509.Certificate
+	header metadata.MD
+}
+
+// Header returns the header carrying the signatures of the current and the previous certificate.
+func (s *operatorIdentitySigner) Header() metadata.MD {
+	cert := s.reloader.Certificate()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cert.Leaf != s.leaf {
+		values := []string{string(SignOperatorIdentity(s.keyPair, s.operatorID, cert.Leaf).Serialize())}
+		if s.header != nil {
+			values = append(values, s.header.Get(OperatorIdentityHeader)[0])
+		}
+		s.leaf = cert.Leaf
+		s.header = metadata.MD{OperatorIdentityHeader: values}
+	}
+	return s.header
+}
+
+// operatorIdentityInterceptor sends the signatures of the TLS certificates of the node in the header of every reply
+func operatorIdentityInterceptor(signer *operatorIdentitySigner) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := grpc.SetHeader(ctx, signer.Header()); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// retrievalTLSOptions returns the server options to serve retrievals over TLS, along with the signatures of the
+// certificates by the operator
+func retrievalTLSOptions(config grpctls.Config, keyPair *core.KeyPair, operatorID core.OperatorID, logger logging.Logger) ([]grpc.ServerOption, error) {
+	reloader, err := grpctls.NewReloader(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load retrieval TLS certificate: %w", err)
+	}
+	signer := &operatorIdentitySigner{
+		keyPair:    keyPair,
+		operatorID: operatorID,
+		reloader:   reloader,
+	}
+	return []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(reloader.TLSConfig())),
+		grpc.UnaryInterceptor(operatorIdentityInterceptor(signer)),
+	}, nil
+}
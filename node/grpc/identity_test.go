@@ -0,0 +1,79 @@
+package grpc_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func selfSignedCertificate(t *testing.T) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func tlsPeer(cert *x509.Certificate) *peer.Peer {
+	return &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	}
+}
+
+func TestVerifyOperatorIdentity(t *testing.T) {
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	operatorID := keyPair.GetPubKeyG1().GetOperatorID()
+	cert := selfSignedCertificate(t)
+
+	signature := grpc.SignOperatorIdentity(keyPair, operatorID, cert)
+	header := metadata.Pairs(grpc.OperatorIdentityHeader, string(signature.Serialize()))
+	assert.NoError(t, grpc.VerifyOperatorIdentity(operatorID, keyPair.GetPubKeyG2(), tlsPeer(cert), header))
+
+	// A host replaying the signature can't present the certificate it signs
+	err = grpc.VerifyOperatorIdentity(operatorID, keyPair.GetPubKeyG2(), tlsPeer(selfSignedCertificate(t)), header)
+	assert.ErrorIs(t, err, grpc.ErrOperatorIdentity)
+
+	// The signature binds the certificate to a single operator
+	other, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	err = grpc.VerifyOperatorIdentity(other.GetPubKeyG1().GetOperatorID(), other.GetPubKeyG2(), tlsPeer(cert), header)
+	assert.ErrorIs(t, err, grpc.ErrOperatorIdentity)
+	err = grpc.VerifyOperatorIdentity(operatorID, other.GetPubKeyG2(), tlsPeer(cert), header)
+	assert.ErrorIs(t, err, grpc.ErrOperatorIdentity)
+
+	// The connections established before a rotation of the certificate verify with the previous signature
+	rotated := grpc.SignOperatorIdentity(keyPair, operatorID, selfSignedCertificate(t))
+	rotatedHeader := metadata.Pairs(grpc.OperatorIdentityHeader, string(rotated.Serialize()), grpc.OperatorIdentityHeader, string(signature.Serialize()))
+	assert.NoError(t, grpc.VerifyOperatorIdentity(operatorID, keyPair.GetPubKeyG2(), tlsPeer(cert), rotatedHeader))
+
+	// Nodes serving retrievals without TLS or without the signature aren't verified
+	err = grpc.VerifyOperatorIdentity(operatorID, keyPair.GetPubKeyG2(), &peer.Peer{}, header)
+	assert.ErrorIs(t, err, grpc.ErrOperatorIdentity)
+	err = grpc.VerifyOperatorIdentity(operatorID, keyPair.GetPubKeyG2(), tlsPeer(cert), metadata.MD{})
+	assert.ErrorIs(t, err, grpc.ErrOperatorIdentity)
+	err = grpc.VerifyOperatorIdentity(operatorID, keyPair.GetPubKeyG2(), tlsPeer(cert), metadata.Pairs(grpc.OperatorIdentityHeader, "junk"))
+	assert.ErrorIs(t, err, grpc.ErrOperatorIdentity)
+	err = grpc.VerifyOperatorIdentity(operatorID, nil, tlsPeer(cert), header)
+	assert.ErrorIs(t, err, grpc.ErrOperatorIdentity)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 
 	"net"
@@ -11,6 +12,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -19,6 +21,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/proto"
 )
@@ -87,8 +90,12 @@ func (s *Server) serveDispersal() error {
 		s.logger.Fatalf("Could not start tcp listener: %v", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(60 * 1024 * 1024 * 1024) // 60 GiB
-	gs := grpc.NewServer(opt)
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(node.MaxBatchSize)}
+	tlsOpts, err := grpctls.ServerOptions(s.config.DispersalTLS, s.logger)
+	if err != nil {
+		s.logger.Fatalf("Could not start dispersal server with TLS: %v", err)
+	}
+	gs := grpc.NewServer(append(opts, tlsOpts...)...)
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -112,8 +119,15 @@ func (s *Server) serveRetrieval() error {
 		s.logger.Fatalf("Could not start tcp listener: %v", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
+	opts := []grpc.ServerOption{grpc.MaxRecvMsgSize(1024 * 1024 * 300)} // 300 MiB
+	if s.config.RetrievalTLS.Enabled() {
+		tlsOpts, err := retrievalTLSOptions(s.config.RetrievalTLS, s.node.KeyPair, s.config.ID, s.logger)
+		if err != nil {
+			s.logger.Fatalf("Could not start retrieval server with TLS: %v", err)
+		}
+		opts = append(opts, tlsOpts...)
+	}
+	gs := grpc.NewServer(opts...)
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -142,14 +156,26 @@ func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunk
 		return nil, err
 	}
 
-	sig, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs())
+	sig, stats, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs())
 	if err != nil {
 		return nil, err
 	}
 
 	sigData := sig.Serialize()
 
-	return &pb.StoreChunksReply{Signature: sigData[:]}, nil
+	return &pb.StoreChunksReply{Signature: sigData[:], BlobDiagnostics: getBlobDiagnostics(stats)}, nil
+}
+
+// getBlobDiagnostics converts the diagnostics of the validation of the blobs to their protobuf messages
+func getBlobDiagnostics(stats []core.BlobValidationStats) []*pb.BlobValidationDiagnostics {
+	diagnostics := make([]*pb.BlobValidationDiagnostics, len(stats))
+	for i, blobStats := range stats {
+		diagnostics[i] = &pb.BlobValidationDiagnostics{
+			VerificationMicros: uint64(blobStats.VerificationTime.Microseconds()),
+			NumChunks:          uint32(blobStats.NumChunks),
+		}
+	}
+	return diagnostics
 }
 
 func (s *Server) validateStoreChunkRequest(in *pb.StoreChunksRequest) error {
@@ -162,11 +188,19 @@ func (s *Server) validateStoreChunkRequest(in *pb.StoreChunksRequest) error {
 	if in.GetBatchHeader().GetReferenceBlockNumber() == 0 {
 		return api.NewInvalidArgError("missing reference_block_number in request")
 	}
+	if _, ok := pb.ChunkEncodingFormat_name[int32(in.GetChunkEncodingFormat())]; !ok {
+		return api.NewInvalidArgError(fmt.Sprintf("unsupported chunk_encoding_format %d in request", in.GetChunkEncodingFormat()))
+	}
+
+	return validateStoreChunkBlobs(in.GetBlobs())
+}
 
-	if len(in.GetBlobs()) == 0 {
+// validateStoreChunkBlobs validates the blobs of a StoreChunks request, or of a minibatch of a StoreChunksStream
+func validateStoreChunkBlobs(blobs []*pb.Blob) error {
+	if len(blobs) == 0 {
 		return api.NewInvalidArgError("missing blobs in request")
 	}
-	for _, blob := range in.Blobs {
+	for _, blob := range blobs {
 		if blob.GetHeader() == nil {
 			return api.NewInvalidArgError("missing blob header in request")
 		}
@@ -207,7 +241,10 @@ func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*p
 	reply, err := s.handleStoreChunksRequest(ctx, in)
 
 	// Record metrics.
-	if err != nil {
+	if errors.Is(err, node.ErrObserverMode) {
+		s.node.Metrics.RecordRPCRequest("StoreChunks", "observed")
+		return nil, api.NewGRPCError(codes.Unavailable, err.Error())
+	} else if err != nil {
 		s.node.Metrics.RecordRPCRequest("StoreChunks", "failure")
 		s.node.Logger.Error("StoreChunks failed", "err", err)
 	} else {
@@ -217,6 +254,91 @@ func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*p
 	return reply, err
 }
 
+// StoreChunksStream is called by dispersers to store data streamed in minibatches.
+func (s *Server) StoreChunksStream(stream pb.Dispersal_StoreChunksStreamServer) error {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(sec float64) {
+		s.node.Metrics.ObserveLatency("StoreChunksStream", "total", sec*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	reply, err := s.handleStoreChunksStream(stream)
+
+	if errors.Is(err, node.ErrObserverMode) {
+		s.node.Metrics.RecordRPCRequest("StoreChunksStream", "observed")
+		return api.NewGRPCError(codes.Unavailable, err.Error())
+	} else if errors.Is(err, node.ErrBatchTooLarge) {
+		s.node.Metrics.RecordRPCRequest("StoreChunksStream", "failure")
+		return api.NewResourceExhaustedError(err.Error())
+	} else if err != nil {
+		s.node.Metrics.RecordRPCRequest("StoreChunksStream", "failure")
+		s.node.Logger.Error("StoreChunksStream failed", "err", err)
+		return err
+	}
+	s.node.Metrics.RecordRPCRequest("StoreChunksStream", "success")
+	return stream.SendAndClose(reply)
+}
+
+func (s *Server) handleStoreChunksStream(stream pb.Dispersal_StoreChunksStreamServer) (*pb.StoreChunksReply, error) {
+	ctx := stream.Context()
+
+	// The first request carries the batch header along with the first minibatch
+	in, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return nil, api.NewInvalidArgError("missing batch_header in request")
+	} else if err != nil {
+		return nil, err
+	}
+	if err := s.validateStoreChunkRequest(in); err != nil {
+		return nil, err
+	}
+	batchHeader, err := GetBatchHeader(in)
+	if err != nil {
+		return nil, err
+	}
+	format := in.GetChunkEncodingFormat()
+
+	batch, err := s.node.NewBatchStream(ctx, batchHeader)
+	if err != nil {
+		return nil, err
+	}
+	defer batch.Close()
+
+	for {
+		blobs, err := GetBlobMessages(in)
+		if err != nil {
+			return nil, err
+		}
+		if err := batch.Add(blobs, in.GetBlobs()); err != nil {
+			return nil, err
+		}
+
+		in, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if in.GetBatchHeader() != nil {
+			return nil, api.NewInvalidArgError("batch_header must only be set in the first request of the stream")
+		}
+		if in.GetChunkEncodingFormat() != format {
+			return nil, api.NewInvalidArgError(fmt.Sprintf("chunk_encoding_format %d differs from the format %d of the first request", in.GetChunkEncodingFormat(), format))
+		}
+		if err := validateStoreChunkBlobs(in.GetBlobs()); err != nil {
+			return nil, err
+		}
+	}
+
+	sig, stats, err := batch.Process()
+	if err != nil {
+		return nil, err
+	}
+
+	sigData := sig.Serialize()
+
+	return &pb.StoreChunksReply{Signature: sigData[:], BlobDiagnostics: getBlobDiagnostics(stats)}, nil
+}
+
 func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksRequest) (*pb.RetrieveChunksReply, error) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(sec float64) {
 		s.node.Metrics.ObserveLatency("RetrieveChunks", "total", sec*1000) // make milliseconds
@@ -230,6 +352,10 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 	var batchHeaderHash [32]byte
 	copy(batchHeaderHash[:], in.GetBatchHeaderHash())
 
+	// Keep the batch from being removed while its chunks are being read.
+	release := s.node.Store.AcquireBatch(batchHeaderHash)
+	defer release()
+
 	blobHeader, _, err := s.getBlobHeader(ctx, batchHeaderHash, int(in.GetBlobIndex()))
 	if err != nil {
 		return nil, err
@@ -293,7 +419,7 @@ func (s *Server) GetBlobHeader(ctx context.Context, in *pb.GetBlobHeaderRequest)
 		return nil, err
 	}
 
-	proof, err := tree.GenerateProof(blobHeaderHash[:], 0)
+	proof, err := tree.GenerateProof(blobHeaderHash[:])
 	if err != nil {
 		return nil, err
 	}
@@ -307,6 +433,12 @@ func (s *Server) GetBlobHeader(ctx context.Context, in *pb.GetBlobHeaderRequest)
 	}, nil
 }
 
+// NodeInfo returns the build of the Node, on both the dispersal and the retrieval servers
+func (s *Server) NodeInfo(ctx context.Context, in *pb.NodeInfoRequest) (*pb.NodeInfoReply, error) {
+	s.node.Metrics.RecordRPCRequest("NodeInfo", "success")
+	return &pb.NodeInfoReply{BuildInfo: s.node.BuildInfo.ToProtobuf()}, nil
+}
+
 func (s *Server) getBlobHeader(ctx context.Context, batchHeaderHash [32]byte, blobIndex int) (*core.BlobHeader, *pb.BlobHeader, error) {
 
 	blobHeaderBytes, err := s.node.Store.GetBlobHeader(ctx, batchHeaderHash, blobIndex)
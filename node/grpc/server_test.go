@@ -3,6 +3,7 @@ package grpc_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"runtime"
@@ -29,7 +30,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/wealdtech/go-merkletree"
 	"github.com/wealdtech/go-merkletree/keccak256"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -74,6 +77,10 @@ func makeTestComponents() (encoding.Prover, encoding.Verifier, error) {
 }
 
 func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
+	return newTestServerWithConfig(t, mockValidator, func(*node.Config) {})
+}
+
+func newTestServerWithConfig(t *testing.T, mockValidator bool, configure func(*node.Config)) *grpc.Server {
 	dbPath := t.TempDir()
 	keyPair, err := core.GenRandomBlsKeys()
 	if err != nil {
@@ -89,6 +96,7 @@ func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
 		ID:                        opID,
 		NumBatchValidators:        runtime.GOMAXPROCS(0),
 	}
+	configure(config)
 	loggerConfig := common.DefaultLoggerConfig()
 	logger, err := common.NewLogger(loggerConfig)
 	if err != nil {
@@ -110,7 +118,8 @@ func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
 	if mockValidator {
 		mockVal := coremock.NewMockShardValidator()
 		mockVal.On("ValidateBlob", mock.Anything, mock.Anything).Return(nil)
-		mockVal.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockVal.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+		mockVal.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 		val = mockVal
 	} else {
 
@@ -134,7 +143,7 @@ func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
 	}
 
 	metrics := node.NewMetrics(noopMetrics, reg, logger, ":9090", opID, -1, tx, chainState)
-	store, err := node.NewLevelDBStore(dbPath, logger, metrics, 1e9, 1e9)
+	store, err := node.NewLevelDBStore(dbPath, logger, metrics, 1e9, 1e9, 0, 0, nil)
 	if err != nil {
 		panic("failed to create a new levelDB store")
 	}
@@ -148,6 +157,7 @@ func newTestServer(t *testing.T, mockValidator bool) *grpc.Server {
 		Store:      store,
 		ChainState: chainState,
 		Validator:  val,
+		BuildInfo:  node.NewBuildInfo(config),
 	}
 	return grpc.NewServer(config, node, logger, ratelimiter)
 }
@@ -278,6 +288,88 @@ func storeChunks(t *testing.T, server *grpc.Server) ([32]byte, [32]byte, []*core
 	return batchHeaderHash, batchRoot, blobHeaders, blobHeadersProto
 }
 
+// storeChunksStream feeds the requests to StoreChunksStream
+type storeChunksStream struct {
+	pb.Dispersal_StoreChunksStreamServer
+	requests []*pb.StoreChunksRequest
+	reply    *pb.StoreChunksReply
+}
+
+func (s *storeChunksStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *storeChunksStream) Recv() (*pb.StoreChunksRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *storeChunksStream) SendAndClose(reply *pb.StoreChunksReply) error {
+	s.reply = reply
+	return nil
+}
+
+func TestStoreChunksStream(t *testing.T) {
+	server := newTestServer(t, true)
+	req, batchHeaderHash, _, _, blobHeadersProto := makeStoreChunksRequest(t, 100, 90)
+
+	// Each blob is sent in its own minibatch
+	stream := &storeChunksStream{requests: []*pb.StoreChunksRequest{
+		{BatchHeader: req.GetBatchHeader(), Blobs: req.GetBlobs()[:1]},
+		{Blobs: req.GetBlobs()[1:]},
+	}}
+	err := server.StoreChunksStream(stream)
+	assert.NoError(t, err)
+	assert.NotNil(t, stream.reply.GetSignature())
+
+	reply, err := server.GetBlobHeader(context.Background(), &pb.GetBlobHeaderRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       1,
+		QuorumId:        0,
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(blobHeadersProto[1], reply.GetBlobHeader()))
+
+	// The batch header must only be sent first
+	stream = &storeChunksStream{requests: []*pb.StoreChunksRequest{
+		{BatchHeader: req.GetBatchHeader(), Blobs: req.GetBlobs()[:1]},
+		{BatchHeader: req.GetBatchHeader(), Blobs: req.GetBlobs()[1:]},
+	}}
+	err = server.StoreChunksStream(stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream = &storeChunksStream{requests: []*pb.StoreChunksRequest{
+		{Blobs: req.GetBlobs()},
+	}}
+	err = server.StoreChunksStream(stream)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The minibatches out of order don't match the batch root
+	stream = &storeChunksStream{requests: []*pb.StoreChunksRequest{
+		{BatchHeader: req.GetBatchHeader(), Blobs: req.GetBlobs()[1:]},
+		{Blobs: req.GetBlobs()[:1]},
+	}}
+	err = server.StoreChunksStream(stream)
+	assert.ErrorIs(t, err, core.ErrBatchRootMismatch)
+	assert.Nil(t, stream.reply)
+
+	// The stream is aborted once the batch has more blobs than allowed
+	server = newTestServerWithConfig(t, true, func(config *node.Config) {
+		config.MaxBatchNumBlobs = 1
+	})
+	stream = &storeChunksStream{requests: []*pb.StoreChunksRequest{
+		{BatchHeader: req.GetBatchHeader(), Blobs: req.GetBlobs()[:1]},
+		{Blobs: req.GetBlobs()[1:]},
+	}}
+	err = server.StoreChunksStream(stream)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Nil(t, stream.reply)
+}
+
 func TestStoreChunksRequestValidation(t *testing.T) {
 	server := newTestServer(t, true)
 
@@ -299,6 +391,12 @@ func TestStoreChunksRequestValidation(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, strings.Contains(err.Error(), "missing reference_block_number in request"))
 
+	req, _, _, _, _ = makeStoreChunksRequest(t, 66, 33)
+	req.ChunkEncodingFormat = pb.ChunkEncodingFormat(100)
+	_, err = server.StoreChunks(context.Background(), req)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "unsupported chunk_encoding_format"))
+
 	req, _, _, _, _ = makeStoreChunksRequest(t, 66, 33)
 	req.Blobs = nil
 	_, err = server.StoreChunks(context.Background(), req)
@@ -387,6 +485,40 @@ func TestRevertInvalidBatch(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestObserverMode(t *testing.T) {
+	server := newTestServerWithConfig(t, true, func(config *node.Config) {
+		config.ObserverMode = true
+	})
+
+	// a valid batch is neither stored nor signed
+	req, batchHeaderHash, _, _, _ := makeStoreChunksRequest(t, 100, 90)
+	reply, err := server.StoreChunks(context.Background(), req)
+	assert.Nil(t, reply)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.ErrorContains(t, err, "observer mode")
+
+	_, err = server.GetBlobHeader(context.Background(), &pb.GetBlobHeaderRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+		QuorumId:        0,
+	})
+	assert.Error(t, err)
+}
+
+func TestNodeInfo(t *testing.T) {
+	server := newTestServerWithConfig(t, true, func(config *node.Config) {
+		config.ObserverMode = true
+		config.EncoderConfig.SRSOrder = 3000
+	})
+
+	reply, err := server.NodeInfo(context.Background(), &pb.NodeInfoRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, node.AppName, reply.GetBuildInfo().GetService())
+	assert.Equal(t, node.SemVer, reply.GetBuildInfo().GetVersion())
+	assert.Equal(t, uint64(3000), reply.GetBuildInfo().GetSrsOrder())
+	assert.Equal(t, []string{"observer_mode"}, reply.GetBuildInfo().GetFeatures())
+}
+
 func TestGetBlobHeader(t *testing.T) {
 	server := newTestServer(t, true)
 	batchHeaderHash, batchRoot, blobHeaders, protoBlobHeaders := storeChunks(t, server)
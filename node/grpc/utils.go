@@ -5,16 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/node"
-	"github.com/consensys/gnark-crypto/ecc/bn254"
-	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
-	"github.com/wealdtech/go-merkletree"
-	"github.com/wealdtech/go-merkletree/keccak256"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -35,6 +32,7 @@ func GetBatchHeader(in *pb.StoreChunksRequest) (*core.BatchHeader, error) {
 // Note the StoreChunksRequest is validated as soon as it enters the node gRPC
 // interface, see grpc.Server.validateStoreChunkRequest.
 func GetBlobMessages(in *pb.StoreChunksRequest) ([]*core.BlobMessage, error) {
+	format := encoding.ChunkEncodingFormat(in.GetChunkEncodingFormat())
 	blobs := make([]*core.BlobMessage, len(in.GetBlobs()))
 	for i, blob := range in.GetBlobs() {
 		blobHeader, err := GetBlobHeaderFromProto(blob.GetHeader())
@@ -51,7 +49,7 @@ func GetBlobMessages(in *pb.StoreChunksRequest) ([]*core.BlobMessage, error) {
 			quorumID := blob.GetHeader().GetQuorumHeaders()[j].GetQuorumId()
 			bundles[uint8(quorumID)] = make([]*encoding.Frame, len(chunks.GetChunks()))
 			for k, data := range chunks.GetChunks() {
-				chunk, err := new(encoding.Frame).Deserialize(data)
+				chunk, err := new(encoding.Frame).DeserializeWithFormat(data, format)
 				if err != nil {
 					return nil, err
 				}
@@ -68,40 +66,39 @@ func GetBlobMessages(in *pb.StoreChunksRequest) ([]*core.BlobMessage, error) {
 }
 
 func ValidatePointsFromBlobHeader(h *pb.BlobHeader) error {
-	commitX := new(fp.Element).SetBytes(h.GetCommitment().GetX())
-	commitY := new(fp.Element).SetBytes(h.GetCommitment().GetY())
-	commitment := &encoding.G1Commitment{
-		X: *commitX,
-		Y: *commitY,
-	}
-
-	if !(*bn254.G1Affine)(commitment).IsInSubGroup() {
-		return errors.New("commitment is not in the subgroup")
-	}
-
-	var lengthCommitment, lengthProof encoding.G2Commitment
-	if h.GetLengthCommitment() != nil {
-		lengthCommitment.X.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA0())
-		lengthCommitment.X.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA1())
-		lengthCommitment.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA0())
-		lengthCommitment.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA1())
-	}
+	_, _, _, err := getCommitmentsFromProto(h)
+	return err
+}
 
-	if !(*bn254.G2Affine)(&lengthCommitment).IsInSubGroup() {
-		return errors.New("lengthCommitment is not in the subgroup")
+// getCommitmentsFromProto returns the commitment, length commitment and length proof of the blob header, checking
+// that their coordinates are canonical and the points in the subgroups. A missing length commitment or proof is the
+// point at infinity.
+func getCommitmentsFromProto(h *pb.BlobHeader) (*encoding.G1Commitment, *encoding.G2Commitment, *encoding.LengthProof, error) {
+	commitment, err := encoding.G1CommitmentFromCoordinates(h.GetCommitment().GetX(), h.GetCommitment().GetY())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid commitment: %w", err)
 	}
 
-	if h.GetLengthProof() != nil {
-		lengthProof.X.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA0())
-		lengthProof.X.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA1())
-		lengthProof.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA0())
-		lengthProof.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA1())
+	lengthCommitment, err := encoding.G2CommitmentFromCoordinates(
+		h.GetLengthCommitment().GetXA0(),
+		h.GetLengthCommitment().GetXA1(),
+		h.GetLengthCommitment().GetYA0(),
+		h.GetLengthCommitment().GetYA1(),
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid lengthCommitment: %w", err)
 	}
 
-	if !(*bn254.G2Affine)(&lengthProof).IsInSubGroup() {
-		return errors.New("lengthProof is not in the subgroup")
+	lengthProof, err := encoding.G2CommitmentFromCoordinates(
+		h.GetLengthProof().GetXA0(),
+		h.GetLengthProof().GetXA1(),
+		h.GetLengthProof().GetYA0(),
+		h.GetLengthProof().GetYA1(),
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid lengthProof: %w", err)
 	}
-	return nil
+	return commitment, lengthCommitment, lengthProof, nil
 }
 
 // GetBlobHeaderFromProto constructs a core.BlobHeader from a proto of pb.BlobHeader.
@@ -112,38 +109,9 @@ func GetBlobHeaderFromProto(h *pb.BlobHeader) (*core.BlobHeader, error) {
 
 	}
 
-	commitX := new(fp.Element).SetBytes(h.GetCommitment().GetX())
-	commitY := new(fp.Element).SetBytes(h.GetCommitment().GetY())
-	commitment := &encoding.G1Commitment{
-		X: *commitX,
-		Y: *commitY,
-	}
-
-	if !(*bn254.G1Affine)(commitment).IsInSubGroup() {
-		return nil, errors.New("commitment is not in the subgroup")
-	}
-
-	var lengthCommitment, lengthProof encoding.G2Commitment
-	if h.GetLengthCommitment() != nil {
-		lengthCommitment.X.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA0())
-		lengthCommitment.X.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA1())
-		lengthCommitment.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA0())
-		lengthCommitment.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA1())
-	}
-
-	if !(*bn254.G2Affine)(&lengthCommitment).IsInSubGroup() {
-		return nil, errors.New("lengthCommitment is not in the subgroup")
-	}
-
-	if h.GetLengthProof() != nil {
-		lengthProof.X.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA0())
-		lengthProof.X.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA1())
-		lengthProof.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA0())
-		lengthProof.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA1())
-	}
-
-	if !(*bn254.G2Affine)(&lengthProof).IsInSubGroup() {
-		return nil, errors.New("lengthProof is not in the subgroup")
+	commitment, lengthCommitment, lengthProof, err := getCommitmentsFromProto(h)
+	if err != nil {
+		return nil, err
 	}
 
 	quorumHeaders := make([]*core.BlobQuorumInfo, len(h.GetQuorumHeaders()))
@@ -169,17 +137,19 @@ func GetBlobHeaderFromProto(h *pb.BlobHeader) (*core.BlobHeader, error) {
 	return &core.BlobHeader{
 		BlobCommitments: encoding.BlobCommitments{
 			Commitment:       commitment,
-			LengthCommitment: &lengthCommitment,
-			LengthProof:      &lengthProof,
+			LengthCommitment: lengthCommitment,
+			LengthProof:      lengthProof,
 			Length:           uint(h.GetLength()),
 		},
-		QuorumInfos: quorumHeaders,
-		AccountID:   h.AccountId,
+		QuorumInfos:     quorumHeaders,
+		AccountID:       h.AccountId,
+		RetentionPeriod: time.Duration(h.GetRetentionPeriodSeconds()) * time.Second,
+		CorrelationID:   h.GetCorrelationId(),
 	}, nil
 }
 
 // rebuildMerkleTree rebuilds the merkle tree from the blob headers and batch header.
-func (s *Server) rebuildMerkleTree(batchHeaderHash [32]byte) (*merkletree.MerkleTree, error) {
+func (s *Server) rebuildMerkleTree(batchHeaderHash [32]byte) (*core.MerkleTree, error) {
 	batchHeaderBytes, err := s.node.Store.GetBatchHeader(context.Background(), batchHeaderHash)
 	if err != nil {
 		return nil, errors.New("failed to get the batch header from Store")
@@ -224,7 +194,7 @@ func (s *Server) rebuildMerkleTree(batchHeaderHash [32]byte) (*merkletree.Merkle
 		return nil, errors.New("no blob header found")
 	}
 
-	tree, err := merkletree.NewTree(merkletree.WithData(leafs), merkletree.WithHashType(keccak256.New()))
+	tree, err := core.NewMerkleTree(leafs)
 	if err != nil {
 		return nil, err
 	}
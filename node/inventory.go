@@ -0,0 +1,309 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node/leveldb"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+// inventoryDomain separates the signatures of inventories from the other messages signed with the BLS key of the node
+var inventoryDomain = []byte("EigenDA node inventory v1")
+
+// Inventory is the custody inventory of a node, i.e. everything the node currently stores, which operators and
+// auditors reconcile against the onchain assignments of the node. It's signed with the BLS key of the node.
+type Inventory struct {
+	// OperatorID is the ID of the operator, derived from the BLS public key
+	OperatorID string `json:"operatorId"`
+	// PubKeyG2 is the BLS public key of the operator the signature is verified with
+	PubKeyG2 string `json:"pubKeyG2"`
+	// CreatedAt is the Unix time in seconds at which the inventory was taken
+	CreatedAt int64             `json:"createdAt"`
+	Batches   []*BatchInventory `json:"batches"`
+	// Signature is the BLS signature of the hash of the inventory, see Hash
+	Signature string `json:"signature,omitempty"`
+}
+
+// BatchInventory is a stored batch, in the order of expiry.
+type BatchInventory struct {
+	BatchHeaderHash string `json:"batchHeaderHash"`
+	// ReferenceBlockNumber is the block at which the chunks of the batch were assigned to the operators
+	ReferenceBlockNumber uint `json:"referenceBlockNumber"`
+	// ExpiresAt is the Unix time in seconds at which the batch is deleted
+	ExpiresAt int64            `json:"expiresAt"`
+	Blobs     []*BlobInventory `json:"blobs"`
+}
+
+// BlobInventory is a stored blob of a batch.
+type BlobInventory struct {
+	BlobIndex int `json:"blobIndex"`
+	// ExpiresAt is the Unix time in seconds at which the blob is deleted, which is before its batch for the blobs
+	// requested with a shorter retention period, and the expiry of the batch otherwise
+	ExpiresAt int64              `json:"expiresAt"`
+	Quorums   []*ChunksInventory `json:"quorums"`
+}
+
+// ChunksInventory is the bundle of chunks of a blob stored for a quorum. The chunks are stored in the order of the
+// assignment of the operator, i.e. they are the chunks with the indices [StartIndex, StartIndex+NumChunks) of the
+// blob in the quorum.
+type ChunksInventory struct {
+	QuorumID core.QuorumID `json:"quorumId"`
+	// StartIndex is the index of the first chunk assigned to the operator at the reference block of the batch
+	StartIndex uint32 `json:"startIndex"`
+	NumChunks  int    `json:"numChunks"`
+	// ChunkLength is the number of symbols in a chunk
+	ChunkLength uint32 `json:"chunkLength"`
+	// Size is the number of bytes stored for the chunks
+	Size int64 `json:"size"`
+}
+
+// Hash returns the hash signed by the node, the Keccak256 hash of the inventory domain followed by the canonical
+// encoding of the inventory without its signature. The encoding is the concatenation of the fields in the order of
+// their declaration, where the quorum IDs are single bytes, the other integers are 8 bytes in big endian, the hex
+// strings are decoded and prefixed with their length as 4 bytes in big endian, and the lists are prefixed with their
+// number of elements.
+func (i *Inventory) Hash() ([32]byte, error) {
+	operatorID, err := hex.DecodeString(i.OperatorID)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid operator ID: %w", err)
+	}
+	pubKey, err := hexutil.Decode(i.PubKeyG2)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	buf := new(bytes.Buffer)
+	writeBytes(buf, operatorID)
+	writeBytes(buf, pubKey)
+	writeUint(buf, uint64(i.CreatedAt))
+	writeUint(buf, uint64(len(i.Batches)))
+	for _, batch := range i.Batches {
+		batchHeaderHash, err := hexutil.Decode(batch.BatchHeaderHash)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("invalid batch header hash: %w", err)
+		}
+		writeBytes(buf, batchHeaderHash)
+		writeUint(buf, uint64(batch.ReferenceBlockNumber))
+		writeUint(buf, uint64(batch.ExpiresAt))
+		writeUint(buf, uint64(len(batch.Blobs)))
+		for _, blob := range batch.Blobs {
+			writeUint(buf, uint64(blob.BlobIndex))
+			writeUint(buf, uint64(blob.ExpiresAt))
+			writeUint(buf, uint64(len(blob.Quorums)))
+			for _, chunks := range blob.Quorums {
+				buf.WriteByte(chunks.QuorumID)
+				writeUint(buf, uint64(chunks.StartIndex))
+				writeUint(buf, uint64(chunks.NumChunks))
+				writeUint(buf, uint64(chunks.ChunkLength))
+				writeUint(buf, uint64(chunks.Size))
+			}
+		}
+	}
+	return [32]byte(crypto.Keccak256(inventoryDomain, buf.Bytes())), nil
+}
+
+// writeUint writes an integer of the canonical encoding of the inventory, as 8 bytes in big endian
+func writeUint(buf *bytes.Buffer, v uint64) {
+	_ = binary.Write(buf, binary.BigEndian, v)
+}
+
+// writeBytes writes a byte string of the canonical encoding of the inventory, prefixed with its length
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+}
+
+// Sign signs the inventory with the BLS key of the node.
+func (i *Inventory) Sign(keyPair *core.KeyPair) error {
+	i.OperatorID = keyPair.GetPubKeyG1().GetOperatorID().Hex()
+	i.PubKeyG2 = hexutil.Encode(keyPair.GetPubKeyG2().Serialize())
+	hash, err := i.Hash()
+	if err != nil {
+		return err
+	}
+	i.Signature = hexutil.Encode(keyPair.SignMessage(hash).Serialize())
+	return nil
+}
+
+// Verify checks that the inventory is signed by the BLS key of its operator. The auditors have to check separately
+// that the public key is the one the operator registered onchain.
+func (i *Inventory) Verify() error {
+	pubKeyData, err := hexutil.Decode(i.PubKeyG2)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	pubKey, err := new(core.G2Point).Deserialize(pubKeyData)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+	sigData, err := hexutil.Decode(i.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	sig, err := new(core.G1Point).Deserialize(sigData)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	hash, err := i.Hash()
+	if err != nil {
+		return err
+	}
+	if !(&core.Signature{G1Point: sig}).Verify(pubKey, hash) {
+		return errors.New("the signature does not match the inventory")
+	}
+	return nil
+}
+
+// ChunkAssignmentFunc returns the assignment of the operator for a quorum of a blob of a batch, which gives the indices
+// of the chunks it stores.
+type ChunkAssignmentFunc func(ctx context.Context, batchHeader *core.BatchHeader, blobLength uint, quorumInfo *core.BlobQuorumInfo) (core.Assignment, error)
+
+// GetInventory returns the unsigned inventory of the batches in the store, with the indices of the chunks given by the
+// assignment function. The chunks are counted from their length prefixes without being decoded, but those of the
+// encrypted batches are decrypted first, which requires the chunk cipher of the store.
+func (s *Store) GetInventory(ctx context.Context, assignment ChunkAssignmentFunc) (*Inventory, error) {
+	blobExpiries := make(map[[32]byte]map[int]int64)
+	iter := s.db.NewIterator(EncodeBlobExpirationKeyPrefix())
+	for iter.Next() {
+		ts, batchHeaderHash, blobIndex, err := DecodeBlobExpirationKey(iter.Key())
+		if err != nil {
+			s.logger.Error("Could not decode the blob expiration key", "key:", iter.Key(), "error:", err)
+			continue
+		}
+		if blobExpiries[batchHeaderHash] == nil {
+			blobExpiries[batchHeaderHash] = make(map[int]int64)
+		}
+		blobExpiries[batchHeaderHash][blobIndex] = ts
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	inventory := &Inventory{
+		CreatedAt: time.Now().Unix(),
+		Batches:   make([]*BatchInventory, 0),
+	}
+	iter = s.db.NewIterator(EncodeBatchExpirationKeyPrefix())
+	defer iter.Release()
+	for iter.Next() {
+		ts, err := DecodeBatchExpirationKey(iter.Key())
+		if err != nil {
+			s.logger.Error("Could not decode the expiration key", "key:", iter.Key(), "error:", err)
+			continue
+		}
+		var batchHeaderHash [32]byte
+		copy(batchHeaderHash[:], iter.Value())
+		batchHeaderBytes, err := s.GetBatchHeader(ctx, batchHeaderHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the header of batch %s: %w", hexutil.Encode(batchHeaderHash[:]), err)
+		}
+		batchHeader, err := new(core.BatchHeader).Deserialize(batchHeaderBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the header of batch %s: %w", hexutil.Encode(batchHeaderHash[:]), err)
+		}
+		blobs, err := s.getBlobsInventory(ctx, batchHeaderHash, batchHeader, ts, blobExpiries[batchHeaderHash], assignment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to take the inventory of batch %s: %w", hexutil.Encode(batchHeaderHash[:]), err)
+		}
+		inventory.Batches = append(inventory.Batches, &BatchInventory{
+			BatchHeaderHash:      hexutil.Encode(batchHeaderHash[:]),
+			ReferenceBlockNumber: batchHeader.ReferenceBlockNumber,
+			ExpiresAt:            ts,
+			Blobs:                blobs,
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// getBlobsInventory returns the inventory of the blobs of a batch, in the order of their indices.
+func (s *Store) getBlobsInventory(ctx context.Context, batchHeaderHash [32]byte, batchHeader *core.BatchHeader, batchExpiry int64, blobExpiries map[int]int64, assignment ChunkAssignmentFunc) ([]*BlobInventory, error) {
+	prefix := EncodeBlobHeaderKeyPrefix(batchHeaderHash)
+	iter := s.db.NewIterator(prefix)
+	defer iter.Release()
+
+	blobs := make([]*BlobInventory, 0)
+	for iter.Next() {
+		if len(iter.Key()) != len(prefix)+4 {
+			return nil, errors.New("the blob header key is invalid")
+		}
+		blobIndex := int(int32(binary.LittleEndian.Uint32(iter.Key()[len(prefix):])))
+		header := &node.BlobHeader{}
+		if err := proto.Unmarshal(iter.Value(), header); err != nil {
+			return nil, fmt.Errorf("failed to parse the header of blob %d: %w", blobIndex, err)
+		}
+
+		blob := &BlobInventory{
+			BlobIndex: blobIndex,
+			ExpiresAt: batchExpiry,
+			Quorums:   make([]*ChunksInventory, 0, len(header.GetQuorumHeaders())),
+		}
+		if ts, ok := blobExpiries[blobIndex]; ok {
+			blob.ExpiresAt = ts
+		}
+		for _, quorumHeader := range header.GetQuorumHeaders() {
+			quorumID := core.QuorumID(quorumHeader.GetQuorumId())
+			blobKey, err := EncodeBlobKey(batchHeaderHash, blobIndex, quorumID)
+			if err != nil {
+				return nil, err
+			}
+			data, err := s.db.Get(blobKey)
+			if errors.Is(err, leveldb.ErrNotFound) {
+				// The operator may not be assigned any chunk of the quorum
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			size := int64(len(data))
+			data, err = s.decryptChunks(batchHeaderHash, blobKey, data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt the chunks of blob %d in quorum %d: %w", blobIndex, quorumID, err)
+			}
+			numChunks, err := countChunks(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to count the chunks of blob %d in quorum %d: %w", blobIndex, quorumID, err)
+			}
+			chunksAssignment, err := assignment(ctx, batchHeader, uint(header.GetLength()), &core.BlobQuorumInfo{
+				SecurityParam: core.SecurityParam{
+					QuorumID:              quorumID,
+					AdversaryThreshold:    uint8(quorumHeader.GetAdversaryThreshold()),
+					ConfirmationThreshold: uint8(quorumHeader.GetConfirmationThreshold()),
+				},
+				ChunkLength: uint(quorumHeader.GetChunkLength()),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the assignment of blob %d in quorum %d: %w", blobIndex, quorumID, err)
+			}
+			blob.Quorums = append(blob.Quorums, &ChunksInventory{
+				QuorumID:    quorumID,
+				StartIndex:  uint32(chunksAssignment.StartIndex),
+				NumChunks:   numChunks,
+				ChunkLength: quorumHeader.GetChunkLength(),
+				Size:        size,
+			})
+		}
+		blobs = append(blobs, blob)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	// The blob indices are encoded in little endian, so the keys are not in the order of the indices
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].BlobIndex < blobs[j].BlobIndex
+	})
+	return blobs, nil
+}
@@ -3,13 +3,13 @@ package node
 import (
 	"context"
 	"fmt"
-	"math/big"
 	"sort"
 	"strconv"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/threshold"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	eigenmetrics "github.com/Layr-Labs/eigensdk-go/metrics"
 
@@ -39,8 +39,24 @@ type Metrics struct {
 	AccuRemovedBatches *prometheus.CounterVec
 	// Accumulated number and size of blobs processed by quorums.
 	AccuBlobs *prometheus.CounterVec
+	// Accumulated number and size of blobs that have been removed from the Node before their batch.
+	AccuRemovedBlobs *prometheus.CounterVec
+	// The logical and physical size (in bytes) of the batches stored.
+	StoredBatchBytes *prometheus.SummaryVec
+	// The ratio of the physical to the logical size of the batches stored.
+	StorageAmplification prometheus.Summary
+	// Accumulated number of expired batches whose removal was deferred due to in-flight retrievals.
+	AccuDeferredBatchDeletions prometheus.Counter
+	// Accumulated number of announcements of upcoming large batches received from the disperser.
+	AccuBatchAnnouncements prometheus.Counter
+	// The size (in bytes) of the last upcoming large batch announced, in total and for the operator.
+	AnnouncedBatchBytes *prometheus.GaugeVec
 	// Total number of changes in the node's socket address.
 	AccuSocketUpdates prometheus.Counter
+	// The number of parallel workers used to validate a batch.
+	BatchValidators prometheus.Gauge
+	// The share of the deadline of a batch used by its validation.
+	ValidationDeadlineUsage prometheus.Summary
 	// avs node spec eigen_ metrics: https://eigen.nethermind.io/docs/spec/metrics/metrics-prom-spec
 	EigenMetrics eigenmetrics.Metrics
 
@@ -122,6 +138,55 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 			},
 			[]string{"type"},
 		),
+		AccuRemovedBlobs: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_removed_blobs_total",
+				Help:      "the total number and size of blobs that have been removed by the DA node before their batch, as their retention period expired",
+			},
+			[]string{"type"},
+		),
+		// The "type" label has values: logical, physical.
+		StoredBatchBytes: promauto.With(reg).NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  Namespace,
+				Name:       "stored_batch_bytes",
+				Help:       "the logical (chunks) and physical (all keys and values, including index overhead) size in bytes of the batches stored",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"type"},
+		),
+		StorageAmplification: promauto.With(reg).NewSummary(
+			prometheus.SummaryOpts{
+				Namespace:  Namespace,
+				Name:       "storage_amplification",
+				Help:       "the ratio of the physical to the logical size of the batches stored",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+		),
+		AccuDeferredBatchDeletions: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_deferred_batch_deletions_total",
+				Help:      "the total number of expired batches whose removal was deferred because they were being retrieved",
+			},
+		),
+		AccuBatchAnnouncements: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_batch_announcements_total",
+				Help:      "the total number of announcements of upcoming large batches received from the disperser",
+			},
+		),
+		// The "type" label has values: total, operator.
+		AnnouncedBatchBytes: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "announced_batch_bytes",
+				Help:      "the size in bytes of the last upcoming large batch announced by the disperser, in total and for the operator",
+			},
+			[]string{"type"},
+		),
 		AccuSocketUpdates: promauto.With(reg).NewCounter(
 			prometheus.CounterOpts{
 				Namespace: Namespace,
@@ -129,6 +194,21 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the total number of node's socket address updates",
 			},
 		),
+		BatchValidators: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_batch_validators",
+				Help:      "the number of parallel workers used to validate a batch",
+			},
+		),
+		ValidationDeadlineUsage: promauto.With(reg).NewSummary(
+			prometheus.SummaryOpts{
+				Namespace:  Namespace,
+				Name:       "batch_validation_deadline_usage",
+				Help:       "the share of the deadline of a batch used by its validation",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+		),
 		EigenMetrics:           eigenMetrics,
 		logger:                 logger.With("component", "NodeMetrics"),
 		registry:               reg,
@@ -159,6 +239,14 @@ func (g *Metrics) RecordSocketAddressChange() {
 	g.AccuSocketUpdates.Inc()
 }
 
+func (g *Metrics) RecordBatchValidators(numValidators int) {
+	g.BatchValidators.Set(float64(numValidators))
+}
+
+func (g *Metrics) ObserveValidationDeadlineUsage(usage float64) {
+	g.ValidationDeadlineUsage.Observe(usage)
+}
+
 func (g *Metrics) ObserveLatency(method, stage string, latencyMs float64) {
 	g.RequestLatency.WithLabelValues(method, stage).Observe(latencyMs)
 }
@@ -170,6 +258,29 @@ func (g *Metrics) RemoveNCurrentBatch(numBatches int, totalBatchSize int64) {
 	g.AccuRemovedBatches.WithLabelValues("size").Add(float64(totalBatchSize))
 }
 
+func (g *Metrics) RemoveExpiredBlobs(numBlobs int, totalBlobSize int64) {
+	g.AccuRemovedBlobs.WithLabelValues("number").Add(float64(numBlobs))
+	g.AccuRemovedBlobs.WithLabelValues("size").Add(float64(totalBlobSize))
+}
+
+func (g *Metrics) RecordBatchFootprint(logicalBytes, physicalBytes int64) {
+	g.StoredBatchBytes.WithLabelValues("logical").Observe(float64(logicalBytes))
+	g.StoredBatchBytes.WithLabelValues("physical").Observe(float64(physicalBytes))
+	if logicalBytes > 0 {
+		g.StorageAmplification.Observe(float64(physicalBytes) / float64(logicalBytes))
+	}
+}
+
+func (g *Metrics) RecordBatchAnnouncement(totalBytes, operatorBytes uint64) {
+	g.AccuBatchAnnouncements.Inc()
+	g.AnnouncedBatchBytes.WithLabelValues("total").Set(float64(totalBytes))
+	g.AnnouncedBatchBytes.WithLabelValues("operator").Set(float64(operatorBytes))
+}
+
+func (g *Metrics) DeferBatchDeletion() {
+	g.AccuDeferredBatchDeletions.Inc()
+}
+
 func (g *Metrics) AcceptBlobs(quorumId core.QuorumID, blobSize uint64) {
 	quorum := strconv.Itoa(int(quorumId))
 	g.AccuBlobs.WithLabelValues("number", quorum).Inc()
@@ -216,7 +327,7 @@ func (g *Metrics) collectOnchainMetrics() {
 		for q, operators := range state.Operators {
 			operatorStakeShares := make([]*OperatorStakeShare, 0)
 			for opId, opInfo := range operators {
-				share, _ := new(big.Int).Div(new(big.Int).Mul(opInfo.Stake, big.NewInt(10000)), state.Totals[q].Stake).Float64()
+				share := float64(threshold.StakeShareBasisPoints(opInfo.Stake, state.Totals[q].Stake))
 				operatorStakeShares = append(operatorStakeShares, &OperatorStakeShare{operatorId: opId, stakeShare: share})
 			}
 			// Descending order by stake share in the quorum.
@@ -28,3 +28,17 @@ func (c *ChurnerClient) Churn(ctx context.Context, operatorAddress string, keyPa
 	}
 	return reply, err
 }
+
+func (c *ChurnerClient) RefreshChurn(ctx context.Context, operatorAddress string, keyPair *core.KeyPair, quorumIDs []core.QuorumID) (*churnerpb.ChurnReply, error) {
+	args := c.Called()
+	var reply *churnerpb.ChurnReply
+	if args.Get(0) != nil {
+		reply = (args.Get(0)).(*churnerpb.ChurnReply)
+	}
+
+	var err error
+	if args.Get(1) != nil {
+		err = (args.Get(1)).(error)
+	}
+	return reply, err
+}
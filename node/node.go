@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/common/pubip"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -55,9 +56,15 @@ type Node struct {
 	PubIPProvider           pubip.Provider
 	OperatorSocketsFilterer indexer.OperatorSocketsFilterer
 	ChainID                 *big.Int
+	// ValidatorTuner tunes the number of batch validators. If nil, NumBatchValidators workers are used.
+	ValidatorTuner *BatchValidatorTuner
+	// BuildInfo is the build of the Node and the capabilities it's deployed with, returned by NodeInfo
+	BuildInfo buildinfo.Info
 
 	mu            sync.Mutex
 	CurrentSocket string
+	// expireNow triggers an expiration cycle ahead of the next tick of the expireLoop.
+	expireNow chan struct{}
 }
 
 // NewNode creates a new Node with the provided config.
@@ -78,7 +85,10 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger logging.Logger
 		return nil, err
 	}
 
-	config.ID = keyPair.GetPubKeyG1().GetOperatorID()
+	config.ID, err = core.OperatorIDFromPubkeys(keyPair.GetPubKeyG1(), keyPair.GetPubKeyG2())
+	if err != nil {
+		return nil, fmt.Errorf("invalid BLS key: %w", err)
+	}
 
 	// Make sure config folder exists.
 	err = os.MkdirAll(config.DbPath, os.ModePerm)
@@ -109,6 +119,10 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger logging.Logger
 	nodeApi := nodeapi.NewNodeApi(AppName, SemVer, ":"+config.NodeApiPort, logger.With("component", "NodeApi"))
 
 	metrics := NewMetrics(eigenMetrics, promReg, logger, ":"+config.MetricsPort, config.ID, config.OnchainMetricsInterval, tx, cst)
+	buildInfo := NewBuildInfo(config)
+	if err := buildinfo.Register(promReg, buildInfo); err != nil {
+		return nil, fmt.Errorf("failed to register build info: %w", err)
+	}
 
 	// Make validator
 	v, err := verifier.NewVerifier(&config.EncoderConfig, false)
@@ -138,8 +152,20 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger logging.Logger
 		}
 		storeDurationBlocks = storeDuration
 	}
+	var chunkCipher *ChunkCipher
+	if config.ChunkEncryptionKeyFile != "" || config.ChunkEncryptionKeySecret != "" {
+		key, err := LoadChunkEncryptionKey(context.Background(), config.ChunkEncryptionKeyFile, config.ChunkEncryptionKeySecret, config.ChunkEncryptionKeySecretRegion)
+		if err != nil {
+			return nil, err
+		}
+		chunkCipher, err = NewChunkCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the chunk cipher: %w", err)
+		}
+		logger.Info("The stored chunks are encrypted")
+	}
 	// Create new store
-	store, err := NewLevelDBStore(config.DbPath+"/chunk", logger, metrics, blockStaleMeasure, storeDurationBlocks)
+	store, err := NewLevelDBStore(config.DbPath+"/chunk", logger, metrics, blockStaleMeasure, storeDurationBlocks, config.RetrievalExpiryGracePeriod, config.MinBlobRetentionPeriod, chunkCipher)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new store: %w", err)
 	}
@@ -149,10 +175,15 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger logging.Logger
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new operator sockets filterer: %w", err)
 	}
+	var validatorTuner *BatchValidatorTuner
+	if config.MinNumBatchValidators > 0 {
+		validatorTuner = NewBatchValidatorTuner(config.MinNumBatchValidators, config.NumBatchValidators, logger, metrics)
+	}
+
 	nodeLogger := logger.With("component", "Node")
 	nodeLogger.Info("Creating node", "chainID", chainID.String(), "operatorID", config.ID.Hex(),
 		"dispersalPort", config.DispersalPort, "retrievalPort", config.RetrievalPort, "churnerUrl", config.ChurnerUrl,
-		"quorumIDs", fmt.Sprint(config.QuorumIDList), "registerNodeAtStart", config.RegisterNodeAtStart, "pubIPCheckInterval", config.PubIPCheckInterval,
+		"quorumIDs", fmt.Sprint(config.QuorumIDList), "registerNodeAtStart", config.RegisterNodeAtStart, "observerMode", config.ObserverMode, "pubIPCheckInterval", config.PubIPCheckInterval,
 		"eigenDAServiceManagerAddr", config.EigenDAServiceManagerAddr, "blockStaleMeasure", blockStaleMeasure, "storeDurationBlocks", storeDurationBlocks)
 
 	return &Node{
@@ -168,9 +199,39 @@ func NewNode(config *Config, pubIPProvider pubip.Provider, logger logging.Logger
 		PubIPProvider:           pubIPProvider,
 		OperatorSocketsFilterer: socketsFilterer,
 		ChainID:                 chainID,
+		ValidatorTuner:          validatorTuner,
+		BuildInfo:               buildInfo,
+		expireNow:               make(chan struct{}, 1),
 	}, nil
 }
 
+// NewBuildInfo returns the build of the Node and the optional features enabled in the config
+func NewBuildInfo(config *Config) buildinfo.Info {
+	var features []string
+	if config.ObserverMode {
+		features = append(features, "observer_mode")
+	}
+	if config.DispersalTLS.Enabled() {
+		features = append(features, "dispersal_tls")
+	}
+	if config.RetrievalTLS.Enabled() {
+		features = append(features, "retrieval_tls")
+	}
+	if config.ChunkEncryptionKeyFile != "" || config.ChunkEncryptionKeySecret != "" {
+		features = append(features, "chunk_encryption")
+	}
+	if config.MinNumBatchValidators > 0 {
+		features = append(features, "batch_validator_tuning")
+	}
+	return buildinfo.Info{
+		Service:  AppName,
+		Version:  SemVer,
+		Commit:   GitCommit,
+		SRSOrder: config.EncoderConfig.SRSOrder,
+		Features: features,
+	}
+}
+
 // Starts the Node. If the node is not registered, register it on chain, otherwise just
 // update its socket on chain.
 func (n *Node) Start(ctx context.Context) error {
@@ -185,6 +246,15 @@ func (n *Node) Start(ctx context.Context) error {
 
 	go n.expireLoop()
 
+	if n.Config.AnnouncementPort != "" {
+		go n.serveAnnouncements()
+		n.Logger.Info("Receiving batch announcements", "port", n.Config.AnnouncementPort)
+	}
+	if n.Config.AdminAddress != "" {
+		go n.serveAdmin()
+		n.Logger.Info("Serving the admin endpoints", "address", n.Config.AdminAddress)
+	}
+
 	// Build the socket based on the hostname/IP provided in the CLI
 	socket := string(core.MakeOperatorSocket(n.Config.Hostname, n.Config.DispersalPort, n.Config.RetrievalPort))
 	var operator *Operator
@@ -212,6 +282,8 @@ func (n *Node) Start(ctx context.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to register the operator: %w", err)
 		}
+	} else if n.Config.ObserverMode {
+		n.Logger.Info("The node has started in observer mode: the batches it receives are validated, but neither stored nor signed")
 	} else {
 		eigenDAUrl, ok := eigenDAUIMap[n.ChainID.String()]
 		if ok {
@@ -222,12 +294,9 @@ func (n *Node) Start(ctx context.Context) error {
 	}
 
 	if operator != nil && operator.Address != "" {
-		operatorID, err := n.Transactor.OperatorAddressToID(ctx, gethcommon.HexToAddress(operator.Address))
+		err := core.VerifyOperatorRegistration(ctx, n.Transactor, gethcommon.HexToAddress(operator.Address), operator.OperatorId)
 		if err != nil {
-			return fmt.Errorf("failed to get operator ID: %w", err)
-		}
-		if operatorID != operator.OperatorId {
-			return fmt.Errorf("operator ID mismatch: expected %s, got %s", operator.OperatorId.Hex(), operatorID.Hex())
+			return err
 		}
 	}
 
@@ -249,7 +318,10 @@ func (n *Node) expireLoop() {
 	defer ticker.Stop()
 
 	for {
-		<-ticker.C
+		select {
+		case <-ticker.C:
+		case <-n.expireNow:
+		}
 
 		// We cap the time the deletion function can run, to make sure there is no overlapping
 		// between loops and the garbage collection doesn't take too much resource.
@@ -277,18 +349,27 @@ func (n *Node) expireLoop() {
 //   - If the batch is stored already, it's no-op to store it more than once
 //   - If the batch is stored, but the processing fails after that, these data items will not be rollback
 //   - These data items will be garbage collected eventually when they become stale.
-func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob) (*core.Signature, error) {
+//
+// Along with the signature, it returns the diagnostics of the validation of each blob.
+func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob) (*core.Signature, []core.BlobValidationStats, error) {
+	return n.processBatch(ctx, header, blobs, rawBlobs, func() ([]core.BlobValidationStats, error) {
+		return n.ValidateBatch(ctx, header, blobs)
+	})
+}
+
+// processBatch stores the blobs of the batch while validate validates them, and signs the batch if they're valid
+func (n *Node) processBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob, validate func() ([]core.BlobValidationStats, error)) (*core.Signature, []core.BlobValidationStats, error) {
 	start := time.Now()
 	log := n.Logger
 
-	log.Debug("Processing batch", "num of blobs", len(blobs))
+	log.Debug("Processing batch", "num of blobs", len(blobs), "correlationIDs", blobCorrelationIDs(blobs))
 
 	if len(blobs) == 0 {
-		return nil, errors.New("ProcessBatch: number of blobs must be greater than zero")
+		return nil, nil, errors.New("ProcessBatch: number of blobs must be greater than zero")
 	}
 
 	if len(blobs) != len(rawBlobs) {
-		return nil, errors.New("number of parsed blobs must be the same as number of blobs from protobuf request")
+		return nil, nil, errors.New("number of parsed blobs must be the same as number of blobs from protobuf request")
 	}
 
 	// Measure num batches received and its size in bytes
@@ -303,7 +384,11 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	batchHeaderHash, err := header.GetBatchHeaderHash()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if n.Config.ObserverMode {
+		return nil, nil, n.observeBatch(blobs, batchHeaderHash, batchSize, validate)
 	}
 
 	// Store the batch.
@@ -342,7 +427,7 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	// Validate batch.
 	stageTimer := time.Now()
-	err = n.ValidateBatch(ctx, header, blobs)
+	stats, err := validate()
 	if err != nil {
 		// If we have already stored the batch into database, but it's not valid, we
 		// revert all the keys for that batch.
@@ -352,7 +437,8 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 				log.Error("Failed to delete the invalid batch that should be rolled back", "batchHeaderHash", batchHeaderHash, "err", deleteKeysErr)
 			}
 		}
-		return nil, fmt.Errorf("failed to validate batch: %w", err)
+		log.Warn("Failed to validate batch", "batchHeaderHash", hexutil.Encode(batchHeaderHash[:]), "correlationIDs", blobCorrelationIDs(blobs), "err", err)
+		return nil, nil, fmt.Errorf("failed to validate batch: %w", err)
 	}
 	n.Metrics.AcceptBatches("validated", batchSize)
 	n.Metrics.ObserveLatency("StoreChunks", "validated", float64(time.Since(stageTimer).Milliseconds()))
@@ -361,7 +447,7 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 	// Before we sign the batch, we should first complete the batch storing successfully.
 	result := <-storeChan
 	if result.err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if result.keys != nil {
 		n.Metrics.AcceptBatches("stored", batchSize)
@@ -377,20 +463,66 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 	n.Metrics.ObserveLatency("StoreChunks", "signed", float64(time.Since(stageTimer).Milliseconds()))
 	log.Debug("Sign batch took", "duration", time.Since(stageTimer))
 
-	log.Info("StoreChunks succeeded")
+	log.Info("StoreChunks succeeded", "batchHeaderHash", hexutil.Encode(batchHeaderHash[:]), "correlationIDs", blobCorrelationIDs(blobs))
 
 	log.Debug("Exiting process batch", "duration", time.Since(start))
-	return sig, nil
+	return sig, stats, nil
+}
+
+// blobCorrelationIDs returns the correlation IDs of the blobs, which join the log lines of the batch with those of
+// the disperser and the encoders
+func blobCorrelationIDs(blobs []*core.BlobMessage) []string {
+	ids := make([]string, len(blobs))
+	for i, blob := range blobs {
+		if blob.BlobHeader != nil {
+			ids[i] = blob.BlobHeader.CorrelationID
+		}
+	}
+	return ids
+}
+
+// observeBatch validates a batch received in observer mode, and reports the outcome and the duration of the
+// validation without storing nor signing the batch. It returns ErrObserverMode if the batch is valid.
+func (n *Node) observeBatch(blobs []*core.BlobMessage, batchHeaderHash [32]byte, batchSize uint64, validate func() ([]core.BlobValidationStats, error)) error {
+	stageTimer := time.Now()
+	_, err := validate()
+	latency := time.Since(stageTimer)
+	if err != nil {
+		n.Logger.Warn("Observed an invalid batch", "batchHeaderHash", hexutil.Encode(batchHeaderHash[:]), "numBlobs", len(blobs), "batchSize", batchSize, "validationDuration", latency, "err", err)
+		return fmt.Errorf("failed to validate batch: %w", err)
+	}
+
+	n.Metrics.AcceptBatches("observed", batchSize)
+	n.Metrics.ObserveLatency("StoreChunks", "observed", float64(latency.Milliseconds()))
+	n.Logger.Info("Observed a valid batch", "batchHeaderHash", hexutil.Encode(batchHeaderHash[:]), "numBlobs", len(blobs), "batchSize", batchSize, "validationDuration", latency)
+	return ErrObserverMode
 }
 
-func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage) error {
+func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage) ([]core.BlobValidationStats, error) {
 	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, n.Config.ID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if n.ValidatorTuner == nil {
+		pool := workerpool.New(n.Config.NumBatchValidators)
+		return n.Validator.ValidateBatch(header, blobs, operatorState, pool)
 	}
 
-	pool := workerpool.New(n.Config.NumBatchValidators)
-	return n.Validator.ValidateBatch(header, blobs, operatorState, pool)
+	// The validation is timed against the time left until the deadline of the request, so that the number of batch
+	// validators follows how close the node gets to not signing the batches in time
+	start := time.Now()
+	budget := n.Config.Timeout
+	if deadline, ok := ctx.Deadline(); ok {
+		budget = deadline.Sub(start)
+	}
+	pool := workerpool.New(n.ValidatorTuner.NumValidators())
+	stats, err := n.Validator.ValidateBatch(header, blobs, operatorState, pool)
+	if err != nil {
+		return nil, err
+	}
+	n.ValidatorTuner.Observe(time.Since(start), budget)
+	return stats, nil
 }
 
 func (n *Node) updateSocketAddress(ctx context.Context, newSocketAddr string) {
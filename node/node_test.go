@@ -13,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/node"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -57,7 +58,7 @@ func newComponents(t *testing.T) *components {
 
 	mockVal := coremock.NewMockShardValidator()
 	mockVal.On("ValidateBlob", mock.Anything, mock.Anything).Return(nil)
-	mockVal.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockVal.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	chainState, _ := coremock.MakeChainDataMock(map[uint8]int{
 		0: 4,
@@ -65,7 +66,7 @@ func newComponents(t *testing.T) *components {
 		2: 4,
 	})
 
-	store, err := node.NewLevelDBStore(dbPath, logger, nil, 1e9, 1e9)
+	store, err := node.NewLevelDBStore(dbPath, logger, nil, 1e9, 1e9, 0, 0, nil)
 	if err != nil {
 		panic("failed to create a new levelDB store")
 	}
@@ -112,6 +113,7 @@ func TestNodeStartOperatorIDMatch(t *testing.T) {
 	c.tx.On("RegisterOperator", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	c.tx.On("OperatorAddressToID", mock.Anything).Return(core.OperatorID(opID), nil)
+	c.tx.On("OperatorIDToAddress").Return(gethcommon.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), nil)
 
 	err := c.node.Start(context.Background())
 	assert.NoError(t, err)
@@ -139,3 +141,28 @@ func TestNodeStartOperatorIDDoesNotMatch(t *testing.T) {
 	err := c.node.Start(context.Background())
 	assert.ErrorContains(t, err, "operator ID mismatch")
 }
+
+func TestNodeStartOperatorIDCollision(t *testing.T) {
+	c := newComponents(t)
+	c.node.Config.RegisterNodeAtStart = true
+	c.node.Config.EthClientConfig = geth.EthClientConfig{
+		RPCURLs:          []string{"http://localhost:8545"},
+		PrivateKeyString: privateKey,
+		NumConfirmations: 1,
+	}
+	c.tx.On("GetRegisteredQuorumIdsForOperator", mock.Anything).Return([]core.QuorumID{}, nil)
+	c.tx.On("GetOperatorSetParams", mock.Anything, mock.Anything).Return(&core.OperatorSetParam{
+		MaxOperatorCount:         uint32(4),
+		ChurnBIPsOfOperatorStake: uint16(1000),
+		ChurnBIPsOfTotalStake:    uint16(10),
+	}, nil)
+	c.tx.On("GetNumberOfRegisteredOperatorForQuorum", mock.Anything, mock.Anything).Return(uint32(0), nil)
+	c.tx.On("RegisterOperator", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	// The BLS key of the node is registered by another operator
+	c.tx.On("OperatorAddressToID", mock.Anything).Return(core.OperatorID{}, nil)
+	c.tx.On("OperatorIDToAddress").Return(gethcommon.HexToAddress("0x1"), nil)
+
+	err := c.node.Start(context.Background())
+	assert.ErrorIs(t, err, core.ErrOperatorIDCollision)
+}
@@ -9,8 +9,11 @@ import (
 	"slices"
 	"time"
 
+	churnerpb "github.com/Layr-Labs/eigenda/api/grpc/churner"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/operators/churner"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -85,6 +88,24 @@ func RegisterOperator(ctx context.Context, operator *Operator, transactor core.T
 			return fmt.Errorf("failed to request churn approval: %w", err)
 		}
 
+		// The stakes may change between the churn approval and the registration, so re-validate the approval at the
+		// block the registration is executed at, to fail with a stake changed error rather than a reverted registration
+		if err := validateChurnApproval(ctx, operator, transactor, churnReply); err != nil {
+			return fmt.Errorf("failed to validate churn approval: %w", err)
+		}
+
+		err = transactor.RegisterOperatorWithChurn(ctx, operator.KeyPair, operator.Socket, quorumsToRegister, operator.PrivKey, salt, expiry, churnReply)
+		if err == nil {
+			return nil
+		}
+
+		// The approval may have been made stale by stake changes after it was validated, in which case refreshing it
+		// fails with a stake changed error. Otherwise retry the registration once with the refreshed approval.
+		logger.Warn("Failed to register operator with churn, refreshing churn approval", "err", err)
+		churnReply, refreshErr := churnerClient.RefreshChurn(ctx, operator.Address, operator.KeyPair, quorumsToRegister)
+		if refreshErr != nil {
+			return fmt.Errorf("failed to refresh churn approval after failing to register operator (%v): %w", err, refreshErr)
+		}
 		return transactor.RegisterOperatorWithChurn(ctx, operator.KeyPair, operator.Socket, quorumsToRegister, operator.PrivKey, salt, expiry, churnReply)
 	} else {
 		// other wise just register normally
@@ -92,6 +113,20 @@ func RegisterOperator(ctx context.Context, operator *Operator, transactor core.T
 	}
 }
 
+// validateChurnApproval re-validates the operators to churn of the churn approval against the stakes at the current
+// block. The error is one for which churner.IsStakeChangedError is true if the approval no longer holds.
+func validateChurnApproval(ctx context.Context, operator *Operator, transactor core.Transactor, churnReply *churnerpb.ChurnReply) error {
+	operatorsToChurn, err := churner.ConvertToOperatorsToChurn(churnReply.GetOperatorsToChurn())
+	if err != nil {
+		return err
+	}
+	blockNumber, err := transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	return churner.ValidateChurnApproval(ctx, transactor, gethcommon.HexToAddress(operator.Address), operatorsToChurn, blockNumber)
+}
+
 // DeregisterOperator deregisters the operator with the given public key from the specified quorums that it is registered with at the supplied block number.
 // If the operator isn't registered with any of the specified quorums, this function will return error, and no quorum will be deregistered.
 func DeregisterOperator(ctx context.Context, operator *Operator, KeyPair *core.KeyPair, transactor core.Transactor) error {
@@ -2,15 +2,19 @@ package node_test
 
 import (
 	"context"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
 
+	churnerpb "github.com/Layr-Labs/eigenda/api/grpc/churner"
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/node"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/Layr-Labs/eigenda/operators/churner"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -72,6 +76,18 @@ func TestRegisterOperatorWithChurn(t *testing.T) {
 		OperatorId: operatorID,
 		QuorumIDs:  []core.QuorumID{1},
 	}
+	operatorToChurnKeyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	churnReply := &churnerpb.ChurnReply{
+		SignatureWithSaltAndExpiry: &churnerpb.SignatureWithSaltAndExpiry{},
+		OperatorsToChurn: []*churnerpb.OperatorToChurn{
+			{
+				QuorumId: 1,
+				Operator: gethcommon.HexToAddress("0x0000000000000000000000000000000000000001").Bytes(),
+				Pubkey:   operatorToChurnKeyPair.GetPubKeyG1().Serialize(),
+			},
+		},
+	}
 	tx := &coremock.MockTransactor{}
 	tx.On("GetRegisteredQuorumIdsForOperator").Return([]uint8{2}, nil)
 	tx.On("GetOperatorSetParams", mock.Anything, mock.Anything).Return(&core.OperatorSetParam{
@@ -80,10 +96,74 @@ func TestRegisterOperatorWithChurn(t *testing.T) {
 		ChurnBIPsOfTotalStake:    20000,
 	}, nil)
 	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(1), nil)
+	tx.On("GetCurrentBlockNumber").Return(uint32(10), nil)
+	tx.On("GetOperatorStakesForQuorums").Return(core.OperatorStakes{
+		1: {
+			0: {
+				OperatorID: operatorToChurnKeyPair.GetPubKeyG1().GetOperatorID(),
+				Stake:      big.NewInt(2),
+			},
+		},
+	}, nil)
+	tx.On("WeightOfOperatorForQuorum").Return(big.NewInt(1), nil)
 	tx.On("RegisterOperatorWithChurn", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	churnerClient := &nodemock.ChurnerClient{}
-	churnerClient.On("Churn").Return(nil, nil)
+	churnerClient.On("Churn").Return(churnReply, nil)
 	err = node.RegisterOperator(context.Background(), operator, tx, churnerClient, logger)
 	assert.NoError(t, err)
 	tx.AssertCalled(t, "RegisterOperatorWithChurn", mock.Anything, mock.Anything, mock.Anything, []core.QuorumID{1}, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
+
+func TestRegisterOperatorWithChurnStakeChanged(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	operatorID := [32]byte(hexutil.MustDecode("0x3fbfefcdc76462d2cdb7d0cea75f27223829481b8b4aa6881c94cb2126a316ad"))
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	operatorToChurnKeyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	churnReply := &churnerpb.ChurnReply{
+		SignatureWithSaltAndExpiry: &churnerpb.SignatureWithSaltAndExpiry{},
+		OperatorsToChurn: []*churnerpb.OperatorToChurn{
+			{
+				QuorumId: 1,
+				Operator: gethcommon.HexToAddress("0x0000000000000000000000000000000000000001").Bytes(),
+				Pubkey:   operatorToChurnKeyPair.GetPubKeyG1().Serialize(),
+			},
+		},
+	}
+	operator := &node.Operator{
+		Address:    "0xB7Ad27737D88B07De48CDc2f379917109E993Be4",
+		Socket:     "localhost:50051",
+		Timeout:    10 * time.Second,
+		PrivKey:    nil,
+		KeyPair:    keyPair,
+		OperatorId: operatorID,
+		QuorumIDs:  []core.QuorumID{1},
+	}
+	tx := &coremock.MockTransactor{}
+	tx.On("GetRegisteredQuorumIdsForOperator").Return([]uint8{2}, nil)
+	tx.On("GetOperatorSetParams", mock.Anything, mock.Anything).Return(&core.OperatorSetParam{
+		MaxOperatorCount:         1,
+		ChurnBIPsOfOperatorStake: 11000,
+		ChurnBIPsOfTotalStake:    20000,
+	}, nil)
+	tx.On("GetNumberOfRegisteredOperatorForQuorum").Return(uint32(1), nil)
+	tx.On("GetCurrentBlockNumber").Return(uint32(10), nil)
+	// the stake of the operator to churn grew since the approval, so that the registering operator no longer has
+	// 10% more stake
+	tx.On("GetOperatorStakesForQuorums").Return(core.OperatorStakes{
+		1: {
+			0: {
+				OperatorID: operatorToChurnKeyPair.GetPubKeyG1().GetOperatorID(),
+				Stake:      big.NewInt(100),
+			},
+		},
+	}, nil)
+	tx.On("WeightOfOperatorForQuorum").Return(big.NewInt(105), nil)
+	churnerClient := &nodemock.ChurnerClient{}
+	churnerClient.On("Churn").Return(churnReply, nil)
+	err = node.RegisterOperator(context.Background(), operator, tx, churnerClient, logger)
+	assert.Error(t, err)
+	assert.True(t, churner.IsStakeChangedError(err))
+	tx.AssertNotCalled(t, "RegisterOperatorWithChurn", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
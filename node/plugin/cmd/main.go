@@ -67,7 +67,11 @@ func pluginOps(ctx *cli.Context) {
 	}
 	log.Printf("Info: Bls key read and decrypted from %s", config.BlsKeyFile)
 
-	operatorID := keyPair.GetPubKeyG1().GetOperatorID()
+	operatorID, err := core.OperatorIDFromPubkeys(keyPair.GetPubKeyG1(), keyPair.GetPubKeyG2())
+	if err != nil {
+		log.Printf("Error: invalid BLS key: %v", err)
+		return
+	}
 
 	sk, privateKey, err := plugin.GetECDSAPrivateKey(config.EcdsaKeyFile, config.EcdsaKeyPassword)
 	if err != nil {
@@ -3,9 +3,12 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/cipher"
 	"encoding/binary"
 	"errors"
 	"io"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
@@ -20,10 +23,31 @@ const (
 	// How many batches to delete in one atomic operation during the expiration
 	// garbage collection.
 	numBatchesToDeleteAtomically = 8
+	// How many blobs expiring before their batch to delete in one atomic operation.
+	numBlobsToDeleteAtomically = 64
 )
 
 var ErrBatchAlreadyExist = errors.New("batch already exists")
 
+// BatchStats is the storage footprint of a batch, recorded when the batch is stored.
+type BatchStats struct {
+	BatchHeaderHash [32]byte
+	NumBlobs        int
+	// LogicalBytes is the size of the chunks of the batch.
+	LogicalBytes int64
+	// PhysicalBytes is the size of all the keys and values stored for the batch, i.e. the chunks plus the overhead of
+	// the batch and blob headers, the expiry index entries and the stats entry itself.
+	PhysicalBytes int64
+}
+
+// Amplification returns the ratio of the physical to the logical bytes of the batch.
+func (s *BatchStats) Amplification() float64 {
+	if s.LogicalBytes == 0 {
+		return 0
+	}
+	return float64(s.PhysicalBytes) / float64(s.LogicalBytes)
+}
+
 // Store is a key-value database to store blob data (blob header, blob chunks etc).
 type Store struct {
 	db     DB
@@ -32,13 +56,28 @@ type Store struct {
 	blockStaleMeasure   uint32
 	storeDurationBlocks uint32
 
+	// How long past its expiry the deletion of a batch can be deferred while it's being retrieved.
+	retrievalGracePeriod time.Duration
+	// The minimum period the blobs requested with a shorter retention period than the store duration are kept after
+	// they're confirmed.
+	minBlobRetentionPeriod time.Duration
+
+	// Number of in-flight retrievals referencing each batch, keyed by batch header hash.
+	retrievalsMu sync.Mutex
+	retrievals   map[[32]byte]int
+
 	// The DA Node's metrics.
 	metrics *Metrics
+
+	// The cipher of the chunks, which are stored in the clear if nil.
+	chunkCipher *ChunkCipher
 }
 
 // NewLevelDBStore creates a new Store object with a db at the provided path and the given logger.
+// If chunkCipher is not nil, the chunks of the new batches are encrypted with it. The batches stored before their
+// chunks were encrypted can still be read.
 // TODO(jianoaix): parameterize this so we can switch between different database backends.
-func NewLevelDBStore(path string, logger logging.Logger, metrics *Metrics, blockStaleMeasure, storeDurationBlocks uint32) (*Store, error) {
+func NewLevelDBStore(path string, logger logging.Logger, metrics *Metrics, blockStaleMeasure, storeDurationBlocks uint32, retrievalGracePeriod, minBlobRetentionPeriod time.Duration, chunkCipher *ChunkCipher) (*Store, error) {
 	// Create the db at the path. This is currently hardcoded to use
 	// levelDB.
 	db, err := leveldb.NewLevelDBStore(path)
@@ -48,17 +87,50 @@ func NewLevelDBStore(path string, logger logging.Logger, metrics *Metrics, block
 	}
 
 	return &Store{
-		db:                  db,
-		logger:              logger.With("component", "NodeStore"),
-		blockStaleMeasure:   blockStaleMeasure,
-		storeDurationBlocks: storeDurationBlocks,
-		metrics:             metrics,
+		db:                     db,
+		logger:                 logger.With("component", "NodeStore"),
+		blockStaleMeasure:      blockStaleMeasure,
+		storeDurationBlocks:    storeDurationBlocks,
+		retrievalGracePeriod:   retrievalGracePeriod,
+		minBlobRetentionPeriod: minBlobRetentionPeriod,
+		retrievals:             make(map[[32]byte]int),
+		metrics:                metrics,
+		chunkCipher:            chunkCipher,
 	}, nil
 }
 
+// AcquireBatch records an in-flight retrieval of the batch, which keeps the batch from being
+// deleted for up to the retrieval grace period after it expires.
+// The returned function releases the reference and must be called once the retrieval is done.
+func (s *Store) AcquireBatch(batchHeaderHash [32]byte) func() {
+	s.retrievalsMu.Lock()
+	s.retrievals[batchHeaderHash]++
+	s.retrievalsMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.retrievalsMu.Lock()
+			defer s.retrievalsMu.Unlock()
+			s.retrievals[batchHeaderHash]--
+			if s.retrievals[batchHeaderHash] <= 0 {
+				delete(s.retrievals, batchHeaderHash)
+			}
+		})
+	}
+}
+
+// isBeingRetrieved returns whether there is any in-flight retrieval of the batch.
+func (s *Store) isBeingRetrieved(batchHeaderHash [32]byte) bool {
+	s.retrievalsMu.Lock()
+	defer s.retrievalsMu.Unlock()
+	return s.retrievals[batchHeaderHash] > 0
+}
+
 // Delete expired entries in the store.
 // An entry is expired if its expiry <= currentTimeUnixSec, where expiry and
 // currentTimeUnixSec are time since Unix epoch (in seconds).
+// The blobs with a shorter retention period than their batch are deleted first, each blob atomically.
 // The deletion of a batch is done atomically, i.e. either all or none entries of a batch will be deleted.
 // The function will exit with deadline exceeded error if it cannot finish after timeLimitSec seconds.
 // The function returns the number of batches deleted and the status of deletion. Note that the
@@ -68,13 +140,19 @@ func (s *Store) DeleteExpiredEntries(currentTimeUnixSec int64, timeLimitSec uint
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeLimitSec)*time.Second)
 	defer cancel()
 
+	if err := s.deleteExpiredBlobs(ctx, currentTimeUnixSec); err != nil {
+		return 0, err
+	}
+
+	// Batches whose deletion is deferred in this run, so they are skipped by the subsequent scans.
+	deferred := make(map[[32]byte]struct{})
 	numBatchesDeleted := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return numBatchesDeleted, ctx.Err()
 		default:
-			numDeleted, err := s.deleteNBatches(currentTimeUnixSec, numBatchesToDeleteAtomically)
+			numDeleted, err := s.deleteNBatches(currentTimeUnixSec, numBatchesToDeleteAtomically, deferred)
 			if err != nil {
 				return numBatchesDeleted, err
 			}
@@ -90,7 +168,9 @@ func (s *Store) DeleteExpiredEntries(currentTimeUnixSec int64, timeLimitSec uint
 
 // Returns the number of batches we deleted and the status of deletion. The number
 // is set to -1 (invalid value) if the deletion status is an error.
-func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, error) {
+// Expired batches that are being retrieved and are still within the retrieval grace period
+// are not deleted; they are added to deferred instead.
+func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int, deferred map[[32]byte]struct{}) (int, error) {
 	// Scan for expired batches.
 	iter := s.db.NewIterator(EncodeBatchExpirationKeyPrefix())
 	expiredKeys := make([][]byte, 0)
@@ -105,6 +185,17 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 		if currentTimeUnixSec < ts {
 			break
 		}
+		var batchHeaderHash [32]byte
+		copy(batchHeaderHash[:], iter.Value())
+		if _, ok := deferred[batchHeaderHash]; ok {
+			continue
+		}
+		if currentTimeUnixSec < ts+int64(s.retrievalGracePeriod.Seconds()) && s.isBeingRetrieved(batchHeaderHash) {
+			deferred[batchHeaderHash] = struct{}{}
+			s.metrics.DeferBatchDeletion()
+			s.logger.Info("Deferred the deletion of an expired batch that is being retrieved", "batchHeaderHash", hexutil.Encode(batchHeaderHash[:]), "expiry", ts)
+			continue
+		}
 		expiredKeys = append(expiredKeys, copyBytes(iter.Key()))
 		expiredBatches = append(expiredBatches, copyBytes(iter.Value()))
 		if len(expiredKeys) == numBatches {
@@ -125,8 +216,11 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 		var batchHeaderHash [32]byte
 		copy(batchHeaderHash[:], hash)
 
-		// Batch header.
-		expiredKeys = append(expiredKeys, EncodeBatchHeaderKey(batchHeaderHash))
+		// Batch header, stats and data key.
+		expiredKeys = append(expiredKeys, EncodeBatchHeaderKey(batchHeaderHash), EncodeBatchStatsKey(batchHeaderHash), EncodeBatchDataKeyKey(batchHeaderHash))
+		if s.chunkCipher != nil {
+			s.chunkCipher.ForgetDataKey(batchHeaderHash)
+		}
 
 		// Blob headers.
 		blobHeaderIter := s.db.NewIterator(EncodeBlobHeaderKeyPrefix(batchHeaderHash))
@@ -157,13 +251,112 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 	return len(expiredBatches), nil
 }
 
+// deleteExpiredBlobs deletes the chunks and the headers of the blobs which expired before their batch. Like the
+// batches, the deletion of the blobs of a batch which is being retrieved is deferred within the retrieval grace period.
+func (s *Store) deleteExpiredBlobs(ctx context.Context, currentTimeUnixSec int64) error {
+	// Blobs whose deletion is deferred in this run, keyed by their expiration key.
+	deferred := make(map[string]struct{})
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			numDeleted, err := s.deleteNBlobs(currentTimeUnixSec, numBlobsToDeleteAtomically, deferred)
+			if err != nil {
+				return err
+			}
+			if numDeleted == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// blobID identifies a blob of a batch in the store.
+type blobID struct {
+	batchHeaderHash [32]byte
+	blobIndex       int
+}
+
+// Returns the number of blobs deleted, which expired before their batch.
+func (s *Store) deleteNBlobs(currentTimeUnixSec int64, numBlobs int, deferred map[string]struct{}) (int, error) {
+	iter := s.db.NewIterator(EncodeBlobExpirationKeyPrefix())
+	expiredKeys := make([][]byte, 0)
+	expiredBlobs := make([]blobID, 0)
+	for iter.Next() {
+		ts, batchHeaderHash, blobIndex, err := DecodeBlobExpirationKey(iter.Key())
+		if err != nil {
+			s.logger.Error("Could not decode the blob expiration key", "key:", iter.Key(), "error:", err)
+			continue
+		}
+		// No more blobs expired up to current time.
+		if currentTimeUnixSec < ts {
+			break
+		}
+		if _, ok := deferred[string(iter.Key())]; ok {
+			continue
+		}
+		if currentTimeUnixSec < ts+int64(s.retrievalGracePeriod.Seconds()) && s.isBeingRetrieved(batchHeaderHash) {
+			deferred[string(iter.Key())] = struct{}{}
+			s.logger.Info("Deferred the deletion of an expired blob that is being retrieved", "batchHeaderHash", hexutil.Encode(batchHeaderHash[:]), "blobIndex", blobIndex, "expiry", ts)
+			continue
+		}
+
+		expiredKeys = append(expiredKeys, copyBytes(iter.Key()))
+		expiredBlobs = append(expiredBlobs, blobID{batchHeaderHash: batchHeaderHash, blobIndex: blobIndex})
+		if len(expiredBlobs) == numBlobs {
+			break
+		}
+	}
+	iter.Release()
+
+	if len(expiredBlobs) == 0 {
+		return 0, nil
+	}
+
+	// Scan for the header and the chunks of each expired blob.
+	size := int64(0)
+	for _, blob := range expiredBlobs {
+		blobHeaderKey, err := EncodeBlobHeaderKey(blob.batchHeaderHash, blob.blobIndex)
+		if err != nil {
+			return -1, err
+		}
+		expiredKeys = append(expiredKeys, blobHeaderKey)
+
+		prefix, err := EncodeBlobKeyPrefix(blob.batchHeaderHash, blob.blobIndex)
+		if err != nil {
+			return -1, err
+		}
+		blobIter := s.db.NewIterator(prefix)
+		for blobIter.Next() {
+			expiredKeys = append(expiredKeys, copyBytes(blobIter.Key()))
+			size += int64(len(blobIter.Value()))
+		}
+		blobIter.Release()
+	}
+
+	err := s.db.DeleteBatch(expiredKeys)
+	if err != nil {
+		s.logger.Error("Failed to delete the expired blobs", "error:", err)
+		return -1, err
+	}
+	s.metrics.RemoveExpiredBlobs(len(expiredBlobs), size)
+
+	return len(expiredBlobs), nil
+}
+
 // Store the batch into the store.
 //
 // The batch will be itemized into multiple entries when it's stored:
 //   - Batch header: keyed by <batchHeaderPrefix, batchHeaderHash>
 //   - Batch expiry: keyed by <batchExprationPrefix, expirationTime>
+//   - Blob expiry: keyed by <blobExpirationPrefix, expirationTime, batchHeaderHash, blobIdx>, for each blob in the
+//     batch whose retention period makes it expire before the batch
 //   - The header of each blob in the batch: one entry to each blob header, keyed by <blobHeaderPrefix, batchHeaderHash, blobIdx>
 //   - The chunks of each blob in the batch: one entry for each blob chunks, keyed by <batchHeaderHash, blobIdx, quorumID>
+//   - Batch stats: the storage footprint of the batch, keyed by <batchStatsPrefix, batchHeaderHash>
+//   - Batch data key: the wrapped key the chunks of the batch are encrypted with, keyed by
+//     <batchDataKeyPrefix, batchHeaderHash>, if the chunks are encrypted
 //
 // These entries will be stored atomically, i.e. either all or none entries will be stored.
 func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, blobsProto []*node.Blob) (*[][]byte, error) {
@@ -211,11 +404,28 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 	//
 	// Note if a batch is unconfirmed, it could be removed even earlier; here we treat its
 	// lifecycle the same as confirmed batches for simplicity.
+	//
+	// The blobs requested with a shorter retention period expire the same way, with their
+	// retention period (at least the minimum retention period of the node) in place of
+	// storeDurationBlocks.
 	expirationTime := curr + int64(timeToExpire)
 	expirationKey := EncodeBatchExpirationKey(expirationTime)
 	keys = append(keys, expirationKey)
 	values = append(values, batchHeaderHash[:])
 
+	// The data key the chunks of the batch are encrypted with.
+	var dataKey cipher.AEAD
+	var wrappedDataKey []byte
+	if s.chunkCipher != nil {
+		dataKey, wrappedDataKey, err = s.chunkCipher.NewDataKey(batchHeaderHash)
+		if err != nil {
+			log.Error("Cannot generate the data key of the batch:", "err", err)
+			return nil, err
+		}
+		keys = append(keys, EncodeBatchDataKeyKey(batchHeaderHash))
+		values = append(values, wrappedDataKey)
+	}
+
 	// Generate key/value pairs for all blob headers and blob chunks .
 	size := int64(0)
 	for idx, blob := range blobs {
@@ -233,6 +443,20 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 		keys = append(keys, blobHeaderKey)
 		values = append(values, blobHeaderBytes)
 
+		// blob expiry, if the blob expires before the batch
+		if blob.BlobHeader != nil && blob.BlobHeader.RetentionPeriod > 0 {
+			blobExpirationTime := curr + int64(s.blockStaleMeasure)*12 + int64(max(blob.BlobHeader.RetentionPeriod, s.minBlobRetentionPeriod).Seconds())
+			if blobExpirationTime < expirationTime {
+				blobExpirationKey, err := EncodeBlobExpirationKey(blobExpirationTime, batchHeaderHash, idx)
+				if err != nil {
+					log.Error("Cannot generate the key for storing blob expiry:", "err", err)
+					return nil, err
+				}
+				keys = append(keys, blobExpirationKey)
+				values = append(values, []byte{})
+			}
+		}
+
 		// blob chunks
 		for quorumID, bundle := range blob.Bundles {
 			key, err := EncodeBlobKey(batchHeaderHash, idx, quorumID)
@@ -254,6 +478,13 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 				return nil, err
 			}
 			size += int64(len(chunkBytes))
+			if dataKey != nil {
+				chunkBytes, err = seal(dataKey, chunkBytes, key)
+				if err != nil {
+					log.Error("Cannot encrypt the chunks:", "err", err)
+					return nil, err
+				}
+			}
 
 			keys = append(keys, key)
 			values = append(values, chunkBytes)
@@ -261,12 +492,36 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 		}
 	}
 
+	// Generate the key/value pair for the batch stats, which account for all the entries of the batch.
+	stats := &BatchStats{
+		BatchHeaderHash: batchHeaderHash,
+		NumBlobs:        len(blobs),
+		LogicalBytes:    size,
+	}
+	batchStatsKey := EncodeBatchStatsKey(batchHeaderHash)
+	stats.PhysicalBytes = int64(len(batchStatsKey) + batchStatsSize)
+	for i := range keys {
+		stats.PhysicalBytes += int64(len(keys[i]) + len(values[i]))
+	}
+	keys = append(keys, batchStatsKey)
+	values = append(values, encodeBatchStats(stats))
+
 	// Write all the key/value pairs to the local database atomically.
 	err = s.db.WriteBatch(keys, values)
 	if err != nil {
 		log.Error("Failed to write the batch into local database:", "err", err)
 		return nil, err
 	}
+	s.metrics.RecordBatchFootprint(stats.LogicalBytes, stats.PhysicalBytes)
+
+	// A concurrent store of the same batch may have overwritten the entries of this one, the data key is only cached
+	// if it's the one persisted with the chunks.
+	if dataKey != nil {
+		persisted, err := s.db.Get(EncodeBatchDataKeyKey(batchHeaderHash))
+		if err == nil && bytes.Equal(persisted, wrappedDataKey) {
+			s.chunkCipher.cacheDataKey(batchHeaderHash, dataKey)
+		}
+	}
 
 	return &keys, nil
 }
@@ -284,6 +539,47 @@ func (s *Store) GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) ([
 	return data, nil
 }
 
+// GetBatchStats returns the storage stats of the batch with the given batchHeaderHash.
+func (s *Store) GetBatchStats(ctx context.Context, batchHeaderHash [32]byte) (*BatchStats, error) {
+	key := EncodeBatchStatsKey(batchHeaderHash)
+	data, err := s.db.Get(key)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return decodeBatchStats(key, data)
+}
+
+// GetLargestBatches returns the storage stats of the n batches in the store with the most physical bytes, in
+// descending order. All the batches are returned if n is not positive.
+// Batches stored before the stats were recorded are not included.
+func (s *Store) GetLargestBatches(ctx context.Context, n int) ([]*BatchStats, error) {
+	batches := make([]*BatchStats, 0)
+	iter := s.db.NewIterator(EncodeBatchStatsKeyPrefix())
+	defer iter.Release()
+	for iter.Next() {
+		stats, err := decodeBatchStats(iter.Key(), iter.Value())
+		if err != nil {
+			s.logger.Error("Could not decode the batch stats", "key:", iter.Key(), "error:", err)
+			continue
+		}
+		batches = append(batches, stats)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].PhysicalBytes > batches[j].PhysicalBytes
+	})
+	if n > 0 && len(batches) > n {
+		batches = batches[:n]
+	}
+	return batches, nil
+}
+
 // GetBlobHeader returns the blob header for the given batchHeaderHash, blob index.
 func (s *Store) GetBlobHeader(ctx context.Context, batchHeaderHash [32]byte, blobIndex int) ([]byte, error) {
 	blobHeaderKey, err := EncodeBlobHeaderKey(batchHeaderHash, blobIndex)
@@ -315,6 +611,12 @@ func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobInd
 	}
 	log.Debug("Retrieved chunk", "blobKey", hexutil.Encode(blobKey), "length", len(data))
 
+	data, err = s.decryptChunks(batchHeaderHash, blobKey, data)
+	if err != nil {
+		log.Error("Failed to decrypt the chunks", "blobKey", hexutil.Encode(blobKey), "err", err)
+		return nil, false
+	}
+
 	chunks, err := decodeChunks(data)
 	if err != nil {
 		return nil, false
@@ -322,6 +624,36 @@ func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobInd
 	return chunks, true
 }
 
+// decryptChunks decrypts the chunks stored under blobKey if their batch is encrypted. The batches stored without a
+// data key are in the clear.
+func (s *Store) decryptChunks(batchHeaderHash [32]byte, blobKey, data []byte) ([]byte, error) {
+	if s.chunkCipher != nil {
+		// The data keys of the batches read recently are cached, sparing the lookup of the wrapped key. The cached
+		// key is stale if the batch was stored again concurrently, in which case the persisted key is read again.
+		if dataKey, ok := s.chunkCipher.lookupDataKey(batchHeaderHash); ok {
+			if plaintext, err := open(dataKey, data, blobKey); err == nil {
+				return plaintext, nil
+			}
+			s.chunkCipher.ForgetDataKey(batchHeaderHash)
+		}
+	}
+	wrappedDataKey, err := s.db.Get(EncodeBatchDataKeyKey(batchHeaderHash))
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.chunkCipher == nil {
+		return nil, errors.New("the chunks are encrypted but no chunk encryption key is configured")
+	}
+	dataKey, err := s.chunkCipher.OpenDataKey(batchHeaderHash, wrappedDataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(dataKey, data, blobKey)
+}
+
 // HasKey returns if a given key has been stored.
 func (s *Store) HasKey(ctx context.Context, key []byte) bool {
 	_, err := s.db.Get(key)
@@ -388,6 +720,21 @@ func decodeChunks(data []byte) ([][]byte, error) {
 	return chunks, nil
 }
 
+// countChunks returns the number of chunks encoded by encodeChunks, walking their length prefixes without copying them.
+func countChunks(data []byte) (int, error) {
+	numChunks := 0
+	for len(data) >= 8 {
+		length := binary.LittleEndian.Uint64(data)
+		data = data[8:]
+		if length > uint64(len(data)) {
+			return 0, errors.New("the chunk length exceeds the encoded chunks")
+		}
+		data = data[length:]
+		numChunks++
+	}
+	return numChunks, nil
+}
+
 func copyBytes(src []byte) []byte {
 	dst := make([]byte, len(src))
 	copy(dst, src)
@@ -20,12 +20,13 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 )
 
 // Creates a batch and returns its header and blobs.
-func CreateBatch(t *testing.T) (*core.BatchHeader, []*core.BlobMessage, []*pb.Blob) {
+func CreateBatch(t testing.TB) (*core.BatchHeader, []*core.BlobMessage, []*pb.Blob) {
 	var commitX, commitY, lengthX, lengthY fp.Element
 	_, err := commitX.SetString("21661178944771197726808973281966770251114553549453983978976194544185382599016")
 	assert.NoError(t, err)
@@ -186,7 +187,7 @@ func TestStoringBlob(t *testing.T) {
 		0: 6,
 		1: 3,
 	})
-	s, _ := node.NewLevelDBStore(t.TempDir(), logger, node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat), staleMeasure, storeDuration)
+	s, _ := node.NewLevelDBStore(t.TempDir(), logger, node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat), staleMeasure, storeDuration, 0, 0, nil)
 	ctx := context.Background()
 
 	// Empty store
@@ -253,3 +254,259 @@ func TestStoringBlob(t *testing.T) {
 	assert.False(t, s.HasKey(ctx, blobKey1))
 	assert.False(t, s.HasKey(ctx, blobKey2))
 }
+
+func TestDeferExpiryOfRetrievedBatch(t *testing.T) {
+	staleMeasure := uint32(1)
+	storeDuration := uint32(1)
+	gracePeriod := time.Minute
+	noopMetrics := metrics.NewNoopMetrics()
+	reg := prometheus.NewRegistry()
+	logger := logging.NewNoopLogger()
+	operatorId := [32]byte(hexutil.MustDecode("0x3fbfefcdc76462d2cdb7d0cea75f27223829481b8b4aa6881c94cb2126a316ad"))
+	tx := &coremock.MockTransactor{}
+	dat, _ := mock.MakeChainDataMock(map[uint8]int{
+		0: 6,
+		1: 3,
+	})
+	nodeMetrics := node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat)
+	s, _ := node.NewLevelDBStore(t.TempDir(), logger, nodeMetrics, staleMeasure, storeDuration, gracePeriod, 0, nil)
+	ctx := context.Background()
+
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+	batchHeaderKey := node.EncodeBatchHeaderKey(batchHeaderHash)
+
+	expiry := time.Now().Unix() + int64(staleMeasure+storeDuration)*12
+
+	// The batch is not removed while it's being retrieved.
+	release := s.AcquireBatch(batchHeaderHash)
+	releaseOther := s.AcquireBatch(batchHeaderHash)
+	numDeleted, err := s.DeleteExpiredEntries(expiry+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numDeleted)
+	assert.True(t, s.HasKey(ctx, batchHeaderKey))
+	assert.Equal(t, float64(1), testutil.ToFloat64(nodeMetrics.AccuDeferredBatchDeletions))
+
+	// Releasing one of the references still keeps the batch around.
+	release()
+	release()
+	numDeleted, err = s.DeleteExpiredEntries(expiry+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numDeleted)
+	assert.True(t, s.HasKey(ctx, batchHeaderKey))
+
+	// Once the grace period has passed, the batch is removed even if it's still being retrieved.
+	numDeleted, err = s.DeleteExpiredEntries(expiry+int64(gracePeriod.Seconds())+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numDeleted)
+	assert.False(t, s.HasKey(ctx, batchHeaderKey))
+	assert.Equal(t, float64(2), testutil.ToFloat64(nodeMetrics.AccuDeferredBatchDeletions))
+	releaseOther()
+}
+
+func TestExpireReleasedBatch(t *testing.T) {
+	staleMeasure := uint32(1)
+	storeDuration := uint32(1)
+	noopMetrics := metrics.NewNoopMetrics()
+	reg := prometheus.NewRegistry()
+	logger := logging.NewNoopLogger()
+	operatorId := [32]byte(hexutil.MustDecode("0x3fbfefcdc76462d2cdb7d0cea75f27223829481b8b4aa6881c94cb2126a316ad"))
+	tx := &coremock.MockTransactor{}
+	dat, _ := mock.MakeChainDataMock(map[uint8]int{
+		0: 6,
+		1: 3,
+	})
+	s, _ := node.NewLevelDBStore(t.TempDir(), logger, node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat), staleMeasure, storeDuration, time.Minute, 0, nil)
+	ctx := context.Background()
+
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+
+	release := s.AcquireBatch(batchHeaderHash)
+	release()
+
+	expiry := time.Now().Unix() + int64(staleMeasure+storeDuration)*12
+	numDeleted, err := s.DeleteExpiredEntries(expiry+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numDeleted)
+	assert.False(t, s.HasKey(ctx, node.EncodeBatchHeaderKey(batchHeaderHash)))
+}
+
+func TestExpireBlobWithShortRetentionPeriod(t *testing.T) {
+	staleMeasure := uint32(1)
+	storeDuration := uint32(100)
+	noopMetrics := metrics.NewNoopMetrics()
+	reg := prometheus.NewRegistry()
+	logger := logging.NewNoopLogger()
+	operatorId := [32]byte(hexutil.MustDecode("0x3fbfefcdc76462d2cdb7d0cea75f27223829481b8b4aa6881c94cb2126a316ad"))
+	tx := &coremock.MockTransactor{}
+	dat, _ := mock.MakeChainDataMock(map[uint8]int{
+		0: 6,
+		1: 3,
+	})
+	nodeMetrics := node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat)
+	s, _ := node.NewLevelDBStore(t.TempDir(), logger, nodeMetrics, staleMeasure, storeDuration, time.Minute, 30*time.Second, nil)
+	ctx := context.Background()
+
+	// The first blob is kept for the minimum retention period of the node rather than the one requested.
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	blobs[0].BlobHeader.RetentionPeriod = time.Second
+	_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+	batchHeaderKey := node.EncodeBatchHeaderKey(batchHeaderHash)
+	blobHeaderKey0, err := node.EncodeBlobHeaderKey(batchHeaderHash, 0)
+	assert.Nil(t, err)
+	blobKey0, err := node.EncodeBlobKey(batchHeaderHash, 0, 0)
+	assert.Nil(t, err)
+	blobKey1, err := node.EncodeBlobKey(batchHeaderHash, 1, 0)
+	assert.Nil(t, err)
+
+	blobExpiry := time.Now().Unix() + int64(staleMeasure)*12 + 30
+	numDeleted, err := s.DeleteExpiredEntries(blobExpiry-10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numDeleted)
+	assert.True(t, s.HasKey(ctx, blobKey0))
+
+	// Once expired, the blob is deleted while the rest of the batch is kept.
+	numDeleted, err = s.DeleteExpiredEntries(blobExpiry+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, numDeleted)
+	assert.False(t, s.HasKey(ctx, blobHeaderKey0))
+	assert.False(t, s.HasKey(ctx, blobKey0))
+	assert.True(t, s.HasKey(ctx, blobKey1))
+	assert.True(t, s.HasKey(ctx, batchHeaderKey))
+	assert.Equal(t, float64(1), testutil.ToFloat64(nodeMetrics.AccuRemovedBlobs.WithLabelValues("number")))
+
+	// The rest of the batch expires with the batch.
+	batchExpiry := time.Now().Unix() + int64(staleMeasure+storeDuration)*12
+	numDeleted, err = s.DeleteExpiredEntries(batchExpiry+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numDeleted)
+	assert.False(t, s.HasKey(ctx, batchHeaderKey))
+	assert.False(t, s.HasKey(ctx, blobKey1))
+}
+
+func TestBatchStats(t *testing.T) {
+	staleMeasure := uint32(1)
+	storeDuration := uint32(1)
+	noopMetrics := metrics.NewNoopMetrics()
+	reg := prometheus.NewRegistry()
+	logger := logging.NewNoopLogger()
+	operatorId := [32]byte(hexutil.MustDecode("0x3fbfefcdc76462d2cdb7d0cea75f27223829481b8b4aa6881c94cb2126a316ad"))
+	tx := &coremock.MockTransactor{}
+	dat, _ := mock.MakeChainDataMock(map[uint8]int{
+		0: 6,
+		1: 3,
+	})
+	nodeMetrics := node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat)
+	s, _ := node.NewLevelDBStore(t.TempDir(), logger, nodeMetrics, staleMeasure, storeDuration, 0, 0, nil)
+	ctx := context.Background()
+
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+
+	// The logical bytes are the stored chunks, each of them prefixed with its length.
+	logical := int64(0)
+	for idx := range blobs {
+		for quorumID := range blobs[idx].Bundles {
+			chunks, ok := s.GetChunks(ctx, batchHeaderHash, idx, quorumID)
+			assert.True(t, ok)
+			for _, chunk := range chunks {
+				logical += int64(8 + len(chunk))
+			}
+		}
+	}
+	stats, err := s.GetBatchStats(ctx, batchHeaderHash)
+	assert.Nil(t, err)
+	assert.Equal(t, batchHeaderHash, stats.BatchHeaderHash)
+	assert.Equal(t, len(blobs), stats.NumBlobs)
+	assert.Equal(t, logical, stats.LogicalBytes)
+	assert.Greater(t, stats.Amplification(), 1.0)
+	assert.Equal(t, 2, testutil.CollectAndCount(nodeMetrics.StoredBatchBytes))
+
+	largest, err := s.GetLargestBatches(ctx, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []*node.BatchStats{stats}, largest)
+
+	// The stats are deleted with the batch.
+	curTime := time.Now().Unix() + int64(staleMeasure+storeDuration)*12
+	numDeleted, err := s.DeleteExpiredEntries(curTime+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numDeleted)
+	_, err = s.GetBatchStats(ctx, batchHeaderHash)
+	assert.ErrorIs(t, err, node.ErrKeyNotFound)
+	largest, err = s.GetLargestBatches(ctx, 5)
+	assert.Nil(t, err)
+	assert.Empty(t, largest)
+}
+
+func TestInventory(t *testing.T) {
+	staleMeasure := uint32(1)
+	storeDuration := uint32(100)
+	noopMetrics := metrics.NewNoopMetrics()
+	reg := prometheus.NewRegistry()
+	logger := logging.NewNoopLogger()
+	operatorId := [32]byte(hexutil.MustDecode("0x3fbfefcdc76462d2cdb7d0cea75f27223829481b8b4aa6881c94cb2126a316ad"))
+	tx := &coremock.MockTransactor{}
+	dat, _ := mock.MakeChainDataMock(map[uint8]int{
+		0: 6,
+		1: 3,
+	})
+	nodeMetrics := node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat)
+	s, _ := node.NewLevelDBStore(t.TempDir(), logger, nodeMetrics, staleMeasure, storeDuration, 0, 30*time.Second, nil)
+	ctx := context.Background()
+
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	blobs[1].BlobHeader.RetentionPeriod = time.Second
+	_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+
+	inventory, err := s.GetInventory(ctx, func(ctx context.Context, header *core.BatchHeader, blobLength uint, quorumInfo *core.BlobQuorumInfo) (core.Assignment, error) {
+		assert.Equal(t, batchHeader.ReferenceBlockNumber, header.ReferenceBlockNumber)
+		return core.Assignment{StartIndex: 2, NumChunks: 1}, nil
+	})
+	assert.Nil(t, err)
+	assert.Len(t, inventory.Batches, 1)
+	batch := inventory.Batches[0]
+	assert.Equal(t, hexutil.Encode(batchHeaderHash[:]), batch.BatchHeaderHash)
+	assert.Equal(t, batchHeader.ReferenceBlockNumber, batch.ReferenceBlockNumber)
+	assert.Len(t, batch.Blobs, 2)
+	for idx, blob := range batch.Blobs {
+		assert.Equal(t, idx, blob.BlobIndex)
+		assert.Equal(t, []*node.ChunksInventory{{QuorumID: 0, StartIndex: 2, NumChunks: 1, ChunkLength: 10, Size: blob.Quorums[0].Size}}, blob.Quorums)
+		assert.Greater(t, blob.Quorums[0].Size, int64(0))
+	}
+	// The second blob expires before its batch
+	assert.Less(t, batch.Blobs[1].ExpiresAt, batch.ExpiresAt)
+	assert.Equal(t, batch.ExpiresAt, batch.Blobs[0].ExpiresAt)
+
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.Nil(t, err)
+	assert.Nil(t, inventory.Sign(keyPair))
+	assert.Equal(t, keyPair.GetPubKeyG1().GetOperatorID().Hex(), inventory.OperatorID)
+	assert.Nil(t, inventory.Verify())
+
+	// Any change to the inventory invalidates the signature
+	batch.Blobs[0].Quorums[0].NumChunks++
+	assert.Error(t, inventory.Verify())
+	batch.Blobs[0].Quorums[0].NumChunks--
+	assert.Nil(t, inventory.Verify())
+	batch.Blobs[0].Quorums[0].StartIndex++
+	assert.Error(t, inventory.Verify())
+	batch.Blobs[0].Quorums[0].StartIndex--
+	batch.ReferenceBlockNumber++
+	assert.Error(t, inventory.Verify())
+}
@@ -14,9 +14,15 @@ import (
 const (
 	// Caution: the change to these prefixes needs to handle the backward compatibility,
 	// making sure the new code work with old data in DA Node store.
-	blobHeaderPrefix      = "_BLOB_HEADER_"  // The prefix of the blob header key.
-	batchHeaderPrefix     = "_BATCH_HEADER_" // The prefix of the batch header key.
-	batchExpirationPrefix = "_EXPIRATION_"   // The prefix of the batch expiration key.
+	blobHeaderPrefix      = "_BLOB_HEADER_"     // The prefix of the blob header key.
+	batchHeaderPrefix     = "_BATCH_HEADER_"    // The prefix of the batch header key.
+	batchExpirationPrefix = "_EXPIRATION_"      // The prefix of the batch expiration key.
+	blobExpirationPrefix  = "_BLOB_EXPIRATION_" // The prefix of the blob expiration key.
+	batchStatsPrefix      = "_BATCH_STATS_"     // The prefix of the batch storage stats key.
+	batchDataKeyPrefix    = "_BATCH_DATA_KEY_"  // The prefix of the key of the wrapped data key of an encrypted batch.
+
+	// The size of an encoded BatchStats value: the number of blobs, the logical and the physical bytes.
+	batchStatsSize = 4 + 8 + 8
 )
 
 // EncodeBlobKey returns an encoded key as blob identification.
@@ -44,6 +50,16 @@ func EncodeBlobHeaderKey(batchHeaderHash [32]byte, blobIndex int) ([]byte, error
 	return buf.Bytes(), nil
 }
 
+// EncodeBlobKeyPrefix returns an encoded prefix of the keys of the chunks of a blob.
+func EncodeBlobKeyPrefix(batchHeaderHash [32]byte, blobIndex int) ([]byte, error) {
+	buf := bytes.NewBuffer(batchHeaderHash[:])
+	err := binary.Write(buf, binary.LittleEndian, int32(blobIndex))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // Returns an encoded prefix of blob header key.
 func EncodeBlobHeaderKeyPrefix(batchHeaderHash [32]byte) []byte {
 	prefix := []byte(blobHeaderPrefix)
@@ -84,6 +100,81 @@ func DecodeBatchExpirationKey(key []byte) (int64, error) {
 	return ts, nil
 }
 
+// Returns the encoded prefix for blob expiration key.
+func EncodeBlobExpirationKeyPrefix() []byte {
+	return []byte(blobExpirationPrefix)
+}
+
+// Returns an encoded key for the expiration time of a blob which expires before its batch.
+// Like the batch expiration keys, the encoded keys preserve the order of expiration time.
+func EncodeBlobExpirationKey(expirationTime int64, batchHeaderHash [32]byte, blobIndex int) ([]byte, error) {
+	prefix := []byte(blobExpirationPrefix)
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts[0:8], uint64(expirationTime))
+	buf := bytes.NewBuffer(append(prefix, ts[:]...))
+	buf.Write(batchHeaderHash[:])
+	err := binary.Write(buf, binary.LittleEndian, int32(blobIndex))
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Returns the expiration timestamp, the batch header hash and the blob index encoded in the key.
+func DecodeBlobExpirationKey(key []byte) (int64, [32]byte, int, error) {
+	var batchHeaderHash [32]byte
+	if len(key) != len(blobExpirationPrefix)+8+32+4 {
+		return 0, batchHeaderHash, 0, errors.New("the blob expiration key is invalid")
+	}
+	key = key[len(blobExpirationPrefix):]
+	ts := int64(binary.BigEndian.Uint64(key[:8]))
+	copy(batchHeaderHash[:], key[8:40])
+	blobIndex := int(int32(binary.LittleEndian.Uint32(key[40:])))
+	return ts, batchHeaderHash, blobIndex, nil
+}
+
+// Returns the encoded prefix for batch stats key.
+func EncodeBatchStatsKeyPrefix() []byte {
+	return []byte(batchStatsPrefix)
+}
+
+// EncodeBatchStatsKey returns an encoded key as the identification of the storage stats of a batch.
+func EncodeBatchStatsKey(batchHeaderHash [32]byte) []byte {
+	prefix := []byte(batchStatsPrefix)
+	buf := bytes.NewBuffer(append(prefix, batchHeaderHash[:]...))
+	return buf.Bytes()
+}
+
+// EncodeBatchDataKeyKey returns an encoded key as the identification of the wrapped data key of an encrypted batch.
+func EncodeBatchDataKeyKey(batchHeaderHash [32]byte) []byte {
+	prefix := []byte(batchDataKeyPrefix)
+	buf := bytes.NewBuffer(append(prefix, batchHeaderHash[:]...))
+	return buf.Bytes()
+}
+
+// Returns the encoded value of the storage stats of a batch.
+func encodeBatchStats(stats *BatchStats) []byte {
+	value := make([]byte, batchStatsSize)
+	binary.BigEndian.PutUint32(value[0:4], uint32(stats.NumBlobs))
+	binary.BigEndian.PutUint64(value[4:12], uint64(stats.LogicalBytes))
+	binary.BigEndian.PutUint64(value[12:20], uint64(stats.PhysicalBytes))
+	return value
+}
+
+// Returns the storage stats of a batch decoded from its key and value.
+func decodeBatchStats(key, value []byte) (*BatchStats, error) {
+	if len(key) != len(batchStatsPrefix)+32 || len(value) != batchStatsSize {
+		return nil, errors.New("the batch stats entry is invalid")
+	}
+	stats := &BatchStats{
+		NumBlobs:      int(binary.BigEndian.Uint32(value[0:4])),
+		LogicalBytes:  int64(binary.BigEndian.Uint64(value[4:12])),
+		PhysicalBytes: int64(binary.BigEndian.Uint64(value[12:20])),
+	}
+	copy(stats.BatchHeaderHash[:], key[len(batchStatsPrefix):])
+	return stats, nil
+}
+
 func SocketAddress(ctx context.Context, provider pubip.Provider, dispersalPort string, retrievalPort string) (string, error) {
 	ip, err := provider.PublicIPAddress(ctx)
 	if err != nil {
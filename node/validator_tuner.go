@@ -0,0 +1,97 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// Bounds of the share of the deadline of a batch used by its validation. Above the upper bound, batch validators
+	// are added so that the batches keep being signed in time. Below the lower bound, batch validators are removed to
+	// leave CPU to the retrieval requests.
+	maxValidationDeadlineUsage = 0.5
+	minValidationDeadlineUsage = 0.2
+	// validationUsageWeight is the weight of the latest batch in the moving average of the deadline usage, so that
+	// the number of batch validators doesn't swing with the size of every batch
+	validationUsageWeight = 0.3
+)
+
+// BatchValidatorTuner tunes the number of parallel workers used to validate a batch, within the configured bounds,
+// from the share of the deadline of the recent batches their validation used.
+type BatchValidatorTuner struct {
+	logger  logging.Logger
+	metrics *Metrics
+
+	minValidators int
+	maxValidators int
+
+	mu         sync.Mutex
+	validators int
+	// usage is the moving average of the share of the deadline used by the validation, negative until the first
+	// batch is validated
+	usage float64
+}
+
+// NewBatchValidatorTuner returns a tuner starting with the maximum number of batch validators, which is the number
+// used without auto-tuning. The metrics may be nil.
+func NewBatchValidatorTuner(minValidators, maxValidators int, logger logging.Logger, metrics *Metrics) *BatchValidatorTuner {
+	minValidators = max(minValidators, 1)
+	maxValidators = max(maxValidators, minValidators)
+	if metrics != nil {
+		metrics.RecordBatchValidators(maxValidators)
+	}
+	return &BatchValidatorTuner{
+		logger:        logger.With("component", "BatchValidatorTuner"),
+		metrics:       metrics,
+		minValidators: minValidators,
+		maxValidators: maxValidators,
+		validators:    maxValidators,
+		usage:         -1,
+	}
+}
+
+// NumValidators returns the number of workers to validate the next batch with
+func (t *BatchValidatorTuner) NumValidators() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.validators
+}
+
+// Observe records the duration of the validation of a batch which had the given time until its deadline when the
+// validation started, and adjusts the number of batch validators accordingly.
+func (t *BatchValidatorTuner) Observe(elapsed, budget time.Duration) {
+	if budget <= 0 {
+		return
+	}
+	usage := min(float64(elapsed)/float64(budget), 1)
+	if t.metrics != nil {
+		t.metrics.ObserveValidationDeadlineUsage(usage)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.usage < 0 {
+		t.usage = usage
+	} else {
+		t.usage = validationUsageWeight*usage + (1-validationUsageWeight)*t.usage
+	}
+
+	validators := t.validators
+	switch {
+	case t.usage > maxValidationDeadlineUsage:
+		validators = min(validators+max(validators/4, 1), t.maxValidators)
+	case t.usage < minValidationDeadlineUsage:
+		validators = max(validators-max(validators/8, 1), t.minValidators)
+	}
+	if validators == t.validators {
+		return
+	}
+
+	t.logger.Info("Adjusted the number of batch validators", "from", t.validators, "to", validators, "deadlineUsage", t.usage)
+	t.validators = validators
+	if t.metrics != nil {
+		t.metrics.RecordBatchValidators(validators)
+	}
+}
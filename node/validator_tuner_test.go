@@ -0,0 +1,40 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchValidatorTuner(t *testing.T) {
+	tuner := node.NewBatchValidatorTuner(4, 32, logging.NewNoopLogger(), nil)
+	assert.Equal(t, 32, tuner.NumValidators())
+
+	// Batches validated well ahead of their deadline free up validators, down to the minimum
+	for i := 0; i < 100; i++ {
+		tuner.Observe(time.Second, 10*time.Second)
+	}
+	assert.Equal(t, 4, tuner.NumValidators())
+
+	// Within the target band the number of validators doesn't change
+	for i := 0; i < 10; i++ {
+		tuner.Observe(3*time.Second, 10*time.Second)
+	}
+	assert.Equal(t, 4, tuner.NumValidators())
+
+	// Batches validated close to their deadline add validators, up to the maximum
+	tuner.Observe(9*time.Second, 10*time.Second)
+	tuner.Observe(9*time.Second, 10*time.Second)
+	assert.Greater(t, tuner.NumValidators(), 4)
+	for i := 0; i < 100; i++ {
+		tuner.Observe(20*time.Second, 10*time.Second)
+	}
+	assert.Equal(t, 32, tuner.NumValidators())
+
+	// Batches without a deadline are ignored
+	tuner.Observe(time.Second, 0)
+	assert.Equal(t, 32, tuner.NumValidators())
+}
@@ -24,6 +24,10 @@ var (
 	secondsTillExpiry = 3600 * time.Second
 )
 
+// ReasonStakeChanged is the reason of the error returned when a churn approval no longer holds because the stakes
+// changed since it was given, so that the registration with churn would revert
+const ReasonStakeChanged = "STAKE_CHANGED"
+
 type ChurnRequest struct {
 	OperatorAddress            gethcommon.Address
 	OperatorToRegisterPubkeyG1 *core.G1Point
@@ -80,12 +84,8 @@ func NewChurner(
 
 func (c *churner) VerifyRequestSignature(ctx context.Context, churnRequest *ChurnRequest) (gethcommon.Address, error) {
 	operatorToRegisterAddress := churnRequest.OperatorAddress
-	isEqual, err := churnRequest.OperatorToRegisterPubkeyG1.VerifyEquivalence(churnRequest.OperatorToRegisterPubkeyG2)
-	if err != nil {
-		return gethcommon.Address{}, err
-	}
-	if !isEqual {
-		return gethcommon.Address{}, errors.New("operatorToRegisterPubkeyG1 and operatorToRegisterPubkeyG2 are not equivalent")
+	if _, err := core.OperatorIDFromPubkeys(churnRequest.OperatorToRegisterPubkeyG1, churnRequest.OperatorToRegisterPubkeyG2); err != nil {
+		return gethcommon.Address{}, fmt.Errorf("invalid operatorToRegisterPubkeyG1 and operatorToRegisterPubkeyG2: %w", err)
 	}
 
 	requestHash := CalculateRequestHash(churnRequest)
@@ -216,9 +216,6 @@ func (c *churner) getOperatorsToChurn(ctx context.Context, quorumIDs []uint8, op
 			totalStake.Add(totalStake, operatorStake.Stake)
 		}
 
-		churnBIPsOfOperatorStake := big.NewInt(int64(operatorSetParams.ChurnBIPsOfOperatorStake))
-		churnBIPsOfTotalStake := big.NewInt(int64(operatorSetParams.ChurnBIPsOfTotalStake))
-
 		c.logger.Info("lowestStake", "lowestStake", lowestStake.String(), "operatorToRegisterStake", operatorToRegisterStake.String(), "totalStake", totalStake.String(), "operatorToRegisterAddress", operatorToRegisterAddress.Hex())
 
 		// verify the lowest stake against the registering operator's stake
@@ -228,7 +225,7 @@ func (c *churner) getOperatorsToChurn(ctx context.Context, quorumIDs []uint8, op
 		// churn the lowest-stake operator out.
 		// For example, when churnBIPsOfOperatorStake=11000, the operator trying to
 		// register needs to have 1.1 times the stake of the lowest-stake operator.
-		if new(big.Int).Mul(lowestStake, churnBIPsOfOperatorStake).Cmp(new(big.Int).Mul(operatorToRegisterStake, bipMultiplier)) >= 0 {
+		if !hasEnoughStakeToRegister(operatorSetParams, operatorToRegisterStake, lowestStake) {
 			c.metrics.IncrementFailedRequestNum("getOperatorsToChurn", FailReasonInsufficientStakeToRegister)
 			msg := "registering operator must have %f%% more than the stake of the " +
 				"lowest-stake operator. Block number used for this decision: %d, " +
@@ -245,7 +242,7 @@ func (c *churner) getOperatorsToChurn(ctx context.Context, quorumIDs []uint8, op
 		// For example, when churnBIPsOfTotalStake=1001, the operator to be churned out
 		// (i.e. the lowest-stake operator) needs to have less than 10.01% of the total
 		// stake.
-		if new(big.Int).Mul(lowestStake, bipMultiplier).Cmp(new(big.Int).Mul(totalStake, churnBIPsOfTotalStake)) >= 0 {
+		if !hasLowEnoughStakeToChurn(operatorSetParams, lowestStake, totalStake) {
 			c.metrics.IncrementFailedRequestNum("getOperatorsToChurn", FailReasonInsufficientStakeToChurn)
 			msg := "operator to churn out must have less than %f%% of the total stake. " +
 				"Block number used for this decision: %d, operatorId of the operator " +
@@ -277,6 +274,123 @@ func (c *churner) getOperatorsToChurn(ctx context.Context, quorumIDs []uint8, op
 	return operatorsToChurn, nil
 }
 
+// RefreshChurnResponse re-validates the operators to churn of an unexpired churn approval against the stakes at the
+// current block, and signs them again with a new salt and expiry if the approval still holds.
+func (c *churner) RefreshChurnResponse(
+	ctx context.Context,
+	operatorToRegisterAddress gethcommon.Address,
+	operatorToRegisterId core.OperatorID,
+	operatorsToChurn []core.OperatorToChurn,
+) (*ChurnResponse, error) {
+	currentBlockNumber, err := c.Transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateChurnApproval(ctx, c.Transactor, operatorToRegisterAddress, operatorsToChurn, currentBlockNumber); err != nil {
+		return nil, err
+	}
+
+	signatureWithSaltAndExpiry, err := c.sign(ctx, operatorToRegisterAddress, operatorToRegisterId, operatorsToChurn)
+	if err != nil {
+		return nil, err
+	}
+	return &ChurnResponse{
+		SignatureWithSaltAndExpiry: signatureWithSaltAndExpiry,
+		OperatorsToChurn:           operatorsToChurn,
+	}, nil
+}
+
+// ValidateChurnApproval re-validates the operators to churn of a churn approval given to the registering operator
+// against the stakes at the block number, i.e. checks that the registration with churn executed at that block
+// wouldn't revert because the stakes changed since the approval. It returns an error for which IsStakeChangedError
+// is true if the approval no longer holds.
+func ValidateChurnApproval(
+	ctx context.Context,
+	transactor core.Transactor,
+	operatorToRegisterAddress gethcommon.Address,
+	operatorsToChurn []core.OperatorToChurn,
+	blockNumber uint32,
+) error {
+	for _, operatorToChurn := range operatorsToChurn {
+		quorumID := operatorToChurn.QuorumId
+		operatorSetParams, err := transactor.GetOperatorSetParams(ctx, quorumID)
+		if err != nil {
+			return err
+		}
+
+		operatorStakes, err := transactor.GetOperatorStakesForQuorums(ctx, []core.QuorumID{quorumID}, blockNumber)
+		if err != nil {
+			return err
+		}
+
+		if operatorToChurn.Pubkey == nil {
+			// the quorum wasn't full at the approval, so it must still have room for the registering operator
+			if uint32(len(operatorStakes[quorumID])) >= operatorSetParams.MaxOperatorCount {
+				return newStakeChangedError(fmt.Sprintf("quorum became full since the churn approval. Block number used for this decision: %d, quorum ID: %d", blockNumber, quorumID))
+			}
+			continue
+		}
+
+		operatorToChurnId := operatorToChurn.Pubkey.GetOperatorID()
+		var operatorToChurnStake *big.Int
+		totalStake := big.NewInt(0)
+		for _, operatorStake := range operatorStakes[quorumID] {
+			if operatorStake.OperatorID == operatorToChurnId {
+				operatorToChurnStake = operatorStake.Stake
+			}
+			totalStake.Add(totalStake, operatorStake.Stake)
+		}
+		if operatorToChurnStake == nil {
+			return newStakeChangedError(fmt.Sprintf("operator to churn out is no longer registered. Block number used for this decision: %d, operatorId of the operator to churn: %x, quorum ID: %d", blockNumber, operatorToChurnId, quorumID))
+		}
+
+		operatorToRegisterStake, err := transactor.WeightOfOperatorForQuorum(ctx, quorumID, operatorToRegisterAddress)
+		if err != nil {
+			return err
+		}
+
+		if !hasEnoughStakeToRegister(operatorSetParams, operatorToRegisterStake, operatorToChurnStake) {
+			msg := "registering operator no longer has %f%% more than the stake of the operator to churn out. " +
+				"Block number used for this decision: %d, registering operator address: %s, registering operator " +
+				"stake: %d, stake of the operator to churn: %d, operatorId of the operator to churn: %x, quorum ID: %d"
+			return newStakeChangedError(fmt.Sprintf(msg, float64(operatorSetParams.ChurnBIPsOfOperatorStake)/100.0-100.0, blockNumber, operatorToRegisterAddress.Hex(), operatorToRegisterStake, operatorToChurnStake, operatorToChurnId, quorumID))
+		}
+
+		if !hasLowEnoughStakeToChurn(operatorSetParams, operatorToChurnStake, totalStake) {
+			msg := "operator to churn out no longer has less than %f%% of the total stake. " +
+				"Block number used for this decision: %d, operatorId of the operator to churn: %x, stake of the " +
+				"operator to churn: %d, total stake in quorum: %d, quorum ID: %d"
+			return newStakeChangedError(fmt.Sprintf(msg, float64(operatorSetParams.ChurnBIPsOfTotalStake)/100.0, blockNumber, operatorToChurnId, operatorToChurnStake, totalStake, quorumID))
+		}
+	}
+	return nil
+}
+
+// IsStakeChangedError returns whether the error, possibly received from the Churner, tells that a churn approval
+// no longer holds because the stakes changed since it was given
+func IsStakeChangedError(err error) bool {
+	return api.ErrorReason(err) == ReasonStakeChanged
+}
+
+func newStakeChangedError(msg string) error {
+	return api.NewFailedPreconditionError(msg, ReasonStakeChanged)
+}
+
+// hasEnoughStakeToRegister returns whether operatorToRegisterStake * bipMultiplier > operatorToChurnStake *
+// churnBIPsOfOperatorStake, as required by the contract to churn the operator out
+func hasEnoughStakeToRegister(operatorSetParams *core.OperatorSetParam, operatorToRegisterStake, operatorToChurnStake *big.Int) bool {
+	churnBIPsOfOperatorStake := big.NewInt(int64(operatorSetParams.ChurnBIPsOfOperatorStake))
+	return new(big.Int).Mul(operatorToChurnStake, churnBIPsOfOperatorStake).Cmp(new(big.Int).Mul(operatorToRegisterStake, bipMultiplier)) < 0
+}
+
+// hasLowEnoughStakeToChurn returns whether operatorToChurnStake * bipMultiplier < totalStake * churnBIPsOfTotalStake,
+// as required by the contract to churn the operator out
+func hasLowEnoughStakeToChurn(operatorSetParams *core.OperatorSetParam, operatorToChurnStake, totalStake *big.Int) bool {
+	churnBIPsOfTotalStake := big.NewInt(int64(operatorSetParams.ChurnBIPsOfTotalStake))
+	return new(big.Int).Mul(operatorToChurnStake, bipMultiplier).Cmp(new(big.Int).Mul(totalStake, churnBIPsOfTotalStake)) < 0
+}
+
 func (c *churner) sign(ctx context.Context, operatorToRegisterAddress gethcommon.Address, operatorToRegisterId core.OperatorID, operatorsToChurn []core.OperatorToChurn) (*SignatureWithSaltAndExpiry, error) {
 	now := time.Now()
 	privateKeyBytes := crypto.FromECDSA(c.privateKey)
@@ -2,6 +2,7 @@ package churner_test
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -9,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/operators/churner"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	dacore "github.com/Layr-Labs/eigenda/core"
 	indexermock "github.com/Layr-Labs/eigenda/core/mock"
@@ -84,3 +86,56 @@ func TestProcessChurnRequest(t *testing.T) {
 	}
 	assert.ElementsMatch(t, []dacore.QuorumID{0, 1}, actualQuorums)
 }
+
+func TestValidateChurnApproval(t *testing.T) {
+	ctx := context.Background()
+	operatorToRegisterAddress := gethcommon.HexToAddress("0x0000000000000000000000000000000000000002")
+	operatorToChurnKeyPair, err := dacore.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	operatorsToChurn := []dacore.OperatorToChurn{
+		{
+			QuorumId: 0,
+			Operator: gethcommon.HexToAddress("0x0000000000000000000000000000000000000001"),
+			Pubkey:   operatorToChurnKeyPair.PubKey,
+		},
+	}
+
+	newTransactor := func(operatorToChurnStake, operatorToRegisterStake int64) *indexermock.MockTransactor {
+		tx := &indexermock.MockTransactor{}
+		tx.On("GetOperatorSetParams", mock.Anything, uint8(0)).Return(&dacore.OperatorSetParam{
+			MaxOperatorCount:         2,
+			ChurnBIPsOfOperatorStake: 11000,
+			ChurnBIPsOfTotalStake:    5001,
+		}, nil)
+		tx.On("GetOperatorStakesForQuorums").Return(dacore.OperatorStakes{
+			0: {
+				0: {
+					OperatorID: operatorToChurnKeyPair.PubKey.GetOperatorID(),
+					Stake:      big.NewInt(operatorToChurnStake),
+				},
+				1: {
+					OperatorID: makeOperatorId(1),
+					Stake:      big.NewInt(100),
+				},
+			},
+		}, nil)
+		tx.On("WeightOfOperatorForQuorum").Return(big.NewInt(operatorToRegisterStake), nil)
+		return tx
+	}
+
+	// the approval still holds
+	err = churner.ValidateChurnApproval(ctx, newTransactor(50, 60), operatorToRegisterAddress, operatorsToChurn, 10)
+	assert.NoError(t, err)
+
+	// the registering operator no longer has 10% more stake than the operator to churn out
+	err = churner.ValidateChurnApproval(ctx, newTransactor(50, 55), operatorToRegisterAddress, operatorsToChurn, 10)
+	assert.True(t, churner.IsStakeChangedError(err))
+
+	// the operator to churn out no longer has less than 50.01% of the total stake
+	err = churner.ValidateChurnApproval(ctx, newTransactor(101, 200), operatorToRegisterAddress, operatorsToChurn, 10)
+	assert.True(t, churner.IsStakeChangedError(err))
+
+	// the quorum which had room at the approval became full
+	err = churner.ValidateChurnApproval(ctx, newTransactor(50, 60), operatorToRegisterAddress, []dacore.OperatorToChurn{{QuorumId: 0}}, 10)
+	assert.True(t, churner.IsStakeChangedError(err))
+}
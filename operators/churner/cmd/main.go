@@ -8,7 +8,9 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/churner"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
@@ -53,12 +55,6 @@ func run(ctx *cli.Context) error {
 		log.Fatalln("could not start tcp listener", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300)
-	gs := grpc.NewServer(
-		opt,
-		grpc.ChainUnaryInterceptor(),
-	)
-
 	config, err := churner.NewConfig(ctx)
 	if err != nil {
 		log.Fatalf("failed to parse the command line flags: %v", err)
@@ -68,6 +64,16 @@ func run(ctx *cli.Context) error {
 		log.Fatalf("failed to create logger: %v", err)
 	}
 
+	tlsOpts, err := grpctls.ServerOptions(config.TLSConfig, logger)
+	if err != nil {
+		log.Fatalf("failed to configure TLS: %v", err)
+	}
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(1024 * 1024 * 300),
+		grpc.ChainUnaryInterceptor(),
+	}
+	gs := grpc.NewServer(append(opts, tlsOpts...)...)
+
 	log.Println("Starting geth client")
 	gethClient, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
 	if err != nil {
@@ -87,6 +93,13 @@ func run(ctx *cli.Context) error {
 	indexer := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
 
 	metrics := churner.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	buildInfo := buildinfo.Info{Service: "churner", Version: Version, Commit: GitCommit}
+	if config.TLSConfig.Enabled() {
+		buildInfo.Features = append(buildInfo.Features, "tls")
+	}
+	if err := metrics.RegisterBuildInfo(buildInfo); err != nil {
+		log.Fatalln("cannot register build info", err)
+	}
 
 	cn, err := churner.NewChurner(config, indexer, tx, logger, metrics)
 	if err != nil {
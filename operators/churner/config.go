@@ -5,6 +5,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/operators/churner/flags"
 	"github.com/urfave/cli"
@@ -15,6 +16,7 @@ type Config struct {
 	LoggerConfig     common.LoggerConfig
 	MetricsConfig    MetricsConfig
 	ChainStateConfig thegraph.Config
+	TLSConfig        grpctls.Config
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -27,10 +29,15 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	tlsConfig, err := grpctls.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
 	return &Config{
 		EthClientConfig:               geth.ReadEthClientConfig(ctx),
 		LoggerConfig:                  *loggerConfig,
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		TLSConfig:                     tlsConfig,
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		PerPublicKeyRateLimit:         ctx.GlobalDuration(flags.PerPublicKeyRateLimit.Name),
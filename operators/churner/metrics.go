@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -25,6 +26,8 @@ const (
 	FailReasonInvalidSignature            FailReason = "invalid_signature"              // Invalid signature: operator's signature is wrong
 	FailReasonProcessChurnRequestFailed   FailReason = "failed_process_churn_request"   // Failed to process churn request
 	FailReasonInvalidRequest              FailReason = "invalid_request"                // Invalid request: request is malformed
+	FailReasonApprovalNotFound            FailReason = "approval_not_found"             // No unexpired approval to refresh for the operator
+	FailReasonStakeChanged                FailReason = "stake_changed"                  // Approval no longer holds: stakes changed since the approval
 )
 
 // Note: statusCodeMap must be maintained in sync with failure reason constants.
@@ -37,6 +40,8 @@ var statusCodeMap map[FailReason]string = map[FailReason]string{
 	FailReasonInvalidSignature:            codes.InvalidArgument.String(),
 	FailReasonProcessChurnRequestFailed:   codes.Internal.String(),
 	FailReasonInvalidRequest:              codes.InvalidArgument.String(),
+	FailReasonApprovalNotFound:            codes.NotFound.String(),
+	FailReasonStakeChanged:                codes.FailedPrecondition.String(),
 }
 
 type MetricsConfig struct {
@@ -115,6 +120,11 @@ func (g *Metrics) IncrementFailedRequestNum(method string, reason FailReason) {
 	}).Inc()
 }
 
+// RegisterBuildInfo exports the build of the churner as the build_info metric
+func (g *Metrics) RegisterBuildInfo(info buildinfo.Info) error {
+	return buildinfo.Register(g.registry, info)
+}
+
 // Start starts the metrics server
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
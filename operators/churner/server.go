@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
@@ -15,13 +16,22 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// approval is a churn approval given to an operator
+type approval struct {
+	operatorToRegisterId core.OperatorID
+	quorumIDs            []core.QuorumID
+	operatorsToChurn     []core.OperatorToChurn
+}
+
 type Server struct {
 	pb.UnimplementedChurnerServer
 
 	config  *Config
 	churner *churner
 	// the signature with the lastest expiry
-	latestExpiry                int64
+	latestExpiry int64
+	// the approval with the latest expiry, which its operator can refresh until it expires
+	latestApproval              *approval
 	lastRequestTimeByOperatorID map[core.OperatorID]time.Time
 
 	logger  logging.Logger
@@ -104,18 +114,75 @@ func (s *Server) Churn(ctx context.Context, req *pb.ChurnRequest) (*pb.ChurnRepl
 
 	// update the latest expiry
 	s.latestExpiry = response.SignatureWithSaltAndExpiry.Expiry.Int64()
-
-	operatorsToChurn := convertToOperatorsToChurnGrpc(response.OperatorsToChurn)
+	s.latestApproval = &approval{
+		operatorToRegisterId: request.OperatorToRegisterPubkeyG1.GetOperatorID(),
+		quorumIDs:            request.QuorumIDs,
+		operatorsToChurn:     response.OperatorsToChurn,
+	}
 
 	s.metrics.IncrementSuccessfulRequestNum("Churn")
-	return &pb.ChurnReply{
-		SignatureWithSaltAndExpiry: &pb.SignatureWithSaltAndExpiry{
-			Signature: response.SignatureWithSaltAndExpiry.Signature,
-			Salt:      response.SignatureWithSaltAndExpiry.Salt[:],
-			Expiry:    response.SignatureWithSaltAndExpiry.Expiry.Int64(),
-		},
-		OperatorsToChurn: operatorsToChurn,
-	}, nil
+	return convertToChurnReply(response), nil
+}
+
+// RefreshChurn re-validates the unexpired approval given to the operator against the stakes at the current block,
+// so that an approval made stale by stake changes between the approval and the registration fails with a stake
+// changed error rather than with a reverted registration. If the approval still holds, it's signed again with a new
+// salt and expiry. Otherwise it's revoked, so that a new churn decision can be requested.
+func (s *Server) RefreshChurn(ctx context.Context, req *pb.ChurnRequest) (*pb.ChurnReply, error) {
+	err := s.validateChurnRequest(ctx, req)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("RefreshChurn", FailReasonInvalidRequest)
+		return nil, api.NewInvalidArgError(fmt.Sprintf("invalid request: %s", err.Error()))
+	}
+
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("RefreshChurn", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+	s.logger.Info("Received refresh request: ", "QuorumIds", req.GetQuorumIds())
+
+	request, err := createChurnRequest(req)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("RefreshChurn", FailReasonInvalidRequest)
+		return nil, api.NewInvalidArgError(err.Error())
+	}
+
+	operatorToRegisterAddress, err := s.churner.VerifyRequestSignature(ctx, request)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("RefreshChurn", FailReasonInvalidSignature)
+		return nil, api.NewInvalidArgError(fmt.Sprintf("failed to verify request signature: %s", err.Error()))
+	}
+
+	// Only the operator holding the unexpired approval can refresh it, so the refreshes aren't subject to the
+	// per-operator rate limiting
+	operatorToRegisterId := request.OperatorToRegisterPubkeyG1.GetOperatorID()
+	latestApproval := s.latestApproval
+	if time.Now().Unix() >= s.latestExpiry || latestApproval == nil || latestApproval.operatorToRegisterId != operatorToRegisterId || !slices.Equal(latestApproval.quorumIDs, request.QuorumIDs) {
+		s.metrics.IncrementFailedRequestNum("RefreshChurn", FailReasonApprovalNotFound)
+		return nil, api.NewNotFoundError("no unexpired churn approval to refresh for the operator and quorums")
+	}
+
+	response, err := s.churner.RefreshChurnResponse(ctx, operatorToRegisterAddress, operatorToRegisterId, latestApproval.operatorsToChurn)
+	if err != nil {
+		if IsStakeChangedError(err) {
+			// revoke the stale approval, so that a new churn decision can be requested right away
+			s.latestExpiry = 0
+			s.latestApproval = nil
+			s.metrics.IncrementFailedRequestNum("RefreshChurn", FailReasonStakeChanged)
+			s.logger.Info("Revoked churn approval after stake changes", "operatorID", operatorToRegisterId.Hex(), "err", err)
+			return nil, err
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		s.metrics.IncrementFailedRequestNum("RefreshChurn", FailReasonProcessChurnRequestFailed)
+		return nil, api.NewInternalError(fmt.Sprintf("failed to refresh churn approval: %s", err.Error()))
+	}
+
+	s.latestExpiry = response.SignatureWithSaltAndExpiry.Expiry.Int64()
+
+	s.metrics.IncrementSuccessfulRequestNum("RefreshChurn")
+	return convertToChurnReply(response), nil
 }
 
 func (s *Server) checkShouldBeRateLimited(now time.Time, request ChurnRequest) error {
@@ -212,6 +279,17 @@ func createChurnRequest(req *pb.ChurnRequest) (*ChurnRequest, error) {
 	}, nil
 }
 
+func convertToChurnReply(response *ChurnResponse) *pb.ChurnReply {
+	return &pb.ChurnReply{
+		SignatureWithSaltAndExpiry: &pb.SignatureWithSaltAndExpiry{
+			Signature: response.SignatureWithSaltAndExpiry.Signature,
+			Salt:      response.SignatureWithSaltAndExpiry.Salt[:],
+			Expiry:    response.SignatureWithSaltAndExpiry.Expiry.Int64(),
+		},
+		OperatorsToChurn: convertToOperatorsToChurnGrpc(response.OperatorsToChurn),
+	}
+}
+
 func convertToOperatorsToChurnGrpc(operatorsToChurn []core.OperatorToChurn) []*pb.OperatorToChurn {
 	operatorsToChurnGRPC := make([]*pb.OperatorToChurn, len(operatorsToChurn))
 	for i, operator := range operatorsToChurn {
@@ -227,3 +305,28 @@ func convertToOperatorsToChurnGrpc(operatorsToChurn []core.OperatorToChurn) []*p
 	}
 	return operatorsToChurnGRPC
 }
+
+// ConvertToOperatorsToChurn converts the operators to churn of a ChurnReply back to the operators to churn of the
+// churn approval
+func ConvertToOperatorsToChurn(operatorsToChurnGRPC []*pb.OperatorToChurn) ([]core.OperatorToChurn, error) {
+	operatorsToChurn := make([]core.OperatorToChurn, len(operatorsToChurnGRPC))
+	for i, operator := range operatorsToChurnGRPC {
+		if operator.GetQuorumId() > core.MaxQuorumID {
+			return nil, fmt.Errorf("quorum id %d is out of range", operator.GetQuorumId())
+		}
+		var pubkey *core.G1Point
+		if len(operator.GetPubkey()) > 0 {
+			var err error
+			pubkey, err = new(core.G1Point).Deserialize(operator.GetPubkey())
+			if err != nil {
+				return nil, err
+			}
+		}
+		operatorsToChurn[i] = core.OperatorToChurn{
+			QuorumId: core.QuorumID(operator.GetQuorumId()),
+			Operator: gethcommon.BytesToAddress(operator.GetOperator()),
+			Pubkey:   pubkey,
+		}
+	}
+	return operatorsToChurn, nil
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/churner"
 )
@@ -95,6 +97,57 @@ func TestChurn(t *testing.T) {
 	assert.Equal(t, err.Error(), "rpc error: code = ResourceExhausted desc = previous approval not expired, retry in 3600 seconds")
 }
 
+func TestRefreshChurn(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	salt := crypto.Keccak256([]byte(operatorToChurnInPrivateKeyHex), []byte("ChurnRequest"))
+	request := &pb.ChurnRequest{
+		OperatorAddress:            operatorAddr.Hex(),
+		OperatorToRegisterPubkeyG1: keyPair.PubKey.Serialize(),
+		OperatorToRegisterPubkeyG2: keyPair.GetPubKeyG2().Serialize(),
+		Salt:                       salt,
+		QuorumIds:                  quorumIds,
+	}
+
+	var requestHash [32]byte
+	requestHashBytes := crypto.Keccak256(
+		[]byte("ChurnRequest"),
+		[]byte(request.OperatorAddress),
+		request.OperatorToRegisterPubkeyG1,
+		request.OperatorToRegisterPubkeyG2,
+		request.Salt,
+	)
+	copy(requestHash[:], requestHashBytes)
+
+	signature := keyPair.SignMessage(requestHash)
+	request.OperatorRequestSignature = signature.Serialize()
+
+	mockIndexer.On("GetIndexedOperatorInfoByOperatorId").Return(&core.IndexedOperatorInfo{
+		PubkeyG1: keyPair.PubKey,
+	}, nil)
+
+	// no approval to refresh yet
+	_, err := s.RefreshChurn(ctx, request)
+	assert.NotNil(t, err)
+	assert.Equal(t, err.Error(), "rpc error: code = NotFound desc = no unexpired churn approval to refresh for the operator and quorums")
+
+	_, err = s.Churn(ctx, request)
+	assert.NoError(t, err)
+
+	// the operator to churn out for quorum 1 is no longer registered at the current block
+	_, err = s.RefreshChurn(ctx, request)
+	assert.NotNil(t, err)
+	assert.True(t, churner.IsStakeChangedError(err))
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	// the stale approval is revoked, so a new churn decision can be requested right away
+	_, err = s.Churn(ctx, request)
+	assert.NoError(t, err)
+}
+
 func TestChurnWithInvalidQuorum(t *testing.T) {
 	s := newTestServer(t)
 	ctx := context.Background()
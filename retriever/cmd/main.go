@@ -2,16 +2,18 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net"
 	"os"
 
+	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
 	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/common/geth"
-	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	coreindexer "github.com/Layr-Labs/eigenda/core/indexer"
@@ -24,6 +26,9 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -78,6 +83,9 @@ func RetrieverMain(ctx *cli.Context) error {
 	}
 
 	nodeClient := clients.NewNodeClient(config.Timeout)
+	if config.VerifyOperatorIdentity {
+		nodeClient = clients.NewIdentityVerifyingNodeClient(config.Timeout)
+	}
 	v, err := verifier.NewVerifier(&config.EncoderConfig, false)
 	if err != nil {
 		log.Fatalln("could not start tcp listener", err)
@@ -134,8 +142,24 @@ func RetrieverMain(ctx *cli.Context) error {
 		log.Fatalln("could not start tcp listener", err)
 	}
 
+	var disperserClient disperserpb.DisperserClient
+	if config.DisperserAddr != "" {
+		logger.Info("Refreshing the blobs nearing expiry", "disperser", config.DisperserAddr)
+		creds := insecure.NewCredentials()
+		if config.DisperserUseSecureGrpc {
+			creds = credentials.NewTLS(&tls.Config{})
+		}
+		conn, err := grpc.Dial(config.DisperserAddr, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		disperserClient = disperserpb.NewDisperserClient(conn)
+	}
+
 	chainClient := retrivereth.NewChainClient(gethClient, logger)
-	retrieverServiceServer := retriever.NewServer(config, logger, retrievalClient, v, ics, chainClient)
+	config.BuildInfo = newBuildInfo(config)
+	retrieverServiceServer := retriever.NewServer(config, logger, retrievalClient, v, ics, chainClient, tx, disperserClient)
 	if err = retrieverServiceServer.Start(context.Background()); err != nil {
 		log.Fatalln("failed to start retriever service server", err)
 	}
@@ -147,9 +171,29 @@ func RetrieverMain(ctx *cli.Context) error {
 	pb.RegisterRetrieverServer(gs, retrieverServiceServer)
 
 	// Register Server for Health Checks
-	name := pb.Retriever_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	grpc_health_v1.RegisterHealthServer(gs, retrieverServiceServer.HealthServer())
 
 	log.Printf("server listening at %s", addr)
 	return gs.Serve(listener)
 }
+
+// newBuildInfo returns the build of the retriever and the optional features enabled in the config
+func newBuildInfo(config *retriever.Config) buildinfo.Info {
+	var features []string
+	if config.UseGraph {
+		features = append(features, "graph")
+	}
+	if config.VerifyOperatorIdentity {
+		features = append(features, "operator_identity_check")
+	}
+	if config.DisperserAddr != "" {
+		features = append(features, "blob_refresh")
+	}
+	return buildinfo.Info{
+		Service:  "retriever",
+		Version:  Version,
+		Commit:   GitCommit,
+		SRSOrder: config.EncoderConfig.SRSOrder,
+		Features: features,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -26,6 +27,21 @@ type Config struct {
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 	UseGraph                      bool
+	NumDecodeWorkers              int
+	MaxDecodeQueueDepth           int
+	RetryAfter                    time.Duration
+	VerifyOperatorIdentity        bool
+	// DisperserAddr is the address of the disperser the blobs nearing expiry are re-dispersed with. Refreshing the
+	// blobs is disabled if empty.
+	DisperserAddr          string
+	DisperserUseSecureGrpc bool
+	// RefreshWindow is how long before the end of the custody window of a blob it's refreshed, if the caller asks so
+	RefreshWindow time.Duration
+	// RefreshTimeout is how long a refresh waits for the new blob to be confirmed before replying without its cert
+	RefreshTimeout time.Duration
+	// BuildInfo is the build of the retriever exported as the build_info metric. It's set by the binary rather than
+	// read from the flags.
+	BuildInfo buildinfo.Info
 }
 
 func NewConfig(ctx *cli.Context) (*Config, error) {
@@ -48,5 +64,13 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		UseGraph:                      ctx.GlobalBool(flags.UseGraphFlag.Name),
+		NumDecodeWorkers:              ctx.GlobalInt(flags.NumDecodeWorkersFlag.Name),
+		MaxDecodeQueueDepth:           ctx.GlobalInt(flags.MaxDecodeQueueDepthFlag.Name),
+		RetryAfter:                    ctx.GlobalDuration(flags.RetryAfterFlag.Name),
+		VerifyOperatorIdentity:        ctx.GlobalBool(flags.VerifyOperatorIdentityFlag.Name),
+		DisperserAddr:                 ctx.GlobalString(flags.DisperserAddrFlag.Name),
+		DisperserUseSecureGrpc:        ctx.GlobalBool(flags.DisperserUseSecureGrpcFlag.Name),
+		RefreshWindow:                 ctx.GlobalDuration(flags.RefreshWindowFlag.Name),
+		RefreshTimeout:                ctx.GlobalDuration(flags.RefreshTimeoutFlag.Name),
 	}, nil
 }
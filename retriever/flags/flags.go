@@ -1,6 +1,9 @@
 package flags
 
 import (
+	"runtime"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
@@ -74,6 +77,59 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envPrefix, "USE_GRAPH"),
 	}
+	NumDecodeWorkersFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "num-decode-workers"),
+		Usage:    "maximum number of blobs retrieved and decoded concurrently (defaults to the number of CPUs)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_DECODE_WORKERS"),
+		Value:    runtime.NumCPU(),
+	}
+	MaxDecodeQueueDepthFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-decode-queue-depth"),
+		Usage:    "maximum number of requests waiting for a decode worker before new requests are rejected as unavailable (defaults to 100)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_DECODE_QUEUE_DEPTH"),
+		Value:    100,
+	}
+	RetryAfterFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retry-after"),
+		Usage:    "delay after which clients are told to retry the requests rejected while the decode queue is full",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RETRY_AFTER"),
+		Value:    5 * time.Second,
+	}
+	VerifyOperatorIdentityFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "verify-operator-identity"),
+		Usage:    "Whether to connect to the DA nodes over TLS and reject the chunks of the nodes whose TLS certificate isn't signed by the BLS key of the operator",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "VERIFY_OPERATOR_IDENTITY"),
+	}
+	DisperserAddrFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-addr"),
+		Usage:    "address (host:port) of the disperser the blobs nearing expiry are re-dispersed with when the callers ask to refresh them. Refreshing the blobs is disabled if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISPERSER_ADDR"),
+	}
+	DisperserUseSecureGrpcFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-use-secure-grpc"),
+		Usage:    "Whether to connect to the disperser over TLS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISPERSER_USE_SECURE_GRPC"),
+	}
+	RefreshWindowFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "refresh-window"),
+		Usage:    "how long before the end of the custody window of a blob it's re-dispersed, if the caller asks to refresh it",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "REFRESH_WINDOW"),
+		Value:    24 * time.Hour,
+	}
+	RefreshTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "refresh-timeout"),
+		Usage:    "how long a refresh waits for the re-dispersed blob to be confirmed before replying with its request ID only",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "REFRESH_TIMEOUT"),
+		Value:    10 * time.Minute,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -89,6 +145,14 @@ var optionalFlags = []cli.Flag{
 	IndexerDataDirFlag,
 	MetricsHTTPPortFlag,
 	UseGraphFlag,
+	NumDecodeWorkersFlag,
+	MaxDecodeQueueDepthFlag,
+	RetryAfterFlag,
+	VerifyOperatorIdentityFlag,
+	DisperserAddrFlag,
+	DisperserUseSecureGrpcFlag,
+	RefreshWindowFlag,
+	RefreshTimeoutFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
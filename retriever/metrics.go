@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Layr-Labs/eigenda/common/buildinfo"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -24,6 +25,8 @@ type Metrics struct {
 	registry *prometheus.Registry
 
 	NumRetrievalRequest prometheus.Counter
+	NumShedRequest      prometheus.Counter
+	DecodeQueueDepth    prometheus.Gauge
 
 	httpPort string
 	logger   logging.Logger
@@ -43,6 +46,20 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 				Help:      "the number of retrieval requests",
 			},
 		),
+		NumShedRequest: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "shed_request",
+				Help:      "the number of retrieval requests rejected because the decode queue was full",
+			},
+		),
+		DecodeQueueDepth: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "decode_queue_depth",
+				Help:      "the number of retrieval requests waiting for a decode worker",
+			},
+		),
 		httpPort: httpPort,
 		logger:   logger.With("component", "RetrieverMetrics"),
 	}
@@ -56,6 +73,21 @@ func (g *Metrics) IncrementRetrievalRequestCounter() {
 	g.NumRetrievalRequest.Inc()
 }
 
+// IncrementShedRequestCounter increments the number of requests rejected because the decode queue was full
+func (g *Metrics) IncrementShedRequestCounter() {
+	g.NumShedRequest.Inc()
+}
+
+// UpdateDecodeQueueDepth sets the number of requests waiting for a decode worker
+func (g *Metrics) UpdateDecodeQueueDepth(depth int) {
+	g.DecodeQueueDepth.Set(float64(depth))
+}
+
+// RegisterBuildInfo exports the build of the retriever as the build_info metric
+func (g *Metrics) RegisterBuildInfo(info buildinfo.Info) error {
+	return buildinfo.Register(g.registry, info)
+}
+
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
@@ -0,0 +1,83 @@
+package retriever
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	// blockTime is the time between two Ethereum blocks, which the custody windows of the blobs are estimated with
+	blockTime = 12 * time.Second
+	// refreshPollInterval is how often the status of a re-dispersed blob is polled until it's confirmed
+	refreshPollInterval = 2 * time.Second
+)
+
+// custodyExpiresSoon returns whether the custody window of the blobs of a batch with the given reference block
+// lapses within the refresh window. The operators store a batch until referenceBlockNumber + BLOCK_STALE_MEASURE +
+// STORE_DURATION_BLOCKS at most.
+func (s *Server) custodyExpiresSoon(ctx context.Context, referenceBlockNumber uint32) (bool, error) {
+	blockStaleMeasure, err := s.tx.GetBlockStaleMeasure(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the block stale measure: %w", err)
+	}
+	storeDurationBlocks, err := s.tx.GetStoreDurationBlocks(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the store duration: %w", err)
+	}
+	currentBlockNumber, err := s.tx.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the current block number: %w", err)
+	}
+
+	expiryBlockNumber := uint64(referenceBlockNumber) + uint64(blockStaleMeasure) + uint64(storeDurationBlocks)
+	windowBlocks := uint64(s.config.RefreshWindow / blockTime)
+	return uint64(currentBlockNumber)+windowBlocks >= expiryBlockNumber, nil
+}
+
+// refreshBlob re-disperses the blob with the credentials of the caller and returns the request ID of the new blob,
+// along with its cert if it's confirmed within the refresh timeout.
+func (s *Server) refreshBlob(ctx context.Context, data []byte, credentials *pb.RefreshCredentials) ([]byte, *disperserpb.BlobInfo, error) {
+	reply, err := s.disperserClient.DisperseBlob(ctx, &disperserpb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: credentials.GetCustomQuorumNumbers(),
+		AccountId:           credentials.GetAccountId(),
+		PayloadHash:         disperser.ComputePayloadHash(data),
+		Signature:           credentials.GetSignature(),
+		Nonce:               credentials.GetNonce(),
+		Expiry:              credentials.GetExpiry(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to re-disperse the blob: %w", err)
+	}
+	requestID := reply.GetRequestId()
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.RefreshTimeout)
+	defer cancel()
+	ticker := time.NewTicker(refreshPollInterval)
+	defer ticker.Stop()
+	for {
+		status, err := s.disperserClient.GetBlobStatus(ctx, &disperserpb.BlobStatusRequest{RequestId: requestID})
+		if err != nil {
+			s.logger.Warn("failed to get the status of the refreshed blob", "requestID", string(requestID), "err", err)
+		} else {
+			switch status.GetStatus() {
+			case disperserpb.BlobStatus_CONFIRMED, disperserpb.BlobStatus_FINALIZED:
+				return requestID, status.GetInfo(), nil
+			case disperserpb.BlobStatus_FAILED, disperserpb.BlobStatus_INSUFFICIENT_SIGNATURES:
+				return nil, nil, fmt.Errorf("the refreshed blob %s failed with status %s", requestID, status.GetStatus())
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			// The caller polls the status of the new blob with its request ID
+			return requestID, nil, nil
+		}
+	}
+}
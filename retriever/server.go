@@ -3,7 +3,12 @@ package retriever
 import (
 	"context"
 	"errors"
+	"fmt"
+	"runtime"
+	"sync"
 
+	"github.com/Layr-Labs/eigenda/api"
+	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
 	"github.com/Layr-Labs/eigenda/clients"
 	"github.com/Layr-Labs/eigenda/core"
@@ -11,6 +16,8 @@ import (
 	"github.com/Layr-Labs/eigenda/retriever/eth"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gcommon "github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type Server struct {
@@ -20,10 +27,21 @@ type Server struct {
 	retrievalClient clients.RetrievalClient
 	chainClient     eth.ChainClient
 	indexedState    core.IndexedChainState
+	tx              core.Transactor
+	disperserClient disperserpb.DisperserClient
 	logger          logging.Logger
 	metrics         *Metrics
+	health          *health.Server
+
+	// decodeWorkers bounds the number of blobs retrieved and decoded concurrently
+	decodeWorkers chan struct{}
+	// queueDepth is the number of requests waiting for a decode worker
+	queueDepth int
+	mu         sync.Mutex
 }
 
+// NewServer creates the retriever server. The blobs are only refreshed if a disperser client is given, which
+// re-disperses them.
 func NewServer(
 	config *Config,
 	logger logging.Logger,
@@ -31,20 +49,42 @@ func NewServer(
 	verifier encoding.Verifier,
 	indexedState core.IndexedChainState,
 	chainClient eth.ChainClient,
+	tx core.Transactor,
+	disperserClient disperserpb.DisperserClient,
 ) *Server {
 	metrics := NewMetrics(config.MetricsConfig.HTTPPort, logger)
 
+	numDecodeWorkers := config.NumDecodeWorkers
+	if numDecodeWorkers <= 0 {
+		numDecodeWorkers = runtime.NumCPU()
+	}
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(pb.Retriever_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+
 	return &Server{
 		config:          config,
 		retrievalClient: retrievalClient,
 		chainClient:     chainClient,
 		indexedState:    indexedState,
+		tx:              tx,
+		disperserClient: disperserClient,
 		logger:          logger.With("component", "RetrieverServer"),
 		metrics:         metrics,
+		health:          healthServer,
+		decodeWorkers:   make(chan struct{}, numDecodeWorkers),
 	}
 }
 
+// HealthServer returns the grpc.health.v1 server of the retriever. The retriever service is NOT_SERVING while the
+// decode queue is full and new requests are shed.
+func (s *Server) HealthServer() grpc_health_v1.HealthServer {
+	return s.health
+}
+
 func (s *Server) Start(ctx context.Context) error {
+	if err := s.metrics.RegisterBuildInfo(s.config.BuildInfo); err != nil {
+		return fmt.Errorf("failed to register build info: %w", err)
+	}
 	s.metrics.Start(ctx)
 	return s.indexedState.Start(ctx)
 }
@@ -57,6 +97,15 @@ func (s *Server) RetrieveBlob(ctx context.Context, req *pb.BlobRequest) (*pb.Blo
 	}
 	var batchHeaderHash [32]byte
 	copy(batchHeaderHash[:], req.GetBatchHeaderHash())
+	if req.GetRefresh() != nil && s.disperserClient == nil {
+		return nil, api.NewInvalidArgError("refreshing blobs is not enabled on this retriever")
+	}
+
+	release, err := s.acquireDecodeWorker(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	batchHeader, err := s.chainClient.FetchBatchHeader(ctx, gcommon.HexToAddress(s.config.EigenDAServiceManagerAddr), req.GetBatchHeaderHash())
 	if err != nil {
@@ -73,7 +122,65 @@ func (s *Server) RetrieveBlob(ctx context.Context, req *pb.BlobRequest) (*pb.Blo
 	if err != nil {
 		return nil, err
 	}
-	return &pb.BlobReply{
+	reply := &pb.BlobReply{
 		Data: data,
-	}, nil
+	}
+
+	if req.GetRefresh() != nil {
+		expiresSoon, err := s.custodyExpiresSoon(ctx, batchHeader.ReferenceBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if expiresSoon {
+			s.logger.Info("Refreshing blob nearing expiry", "BatchHeaderHash", req.GetBatchHeaderHash(), "BlobIndex", req.GetBlobIndex())
+			reply.RefreshRequestId, reply.RefreshedBlobInfo, err = s.refreshBlob(ctx, data, req.GetRefresh())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return reply, nil
+}
+
+// acquireDecodeWorker waits for a decode worker and returns the function releasing it. If MaxDecodeQueueDepth requests
+// are already waiting, the request is rejected as unavailable with the delay after which to retry.
+func (s *Server) acquireDecodeWorker(ctx context.Context) (func(), error) {
+	release := func() { <-s.decodeWorkers }
+	select {
+	case s.decodeWorkers <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	s.mu.Lock()
+	if s.config.MaxDecodeQueueDepth > 0 && s.queueDepth >= s.config.MaxDecodeQueueDepth {
+		s.mu.Unlock()
+		s.metrics.IncrementShedRequestCounter()
+		return nil, api.NewUnavailableError("retriever is overloaded, too many blobs waiting to be decoded", s.config.RetryAfter)
+	}
+	s.setQueueDepth(s.queueDepth + 1)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.setQueueDepth(s.queueDepth - 1)
+		s.mu.Unlock()
+	}()
+
+	select {
+	case s.decodeWorkers <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// setQueueDepth updates the decode queue depth and the health of the service. It must be called with the lock held.
+func (s *Server) setQueueDepth(depth int) {
+	s.queueDepth = depth
+	s.metrics.UpdateDecodeQueueDepth(depth)
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if s.config.MaxDecodeQueueDepth > 0 && depth >= s.config.MaxDecodeQueueDepth {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	s.health.SetServingStatus(pb.Retriever_ServiceDesc.ServiceName, status)
 }
@@ -5,7 +5,9 @@ import (
 	"log"
 	"runtime"
 	"testing"
+	"time"
 
+	disperserpb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
 	clientsmock "github.com/Layr-Labs/eigenda/clients/mock"
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
@@ -20,6 +22,13 @@ import (
 	"github.com/Layr-Labs/eigenda/retriever/mock"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/stretchr/testify/assert"
+	tmock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 const numOperators = 10
@@ -28,6 +37,7 @@ var (
 	indexedChainState      core.IndexedChainState
 	retrievalClient        *clientsmock.MockRetrievalClient
 	chainClient            *mock.MockChainClient
+	transactor             *coremock.MockTransactor
 	batchHeaderHash        [32]byte
 	batchRoot              [32]byte
 	gettysburgAddressBytes = codec.ConvertByPaddingEmptyByte([]byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal. Now we are engaged in a great civil war, testing whether that nation, or any nation so conceived, and so dedicated, can long endure. We are met on a great battle-field of that war. We have come to dedicate a portion of that field, as a final resting-place for those who here gave their lives, that that nation might live. It is altogether fitting and proper that we should do this. But, in a larger sense, we cannot dedicate, we cannot consecrate—we cannot hallow—this ground. The brave men, living and dead, who struggled here, have consecrated it far above our poor power to add or detract. The world will little note, nor long remember what we say here, but it can never forget what they did here. It is for us the living, rather, to be dedicated here to the unfinished work which they who fought here have thus far so nobly advanced. It is rather for us to be here dedicated to the great task remaining before us—that from these honored dead we take increased devotion to that cause for which they here gave the last full measure of devotion—that we here highly resolve that these dead shall not have died in vain—that this nation, under God, shall have a new birth of freedom, and that government of the people, by the people, for the people, shall not perish from the earth."))
@@ -56,9 +66,32 @@ func makeTestComponents() (encoding.Prover, encoding.Verifier, error) {
 	return p, v, nil
 }
 
-func newTestServer(t *testing.T) *retriever.Server {
+// fakeDisperserClient accepts the dispersals and reports the blobs as confirmed
+type fakeDisperserClient struct {
+	disperserpb.DisperserClient
+	requests []*disperserpb.DisperseBlobRequest
+}
+
+func (c *fakeDisperserClient) DisperseBlob(ctx context.Context, in *disperserpb.DisperseBlobRequest, opts ...grpc.CallOption) (*disperserpb.DisperseBlobReply, error) {
+	c.requests = append(c.requests, in)
+	return &disperserpb.DisperseBlobReply{Result: disperserpb.BlobStatus_PROCESSING, RequestId: []byte("refreshed")}, nil
+}
+
+func (c *fakeDisperserClient) GetBlobStatus(ctx context.Context, in *disperserpb.BlobStatusRequest, opts ...grpc.CallOption) (*disperserpb.BlobStatusReply, error) {
+	return &disperserpb.BlobStatusReply{
+		Status: disperserpb.BlobStatus_CONFIRMED,
+		Info: &disperserpb.BlobInfo{
+			BlobVerificationProof: &disperserpb.BlobVerificationProof{BatchId: 7},
+		},
+	}, nil
+}
+
+func newTestServer(t *testing.T, config *retriever.Config) *retriever.Server {
+	return newTestServerWithDisperser(t, config, nil)
+}
+
+func newTestServerWithDisperser(t *testing.T, config *retriever.Config, disperserClient disperserpb.DisperserClient) *retriever.Server {
 	var err error
-	config := &retriever.Config{}
 
 	logger := logging.NewNoopLogger()
 
@@ -78,11 +111,12 @@ func newTestServer(t *testing.T) *retriever.Server {
 
 	retrievalClient = &clientsmock.MockRetrievalClient{}
 	chainClient = mock.NewMockChainClient()
-	return retriever.NewServer(config, logger, retrievalClient, v, indexedChainState, chainClient)
+	transactor = &coremock.MockTransactor{}
+	return retriever.NewServer(config, logger, retrievalClient, v, indexedChainState, chainClient, transactor, disperserClient)
 }
 
 func TestRetrieveBlob(t *testing.T) {
-	server := newTestServer(t)
+	server := newTestServer(t, &retriever.Config{})
 	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
 		BlobHeadersRoot:       batchRoot,
 		QuorumNumbers:         []byte{0},
@@ -101,3 +135,112 @@ func TestRetrieveBlob(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, gettysburgAddressBytes, retrievalReply.Data)
 }
+
+func TestRetrieveBlobRefresh(t *testing.T) {
+	disperserClient := &fakeDisperserClient{}
+	server := newTestServerWithDisperser(t, &retriever.Config{
+		RefreshWindow:  time.Hour,
+		RefreshTimeout: time.Second,
+	}, disperserClient)
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:       batchRoot,
+		QuorumNumbers:         []byte{0},
+		SignedStakeForQuorums: []byte{90},
+		ReferenceBlockNumber:  1000,
+	}, nil)
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+	transactor.On("GetBlockStaleMeasure").Return(nil)
+	transactor.On("GetStoreDurationBlocks").Return(nil)
+	currentBlock := transactor.On("GetCurrentBlockNumber").Return(uint32(500), nil)
+
+	request := &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		Refresh: &pb.RefreshCredentials{
+			AccountId: "0x1234",
+			Nonce:     1,
+			Expiry:    2,
+			Signature: []byte{3},
+		},
+	}
+
+	// The custody window of the blob lapses in more than an hour: it isn't refreshed
+	reply, err := server.RetrieveBlob(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, reply.GetData())
+	assert.Nil(t, reply.GetRefreshRequestId())
+	assert.Empty(t, disperserClient.requests)
+
+	// The custody window lapses within the hour: the blob is re-dispersed with the credentials of the caller
+	currentBlock.Unset()
+	transactor.On("GetCurrentBlockNumber").Return(uint32(900), nil)
+	reply, err = server.RetrieveBlob(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, reply.GetData())
+	assert.Equal(t, []byte("refreshed"), reply.GetRefreshRequestId())
+	assert.Equal(t, uint32(7), reply.GetRefreshedBlobInfo().GetBlobVerificationProof().GetBatchId())
+	require.Len(t, disperserClient.requests, 1)
+	assert.Equal(t, gettysburgAddressBytes, disperserClient.requests[0].GetData())
+	assert.Equal(t, "0x1234", disperserClient.requests[0].GetAccountId())
+	assert.Equal(t, []byte{3}, disperserClient.requests[0].GetSignature())
+
+	// A retriever without a disperser can't refresh the blobs
+	server = newTestServer(t, &retriever.Config{})
+	_, err = server.RetrieveBlob(context.Background(), request)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRetrieveBlobLoadShedding(t *testing.T) {
+	server := newTestServer(t, &retriever.Config{
+		NumDecodeWorkers:    1,
+		MaxDecodeQueueDepth: 1,
+		RetryAfter:          3 * time.Second,
+	})
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:       batchRoot,
+		QuorumNumbers:         []byte{0},
+		SignedStakeForQuorums: []byte{90},
+		ReferenceBlockNumber:  0,
+	}, nil)
+	unblock := make(chan struct{})
+	retrievalClient.On("RetrieveBlob").Run(func(args tmock.Arguments) { <-unblock }).Return(gettysburgAddressBytes, nil)
+
+	request := &pb.BlobRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+	}
+	healthRequest := &grpc_health_v1.HealthCheckRequest{Service: pb.Retriever_ServiceDesc.ServiceName}
+	isServing := func() bool {
+		reply, err := server.HealthServer().Check(context.Background(), healthRequest)
+		require.NoError(t, err)
+		return reply.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	assert.True(t, isServing())
+
+	// The first request takes the only decode worker and the second one waits for it, filling the queue
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := server.RetrieveBlob(context.Background(), request)
+			errs <- err
+		}()
+	}
+	assert.Eventually(t, func() bool { return !isServing() }, 5*time.Second, 10*time.Millisecond)
+
+	_, err := server.RetrieveBlob(context.Background(), request)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 3*time.Second, retryInfo.GetRetryDelay().AsDuration())
+
+	// Once the workers catch up, the queued request is served and so are new ones
+	close(unblock)
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-errs)
+	}
+	assert.True(t, isServing())
+	reply, err := server.RetrieveBlob(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, reply.Data)
+}
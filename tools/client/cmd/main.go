@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/clients"
+	"github.com/urfave/cli"
+)
+
+var (
+	receiptsDirFlag = cli.StringFlag{
+		Name:     "receipts-dir",
+		Usage:    "Directory of the receipt store the client records its dispersals in",
+		Required: true,
+		EnvVar:   "EIGENDA_CLIENT_RECEIPTS_DIR",
+	}
+	limitFlag = cli.IntFlag{
+		Name:  "limit",
+		Usage: "Number of the most recent dispersals to list (all dispersals if not positive)",
+		Value: 20,
+	}
+	statusFlag = cli.StringFlag{
+		Name:  "status",
+		Usage: "Only list the dispersals whose blob has this status, e.g. CONFIRMED",
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "client"
+	app.Usage = "Inspect the dispersals recorded by an EigenDA client"
+	app.Commands = []cli.Command{
+		{
+			Name:  "history",
+			Usage: "inspect the receipts of the dispersals recorded by the client",
+			Description: "The receipts are recorded by the clients created with clients.NewRecordingDisperserClient. The " +
+				"store is locked by a running client, so the client has to be stopped first.",
+			Subcommands: []cli.Command{
+				{
+					Name:   "list",
+					Usage:  "list the recorded dispersals, the most recent first",
+					Flags:  []cli.Flag{receiptsDirFlag, limitFlag, statusFlag},
+					Action: ListReceipts,
+				},
+				{
+					Name:      "show",
+					Usage:     "print the receipt of a dispersal, including the cert of the blob once it's confirmed",
+					ArgsUsage: "<request ID>",
+					Flags:     []cli.Flag{receiptsDirFlag},
+					Action:    ShowReceipt,
+				},
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+// ListReceipts prints a line per recorded dispersal
+func ListReceipts(ctx *cli.Context) error {
+	store, err := clients.NewReceiptStore(ctx.String(receiptsDirFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	receipts, err := store.List()
+	if err != nil {
+		return err
+	}
+	if status := ctx.String(statusFlag.Name); status != "" {
+		filtered := receipts[:0]
+		for _, receipt := range receipts {
+			if receipt.Status == status {
+				filtered = append(filtered, receipt)
+			}
+		}
+		receipts = filtered
+	}
+	if limit := ctx.Int(limitFlag.Name); limit > 0 && len(receipts) > limit {
+		receipts = receipts[:limit]
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DISPERSED AT\tREQUEST ID\tMETHOD\tSIZE\tQUORUMS\tSTATUS\tERROR")
+	for _, receipt := range receipts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%v\t%s\t%s\n", receipt.DispersedAt.Format(time.RFC3339), receipt.ID, receipt.Method, receipt.DataSize, receipt.Quorums, receipt.Status, receipt.Error)
+	}
+	return w.Flush()
+}
+
+// ShowReceipt prints the receipt of a dispersal as JSON
+func ShowReceipt(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("expected the request ID of the dispersal")
+	}
+	store, err := clients.NewReceiptStore(ctx.String(receiptsDirFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	receipt, err := store.Get(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(receipt, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+)
+
+// Computes the G1 SRS in Lagrange form read by the committer from its G1LagrangePath, over the domain of the
+// NextPowerOf2(max-blob-length)-th roots of unity. The points are checked against the G1 SRS before being written.
+//
+// An example, for blobs of up to 2^20 symbols:
+//
+//	tools/lagrangesrs/bin/lagrangesrs \
+//		-g1-path ./inabox/resources/kzg/g1.point \
+//		-max-blob-length 1048576 \
+//		-out ./g1.lagrange.point
+
+func main() {
+	g1Path := flag.String("g1-path", "", "path of the G1 SRS in monomial form")
+	maxBlobLength := flag.Uint64("max-blob-length", 0, "length, in symbols, of the largest blob to commit to")
+	out := flag.String("out", "", "path of the G1 SRS in Lagrange form to write")
+	flag.Parse()
+
+	if *g1Path == "" || *maxBlobLength == 0 || *out == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if err := run(*g1Path, *maxBlobLength, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(g1Path string, maxBlobLength uint64, out string) error {
+	n := encoding.NextPowerOf2(maxBlobLength)
+	g1, err := kzg.ReadG1Points(g1Path, n, uint64(runtime.GOMAXPROCS(0)))
+	if err != nil {
+		return fmt.Errorf("failed to read G1 points: %w", err)
+	}
+
+	lagrangeG1, err := kzg.ComputeLagrangeG1(g1)
+	if err != nil {
+		return err
+	}
+	if err := kzg.CheckLagrangeG1(g1, lagrangeG1); err != nil {
+		return err
+	}
+	if err := kzg.WriteG1Points(out, lagrangeG1); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d Lagrange G1 points to %s\n", len(lagrangeG1), out)
+	return nil
+}